@@ -48,6 +48,7 @@ type ChatModel struct {
 	messages      []Message
 	messageList   *widget.List
 	input         *widget.TextInput
+	commands      *widget.CommandPrompt // Opened by typing / on an empty line
 	username      string
 	nextMessageID int
 
@@ -60,6 +61,7 @@ type ChatModel struct {
 	// Settings
 	showTimestamps bool
 	use24Hour      bool
+	location       *time.Location // The user's time zone
 }
 
 // ChatComponent is our main chat component
@@ -78,20 +80,24 @@ func NewChatComponent() *ChatComponent {
 		SetPlaceholder("Type a message or /help for commands...").
 		SetMaxLength(200)
 
-	return &ChatComponent{
+	c := &ChatComponent{
 		model: ChatModel{
 			messages:       make([]Message, 0),
 			messageList:    messageList,
 			input:          input,
+			commands:       widget.NewCommandPrompt().SetTrigger('/'),
 			username:       "User",
 			nextMessageID:  1,
 			typingUsers:    make(map[string]time.Time),
 			showTimestamps: true,
 			use24Hour:      false,
+			location:       time.Local,
 			width:          80,
 			height:         24,
 		},
 	}
+	c.addCommands()
+	return c
 }
 
 // Init initializes the component
@@ -116,6 +122,10 @@ func (c *ChatComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 	var cmds []terminus.Cmd
 
 	switch msg := msg.(type) {
+	case terminus.EnvironmentMsg:
+		// Show timestamps in the user's local time
+		c.model.location = msg.Location()
+
 	case terminus.WindowSizeMsg:
 		// Handle window resize
 		c.model.width = msg.Width
@@ -123,6 +133,19 @@ func (c *ChatComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 		c.updateLayout()
 
 	case terminus.KeyMsg:
+		if msg.Type == terminus.KeyCtrlC {
+			return c, terminus.Quit
+		}
+
+		// An open command line takes every key; / on an empty line opens it
+		if c.model.commands.Focused() {
+			_, cmd := c.model.commands.Update(msg)
+			return c, cmd
+		}
+		if c.model.input.Value() == "" && c.model.commands.OpenOn(msg) {
+			return c, nil
+		}
+
 		// First, let the input widget handle the key
 		var inputCmd terminus.Cmd
 		_, inputCmd = c.model.input.Update(msg)
@@ -133,8 +156,8 @@ func (c *ChatComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 		case terminus.KeyEnter:
 			// Send message
 			if text := strings.TrimSpace(c.model.input.Value()); text != "" {
-				cmd := c.handleInput(text)
-				cmds = append(cmds, cmd)
+				c.addMessage(c.model.username, text, false)
+				c.model.lastActivity = time.Now()
 				c.model.input.Clear()
 			}
 
@@ -144,11 +167,8 @@ func (c *ChatComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 			cmds = append(cmds, listCmd)
 		}
 
-		// Handle quit commands
-		switch msg.String() {
-		case "ctrl+c":
-			return c, terminus.Quit
-		}
+	case widget.CommandMsg:
+		c.addSystemMessage(fmt.Sprintf("Unknown command: /%s", msg.Name))
 
 	case simulatedMessageMsg:
 		// Add simulated message from another user
@@ -221,7 +241,7 @@ func (c *ChatComponent) renderMessages(height int) string {
 	// Convert messages to list items
 	items := make([]widget.ListItem, len(c.model.messages))
 	for i, msg := range c.model.messages {
-		items[i] = &messageListItem{message: msg, showTimestamp: c.model.showTimestamps, use24Hour: c.model.use24Hour}
+		items[i] = &messageListItem{message: msg, showTimestamp: c.model.showTimestamps, use24Hour: c.model.use24Hour, location: c.model.location}
 	}
 	c.model.messageList.SetItems(items)
 
@@ -260,6 +280,10 @@ func (c *ChatComponent) renderTypingIndicator() string {
 
 // renderInput renders the input area
 func (c *ChatComponent) renderInput() string {
+	if c.model.commands.Focused() {
+		return c.model.commands.View()
+	}
+
 	// Update input dimensions
 	c.model.input.SetSize(c.model.width-4, 1)
 
@@ -275,80 +299,81 @@ func (c *ChatComponent) renderFooter() string {
 	return helpStyle.Render("Commands: /nick <name> | /clear | /time | /help | /quit | Ctrl+C to exit")
 }
 
-// handleInput processes user input
-func (c *ChatComponent) handleInput(text string) terminus.Cmd {
-	// Check for commands
-	if strings.HasPrefix(text, "/") {
-		parts := strings.Fields(text)
-		if len(parts) == 0 {
-			return nil
-		}
-
-		command := strings.ToLower(parts[0])
-		args := parts[1:]
-
-		switch command {
-		case "/nick":
-			if len(args) > 0 {
-				oldName := c.model.username
-				c.model.username = strings.Join(args, " ")
-				c.addSystemMessage(fmt.Sprintf("%s changed their name to %s", oldName, c.model.username))
-			} else {
+// addCommands adds the chat's slash commands
+func (c *ChatComponent) addCommands() {
+	c.model.commands.AddCommand(widget.PromptCommand{
+		Name:  "nick",
+		Usage: "<name>",
+		Help:  "Change your username",
+		Run: func(args []string) terminus.Cmd {
+			if len(args) == 0 {
 				c.addSystemMessage("Usage: /nick <new name>")
+				return nil
 			}
-
-		case "/clear":
+			oldName := c.model.username
+			c.model.username = strings.Join(args, " ")
+			c.addSystemMessage(fmt.Sprintf("%s changed their name to %s", oldName, c.model.username))
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "clear",
+		Help: "Clear all messages",
+		Run: func(args []string) terminus.Cmd {
 			c.model.messages = make([]Message, 0)
 			c.model.nextMessageID = 1
 			c.addSystemMessage("Chat cleared")
-
-		case "/time":
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "time",
+		Help: "Toggle timestamps",
+		Run: func(args []string) terminus.Cmd {
 			c.model.showTimestamps = !c.model.showTimestamps
 			if c.model.showTimestamps {
 				c.addSystemMessage("Timestamps enabled")
 			} else {
 				c.addSystemMessage("Timestamps disabled")
 			}
-
-		case "/24h":
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "24h",
+		Help: "Toggle 24-hour time format",
+		Run: func(args []string) terminus.Cmd {
 			c.model.use24Hour = !c.model.use24Hour
 			if c.model.use24Hour {
 				c.addSystemMessage("24-hour time format enabled")
 			} else {
 				c.addSystemMessage("12-hour time format enabled")
 			}
-
-		case "/help":
-			c.addSystemMessage("Available commands:")
-			c.addSystemMessage("  /nick <name> - Change your username")
-			c.addSystemMessage("  /clear - Clear all messages")
-			c.addSystemMessage("  /time - Toggle timestamps")
-			c.addSystemMessage("  /24h - Toggle 24-hour time format")
-			c.addSystemMessage("  /quit - Exit the chat")
-			c.addSystemMessage("  /help - Show this help message")
-
-		case "/quit":
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "quit",
+		Help: "Exit the chat",
+		Run: func(args []string) terminus.Cmd {
 			return terminus.Quit
-
-		default:
-			c.addSystemMessage(fmt.Sprintf("Unknown command: %s", command))
-		}
-
-		return nil
-	}
-
-	// Regular message
-	c.addMessage(c.model.username, text, false)
-	c.model.lastActivity = time.Now()
-	return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "help",
+		Help: "Show this help message",
+		Run: func(args []string) terminus.Cmd {
+			c.addSystemMessage("Available commands:")
+			for _, cmd := range c.model.commands.Commands() {
+				usage := strings.TrimSpace("/" + cmd.Name + " " + cmd.Usage)
+				c.addSystemMessage(fmt.Sprintf("  %s - %s", usage, cmd.Help))
+			}
+			return nil
+		},
+	})
 }
 
 // addMessage adds a message to the chat
 func (c *ChatComponent) addMessage(user, text string, isSystem bool) {
 	msg := Message{
 		ID:        c.model.nextMessageID,
-		User:      user,
-		Text:      text,
+		User:      terminus.Sanitize(user),
+		Text:      terminus.Sanitize(text),
 		Timestamp: time.Now(),
 		IsSystem:  isSystem,
 	}
@@ -372,6 +397,7 @@ func (c *ChatComponent) updateLayout() {
 
 	// Input gets full width minus prompt
 	c.model.input.SetSize(c.model.width-4, 1)
+	c.model.commands.SetSize(c.model.width, 1)
 }
 
 // getOnlineUsers returns a string showing online users
@@ -396,6 +422,7 @@ type messageListItem struct {
 	message       Message
 	showTimestamp bool
 	use24Hour     bool
+	location      *time.Location
 }
 
 func (m *messageListItem) Render() string {
@@ -414,7 +441,7 @@ func (m *messageListItem) Render() string {
 		} else {
 			timeFormat = "3:04 PM"
 		}
-		timestamp := m.message.Timestamp.Format(timeFormat)
+		timestamp := m.message.Timestamp.In(m.location).Format(timeFormat)
 		result.WriteString(timeStyle.Render("[" + timestamp + "] "))
 	}
 
@@ -433,6 +460,12 @@ func (m *messageListItem) String() string {
 	return fmt.Sprintf("%s: %s", m.message.User, m.message.Text)
 }
 
+// Direction aligns messages written in Hebrew or Arabic right, whatever the
+// sender's name is written in
+func (m *messageListItem) Direction() widget.Direction {
+	return widget.TextDirection(m.message.Text)
+}
+
 // Custom message types for async operations
 type simulatedMessageMsg struct {
 	user string
@@ -20,11 +20,12 @@ import (
 	"log"
 	"math"
 	"math/rand"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
+	"strconv"
 	"strings"
-	"sync"
 	"syscall"
 	"time"
 
@@ -36,6 +37,20 @@ import (
 //go:embed all:static/*
 var staticFiles embed.FS
 
+// highCPU is the CPU percentage at which a process is shown in red
+const highCPU = 15.0
+
+// systemStats is sampled once for the whole server and shared by every
+// dashboard, which subscribes to it
+var systemStats = terminus.NewStore(SystemStats{
+	CPUUsage:    rand.Float64() * 100,
+	MemoryUsage: 4 + rand.Float64()*4,
+	MemoryTotal: 16.0,
+	NetworkIn:   rand.Float64() * 10,
+	NetworkOut:  rand.Float64() * 5,
+	Processes:   120 + rand.Intn(30),
+})
+
 // SystemStats holds real-time system statistics
 type SystemStats struct {
 	CPUUsage    float64
@@ -50,11 +65,11 @@ type SystemStats struct {
 
 // ProcessInfo represents a system process
 type ProcessInfo struct {
-	PID    int
-	Name   string
-	CPU    float64
-	Memory float64
-	Status string
+	PID    int     `table:"PID,width=8,align=right"`
+	Name   string  `table:",width=20"`
+	CPU    float64 `table:"CPU %,width=8,align=right,format=%.1f"`
+	Memory float64 `table:"Mem %,width=8,align=right,format=%.1f"`
+	Status string  `table:",width=10,align=center"`
 }
 
 // Alert represents a system alert
@@ -72,23 +87,26 @@ type Dashboard struct {
 	panels       []string
 
 	// Real-time data
-	stats         SystemStats
-	statsMutex    sync.RWMutex
+	stats         SystemStats // Latest from systemStats
 	cpuHistory    []float64
 	memHistory    []float64
 	netInHistory  []float64
 	netOutHistory []float64
 
 	// Widgets
-	processTable *widget.Table
+	processTable *widget.StructTable[ProcessInfo]
 	alertList    *widget.List
-	commandInput *widget.TextInput
+	alertsPanel  *widget.Panel // Boxes alertList; pulses when an error lands
+	prompt       *widget.CommandPrompt
+	exportPath   string                     // Where the next HTML snapshot is saved
+	regions      map[string]terminus.Region // Where each panel was last drawn, to flash alerts and for the tour
 
 	// UI state
 	refreshRate    time.Duration
-	showHelp       bool
+	help           *widget.Label // Keyboard help, toggled with H
 	selectedMetric int
 	autoRefresh    bool
+	refreshID      string
 
 	// Data
 	processes   []ProcessInfo
@@ -107,18 +125,20 @@ type Dashboard struct {
 	memSpinner     *widget.Spinner
 	netSpinner     *widget.Spinner
 	processSpinner *widget.Spinner
+	spinners       *widget.Compose // Routes messages to the spinners
 }
 
 func NewDashboard() *Dashboard {
 	d := &Dashboard{
 		focusedPanel: 0,
 		panels: []string{
-			"CPU", "Memory", "Network", "Processes", "Alerts", "Command",
+			"CPU", "Memory", "Network", "Processes", "Alerts",
 		},
 		refreshRate:   time.Second,
 		autoRefresh:   true,
 		startTime:     time.Now(),
 		renderCache:   make(map[string]string),
+		regions:       make(map[string]terminus.Region),
 		cacheEnabled:  true,
 		cpuHistory:    make([]float64, 0, 60),
 		memHistory:    make([]float64, 0, 60),
@@ -129,36 +149,119 @@ func NewDashboard() *Dashboard {
 	}
 
 	// Initialize process table
-	d.processTable = widget.NewTable().
+	d.processTable = widget.NewTableFromStructs(d.processes)
+	d.processTable.
 		SetShowHeader(true).
 		SetShowRowNumbers(false).
 		SetStyle(terminus.NewStyle()).
 		SetHeaderStyle(terminus.NewStyle().Bold(true).Foreground(terminus.Cyan)).
-		SetSelectedStyle(terminus.NewStyle().Reverse(true))
-
-	// Set process table columns
-	columns := []widget.TableColumn{
-		{Title: "PID", Width: 8, Align: widget.AlignRight},
-		{Title: "Name", Width: 20, Align: widget.AlignLeft},
-		{Title: "CPU %", Width: 8, Align: widget.AlignRight},
-		{Title: "Mem %", Width: 8, Align: widget.AlignRight},
-		{Title: "Status", Width: 10, Align: widget.AlignCenter},
-	}
-	d.processTable.SetColumns(columns)
+		SetSelectedStyle(terminus.NewStyle().Reverse(true)).
+		SetRowStyleFunc(func(row int, data widget.TableRow) terminus.Style {
+			// Flag busy processes
+			if cpu, ok := data[2].Value().(float64); ok && cpu >= highCPU {
+				return terminus.NewStyle().Foreground(terminus.Red)
+			}
+			return terminus.NewStyle()
+		}).
+		SetCellStyleFunc(func(row, col int, cell widget.TableCell) terminus.Style {
+			if col != 4 {
+				return terminus.NewStyle()
+			}
+			switch cell.String() {
+			case "Running":
+				return terminus.NewStyle().Foreground(terminus.Green)
+			case "Sleeping":
+				return terminus.NewStyle().Foreground(terminus.Blue)
+			case "Stopped":
+				return terminus.NewStyle().Foreground(terminus.Red)
+			}
+			return terminus.NewStyle()
+		})
 
 	// Initialize alert list
 	d.alertList = widget.NewList().
 		SetShowCursor(false).
 		SetStyle(terminus.NewStyle())
-
-	// Initialize command input
-	d.commandInput = widget.NewTextInput().
-		SetPlaceholder("Enter command...").
-		SetStyle(terminus.NewStyle().Foreground(terminus.White)).
-		SetFocusStyle(terminus.NewStyle().Foreground(terminus.Cyan).Underline(true)).
-		SetOnSubmit(func(value string) terminus.Cmd {
-			return d.executeCommand(value)
-		})
+	d.alertsPanel = widget.NewPanel("Alerts", d.alertList).SetPadding(1)
+
+	// The help is hidden until H shows it
+	d.help = widget.NewLabel(renderHelp())
+	d.help.Hide()
+
+	// Initialize the command prompt, opened with ':'
+	d.prompt = widget.NewCommandPrompt().
+		SetPromptStyle(terminus.NewStyle().Foreground(terminus.Cyan).Bold(true))
+	d.prompt.SetSize(122, 1)
+	d.prompt.AddCommand(widget.PromptCommand{
+		Name:  "clear",
+		Help:  "Clear all alerts",
+		Roles: []string{"operator", "admin"},
+		Run: func(args []string) terminus.Cmd {
+			d.alerts = make([]Alert, 0)
+			d.addAlert("info", "Alerts cleared")
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "stats",
+		Help: "Show update count and uptime",
+		Run: func(args []string) terminus.Cmd {
+			d.addAlert("info", fmt.Sprintf("Updates: %d, Uptime: %s",
+				d.updateCount, d.formatDuration(d.stats.Uptime)))
+			return nil
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name:  "gc",
+		Help:  "Run the garbage collector",
+		Roles: []string{"admin"},
+		Run: func(args []string) terminus.Cmd {
+			return func() terminus.Msg {
+				runtime.GC()
+				return commandResultMsg{result: "Garbage collection completed"}
+			}
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name:  "refresh",
+		Usage: "on|off|<seconds>",
+		Help:  "Toggle auto-refresh or set its rate",
+		Complete: func(args []string, prefix string) []string {
+			if len(args) > 0 {
+				return nil
+			}
+			return []string{"on", "off", "1", "2", "5"}
+		},
+		Run: func(args []string) terminus.Cmd {
+			return d.setRefresh(args)
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name:  "export",
+		Usage: "[file]",
+		Help:  "Save a snapshot of the dashboard as HTML",
+		Roles: []string{"admin"},
+		Run: func(args []string) terminus.Cmd {
+			d.exportPath = "dashboard.html"
+			if len(args) > 0 {
+				d.exportPath = args[0]
+			}
+			return terminus.ExportHTML("TerminusGo Dashboard")
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name:  "screenshot",
+		Usage: "[file.png|file.svg]",
+		Help:  "Download a screenshot of the dashboard",
+		Run: func(args []string) terminus.Cmd {
+			filename := "dashboard.png"
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			return terminus.DownloadScreenshot(filename)
+		},
+	}).AddCommand(widget.PromptCommand{
+		Name: "print",
+		Help: "Print the dashboard",
+		Run: func(args []string) terminus.Cmd {
+			return terminus.PrintView("TerminusGo Dashboard - " + time.Now().Format("2006-01-02 15:04"))
+		},
+	})
 
 	// Initialize spinners
 	d.cpuSpinner = widget.NewSpinner().
@@ -186,16 +289,24 @@ func NewDashboard() *Dashboard {
 	d.memSpinner.Start()
 	d.netSpinner.Start()
 	d.processSpinner.Start()
+	d.spinners = widget.NewCompose(widget.ComposeVertical).
+		Add("cpu", d.cpuSpinner).
+		Add("memory", d.memSpinner).
+		Add("network", d.netSpinner).
+		Add("processes", d.processSpinner)
 
 	// Generate initial data
 	d.generateInitialData()
 
+	// Unique per dashboard so sessions don't cancel each other's refresh
+	d.refreshID = fmt.Sprintf("dashboard-refresh-%p", d)
+
 	return d
 }
 
 func (d *Dashboard) Init() terminus.Cmd {
-	// Start auto-refresh
-	return d.startAutoRefresh()
+	// Start auto-refresh and follow the shared statistics
+	return terminus.Batch(d.startAutoRefresh(), systemStats.Subscribe(), d.spinners.Init())
 }
 
 func (d *Dashboard) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
@@ -203,21 +314,26 @@ func (d *Dashboard) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 
 	switch msg := msg.(type) {
 	case terminus.KeyMsg:
+		// The open prompt takes every key
+		if d.prompt.Focused() {
+			_, cmd := d.prompt.Update(msg)
+			return d, cmd
+		}
+		if d.prompt.OpenOn(msg) {
+			return d, nil
+		}
 		cmd := d.handleKeyPress(msg)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
 
 	case refreshMsg:
-		d.updateStats()
+		if cmd := d.updateStats(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		d.updateCount++
 		d.lastUpdate = time.Now()
 
-		// Continue auto-refresh if enabled
-		if d.autoRefresh {
-			cmds = append(cmds, d.scheduleRefresh())
-		}
-
 		// Stop spinners after first update
 		if d.updateCount == 1 {
 			d.cpuSpinner.Stop()
@@ -226,23 +342,54 @@ func (d *Dashboard) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 			d.processSpinner.Stop()
 		}
 
+	case terminus.StoreMsg[SystemStats]:
+		d.recordStats(msg.State)
+
 	case commandResultMsg:
 		d.addAlert("info", msg.result)
 
-	case widget.SpinnerTickMsg:
-		// Forward to all spinners
-		d.cpuSpinner.Update(msg)
-		d.memSpinner.Update(msg)
-		d.netSpinner.Update(msg)
-		d.processSpinner.Update(msg)
+	case terminus.ScreenshotMsg:
+		if msg.Err != nil {
+			d.addAlert("error", msg.Err.Error())
+		} else {
+			d.addAlert("info", fmt.Sprintf("Screenshot downloaded as %s (%d bytes)", msg.Filename, len(msg.Data)))
+		}
+
+	case terminus.HTMLExportMsg:
+		path := d.exportPath
+		cmds = append(cmds, func() terminus.Msg {
+			if err := os.WriteFile(path, []byte(msg.HTML), 0o644); err != nil {
+				return commandResultMsg{result: fmt.Sprintf("Export failed: %v", err)}
+			}
+			return commandResultMsg{result: "Snapshot saved to " + path}
+		})
+
+	case terminus.RolesMsg:
+		// Commands the user may not run are left out
+		d.prompt.Update(msg)
+
+	case widget.CommandMsg:
+		d.prompt.SetMessage(fmt.Sprintf("Unknown command: %s", msg.Name),
+			terminus.NewStyle().Foreground(terminus.Red))
+
+	case terminus.FrameMsg:
+		// Redraw the alerts panel as it pulses
+		if _, cmd := d.alertsPanel.Update(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		delete(d.renderCache, "full")
+	}
+
+	// Keep the spinners animating
+	if _, cmd := d.spinners.Update(msg); cmd != nil {
+		cmds = append(cmds, cmd)
 	}
 
 	// Update focused widget
 	switch d.panels[d.focusedPanel] {
 	case "Processes":
 		if d.processTable != nil {
-			newTable, cmd := d.processTable.Update(msg)
-			d.processTable = newTable.(*widget.Table)
+			_, cmd := d.processTable.Update(msg)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
@@ -255,14 +402,6 @@ func (d *Dashboard) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 				cmds = append(cmds, cmd)
 			}
 		}
-	case "Command":
-		if d.commandInput != nil {
-			newInput, cmd := d.commandInput.Update(msg)
-			d.commandInput = newInput.(*widget.TextInput)
-			if cmd != nil {
-				cmds = append(cmds, cmd)
-			}
-		}
 	}
 
 	if len(cmds) > 0 {
@@ -275,7 +414,7 @@ func (d *Dashboard) View() string {
 	// Performance optimization: check if we can use cached render
 	if d.cacheEnabled && d.updateCount > 0 && d.updateCount%5 != 0 {
 		if cached, ok := d.renderCache["full"]; ok && cached != "" {
-			return cached
+			return d.prompt.Overlay(cached)
 		}
 	}
 
@@ -288,21 +427,50 @@ func (d *Dashboard) View() string {
 	// Main content area using grid layout
 	grid := layout.NewGrid(3, 3).SetGap(1)
 
+	// place records where a panel in the grid is drawn, from its row's top
+	// and the columns it spans
+	top := strings.Count(result.String(), "\n")
+	place := func(name string, col, y, span int, panel string) {
+		d.regions[name] = terminus.Region{
+			X:      col * (40 + 1), // Past the columns before it and their gaps
+			Y:      y,
+			Width:  span*40 + span - 1,
+			Height: strings.Count(panel, "\n") + 1,
+		}
+	}
+
 	// Top row: CPU, Memory, Network graphs
-	grid.SetCell(0, 0, d.renderCPUPanel())
-	grid.SetCell(1, 0, d.renderMemoryPanel())
-	grid.SetCell(2, 0, d.renderNetworkPanel())
+	topHeight := 0
+	graphs := []struct {
+		name   string
+		render func() string
+	}{{"cpu", d.renderCPUPanel}, {"memory", d.renderMemoryPanel}, {"network", d.renderNetworkPanel}}
+	for col, graph := range graphs {
+		panel := graph.render()
+		grid.SetCell(col, 0, panel)
+		place(graph.name, col, top, 1, panel)
+		topHeight = max(topHeight, strings.Count(panel, "\n")+1)
+	}
+	middle := top + topHeight + 1
 
 	// Middle row: Process table (spans 2 columns), Alerts
 	processPanel := d.renderProcessPanel()
 	grid.SetCell(0, 1, processPanel)
 	grid.SetCell(1, 1, "") // Process panel spans this cell
-	grid.SetCell(2, 1, d.renderAlertsPanel())
-
-	// Bottom row: System info, Command input (spans 2 columns)
-	grid.SetCell(0, 2, d.renderSystemInfoPanel())
+	alertsPanel := d.renderAlertsPanel()
+	grid.SetCell(2, 1, alertsPanel)
+	place("processes", 0, middle, 2, processPanel)
+	place("alerts", 2, middle, 1, alertsPanel)
+	middleHeight := max(strings.Count(processPanel, "\n"), strings.Count(alertsPanel, "\n")) + 1
+
+	// Bottom row: System info, Commands (spans 2 columns)
+	bottom := middle + middleHeight + 1
+	systemPanel := d.renderSystemInfoPanel()
+	grid.SetCell(0, 2, systemPanel)
+	place("system", 0, bottom, 1, systemPanel)
 	commandPanel := d.renderCommandPanel()
 	grid.SetCell(1, 2, commandPanel)
+	place("commands", 1, bottom, 2, commandPanel)
 	grid.SetCell(2, 2, "") // Command panel spans this cell
 
 	// Set column widths
@@ -316,20 +484,22 @@ func (d *Dashboard) View() string {
 	// Footer
 	d.renderFooter(&result)
 
-	// Help overlay
-	if d.showHelp {
-		result.WriteString("\n\n")
-		result.WriteString(d.renderHelp())
-	}
-
-	rendered := result.String()
+	// The help goes below the footer while shown
+	rendered := widget.Stack(1, widget.NewLabel(result.String()), d.help)
 
 	// Cache the render
 	if d.cacheEnabled {
 		d.renderCache["full"] = rendered
 	}
 
-	return rendered
+	// The prompt covers the footer while open
+	return d.prompt.Overlay(rendered)
+}
+
+// ChildRegion implements widget.TourTargets, finding panels by name
+func (d *Dashboard) ChildRegion(name string) (terminus.Region, bool) {
+	region, ok := d.regions[name]
+	return region, ok
 }
 
 // Panel rendering methods
@@ -346,10 +516,8 @@ func (d *Dashboard) renderHeader(result *strings.Builder) {
 }
 
 func (d *Dashboard) renderCPUPanel() string {
-	d.statsMutex.RLock()
 	cpuUsage := d.stats.CPUUsage
-	history := append([]float64{}, d.cpuHistory...)
-	d.statsMutex.RUnlock()
+	history := d.cpuHistory
 
 	var content strings.Builder
 
@@ -389,11 +557,9 @@ func (d *Dashboard) renderCPUPanel() string {
 }
 
 func (d *Dashboard) renderMemoryPanel() string {
-	d.statsMutex.RLock()
 	memUsage := d.stats.MemoryUsage
 	memTotal := d.stats.MemoryTotal
-	history := append([]float64{}, d.memHistory...)
-	d.statsMutex.RUnlock()
+	history := d.memHistory
 
 	var content strings.Builder
 
@@ -439,12 +605,10 @@ func (d *Dashboard) renderMemoryPanel() string {
 }
 
 func (d *Dashboard) renderNetworkPanel() string {
-	d.statsMutex.RLock()
 	netIn := d.stats.NetworkIn
 	netOut := d.stats.NetworkOut
-	inHistory := append([]float64{}, d.netInHistory...)
-	outHistory := append([]float64{}, d.netOutHistory...)
-	d.statsMutex.RUnlock()
+	inHistory := d.netInHistory
+	outHistory := d.netOutHistory
 
 	var content strings.Builder
 
@@ -490,30 +654,7 @@ func (d *Dashboard) renderProcessPanel() string {
 	if d.updateCount == 0 {
 		content.WriteString(d.processSpinner.View())
 	} else {
-		// Update table data
-		headers := []string{"PID", "Name", "CPU %", "Mem %", "Status"}
-		data := make([][]string, len(d.processes))
-		for i, p := range d.processes {
-			statusStyle := terminus.NewStyle()
-			switch p.Status {
-			case "Running":
-				statusStyle = statusStyle.Foreground(terminus.Green)
-			case "Sleeping":
-				statusStyle = statusStyle.Foreground(terminus.Blue)
-			case "Stopped":
-				statusStyle = statusStyle.Foreground(terminus.Red)
-			}
-
-			data[i] = []string{
-				fmt.Sprintf("%d", p.PID),
-				p.Name,
-				fmt.Sprintf("%.1f", p.CPU),
-				fmt.Sprintf("%.1f", p.Memory),
-				statusStyle.Render(p.Status),
-			}
-		}
-
-		d.processTable.SetStringData(headers, data)
+		d.processTable.SetData(d.processes)
 		d.processTable.SetSize(70, 10)
 		content.WriteString(d.processTable.View())
 	}
@@ -535,8 +676,6 @@ func (d *Dashboard) renderProcessPanel() string {
 }
 
 func (d *Dashboard) renderAlertsPanel() string {
-	var content strings.Builder
-
 	// Convert alerts to list items
 	items := make([]widget.ListItem, len(d.alerts))
 	for i, alert := range d.alerts {
@@ -551,7 +690,7 @@ func (d *Dashboard) renderAlertsPanel() string {
 		}
 
 		timeStr := alert.Timestamp.Format("15:04:05")
-		items[i] = widget.NewSimpleListItem(fmt.Sprintf("%s [%s] %s",
+		items[i] = widget.NewStyledListItem(fmt.Sprintf("%s [%s] %s",
 			timeStr,
 			levelStyle.Render(strings.ToUpper(alert.Level)),
 			alert.Message,
@@ -560,24 +699,11 @@ func (d *Dashboard) renderAlertsPanel() string {
 
 	d.alertList.SetItems(items)
 	d.alertList.SetSize(35, 10)
-	content.WriteString(d.alertList.View())
-
-	boxStyle := layout.BoxStyleSingle
-	if d.focusedPanel == 4 {
-		boxStyle = layout.BoxStyleDouble
-	}
-
-	return layout.NewBox(content.String()).
-		WithStyle(boxStyle).
-		WithTitle(fmt.Sprintf("Alerts (%d)", len(d.alerts))).
-		WithUniformPadding(1).
-		Render()
+	return d.alertsPanel.SetTitle(fmt.Sprintf("Alerts (%d)", len(d.alerts))).View()
 }
 
 func (d *Dashboard) renderSystemInfoPanel() string {
-	d.statsMutex.RLock()
 	stats := d.stats
-	d.statsMutex.RUnlock()
 
 	var content strings.Builder
 
@@ -618,18 +744,15 @@ func (d *Dashboard) renderSystemInfoPanel() string {
 func (d *Dashboard) renderCommandPanel() string {
 	var content strings.Builder
 
-	content.WriteString("Execute system commands:\n")
-	d.commandInput.SetSize(60, 1)
-	content.WriteString(d.commandInput.View())
-
-	boxStyle := layout.BoxStyleSingle
-	if d.focusedPanel == 5 {
-		boxStyle = layout.BoxStyleDouble
+	content.WriteString("Press : to run a command (Tab completes):\n")
+	for _, cmd := range d.prompt.Commands() {
+		usage := strings.TrimSpace(cmd.Name + " " + cmd.Usage)
+		content.WriteString(fmt.Sprintf("\n  %-20s %s", usage, cmd.Help))
 	}
 
 	box := layout.NewBox(content.String()).
-		WithStyle(boxStyle).
-		WithTitle("Command").
+		WithStyle(layout.BoxStyleSingle).
+		WithTitle("Commands").
 		WithUniformPadding(1)
 
 	// Span 2 columns
@@ -645,6 +768,7 @@ func (d *Dashboard) renderFooter(result *strings.Builder) {
 		"[R] Toggle Refresh",
 		"[+/-] Change Rate",
 		"[C] Clear Alerts",
+		"[:] Command",
 		"[H] Help",
 		"[Q] Quit",
 	}
@@ -653,7 +777,7 @@ func (d *Dashboard) renderFooter(result *strings.Builder) {
 	result.WriteString(layout.Center(footer, 122, 1))
 }
 
-func (d *Dashboard) renderHelp() string {
+func renderHelp() string {
 	helpContent := `
 Keyboard Shortcuts:
 
@@ -666,6 +790,7 @@ Controls:
   +           - Increase refresh rate
   -           - Decrease refresh rate
   C           - Clear all alerts
+  :           - Run a command (clear, stats, gc, refresh)
   H           - Toggle this help
   Q           - Quit application
 
@@ -888,9 +1013,7 @@ func (d *Dashboard) handleKeyPress(msg terminus.KeyMsg) terminus.Cmd {
 		case "Processes":
 			d.processTable.Blur()
 		case "Alerts":
-			d.alertList.Blur()
-		case "Command":
-			d.commandInput.Blur()
+			d.alertsPanel.Blur()
 		}
 
 		// Move to next panel
@@ -901,9 +1024,7 @@ func (d *Dashboard) handleKeyPress(msg terminus.KeyMsg) terminus.Cmd {
 		case "Processes":
 			d.processTable.Focus()
 		case "Alerts":
-			d.alertList.Focus()
-		case "Command":
-			d.commandInput.Focus()
+			d.alertsPanel.Focus()
 		}
 
 		// Clear render cache when switching panels
@@ -921,23 +1042,24 @@ func (d *Dashboard) handleKeyPress(msg terminus.KeyMsg) terminus.Cmd {
 				if d.autoRefresh {
 					return d.scheduleRefresh()
 				}
+				terminus.Cancel(d.refreshID)
 				return nil
 			case '+':
 				if d.refreshRate > 500*time.Millisecond {
 					d.refreshRate -= 500 * time.Millisecond
 				}
-				return nil
+				return d.restartRefresh()
 			case '-':
 				if d.refreshRate < 5*time.Second {
 					d.refreshRate += 500 * time.Millisecond
 				}
-				return nil
+				return d.restartRefresh()
 			case 'c', 'C':
 				d.alerts = make([]Alert, 0)
 				d.addAlert("info", "Alerts cleared")
 				return nil
 			case 'h', 'H':
-				d.showHelp = !d.showHelp
+				d.help.Toggle()
 				return nil
 			case 'p', 'P':
 				d.cacheEnabled = !d.cacheEnabled
@@ -954,9 +1076,6 @@ func (d *Dashboard) handleKeyPress(msg terminus.KeyMsg) terminus.Cmd {
 
 func (d *Dashboard) generateInitialData() {
 	// Initialize with some data
-	d.statsMutex.Lock()
-	defer d.statsMutex.Unlock()
-
 	// CPU history
 	for i := 0; i < 30; i++ {
 		d.cpuHistory = append(d.cpuHistory, rand.Float64()*100)
@@ -973,17 +1092,7 @@ func (d *Dashboard) generateInitialData() {
 		d.netOutHistory = append(d.netOutHistory, rand.Float64()*5)
 	}
 
-	// Initial stats
-	d.stats = SystemStats{
-		CPUUsage:    rand.Float64() * 100,
-		MemoryUsage: 4 + rand.Float64()*4,
-		MemoryTotal: 16.0,
-		NetworkIn:   rand.Float64() * 10,
-		NetworkOut:  rand.Float64() * 5,
-		Processes:   120 + rand.Intn(30),
-		Goroutines:  runtime.NumGoroutine(),
-		Uptime:      time.Since(d.startTime),
-	}
+	d.stats = systemStats.Get()
 
 	// Generate some processes
 	processNames := []string{
@@ -1007,18 +1116,26 @@ func (d *Dashboard) generateInitialData() {
 	d.addAlert("warning", "High memory usage detected")
 }
 
-func (d *Dashboard) updateStats() {
-	d.statsMutex.Lock()
-	defer d.statsMutex.Unlock()
+// sampleStats simulates real-time system statistics, updating systemStats
+// every second
+func sampleStats(start time.Time) {
+	for range time.Tick(time.Second) {
+		systemStats.Update(func(s SystemStats) SystemStats {
+			s.CPUUsage = math.Max(0, math.Min(100, s.CPUUsage+(rand.Float64()-0.5)*10))
+			s.MemoryUsage = math.Max(1, math.Min(s.MemoryTotal-0.5, s.MemoryUsage+(rand.Float64()-0.5)*0.5))
+			s.NetworkIn = math.Max(0, s.NetworkIn+(rand.Float64()-0.5)*2)
+			s.NetworkOut = math.Max(0, s.NetworkOut+(rand.Float64()-0.5)*1)
+			s.Processes = 120 + rand.Intn(30)
+			s.Goroutines = runtime.NumGoroutine()
+			s.Uptime = time.Since(start)
+			return s
+		})
+	}
+}
 
-	// Simulate real-time data updates
-	d.stats.CPUUsage = math.Max(0, math.Min(100, d.stats.CPUUsage+(rand.Float64()-0.5)*10))
-	d.stats.MemoryUsage = math.Max(1, math.Min(d.stats.MemoryTotal-0.5, d.stats.MemoryUsage+(rand.Float64()-0.5)*0.5))
-	d.stats.NetworkIn = math.Max(0, d.stats.NetworkIn+(rand.Float64()-0.5)*2)
-	d.stats.NetworkOut = math.Max(0, d.stats.NetworkOut+(rand.Float64()-0.5)*1)
-	d.stats.Processes = 120 + rand.Intn(30)
-	d.stats.Goroutines = runtime.NumGoroutine()
-	d.stats.Uptime = time.Since(d.startTime)
+// recordStats shows new statistics and adds them to the history
+func (d *Dashboard) recordStats(stats SystemStats) {
+	d.stats = stats
 
 	// Update history (keep last 60 values)
 	d.cpuHistory = append(d.cpuHistory, d.stats.CPUUsage)
@@ -1041,7 +1158,13 @@ func (d *Dashboard) updateStats() {
 	if len(d.netOutHistory) > 60 {
 		d.netOutHistory = d.netOutHistory[1:]
 	}
+}
 
+// updateStats simulates this session's processes and alerts. New alerts
+// flash the alerts panel; errors also make it pulse until it is focused,
+// and are raised as desktop notifications while the tab is in the
+// background.
+func (d *Dashboard) updateStats() terminus.Cmd {
 	// Update processes
 	for i := range d.processes {
 		d.processes[i].CPU = math.Max(0, math.Min(100, d.processes[i].CPU+(rand.Float64()-0.5)*5))
@@ -1064,6 +1187,7 @@ func (d *Dashboard) updateStats() {
 	}
 
 	// Generate occasional alerts
+	memPercent := (d.stats.MemoryUsage / d.stats.MemoryTotal) * 100
 	if rand.Float64() < 0.1 {
 		alertTypes := []struct {
 			level   string
@@ -1082,8 +1206,15 @@ func (d *Dashboard) updateStats() {
 			alert.level == "info" ||
 			(alert.level == "error" && rand.Float64() < 0.3) {
 			d.addAlert(alert.level, alert.message)
+			flash := terminus.Flash(d.regions["alerts"], terminus.DefaultFlashStyle, terminus.DefaultFlashDuration)
+			if alert.level == "error" {
+				return terminus.Batch(flash, d.alertsPanel.RequestAttention(),
+					terminus.DesktopNotify("Dashboard alert", alert.message, terminus.NotifyOptions{Tag: "dashboard-alert"}))
+			}
+			return flash
 		}
 	}
+	return nil
 }
 
 func (d *Dashboard) addAlert(level, message string) {
@@ -1102,25 +1233,31 @@ func (d *Dashboard) addAlert(level, message string) {
 	}
 }
 
-func (d *Dashboard) executeCommand(cmd string) terminus.Cmd {
-	return func() terminus.Msg {
-		// Simulate command execution
-		time.Sleep(500 * time.Millisecond)
-
-		switch cmd {
-		case "clear":
-			d.alerts = make([]Alert, 0)
-			return commandResultMsg{result: "Alerts cleared"}
-		case "stats":
-			return commandResultMsg{result: fmt.Sprintf("Updates: %d, Uptime: %s",
-				d.updateCount, d.formatDuration(d.stats.Uptime))}
-		case "gc":
-			runtime.GC()
-			return commandResultMsg{result: "Garbage collection completed"}
-		default:
-			return commandResultMsg{result: fmt.Sprintf("Command executed: %s", cmd)}
+// setRefresh handles the refresh command's argument
+func (d *Dashboard) setRefresh(args []string) terminus.Cmd {
+	if len(args) != 1 {
+		d.prompt.SetMessage("Usage: refresh on|off|<seconds>", terminus.NewStyle().Foreground(terminus.Red))
+		return nil
+	}
+	switch args[0] {
+	case "on":
+		if d.autoRefresh {
+			return nil
 		}
+		d.autoRefresh = true
+		return d.scheduleRefresh()
+	case "off":
+		d.autoRefresh = false
+		terminus.Cancel(d.refreshID)
+		return nil
+	}
+	seconds, err := strconv.ParseFloat(args[0], 64)
+	if err != nil || seconds < 0.5 || seconds > 5 {
+		d.prompt.SetMessage("Refresh rate must be between 0.5 and 5 seconds", terminus.NewStyle().Foreground(terminus.Red))
+		return nil
 	}
+	d.refreshRate = time.Duration(seconds * float64(time.Second))
+	return d.restartRefresh()
 }
 
 func (d *Dashboard) formatDuration(dur time.Duration) string {
@@ -1143,12 +1280,22 @@ func (d *Dashboard) startAutoRefresh() terminus.Cmd {
 	return d.scheduleRefresh()
 }
 
+// scheduleRefresh starts a refresh interval at the current rate, replacing
+// any interval already running for this dashboard
 func (d *Dashboard) scheduleRefresh() terminus.Cmd {
-	return terminus.Tick(d.refreshRate, func(t time.Time) terminus.Msg {
+	return terminus.Interval(d.refreshID, d.refreshRate, func(t time.Time) terminus.Msg {
 		return refreshMsg{time: t}
 	})
 }
 
+// restartRefresh applies a changed refresh rate while auto-refresh is on
+func (d *Dashboard) restartRefresh() terminus.Cmd {
+	if !d.autoRefresh {
+		return nil
+	}
+	return d.scheduleRefresh()
+}
+
 // Message types
 
 type refreshMsg struct {
@@ -1159,12 +1306,37 @@ type commandResultMsg struct {
 	result string
 }
 
+// tourSteps introduce the dashboard to new users
+var tourSteps = []widget.TourStep{
+	{Title: "Welcome", Text: "This dashboard shows how the machine is doing, refreshed every second. Take a quick tour of its panels, or press Esc to skip it."},
+	{Target: "cpu", Title: "CPU", Text: "CPU usage over time. The title turns yellow above 60% and red above 80%."},
+	{Target: "memory", Title: "Memory", Text: "Memory in use against the total, with its history."},
+	{Target: "network", Title: "Network", Text: "Network traffic in and out."},
+	{Target: "processes", Title: "Processes", Text: "Running processes. Tab here, then use the arrow keys to scroll, S to sort and / to filter."},
+	{Target: "alerts", Title: "Alerts", Text: "Warnings and errors as they happen. The panel flashes when one arrives."},
+	{Target: "commands", Title: "Commands", Text: "Press : to run one of these commands. Press H at any time for help with the keys."},
+}
+
+// authenticate gives every user the roles in DASHBOARD_ROLES, "admin" by
+// default, so the commands they allow can be tried, e.g. with
+// DASHBOARD_ROLES=viewer. A real deployment would look the user up from
+// its sign-in cookie or a header set by its proxy.
+func authenticate(r *http.Request) (terminus.RoleSet, error) {
+	roles := os.Getenv("DASHBOARD_ROLES")
+	if roles == "" {
+		roles = "admin"
+	}
+	return terminus.NewRoleSet(strings.Split(roles, ",")...), nil
+}
+
 // Main function
 
 func main() {
 	// Component factory
 	factory := func() terminus.Component {
-		return NewDashboard()
+		tour := widget.NewTour(NewDashboard(), tourSteps...)
+		tour.Start()
+		return tour
 	}
 
 	// Create program with static files
@@ -1172,9 +1344,13 @@ func main() {
 		factory,
 		terminus.WithStaticFiles(staticFiles, "static"),
 		terminus.WithAddress(":8890"),
+		terminus.WithBinaryProtocol(),
+		terminus.WithCopyMode(terminus.DefaultCopyModeKey),
+		terminus.WithAuth(authenticate),
 	)
 
 	// Start the program
+	go sampleStats(time.Now())
 	if err := program.Start(); err != nil {
 		log.Fatalf("Failed to start program: %v", err)
 	}
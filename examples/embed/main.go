@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+	"github.com/skaiser/terminusgo/pkg/terminus/style"
+)
+
+//go:embed all:static/*
+var staticFiles embed.FS
+
+// tickMsg updates the clock
+type tickMsg time.Time
+
+// ClockComponent shows the time in the user's time zone
+type ClockComponent struct {
+	now      time.Time
+	location *time.Location
+}
+
+func (c *ClockComponent) Init() terminus.Cmd {
+	c.now = time.Now()
+	c.location = time.UTC
+	return c.tick()
+}
+
+func (c *ClockComponent) tick() terminus.Cmd {
+	return terminus.Tick(time.Second, func(t time.Time) terminus.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (c *ClockComponent) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	switch msg := msg.(type) {
+	case terminus.EnvironmentMsg:
+		c.location = msg.Location()
+	case tickMsg:
+		c.now = time.Time(msg)
+		return c, c.tick()
+	}
+	return c, nil
+}
+
+func (c *ClockComponent) View() string {
+	now := c.now.In(c.location)
+	return style.New().Bold(true).Foreground(style.Cyan).Render(now.Format("15:04:05")) + "\n" +
+		style.New().Faint(true).Render(now.Format("Monday 2 January"))
+}
+
+// CounterComponent counts up and down with the + and - keys
+type CounterComponent struct {
+	count int
+}
+
+func (c *CounterComponent) Init() terminus.Cmd {
+	return nil
+}
+
+func (c *CounterComponent) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if key, ok := msg.(terminus.KeyMsg); ok {
+		switch key.String() {
+		case "+", "=":
+			if c.count < 20 {
+				c.count++
+			}
+		case "-":
+			if c.count > 0 {
+				c.count--
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *CounterComponent) View() string {
+	bar := style.New().Foreground(style.Green).Render(strings.Repeat("█", c.count))
+	return fmt.Sprintf("Count: %d\n%s\n", c.count, bar) +
+		style.New().Faint(true).Render("Press + or - (click here first)")
+}
+
+func main() {
+	// The counter is served on /ws and the clock on /ws/clock. The page
+	// mounts both, each in its own session.
+	program := terminus.NewProgram(
+		func() terminus.Component {
+			return &CounterComponent{}
+		},
+		terminus.WithEndpoint("/ws/clock", func() terminus.Component {
+			return &ClockComponent{}
+		}),
+		terminus.WithStaticFiles(staticFiles, "static"),
+		terminus.WithAddress(":8890"),
+	)
+
+	if err := program.Start(); err != nil {
+		log.Fatalf("Failed to start program: %v", err)
+	}
+
+	fmt.Println("TerminusGo embedding example is running on http://localhost:8890")
+	fmt.Println("Press Ctrl+C to stop...")
+
+	program.Wait()
+}
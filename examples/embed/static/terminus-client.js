@@ -0,0 +1,1441 @@
+/**
+ * Copyright 2025 Google LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Terminus Client - Complete Implementation
+(function() {
+    'use strict';
+
+    // TerminusClient connects one terminal element to a session served on
+    // path. A page can hold several, each with its own session.
+    //
+    // Options:
+    //   inline   grow to fit the view; defaults to the data-inline attribute
+    //   rows     most rows an inline terminal grows to; defaults to data-rows
+    //   observe  watch the shared session with this token
+    //   join     collaborate in the shared session with this token
+    //   name     the collaborator's name
+    //   status   an element to show the connection state in
+    class TerminusClient {
+        constructor(terminal, path = '/ws', options = {}) {
+            this.ws = null;
+            this.terminal = terminal;
+            this.path = path;
+            this.status = options.status || null;
+            this.connected = false;
+            this.destroyed = false;
+            this.windowListeners = [];
+            this.handlers = new Map();
+            this.typeHandlers = new Map();
+            this.reconnectAttempts = 0;
+            this.reconnectDelay = 1000;
+            this.maxReconnectDelay = 30000;
+            this.reconnectTimer = null;
+            this.lines = [];
+            this.cursorPosition = { x: 0, y: 0 };
+            this.showCursor = true;
+            this.cursorBlinkInterval = null;
+            this.dimensions = { width: 80, height: 24 };
+            this.ansiParser = new ANSIParser();
+            this.textDecoder = new TextDecoder();
+
+            // Observers of a shared session watch without sending input;
+            // collaborators send keys but not resizes
+            this.observeToken = options.observe || null;
+            this.joinToken = options.join || null;
+            if (this.joinToken) {
+                this.userName = options.name || window.prompt('Your name') || '';
+            }
+
+            // The token lets the client pick its session back up after the
+            // connection drops. Only the session's own client can resume it.
+            if (!this.observeToken && !this.joinToken) {
+                this.resumeToken = this.newResumeToken();
+            }
+            this.exited = false;
+            this.guarded = false;
+
+            // Characters beyond ASCII are measured in the terminal's font
+            // and their widths reported, so the server's layout matches
+            this.measuredGlyphs = new Set();
+            this.pendingGlyphs = [];
+            this.glyphTimer = null;
+
+            // Errors are reported to the server, at most maxErrors a minute
+            this.maxErrors = 20;
+            this.errorTimes = [];
+
+            // An inline terminal is embedded in a page and grows to fit the
+            // view, up to rows rows or the height of the window
+            this.inline = options.inline !== undefined ? !!options.inline : this.terminal.hasAttribute('data-inline');
+            this.rows = options.rows || parseInt(this.terminal.dataset.rows, 10) || 0;
+            this.terminal.classList.toggle('inline', this.inline);
+        }
+
+        newResumeToken() {
+            const bytes = new Uint8Array(16);
+            window.crypto.getRandomValues(bytes);
+            return Array.from(bytes, b => b.toString(16).padStart(2, '0')).join('');
+        }
+
+        connect() {
+            if (this.destroyed) return;
+            const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+            const url = new URL(this.path, `${protocol}//${window.location.host}`);
+            if (this.joinToken) {
+                url.searchParams.set('join', this.joinToken);
+                url.searchParams.set('name', this.userName);
+            } else if (this.observeToken) {
+                url.searchParams.set('observe', this.observeToken);
+                this.terminal.classList.add('observer');
+            } else {
+                url.searchParams.set('resume', this.resumeToken);
+            }
+            const wsUrl = url.toString();
+
+            try {
+                // Offer the binary frame encoding; servers that don't
+                // enable it answer in JSON
+                this.ws = new WebSocket(wsUrl, ['terminus.binary.v1']);
+                this.ws.binaryType = 'arraybuffer';
+                this.setupWebSocketHandlers();
+            } catch (err) {
+                console.error('WebSocket connection failed:', err);
+                this.scheduleReconnect();
+            }
+        }
+
+        setupWebSocketHandlers() {
+            this.ws.onopen = () => {
+                console.log('Connected to Terminus server');
+                this.connected = true;
+                this.reconnectAttempts = 0;
+                this.setConnectionState('connected');
+                this.measuredGlyphs = new Set();
+                
+                // Describe the browser, then send the initial size
+                this.sendEnvironment();
+                this.sendVisibility();
+                this.calculateAndSendResize();
+            };
+
+            this.ws.onclose = () => {
+                if (this.destroyed) return;
+                console.log('Disconnected from Terminus server');
+                this.connected = false;
+                if (this.exited) {
+                    this.setConnectionState('ended');
+                    return;
+                }
+                this.scheduleReconnect();
+            };
+
+            this.ws.onerror = (error) => {
+                console.error('WebSocket error:', error);
+            };
+
+            this.ws.onmessage = (event) => {
+                try {
+                    if (event.data instanceof ArrayBuffer) {
+                        for (const message of this.decodeBinaryFrame(event.data)) {
+                            this.handleServerMessage(message);
+                        }
+                        return;
+                    }
+                    const message = JSON.parse(event.data);
+                    this.handleServerMessage(message);
+                } catch (err) {
+                    console.error('Failed to parse server message:', err);
+                    this.reportError('protocol', err);
+                }
+            };
+        }
+
+        // scheduleReconnect retries with exponential backoff and jitter,
+        // capped at maxReconnectDelay. While the browser is offline it waits
+        // for the network to come back instead.
+        scheduleReconnect() {
+            clearTimeout(this.reconnectTimer);
+            if (!navigator.onLine) {
+                this.setConnectionState('offline');
+                return;
+            }
+            this.setConnectionState('reconnecting');
+
+            const backoff = Math.min(this.reconnectDelay * Math.pow(2, this.reconnectAttempts), this.maxReconnectDelay);
+            const delay = backoff / 2 + Math.random() * backoff / 2;
+            this.reconnectAttempts++;
+
+            this.reconnectTimer = setTimeout(() => {
+                console.log(`Reconnection attempt ${this.reconnectAttempts}`);
+                this.connect();
+            }, delay);
+        }
+
+        // setConnectionState shows whether the client is connected,
+        // reconnecting, offline or ended. The last screen stays visible,
+        // dimmed while the connection is down, under a banner; pages can
+        // also style on the terminal's data-connection attribute or
+        // provide a #status element.
+        setConnectionState(state) {
+            this.terminal.dataset.connection = state;
+            this.terminal.classList.toggle('disconnected', state === 'reconnecting' || state === 'offline');
+
+            const labels = {
+                connected: 'Connected',
+                reconnecting: 'Connection lost. Reconnecting...',
+                offline: 'Offline. Waiting for the network...',
+                ended: 'Session ended'
+            };
+            if (!this.connectionBanner) {
+                // Full-screen terminals have a banner over the page; inline
+                // ones, one under the terminal
+                this.connectionBanner = document.createElement('div');
+                this.connectionBanner.className = 'connection-banner';
+                this.connectionBanner.setAttribute('role', 'status');
+                if (this.inline) {
+                    this.connectionBanner.classList.add('attached');
+                    this.terminal.insertAdjacentElement('afterend', this.connectionBanner);
+                } else {
+                    document.body.appendChild(this.connectionBanner);
+                }
+            }
+            this.connectionBanner.textContent = labels[state];
+            this.connectionBanner.hidden = state === 'connected';
+            this.connectionBanner.classList.toggle('ended', state === 'ended');
+            if (state === 'ended') {
+                const button = document.createElement('button');
+                button.textContent = 'Reconnect';
+                button.addEventListener('click', () => this.restart());
+                this.connectionBanner.append(' \u2014 ', button);
+            }
+
+            const status = this.status;
+            if (status) {
+                status.className = state === 'connected' ? 'connected' : state === 'reconnecting' ? 'connecting' : 'disconnected';
+                status.textContent = labels[state];
+            }
+        }
+
+        // restart starts a new session after the last one ended
+        restart() {
+            this.exited = false;
+            this.guarded = false;
+            this.reconnectAttempts = 0;
+            if (this.resumeToken) {
+                this.resumeToken = this.newResumeToken();
+            }
+            this.connect();
+            this.terminal.focus();
+        }
+
+        // decodeBinaryFrame decodes a frame in the terminus.binary.v1
+        // encoding into the messages it stands for: a sequence of opcodes,
+        // each followed by its fields, with integers as unsigned varints
+        // and strings as a varint byte length then UTF-8
+        decodeBinaryFrame(buffer) {
+            const bytes = new Uint8Array(buffer);
+            let pos = 0;
+            const uint = () => {
+                let value = 0;
+                let scale = 1;
+                let b;
+                do {
+                    if (pos >= bytes.length) throw new Error('Truncated binary frame');
+                    b = bytes[pos++];
+                    value += (b & 0x7f) * scale;
+                    scale *= 128;
+                } while (b & 0x80);
+                return value;
+            };
+            const string = () => {
+                const length = uint();
+                if (pos + length > bytes.length) throw new Error('Truncated binary frame');
+                const text = this.textDecoder.decode(bytes.subarray(pos, pos + length));
+                pos += length;
+                return text;
+            };
+
+            const messages = [];
+            while (pos < bytes.length) {
+                const op = bytes[pos++];
+                switch (op) {
+                    case 1:
+                        messages.push({ type: 'clear', data: {} });
+                        break;
+                    case 2: {
+                        const y = uint();
+                        messages.push({ type: 'updateLine', data: { y, content: string() } });
+                        break;
+                    }
+                    case 3: {
+                        const x = uint();
+                        const y = uint();
+                        const rune = string();
+                        messages.push({ type: 'setCell', data: { x, y, rune, style: string() } });
+                        break;
+                    }
+                    case 4: {
+                        const lines = new Array(uint());
+                        for (let i = 0; i < lines.length; i++) {
+                            lines[i] = string();
+                        }
+                        messages.push({ type: 'render', data: { lines } });
+                        break;
+                    }
+                    default:
+                        throw new Error(`Unknown binary opcode ${op}`);
+                }
+            }
+            return messages;
+        }
+
+        handleServerMessage(message) {
+            switch (message.type) {
+                case 'render':
+                    this.render(message.data);
+                    break;
+                case 'clear':
+                    this.clearScreen();
+                    break;
+                case 'updateLine':
+                    this.updateLine(message.data.y, message.data.content);
+                    break;
+                case 'setCell':
+                    this.setCell(message.data.x, message.data.y, message.data.rune, message.data.style);
+                    break;
+                case 'setCursor':
+                    this.setCursor(message.data.x, message.data.y, message.data.visible);
+                    break;
+                case 'batch':
+                    this.processBatch(message.data.commands);
+                    break;
+                case 'screenshot':
+                    this.takeScreenshot(message.data);
+                    break;
+                case 'print':
+                    this.printPage(message.data.html);
+                    break;
+                case 'download':
+                    this.download(message.data);
+                    break;
+                case 'upload':
+                    this.upload(message.data);
+                    break;
+                case 'clipboard':
+                    this.copyText(message.data.text);
+                    break;
+                case 'notify':
+                    this.notify(message.data);
+                    break;
+                case 'custom':
+                    this.dispatch(message.data.channel, message.data.payload);
+                    break;
+                case 'guard':
+                    // The app has unsaved state while the guard is on
+                    this.guarded = !!message.data.enabled;
+                    break;
+                case 'exit':
+                    // The session quit; its last frame is the exit screen
+                    this.exited = true;
+                    break;
+                default:
+                    if (this.typeHandlers.has(message.type)) {
+                        this.handleMessageType(message.type, message.data);
+                    } else {
+                        console.warn('Unknown message type:', message.type);
+                    }
+            }
+        }
+
+        render(data) {
+            this.hydrate();
+            if (typeof data === 'string') {
+                // Legacy string render
+                this.terminal.innerHTML = this.ansiParser.parse(data);
+            } else if (data.content) {
+                // Structured render with content
+                this.terminal.innerHTML = this.ansiParser.parse(data.content);
+            } else if (data.lines) {
+                // Line-based render
+                data.lines.forEach(line => this.noteGlyphs(line));
+                this.lines = data.lines.map(line => this.ansiParser.parse(line));
+                this.rebuildDisplay();
+            }
+            this.scrollToBottom();
+        }
+
+        // hydrate notes that the session's frames have replaced the one the
+        // page was served with, if any
+        hydrate() {
+            this.terminal.removeAttribute('data-prerendered');
+        }
+
+        clearScreen() {
+            this.hydrate();
+            this.lines = [];
+            this.terminal.innerHTML = '';
+            this.cursorPosition = { x: 0, y: 0 };
+        }
+
+        updateLine(y, content) {
+            this.noteGlyphs(content);
+            this.ensureLines(y + 1);
+            this.lines[y] = this.ansiParser.parse(content);
+            this.rebuildDisplay();
+        }
+
+        setCell(x, y, rune, style) {
+            this.ensureLines(y + 1);
+            
+            // Convert line to character array if needed
+            if (!this.lineCharacters) {
+                this.lineCharacters = {};
+            }
+            
+            if (!this.lineCharacters[y]) {
+                this.lineCharacters[y] = new Array(this.dimensions.width).fill(' ');
+            }
+            
+            // Apply style and character
+            const styledChar = style ? 
+                `<span style="${this.styleToCSS(style)}">${this.escapeHtml(rune)}</span>` : 
+                this.escapeHtml(rune);
+            
+            this.lineCharacters[y][x] = styledChar;
+            
+            // Rebuild the line
+            this.lines[y] = this.lineCharacters[y].join('');
+            this.rebuildDisplay();
+        }
+
+        // noteGlyphs queues the characters of text beyond ASCII that haven't
+        // been measured yet, to be measured once the frame is drawn
+        noteGlyphs(text) {
+            if (!/[^\x00-\x7f]/.test(text)) return;
+            for (const ch of text) {
+                if (ch.codePointAt(0) > 0x9f && !this.measuredGlyphs.has(ch)) {
+                    this.measuredGlyphs.add(ch);
+                    this.pendingGlyphs.push(ch);
+                }
+            }
+            if (this.pendingGlyphs.length && !this.glyphTimer) {
+                this.glyphTimer = setTimeout(() => this.measureGlyphs(), 100);
+            }
+        }
+
+        // measureGlyphs reports how many cells the font draws each queued
+        // character across
+        measureGlyphs() {
+            this.glyphTimer = null;
+            const glyphs = this.pendingGlyphs;
+            this.pendingGlyphs = [];
+            if (!glyphs.length || !this.connected) return;
+
+            // Ten copies of each character average out sub-pixel rounding
+            const measurer = document.createElement('span');
+            measurer.style.position = 'absolute';
+            measurer.style.visibility = 'hidden';
+            measurer.style.whiteSpace = 'pre';
+            this.terminal.appendChild(measurer);
+            measurer.textContent = 'W'.repeat(10);
+            const cell = measurer.getBoundingClientRect().width;
+            const widths = {};
+            for (const ch of glyphs) {
+                measurer.textContent = ch.repeat(10);
+                widths[ch] = Math.round(measurer.getBoundingClientRect().width / cell);
+            }
+            this.terminal.removeChild(measurer);
+            if (cell > 0) {
+                this.sendMessage('glyphs', { widths });
+            }
+        }
+
+        setCursor(x, y, visible = true) {
+            this.cursorPosition = { x, y };
+            this.showCursor = visible;
+            this.updateCursorDisplay();
+        }
+
+        processBatch(commands) {
+            commands.forEach(cmd => {
+                this.handleServerMessage(cmd);
+            });
+        }
+
+        ensureLines(count) {
+            while (this.lines.length < count) {
+                this.lines.push('');
+            }
+        }
+
+        rebuildDisplay() {
+            this.hydrate();
+            // Lines are already parsed, just join them with <br> tags
+            const content = this.lines.join('<br>');
+            this.terminal.innerHTML = content;
+            this.updateCursorDisplay();
+        }
+
+        updateCursorDisplay() {
+            // Remove existing cursor
+            const existingCursor = this.terminal.querySelector('.cursor');
+            if (existingCursor) {
+                existingCursor.remove();
+            }
+
+            if (!this.showCursor) return;
+
+            // Add cursor at current position
+            // This is a simplified implementation
+            // A full implementation would insert the cursor at the exact character position
+        }
+
+        scrollToBottom() {
+            this.terminal.scrollTop = this.terminal.scrollHeight;
+        }
+
+        styleToCSS(style) {
+            const css = [];
+            if (style.foreground) css.push(`color: ${style.foreground}`);
+            if (style.background) css.push(`background-color: ${style.background}`);
+            if (style.bold) css.push('font-weight: bold');
+            if (style.italic) css.push('font-style: italic');
+            if (style.underline) css.push('text-decoration: underline');
+            if (style.strikethrough) css.push('text-decoration: line-through');
+            return css.join('; ');
+        }
+
+        // printPage opens the print dialog for a page from the server,
+        // loaded in a hidden frame so the terminal itself isn't printed
+        printPage(html) {
+            const frame = document.createElement('iframe');
+            frame.style.cssText = 'position: fixed; width: 0; height: 0; border: 0; visibility: hidden;';
+            frame.onload = () => {
+                frame.contentWindow.focus();
+                frame.contentWindow.print();
+                setTimeout(() => frame.remove(), 1000);
+            };
+            frame.srcdoc = html;
+            document.body.appendChild(frame);
+        }
+
+        // download saves a file from the server in the user's downloads
+        download({ filename, mimeType, data }) {
+            const bytes = Uint8Array.from(atob(data), c => c.charCodeAt(0));
+            const url = URL.createObjectURL(new Blob([bytes], { type: mimeType || 'application/octet-stream' }));
+            const link = document.createElement('a');
+            link.href = url;
+            link.download = filename;
+            link.click();
+            setTimeout(() => URL.revokeObjectURL(url), 1000);
+        }
+
+        // upload opens a file picker and sends the chosen file to the
+        // server, or tells it none was chosen
+        upload({ accept, maxSize }) {
+            const input = document.createElement('input');
+            input.type = 'file';
+            if (accept) input.accept = accept;
+            input.addEventListener('cancel', () => this.sendMessage('upload', { cancelled: true }));
+            input.addEventListener('change', () => {
+                const file = input.files[0];
+                if (!file) {
+                    this.sendMessage('upload', { cancelled: true });
+                    return;
+                }
+                if (maxSize && file.size > maxSize) {
+                    this.sendMessage('upload', { filename: file.name, error: `file is larger than ${maxSize} bytes` });
+                    return;
+                }
+                const reader = new FileReader();
+                reader.onload = () => this.sendMessage('upload', {
+                    filename: file.name,
+                    data: reader.result.slice(reader.result.indexOf(',') + 1),
+                });
+                reader.onerror = () => this.sendMessage('upload', { filename: file.name, error: String(reader.error) });
+                reader.readAsDataURL(file);
+            });
+            input.click();
+        }
+
+        // copyText puts text from the server on the clipboard, through a
+        // hidden text area where the Clipboard API isn't available
+        copyText(text) {
+            const fallback = () => {
+                const area = document.createElement('textarea');
+                area.value = text;
+                area.style.cssText = 'position: fixed; top: 0; left: 0; opacity: 0;';
+                document.body.appendChild(area);
+                area.select();
+                try {
+                    document.execCommand('copy');
+                } finally {
+                    area.remove();
+                    this.terminal.focus();
+                }
+            };
+            if (navigator.clipboard && window.isSecureContext) {
+                navigator.clipboard.writeText(text).catch(fallback);
+            } else {
+                fallback();
+            }
+        }
+
+        // takeScreenshot draws the terminal as a PNG or SVG image and sends
+        // it to the server, downloading it first if a filename is given
+        async takeScreenshot({ id, format, filename }) {
+            try {
+                const blob = format === 'svg' ? this.renderSVG() : await this.renderPNG();
+                if (filename) {
+                    const url = URL.createObjectURL(blob);
+                    const link = document.createElement('a');
+                    link.href = url;
+                    link.download = filename;
+                    link.click();
+                    setTimeout(() => URL.revokeObjectURL(url), 1000);
+                }
+                const data = await new Promise((resolve, reject) => {
+                    const reader = new FileReader();
+                    reader.onload = () => resolve(reader.result.slice(reader.result.indexOf(',') + 1));
+                    reader.onerror = () => reject(reader.error);
+                    reader.readAsDataURL(blob);
+                });
+                this.sendMessage('screenshot', { id, format, filename, data });
+            } catch (err) {
+                this.sendMessage('screenshot', { id, format, filename, error: String(err) });
+            }
+        }
+
+        // screenRuns returns the text on screen as runs of one style, with
+        // their cell positions, and the metrics of a cell
+        screenRuns() {
+            const base = getComputedStyle(this.terminal);
+            const canvas = document.createElement('canvas');
+            const ctx = canvas.getContext('2d');
+            ctx.font = `${base.fontSize} ${base.fontFamily}`;
+            const cell = {
+                width: ctx.measureText('M').width,
+                height: parseFloat(base.lineHeight) || parseFloat(base.fontSize) * 1.2,
+            };
+
+            let background = base.backgroundColor;
+            if (background === 'rgba(0, 0, 0, 0)' || background === 'transparent') {
+                background = getComputedStyle(document.body).backgroundColor;
+            }
+
+            const runs = [];
+            let x = 0, y = 0, columns = 0;
+            const walk = (node) => {
+                for (const child of node.childNodes) {
+                    if (child.nodeName === 'BR') {
+                        x = 0;
+                        y++;
+                    } else if (child.nodeType === Node.TEXT_NODE) {
+                        const style = getComputedStyle(child.parentElement);
+                        child.textContent.split('\n').forEach((text, i) => {
+                            if (i > 0) {
+                                x = 0;
+                                y++;
+                            }
+                            if (text) {
+                                runs.push({
+                                    x, y, text,
+                                    color: style.color,
+                                    background: style.backgroundColor,
+                                    weight: style.fontWeight,
+                                    fontStyle: style.fontStyle,
+                                });
+                            }
+                            x += [...text].length;
+                            columns = Math.max(columns, x);
+                        });
+                    } else if (child.classList && child.classList.contains('cursor')) {
+                        continue;
+                    } else {
+                        walk(child);
+                    }
+                }
+            };
+            walk(this.terminal);
+            return {
+                runs, cell, background,
+                font: { size: base.fontSize, family: base.fontFamily },
+                width: Math.ceil(Math.max(columns, this.dimensions.width) * cell.width),
+                height: Math.ceil((y + 1) * cell.height),
+            };
+        }
+
+        // renderPNG rasterizes the terminal onto a canvas
+        renderPNG() {
+            const screen = this.screenRuns();
+            const scale = window.devicePixelRatio || 1;
+            const canvas = document.createElement('canvas');
+            canvas.width = screen.width * scale;
+            canvas.height = screen.height * scale;
+            const ctx = canvas.getContext('2d');
+            ctx.scale(scale, scale);
+            ctx.fillStyle = screen.background;
+            ctx.fillRect(0, 0, screen.width, screen.height);
+            ctx.textBaseline = 'middle';
+            for (const run of screen.runs) {
+                const left = run.x * screen.cell.width;
+                const top = run.y * screen.cell.height;
+                if (run.background !== 'rgba(0, 0, 0, 0)') {
+                    ctx.fillStyle = run.background;
+                    ctx.fillRect(left, top, [...run.text].length * screen.cell.width, screen.cell.height);
+                }
+                ctx.font = `${run.fontStyle} ${run.weight} ${screen.font.size} ${screen.font.family}`;
+                ctx.fillStyle = run.color;
+                ctx.fillText(run.text, left, top + screen.cell.height / 2);
+            }
+            return new Promise((resolve, reject) => canvas.toBlob(blob => {
+                blob ? resolve(blob) : reject(new Error('Canvas could not be encoded'));
+            }, 'image/png'));
+        }
+
+        // renderSVG draws the terminal as SVG text
+        renderSVG() {
+            const screen = this.screenRuns();
+            const xml = (text) => String(text).replace(/[&<>"']/g, c => `&#${c.charCodeAt(0)};`);
+            const parts = [
+                `<svg xmlns="http://www.w3.org/2000/svg" width="${screen.width}" height="${screen.height}" ` +
+                `font-family="${xml(screen.font.family)}" font-size="${xml(screen.font.size)}">`,
+                `<rect width="100%" height="100%" fill="${xml(screen.background)}"/>`,
+            ];
+            for (const run of screen.runs) {
+                const left = run.x * screen.cell.width;
+                const top = run.y * screen.cell.height;
+                if (run.background !== 'rgba(0, 0, 0, 0)') {
+                    parts.push(`<rect x="${left}" y="${top}" width="${[...run.text].length * screen.cell.width}" ` +
+                        `height="${screen.cell.height}" fill="${xml(run.background)}"/>`);
+                }
+                parts.push(`<text x="${left}" y="${top + screen.cell.height / 2}" dominant-baseline="middle" ` +
+                    `xml:space="preserve" fill="${xml(run.color)}" font-weight="${xml(run.weight)}" ` +
+                    `font-style="${xml(run.fontStyle)}">${xml(run.text)}</text>`);
+            }
+            parts.push('</svg>');
+            return new Blob([parts.join('\n')], { type: 'image/svg+xml' });
+        }
+
+        escapeHtml(text) {
+            const div = document.createElement('div');
+            div.textContent = text;
+            return div.innerHTML;
+        }
+
+        // notify shows a desktop notification, asking permission first if
+        // need be. Unless always is set, it is only shown while the tab is
+        // in the background. Clicks, and notifications that can't be shown,
+        // are reported to the server.
+        notify(data) {
+            const report = event => this.sendMessage('notification', { id: data.id, event });
+            if (!('Notification' in window)) {
+                report('denied');
+                return;
+            }
+            if (!data.always && !document.hidden) {
+                report('close');
+                return;
+            }
+
+            const show = () => {
+                const options = { body: data.body };
+                if (data.tag) options.tag = data.tag;
+                if (data.icon) options.icon = data.icon;
+                const notification = new Notification(data.title, options);
+                notification.onclick = () => {
+                    window.focus();
+                    this.terminal.focus();
+                    report('click');
+                    notification.close();
+                };
+                notification.onclose = () => report('close');
+            };
+            if (Notification.permission === 'granted') {
+                show();
+            } else if (Notification.permission === 'denied') {
+                report('denied');
+            } else {
+                Notification.requestPermission().then(permission => {
+                    if (permission === 'granted') {
+                        show();
+                    } else {
+                        report('denied');
+                    }
+                });
+            }
+        }
+
+        // on registers a handler for messages the component sends on
+        // channel with terminus.SendToClient, returning a function that
+        // removes it
+        on(channel, handler) {
+            if (!this.handlers.has(channel)) {
+                this.handlers.set(channel, new Set());
+            }
+            this.handlers.get(channel).add(handler);
+            return () => this.handlers.get(channel).delete(handler);
+        }
+
+        // onMessage registers a handler for messages of a type the server
+        // registered with terminus.RegisterMessageType, sent by components
+        // with terminus.Emit, returning a function that removes it. Send
+        // messages of the type with sendMessage(type, data).
+        onMessage(type, handler) {
+            if (!this.typeHandlers.has(type)) {
+                this.typeHandlers.set(type, new Set());
+            }
+            this.typeHandlers.get(type).add(handler);
+            return () => this.typeHandlers.get(type).delete(handler);
+        }
+
+        // handleMessageType hands a message of a registered type to its
+        // handlers
+        handleMessageType(type, data) {
+            for (const handler of this.typeHandlers.get(type)) {
+                try {
+                    handler(data);
+                } catch (err) {
+                    console.error(`Handler for ${type} messages failed:`, err);
+                    this.reportError('handler', err);
+                }
+            }
+        }
+
+        // send sends payload to the component as a terminus.ClientMsg on
+        // channel
+        send(channel, payload) {
+            this.sendMessage('custom', { channel, payload });
+        }
+
+        // dispatch hands a message from the component to the handlers for
+        // its channel, and to terminus:message listeners on the terminal
+        dispatch(channel, payload) {
+            for (const handler of this.handlers.get(channel) || []) {
+                try {
+                    handler(payload);
+                } catch (err) {
+                    console.error(`Handler for ${channel} failed:`, err);
+                    this.reportError('handler', err);
+                }
+            }
+            this.terminal.dispatchEvent(new CustomEvent('terminus:message', {
+                bubbles: true,
+                detail: { channel, payload }
+            }));
+        }
+
+        // reportError sends an error to the server, so it shows in the
+        // server's logs
+        reportError(source, err) {
+            const now = Date.now();
+            this.errorTimes = this.errorTimes.filter(t => now - t < 60000);
+            if (this.errorTimes.length >= this.maxErrors) return;
+            this.errorTimes.push(now);
+            this.sendMessage('error', {
+                source,
+                message: err instanceof Error ? err.message : String(err),
+                stack: err instanceof Error && err.stack ? String(err.stack).slice(0, 4096) : ''
+            });
+        }
+
+        sendMessage(type, data) {
+            if (!this.connected || this.ws.readyState !== WebSocket.OPEN) {
+                return;
+            }
+            if (this.observeToken && !this.joinToken) {
+                return;
+            }
+            if (this.joinToken && type !== 'key') {
+                return;
+            }
+
+            const message = JSON.stringify({ type, data });
+            this.ws.send(message);
+        }
+
+        sendKey(keyType, runes = null, modifiers = null) {
+            const data = { keyType };
+            if (runes) {
+                data.runes = runes;
+            }
+            if (modifiers) {
+                Object.assign(data, modifiers);
+            }
+            this.sendMessage('key', data);
+        }
+
+        sendEnvironment() {
+            const reducedMotion = window.matchMedia &&
+                window.matchMedia('(prefers-reduced-motion: reduce)').matches;
+            this.sendMessage('environment', {
+                locale: navigator.language || '',
+                timeZone: Intl.DateTimeFormat().resolvedOptions().timeZone || '',
+                utcOffset: -new Date().getTimezoneOffset(),
+                colorDepth: window.screen ? window.screen.colorDepth : 24,
+                reducedMotion: !!reducedMotion,
+                userAgent: navigator.userAgent || '',
+                inline: this.inline
+            });
+        }
+
+        // sendVisibility tells the server whether the tab is showing, so
+        // SmartTick can pause in the background
+        sendVisibility() {
+            this.sendMessage('visibility', { visible: !document.hidden });
+        }
+
+        calculateAndSendResize() {
+            // Get terminal element dimensions
+            const rect = this.terminal.getBoundingClientRect();
+            const computedStyle = window.getComputedStyle(this.terminal);
+            
+            // Calculate usable space
+            const usableWidth = rect.width - 
+                parseFloat(computedStyle.paddingLeft) - 
+                parseFloat(computedStyle.paddingRight);
+            const usableHeight = rect.height - 
+                parseFloat(computedStyle.paddingTop) - 
+                parseFloat(computedStyle.paddingBottom);
+            
+            // Create temporary element to measure character dimensions
+            const measurer = document.createElement('span');
+            measurer.style.position = 'absolute';
+            measurer.style.visibility = 'hidden';
+            measurer.style.whiteSpace = 'pre';
+            measurer.textContent = 'W'; // Use 'W' as it's typically widest
+            this.terminal.appendChild(measurer);
+            
+            const charWidth = measurer.getBoundingClientRect().width;
+            const charHeight = parseFloat(computedStyle.lineHeight);
+            
+            this.terminal.removeChild(measurer);
+            
+            // Calculate dimensions
+            const width = Math.floor(usableWidth / charWidth);
+            let height = Math.floor(usableHeight / charHeight);
+            if (this.inline) {
+                height = this.rows || Math.floor(window.innerHeight / charHeight);
+            }
+            
+            // Update dimensions, remembering the page's for prerendering it
+            this.dimensions = { width, height };
+            if (this.path === '/ws' && width > 0 && height > 0) {
+                document.cookie = `terminus_size=${width}x${height}; path=/; max-age=31536000; SameSite=Lax`;
+            }
+            
+            // Send to server
+            this.sendMessage('resize', { width, height });
+        }
+
+        setupInputHandlers() {
+            // Focus terminal on click
+            this.terminal.addEventListener('click', () => {
+                this.terminal.focus();
+            });
+
+            // Keyboard input
+            this.terminal.addEventListener('keydown', (e) => {
+                if (!this.connected) return;
+
+                let handled = true;
+
+                // Special key combinations
+                if (e.ctrlKey || e.metaKey) {
+                    // Shift is passed along for chords such as Ctrl+Shift+D
+                    const mods = e.shiftKey ? { shift: true } : null;
+                    switch (e.key.toLowerCase()) {
+                        case 'c':
+                            this.sendKey('ctrl+c', null, mods);
+                            break;
+                        case 'v':
+                            // Allow paste
+                            handled = false;
+                            break;
+                        case 'a':
+                            this.sendKey('ctrl+a', null, mods);
+                            break;
+                        case 'd':
+                            this.sendKey('ctrl+d', null, mods);
+                            break;
+                        case 'e':
+                            this.sendKey('ctrl+e', null, mods);
+                            break;
+                        case 'f':
+                            // Search within the application, not the page
+                            this.sendKey('ctrl+f', null, mods);
+                            break;
+                        case 'k':
+                            this.sendKey('ctrl+k', null, mods);
+                            break;
+                        case 'l':
+                            this.sendKey('ctrl+l', null, mods);
+                            break;
+                        case 'r':
+                            this.sendKey('ctrl+r', null, mods);
+                            break;
+                        case 't':
+                            this.sendKey('ctrl+t', null, mods);
+                            break;
+                        case 's':
+                            this.sendKey('ctrl+s', null, mods);
+                            break;
+                        case 'u':
+                            this.sendKey('ctrl+u', null, mods);
+                            break;
+                        case 'w':
+                            this.sendKey('ctrl+w', null, mods);
+                            break;
+                        case 'z':
+                            this.sendKey('ctrl+z', null, mods);
+                            break;
+                        case 'arrowup':
+                            this.sendKey('up', null, { ctrl: true });
+                            break;
+                        case 'arrowdown':
+                            this.sendKey('down', null, { ctrl: true });
+                            break;
+                        case 'arrowleft':
+                            this.sendKey('left', null, { ctrl: true });
+                            break;
+                        case 'arrowright':
+                            this.sendKey('right', null, { ctrl: true });
+                            break;
+                        default:
+                            handled = false;
+                    }
+                } else if (e.altKey) {
+                    switch (e.key.toLowerCase()) {
+                        case 'b':
+                            this.sendKey('alt+b');
+                            break;
+                        case 'f':
+                            this.sendKey('alt+f');
+                            break;
+                        case 'd':
+                            this.sendKey('alt+d');
+                            break;
+                        case 'backspace':
+                            this.sendKey('alt+backspace');
+                            break;
+                        default:
+                            handled = false;
+                    }
+                } else {
+                    // Regular keys
+                    switch (e.key) {
+                        case 'Enter':
+                            this.sendKey('enter');
+                            break;
+                        case ' ':
+                            this.sendKey('space');
+                            break;
+                        case 'Backspace':
+                            this.sendKey('backspace');
+                            break;
+                        case 'Delete':
+                            this.sendKey('delete');
+                            break;
+                        case 'Tab':
+                            this.sendKey(e.shiftKey ? 'shift+tab' : 'tab');
+                            break;
+                        case 'Escape':
+                            this.sendKey('escape');
+                            break;
+                        case 'ArrowUp':
+                            this.sendKey('up');
+                            break;
+                        case 'ArrowDown':
+                            this.sendKey('down');
+                            break;
+                        case 'ArrowLeft':
+                            this.sendKey('left');
+                            break;
+                        case 'ArrowRight':
+                            this.sendKey('right');
+                            break;
+                        case 'Home':
+                            this.sendKey('home');
+                            break;
+                        case 'End':
+                            this.sendKey('end');
+                            break;
+                        case 'PageUp':
+                            this.sendKey('pageup');
+                            break;
+                        case 'PageDown':
+                            this.sendKey('pagedown');
+                            break;
+                        case 'Insert':
+                            this.sendKey('insert');
+                            break;
+                        default:
+                            // Function keys
+                            if (e.key.match(/^F([1-9]|1[0-2])$/)) {
+                                this.sendKey(e.key.toLowerCase());
+                            }
+                            // Regular character input
+                            else if (e.key.length === 1) {
+                                this.sendKey('runes', [e.key]);
+                            } else {
+                                handled = false;
+                            }
+                    }
+                }
+
+                if (handled) {
+                    e.preventDefault();
+                }
+            });
+
+            // Paste handling
+            this.terminal.addEventListener('paste', (e) => {
+                if (!this.connected) return;
+                
+                e.preventDefault();
+                const text = e.clipboardData.getData('text/plain');
+                if (text) {
+                    // Send paste as individual characters
+                    this.sendKey('runes', Array.from(text));
+                }
+            });
+
+            // Window resize
+            let resizeTimeout;
+            this.listen(window, 'resize', () => {
+                clearTimeout(resizeTimeout);
+                resizeTimeout = setTimeout(() => {
+                    this.calculateAndSendResize();
+                }, 300);
+            });
+
+            // Reconnect as soon as the network comes back
+            this.listen(window, 'online', () => {
+                const connecting = this.ws && this.ws.readyState === WebSocket.CONNECTING;
+                if (!this.connected && !connecting && !this.exited) {
+                    this.reconnectAttempts = 0;
+                    clearTimeout(this.reconnectTimer);
+                    this.connect();
+                }
+            });
+            this.listen(window, 'offline', () => {
+                if (!this.connected && !this.exited) {
+                    this.scheduleReconnect();
+                }
+            });
+
+            // Report errors on the page, which may have broken the client
+            this.listen(window, 'error', (e) => {
+                this.reportError('window', e.error || e.message);
+            });
+            this.listen(window, 'unhandledrejection', (e) => {
+                this.reportError('promise', e.reason);
+            });
+
+            // Ask before leaving while the app has unsaved state
+            this.listen(window, 'beforeunload', (e) => {
+                if (this.guarded && !this.exited) {
+                    e.preventDefault();
+                    e.returnValue = '';
+                }
+            });
+
+            // Visibility change
+            this.listen(document, 'visibilitychange', () => {
+                if (this.connected) {
+                    this.sendVisibility();
+                }
+                if (!document.hidden && this.connected) {
+                    // Refresh on visibility restore
+                    this.sendMessage('refresh', {});
+                }
+            });
+        }
+
+        // listen adds a listener outside the terminal, removed by destroy
+        listen(target, type, listener) {
+            target.addEventListener(type, listener);
+            this.windowListeners.push([target, type, listener]);
+        }
+
+        init() {
+            this.setupInputHandlers();
+            this.connect();
+        }
+
+        // destroy disconnects the terminal for good, e.g. when the page
+        // removes it
+        destroy() {
+            this.destroyed = true;
+            this.exited = true;
+            clearTimeout(this.reconnectTimer);
+            clearTimeout(this.glyphTimer);
+            for (const [target, type, listener] of this.windowListeners) {
+                target.removeEventListener(type, listener);
+            }
+            this.windowListeners = [];
+            if (this.ws) {
+                this.ws.close();
+            }
+            if (this.connectionBanner) {
+                this.connectionBanner.remove();
+            }
+        }
+    }
+
+    // ANSI Parser with full color support
+    class ANSIParser {
+        constructor() {
+            this.colorMap = {
+                30: 'black', 31: 'red', 32: 'green', 33: 'yellow',
+                34: 'blue', 35: 'magenta', 36: 'cyan', 37: 'white',
+                90: 'bright-black', 91: 'bright-red', 92: 'bright-green', 93: 'bright-yellow',
+                94: 'bright-blue', 95: 'bright-magenta', 96: 'bright-cyan', 97: 'bright-white'
+            };
+        }
+
+        parse(text) {
+            // Escape HTML first
+            text = text
+                .replace(/&/g, '&amp;')
+                .replace(/</g, '&lt;')
+                .replace(/>/g, '&gt;');
+
+            // Parse ANSI sequences. Each sequence changes only the attributes
+            // it names, so the current style is tracked across them and the
+            // text after each sequence gets a span with the whole style.
+            const regex = /\x1b\[([0-9;]*)m/g;
+            const state = this.resetState();
+            let result = '';
+            let lastIndex = 0;
+            let open = false;
+
+            let match;
+            while ((match = regex.exec(text)) !== null) {
+                // Add text before match
+                if (match.index > lastIndex) {
+                    result += text.substring(lastIndex, match.index);
+                }
+
+                this.applyCodes(state, match[1].split(';'));
+                if (open) {
+                    result += '</span>';
+                }
+                const span = this.openSpan(state);
+                result += span;
+                open = span !== '';
+
+                lastIndex = match.index + match[0].length;
+            }
+
+            // Add remaining text
+            if (lastIndex < text.length) {
+                result += text.substring(lastIndex);
+            }
+
+            // Close the open span
+            if (open) {
+                result += '</span>';
+            }
+
+            // Convert newlines to <br>
+            result = result.replace(/\n/g, '<br>');
+
+            return result;
+        }
+
+        // resetState returns the default style: no attributes, no colors
+        resetState() {
+            return { attrs: new Set(), fg: null, bg: null };
+        }
+
+        // applyCodes applies the SGR codes of one sequence to state
+        applyCodes(state, codes) {
+            const attrs = {
+                1: 'ansi-bold', 2: 'ansi-faint', 3: 'ansi-italic', 4: 'ansi-underline',
+                5: 'ansi-blink', 7: 'ansi-reverse', 8: 'ansi-hidden', 9: 'ansi-strikethrough',
+                21: 'ansi-double-underline', 53: 'ansi-overline'
+            };
+
+            for (let i = 0; i < codes.length; i++) {
+                const code = codes[i] === '' ? 0 : parseInt(codes[i]);
+
+                if (code === 0) {
+                    Object.assign(state, this.resetState());
+                } else if (attrs[code]) {
+                    state.attrs.add(attrs[code]);
+                } else if (code === 22) {
+                    state.attrs.delete('ansi-bold');
+                    state.attrs.delete('ansi-faint');
+                } else if (code === 24) {
+                    state.attrs.delete('ansi-underline');
+                    state.attrs.delete('ansi-double-underline');
+                } else if (code >= 23 && code <= 29 && attrs[code - 20]) {
+                    state.attrs.delete(attrs[code - 20]);
+                } else if (code === 55) {
+                    state.attrs.delete('ansi-overline');
+                } else if (code === 38 || code === 48) {
+                    // 256 color or RGB
+                    let color = null;
+                    if (codes[i + 1] === '5' && codes[i + 2]) {
+                        color = { style: this.ansi256ToHex(parseInt(codes[i + 2])) };
+                        i += 2;
+                    } else if (codes[i + 1] === '2' && codes[i + 2] && codes[i + 3] && codes[i + 4]) {
+                        color = { style: `rgb(${codes[i + 2]}, ${codes[i + 3]}, ${codes[i + 4]})` };
+                        i += 4;
+                    }
+                    if (color) {
+                        state[code === 38 ? 'fg' : 'bg'] = color;
+                    }
+                } else if (code === 39) {
+                    state.fg = null;
+                } else if (code === 49) {
+                    state.bg = null;
+                } else if ((code >= 30 && code <= 37) || (code >= 90 && code <= 97)) {
+                    state.fg = { className: `ansi-${this.colorMap[code]}` };
+                } else if ((code >= 40 && code <= 47) || (code >= 100 && code <= 107)) {
+                    state.bg = { className: `ansi-bg-${this.colorMap[code - 10]}` };
+                }
+            }
+        }
+
+        // openSpan returns the opening tag of a span in the style of state,
+        // or '' for the default style
+        openSpan(state) {
+            const classes = [...state.attrs];
+            const styles = [];
+
+            // Each line class sets text-decoration, so a span with several
+            // lines draws them all inline instead
+            const lines = [];
+            if (state.attrs.has('ansi-underline') || state.attrs.has('ansi-double-underline')) {
+                lines.push('underline');
+            }
+            if (state.attrs.has('ansi-overline')) lines.push('overline');
+            if (state.attrs.has('ansi-strikethrough')) lines.push('line-through');
+            if (lines.length > 1) {
+                styles.push(`text-decoration-line: ${lines.join(' ')}`);
+            }
+            if (state.fg) {
+                if (state.fg.className) {
+                    classes.push(state.fg.className);
+                } else {
+                    styles.push(`color: ${state.fg.style}`);
+                }
+            }
+            if (state.bg) {
+                if (state.bg.className) {
+                    classes.push(state.bg.className);
+                } else {
+                    styles.push(`background-color: ${state.bg.style}`);
+                }
+            }
+            if (classes.length === 0 && styles.length === 0) {
+                return '';
+            }
+
+            let span = '<span';
+            if (classes.length > 0) {
+                span += ` class="${classes.join(' ')}"`;
+            }
+            if (styles.length > 0) {
+                span += ` style="${styles.join('; ')}"`;
+            }
+            return span + '>';
+        }
+
+        ansi256ToHex(code) {
+            // ANSI 256 color palette
+            const colors = [
+                // Standard colors (0-15)
+                '#000000', '#800000', '#008000', '#808000', '#000080', '#800080', '#008080', '#c0c0c0',
+                '#808080', '#ff0000', '#00ff00', '#ffff00', '#0000ff', '#ff00ff', '#00ffff', '#ffffff',
+                // 216 color cube (16-231)
+                ...this.generate216ColorCube(),
+                // Grayscale (232-255)
+                ...this.generateGrayscale()
+            ];
+            
+            return colors[code] || '#ffffff';
+        }
+
+        generate216ColorCube() {
+            const colors = [];
+            const values = [0, 95, 135, 175, 215, 255];
+            
+            for (let r = 0; r < 6; r++) {
+                for (let g = 0; g < 6; g++) {
+                    for (let b = 0; b < 6; b++) {
+                        colors.push(`#${values[r].toString(16).padStart(2, '0')}${values[g].toString(16).padStart(2, '0')}${values[b].toString(16).padStart(2, '0')}`);
+                    }
+                }
+            }
+            
+            return colors;
+        }
+
+        generateGrayscale() {
+            const colors = [];
+            for (let i = 0; i < 24; i++) {
+                const value = 8 + i * 10;
+                const hex = value.toString(16).padStart(2, '0');
+                colors.push(`#${hex}${hex}${hex}`);
+            }
+            return colors;
+        }
+    }
+
+    // Terminus.mount connects a terminal element, or the first element
+    // matching a selector, to the component served on path, returning its
+    // client. Each mounted terminal has its own session.
+    window.Terminus = {
+        mount(element, path = '/ws', options = {}) {
+            const terminal = typeof element === 'string' ? document.querySelector(element) : element;
+            if (!terminal) {
+                throw new Error(`No terminal element ${element}`);
+            }
+            if (!terminal.hasAttribute('tabindex')) {
+                terminal.tabIndex = 0;
+            }
+            const client = new TerminusClient(terminal, path, options);
+            client.init();
+            return client;
+        }
+    };
+
+    // Mount the page's #terminal, if it has one, taking shared-session
+    // tokens from the page's address
+    function mountDefault() {
+        const terminal = document.getElementById('terminal');
+        if (!terminal) return;
+        const params = new URLSearchParams(window.location.search);
+        const client = window.Terminus.mount(terminal, '/ws', {
+            observe: params.get('observe'),
+            join: params.get('join'),
+            name: params.get('name'),
+            status: document.getElementById('status')
+        });
+        terminal.focus();
+        window.terminusClient = client; // For debugging
+    }
+
+    // Initialize client when DOM is ready
+    if (document.readyState === 'loading') {
+        document.addEventListener('DOMContentLoaded', mountDefault);
+    } else {
+        mountDefault();
+    }
+})();
\ No newline at end of file
@@ -29,6 +29,7 @@ import (
 	"github.com/skaiser/terminusgo/pkg/terminus"
 	"github.com/skaiser/terminusgo/pkg/terminus/style"
 	"github.com/skaiser/terminusgo/pkg/terminus/widget"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -40,8 +41,15 @@ type Message struct {
 	Role      string // "user" or "assistant"
 	Content   string
 	Timestamp time.Time
+	Streaming bool // Still receiving text; rendered with a typing cursor
 }
 
+// typingCursor is shown after a response that is still streaming
+const typingCursor = "▌"
+
+// replyStreamID identifies Gemini response streams
+const replyStreamID = "gemini-reply"
+
 // GeminiChatModel represents the application state
 type GeminiChatModel struct {
 	messages      []Message
@@ -53,8 +61,11 @@ type GeminiChatModel struct {
 	isWaiting     bool
 	error         string
 	showTimestamp bool
-	scrollOffset  int
+	transcript    *widget.Pager   // Scrollback of the rendered messages
+	find          *widget.FindBar // Ctrl+F search of the transcript
 	viewHeight    int
+	retryStatus   string
+	location      *time.Location // The user's time zone
 }
 
 // GeminiChatComponent is the main component
@@ -74,6 +85,14 @@ func NewGeminiChatComponent() *GeminiChatComponent {
 	// Set a reasonable width for the input
 	input.SetSize(80, 1)
 
+	transcript := widget.NewPager().
+		SetShowStatus(false).
+		SetHighlightStyle(style.New().Background(style.Yellow).Foreground(style.Black))
+	transcript.SetSize(120, 20)
+	find := widget.NewFindBar(transcript).SetPrompt("Search: ")
+	find.SetSize(120, 1)
+	find.Blur()
+
 	return &GeminiChatComponent{
 		model: GeminiChatModel{
 			messages:      []Message{},
@@ -82,8 +101,10 @@ func NewGeminiChatComponent() *GeminiChatComponent {
 			isConnected:   false,
 			isWaiting:     false,
 			showTimestamp: true,
-			scrollOffset:  0,
+			transcript:    transcript,
+			find:          find,
 			viewHeight:    20, // Default view height
+			location:      time.Local,
 		},
 	}
 }
@@ -125,24 +146,40 @@ func (g *GeminiChatComponent) connectToGemini() terminus.Cmd {
 func (g *GeminiChatComponent) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 	switch msg := msg.(type) {
 	case terminus.KeyMsg:
+		// While searching, keys edit the search and step through matches
+		if g.model.find.Focused() && msg.Type != terminus.KeyCtrlC {
+			g.model.find.Update(msg)
+			return g, nil
+		}
+
 		switch msg.Type {
 		case terminus.KeyEnter:
 			if g.model.input.Value() != "" && !g.model.isWaiting {
 				userMessage := g.model.input.Value()
 				g.model.input.SetValue("")
 
+				// Sending ends the search so the reply can be followed
+				g.model.find.Clear()
+
 				// Add user message
 				g.addMessage("user", userMessage)
 
-				// Send to Gemini
+				// Stream the reply from Gemini into a placeholder message
 				if g.model.isConnected {
 					g.model.isWaiting = true
-					return g, g.sendToGemini(userMessage)
+					g.model.retryStatus = ""
+					g.addMessage("assistant", "")
+					g.model.messages[len(g.model.messages)-1].Streaming = true
+					return g, terminus.Retry(g.streamFromGemini(userMessage), geminiRetryPolicy())
 				}
 			}
 			return g, nil
 
 		case terminus.KeyEsc:
+			if g.model.find.Query() != "" {
+				g.model.find.Clear()
+				return g, nil
+			}
 			return g, terminus.Quit
 
 		case terminus.KeyCtrlC:
@@ -150,46 +187,45 @@ func (g *GeminiChatComponent) Update(msg terminus.Msg) (terminus.Component, term
 			
 		case terminus.KeyUp:
 			// Scroll up
-			if g.model.scrollOffset > 0 {
-				g.model.scrollOffset--
-			}
+			g.scrollBy(-1)
 			return g, nil
 			
 		case terminus.KeyDown:
 			// Scroll down
-			g.model.scrollOffset++
+			g.scrollBy(1)
 			return g, nil
 			
 		case terminus.KeyPgUp:
 			// Page up
-			g.model.scrollOffset -= g.model.viewHeight - 1
-			if g.model.scrollOffset < 0 {
-				g.model.scrollOffset = 0
-			}
+			g.scrollBy(-(g.model.viewHeight - 1))
 			return g, nil
 			
 		case terminus.KeyPgDown:
 			// Page down
-			g.model.scrollOffset += g.model.viewHeight - 1
+			g.scrollBy(g.model.viewHeight - 1)
 			return g, nil
 			
 		case terminus.KeyHome:
 			// Go to top
-			g.model.scrollOffset = 0
+			g.model.transcript.GotoLine(1)
 			return g, nil
 			
 		case terminus.KeyEnd:
-			// Go to bottom
-			g.model.scrollOffset = 999999 // Will be adjusted in renderMessages
+			// Go to bottom, where new messages are followed
+			g.scrollToEnd()
 			return g, nil
 		}
 
 		// Check for manual clear/timestamp toggle
 		if msg.Type == terminus.KeyRunes && len(msg.Runes) > 0 {
-			if msg.Ctrl && msg.Runes[0] == 'l' {
+			if msg.Ctrl && msg.Runes[0] == 'f' {
+				// Search the transcript, starting from the last search
+				g.model.find.Focus()
+				return g, nil
+			} else if msg.Ctrl && msg.Runes[0] == 'l' {
 				// Clear chat
 				g.model.messages = []Message{}
-				g.model.scrollOffset = 0
+				g.model.find.Clear()
 				if g.model.chat != nil {
 					// Recreate chat to clear history
 					return g, g.connectToGemini()
@@ -226,17 +262,56 @@ func (g *GeminiChatComponent) Update(msg terminus.Msg) (terminus.Component, term
 		g.addSystemMessage("Connected to Gemini. Start chatting!")
 		return g, nil
 
-	case GeminiResponseMsg:
+	case terminus.RetryAttemptMsg:
+		g.model.retryStatus = msg.String()
+		if reply := g.streamingReply(); reply != nil {
+			reply.Content = ""
+		}
+		return g, nil
+
+	case terminus.TextChunkMsg:
+		if reply := g.streamingReply(); reply != nil && msg.ID == replyStreamID {
+			reply.Content = msg.Text
+			g.model.retryStatus = ""
+		}
+		return g, nil
+
+	case terminus.TextDoneMsg:
+		if msg.ID != replyStreamID {
+			return g, nil
+		}
 		g.model.isWaiting = false
-		g.addMessage("assistant", msg.Response)
+		g.model.retryStatus = ""
+		reply := g.streamingReply()
+		if reply == nil {
+			return g, nil
+		}
+		reply.Streaming = false
+		reply.Content = msg.Text
+
+		if msg.Err != nil {
+			if reply.Content == "" {
+				g.model.messages = g.model.messages[:len(g.model.messages)-1]
+			}
+			g.model.error = msg.Err.Error()
+			g.addSystemMessage(fmt.Sprintf("Error: %v", msg.Err))
+		} else if reply.Content == "" {
+			reply.Content = "No response from Gemini (empty response)"
+		}
 		return g, nil
 
 	case GeminiErrorMsg:
 		g.model.isWaiting = false
+		g.model.retryStatus = ""
 		g.model.error = msg.Error.Error()
 		g.addSystemMessage(fmt.Sprintf("Error: %v", msg.Error))
 		return g, nil
 
+	case terminus.EnvironmentMsg:
+		// Show timestamps in the user's local time
+		g.model.location = msg.Location()
+		return g, nil
+
 	case terminus.WindowSizeMsg:
 		// Window resize handled automatically by terminal
 		return g, nil
@@ -257,6 +332,10 @@ func (g *GeminiChatComponent) View() string {
 	var status string
 	if g.model.error != "" {
 		status = style.New().Foreground(style.Red).Render("❌ " + g.model.error)
+	} else if g.model.isWaiting && g.model.retryStatus != "" {
+		status = style.New().Foreground(style.Yellow).Render("⏳ " + g.model.retryStatus)
+	} else if reply := g.streamingReply(); reply != nil && reply.Content != "" {
+		status = style.New().Foreground(style.Cyan).Render("✎ Gemini is typing...")
 	} else if g.model.isWaiting {
 		status = style.New().Foreground(style.Yellow).Render("⏳ Waiting for response...")
 	} else if g.model.isConnected {
@@ -276,7 +355,11 @@ func (g *GeminiChatComponent) View() string {
 
 	// Help text
 	help := style.New().Faint(true).Render(
-		"Enter: send | Ctrl+L: clear | Ctrl+T: toggle timestamps | Ctrl+C: quit")
+		"Enter: send | Ctrl+F: search | Ctrl+L: clear | Ctrl+T: toggle timestamps | Ctrl+C: quit")
+	if g.model.find.Focused() || g.model.find.Query() != "" {
+		help = g.model.find.View() + style.New().Faint(true).Render(
+			"  ↑/↓: previous/next | Enter: done | Esc: clear")
+	}
 
 	// Input section with prompt
 	inputSection := fmt.Sprintf("%s %s", 
@@ -304,38 +387,57 @@ func (g *GeminiChatComponent) View() string {
 	)
 }
 
-// sendToGemini sends a message to Gemini and returns the response
-func (g *GeminiChatComponent) sendToGemini(message string) terminus.Cmd {
-	return func() terminus.Msg {
-		if g.model.chat == nil {
-			return GeminiErrorMsg{Error: fmt.Errorf("not connected to Gemini")}
+// streamFromGemini sends a message to Gemini and streams the reply as it is
+// generated
+func (g *GeminiChatComponent) streamFromGemini(message string) terminus.Cmd {
+	chat := g.model.chat
+	return terminus.StreamText(replyStreamID, func(ctx context.Context, emit func(string)) error {
+		if chat == nil {
+			return fmt.Errorf("not connected to Gemini")
 		}
 
-		ctx := context.Background()
-		resp, err := g.model.chat.SendMessage(ctx, genai.Text(message))
-		if err != nil {
-			return GeminiErrorMsg{Error: err}
-		}
-
-		if len(resp.Candidates) == 0 {
-			return GeminiResponseMsg{Response: "No response from Gemini (no candidates)"}
-		}
-
-		var response string
-		for _, part := range resp.Candidates[0].Content.Parts {
-			if textPart, ok := part.(genai.Text); ok {
-				response += string(textPart)
-			} else {
-				response += fmt.Sprintf("[%T]", part)
+		iter := chat.SendMessageStream(ctx, genai.Text(message))
+		for {
+			resp, err := iter.Next()
+			if err == iterator.Done {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+				continue
+			}
+			for _, part := range resp.Candidates[0].Content.Parts {
+				if textPart, ok := part.(genai.Text); ok {
+					emit(string(textPart))
+				} else {
+					emit(fmt.Sprintf("[%T]", part))
+				}
 			}
 		}
+	})
+}
 
-		if response == "" {
-			response = "No response from Gemini (empty response)"
-		}
+// streamingReply returns the assistant message currently being streamed, if any
+func (g *GeminiChatComponent) streamingReply() *Message {
+	if n := len(g.model.messages); n > 0 && g.model.messages[n-1].Streaming {
+		return &g.model.messages[n-1]
+	}
+	return nil
+}
 
-		return GeminiResponseMsg{Response: response}
+// geminiRetryPolicy retries failed Gemini calls with exponential backoff
+func geminiRetryPolicy() terminus.RetryPolicy {
+	policy := terminus.DefaultRetryPolicy()
+	policy.MaxAttempts = 4
+	policy.InitialDelay = 500 * time.Millisecond
+	policy.ShouldRetry = func(msg terminus.Msg) bool {
+		// Only retry if nothing was streamed yet, so partial replies aren't repeated
+		done, ok := msg.(terminus.TextDoneMsg)
+		return ok && done.Err != nil && done.Text == ""
 	}
+	return policy
 }
 
 // renderMessages renders all messages with scrolling
@@ -357,28 +459,13 @@ func (g *GeminiChatComponent) renderMessages() string {
 		allLines = allLines[:len(allLines)-1]
 	}
 	
-	// Adjust scroll offset
-	maxOffset := len(allLines) - g.model.viewHeight
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	if g.model.scrollOffset > maxOffset {
-		g.model.scrollOffset = maxOffset
-	}
-	if g.model.scrollOffset < 0 {
-		g.model.scrollOffset = 0
-	}
-	
-	// Get visible lines
-	start := g.model.scrollOffset
-	end := start + g.model.viewHeight
-	if end > len(allLines) {
-		end = len(allLines)
-	}
-	
-	var result []string
-	for i := start; i < end; i++ {
-		result = append(result, allLines[i])
+	// The transcript keeps its place, and the current match, as lines are
+	// added, and follows new lines while scrolled to the bottom
+	g.model.transcript.SetSize(120, g.model.viewHeight)
+	g.model.transcript.ReplaceLines(allLines)
+	result := strings.Split(g.model.transcript.View(), "\n")
+	if len(result) > len(allLines) {
+		result = result[:len(allLines)] // Drop the pager's filler
 	}
 	
 	// Pad to view height
@@ -387,10 +474,10 @@ func (g *GeminiChatComponent) renderMessages() string {
 	}
 	
 	// Add scroll indicators
-	if g.model.scrollOffset > 0 {
+	if g.model.transcript.Line() > 1 {
 		result[0] = result[0] + style.New().Faint(true).Render(" ↑ scroll up")
 	}
-	if g.model.scrollOffset < maxOffset {
+	if g.model.transcript.Percent() < 100 {
 		lastIdx := len(result) - 1
 		result[lastIdx] = result[lastIdx] + style.New().Faint(true).Render(" ↓ scroll down")
 	}
@@ -420,7 +507,7 @@ func (g *GeminiChatComponent) formatMessage(msg Message) []string {
 	
 	header := style.New().Foreground(roleColor).Bold(true).Render(rolePrefix + ":")
 	if g.model.showTimestamp {
-		timestamp := style.New().Faint(true).Render(msg.Timestamp.Format(" [15:04:05]"))
+		timestamp := style.New().Faint(true).Render(msg.Timestamp.In(g.model.location).Format(" [15:04:05]"))
 		header += timestamp
 	}
 	
@@ -428,6 +515,14 @@ func (g *GeminiChatComponent) formatMessage(msg Message) []string {
 	
 	// Wrap and indent content
 	contentLines := wrapText(msg.Content, 100)
+	if msg.Streaming {
+		cursor := style.New().Foreground(style.Blue).Render(typingCursor)
+		if msg.Content == "" {
+			contentLines = []string{cursor}
+		} else {
+			contentLines[len(contentLines)-1] += cursor
+		}
+	}
 	for _, line := range contentLines {
 		lines = append(lines, "  " + line)
 	}
@@ -442,8 +537,10 @@ func (g *GeminiChatComponent) addMessage(role, content string) {
 		Content:   content,
 		Timestamp: time.Now(),
 	})
-	// Auto-scroll to bottom on new message
-	g.model.scrollOffset = 999999
+	// Auto-scroll to bottom on new message, unless searching
+	if g.model.find.Query() == "" {
+		g.scrollToEnd()
+	}
 }
 
 // addSystemMessage adds a system message to the chat
@@ -453,10 +550,22 @@ func (g *GeminiChatComponent) addSystemMessage(content string) {
 		Content:   content,
 		Timestamp: time.Now(),
 	})
-	// Auto-scroll to bottom on new message
-	g.model.scrollOffset = 999999
+	// Auto-scroll to bottom on new message, unless searching
+	if g.model.find.Query() == "" {
+		g.scrollToEnd()
+	}
 }
 
+// scrollBy scrolls the transcript by delta lines
+func (g *GeminiChatComponent) scrollBy(delta int) {
+	g.model.transcript.GotoLine(g.model.transcript.Line() + delta)
+}
+
+// scrollToEnd scrolls the transcript to the bottom, so that it follows new
+// lines
+func (g *GeminiChatComponent) scrollToEnd() {
+	g.model.transcript.GotoLine(g.model.transcript.LineCount())
+}
 
 // wrapText wraps text to specified width while preserving newlines
 func wrapText(text string, width int) []string {
@@ -506,10 +615,6 @@ type GeminiConnectedMsg struct {
 	Chat   *genai.ChatSession
 }
 
-type GeminiResponseMsg struct {
-	Response string
-}
-
 type GeminiErrorMsg struct {
 	Error error
 }
@@ -106,8 +106,8 @@ func (h *HelloComponent) Update(msg terminus.Msg) (terminus.Component, terminus.
 		// Handle specific key strings
 		switch msg.String() {
 		case "q", "ctrl+c":
-			// Quit the application
-			return h, terminus.Quit
+			// Quit the application, leaving a farewell on screen
+			return h, terminus.QuitWithMessage("Goodbye! Thanks for saying hello.")
 		case "r":
 			// Reset the application
 			if !h.model.collectingName {
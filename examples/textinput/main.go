@@ -104,10 +104,11 @@ func NewTextInputExample() *TextInputExample {
 			return nil
 		})
 
-	// Set sizes for all inputs
-	ex.nameInput.SetSize(40, 1)
-	ex.emailInput.SetSize(40, 1)
-	ex.phoneInput.SetSize(40, 1)
+	// The inputs fill the width of the window, up to 40 cells; the
+	// container sizes them when the window's size arrives
+	ex.nameInput.SetMaxSize(40, 1)
+	ex.emailInput.SetMaxSize(40, 1)
+	ex.phoneInput.SetMaxSize(40, 1)
 
 	// Add inputs to container
 	ex.container.AddChild(ex.nameInput)
@@ -123,6 +124,9 @@ func (ex *TextInputExample) Init() terminus.Cmd {
 
 func (ex *TextInputExample) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 	switch msg := msg.(type) {
+	case terminus.WindowSizeMsg:
+		ex.container.SetSize(msg.Width, msg.Height)
+
 	case terminus.KeyMsg:
 		switch msg.Type {
 		case terminus.KeyCtrlC:
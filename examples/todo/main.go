@@ -16,6 +16,8 @@ package main
 
 import (
 	"embed"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -26,12 +28,16 @@ import (
 
 	"github.com/skaiser/terminusgo/pkg/terminus"
 	"github.com/skaiser/terminusgo/pkg/terminus/layout"
+	"github.com/skaiser/terminusgo/pkg/terminus/storage"
 	"github.com/skaiser/terminusgo/pkg/terminus/widget"
 )
 
 //go:embed all:static/*
 var staticFiles embed.FS
 
+// todosKey is the key the todos are stored under
+const todosKey = "todos"
+
 // TodoItem represents a single todo item
 type TodoItem struct {
 	ID          int
@@ -41,22 +47,14 @@ type TodoItem struct {
 	CompletedAt *time.Time
 }
 
-// Render implements widget.ListItem interface
+// Render implements widget.ListItem interface. The list draws the
+// checkbox, so only the text is rendered here.
 func (t *TodoItem) Render() string {
-	checkbox := "[ ]"
 	textStyle := terminus.NewStyle()
-
 	if t.Completed {
-		checkbox = "[✓]"
-		textStyle = textStyle.Faint(true)
-	}
-
-	checkboxStyle := terminus.NewStyle().Foreground(terminus.Green)
-	if !t.Completed {
-		checkboxStyle = terminus.NewStyle().Foreground(terminus.Yellow)
+		textStyle = textStyle.Faint(true).CrossOut(true)
 	}
-
-	return checkboxStyle.Render(checkbox) + " " + textStyle.Render(t.Text)
+	return textStyle.Render(t.Text)
 }
 
 // String implements widget.ListItem interface
@@ -64,6 +62,12 @@ func (t *TodoItem) String() string {
 	return t.Text
 }
 
+// todoDocument is the stored form of the todos
+type todoDocument struct {
+	NextID int
+	Todos  []TodoItem
+}
+
 // FilterMode represents different filtering options
 type FilterMode int
 
@@ -84,15 +88,21 @@ type TodoModel struct {
 // TodoComponent is the main todo list component
 type TodoComponent struct {
 	model        TodoModel
+	store        storage.Storage
 	todoList     *widget.List
 	textInput    *widget.TextInput
 	focusManager *widget.FocusManager
+	undo         *terminus.UndoStack
+	history      *widget.UndoHistory
+	showHistory  bool   // Whether the history is shown in place of the list
+	status       string // Outcome of the last backup or restore
 	width        int
 	height       int
 }
 
-// NewTodoComponent creates a new todo component
-func NewTodoComponent() *TodoComponent {
+// NewTodoComponent creates a new todo component that keeps its todos in
+// store
+func NewTodoComponent(store storage.Storage) *TodoComponent {
 	// Create widgets
 	todoList := widget.NewList().
 		SetShowCursor(true).
@@ -100,6 +110,9 @@ func NewTodoComponent() *TodoComponent {
 		SetSelectedChar("  ").
 		SetUnselectedChar("  ").
 		SetWrap(true).
+		SetReorderable(true).
+		SetMultiSelect(true).
+		SetCheckChars("[✓] ", "[ ] ").
 		SetCursorStyle(terminus.NewStyle().Foreground(terminus.Cyan)).
 		SetSelectedStyle(terminus.NewStyle().Background(terminus.ANSI256(237)))
 	todoList.SetSize(60, 15)
@@ -114,6 +127,12 @@ func NewTodoComponent() *TodoComponent {
 	// Create focus manager
 	focusManager := widget.NewFocusManager(textInput, todoList)
 
+	// Changes to the todos can be undone, and their history browsed
+	undo := terminus.NewUndoStack(0)
+	history := widget.NewUndoHistory(undo).SetStartLabel("Opened")
+	history.SetSize(60, 15)
+	history.Focus()
+
 	component := &TodoComponent{
 		model: TodoModel{
 			todos:      make([]*TodoItem, 0),
@@ -121,9 +140,12 @@ func NewTodoComponent() *TodoComponent {
 			filterMode: FilterAll,
 			focusIndex: 0,
 		},
+		store:        store,
 		todoList:     todoList,
 		textInput:    textInput,
 		focusManager: focusManager,
+		undo:         undo,
+		history:      history,
 		width:        80,
 		height:       24,
 	}
@@ -131,28 +153,44 @@ func NewTodoComponent() *TodoComponent {
 	// Set up event handlers
 	textInput.SetOnSubmit(func(value string) terminus.Cmd {
 		if strings.TrimSpace(value) != "" {
-			component.addTodo(value)
 			textInput.Clear()
-			component.updateList()
+			return component.record("Add "+value, func() {
+				component.addTodo(value)
+			})
 		}
 		return nil
 	})
 
 	todoList.SetOnSelect(func(index int, item widget.ListItem) terminus.Cmd {
 		if todoItem, ok := item.(*TodoItem); ok {
-			component.toggleTodo(todoItem.ID)
-			component.updateList()
+			return component.record("Toggle "+todoItem.Text, func() {
+				component.toggleTodo(todoItem.ID)
+			})
+		}
+		return nil
+	})
+
+	todoList.SetOnToggle(func(index int, checked bool) terminus.Cmd {
+		if todoItem, ok := todoList.Items()[index].(*TodoItem); ok {
+			return component.record("Toggle "+todoItem.Text, func() {
+				setCompleted(todoItem, checked)
+			})
 		}
 		return nil
 	})
 
-	// Add some sample todos
+	todoList.SetOnReorder(func(from, to int) terminus.Cmd {
+		moved := todoList.Items()[to].(*TodoItem)
+		return component.record("Move "+moved.Text, func() {
+			component.syncOrder(to)
+		})
+	})
+
+	// Add some sample todos, replaced by the stored ones once loaded
 	component.addTodo("Learn TerminusGo widget system")
 	component.addTodo("Build an awesome todo app")
 	component.addTodo("Master the MVU pattern")
-	component.model.todos[0].Completed = true
-	component.model.todos[0].CompletedAt = &time.Time{}
-	*component.model.todos[0].CompletedAt = time.Now()
+	setCompleted(component.model.todos[0], true)
 
 	component.updateList()
 
@@ -175,18 +213,38 @@ func (c *TodoComponent) addTodo(text string) {
 func (c *TodoComponent) toggleTodo(id int) {
 	for _, todo := range c.model.todos {
 		if todo.ID == id {
-			todo.Completed = !todo.Completed
-			if todo.Completed {
-				now := time.Now()
-				todo.CompletedAt = &now
-			} else {
-				todo.CompletedAt = nil
-			}
+			setCompleted(todo, !todo.Completed)
 			break
 		}
 	}
 }
 
+// setCompleted marks a todo as completed or active
+func setCompleted(todo *TodoItem, completed bool) {
+	todo.Completed = completed
+	if completed {
+		now := time.Now()
+		todo.CompletedAt = &now
+	} else {
+		todo.CompletedAt = nil
+	}
+}
+
+// toggleAll checks every visible todo, or unchecks them all if they are
+// already checked, and applies the result to the model
+func (c *TodoComponent) toggleAll() {
+	if c.todoList.AllSelected() {
+		c.todoList.ClearSelection()
+	} else {
+		c.todoList.SelectAll()
+	}
+	for i, item := range c.todoList.Items() {
+		if todoItem, ok := item.(*TodoItem); ok {
+			setCompleted(todoItem, c.todoList.IsChecked(i))
+		}
+	}
+}
+
 // deleteTodo removes a todo by ID
 func (c *TodoComponent) deleteTodo(id int) {
 	filtered := make([]*TodoItem, 0, len(c.model.todos))
@@ -198,6 +256,50 @@ func (c *TodoComponent) deleteTodo(id int) {
 	c.model.todos = filtered
 }
 
+// syncOrder applies a reorder made in the list to the todo model. The list
+// may only show some todos, so the moved todo is placed next to the
+// neighbour it now sits beside.
+func (c *TodoComponent) syncOrder(listIndex int) {
+	items := c.todoList.Items()
+	moved, ok := items[listIndex].(*TodoItem)
+	if !ok {
+		return
+	}
+
+	// Remove the moved todo
+	todos := make([]*TodoItem, 0, len(c.model.todos))
+	for _, todo := range c.model.todos {
+		if todo != moved {
+			todos = append(todos, todo)
+		}
+	}
+
+	// Insert it before the todo that follows it, or after the one before it
+	pos := len(todos)
+	if listIndex+1 < len(items) {
+		next := items[listIndex+1].(*TodoItem)
+		for i, todo := range todos {
+			if todo == next {
+				pos = i
+				break
+			}
+		}
+	} else if listIndex > 0 {
+		prev := items[listIndex-1].(*TodoItem)
+		for i, todo := range todos {
+			if todo == prev {
+				pos = i + 1
+				break
+			}
+		}
+	}
+
+	todos = append(todos, nil)
+	copy(todos[pos+1:], todos[pos:])
+	todos[pos] = moved
+	c.model.todos = todos
+}
+
 // clearCompleted removes all completed todos
 func (c *TodoComponent) clearCompleted() {
 	filtered := make([]*TodoItem, 0, len(c.model.todos))
@@ -231,49 +333,145 @@ func (c *TodoComponent) getFilteredTodos() []widget.ListItem {
 	return items
 }
 
-// updateList updates the list widget with current todos
+// updateList updates the list widget with current todos, checking the
+// completed ones. The selection is kept where it was.
 func (c *TodoComponent) updateList() {
-	c.todoList.SetItems(c.getFilteredTodos())
+	selected := c.todoList.SelectedIndex()
+	items := c.getFilteredTodos()
+	c.todoList.SetItems(items)
+	for i, item := range items {
+		c.todoList.SetChecked(i, item.(*TodoItem).Completed)
+	}
+	c.todoList.SetSelected(selected)
 }
 
-// Init implements terminus.Component
+// document returns a copy of the todos to store or back up, as commands
+// run while the model keeps changing
+func (c *TodoComponent) document() todoDocument {
+	doc := todoDocument{NextID: c.model.nextID, Todos: make([]TodoItem, len(c.model.todos))}
+	for i, todo := range c.model.todos {
+		doc.Todos[i] = *todo
+	}
+	return doc
+}
+
+// save returns a command that stores the todos
+func (c *TodoComponent) save() terminus.Cmd {
+	return storage.Save(c.store, todosKey, c.document())
+}
+
+// record makes change to the todos undoable: the todos before and after it
+// are kept for the undo stack to load. It returns the command saving them.
+func (c *TodoComponent) record(name string, change func()) terminus.Cmd {
+	before := c.document()
+	change()
+	after := c.document()
+	c.updateList()
+	c.undo.Push(terminus.Action{
+		Name: name,
+		Do: func() terminus.Cmd {
+			c.load(after)
+			return c.save()
+		},
+		Undo: func() terminus.Cmd {
+			c.load(before)
+			return c.save()
+		},
+	})
+	return c.save()
+}
+
+// restore replaces the todos with those of an uploaded backup
+func (c *TodoComponent) restore(msg terminus.UploadMsg) terminus.Cmd {
+	var doc todoDocument
+	if err := msg.DecodeJSON(&doc); err != nil {
+		if !errors.Is(err, terminus.ErrUploadCancelled) {
+			c.status = fmt.Sprintf("Couldn't restore %s: %v", msg.Filename, err)
+		}
+		return nil
+	}
+	for _, todo := range doc.Todos {
+		doc.NextID = max(doc.NextID, todo.ID+1)
+	}
+	c.status = fmt.Sprintf("Restored %d todos from %s", len(doc.Todos), msg.Filename)
+	return c.record("Restore "+msg.Filename, func() {
+		c.load(doc)
+	})
+}
+
+// load replaces the todos with copies of the stored ones, so changing them
+// leaves doc as it is
+func (c *TodoComponent) load(doc todoDocument) {
+	c.model.nextID = doc.NextID
+	c.model.todos = make([]*TodoItem, len(doc.Todos))
+	for i, todo := range doc.Todos {
+		c.model.todos[i] = &todo
+	}
+	c.updateList()
+}
+
+// Init implements terminus.Component. It loads the stored todos and
+// follows the changes other sessions make to them.
 func (c *TodoComponent) Init() terminus.Cmd {
-	return nil
+	return terminus.Batch(
+		storage.Load(c.store, todosKey),
+		storage.Subscribe(c.store, todosKey),
+	)
 }
 
 // Update implements terminus.Component
 func (c *TodoComponent) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 	switch msg := msg.(type) {
+	case storage.LoadedMsg:
+		for _, entry := range msg.Entries {
+			var doc todoDocument
+			if entry.Key == todosKey && entry.Decode(&doc) == nil {
+				c.load(doc)
+				return c, nil
+			}
+		}
+		// Nothing stored yet, so keep the samples
+		return c, c.save()
+
+	case storage.ChangeMsg:
+		var doc todoDocument
+		if msg.Key == todosKey && !msg.Deleted && msg.Decode(&doc) == nil {
+			c.load(doc)
+		}
+		return c, nil
+
+	case terminus.UploadMsg:
+		return c, c.restore(msg)
+
+	case terminus.ShortcutMsg:
+		cmd, _ := c.undo.HandleShortcut(msg)
+		return c, cmd
+
+	case terminus.ErrMsg:
+		log.Printf("%s: %v", msg.Source, msg.Err)
+		return c, nil
+
 	case terminus.KeyMsg:
+		// The history takes the keys while shown, until closed
+		if c.showHistory {
+			if msg.Type == terminus.KeyEsc || msg.String() == "h" {
+				c.showHistory = false
+				return c, nil
+			}
+			_, cmd := c.history.Update(msg)
+			return c, cmd
+		}
+
 		// Check for global shortcuts first
 		switch msg.String() {
 		case "ctrl+c", "ctrl+q":
 			return c, terminus.Quit
 		case "ctrl+a":
-			// Toggle all todos
-			allCompleted := true
-			for _, todo := range c.model.todos {
-				if !todo.Completed {
-					allCompleted = false
-					break
-				}
-			}
-			for _, todo := range c.model.todos {
-				todo.Completed = !allCompleted
-				if todo.Completed {
-					now := time.Now()
-					todo.CompletedAt = &now
-				} else {
-					todo.CompletedAt = nil
-				}
-			}
-			c.updateList()
-			return c, nil
+			// Toggle all visible todos
+			return c, c.record("Toggle all", c.toggleAll)
 		case "ctrl+k":
 			// Clear completed todos
-			c.clearCompleted()
-			c.updateList()
-			return c, nil
+			return c, c.record("Clear completed", c.clearCompleted)
 		case "1":
 			// Show all todos
 			c.model.filterMode = FilterAll
@@ -305,13 +503,26 @@ func (c *TodoComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 			if msg.Type == terminus.KeyDelete || msg.String() == "d" {
 				if item := c.todoList.SelectedItem(); item != nil {
 					if todoItem, ok := item.(*TodoItem); ok {
-						c.deleteTodo(todoItem.ID)
-						c.updateList()
+						return c, c.record("Delete "+todoItem.Text, func() {
+							c.deleteTodo(todoItem.ID)
+						})
 					}
 				}
 				return c, nil
 			}
 
+			// Back up the todos to a file, or restore them from one
+			switch msg.String() {
+			case "e":
+				c.status = "Downloaded todos-backup.json"
+				return c, terminus.DownloadJSON("todos-backup.json", c.document())
+			case "i":
+				return c, terminus.UploadJSON()
+			case "h":
+				c.showHistory = true
+				return c, nil
+			}
+
 			_, cmd := c.todoList.Update(msg)
 			return c, cmd
 		}
@@ -320,11 +531,12 @@ func (c *TodoComponent) Update(msg terminus.Msg) (terminus.Component, terminus.C
 		c.width = msg.Width
 		c.height = msg.Height
 		// Update widget sizes
-		listHeight := c.height - 12 // Leave room for header, input, and footer
+		listHeight := c.height - 14 // Leave room for header, input, and footer
 		if listHeight < 5 {
 			listHeight = 5
 		}
 		c.todoList.SetSize(c.width-20, listHeight)
+		c.history.SetSize(c.width-20, listHeight)
 		c.textInput.SetSize(c.width-20, 1)
 		return c, nil
 	}
@@ -374,6 +586,9 @@ func (c *TodoComponent) View() string {
 
 	// Todo list
 	listView := c.todoList.View()
+	if c.showHistory {
+		listView = c.history.View()
+	}
 	view.WriteString(layout.Margin(listView, 0, 10, 1, 10))
 	view.WriteString("\n")
 
@@ -395,11 +610,14 @@ func (c *TodoComponent) View() string {
 		statsStyle.Render("Completed"), completedCount)
 	view.WriteString(layout.Center(stats, c.width, 1))
 	view.WriteString("\n")
+	view.WriteString(layout.Center(footerStyle.Render(c.status), c.width, 1))
+	view.WriteString("\n")
 
 	// Instructions
 	instructions := []string{
-		"Tab: Switch focus | Enter: Add/Toggle todo | Delete/d: Remove todo",
-		"Ctrl+A: Toggle all | Ctrl+K: Clear completed | Ctrl+C: Quit",
+		"Tab: Switch focus | Enter/Space: Add/Toggle todo | Delete/d: Remove todo",
+		"Ctrl+↑/↓: Reorder | Ctrl+A: Toggle all | Ctrl+K: Clear completed | Ctrl+C: Quit",
+		"e: Download a backup | i: Restore a backup | h: History | Ctrl+Z/Ctrl+Shift+Z: Undo/Redo",
 	}
 	for _, instruction := range instructions {
 		view.WriteString(layout.Center(footerStyle.Render(instruction), c.width, 1))
@@ -410,12 +628,22 @@ func (c *TodoComponent) View() string {
 }
 
 func main() {
+	dataFile := flag.String("data", "todos.json", "file the todos are kept in")
+	flag.Parse()
+
+	// Every session shares the stored todos
+	store, err := storage.NewFileStorage(*dataFile)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *dataFile, err)
+	}
+
 	// Create and configure the TerminusGo program
 	program := terminus.NewProgram(
 		func() terminus.Component {
-			return NewTodoComponent()
+			return NewTodoComponent(store)
 		},
 		terminus.WithStaticFiles(staticFiles, "static"),
+		terminus.WithShortcuts(terminus.UndoShortcuts...),
 		terminus.WithAddress(":8890"),
 	)
 
@@ -157,6 +157,7 @@ func NewWidgetShowcase() *WidgetShowcase {
 		SetShowHeader(true).
 		SetShowRowNumbers(true).
 		SetCellSelection(true).
+		SetShowPosition(true).
 		SetStyle(terminus.NewStyle()).
 		SetHeaderStyle(terminus.NewStyle().Bold(true).Foreground(terminus.Cyan)).
 		SetSelectedStyle(terminus.NewStyle().Reverse(true)).
@@ -169,14 +170,14 @@ func NewWidgetShowcase() *WidgetShowcase {
 			return nil
 		})
 
-	// Adjust column widths
+	// Size columns to their content
 	columns := []widget.TableColumn{
-		{Title: "ID", Width: 5, Align: widget.AlignCenter, Sortable: true},
-		{Title: "Name", Width: 15, Align: widget.AlignLeft, Sortable: true},
-		{Title: "Price", Width: 10, Align: widget.AlignRight, Sortable: true},
-		{Title: "Stock", Width: 8, Align: widget.AlignCenter, Sortable: true},
+		{Title: "ID", MinWidth: 5, Align: widget.AlignCenter, Sortable: true},
+		{Title: "Name", MaxWidth: 20, Align: widget.AlignLeft, Sortable: true},
+		{Title: "Price", MinWidth: 10, Align: widget.AlignRight, Sortable: true},
+		{Title: "Stock", MinWidth: 8, Align: widget.AlignCenter, Sortable: true},
 	}
-	showcase.table.SetColumns(columns)
+	showcase.table.SetColumns(columns).AutoSizeColumns(50)
 
 	// Initialize Spinner
 	showcase.spinner = widget.NewSpinner().
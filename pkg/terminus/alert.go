@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"time"
+)
+
+// Severity is how urgent an alert is
+type Severity int
+
+const (
+	// SeverityInfo is worth knowing about
+	SeverityInfo Severity = iota
+	// SeverityWarning needs attention soon
+	SeverityWarning
+	// SeverityCritical needs attention now
+	SeverityCritical
+)
+
+// String returns the severity's name
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "info"
+}
+
+// AlertMsg is sent when an alert rule starts firing, and again with Firing
+// false when it resolves
+type AlertMsg struct {
+	Rule     string
+	Metric   string
+	Severity Severity
+	Firing   bool
+	Value    float64 // The metric's value when the alert fired or resolved
+	Time     time.Time
+	Message  string
+}
+
+// Notify returns a command raising a desktop notification for the alert.
+// Notifications for a rule replace the rule's earlier ones.
+func (a AlertMsg) Notify() Cmd {
+	title := fmt.Sprintf("[%s] %s", a.Severity, a.Rule)
+	if !a.Firing {
+		title = "Resolved: " + a.Rule
+	}
+	return DesktopNotify(title, a.Message, NotifyOptions{Tag: "alert:" + a.Rule})
+}
+
+// Sample is a metric's value at a point in time
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// conditionKind is what an AlertCondition compares
+type conditionKind int
+
+const (
+	conditionAbove conditionKind = iota
+	conditionBelow
+	conditionRateAbove
+	conditionRateBelow
+)
+
+// AlertCondition is the test an alert rule applies to a metric. Create one
+// with Above, Below, RateAbove or RateBelow.
+type AlertCondition struct {
+	kind      conditionKind
+	threshold float64
+	window    time.Duration // Span the rate is measured over
+}
+
+// Above holds while the metric is above threshold
+func Above(threshold float64) AlertCondition {
+	return AlertCondition{kind: conditionAbove, threshold: threshold}
+}
+
+// Below holds while the metric is below threshold
+func Below(threshold float64) AlertCondition {
+	return AlertCondition{kind: conditionBelow, threshold: threshold}
+}
+
+// RateAbove holds while the metric rises faster than perSecond, measured
+// across the samples of the last window
+func RateAbove(perSecond float64, window time.Duration) AlertCondition {
+	return AlertCondition{kind: conditionRateAbove, threshold: perSecond, window: window}
+}
+
+// RateBelow holds while the metric changes slower than perSecond, e.g. a
+// negative rate for a metric that falls fast, measured across the samples
+// of the last window
+func RateBelow(perSecond float64, window time.Duration) AlertCondition {
+	return AlertCondition{kind: conditionRateBelow, threshold: perSecond, window: window}
+}
+
+// holds reports whether the condition holds for samples, oldest first, and
+// the value it compared
+func (c AlertCondition) holds(samples []Sample) (bool, float64) {
+	last := samples[len(samples)-1]
+	switch c.kind {
+	case conditionAbove:
+		return last.Value > c.threshold, last.Value
+	case conditionBelow:
+		return last.Value < c.threshold, last.Value
+	}
+
+	// Rates are measured from the oldest sample within the window
+	first := last
+	for i := len(samples) - 1; i >= 0 && last.Time.Sub(samples[i].Time) <= c.window; i-- {
+		first = samples[i]
+	}
+	elapsed := last.Time.Sub(first.Time).Seconds()
+	if elapsed <= 0 {
+		return false, 0
+	}
+	rate := (last.Value - first.Value) / elapsed
+	if c.kind == conditionRateAbove {
+		return rate > c.threshold, rate
+	}
+	return rate < c.threshold, rate
+}
+
+// describe describes the condition holding for metric at value
+func (c AlertCondition) describe(metric string, value float64) string {
+	switch c.kind {
+	case conditionAbove:
+		return fmt.Sprintf("%s is %g, above %g", metric, value, c.threshold)
+	case conditionBelow:
+		return fmt.Sprintf("%s is %g, below %g", metric, value, c.threshold)
+	case conditionRateAbove:
+		return fmt.Sprintf("%s is changing by %.3g/s, faster than %g/s", metric, value, c.threshold)
+	}
+	return fmt.Sprintf("%s is changing by %.3g/s, slower than %g/s", metric, value, c.threshold)
+}
+
+// AlertRule fires an alert when its condition has held for a metric for a
+// while
+type AlertRule struct {
+	Name      string // Identifies the alert
+	Metric    string
+	Condition AlertCondition
+	// For is how long the condition must hold before the alert fires; 0
+	// fires at once
+	For      time.Duration
+	Severity Severity
+	// Message describes the alert; by default it is made from the
+	// condition
+	Message string
+}
+
+// alertState is the state of a rule
+type alertState struct {
+	since  time.Time // When the condition started holding, zero while it doesn't
+	firing bool
+	last   AlertMsg
+}
+
+// Alerts evaluates alert rules against metrics as their values arrive.
+// Keep one in a component and record metrics in Update; it isn't safe for
+// concurrent use.
+//
+//	case statsMsg:
+//		return m, m.alerts.Record("cpu", msg.CPU)
+//
+// An AlertMsg is sent when a rule starts firing and when it resolves.
+type Alerts struct {
+	rules   []AlertRule
+	states  []alertState // Parallel to rules
+	samples map[string][]Sample
+	now     func() time.Time
+}
+
+// NewAlerts creates an evaluator for rules
+func NewAlerts(rules ...AlertRule) *Alerts {
+	return &Alerts{
+		rules:   rules,
+		states:  make([]alertState, len(rules)),
+		samples: make(map[string][]Sample),
+		now:     time.Now,
+	}
+}
+
+// Record records the current value of a metric and returns a command
+// sending an AlertMsg for each rule it fires or resolves, or nil
+func (a *Alerts) Record(metric string, value float64) Cmd {
+	var cmds []Cmd
+	for _, msg := range a.Observe(metric, value, a.now()) {
+		cmds = append(cmds, func() Msg { return msg })
+	}
+	return Batch(cmds...)
+}
+
+// Observe records the value of a metric at t, which must not be before
+// the metric's previous values, and returns the alerts it fires or
+// resolves
+func (a *Alerts) Observe(metric string, value float64, t time.Time) []AlertMsg {
+	samples := append(a.samples[metric], Sample{Time: t, Value: value})
+
+	// Keep the samples the metric's rate conditions look back on
+	var window time.Duration
+	for _, rule := range a.rules {
+		if rule.Metric == metric {
+			window = max(window, rule.Condition.window)
+		}
+	}
+	keep := len(samples) - 1
+	for keep > 0 && t.Sub(samples[keep-1].Time) <= window {
+		keep--
+	}
+	samples = append(samples[:0], samples[keep:]...)
+	a.samples[metric] = samples
+
+	var msgs []AlertMsg
+	for i, rule := range a.rules {
+		if rule.Metric != metric {
+			continue
+		}
+		state := &a.states[i]
+		holds, measured := rule.Condition.holds(samples)
+		switch {
+		case holds && state.since.IsZero():
+			state.since = t
+		case !holds:
+			state.since = time.Time{}
+		}
+
+		firing := holds && t.Sub(state.since) >= rule.For
+		if firing == state.firing {
+			continue
+		}
+		state.firing = firing
+		msg := AlertMsg{
+			Rule:     rule.Name,
+			Metric:   metric,
+			Severity: rule.Severity,
+			Firing:   firing,
+			Value:    value,
+			Time:     t,
+			Message:  rule.Message,
+		}
+		if msg.Message == "" {
+			msg.Message = rule.Condition.describe(metric, measured)
+			if !firing {
+				msg.Message = fmt.Sprintf("%s is back to %g", metric, value)
+			}
+		}
+		state.last = msg
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Firing returns the alerts that are firing, in the order of their rules
+func (a *Alerts) Firing() []AlertMsg {
+	var firing []AlertMsg
+	for _, state := range a.states {
+		if state.firing {
+			firing = append(firing, state.last)
+		}
+	}
+	return firing
+}
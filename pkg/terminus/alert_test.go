@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlerts(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	// at returns the time s seconds after start
+	at := func(s int) time.Time {
+		return start.Add(time.Duration(s) * time.Second)
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Fires and resolves on a threshold",
+			test: func(t *testing.T) {
+				alerts := NewAlerts(AlertRule{Name: "cpu-high", Metric: "cpu", Condition: Above(90), Severity: SeverityCritical})
+				if msgs := alerts.Observe("cpu", 50, at(0)); len(msgs) != 0 {
+					t.Errorf("Expected no alert, got %v", msgs)
+				}
+				msgs := alerts.Observe("cpu", 95, at(1))
+				if len(msgs) != 1 || !msgs[0].Firing || msgs[0].Severity != SeverityCritical || msgs[0].Value != 95 {
+					t.Fatalf("Expected the alert to fire, got %v", msgs)
+				}
+				if msgs[0].Message != "cpu is 95, above 90" {
+					t.Errorf("Expected a description, got %q", msgs[0].Message)
+				}
+				if msgs := alerts.Observe("cpu", 96, at(2)); len(msgs) != 0 || len(alerts.Firing()) != 1 {
+					t.Errorf("Expected the alert to keep firing quietly, got %v", msgs)
+				}
+				msgs = alerts.Observe("cpu", 40, at(3))
+				if len(msgs) != 1 || msgs[0].Firing || len(alerts.Firing()) != 0 {
+					t.Errorf("Expected the alert to resolve, got %v", msgs)
+				}
+			},
+		},
+		{
+			name: "Waits for a condition to be sustained",
+			test: func(t *testing.T) {
+				alerts := NewAlerts(AlertRule{Name: "disk-low", Metric: "disk", Condition: Below(10), For: 30 * time.Second})
+				alerts.Observe("disk", 5, at(0))
+				alerts.Observe("disk", 20, at(10)) // Recovers, restarting the wait
+				alerts.Observe("disk", 5, at(20))
+				if msgs := alerts.Observe("disk", 5, at(40)); len(msgs) != 0 {
+					t.Errorf("Expected no alert before 30s, got %v", msgs)
+				}
+				if msgs := alerts.Observe("disk", 5, at(50)); len(msgs) != 1 || !msgs[0].Firing {
+					t.Errorf("Expected the alert after 30s, got %v", msgs)
+				}
+			},
+		},
+		{
+			name: "Measures the rate of change over a window",
+			test: func(t *testing.T) {
+				alerts := NewAlerts(
+					AlertRule{Name: "errors-rising", Metric: "errors", Condition: RateAbove(5, 10*time.Second)},
+					AlertRule{Name: "queue-draining", Metric: "queue", Condition: RateBelow(-2, 10*time.Second)},
+				)
+				alerts.Observe("errors", 0, at(0))
+				alerts.Observe("errors", 20, at(5))
+				// The sample at 0 is outside the window; 20 to 40 in 5s is 4/s
+				if msgs := alerts.Observe("errors", 40, at(11)); len(msgs) != 0 {
+					t.Errorf("Expected a slow rise to pass, got %v", msgs)
+				}
+				msgs := alerts.Observe("errors", 100, at(15))
+				if len(msgs) != 1 || msgs[0].Rule != "errors-rising" || !strings.Contains(msgs[0].Message, "8/s") {
+					t.Errorf("Expected a fast rise to fire, got %v", msgs)
+				}
+
+				alerts.Observe("queue", 100, at(0))
+				if msgs := alerts.Observe("queue", 70, at(10)); len(msgs) != 1 || msgs[0].Rule != "queue-draining" {
+					t.Errorf("Expected a fast fall to fire, got %v", msgs)
+				}
+			},
+		},
+		{
+			name: "Sends alerts from Record",
+			test: func(t *testing.T) {
+				alerts := NewAlerts(AlertRule{Name: "hot", Metric: "temp", Condition: Above(80), Message: "Server room is hot"})
+				if cmd := alerts.Record("temp", 20); cmd != nil {
+					t.Error("Expected no command without alerts")
+				}
+				cmd := alerts.Record("temp", 85)
+				if cmd == nil {
+					t.Fatal("Expected a command")
+				}
+				msg, ok := cmd().(AlertMsg)
+				if !ok || msg.Message != "Server room is hot" || msg.Metric != "temp" {
+					t.Errorf("Expected the alert, got %#v", msg)
+				}
+				notify, ok := msg.Notify()().(notifyRequestMsg)
+				if !ok || notify.title != "[info] hot" || notify.opts.Tag != "alert:hot" {
+					t.Errorf("Expected a notification, got %#v", notify)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
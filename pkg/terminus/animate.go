@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"math"
+	"time"
+)
+
+// FrameInterval is the time between frames of a session's animation clock
+const FrameInterval = time.Second / 30
+
+// FrameMsg is sent on a frame of the session's animation clock, after a
+// NextFrame
+type FrameMsg struct {
+	Time time.Time
+}
+
+// frameRequestMsg asks the engine for the next frame
+type frameRequestMsg struct{}
+
+// frameTickMsg is sent by the engine's frame timer
+type frameTickMsg struct {
+	time time.Time
+}
+
+// NextFrame returns a command that sends a FrameMsg on the next frame of the
+// session's animation clock. Requests made before the frame are answered by
+// the same FrameMsg, so any number of animations advance together with one
+// update and one render per frame.
+func NextFrame() Cmd {
+	return func() Msg {
+		return frameRequestMsg{}
+	}
+}
+
+// requestFrame starts the frame timer, unless a frame is already coming
+func (e *Engine) requestFrame() {
+	if e.frameRequested {
+		return
+	}
+	e.frameRequested = true
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		timer := time.NewTimer(FrameInterval)
+		defer timer.Stop()
+		select {
+		case now := <-timer.C:
+			e.SendMessage(frameTickMsg{time: now})
+		case <-e.ctx.Done():
+		}
+	}()
+}
+
+// Easing maps the fraction of an animation's duration that has passed, from
+// 0 to 1, to the fraction of the way from its start value to its end value
+type Easing func(t float64) float64
+
+// Linear moves at a constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseIn starts slowly and speeds up
+func EaseIn(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOut starts quickly and slows down to a stop
+func EaseOut(t float64) float64 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut speeds up and slows down again
+func EaseInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = -2*t + 2
+	return 1 - t*t*t/2
+}
+
+// Animation interpolates a value from one number to another over a duration,
+// advancing with the frames of the session's animation clock. Use it for
+// anything that can be expressed as a number: the fill of a progress bar, the
+// height of an expanding panel or, with style.Blend, a color fade.
+type Animation struct {
+	from, to float64
+	duration time.Duration
+	easing   Easing
+	start    time.Time
+	value    float64
+	done     bool
+}
+
+// Animate creates an animation from from to to over duration, starting now. A
+// nil easing is Linear. Return the animation's Start command from Update and
+// pass it every later message, which advances it on each FrameMsg:
+//
+//	m.height = terminus.Animate(0, 12, 200*time.Millisecond, terminus.EaseOut)
+//	return m, m.height.Start()
+//
+//	case terminus.FrameMsg:
+//	    return m, m.height.Update(msg)
+func Animate(from, to float64, duration time.Duration, easing Easing) *Animation {
+	if easing == nil {
+		easing = Linear
+	}
+	a := &Animation{
+		from:     from,
+		to:       to,
+		duration: duration,
+		easing:   easing,
+		start:    time.Now(),
+		value:    from,
+	}
+	if duration <= 0 {
+		a.value, a.done = to, true
+	}
+	return a
+}
+
+// Start returns the command that requests the animation's first frame, or
+// nil if it is already done
+func (a *Animation) Start() Cmd {
+	if a.done {
+		return nil
+	}
+	return NextFrame()
+}
+
+// Update advances the animation to the time of a FrameMsg and requests the
+// next frame until the animation is done. Other messages are ignored.
+func (a *Animation) Update(msg Msg) Cmd {
+	frame, ok := msg.(FrameMsg)
+	if !ok || a.done {
+		return nil
+	}
+	a.value = a.At(frame.Time)
+	if !frame.Time.Before(a.start.Add(a.duration)) {
+		a.done = true
+		return nil
+	}
+	return NextFrame()
+}
+
+// At returns the animation's value at time t
+func (a *Animation) At(t time.Time) float64 {
+	if a.duration <= 0 {
+		return a.to
+	}
+	progress := float64(t.Sub(a.start)) / float64(a.duration)
+	progress = math.Max(0, math.Min(1, progress))
+	return a.from + (a.to-a.from)*a.easing(progress)
+}
+
+// Value returns the animation's value at its latest frame
+func (a *Animation) Value() float64 {
+	return a.value
+}
+
+// Int returns Value rounded to the nearest integer, e.g. for a size in cells
+func (a *Animation) Int() int {
+	return int(math.Round(a.value))
+}
+
+// Done reports whether the animation has reached its end value
+func (a *Animation) Done() bool {
+	return a.done
+}
+
+// Finish jumps to the end of the animation
+func (a *Animation) Finish() {
+	a.value, a.done = a.to, true
+}
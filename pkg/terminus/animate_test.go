@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// animatedComponent runs two animations when initialized and counts the
+// frames it receives
+type animatedComponent struct {
+	width, height *Animation
+	frames        int
+}
+
+func (c *animatedComponent) Init() Cmd {
+	c.width = Animate(0, 10, 100*time.Millisecond, EaseOut)
+	c.height = Animate(0, 4, 100*time.Millisecond, nil)
+	return Batch(c.width.Start(), c.height.Start())
+}
+
+func (c *animatedComponent) Update(msg Msg) (Component, Cmd) {
+	if _, ok := msg.(FrameMsg); ok {
+		c.frames++
+		return c, Batch(c.width.Update(msg), c.height.Update(msg))
+	}
+	return c, nil
+}
+
+func (c *animatedComponent) View() string {
+	return fmt.Sprintf("%dx%d in %d frames", c.width.Int(), c.height.Int(), c.frames)
+}
+
+func TestEasing(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear":    Linear,
+		"EaseIn":    EaseIn,
+		"EaseOut":   EaseOut,
+		"EaseInOut": EaseInOut,
+	}
+
+	for name, easing := range easings {
+		t.Run(name, func(t *testing.T) {
+			if easing(0) != 0 || easing(1) != 1 {
+				t.Errorf("Expected to run from 0 to 1, got %v to %v", easing(0), easing(1))
+			}
+			for x := 0.1; x < 1; x += 0.1 {
+				if easing(x) < easing(x-0.1) {
+					t.Errorf("Expected %s to never go back, but it does at %v", name, x)
+				}
+			}
+		})
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Interpolates between the values",
+			test: func(t *testing.T) {
+				a := Animate(10, 20, time.Second, Linear)
+				if got := a.At(a.start.Add(250 * time.Millisecond)); math.Abs(got-12.5) > 1e-9 {
+					t.Errorf("Expected 12.5 after a quarter, got %v", got)
+				}
+				if got := a.At(a.start.Add(-time.Second)); got != 10 {
+					t.Errorf("Expected the start value before the start, got %v", got)
+				}
+				if got := a.At(a.start.Add(2 * time.Second)); got != 20 {
+					t.Errorf("Expected the end value after the end, got %v", got)
+				}
+			},
+		},
+		{
+			name: "Requests frames until done",
+			test: func(t *testing.T) {
+				a := Animate(0, 1, time.Second, EaseInOut)
+				if a.Start() == nil {
+					t.Fatal("Expected Start to request a frame")
+				}
+				if a.Update(FrameMsg{Time: a.start.Add(500 * time.Millisecond)}) == nil || a.Done() {
+					t.Error("Expected another frame halfway")
+				}
+				if a.Update(FrameMsg{Time: a.start.Add(time.Second)}) != nil || !a.Done() || a.Value() != 1 {
+					t.Errorf("Expected the animation to end at 1, got %v", a.Value())
+				}
+				if a.Update(FrameMsg{Time: a.start.Add(2 * time.Second)}) != nil {
+					t.Error("Expected no frames after the end")
+				}
+			},
+		},
+		{
+			name: "Zero duration ends at once",
+			test: func(t *testing.T) {
+				a := Animate(0, 5, 0, nil)
+				if !a.Done() || a.Int() != 5 || a.Start() != nil {
+					t.Errorf("Expected the animation to be done at 5, got %v", a.Value())
+				}
+			},
+		},
+		{
+			name: "Animations share the frame clock",
+			test: func(t *testing.T) {
+				_, renders := startEngine(t, &animatedComponent{})
+
+				timeout := time.After(2 * time.Second)
+				for {
+					select {
+					case view := <-renders:
+						if !strings.HasPrefix(view, "10x4") {
+							continue
+						}
+						var frames int
+						fmt.Sscanf(view, "10x4 in %d frames", &frames)
+						// Two animations of 100ms take about 3 frames, not 6
+						if max := int(100*time.Millisecond/FrameInterval) + 2; frames > max {
+							t.Errorf("Expected at most %d frames, got %d", max, frames)
+						}
+						return
+					case <-timeout:
+						t.Fatal("Expected the animations to finish")
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -61,32 +61,42 @@ func CancelAll() {
 // WithCancel creates a cancellable command with a unique ID using this registry
 func (r *CancellationRegistry) WithCancel(id string, cmd func(ctx context.Context) Msg) Cmd {
 	return func() Msg {
-		ctx, cancel := context.WithCancel(context.Background())
-		
-		cancellable := &CancellableCmd{
-			cancel: cancel,
-			done:   make(chan struct{}),
-		}
-		
-		// Cancel any existing command with the same ID
-		r.Cancel(id)
-		
-		// Register the new command
-		r.mu.Lock()
-		r.commands[id] = cancellable
-		r.mu.Unlock()
-		
-		// Run the command
-		msg := cmd(ctx)
-		
-		// Clean up
-		r.mu.Lock()
+		return r.run(context.Background(), id, cmd)
+	}
+}
+
+// run registers id, runs cmd with a context derived from parent and
+// unregisters it when cmd returns. Starting a command with an ID that is
+// already running cancels the previous one.
+func (r *CancellationRegistry) run(parent context.Context, id string, cmd func(ctx context.Context) Msg) Msg {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	
+	cancellable := &CancellableCmd{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	
+	// Cancel any existing command with the same ID
+	r.Cancel(id)
+	
+	// Register the new command
+	r.mu.Lock()
+	r.commands[id] = cancellable
+	r.mu.Unlock()
+	
+	// Run the command
+	msg := cmd(ctx)
+	
+	// Clean up, unless a newer command has already taken over the ID
+	r.mu.Lock()
+	if r.commands[id] == cancellable {
 		delete(r.commands, id)
-		r.mu.Unlock()
-		close(cancellable.done)
-		
-		return msg
 	}
+	r.mu.Unlock()
+	close(cancellable.done)
+	
+	return msg
 }
 
 // Cancel cancels a command by ID
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ClientErrorLimit is how many client errors each session reports per
+	// ClientErrorWindow; the rest are counted but not logged
+	ClientErrorLimit = 10
+
+	// ClientErrorWindow is the period ClientErrorLimit applies to
+	ClientErrorWindow = time.Minute
+
+	// maxClientErrorText is the length client error messages and stack
+	// traces are cut to
+	maxClientErrorText = 4096
+)
+
+// ClientError is an error the browser client of a session ran into, such as
+// an exception on the page or a message from the server it couldn't parse
+type ClientError struct {
+	SessionID string
+	Source    string // What failed: "window", "promise", "protocol" or "handler"
+	Message   string
+	Stack     string // The JavaScript stack trace, if the browser gave one
+	UserAgent string // The browser's, from its EnvironmentMsg
+
+	// Suppressed is how many errors of the session the rate limit dropped
+	// since the previous report, e.g. to add to an error counter
+	Suppressed int
+}
+
+// clientErrorLimiter limits how many client errors a session reports
+type clientErrorLimiter struct {
+	windowStart time.Time
+	reported    int // In the current window
+	suppressed  int // Since the last report
+}
+
+// allow reports whether an error arriving at now may be reported, and if
+// so how many were suppressed before it
+func (l *clientErrorLimiter) allow(now time.Time) (bool, int) {
+	if now.Sub(l.windowStart) >= ClientErrorWindow {
+		l.windowStart = now
+		l.reported = 0
+	}
+	if l.reported >= ClientErrorLimit {
+		l.suppressed++
+		return false, 0
+	}
+	l.reported++
+	suppressed := l.suppressed
+	l.suppressed = 0
+	return true, suppressed
+}
+
+// WithEngineOnClientError calls hook with each error the client reports,
+// within the rate limit
+func WithEngineOnClientError(hook func(ClientError)) EngineOption {
+	return func(e *Engine) {
+		e.onClientError = append(e.onClientError, hook)
+	}
+}
+
+// WithOnClientError calls hook with the errors the browser clients of
+// sessions run into, e.g. to count them in a metric. Each session reports
+// up to ClientErrorLimit errors per ClientErrorWindow; all of them are also
+// logged.
+func WithOnClientError(hook func(ClientError)) ProgramOption {
+	return func(p *Program) {
+		p.onClientError = append(p.onClientError, hook)
+	}
+}
+
+// reportClientError logs an error reported by the client and passes it to
+// the hooks, unless the session has reported too many lately
+func (s *Session) reportClientError(data map[string]interface{}) {
+	s.mu.Lock()
+	ok, suppressed := s.clientErrors.allow(time.Now())
+	userAgent := s.environment.UserAgent
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	report := ClientError{
+		SessionID:  s.id,
+		UserAgent:  userAgent,
+		Suppressed: suppressed,
+	}
+	report.Source, _ = data["source"].(string)
+	report.Message, _ = data["message"].(string)
+	report.Stack, _ = data["stack"].(string)
+	report.Source = truncateClientText(Sanitize(report.Source))
+	report.Message = truncateClientText(Sanitize(report.Message))
+	report.Stack = truncateClientText(Sanitize(report.Stack))
+
+	fmt.Printf("Client error in session %s (%s): %s\n", s.id, report.Source, report.Message)
+	if report.Stack != "" {
+		fmt.Printf("    %s\n", strings.ReplaceAll(report.Stack, "\n", "\n    "))
+	}
+	if suppressed > 0 {
+		fmt.Printf("Suppressed %d more client errors in session %s\n", suppressed, s.id)
+	}
+	for _, hook := range s.engine.onClientError {
+		hook(report)
+	}
+}
+
+// truncateClientText cuts text from the client to maxClientErrorText bytes,
+// at a character boundary
+func truncateClientText(text string) string {
+	if len(text) <= maxClientErrorText {
+		return text
+	}
+	return strings.ToValidUTF8(text[:maxClientErrorText], "")
+}
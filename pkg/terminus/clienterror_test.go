@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientErrors(t *testing.T) {
+	// clientError is the message the client sends for an error
+	clientError := func(message string) ClientMessage {
+		return ClientMessage{Type: "error", Data: map[string]interface{}{
+			"source":  "window",
+			"message": message,
+			"stack":   "at render (terminus-client.js:1:2)",
+		}}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports errors to the hooks",
+			test: func(t *testing.T) {
+				var reports []ClientError
+				session := NewSession("errors", nil, &testComponent{},
+					WithEngineOnClientError(func(e ClientError) { reports = append(reports, e) }))
+				defer session.Close()
+				session.clientToTerminusMessage(ClientMessage{Type: "environment", Data: map[string]interface{}{"userAgent": "Mozilla/5.0"}})
+
+				if msg := session.clientToTerminusMessage(clientError("\x1b[2Jboom")); msg != nil {
+					t.Errorf("Expected no message for the component, got %T", msg)
+				}
+				want := ClientError{
+					SessionID: "errors",
+					Source:    "window",
+					Message:   "boom",
+					Stack:     "at render (terminus-client.js:1:2)",
+					UserAgent: "Mozilla/5.0",
+				}
+				if len(reports) != 1 || reports[0] != want {
+					t.Errorf("Expected %+v, got %+v", want, reports)
+				}
+			},
+		},
+		{
+			name: "Limits how many errors are reported",
+			test: func(t *testing.T) {
+				reported := 0
+				session := NewSession("errors", nil, &testComponent{},
+					WithEngineOnClientError(func(ClientError) { reported++ }))
+				defer session.Close()
+
+				for i := 0; i < ClientErrorLimit+5; i++ {
+					session.clientToTerminusMessage(clientError("again"))
+				}
+				if reported != ClientErrorLimit {
+					t.Errorf("Expected %d reports, got %d", ClientErrorLimit, reported)
+				}
+			},
+		},
+		{
+			name: "Counts suppressed errors once the window passes",
+			test: func(t *testing.T) {
+				var limiter clientErrorLimiter
+				start := time.Now()
+				for i := 0; i < ClientErrorLimit+3; i++ {
+					limiter.allow(start)
+				}
+				ok, suppressed := limiter.allow(start.Add(ClientErrorWindow))
+				if !ok || suppressed != 3 {
+					t.Errorf("Expected a report after 3 suppressed errors, got %v and %d", ok, suppressed)
+				}
+			},
+		},
+		{
+			name: "Truncates long messages",
+			test: func(t *testing.T) {
+				var report ClientError
+				session := NewSession("errors", nil, &testComponent{},
+					WithEngineOnClientError(func(e ClientError) { report = e }))
+				defer session.Close()
+
+				session.clientToTerminusMessage(clientError(strings.Repeat("é", maxClientErrorText)))
+				if len(report.Message) > maxClientErrorText || !strings.HasPrefix(report.Message, "éé") {
+					t.Errorf("Expected the message to be cut to %d bytes, got %d", maxClientErrorText, len(report.Message))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "strings"
+
+// Region is a rectangle of cells, such as the part of the screen a panel
+// is drawn in
+type Region struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Canvas is a grid of cells that views are drawn into, each clipped to its
+// region: a panel whose view is too wide or too long is cut off at its
+// edges rather than pushing its neighbors out of line. Views are drawn in
+// order, later ones over earlier ones.
+//
+//	canvas := terminus.NewCanvas(m.width, m.height)
+//	canvas.Draw(terminus.Region{Width: 30, Height: m.height}, m.sidebar.View())
+//	canvas.Draw(terminus.Region{X: 31, Width: m.width - 31, Height: m.height}, m.main.View())
+//	return canvas.View()
+type Canvas struct {
+	screen *Screen
+}
+
+// NewCanvas creates a blank width x height canvas
+func NewCanvas(width, height int) *Canvas {
+	return &Canvas{screen: NewScreen(max(width, 0), max(height, 0))}
+}
+
+// SetTabWidth sets the distance between the tab stops views are drawn
+// with. Less than 1 uses DefaultTabWidth.
+func (c *Canvas) SetTabWidth(width int) *Canvas {
+	c.screen.SetTabWidth(width)
+	return c
+}
+
+// Draw draws view into region, replacing what was drawn there. Lines wider
+// than the region are cut off at its right edge and lines below it are
+// dropped; the region is clipped to the canvas in turn.
+func (c *Canvas) Draw(region Region, view string) *Canvas {
+	c.screen.drawClipped(region, view)
+	return c
+}
+
+// View returns what has been drawn, each line padded to the width of the
+// canvas
+func (c *Canvas) View() string {
+	var b []byte
+	for y, line := range c.screen.lines {
+		if y > 0 {
+			b = append(b, '\n')
+		}
+		b = appendCells(b, line)
+	}
+	return string(b)
+}
+
+// Clip cuts view off at width columns and height lines and pads it to
+// fill them, so it takes exactly width x height cells wherever it is
+// placed, e.g. in a column of layout.Columns
+func Clip(view string, width, height int) string {
+	return NewCanvas(width, height).Draw(Region{Width: width, Height: height}, view).View()
+}
+
+// drawClipped draws view into region of the screen, cut off at the
+// region's edges
+func (s *Screen) drawClipped(region Region, view string) {
+	if region.Width <= 0 || region.Height <= 0 {
+		return
+	}
+
+	// Lines below the region would scroll the ones above out of it
+	if i := indexNth(view, '\n', region.Height); i >= 0 {
+		view = view[:i]
+	}
+	clip := NewScreen(region.Width, region.Height)
+	clip.SetTabWidth(s.tabWidth)
+	clip.SetWrap(Wrap{Mode: WrapNone})
+	clip.RenderFromString(view)
+
+	for y := 0; y < region.Height; y++ {
+		row := region.Y + y
+		if row < 0 || row >= s.height {
+			continue
+		}
+		for x := 0; x < region.Width; x++ {
+			col := region.X + x
+			if col >= 0 && col < s.width {
+				s.lines[row][col] = clip.lines[y][x]
+			}
+		}
+	}
+}
+
+// indexNth returns the index of the nth occurrence of b in s, counting
+// from 1, or -1 if there are fewer
+func indexNth(s string, b byte, n int) int {
+	offset := 0
+	for ; n > 0; n-- {
+		i := strings.IndexByte(s[offset:], b)
+		if i < 0 {
+			return -1
+		}
+		if n == 1 {
+			return offset + i
+		}
+		offset += i + 1
+	}
+	return -1
+}
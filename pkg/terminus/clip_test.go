@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanvas(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Clips panels to their regions",
+			test: func(t *testing.T) {
+				canvas := NewCanvas(11, 2)
+				canvas.Draw(Region{Width: 5, Height: 2}, "a panel too wide\nfor it\nand too long")
+				canvas.Draw(Region{X: 6, Width: 5, Height: 2}, "right\nside")
+
+				expected := "a pan right\nfor i side "
+				if got := canvas.View(); got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+			},
+		},
+		{
+			name: "Clips regions to the canvas",
+			test: func(t *testing.T) {
+				canvas := NewCanvas(4, 2)
+				canvas.Draw(Region{X: -2, Y: 1, Width: 8, Height: 3}, "abcdefgh\nijkl")
+
+				expected := "    \ncdef"
+				if got := canvas.View(); got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+			},
+		},
+		{
+			name: "Keeps styles and resets them at the edge",
+			test: func(t *testing.T) {
+				canvas := NewCanvas(6, 1)
+				canvas.Draw(Region{Width: 3, Height: 1}, "\x1b[1mbold text\x1b[0m")
+				canvas.Draw(Region{X: 3, Width: 3, Height: 1}, "xyz")
+
+				expected := "\x1b[1mbol\x1b[0mxyz"
+				if got := canvas.View(); got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+			},
+		},
+		{
+			name: "Later views draw over earlier ones",
+			test: func(t *testing.T) {
+				canvas := NewCanvas(5, 1)
+				canvas.Draw(Region{Width: 5, Height: 1}, "aaaaa")
+				canvas.Draw(Region{X: 1, Width: 2, Height: 1}, "")
+
+				if got := canvas.View(); got != "a  aa" {
+					t.Errorf("Expected the region to be replaced, got %q", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestClip(t *testing.T) {
+	got := Clip("short\na line that is too wide\n3\n4", 8, 3)
+	expected := []string{"short   ", "a line t", "3       "}
+	if lines := strings.Split(got, "\n"); strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec converts messages of a custom type to and from the JSON data of
+// protocol messages
+type Codec[T Msg] interface {
+	Encode(msg T) (json.RawMessage, error)
+	Decode(data json.RawMessage) (T, error)
+}
+
+// JSONCodec encodes messages with encoding/json, so their fields can be
+// named with JSON tags
+type JSONCodec[T Msg] struct{}
+
+// Encode implements Codec
+func (JSONCodec[T]) Encode(msg T) (json.RawMessage, error) {
+	return json.Marshal(msg)
+}
+
+// Decode implements Codec
+func (JSONCodec[T]) Decode(data json.RawMessage) (T, error) {
+	var msg T
+	err := json.Unmarshal(data, &msg)
+	return msg, err
+}
+
+// messageType is a message type added to the protocol with
+// RegisterMessageType
+type messageType struct {
+	name   string
+	encode func(msg Msg) (json.RawMessage, error)
+	decode func(data json.RawMessage) (Msg, error)
+}
+
+// messageTypes holds the registered message types by name and by Go type
+var messageTypes = struct {
+	sync.RWMutex
+	byName map[string]*messageType
+	byType map[reflect.Type]*messageType
+}{
+	byName: make(map[string]*messageType),
+	byType: make(map[reflect.Type]*messageType),
+}
+
+// builtinMessageTypes are the types of the messages of the protocol itself,
+// which can't be registered
+var builtinMessageTypes = map[string]bool{
+	"batch": true, "clear": true, "clipboard": true, "custom": true, "download": true,
+	"environment": true, "error": true, "exit": true, "glyphs": true, "guard": true,
+	"key": true, "notification": true, "notify": true, "print": true, "refresh": true,
+	"render": true, "resize": true, "screenshot": true, "setCell": true,
+	"setCursor": true, "updateLine": true, "upload": true, "visibility": true,
+}
+
+// RegisterMessageType adds messages of type T to the protocol as messages
+// of type name, so a custom frontend can exchange them with components
+// without going through ClientMsg and SendToClient: messages of type name
+// from the client are decoded with codec and delivered to the component as
+// T, and Emit sends T to the client. Register types once, before starting
+// the program, typically in an init function. It panics if name or T is
+// already registered, or name is one of the protocol's own types.
+func RegisterMessageType[T Msg](name string, codec Codec[T]) {
+	goType := reflect.TypeOf((*T)(nil)).Elem()
+	messageTypes.Lock()
+	defer messageTypes.Unlock()
+	switch {
+	case name == "" || builtinMessageTypes[name]:
+		panic(fmt.Sprintf("terminus: message type %q is reserved", name))
+	case messageTypes.byName[name] != nil:
+		panic(fmt.Sprintf("terminus: message type %q registered twice", name))
+	case messageTypes.byType[goType] != nil:
+		panic(fmt.Sprintf("terminus: %v already registered as %q", goType, messageTypes.byType[goType].name))
+	}
+
+	mt := &messageType{
+		name: name,
+		encode: func(msg Msg) (json.RawMessage, error) {
+			return codec.Encode(msg.(T))
+		},
+		decode: func(data json.RawMessage) (Msg, error) {
+			return codec.Decode(data)
+		},
+	}
+	messageTypes.byName[name] = mt
+	messageTypes.byType[goType] = mt
+}
+
+// lookupMessageType returns the message type registered as name, or nil
+func lookupMessageType(name string) *messageType {
+	messageTypes.RLock()
+	defer messageTypes.RUnlock()
+	return messageTypes.byName[name]
+}
+
+// emitMsg asks the engine to send a message of a registered type
+type emitMsg struct {
+	msg Msg
+}
+
+// Emit returns a command that sends msg, of a type registered with
+// RegisterMessageType, to the client. The component receives nothing
+// unless the message can't be sent, when it receives an ErrMsg.
+func Emit(msg Msg) Cmd {
+	return func() Msg {
+		return emitMsg{msg: msg}
+	}
+}
+
+// emit sends a message of a registered type to the client, returning an
+// error message for the component if it can't be, and otherwise nil
+func (e *Engine) emit(req emitMsg) Msg {
+	messageTypes.RLock()
+	mt := messageTypes.byType[reflect.TypeOf(req.msg)]
+	messageTypes.RUnlock()
+	if mt == nil {
+		return ErrMsg{Err: fmt.Errorf("message type %T is not registered", req.msg), Source: "Emit"}
+	}
+	data, err := mt.encode(req.msg)
+	if err != nil {
+		return ErrMsg{Err: fmt.Errorf("encoding %s message: %w", mt.name, err), Source: "Emit"}
+	}
+	if e.emitToClient == nil || !e.emitToClient(mt.name, data) {
+		return ErrMsg{Err: ErrClientUnavailable, Source: "Emit"}
+	}
+	return nil
+}
+
+// decodeClientMessage converts a message of a registered type from the
+// client, returning nil if the type isn't registered or the message can't
+// be decoded
+func (s *Session) decodeClientMessage(msg ClientMessage) Msg {
+	mt := lookupMessageType(msg.Type)
+	if mt == nil {
+		return nil
+	}
+	data, err := json.Marshal(msg.Data)
+	if err == nil {
+		var decoded Msg
+		if decoded, err = mt.decode(data); err == nil {
+			return decoded
+		}
+	}
+	fmt.Printf("Failed to decode %s message from session %s: %v\n", msg.Type, s.id, err)
+	return nil
+}
+
+// sendEncoded sends a message with already encoded data to the client
+func (s *Session) sendEncoded(typ string, data []byte) bool {
+	frame, err := json.Marshal(struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}{typ, json.RawMessage(data)})
+	if err != nil {
+		fmt.Printf("Failed to marshal %s message for session %s: %v\n", typ, s.id, err)
+		return false
+	}
+	return s.pushControl(frame)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// geoMsg is sent by a custom frontend
+type geoMsg struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// rumbleMsg is sent to a custom frontend, encoded compactly as an array
+type rumbleMsg struct {
+	Strength float64
+	Millis   int
+}
+
+type rumbleCodec struct{}
+
+func (rumbleCodec) Encode(msg rumbleMsg) (json.RawMessage, error) {
+	return json.Marshal([]interface{}{msg.Strength, msg.Millis})
+}
+
+func (rumbleCodec) Decode(data json.RawMessage) (rumbleMsg, error) {
+	return rumbleMsg{}, errors.New("not sent by clients")
+}
+
+func init() {
+	RegisterMessageType[geoMsg]("geolocation", JSONCodec[geoMsg]{})
+	RegisterMessageType[rumbleMsg]("rumble", rumbleCodec{})
+}
+
+// geoComponent shows where the client is, and rumbles when it moves
+type geoComponent struct {
+	where string
+}
+
+func (c *geoComponent) Init() Cmd {
+	return nil
+}
+
+func (c *geoComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case geoMsg:
+		c.where = fmt.Sprintf("%.1f,%.1f", msg.Lat, msg.Lon)
+		return c, Emit(rumbleMsg{Strength: 0.5, Millis: 200})
+	case ErrMsg:
+		c.where = msg.Err.Error()
+	}
+	return c, nil
+}
+
+func (c *geoComponent) View() string {
+	return "at: " + c.where
+}
+
+func TestMessageTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Exchanges registered messages with the client",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component { return &geoComponent{} })
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+				conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+				if err != nil {
+					t.Fatalf("Failed to connect: %v", err)
+				}
+				defer conn.Close()
+
+				readUntil(t, conn, "at:")
+				conn.WriteJSON(ClientMessage{Type: "geolocation", Data: map[string]interface{}{"lat": 48.5, "lon": 2}})
+				// The component shows the location and then rumbles
+				conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+				located, rumbled := false, false
+				for !located || !rumbled {
+					_, data, err := conn.ReadMessage()
+					if err != nil {
+						t.Fatalf("Expected the location and a rumble, got %v and %v: %v", located, rumbled, err)
+					}
+					located = located || strings.Contains(string(data), "at: 48.5,2.0")
+					rumbled = rumbled || string(data) == `{"type":"rumble","data":[0.5,200]}`
+				}
+			},
+		},
+		{
+			name: "Reports messages of unregistered types",
+			test: func(t *testing.T) {
+				engine, _ := startEngine(t, &geoComponent{})
+				msg := engine.emit(emitMsg{msg: KeyMsg{}})
+				if errMsg, ok := msg.(ErrMsg); !ok || errMsg.Source != "Emit" {
+					t.Errorf("Expected an ErrMsg from Emit, got %v", msg)
+				}
+			},
+		},
+		{
+			name: "Ignores messages that don't decode",
+			test: func(t *testing.T) {
+				session := NewSession("codec", nil, &geoComponent{})
+				defer session.Close()
+				if msg := session.clientToTerminusMessage(ClientMessage{Type: "geolocation", Data: "north"}); msg != nil {
+					t.Errorf("Expected no message, got %v", msg)
+				}
+			},
+		},
+		{
+			name: "Refuses reserved and duplicate types",
+			test: func(t *testing.T) {
+				for _, name := range []string{"key", "upload", "download", "clipboard", "geolocation"} {
+					func() {
+						defer func() {
+							if recover() == nil {
+								t.Errorf("Expected registering %q to panic", name)
+							}
+						}()
+						RegisterMessageType[struct{ X int }](name, JSONCodec[struct{ X int }]{})
+					}()
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
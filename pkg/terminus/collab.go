@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// maxUserName is the longest collaborator name kept, in runes
+const maxUserName = 32
+
+// userColors are assigned to collaborators in the order they join
+var userColors = []Color{Cyan, Magenta, Yellow, Green, Blue, Red}
+
+// User is someone connected to a collaborative session
+type User struct {
+	ID    string // "" for the session's owner
+	Name  string
+	Color Color
+	Owner bool
+}
+
+// PresenceMsg is sent to the component when a collaborator joins or
+// leaves. Users lists everyone connected, the owner first.
+type PresenceMsg struct {
+	Users []User
+}
+
+// InviteCollaborators returns a command that creates a link to the running
+// session for collaborators. Unlike ShareSession observers, collaborators'
+// keys are delivered to the component with KeyMsg.User set to their ID, and
+// the component receives a PresenceMsg whenever someone joins or leaves.
+func InviteCollaborators() Cmd {
+	return func() Msg {
+		return shareRequestMsg{collaborative: true}
+	}
+}
+
+// Invite enables collaboration for the session and returns the token
+// collaborators join with
+func (s *Session) Invite() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inviteToken == "" {
+		s.inviteToken = uuid.New().String()
+	}
+	return s.inviteToken
+}
+
+// InviteToken returns the session's collaboration token, or "" if it has
+// not invited collaborators
+func (s *Session) InviteToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inviteToken
+}
+
+// Users returns the owner and connected collaborators
+func (s *Session) Users() []User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.presence()
+}
+
+// presence lists the owner and collaborators in the order they joined. The
+// caller holds s.mu.
+func (s *Session) presence() []User {
+	users := []User{{Name: "Host", Color: White, Owner: true}}
+	for o := range s.observers {
+		if o.user != nil {
+			users = append(users, *o.user)
+		}
+	}
+	// IDs are assigned in join order by Collaborate
+	collaborators := users[1:]
+	sort.Slice(collaborators, func(i, j int) bool {
+		return collaborators[i].ID < collaborators[j].ID
+	})
+	return users
+}
+
+// userName cleans up a name chosen by a collaborator: it is sanitized, its
+// runs of whitespace, including newlines, become single spaces, and it is cut
+// to maxUserName runes
+func userName(name string) string {
+	name = strings.Join(strings.Fields(Sanitize(name)), " ")
+	if runes := []rune(name); len(runes) > maxUserName {
+		name = strings.TrimSpace(string(runes[:maxUserName]))
+	}
+	return name
+}
+
+// Collaborate connects a collaborator named name to the session until they
+// disconnect, the session ends or ctx is done. Their keys are delivered to
+// the component; resizes are ignored, since the owner's terminal sets the
+// session's size. The name comes from the client, so it is sanitized.
+func (s *Session) Collaborate(ctx context.Context, conn *websocket.Conn, name string) {
+	s.mu.Lock()
+	s.joined++
+	n := s.joined
+	s.mu.Unlock()
+
+	name = userName(name)
+	if name == "" {
+		name = fmt.Sprintf("Guest %d", n)
+	}
+	user := &User{
+		// Zero-padded so that IDs sort in join order
+		ID:    fmt.Sprintf("user-%04d", n),
+		Name:  name,
+		Color: userColors[(n-1)%len(userColors)],
+	}
+
+	o := &observer{conn: conn, outgoing: make(chan []byte, 100), user: user}
+	s.stream(ctx, o, func(msg ClientMessage) {
+		if key, ok := keyFromMessage(msg); ok {
+			key.User = user.ID
+			s.engine.SendMessage(key)
+		}
+	})
+}
+
+// announcePresence tells the component who is connected
+func (s *Session) announcePresence() {
+	s.engine.SendMessage(PresenceMsg{Users: s.Users()})
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// collabComponent invites collaborators on start and shows who is present
+// and who pressed the last key
+type collabComponent struct {
+	link  string
+	users []User
+	last  string
+}
+
+func (c *collabComponent) Init() Cmd {
+	return InviteCollaborators()
+}
+
+func (c *collabComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case ShareLinkMsg:
+		c.link = msg.Path
+	case PresenceMsg:
+		c.users = msg.Users
+	case KeyMsg:
+		name := "?"
+		for _, u := range c.users {
+			if u.ID == msg.User {
+				name = u.Name
+			}
+		}
+		c.last = name + " pressed " + msg.String()
+	}
+	return c, nil
+}
+
+func (c *collabComponent) View() string {
+	var names []string
+	for _, u := range c.users {
+		names = append(names, u.Name)
+	}
+	return "link " + c.link + "\npresent " + strings.Join(names, ",") + "\n" + c.last
+}
+
+func TestCollaboration(t *testing.T) {
+	program := NewProgram(func() Component { return &collabComponent{} })
+	server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	owner, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer owner.Close()
+	msg := readUntil(t, owner, "link /?join=")
+	content := screenText(msg)
+	path := strings.Fields(content[strings.Index(content, "/?join="):])[0]
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Announces collaborators as they join and leave",
+			test: func(t *testing.T) {
+				ada, _, err := websocket.DefaultDialer.Dial(wsURL+path[1:]+"&name=Ada", nil)
+				if err != nil {
+					t.Fatalf("Failed to join: %v", err)
+				}
+				readUntil(t, owner, "present Host,Ada")
+
+				guest, _, err := websocket.DefaultDialer.Dial(wsURL+path[1:], nil)
+				if err != nil {
+					t.Fatalf("Failed to join: %v", err)
+				}
+				readUntil(t, guest, "present Host,Ada,Guest 2")
+
+				ada.Close()
+				readUntil(t, owner, "present Host,Guest 2")
+				guest.Close()
+				readUntil(t, owner, "present Host")
+			},
+		},
+		{
+			name: "Attributes keys to their user",
+			test: func(t *testing.T) {
+				bob, _, err := websocket.DefaultDialer.Dial(wsURL+path[1:]+"&name=Bob", nil)
+				if err != nil {
+					t.Fatalf("Failed to join: %v", err)
+				}
+				defer bob.Close()
+				readUntil(t, owner, "present Host,Bob")
+
+				bob.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "enter"}})
+				readUntil(t, owner, "Bob pressed enter")
+				owner.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "tab"}})
+				readUntil(t, bob, "Host pressed tab")
+
+				// Collaborators cannot resize the owner's session
+				bob.WriteJSON(ClientMessage{Type: "resize", Data: map[string]interface{}{"width": 10.0, "height": 2.0}})
+				bob.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "space"}})
+				readUntil(t, owner, "Bob pressed space")
+				session := program.sessionManager.GetInvitedSession(path[len("/?join="):])
+				session.mu.RLock()
+				width := session.width
+				session.mu.RUnlock()
+				if width != 80 {
+					t.Errorf("Expected the owner's width, got %d", width)
+				}
+				if users := session.Users(); len(users) != 2 || !users[0].Owner {
+					t.Errorf("Expected the owner and Bob, got %+v", users)
+				}
+			},
+		},
+		{
+			name: "Keeps observe and join links separate",
+			test: func(t *testing.T) {
+				_, resp, err := websocket.DefaultDialer.Dial(wsURL+"?observe="+path[len("/?join="):], nil)
+				if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+					t.Errorf("Expected the join token to be rejected for observing, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestUserName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "Keeps plain names", in: "Ada", want: "Ada"},
+		{name: "Removes escape sequences", in: "\x1b[31mAda\x1b[0m", want: "Ada"},
+		{name: "Removes controls that reorder text", in: "Ada\u202eevE", want: "AdaevE"},
+		{name: "Joins lines and collapses whitespace", in: "  Ada\n\tLovelace ", want: "Ada Lovelace"},
+		{name: "Cuts long names", in: strings.Repeat("é", maxUserName+5), want: strings.Repeat("é", maxUserName)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userName(tt.in); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+// WithEngineColorProfile renders in profile whatever the client advertises
+func WithEngineColorProfile(profile ColorProfile) EngineOption {
+	return func(e *Engine) {
+		e.colorProfile = &profile
+	}
+}
+
+// clientColorProfile returns the profile a client with env is rendered in:
+// the engine's if it has one, or the one the client's color depth implies
+func (e *Engine) clientColorProfile(env EnvironmentMsg) ColorProfile {
+	if e.colorProfile != nil {
+		return *e.colorProfile
+	}
+	return ProfileForDepth(env.ColorDepth)
+}
+
+// degrade converts the colors of the screen's cells to profile
+func (s *Screen) degrade(profile ColorProfile) {
+	if profile == TrueColor {
+		return
+	}
+	for _, line := range s.lines {
+		for x := range line {
+			line[x].Style = line[x].Style.Degrade(profile)
+		}
+	}
+}
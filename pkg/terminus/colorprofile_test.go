@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "testing"
+
+func TestColorProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Converts the colors sent",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(10, 1)
+				differ.SetColorProfile(Colors256)
+				differ.Update("ab")
+				ops := differ.Update(NewStyle().Foreground(RGB(255, 128, 0)).Render("ab"))
+				if len(ops) != 1 {
+					t.Fatalf("Expected the line to be sent, got %d ops", len(ops))
+				}
+				if line := ops[0].Data.(UpdateLineOp); line.Content != "\x1b[38;5;208mab\x1b[0m" {
+					t.Errorf("Expected a 256 color, got %q", line.Content)
+				}
+			},
+		},
+		{
+			name: "Follows the client's color depth",
+			test: func(t *testing.T) {
+				session := NewSession("depth", nil, &testComponent{})
+				defer session.Close()
+				session.clientToTerminusMessage(ClientMessage{
+					Type: "environment",
+					Data: map[string]interface{}{"colorDepth": 8.0},
+				})
+				if session.colorProfile != Colors256 {
+					t.Errorf("Expected 256 colors, got %v", session.colorProfile)
+				}
+			},
+		},
+		{
+			name: "Uses the engine's profile",
+			test: func(t *testing.T) {
+				session := NewSession("forced", nil, &testComponent{}, WithEngineColorProfile(Monochrome))
+				defer session.Close()
+				if session.colorProfile != Monochrome {
+					t.Errorf("Expected monochrome before the environment, got %v", session.colorProfile)
+				}
+				session.clientToTerminusMessage(ClientMessage{
+					Type: "environment",
+					Data: map[string]interface{}{"colorDepth": 24.0},
+				})
+				if session.colorProfile != Monochrome {
+					t.Errorf("Expected monochrome, got %v", session.colorProfile)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
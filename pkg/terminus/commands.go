@@ -25,17 +25,28 @@ var Quit Cmd = func() Msg {
 	return QuitMsg{}
 }
 
-// Batch performs a list of commands in parallel and returns immediately
+// batchMsg carries the commands of a Batch to the engine, which runs each
+// on its own
+type batchMsg []Cmd
+
+// Batch performs a list of commands concurrently. Each command's message is
+// delivered to the update loop as soon as it is ready. Nil commands are
+// skipped, and a batch of none is nil.
 func Batch(cmds ...Cmd) Cmd {
-	return func() Msg {
-		for _, cmd := range cmds {
-			if cmd != nil {
-				go func(c Cmd) {
-					c()
-				}(cmd)
-			}
+	var batch batchMsg
+	for _, cmd := range cmds {
+		if cmd != nil {
+			batch = append(batch, cmd)
 		}
+	}
+	switch len(batch) {
+	case 0:
 		return nil
+	case 1:
+		return batch[0]
+	}
+	return func() Msg {
+		return batch
 	}
 }
 
@@ -71,6 +82,63 @@ func Parallel(cmds ...Cmd) Cmd {
 	}
 }
 
+// StreamFunc is the body of a long-running command. It may deliver any number
+// of messages through send before returning its final message (or nil).
+// Implementations must return promptly once ctx is done.
+type StreamFunc func(ctx context.Context, send func(Msg)) Msg
+
+// streamMsg carries a StreamFunc to the engine, which runs it outside the
+// command worker pool so long-lived streams don't starve other commands
+type streamMsg struct {
+	fn StreamFunc
+}
+
+// Stream creates a command that can send intermediate messages to the update
+// loop while it runs. The context is cancelled when the session ends.
+func Stream(fn StreamFunc) Cmd {
+	return func() Msg {
+		return streamMsg{fn: fn}
+	}
+}
+
+// runCmd executes cmd and, if it turns out to be a stream, runs the stream
+// body inline with the given context and sender. The commands of a batch
+// run concurrently, sending their messages.
+func runCmd(ctx context.Context, cmd Cmd, send func(Msg)) Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := execCmd(cmd)
+	switch msg := msg.(type) {
+	case streamMsg:
+		return msg.run(ctx, send)
+	case batchMsg:
+		var wg sync.WaitGroup
+		for _, c := range msg {
+			wg.Add(1)
+			go func(c Cmd) {
+				defer wg.Done()
+				if m := runCmd(ctx, c, send); m != nil {
+					send(m)
+				}
+			}(c)
+		}
+		wg.Wait()
+		return nil
+	}
+	return msg
+}
+
+// run executes the stream body, converting panics and errors into ErrMsg
+func (s streamMsg) run(ctx context.Context, send func(Msg)) (msg Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = ErrMsg{Err: newPanicError(r), Source: funcName(s.fn)}
+		}
+	}()
+	return toErrMsg(s.fn(ctx, send), s.fn)
+}
+
 // tickMsg is the message sent by the Tick command
 type tickMsg struct {
 	time time.Time
@@ -100,44 +168,40 @@ func Tick(d time.Duration, fn func(time.Time) Msg) Cmd {
 	}
 }
 
-// Every returns a command that sends a message at regular intervals
-// Note: This command runs indefinitely and should be used with WithCancel
+// Every returns a command that sends a message at regular intervals until the
+// session ends, without needing to be re-issued from Update. A nil fn sends
+// TickMsg values.
 func Every(d time.Duration, fn func(time.Time) Msg) Cmd {
-	return func() Msg {
-		ticker := time.NewTicker(d)
-		defer ticker.Stop()
-		
-		for t := range ticker.C {
-			if fn != nil {
-				msg := fn(t)
-				if msg != nil {
-					// This is problematic as we can't send messages from here
-					// Every should be used with a different pattern
-				}
-			}
-		}
-		return nil
-	}
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		return tickEvery(ctx, d, fn, send)
+	})
 }
 
-// Interval creates a cancellable command that sends messages at regular intervals
+// Interval creates a cancellable command that sends messages at regular intervals.
+// It runs until the session ends or Cancel(id) is called.
 func Interval(id string, duration time.Duration, fn func(time.Time) Msg) Cmd {
-	return WithCancel(id, func(ctx context.Context) Msg {
-		ticker := time.NewTicker(duration)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case t := <-ticker.C:
-				if fn != nil {
-					if msg := fn(t); msg != nil {
-						// In a real implementation, we'd need a way to send this message
-						// to the update loop. For now, we'll document this limitation
-					}
-				}
-			case <-ctx.Done():
-				return nil
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		return globalRegistry.run(ctx, id, func(ctx context.Context) Msg {
+			return tickEvery(ctx, duration, fn, send)
+		})
+	})
+}
+
+// tickEvery sends fn(t) on every tick of d until ctx is done
+func tickEvery(ctx context.Context, d time.Duration, fn func(time.Time) Msg, send func(Msg)) Msg {
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case t := <-ticker.C:
+			if fn == nil {
+				send(tickMsg{time: t})
+			} else if msg := fn(t); msg != nil {
+				send(msg)
 			}
+		case <-ctx.Done():
+			return nil
 		}
-	})
-}
\ No newline at end of file
+	}
+}
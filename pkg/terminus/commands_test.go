@@ -15,6 +15,7 @@
 package terminus
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -28,8 +29,7 @@ func TestQuitCommand(t *testing.T) {
 }
 
 func TestBatchCommand(t *testing.T) {
-	// For now, we'll test that Batch returns immediately with nil
-	// The actual command execution happens asynchronously
+	// Batch hands its commands to the engine, which runs each on its own
 	cmd1 := func() Msg { return nil }
 	cmd2 := func() Msg { return nil }
 	cmd3 := func() Msg { return nil }
@@ -37,8 +37,8 @@ func TestBatchCommand(t *testing.T) {
 	batch := Batch(cmd1, cmd2, cmd3)
 	msg := batch()
 	
-	if msg != nil {
-		t.Error("Batch should return nil message")
+	if cmds, ok := msg.(batchMsg); !ok || len(cmds) != 3 {
+		t.Errorf("Batch should return its 3 commands, got %v", msg)
 	}
 }
 
@@ -52,6 +52,37 @@ func TestBatchWithNilCommands(t *testing.T) {
 	if msg != nil {
 		t.Error("Batch should return nil message even with nil commands")
 	}
+	if Batch(nil, nil) != nil {
+		t.Error("Batch of no commands should be nil")
+	}
+}
+
+func TestBatchDeliversMessages(t *testing.T) {
+	stream := Stream(func(ctx context.Context, send func(Msg)) Msg {
+		send(testMsg{value: "streamed"})
+		return testMsg{value: "stream done"}
+	})
+	plain := func() Msg { return testMsg{value: "plain"} }
+
+	var mu sync.Mutex
+	var sent []Msg
+	final := runCmd(context.Background(), Batch(plain, stream), func(m Msg) {
+		mu.Lock()
+		sent = append(sent, m)
+		mu.Unlock()
+	})
+	if final != nil {
+		t.Errorf("Expected no final message, got %v", final)
+	}
+	got := make(map[string]bool)
+	for _, m := range sent {
+		got[m.(testMsg).value] = true
+	}
+	for _, want := range []string{"plain", "streamed", "stream done"} {
+		if !got[want] {
+			t.Errorf("Expected %q to be delivered, got %v", want, sent)
+		}
+	}
 }
 
 func TestTickCommand(t *testing.T) {
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "sort"
+
+// Surface is a layer of the screen: a view drawn into a region over the
+// main view and the surfaces below it, such as a modal, a toast or a
+// status overlay. A surface replaces every cell of its region, blank ones
+// included, and is cut off at the region's edges.
+type Surface struct {
+	// Name identifies the surface, e.g. "modal" or "toast"
+	Name string
+	// Z orders the surfaces: higher ones are drawn over lower ones, and
+	// surfaces with the same Z in the order they are given. The main view
+	// is below them all.
+	Z int
+	// Region is where the surface is drawn. The zero Region covers the
+	// whole screen.
+	Region Region
+	// View is what the surface shows
+	View string
+}
+
+// Layered is implemented by components with surfaces drawn over their
+// view, so overlays don't have to be spliced into the view's string. It
+// is asked on every render, after View.
+type Layered interface {
+	Surfaces() []Surface
+}
+
+// Compose draws surfaces over the screen in z order. Surfaces are drawn
+// with the screen's tab width and aren't wrapped.
+func (s *Screen) Compose(surfaces []Surface) {
+	ordered := make([]Surface, len(surfaces))
+	copy(ordered, surfaces)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Z < ordered[j].Z
+	})
+
+	for _, surface := range ordered {
+		region := surface.Region
+		if region == (Region{}) {
+			region = Region{Width: s.width, Height: s.height}
+		}
+		s.drawClipped(region, surface.View)
+	}
+}
+
+// viewSurfaces returns the surfaces of the current component, if it has
+// any
+func (e *Engine) viewSurfaces() []Surface {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if l, ok := e.component.(Layered); ok {
+		return l.Surfaces()
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		name     string
+		surfaces []Surface
+		expected string
+	}{
+		{
+			name:     "Draws surfaces over the view",
+			surfaces: []Surface{{Name: "toast", Region: Region{X: 4, Y: 1, Width: 4, Height: 1}, View: "saved"}},
+			expected: "aaaaaaaa\nbbbbsave\ncccccccc",
+		},
+		{
+			name: "Draws in z order",
+			surfaces: []Surface{
+				{Name: "toast", Z: 2, Region: Region{X: 2, Y: 1, Width: 2, Height: 1}, View: "TT"},
+				{Name: "modal", Z: 1, Region: Region{X: 1, Y: 0, Width: 6, Height: 3}, View: "modal\n\nend"},
+			},
+			expected: "amodal a\nb TT   b\ncend   c",
+		},
+		{
+			name: "Keeps the given order for equal z",
+			surfaces: []Surface{
+				{Name: "first", Region: Region{Width: 3, Height: 1}, View: "111"},
+				{Name: "second", Region: Region{X: 1, Width: 1, Height: 1}, View: "2"},
+			},
+			expected: "121aaaaa\nbbbbbbbb\ncccccccc",
+		},
+		{
+			name:     "Covers the screen with the zero region",
+			surfaces: []Surface{{Name: "full", View: "full"}},
+			expected: "full    \n        \n        ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := NewScreen(8, 3)
+			screen.SetWrap(Wrap{Mode: WrapNone})
+			screen.RenderFromString("aaaaaaaa\nbbbbbbbb\ncccccccc")
+			screen.Compose(tt.surfaces)
+			if got := screen.ToString(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSessionSurfaces(t *testing.T) {
+	component := &layeredComponent{
+		testComponent: testComponent{state: "main view"},
+		surfaces:      []Surface{{Name: "toast", Region: Region{X: 5, Width: 4, Height: 1}, View: "done"}},
+	}
+	session := NewSession("layers", nil, component)
+	defer session.Close()
+
+	session.screenDiffer.SetSurfaces(session.engine.viewSurfaces())
+	for _, op := range session.screenDiffer.Update(component.View()) {
+		if line, ok := op.Data.(UpdateLineOp); ok && line.Y == 0 {
+			if line.Content != "main done" {
+				t.Errorf("Expected the toast over the view, got %q", line.Content)
+			}
+			return
+		}
+	}
+	t.Error("Expected the first line to be drawn")
+}
+
+func TestSurfacesBelowOverlay(t *testing.T) {
+	differ := NewScreenDiffer(6, 1)
+	differ.SetSurfaces([]Surface{{Name: "modal", View: "modal"}})
+	differ.SetOverlay(func(s *Screen) { s.SetCell(5, 0, '!', NewStyle()) })
+
+	ops := differ.Update("view")
+	if line := ops[len(ops)-1].Data.(UpdateLineOp); !strings.HasPrefix(line.Content, "modal!") {
+		t.Errorf("Expected the overlay over the surface, got %q", line.Content)
+	}
+}
+
+// layeredComponent draws surfaces over its view
+type layeredComponent struct {
+	testComponent
+	surfaces []Surface
+}
+
+func (c *layeredComponent) Surfaces() []Surface {
+	return c.surfaces
+}
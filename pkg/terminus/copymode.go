@@ -0,0 +1,408 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultCopyModeKey is the chord that enters copy mode: Ctrl+Shift+S
+var DefaultCopyModeKey = KeyMsg{Type: KeyCtrlS, Shift: true}
+
+// ErrClipboardUnavailable is reported when CopyToClipboard is used without
+// a connected client
+var ErrClipboardUnavailable = errors.New("clipboard unavailable")
+
+// clipboardRequestMsg asks the client to put text on the clipboard
+type clipboardRequestMsg struct {
+	text string
+}
+
+// CopyToClipboard returns a command that puts text on the user's
+// clipboard, e.g. the selected row of a table. The component receives
+// nothing unless there is no client, when it receives an ErrMsg.
+func CopyToClipboard(text string) Cmd {
+	return func() Msg {
+		return clipboardRequestMsg{text: text}
+	}
+}
+
+// copyToClipboard sends text to the client's clipboard, returning an error
+// message for the component if there is no client, and otherwise nil
+func (e *Engine) copyToClipboard(req clipboardRequestMsg) Msg {
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "clipboard",
+		Data: map[string]interface{}{"text": req.text},
+	}) {
+		return ErrMsg{Err: ErrClipboardUnavailable, Source: "CopyToClipboard"}
+	}
+	return nil
+}
+
+// WithEngineCopyMode lets key toggle copy mode, where the keyboard moves
+// over a frozen copy of the screen to select and copy text. The key is not
+// delivered to the component.
+func WithEngineCopyMode(key KeyMsg) EngineOption {
+	return func(e *Engine) {
+		e.copyMode = newCopyMode(key)
+	}
+}
+
+// copyMode is the state of a session's copy mode: a snapshot of the
+// scrollback and screen taken when it was entered, a cursor and the
+// selection's anchor. Keys arrive on the update loop and the screen is
+// drawn by the renderer, so the state is guarded by a mutex.
+type copyMode struct {
+	mu  sync.Mutex
+	key KeyMsg
+
+	active    bool
+	snapshot  []Line // The scrollback and screen as they were when copy mode was entered
+	history   int    // Lines of scrollback in the snapshot
+	top       int    // First line of the snapshot shown
+	rows      int    // Lines shown
+	x, y      int    // Cursor, in the snapshot
+	selecting bool
+	ax, ay    int  // Where the selection started
+	rect      bool // Whether the selection is a rectangle rather than a run of text
+}
+
+func newCopyMode(key KeyMsg) *copyMode {
+	return &copyMode{key: key}
+}
+
+// handle acts on msg, returning whether it was for copy mode rather than
+// the component and the text to copy, if any. Copy mode takes its key and,
+// while active, every key; a resize leaves it, as the snapshot no longer
+// matches the screen.
+func (c *copyMode) handle(msg Msg) (handled bool, copied string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, resized := msg.(WindowSizeMsg); resized {
+		c.exit()
+		return false, ""
+	}
+	key, ok := msg.(KeyMsg)
+	if !ok {
+		return false, ""
+	}
+	if sameKey(key, c.key) {
+		if c.active {
+			c.exit()
+		} else {
+			c.active = true
+		}
+		return true, ""
+	}
+	if !c.active {
+		return false, ""
+	}
+	if c.snapshot == nil {
+		// The screen hasn't been drawn since copy mode was entered
+		if key.Type == KeyEsc {
+			c.exit()
+		}
+		return true, ""
+	}
+
+	width, height := c.size()
+	switch key.Type {
+	case KeyUp:
+		c.y--
+	case KeyDown:
+		c.y++
+	case KeyLeft:
+		c.x--
+	case KeyRight:
+		c.x++
+	case KeyHome:
+		c.x = 0
+	case KeyEnd:
+		c.x = c.lineEnd(c.y)
+	case KeyPgUp:
+		c.y -= c.rows
+	case KeyPgDown:
+		c.y += c.rows
+	case KeySpace:
+		c.toggleSelection()
+	case KeyEnter:
+		copied = c.selection()
+		c.exit()
+		return true, copied
+	case KeyEsc:
+		c.exit()
+	case KeyRunes:
+		switch string(key.Runes) {
+		case "k":
+			c.y--
+		case "j":
+			c.y++
+		case "h":
+			c.x--
+		case "l":
+			c.x++
+		case "0":
+			c.x = 0
+		case "$":
+			c.x = c.lineEnd(c.y)
+		case "g":
+			c.y = 0
+		case "G":
+			c.y = height - 1
+		case "w":
+			c.x = c.nextWord()
+		case "b":
+			c.x = c.previousWord()
+		case "v", " ":
+			c.toggleSelection()
+		case "r":
+			c.rect = !c.rect
+		case "y":
+			copied = c.selection()
+			c.exit()
+			return true, copied
+		case "q":
+			c.exit()
+		}
+	}
+	c.x = max(0, min(c.x, width-1))
+	c.y = max(0, min(c.y, height-1))
+
+	// Scroll to keep the cursor in sight
+	if c.y < c.top {
+		c.top = c.y
+	} else if c.y >= c.top+c.rows {
+		c.top = c.y - c.rows + 1
+	}
+	return true, ""
+}
+
+// exit leaves copy mode, forgetting the snapshot
+func (c *copyMode) exit() {
+	c.active, c.selecting, c.rect = false, false, false
+	c.snapshot = nil
+}
+
+// toggleSelection starts a selection at the cursor, or drops the one
+// started
+func (c *copyMode) toggleSelection() {
+	c.selecting = !c.selecting
+	c.ax, c.ay = c.x, c.y
+}
+
+// size returns the size of the snapshot
+func (c *copyMode) size() (width, height int) {
+	if len(c.snapshot) == 0 {
+		return 0, 0
+	}
+	return len(c.snapshot[0]), len(c.snapshot)
+}
+
+// rowText returns the text of row y from column x0 up to x1, without
+// trailing spaces
+func (c *copyMode) rowText(y, x0, x1 int) string {
+	if y < 0 || y >= len(c.snapshot) {
+		return ""
+	}
+	line := c.snapshot[y]
+	x0, x1 = max(0, x0), min(x1, len(line))
+	var b strings.Builder
+	for x := x0; x < x1; x++ {
+		b.WriteRune(line[x].Rune)
+		b.WriteString(line[x].Combining)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// lineEnd returns the column of the last character on row y
+func (c *copyMode) lineEnd(y int) int {
+	return max(0, len([]rune(c.rowText(y, 0, len(c.snapshot[y]))))-1)
+}
+
+// nextWord returns the column where the next word on the cursor's row
+// starts, or the cursor's if there is none
+func (c *copyMode) nextWord() int {
+	line := c.snapshot[c.y]
+	x := c.x
+	for x < len(line) && line[x].Rune != ' ' {
+		x++
+	}
+	for x < len(line) && line[x].Rune == ' ' {
+		x++
+	}
+	if x == len(line) {
+		return c.x
+	}
+	return x
+}
+
+// previousWord returns the column where the word before the cursor starts
+func (c *copyMode) previousWord() int {
+	line := c.snapshot[c.y]
+	x := c.x
+	for x > 0 && line[x-1].Rune == ' ' {
+		x--
+	}
+	for x > 0 && line[x-1].Rune != ' ' {
+		x--
+	}
+	return x
+}
+
+// bounds returns the selection's corners in reading order: from row y0 to
+// y1 and, for a rectangle, from column x0 to x1 inclusive
+func (c *copyMode) bounds() (x0, y0, x1, y1 int) {
+	x0, y0, x1, y1 = c.ax, c.ay, c.x, c.y
+	if !c.selecting {
+		// Without a selection the cursor's row is copied
+		width, _ := c.size()
+		return 0, c.y, width - 1, c.y
+	}
+	if c.rect {
+		return min(x0, x1), min(y0, y1), max(x0, x1), max(y0, y1)
+	}
+	if y1 < y0 || y1 == y0 && x1 < x0 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	return x0, y0, x1, y1
+}
+
+// selected returns whether the cell at x, y is selected
+func (c *copyMode) selected(x, y int) bool {
+	if !c.selecting {
+		return false
+	}
+	x0, y0, x1, y1 := c.bounds()
+	switch {
+	case y < y0 || y > y1:
+		return false
+	case c.rect:
+		return x >= x0 && x <= x1
+	case y == y0 && x < x0, y == y1 && x > x1:
+		return false
+	}
+	return true
+}
+
+// selection returns the selected text, or the cursor's row without a
+// selection. Rows end without trailing spaces.
+func (c *copyMode) selection() string {
+	if len(c.snapshot) == 0 {
+		return ""
+	}
+	width, _ := c.size()
+	x0, y0, x1, y1 := c.bounds()
+	rows := make([]string, 0, y1-y0+1)
+	for y := y0; y <= y1; y++ {
+		from, to := 0, width
+		if c.rect {
+			from, to = x0, x1+1
+		} else {
+			if y == y0 {
+				from = x0
+			}
+			if y == y1 {
+				to = x1 + 1
+			}
+		}
+		rows = append(rows, c.rowText(y, from, to))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// fitLine returns a copy of line padded with spaces or cut to width
+func fitLine(line Line, width int) Line {
+	fitted := make(Line, width)
+	for x := range fitted {
+		if x < len(line) {
+			fitted[x] = line[x]
+		} else {
+			fitted[x] = Cell{Rune: ' '}
+		}
+	}
+	return fitted
+}
+
+// drawOverlays draws flashes, copy mode and the debug overlay over the
+// screen, as far as they are active
+func (e *Engine) drawOverlays(screen *Screen) {
+	e.flashes.draw(screen)
+	if e.copyMode != nil {
+		e.copyMode.draw(screen)
+	}
+	if e.debug != nil {
+		e.debug.draw(screen)
+	}
+}
+
+// draw shows the snapshot in place of the screen while copy mode is
+// active, with the selection and cursor highlighted and a status in the
+// top-right corner. The first screen drawn and the scrollback then are
+// the snapshot; the cursor starts at the top of the screen.
+func (c *copyMode) draw(screen *Screen) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.active {
+		return
+	}
+	if c.snapshot == nil {
+		c.snapshot = make([]Line, 0, len(screen.scrollback)+len(screen.lines))
+		for _, line := range screen.scrollback {
+			c.snapshot = append(c.snapshot, fitLine(line, screen.width))
+		}
+		for _, line := range screen.lines {
+			c.snapshot = append(c.snapshot, append(Line(nil), line...))
+		}
+		c.history, c.top, c.rows = len(screen.scrollback), len(screen.scrollback), screen.height
+		c.x, c.y = 0, c.top
+	}
+
+	selectedStyle := NewStyle().Reverse(true)
+	cursorStyle := NewStyle().Background(Yellow).Foreground(Black)
+	for row := 0; row < screen.height && c.top+row < len(c.snapshot); row++ {
+		y := c.top + row
+		for x := 0; x < screen.width && x < len(c.snapshot[y]); x++ {
+			cell := c.snapshot[y][x]
+			switch {
+			case x == c.x && y == c.y:
+				cell.Style = cursorStyle
+			case c.selected(x, y):
+				cell.Style = selectedStyle
+			}
+			screen.lines[row][x] = cell
+		}
+	}
+
+	mode := "COPY"
+	if c.selecting && c.rect {
+		mode = "COPY RECT"
+	} else if c.selecting {
+		mode = "COPY SELECT"
+	}
+	position := fmt.Sprintf("%d,%d", c.y-c.top+1, c.x+1)
+	if c.history > 0 {
+		// How far up the scrollback the screen is, as tmux shows it
+		position += fmt.Sprintf(" [%d/%d]", c.history-c.top, c.history)
+	}
+	status := []rune(fmt.Sprintf(" %s %s ", mode, position))
+	left := max(0, screen.width-len(status))
+	for i, r := range status {
+		screen.SetCell(left+i, 0, r, selectedStyle)
+	}
+}
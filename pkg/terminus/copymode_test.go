@@ -0,0 +1,247 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCopyMode(t *testing.T) {
+	// enter returns copy mode entered over a screen showing view
+	enter := func(t *testing.T, view string) (*copyMode, *Screen) {
+		t.Helper()
+		c := newCopyMode(DefaultCopyModeKey)
+		if handled, _ := c.handle(DefaultCopyModeKey); !handled {
+			t.Fatal("Expected the key to enter copy mode")
+		}
+		screen := NewScreen(30, 3)
+		screen.RenderFromString(view)
+		c.draw(screen)
+		return c, screen
+	}
+	// press sends keys to copy mode and returns what the last copied
+	press := func(c *copyMode, keys ...KeyMsg) string {
+		copied := ""
+		for _, key := range keys {
+			_, copied = c.handle(key)
+		}
+		return copied
+	}
+	runes := func(s string) KeyMsg {
+		return KeyMsg{Type: KeyRunes, Runes: []rune(s)}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Copies the cursor's line without a selection",
+			test: func(t *testing.T) {
+				c, _ := enter(t, "first line\nsecond line")
+				if copied := press(c, runes("j"), KeyMsg{Type: KeyEnter}); copied != "second line" {
+					t.Errorf("Expected the second line, got %q", copied)
+				}
+				if c.active {
+					t.Error("Expected copying to leave copy mode")
+				}
+			},
+		},
+		{
+			name: "Copies a run of text across lines",
+			test: func(t *testing.T) {
+				c, _ := enter(t, "first line\nsecond line\nthird")
+				copied := press(c, runes("w"), runes("v"), runes("j"), runes("b"), runes("l"), runes("y"))
+				if copied != "line\nse" {
+					t.Errorf("Expected \"line\\nse\", got %q", copied)
+				}
+			},
+		},
+		{
+			name: "Copies a rectangle",
+			test: func(t *testing.T) {
+				c, _ := enter(t, "ab12cd\nef34gh\nij56kl")
+				copied := press(c, runes("l"), runes("l"), runes("v"), runes("r"),
+					KeyMsg{Type: KeyDown}, KeyMsg{Type: KeyDown}, KeyMsg{Type: KeyRight}, KeyMsg{Type: KeyEnter})
+				if copied != "12\n34\n56" {
+					t.Errorf("Expected the digits, got %q", copied)
+				}
+			},
+		},
+		{
+			name: "Freezes and highlights the screen",
+			test: func(t *testing.T) {
+				c, _ := enter(t, "frozen")
+				press(c, runes("v"), runes("l"))
+
+				screen := NewScreen(30, 3)
+				screen.RenderFromString("changed")
+				c.draw(screen)
+				if !strings.HasPrefix(screen.ToString(), "frozen") {
+					t.Errorf("Expected the snapshot, got %q", screen.ToString())
+				}
+				if !strings.Contains(strings.Split(screen.ToString(), "\n")[0], "COPY SELECT 1,2") {
+					t.Errorf("Expected the status, got %q", screen.ToString())
+				}
+				if screen.GetCell(0, 0).Style != NewStyle().Reverse(true) {
+					t.Error("Expected the selection to be highlighted")
+				}
+			},
+		},
+		{
+			name: "Leaves on Escape, the key or a resize",
+			test: func(t *testing.T) {
+				for _, msg := range []Msg{KeyMsg{Type: KeyEsc}, runes("q"), DefaultCopyModeKey, WindowSizeMsg{Width: 10, Height: 2}} {
+					c, _ := enter(t, "text")
+					handled, _ := c.handle(msg)
+					if c.active {
+						t.Errorf("Expected %v to leave copy mode", msg)
+					}
+					if _, resize := msg.(WindowSizeMsg); resize == handled {
+						t.Errorf("Expected only the resize to reach the component, got %v", handled)
+					}
+				}
+			},
+		},
+		{
+			name: "Leaves other messages to the component",
+			test: func(t *testing.T) {
+				c := newCopyMode(DefaultCopyModeKey)
+				if handled, _ := c.handle(runes("j")); handled {
+					t.Error("Expected keys to reach the component outside copy mode")
+				}
+				c.handle(DefaultCopyModeKey)
+				if handled, _ := c.handle(VisibilityMsg{Visible: true}); handled {
+					t.Error("Expected other messages to reach the component")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// clipboardComponent copies its text when initialized and counts the keys
+// it receives
+type clipboardComponent struct {
+	text string
+	keys int
+}
+
+func (c *clipboardComponent) Init() Cmd {
+	if c.text == "" {
+		return nil
+	}
+	return CopyToClipboard(c.text)
+}
+
+func (c *clipboardComponent) Update(msg Msg) (Component, Cmd) {
+	if _, ok := msg.(KeyMsg); ok {
+		c.keys++
+	}
+	return c, nil
+}
+
+func (c *clipboardComponent) View() string {
+	return "status: ok\nkeys: " + strings.Repeat("|", c.keys)
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	// dial connects to a session of component with copy mode enabled
+	dial := func(t *testing.T, component *clipboardComponent) *websocket.Conn {
+		t.Helper()
+		program := NewProgram(func() Component { return component }, WithCopyMode(DefaultCopyModeKey))
+		server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+		t.Cleanup(server.Close)
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	key := func(data map[string]interface{}) ClientMessage {
+		return ClientMessage{Type: "key", Data: data}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs, capture := captureErrors()
+				startEngine(t, &clipboardComponent{text: "copied"}, capture)
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrClipboardUnavailable) {
+						t.Errorf("Expected ErrClipboardUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Sends text to the client's clipboard",
+			test: func(t *testing.T) {
+				conn := dial(t, &clipboardComponent{text: "copied"})
+				msg := readUntil(t, conn, `"type":"clipboard"`)
+				if msg.Data["text"] != "copied" {
+					t.Errorf("Expected the text, got %v", msg.Data)
+				}
+			},
+		},
+		{
+			name: "Copies from the screen in copy mode",
+			test: func(t *testing.T) {
+				component := &clipboardComponent{}
+				conn := dial(t, component)
+				conn.WriteJSON(ClientMessage{Type: "resize", Data: map[string]interface{}{"width": 30, "height": 3}})
+				readUntil(t, conn, "status: ok")
+
+				conn.WriteJSON(key(map[string]interface{}{"keyType": "ctrl+s", "shift": true}))
+				readUntil(t, conn, "COPY 1,1")
+				for _, r := range []string{"w", "v", "$"} {
+					conn.WriteJSON(key(map[string]interface{}{"keyType": "runes", "runes": []string{r}}))
+				}
+				conn.WriteJSON(key(map[string]interface{}{"keyType": "enter"}))
+				msg := readUntil(t, conn, `"type":"clipboard"`)
+				if msg.Data["text"] != "ok" {
+					t.Errorf("Expected the selection, got %v", msg.Data)
+				}
+
+				// The keys went to copy mode, not the component
+				conn.WriteJSON(key(map[string]interface{}{"keyType": "runes", "runes": []string{"x"}}))
+				readUntil(t, conn, "keys: |")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDebugKey is the chord that toggles the debug overlay: Ctrl+Shift+D
+var DefaultDebugKey = KeyMsg{Type: KeyCtrlD, Shift: true}
+
+// FocusPather is implemented by components that can report which of their
+// children has focus. The debug overlay shows the path, e.g. "form > email".
+type FocusPather interface {
+	FocusPath() []string
+}
+
+// WithEngineDebugOverlay enables a developer overlay that is shown over the
+// view while key is toggled on. The key is not delivered to the component.
+func WithEngineDebugOverlay(key KeyMsg) EngineOption {
+	return func(e *Engine) {
+		e.debug = newDebugOverlay(key)
+	}
+}
+
+// debugOverlay collects frame and message statistics for a session and
+// draws them in a box in the top-right corner of the screen
+type debugOverlay struct {
+	mu  sync.Mutex
+	key KeyMsg
+	now func() time.Time
+
+	visible    bool
+	sessionID  string
+	width      int
+	height     int
+	lastRender time.Duration
+	focus      string
+	frames     []time.Time // Render times within the last second
+	messages   []time.Time // Message times within the last second
+	profiler   *Profiler   // Set when profiling is enabled
+}
+
+func newDebugOverlay(key KeyMsg) *debugOverlay {
+	return &debugOverlay{key: key, now: time.Now}
+}
+
+// toggle shows or hides the overlay if msg is the debug key, and reports
+// whether it was
+func (d *debugOverlay) toggle(msg Msg) bool {
+	key, ok := msg.(KeyMsg)
+	if !ok || !sameKey(key, d.key) {
+		return false
+	}
+	d.mu.Lock()
+	d.visible = !d.visible
+	d.mu.Unlock()
+	return true
+}
+
+// sameKey reports whether two keys are the same chord
+func sameKey(a, b KeyMsg) bool {
+	return a.Type == b.Type && a.Ctrl == b.Ctrl && a.Alt == b.Alt && a.Shift == b.Shift &&
+		string(a.Runes) == string(b.Runes)
+}
+
+// observe records a message delivered to the component
+func (d *debugOverlay) observe(msg Msg) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if size, ok := msg.(WindowSizeMsg); ok {
+		d.width, d.height = size.Width, size.Height
+	}
+	d.messages = d.prune(append(d.messages, d.now()))
+}
+
+// frame records a completed render and the component that was rendered
+func (d *debugOverlay) frame(component Component, took time.Duration) {
+	focus := fmt.Sprintf("%T", component)
+	if f, ok := component.(FocusPather); ok {
+		if path := f.FocusPath(); len(path) > 0 {
+			focus = strings.Join(path, " > ")
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastRender = took
+	d.focus = focus
+	d.frames = d.prune(append(d.frames, d.now()))
+}
+
+// prune drops times older than a second
+func (d *debugOverlay) prune(times []time.Time) []time.Time {
+	cutoff := d.now().Add(-time.Second)
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// lines returns the overlay text
+func (d *debugOverlay) lines() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.frames = d.prune(d.frames)
+	d.messages = d.prune(d.messages)
+	lines := []string{
+		"debug (" + keyLabel(d.key) + ")",
+		fmt.Sprintf("session %s", d.sessionID),
+		fmt.Sprintf("size    %dx%d", d.width, d.height),
+		fmt.Sprintf("fps     %d", len(d.frames)),
+		fmt.Sprintf("render  %s", d.lastRender.Round(time.Microsecond)),
+		fmt.Sprintf("msgs/s  %d", len(d.messages)),
+		fmt.Sprintf("focus   %s", d.focus),
+	}
+
+	if d.profiler != nil {
+		stats := d.profiler.Stats()
+		lines = append(lines,
+			fmt.Sprintf("view    %s", stats.View),
+			fmt.Sprintf("diff    %s", stats.Diff),
+			fmt.Sprintf("encode  %s", stats.Serialize),
+		)
+	}
+	return lines
+}
+
+// keyLabel describes a chord, e.g. "ctrl+shift+d"
+func keyLabel(k KeyMsg) string {
+	mods := ""
+	if k.Alt {
+		mods += "alt+"
+	}
+	if k.Shift {
+		mods += "shift+"
+	}
+	name := k.String()
+	if strings.HasPrefix(name, "ctrl+") {
+		return "ctrl+" + mods + strings.TrimPrefix(name, "ctrl+")
+	}
+	if k.Ctrl {
+		mods = "ctrl+" + mods
+	}
+	return mods + name
+}
+
+// draw paints the overlay onto the screen when it is visible
+func (d *debugOverlay) draw(screen *Screen) {
+	d.mu.Lock()
+	visible := d.visible
+	d.mu.Unlock()
+	if !visible {
+		return
+	}
+
+	lines := d.lines()
+	inner := 0
+	for _, line := range lines {
+		if n := len([]rune(line)); n > inner {
+			inner = n
+		}
+	}
+	if limit := screen.width - 2; inner > limit {
+		inner = limit
+	}
+	if inner < 1 {
+		return
+	}
+
+	style := NewStyle().Reverse(true)
+	left := screen.width - inner - 2
+	for y, line := range lines {
+		if y >= screen.height {
+			break
+		}
+		runes := []rune(" " + line + strings.Repeat(" ", inner))
+		for x := 0; x < inner+2; x++ {
+			screen.SetCell(left+x, y, runes[x], style)
+		}
+	}
+}
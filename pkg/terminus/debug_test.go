@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// focusComponent reports a fixed focus path
+type focusComponent struct {
+	testComponent
+}
+
+func (f *focusComponent) FocusPath() []string {
+	return []string{"form", "email"}
+}
+
+func TestDebugOverlay(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Debug key toggles the overlay without reaching the component",
+			test: func(t *testing.T) {
+				comp := &testComponent{}
+				engine := NewEngine(comp, WithEngineDebugOverlay(DefaultDebugKey))
+				renders := make(chan string, 10)
+				engine.SetRenderCallback(func(view string) { renders <- view })
+				engine.Start()
+				defer engine.Stop()
+				<-renders
+
+				engine.SendMessage(DefaultDebugKey)
+				<-renders
+				if comp.getUpdates() != 0 {
+					t.Error("Expected the debug key to be swallowed")
+				}
+				if !engine.debug.visible {
+					t.Error("Expected the overlay to be visible")
+				}
+
+				engine.SendMessage(KeyMsg{Type: KeyCtrlD})
+				<-renders
+				if comp.getUpdates() != 1 || !engine.debug.visible {
+					t.Error("Expected Ctrl+D without Shift to reach the component")
+				}
+			},
+		},
+		{
+			name: "Draws statistics in the top-right corner",
+			test: func(t *testing.T) {
+				now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+				d := newDebugOverlay(DefaultDebugKey)
+				d.now = func() time.Time { return now }
+				d.sessionID = "abc123"
+				d.visible = true
+
+				d.observe(WindowSizeMsg{Width: 60, Height: 10})
+				d.observe(KeyMsg{Type: KeyEnter})
+				d.frame(&focusComponent{}, 1500*time.Microsecond)
+				d.frame(&focusComponent{}, 2*time.Millisecond)
+
+				screen := NewScreen(60, 10)
+				screen.RenderFromString(strings.Repeat("x", 60))
+				d.draw(screen)
+				lines := strings.Split(screen.ToString(), "\n")
+
+				if !strings.HasSuffix(lines[0], " debug (ctrl+shift+d) ") || !strings.HasPrefix(lines[0], "xxx") {
+					t.Errorf("Expected the title drawn over the right of the view, got %q", lines[0])
+				}
+				for _, want := range []string{"session abc123", "size    60x10", "fps     2", "render  2ms", "msgs/s  2", "focus   form > email"} {
+					if !strings.Contains(screen.ToString(), want) {
+						t.Errorf("Expected %q in the overlay", want)
+					}
+				}
+				if screen.GetCell(59, 0).Style != NewStyle().Reverse(true) {
+					t.Error("Expected the overlay in reverse video")
+				}
+
+				now = now.Add(2 * time.Second)
+				screen.RenderFromString("")
+				d.draw(screen)
+				if !strings.Contains(screen.ToString(), "fps     0") {
+					t.Error("Expected rates to drop after a second")
+				}
+			},
+		},
+		{
+			name: "Hidden overlay draws nothing",
+			test: func(t *testing.T) {
+				screen := NewScreen(40, 5)
+				newDebugOverlay(DefaultDebugKey).draw(screen)
+				if strings.TrimSpace(screen.ToString()) != "" {
+					t.Error("Expected an empty screen")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
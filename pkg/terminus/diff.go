@@ -14,6 +14,11 @@
 
 package terminus
 
+import (
+	"sync"
+	"unicode/utf8"
+)
+
 // DiffOp represents a diff operation
 type DiffOp struct {
 	Type DiffOpType
@@ -131,30 +136,32 @@ func (d *Differ) linesEqual(y int) bool {
 	}
 	
 	for x := 0; x < len(oldLine); x++ {
-		if oldLine[x].Rune != newLine[x].Rune {
+		if oldLine[x].Rune != newLine[x].Rune || oldLine[x].Combining != newLine[x].Combining ||
+			!oldLine[x].Style.Equal(newLine[x].Style) {
 			return false
 		}
-		// For now, ignore style differences in comparison
-		// TODO: Compare styles when client supports it
 	}
 	
 	return true
 }
 
-// renderLine renders a line to a string with ANSI codes
+// renderLine renders a line to a string with ANSI codes. Each change of
+// style emits only the attributes that changed.
 func (d *Differ) renderLine(screen *Screen, y int) string {
 	if y >= screen.height {
 		return ""
 	}
 	
-	line := screen.lines[y]
-	result := ""
-	currentStyle := NewStyle()
-	
+	return renderCells(screen.lines[y])
+}
+
+// renderCells renders a line to a string with ANSI codes, without its
+// trailing spaces
+func renderCells(line Line) string {
 	// Find the last non-space character
 	lastNonSpace := -1
 	for i := len(line) - 1; i >= 0; i-- {
-		if line[i].Rune != ' ' {
+		if line[i].Rune != ' ' || line[i].Combining != "" {
 			lastNonSpace = i
 			break
 		}
@@ -166,76 +173,40 @@ func (d *Differ) renderLine(screen *Screen, y int) string {
 	}
 	
 	// Render up to last non-space
-	for x := 0; x <= lastNonSpace; x++ {
-		cell := line[x]
-		
-		// Check if style changed
-		if !stylesEqual(currentStyle, cell.Style) {
-			// Emit style change
-			result += renderStyleTransition(currentStyle, cell.Style)
-			currentStyle = cell.Style
-		}
-		
-		// Emit character
-		result += string(cell.Rune)
-	}
-	
-	// Reset style at end if needed
-	if !isDefaultStyle(currentStyle) {
-		result += "\x1b[0m"
-	}
-	
-	return result
+	return string(appendCells(make([]byte, 0, lastNonSpace+1), line[:lastNonSpace+1]))
 }
 
-// stylesEqual compares two styles for equality
-func stylesEqual(a, b Style) bool {
-	// This is a simplified comparison
-	// In a real implementation, we'd compare all style attributes
-	return a.String() == b.String()
-}
-
-// isDefaultStyle checks if a style is the default (no attributes)
-func isDefaultStyle(s Style) bool {
-	return s.String() == "Style{}"
-}
-
-// renderStyleTransition renders ANSI codes to transition from one style to another
-func renderStyleTransition(from, to Style) string {
-	// For simplicity, always reset and apply new style
-	// A more sophisticated implementation would compute minimal transitions
-	if isDefaultStyle(to) {
-		return "\x1b[0m"
-	}
-	
-	// Reset and apply new style
-	// This is inefficient but simple
-	result := "\x1b[0m"
-	
-	// Apply new style by rendering a dummy string and extracting codes
-	styled := to.Render("X")
-	if len(styled) > 1 {
-		// Find the ANSI codes
-		if styled[0] == '\x1b' {
-			// Extract everything up to 'm'
-			for i, r := range styled {
-				if r == 'm' {
-					result = styled[:i+1]
-					break
-				}
-			}
-		}
+// appendCells appends cells to dst with the ANSI codes of their styles,
+// emitting only the attributes that change from cell to cell and resetting
+// the style at the end
+func appendCells(dst []byte, cells []Cell) []byte {
+	currentStyle := NewStyle()
+	for _, cell := range cells {
+		dst = cell.Style.AppendTransition(dst, currentStyle)
+		currentStyle = cell.Style
+		dst = utf8.AppendRune(dst, cell.Rune)
+		dst = append(dst, cell.Combining...)
 	}
-	
-	return result
+	return NewStyle().AppendTransition(dst, currentStyle)
 }
 
 // ScreenDiffer manages stateful diffing between screen updates
 type ScreenDiffer struct {
 	width     int
 	height    int
+	tabWidth  int
+	wrap      Wrap
+	surfaces  []Surface
 	oldScreen *Screen
 	differ    *Differ
+	overlay   func(*Screen)
+	profile   ColorProfile
+
+	// Scrollback, guarded by mu as it is read outside of rendering
+	mu              sync.Mutex
+	scrollback      []Line
+	scrollbackLimit int
+	lastOverflow    []Line
 }
 
 // NewScreenDiffer creates a new screen differ
@@ -251,7 +222,16 @@ func NewScreenDiffer(width, height int) *ScreenDiffer {
 func (sd *ScreenDiffer) Update(content string) []DiffOp {
 	// Create new screen and render content
 	newScreen := NewScreen(sd.width, sd.height)
+	newScreen.SetTabWidth(sd.tabWidth)
+	newScreen.SetWrap(sd.wrap)
+	newScreen.overflowLimit = sd.scrollbackLimit
 	newScreen.RenderFromString(content)
+	newScreen.scrollback = sd.keepOverflow(newScreen.overflow)
+	newScreen.Compose(sd.surfaces)
+	if sd.overlay != nil {
+		sd.overlay(newScreen)
+	}
+	newScreen.degrade(sd.profile)
 	
 	// Compute diff
 	ops := sd.differ.Diff(sd.oldScreen, newScreen)
@@ -262,6 +242,39 @@ func (sd *ScreenDiffer) Update(content string) []DiffOp {
 	return ops
 }
 
+// SetOverlay sets a function that draws over each screen after the content,
+// such as the debug overlay
+func (sd *ScreenDiffer) SetOverlay(fn func(*Screen)) {
+	sd.overlay = fn
+}
+
+// SetTabWidth sets the distance between the tab stops content is rendered
+// with. Less than 1 uses DefaultTabWidth.
+func (sd *ScreenDiffer) SetTabWidth(width int) {
+	sd.tabWidth = width
+	sd.oldScreen = nil // Force full redraw on next update
+}
+
+// SetWrap sets how the lines of content wider than the screen are wrapped
+func (sd *ScreenDiffer) SetWrap(wrap Wrap) {
+	sd.wrap = wrap
+}
+
+// SetColorProfile sets the colors the client can display. The colors of
+// each screen are converted to the profile before diffing.
+func (sd *ScreenDiffer) SetColorProfile(profile ColorProfile) {
+	if profile != sd.profile {
+		sd.profile = profile
+		sd.oldScreen = nil // Force full redraw on next update
+	}
+}
+
+// SetSurfaces sets the surfaces drawn over the content of each screen,
+// below the overlay
+func (sd *ScreenDiffer) SetSurfaces(surfaces []Surface) {
+	sd.surfaces = surfaces
+}
+
 // Resize updates the screen dimensions
 func (sd *ScreenDiffer) Resize(width, height int) {
 	sd.width = width
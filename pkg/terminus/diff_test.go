@@ -15,6 +15,8 @@
 package terminus
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -194,7 +196,27 @@ func TestRenderLine(t *testing.T) {
 				return s
 			},
 			lineNum:  0,
-			expected: "\x1b[0;1mBold\x1b[0m Normal",
+			expected: "\x1b[1mBold\x1b[0m Normal",
+		},
+		{
+			name: "Changes only the attributes that differ",
+			setup: func() *Screen {
+				s := NewScreen(20, 1)
+				s.RenderFromString("\x1b[0;1;3;31mab\x1b[0;1;32mcd\x1b[0mef")
+				return s
+			},
+			lineNum:  0,
+			expected: "\x1b[1;3;31mab\x1b[23;32mcd\x1b[0mef",
+		},
+		{
+			name: "Resets when that is shorter",
+			setup: func() *Screen {
+				s := NewScreen(20, 1)
+				s.RenderFromString("\x1b[0;1;3;4;31mab\x1b[0;32mcd")
+				return s
+			},
+			lineNum:  0,
+			expected: "\x1b[1;3;4;31mab\x1b[0;32mcd\x1b[0m",
 		},
 	}
 	
@@ -339,4 +361,42 @@ func contains(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+func TestScreenDifferStyleChange(t *testing.T) {
+	differ := NewScreenDiffer(10, 2)
+	differ.Update("ab\ncd")
+	ops := differ.Update("\x1b[0;1mab\x1b[0m\ncd")
+	if len(ops) != 1 {
+		t.Fatalf("Expected the restyled line to be sent, got %d ops", len(ops))
+	}
+	if line := ops[0].Data.(UpdateLineOp); line.Y != 0 || line.Content != "\x1b[1mab\x1b[0m" {
+		t.Errorf("Unexpected update %+v", line)
+	}
+}
+
+// BenchmarkDiffLargeScreen diffs a full, styled 200x60 screen against a frame
+// in which every other line changed
+func BenchmarkDiffLargeScreen(b *testing.B) {
+	frame := func(n int) string {
+		style := NewStyle().Foreground(Cyan).Bold(true)
+		lines := make([]string, 60)
+		for y := range lines {
+			label := fmt.Sprintf("row %03d", y)
+			if y%2 == 0 {
+				label = fmt.Sprintf("row %03d frame %d", y, n)
+			}
+			lines[y] = style.Render(label) + " " + strings.Repeat("·", 180)
+		}
+		return strings.Join(lines, "\n")
+	}
+	frames := []string{frame(0), frame(1)}
+
+	differ := NewScreenDiffer(200, 60)
+	differ.Update(frames[0])
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		differ.Update(frames[(i+1)%2])
+	}
+}
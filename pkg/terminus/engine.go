@@ -17,35 +17,124 @@ package terminus
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // Engine manages the MVU (Model-View-Update) lifecycle for a component
 type Engine struct {
 	component Component
 	msgQueue  chan Msg
+	priority  chan Msg // Quit and resize, handled before msgQueue
 	processor *CommandProcessor
 	ctx       context.Context
 	cancel    context.CancelFunc
 	wg        sync.WaitGroup
 	mu        sync.RWMutex
+	quit      *QuitMsg // How the component quit, once it has
 	
 	// Callbacks
-	onRender func(view string)
-	onQuit   func()
+	onRender     func(view string)
+	onQuit       func()
+	onShare      func(collaborative bool) string
+	onScreenshot func(req screenshotRequestMsg) Cmd
+	onScrollback func() ScrollbackMsg
+	toClient     func(msg ServerMessage) bool       // Sends a control message to the client
+	emitToClient func(typ string, data []byte) bool // Sends a message of a registered type
+
+	// Configuration
+	poolConfig WorkerPoolConfig
+	debug      *debugOverlay
+	lint       *styleLint
+	copyMode   *copyMode
+	profile    *frameProfile
+	recordDir  string
+
+	resizeDebounce      time.Duration
+	minWidth, minHeight int
+	tabWidth            int
+	wrap                Wrap
+	shortcuts           []Shortcut
+	maxPendingFrames    int
+	reconnectWindow     time.Duration
+	heartbeatInterval   time.Duration
+	heartbeatTimeout    time.Duration
+	applyGlyphWidths    bool
+	scrollbackLines     int
+	colorProfile        *ColorProfile
+	roles               RoleSet
+	onClientError       []func(ClientError)
+	middleware []MessageMiddleware
+	handler    Handler
+
+	cmdMiddleware []CommandMiddleware
+	cmdHandler    CommandHandler
+
+	// Click messages of notifications, oldest first; used only by the
+	// update loop
+	notifications     map[string]Msg
+	notificationOrder []string
+
+	// Pending smart ticks and whether the client can see the session; used
+	// only by the update loop
+	smartTicks      map[*smartTick]struct{}
+	hidden, offline bool
+
+	// Whether a frame of the animation clock is coming; used only by the
+	// update loop
+	frameRequested bool
+
+	// Regions being flashed over the view
+	flashes flashes
+
+	// Whether the client was told to confirm closing the tab; used only by
+	// the update loop
+	guarded bool
+}
+
+// EngineOption configures an Engine
+type EngineOption func(*Engine)
+
+// WithEngineWorkerPool sets the worker pool used to execute commands
+func WithEngineWorkerPool(config WorkerPoolConfig) EngineOption {
+	return func(e *Engine) {
+		e.poolConfig = config
+	}
 }
 
 // NewEngine creates a new MVU engine with the given component
-func NewEngine(component Component) *Engine {
-	ctx, cancel := context.WithCancel(context.Background())
+func NewEngine(component Component, opts ...EngineOption) *Engine {
+	ctx, cancel := context.WithCancel(withSessionScope(context.Background()))
 	e := &Engine{
-		component: component,
-		msgQueue:  make(chan Msg, 100),
-		ctx:       ctx,
-		cancel:    cancel,
+		component:  component,
+		msgQueue:   make(chan Msg, 100),
+		priority:   make(chan Msg, 16),
+		ctx:        ctx,
+		cancel:     cancel,
+		poolConfig: DefaultWorkerPoolConfig(),
+
+		resizeDebounce:    DefaultResizeDebounce,
+		reconnectWindow:   DefaultReconnectWindow,
+		heartbeatInterval: DefaultHeartbeatInterval,
+		heartbeatTimeout:  DefaultHeartbeatTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(e)
 	}
+	if e.debug != nil && e.profile != nil {
+		e.debug.profiler = e.profile.profiler
+	}
+	e.checkShortcuts()
+	e.handler = chain(e.update, e.middleware)
+	e.cmdHandler = chainCommands(execHandler, e.cmdMiddleware)
 	
-	// Create command processor with callback to send messages
-	e.processor = NewCommandProcessor(4, e.SendMessage)
+	// Create command processor with callback to deliver command results
+	e.processor = NewCommandProcessorWithConfig(e.poolConfig, e.deliver)
+	if len(e.cmdMiddleware) > 0 {
+		e.processor.runner = func(cmd Cmd) Msg {
+			return e.runCommand(cmd, CommandInfo{Name: funcName(cmd)})
+		}
+	}
 	
 	return e
 }
@@ -55,6 +144,12 @@ func (e *Engine) SetRenderCallback(fn func(view string)) {
 	e.onRender = fn
 }
 
+// setSessionID records the ID of the session that owns the engine, for
+// SessionID
+func (e *Engine) setSessionID(id string) {
+	scopeFrom(e.ctx).setID(id)
+}
+
 // SetQuitCallback sets the function to call when the engine quits
 func (e *Engine) SetQuitCallback(fn func()) {
 	e.onQuit = fn
@@ -71,7 +166,10 @@ func (e *Engine) Start() error {
 
 	// Initialize the component
 	if cmd := e.component.Init(); cmd != nil {
-		e.processor.Execute(cmd)
+		e.execute(cmd)
+	}
+	if e.roles != nil {
+		e.SendMessage(RolesMsg{Roles: e.roles})
 	}
 
 	// Render initial view
@@ -88,63 +186,311 @@ func (e *Engine) Stop() {
 	close(e.msgQueue)
 }
 
-// SendMessage sends a message to the component
+// SendMessage sends a message to the component. Quit and resize messages
+// are handled before any messages already waiting.
 func (e *Engine) SendMessage(msg Msg) {
+	queue := e.msgQueue
+	if isPriority(msg) {
+		queue = e.priority
+	}
 	select {
-	case e.msgQueue <- msg:
+	case queue <- msg:
 	case <-e.ctx.Done():
 	}
 }
 
+// deliver routes a command result to the update loop. Stream commands are
+// run in their own goroutine and may deliver several messages, and the
+// commands of a batch are each executed.
+func (e *Engine) deliver(msg Msg) {
+	if batch, ok := msg.(batchMsg); ok {
+		for _, cmd := range batch {
+			e.execute(cmd)
+		}
+		return
+	}
+	s, ok := msg.(streamMsg)
+	if !ok {
+		e.SendMessage(msg)
+		return
+	}
+
+	if !e.processor.acquireStream() {
+		e.SendMessage(ErrMsg{Err: ErrTooManyStreams, Source: "Stream"})
+		return
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		defer e.processor.releaseStream()
+		body := func() Msg { return s.run(e.ctx, e.SendMessage) }
+		if final := e.runCommand(body, CommandInfo{Name: funcName(s.fn), Stream: true}); final != nil {
+			e.deliver(final)
+		}
+	}()
+}
+
+// runCommand runs cmd through the command middleware. A panic in the
+// middleware is reported like a panic in the command.
+func (e *Engine) runCommand(cmd Cmd, info CommandInfo) (msg Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = ErrMsg{Err: newPanicError(r), Source: info.Name}
+		}
+	}()
+	return e.cmdHandler(e.ctx, cmd, info)
+}
+
+// execute queues a command on the worker pool. A command rejected because the
+// queue is full is reported to the component as an ErrMsg, unless the message
+// queue is also full.
+func (e *Engine) execute(cmd Cmd) {
+	if err := e.processor.Execute(cmd); err != nil {
+		select {
+		case e.msgQueue <- ErrMsg{Err: err, Source: "Engine"}:
+		default:
+		}
+	}
+}
+
+// Metrics returns a snapshot of the engine's command worker pool
+func (e *Engine) Metrics() WorkerPoolMetrics {
+	return e.processor.Metrics()
+}
+
 // processMessages handles the main update loop
 func (e *Engine) processMessages() {
 	defer e.wg.Done()
 
 	for {
+		// Priority messages go first, then whichever arrives
+		var msg Msg
 		select {
-		case msg, ok := <-e.msgQueue:
-			if !ok {
-				return
-			}
-
-			// Check for quit message
-			if _, isQuit := msg.(QuitMsg); isQuit {
-				if e.onQuit != nil {
-					e.onQuit()
+		case msg = <-e.priority:
+		default:
+			select {
+			case msg = <-e.priority:
+			case queued, ok := <-e.msgQueue:
+				if !ok {
+					return
 				}
-				e.cancel()
+				msg = queued
+			case <-e.ctx.Done():
 				return
 			}
+		}
+		if !e.handleMessage(msg) {
+			return
+		}
+	}
+}
 
-			// Update the component
-			e.mu.Lock()
-			newComponent, cmd := e.component.Update(msg)
-			e.component = newComponent
-			e.mu.Unlock()
+// handleMessage updates the component with msg and renders it, reporting
+// false once the engine has quit
+func (e *Engine) handleMessage(msg Msg) bool {
+	// Check for quit message, which the component may intercept
+	msg = e.guardQuit(msg)
+	switch msg.(type) {
+	case QuitMsg, endSessionMsg:
+		if quit, ok := msg.(QuitMsg); ok {
+			e.exit(quit)
+		}
+		if e.onQuit != nil {
+			e.onQuit()
+		}
+		e.cancel()
+		return false
+	}
 
-			// Execute any resulting command
-			if cmd != nil {
-				e.processor.Execute(cmd)
-			}
+	// Share requests are answered with the session's link
+	if req, isShare := msg.(shareRequestMsg); isShare {
+		msg = e.shareLink(req)
+	}
+
+	// Scrollback requests are answered with the session's scrollback
+	if _, isScrollback := msg.(scrollbackRequestMsg); isScrollback {
+		msg = e.readScrollback()
+	}
 
-			// Render the new view
+	// Roles requests are answered with the session's roles, if it has any
+	if _, isRoles := msg.(rolesRequestMsg); isRoles {
+		if e.roles == nil {
+			return true
+		}
+		msg = RolesMsg{Roles: e.roles}
+	}
+
+	// Export requests are answered with a snapshot of the view
+	if req, isExport := msg.(exportRequestMsg); isExport {
+		msg = e.exportHTML(req)
+	}
+
+	// Print requests send the client a printable page of the view
+	if req, isPrint := msg.(printRequestMsg); isPrint {
+		if msg = e.printView(req); msg == nil {
+			return true
+		}
+	}
+
+	// Messages for the page go straight to the client
+	if req, isSend := msg.(clientSendMsg); isSend {
+		if msg = e.sendToClient(req); msg == nil {
+			return true
+		}
+	}
+
+	// Files go to the client to download, and file pickers open there
+	if req, isDownload := msg.(downloadRequestMsg); isDownload {
+		if msg = e.download(req); msg == nil {
+			return true
+		}
+	}
+	if req, isUpload := msg.(uploadRequestMsg); isUpload {
+		if msg = e.upload(req); msg == nil {
+			return true
+		}
+	}
+
+	// So does text for the clipboard
+	if req, isClipboard := msg.(clipboardRequestMsg); isClipboard {
+		if msg = e.copyToClipboard(req); msg == nil {
+			return true
+		}
+	}
+
+	// So do messages of registered types
+	if req, isEmit := msg.(emitMsg); isEmit {
+		if msg = e.emit(req); msg == nil {
+			return true
+		}
+	}
+
+	// Notifications go to the client, which reports clicks on them
+	if req, isNotify := msg.(notifyRequestMsg); isNotify {
+		if msg = e.notify(req); msg == nil {
+			return true
+		}
+	}
+	if ev, isEvent := msg.(notificationEventMsg); isEvent {
+		if msg = e.notificationEvent(ev); msg == nil {
+			return true
+		}
+	}
+
+	// Smart ticks wait while the client can't see the session
+	e.observeActivity(msg)
+	if req, isSmartTick := msg.(smartTickRequestMsg); isSmartTick {
+		e.scheduleSmartTick(req)
+		return true
+	}
+	if ev, isFired := msg.(smartTickFiredMsg); isFired {
+		if msg = e.fireSmartTick(ev); msg == nil {
+			return true
+		}
+	}
+
+	// Frame requests share one timer, which sends a single FrameMsg
+	if _, isFrame := msg.(frameRequestMsg); isFrame {
+		e.requestFrame()
+		return true
+	}
+	if tick, isTick := msg.(frameTickMsg); isTick {
+		e.frameRequested = false
+		if e.flashes.expire(tick.time) {
+			e.requestFrame()
+		}
+		msg = FrameMsg{Time: tick.time}
+	}
+
+	// Flashes are drawn over the view until the frame after they end
+	if req, isFlash := msg.(flashRequestMsg); isFlash {
+		e.startFlash(req)
+		e.render()
+		return true
+	}
+
+	// Screenshot requests go to the client, and the image comes back as
+	// the result of a command
+	if req, isScreenshot := msg.(screenshotRequestMsg); isScreenshot {
+		if msg = e.requestScreenshot(req); msg == nil {
+			return true
+		}
+	}
+
+	// The debug key toggles the overlay instead of reaching the component
+	if e.debug != nil {
+		if e.debug.toggle(msg) {
+			e.render()
+			return true
+		}
+		e.debug.observe(msg)
+	}
+	if e.lint != nil {
+		e.lint.observe(msg)
+	}
+
+	// Copy mode takes its key, and every key while it is active
+	if e.copyMode != nil {
+		if handled, copied := e.copyMode.handle(msg); handled {
+			if copied != "" {
+				e.copyToClipboard(clipboardRequestMsg{text: copied})
+			}
 			e.render()
+			return true
+		}
+	}
 
-		case <-e.ctx.Done():
-			return
+	// Global shortcuts are matched before the component sees the key
+	if key, isKey := msg.(KeyMsg); isKey {
+		if shortcut, ok := e.shortcut(key); ok {
+			msg = shortcut
 		}
 	}
+
+	// Update the component through the middleware and execute any
+	// resulting command
+	if cmd := e.handler(e.ctx, msg); cmd != nil {
+		e.execute(cmd)
+	}
+	e.syncQuitGuard(msg)
+
+	// Render the new view
+	e.render()
+	return true
 }
 
+// update delivers a message to the component. It is the innermost handler
+// of the middleware chain.
+func (e *Engine) update(ctx context.Context, msg Msg) Cmd {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	newComponent, cmd := e.component.Update(msg)
+	e.component = newComponent
+	return cmd
+}
 
 // render calls the view method and invokes the render callback
 func (e *Engine) render() {
+	start := time.Now()
 	e.mu.RLock()
-	view := e.component.View()
+	component := e.component
+	e.profile.begin(component)
+	var view string
+	e.profile.measure(PhaseView, func() {
+		view = component.View()
+	})
+	if e.lint != nil {
+		e.lint.check(component, view)
+	}
 	e.mu.RUnlock()
 
 	if e.onRender != nil {
 		e.onRender(view)
 	}
-}
+	e.profile.end()
 
+	if e.debug != nil {
+		e.debug.frame(component, time.Since(start))
+	}
+}
\ No newline at end of file
@@ -15,6 +15,7 @@
 package terminus
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -284,6 +285,54 @@ func TestEngineLifecycle(t *testing.T) {
 				engine.Stop()
 			},
 		},
+		{
+			name: "Stream commands deliver every message",
+			test: func(t *testing.T) {
+				comp := &testComponent{
+					initCmd: Stream(func(ctx context.Context, send func(Msg)) Msg {
+						send(testMsg{value: "first"})
+						send(testMsg{value: "second"})
+						return testMsg{value: "final"}
+					}),
+				}
+				engine := NewEngine(comp)
+				engine.Start()
+
+				time.Sleep(30 * time.Millisecond)
+
+				if comp.getState() != "final" {
+					t.Errorf("Expected state 'final', got '%s'", comp.getState())
+				}
+				if comp.getUpdates() != 3 {
+					t.Errorf("Expected 3 updates, got %d", comp.getUpdates())
+				}
+
+				engine.Stop()
+			},
+		},
+		{
+			name: "Stream context is cancelled on stop",
+			test: func(t *testing.T) {
+				stopped := make(chan struct{})
+				comp := &testComponent{
+					initCmd: Stream(func(ctx context.Context, send func(Msg)) Msg {
+						<-ctx.Done()
+						close(stopped)
+						return nil
+					}),
+				}
+				engine := NewEngine(comp)
+				engine.Start()
+				time.Sleep(10 * time.Millisecond)
+				engine.Stop()
+
+				select {
+				case <-stopped:
+				case <-time.After(100 * time.Millisecond):
+					t.Error("Stream context was not cancelled")
+				}
+			},
+		},
 	}
 	
 	for _, tt := range tests {
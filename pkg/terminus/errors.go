@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ErrMsg is the standard message for a failed command. It is delivered when a
+// command panics, returns a bare error, or fails inside Try.
+type ErrMsg struct {
+	Err    error
+	Source string // Name of the command that failed, when known
+}
+
+// Error implements the error interface
+func (e ErrMsg) Error() string {
+	if e.Err == nil {
+		return "unknown error"
+	}
+	if e.Source == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Source, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e ErrMsg) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error carried by ErrMsg when a command panics
+type PanicError struct {
+	Value interface{}
+	Stack string
+}
+
+// Error implements the error interface
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("command panicked: %v", p.Value)
+}
+
+// Try wraps a function returning (Msg, error) as a command. A non-nil error
+// is delivered as an ErrMsg, otherwise the message is delivered as-is.
+func Try(fn func() (Msg, error)) Cmd {
+	if fn == nil {
+		return nil
+	}
+	source := funcName(fn)
+	return func() Msg {
+		msg, err := fn()
+		if err != nil {
+			return ErrMsg{Err: err, Source: source}
+		}
+		return msg
+	}
+}
+
+// execCmd runs cmd, converting panics and bare errors into ErrMsg
+func execCmd(cmd Cmd) (msg Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = ErrMsg{Err: newPanicError(r), Source: funcName(cmd)}
+		}
+	}()
+	return toErrMsg(cmd(), cmd)
+}
+
+// toErrMsg wraps a bare error result in an ErrMsg attributed to fn
+func toErrMsg(msg Msg, fn interface{}) Msg {
+	err, ok := msg.(error)
+	if !ok {
+		return msg
+	}
+	var errMsg ErrMsg
+	if errors.As(err, &errMsg) {
+		return msg
+	}
+	return ErrMsg{Err: err, Source: funcName(fn)}
+}
+
+// newPanicError captures a recovered panic value with the current stack
+func newPanicError(r interface{}) *PanicError {
+	buf := make([]byte, 4096)
+	n := runtime.Stack(buf, false)
+	return &PanicError{Value: r, Stack: string(buf[:n])}
+}
+
+// funcName returns a short name for a function value, such as
+// "main.(*Model).fetch.func1", or "" if it can't be determined
+func funcName(fn interface{}) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
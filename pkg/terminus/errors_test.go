@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func failingFetch() (Msg, error) {
+	return nil, errors.New("fetch failed")
+}
+
+func TestErrMsg(t *testing.T) {
+	base := errors.New("boom")
+	msg := ErrMsg{Err: base, Source: "main.load"}
+
+	if msg.Error() != "main.load: boom" {
+		t.Errorf("Unexpected error string: %q", msg.Error())
+	}
+	if !errors.Is(msg, base) {
+		t.Error("ErrMsg should unwrap to the underlying error")
+	}
+	if (ErrMsg{Err: base}).Error() != "boom" {
+		t.Error("ErrMsg without source should use the bare error text")
+	}
+	if (ErrMsg{}).Error() != "unknown error" {
+		t.Error("Empty ErrMsg should report unknown error")
+	}
+}
+
+func TestTry(t *testing.T) {
+	t.Run("Error becomes ErrMsg", func(t *testing.T) {
+		msg := Try(failingFetch)()
+
+		errMsg, ok := msg.(ErrMsg)
+		if !ok {
+			t.Fatalf("Expected ErrMsg, got %T", msg)
+		}
+		if errMsg.Err.Error() != "fetch failed" {
+			t.Errorf("Unexpected error: %v", errMsg.Err)
+		}
+		if !strings.HasSuffix(errMsg.Source, "failingFetch") {
+			t.Errorf("Expected source to name the function, got %q", errMsg.Source)
+		}
+	})
+
+	t.Run("Success passes message through", func(t *testing.T) {
+		msg := Try(func() (Msg, error) {
+			return testMsg{value: "ok"}, nil
+		})()
+
+		if msg != (testMsg{value: "ok"}) {
+			t.Errorf("Expected success message, got %v", msg)
+		}
+	})
+
+	t.Run("Nil function", func(t *testing.T) {
+		if Try(nil) != nil {
+			t.Error("Try(nil) should return nil")
+		}
+	})
+}
+
+func TestExecCmd(t *testing.T) {
+	t.Run("Panic becomes ErrMsg", func(t *testing.T) {
+		msg := execCmd(func() Msg {
+			panic("kaboom")
+		})
+
+		errMsg, ok := msg.(ErrMsg)
+		if !ok {
+			t.Fatalf("Expected ErrMsg, got %T", msg)
+		}
+		var panicErr *PanicError
+		if !errors.As(errMsg.Err, &panicErr) {
+			t.Fatalf("Expected PanicError, got %T", errMsg.Err)
+		}
+		if panicErr.Value != "kaboom" || panicErr.Stack == "" {
+			t.Errorf("Unexpected panic info: %+v", panicErr)
+		}
+		if errMsg.Source == "" {
+			t.Error("Expected panic source to be recorded")
+		}
+	})
+
+	t.Run("Bare error becomes ErrMsg", func(t *testing.T) {
+		base := errors.New("bad")
+		msg := execCmd(func() Msg { return base })
+
+		errMsg, ok := msg.(ErrMsg)
+		if !ok || errMsg.Err != base {
+			t.Errorf("Expected ErrMsg wrapping the error, got %#v", msg)
+		}
+	})
+
+	t.Run("ErrMsg is not wrapped twice", func(t *testing.T) {
+		orig := ErrMsg{Err: errors.New("bad"), Source: "custom"}
+		if msg := execCmd(func() Msg { return orig }); msg != orig {
+			t.Errorf("Expected original ErrMsg, got %#v", msg)
+		}
+	})
+
+	t.Run("Regular messages are untouched", func(t *testing.T) {
+		if msg := execCmd(func() Msg { return testMsg{value: "x"} }); msg != (testMsg{value: "x"}) {
+			t.Errorf("Unexpected message %#v", msg)
+		}
+	})
+
+	t.Run("Stream panic becomes ErrMsg", func(t *testing.T) {
+		cmd := Stream(func(ctx context.Context, send func(Msg)) Msg {
+			panic("stream failed")
+		})
+		if _, ok := runCmd(context.Background(), cmd, func(Msg) {}).(ErrMsg); !ok {
+			t.Error("Expected stream panic to become ErrMsg")
+		}
+	})
+}
+
+func TestEnginePanickingCommand(t *testing.T) {
+	received := make(chan Msg, 1)
+	comp := &recordingComponent{
+		initCmd: func() Msg { panic("init failed") },
+		onMsg: func(msg Msg) {
+			if _, ok := msg.(ErrMsg); ok {
+				received <- msg
+			}
+		},
+	}
+
+	engine := NewEngine(comp)
+	engine.Start()
+	defer engine.Stop()
+
+	select {
+	case <-received:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Panicking command should be delivered as ErrMsg")
+	}
+}
+
+// recordingComponent forwards every message it receives to onMsg
+type recordingComponent struct {
+	initCmd Cmd
+	onMsg   func(Msg)
+}
+
+func (r *recordingComponent) Init() Cmd { return r.initCmd }
+
+func (r *recordingComponent) Update(msg Msg) (Component, Cmd) {
+	if r.onMsg != nil {
+		r.onMsg(msg)
+	}
+	return r, nil
+}
+
+func (r *recordingComponent) View() string { return "" }
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "time"
+
+// exitFlushTimeout is how long a quitting session waits for its exit screen
+// to reach the client
+var exitFlushTimeout = time.Second
+
+// QuitWithMessage returns a command that ends the session like Quit,
+// leaving view in the browser as its exit screen. The client then offers to
+// start a new session instead of reconnecting on its own.
+func QuitWithMessage(view string) Cmd {
+	return QuitWithCode(0, view)
+}
+
+// QuitWithCode is like QuitWithMessage, also reporting code to
+// WithOnSessionEnd hooks. An empty view leaves the last view on screen.
+func QuitWithCode(code int, view string) Cmd {
+	return func() Msg {
+		return QuitMsg{View: view, Code: code}
+	}
+}
+
+// SessionEnd describes how a session ended, for WithOnSessionEnd hooks
+type SessionEnd struct {
+	ID       string
+	Quit     bool // The component quit, rather than its client leaving or the program stopping
+	ExitCode int  // The code the component quit with
+}
+
+// exit records how the component quit and draws its exit screen
+func (e *Engine) exit(quit QuitMsg) {
+	e.mu.Lock()
+	e.quit = &quit
+	e.mu.Unlock()
+	if quit.View != "" && e.onRender != nil {
+		e.onRender(quit.View)
+	}
+}
+
+// ExitCode returns the code the session's component quit with, reporting
+// false if it didn't quit
+func (s *Session) ExitCode() (int, bool) {
+	s.engine.mu.RLock()
+	defer s.engine.mu.RUnlock()
+	if s.engine.quit == nil {
+		return 0, false
+	}
+	return s.engine.quit.Code, true
+}
+
+// flush stops queueing messages for the client and waits up to timeout for
+// those already queued to be sent
+func (s *Session) flush(timeout time.Duration) {
+	s.mu.RLock()
+	current := s.current
+	s.mu.RUnlock()
+
+	s.outgoing.close()
+	if current == nil {
+		return
+	}
+	select {
+	case <-current.flushed:
+	case <-time.After(timeout):
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// exitComponent quits with an exit screen when q is pressed
+type exitComponent struct{}
+
+func (c *exitComponent) Init() Cmd {
+	return nil
+}
+
+func (c *exitComponent) Update(msg Msg) (Component, Cmd) {
+	if key, ok := msg.(KeyMsg); ok && key.String() == "q" {
+		return c, QuitWithCode(3, "goodbye")
+	}
+	return c, nil
+}
+
+func (c *exitComponent) View() string {
+	return "running"
+}
+
+// startExitServer serves exitComponents, reporting how each session ended
+func startExitServer(t *testing.T, opts ...ProgramOption) (*websocket.Conn, chan SessionEnd) {
+	t.Helper()
+	ended := make(chan SessionEnd, 1)
+	opts = append(opts, WithOnSessionEnd(func(end SessionEnd) { ended <- end }))
+	program := NewProgram(func() Component { return &exitComponent{} }, opts...)
+	server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+	t.Cleanup(func() {
+		server.Close()
+		program.Stop()
+	})
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	readUntil(t, conn, "running")
+	return conn, ended
+}
+
+// expectEnd waits for a session to end
+func expectEnd(t *testing.T, ended chan SessionEnd) SessionEnd {
+	t.Helper()
+	select {
+	case end := <-ended:
+		return end
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the session to end")
+		return SessionEnd{}
+	}
+}
+
+func TestQuitWithMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Sends the exit screen before closing",
+			test: func(t *testing.T) {
+				conn, ended := startExitServer(t)
+				conn.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "runes", "runes": []string{"q"}}})
+
+				// The exit screen's frame and the exit message are both sent,
+				// in either order, before the connection closes
+				var exit *ServerMessage
+				goodbye := false
+				conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+				for {
+					_, data, err := conn.ReadMessage()
+					if err != nil {
+						break
+					}
+					var msg ServerMessage
+					if json.Unmarshal(data, &msg) == nil && msg.Type == "exit" {
+						exit = &msg
+					}
+					goodbye = goodbye || strings.Contains(string(data), "goodbye")
+				}
+				if exit == nil || !goodbye {
+					t.Fatalf("Expected the exit message and screen before the close, got %v and %v", exit, goodbye)
+				}
+				if code, _ := exit.Data["code"].(float64); code != 3 {
+					t.Errorf("Expected exit code 3, got %v", exit.Data["code"])
+				}
+
+				end := expectEnd(t, ended)
+				if !end.Quit || end.ExitCode != 3 || end.ID == "" {
+					t.Errorf("Expected the session to quit with code 3, got %+v", end)
+				}
+			},
+		},
+		{
+			name: "Reports sessions whose client left",
+			test: func(t *testing.T) {
+				conn, ended := startExitServer(t, WithReconnectWindow(0))
+				conn.Close()
+
+				if end := expectEnd(t, ended); end.Quit || end.ExitCode != 0 {
+					t.Errorf("Expected the session not to have quit, got %+v", end)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
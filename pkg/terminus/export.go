@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTMLExportMsg is sent in response to ExportHTML
+type HTMLExportMsg struct {
+	HTML string // A standalone HTML document
+}
+
+// exportRequestMsg asks the engine for an HTML snapshot of the view
+type exportRequestMsg struct {
+	title string
+}
+
+// ExportHTML returns a command that snapshots the current view as a
+// standalone HTML page with its colors and text attributes, e.g. to save or
+// email a dashboard. The component receives an HTMLExportMsg.
+func ExportHTML(title string) Cmd {
+	return func() Msg {
+		return exportRequestMsg{title: title}
+	}
+}
+
+// exportHTML answers an export request with the component's view
+func (e *Engine) exportHTML(req exportRequestMsg) Msg {
+	e.mu.RLock()
+	view := e.component.View()
+	e.mu.RUnlock()
+	return HTMLExportMsg{HTML: RenderHTML(view, req.title)}
+}
+
+// RenderHTML converts a view, or a recorded frame from Cast.FrameAt, into a
+// standalone HTML document. Styled text becomes spans with inline CSS;
+// the page needs no scripts or external stylesheets.
+func RenderHTML(view, title string) string {
+	return fmt.Sprintf(htmlExportTemplate, html.EscapeString(title), htmlBody(view))
+}
+
+// htmlBody converts a view into HTML text with styled spans
+func htmlBody(view string) string {
+	var body strings.Builder
+	parser := NewANSIParser(view)
+	open := ""
+	for {
+		r, style, ok := parser.Next()
+		if !ok {
+			break
+		}
+		css := style.CSS()
+		if r == '\n' {
+			css = open // Keep a span open across lines rather than splitting it
+		}
+		if css != open {
+			if open != "" {
+				body.WriteString("</span>")
+			}
+			if css != "" {
+				fmt.Fprintf(&body, `<span style="%s">`, html.EscapeString(css))
+			}
+			open = css
+		}
+		body.WriteString(html.EscapeString(string(r)))
+	}
+	if open != "" {
+		body.WriteString("</span>")
+	}
+	return body.String()
+}
+
+// htmlExportTemplate is the page around an exported view, in the colors of
+// the web client
+const htmlExportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>%s</title>
+<style>
+:root { --terminus-fg: #d4d4d4; --terminus-bg: #1e1e1e; }
+body { margin: 0; background: var(--terminus-bg); color: var(--terminus-fg); }
+pre { margin: 0; padding: 20px; font-family: 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; line-height: 1.2; }
+</style>
+</head>
+<body>
+<pre>%s</pre>
+</body>
+</html>
+`
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     string
+		contains []string
+	}{
+		{
+			name:     "Escapes text",
+			view:     "a < b & \"c\"",
+			contains: []string{"<pre>a &lt; b &amp; &#34;c&#34;</pre>"},
+		},
+		{
+			name:     "Styles runs of text",
+			view:     "plain " + NewStyle().Bold(true).Foreground(Red).Render("alert") + " done",
+			contains: []string{`plain <span style="color:#cd0000;font-weight:bold">alert</span> done`},
+		},
+		{
+			name:     "Keeps a span across lines",
+			view:     NewStyle().Background(Blue).Render("one\ntwo"),
+			contains: []string{`<span style="background:#0000ee">one` + "\n" + `two</span>`},
+		},
+		{
+			name:     "Escapes the title",
+			view:     "",
+			contains: []string{"<title>Q&amp;A</title>", "<pre></pre>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := RenderHTML(tt.view, "Q&A")
+			if !strings.HasPrefix(page, "<!DOCTYPE html>") {
+				t.Errorf("Expected a standalone document, got %q", page)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(page, want) {
+					t.Errorf("Expected %q in %q", want, page)
+				}
+			}
+		})
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	exports := make(chan HTMLExportMsg, 1)
+	capture := func(next Handler) Handler {
+		return func(ctx context.Context, msg Msg) Cmd {
+			if export, ok := msg.(HTMLExportMsg); ok {
+				exports <- export
+			}
+			return next(ctx, msg)
+		}
+	}
+	engine, _ := startEngine(t, &testComponent{}, WithEngineMessageMiddleware(capture))
+	engine.SendMessage(testMsg{value: "snapshot"})
+	engine.SendMessage(ExportHTML("Report")())
+
+	select {
+	case export := <-exports:
+		if !strings.Contains(export.HTML, "<pre>snapshot</pre>") || !strings.Contains(export.HTML, "<title>Report</title>") {
+			t.Errorf("Expected the current view, got %q", export.HTML)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an HTMLExportMsg")
+	}
+}
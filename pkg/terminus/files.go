@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrTransferUnavailable is reported when Download or Upload is used
+// without a connected client
+var ErrTransferUnavailable = errors.New("file transfer unavailable")
+
+// ErrUploadCancelled is reported when the user closes the file picker
+// without choosing a file
+var ErrUploadCancelled = errors.New("upload cancelled")
+
+// MaxUploadSize is the largest file Upload accepts; the browser refuses
+// larger files
+const MaxUploadSize = 8 << 20
+
+// JSONSavedMsg is sent by SaveJSON once the file is written
+type JSONSavedMsg struct {
+	Path string
+}
+
+// JSONLoadedMsg is sent by LoadJSON with the file it read
+type JSONLoadedMsg struct {
+	Path string
+	Data json.RawMessage
+}
+
+// Decode decodes the file into v
+func (m JSONLoadedMsg) Decode(v interface{}) error {
+	return json.Unmarshal(m.Data, v)
+}
+
+// UploadMsg is sent in response to Upload and UploadJSON with the file the
+// user chose
+type UploadMsg struct {
+	Filename string
+	Data     []byte
+	Err      error // Set if the file couldn't be read, or ErrUploadCancelled
+}
+
+// DecodeJSON decodes the file as JSON into v
+func (m UploadMsg) DecodeJSON(v interface{}) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	return json.Unmarshal(m.Data, v)
+}
+
+// downloadRequestMsg asks the client to save a file
+type downloadRequestMsg struct {
+	filename, mimeType string
+	data               []byte
+}
+
+// uploadRequestMsg asks the client to open a file picker
+type uploadRequestMsg struct {
+	accept string
+}
+
+// SaveJSON returns a command that writes v, encoded as indented JSON, to
+// the file at path on the server, e.g. to back up an app's data. v is
+// encoded when SaveJSON is called, so the component may change it while
+// the file is written. The file is replaced atomically. The component
+// receives a JSONSavedMsg, or an ErrMsg if the file can't be written.
+func SaveJSON(path string, v interface{}) Cmd {
+	data, err := json.MarshalIndent(v, "", "  ")
+	return func() Msg {
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("encoding %s: %w", path, err), Source: "SaveJSON"}
+		}
+		if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+			return ErrMsg{Err: err, Source: "SaveJSON"}
+		}
+		return JSONSavedMsg{Path: path}
+	}
+}
+
+// LoadJSON returns a command that reads the JSON file at path on the
+// server. The component receives a JSONLoadedMsg to decode, or an ErrMsg
+// if the file can't be read or isn't JSON.
+func LoadJSON(path string) Cmd {
+	return func() Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ErrMsg{Err: err, Source: "LoadJSON"}
+		}
+		if !json.Valid(data) {
+			return ErrMsg{Err: fmt.Errorf("%s is not valid JSON", path), Source: "LoadJSON"}
+		}
+		return JSONLoadedMsg{Path: path, Data: data}
+	}
+}
+
+// Download returns a command that has the browser save data as filename,
+// in the user's downloads. The component receives nothing unless there is
+// no client, when it receives an ErrMsg.
+func Download(filename, mimeType string, data []byte) Cmd {
+	return func() Msg {
+		return downloadRequestMsg{filename: filename, mimeType: mimeType, data: data}
+	}
+}
+
+// DownloadJSON is like Download, with v encoded as indented JSON. v is
+// encoded when DownloadJSON is called.
+func DownloadJSON(filename string, v interface{}) Cmd {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return func() Msg {
+			return ErrMsg{Err: fmt.Errorf("encoding %s: %w", filename, err), Source: "DownloadJSON"}
+		}
+	}
+	return Download(filename, "application/json", append(data, '\n'))
+}
+
+// Upload returns a command that opens the browser's file picker for files
+// matching accept, an input element's accept attribute such as ".csv" or
+// "image/*", or any file if it is empty. The component receives an
+// UploadMsg with the file, or with ErrUploadCancelled if the user chose
+// none, or an ErrMsg if there is no client. Browsers only open the picker
+// soon after a key press or click.
+func Upload(accept string) Cmd {
+	return func() Msg {
+		return uploadRequestMsg{accept: accept}
+	}
+}
+
+// UploadJSON is like Upload, for JSON files; decode the file with
+// UploadMsg.DecodeJSON
+func UploadJSON() Cmd {
+	return Upload(".json,application/json")
+}
+
+// download sends a file to the client, returning an error message for the
+// component if there is no client, and otherwise nil
+func (e *Engine) download(req downloadRequestMsg) Msg {
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "download",
+		Data: map[string]interface{}{
+			"filename": req.filename,
+			"mimeType": req.mimeType,
+			"data":     base64.StdEncoding.EncodeToString(req.data),
+		},
+	}) {
+		return ErrMsg{Err: ErrTransferUnavailable, Source: "Download"}
+	}
+	return nil
+}
+
+// upload asks the client to open a file picker, returning an error
+// message for the component if there is no client, and otherwise nil. The
+// file comes back in an upload message from the client.
+func (e *Engine) upload(req uploadRequestMsg) Msg {
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "upload",
+		Data: map[string]interface{}{
+			"accept":  req.accept,
+			"maxSize": MaxUploadSize,
+		},
+	}) {
+		return ErrMsg{Err: ErrTransferUnavailable, Source: "Upload"}
+	}
+	return nil
+}
+
+// uploadFromClient converts the file the client sent in response to
+// Upload
+func uploadFromClient(data map[string]interface{}) UploadMsg {
+	filename, _ := data["filename"].(string)
+	msg := UploadMsg{Filename: filename}
+	if cancelled, _ := data["cancelled"].(bool); cancelled {
+		msg.Err = ErrUploadCancelled
+		return msg
+	}
+	if text, _ := data["error"].(string); text != "" {
+		msg.Err = fmt.Errorf("upload failed: %s", text)
+		return msg
+	}
+	encoded, _ := data["data"].(string)
+	file, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		msg.Err = fmt.Errorf("upload failed: %w", err)
+	} else if len(file) > MaxUploadSize {
+		msg.Err = fmt.Errorf("upload failed: %s is larger than %d bytes", filename, MaxUploadSize)
+	} else {
+		msg.Data = file
+	}
+	return msg
+}
+
+// writeFileAtomic replaces the file at path with data, through a temporary
+// file so a crash never leaves half a file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// backup is the data the file tests save and restore
+type backup struct {
+	Todos []string
+}
+
+func TestSaveAndLoadJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Loads what was saved",
+			test: func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "backup.json")
+				data := backup{Todos: []string{"Write docs"}}
+				cmd := SaveJSON(path, &data)
+				data.Todos[0] = "Changed after saving"
+
+				if msg := cmd(); msg != (JSONSavedMsg{Path: path}) {
+					t.Fatalf("Expected a JSONSavedMsg, got %#v", msg)
+				}
+				loaded, ok := LoadJSON(path)().(JSONLoadedMsg)
+				if !ok {
+					t.Fatal("Expected a JSONLoadedMsg")
+				}
+				var got backup
+				if err := loaded.Decode(&got); err != nil || len(got.Todos) != 1 || got.Todos[0] != "Write docs" {
+					t.Errorf("Expected the data as it was when saved, got %+v and %v", got, err)
+				}
+			},
+		},
+		{
+			name: "Reports files that can't be saved or loaded",
+			test: func(t *testing.T) {
+				dir := t.TempDir()
+				if msg, ok := SaveJSON(filepath.Join(dir, "bad.json"), func() {})().(ErrMsg); !ok || msg.Source != "SaveJSON" {
+					t.Errorf("Expected an ErrMsg for a value that can't be encoded, got %#v", msg)
+				}
+				if _, ok := LoadJSON(filepath.Join(dir, "missing.json"))().(ErrMsg); !ok {
+					t.Error("Expected an ErrMsg for a missing file")
+				}
+				path := filepath.Join(dir, "notes.txt")
+				os.WriteFile(path, []byte("not JSON"), 0o644)
+				if _, ok := LoadJSON(path)().(ErrMsg); !ok {
+					t.Error("Expected an ErrMsg for a file that isn't JSON")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// transferComponent downloads a backup when initialized and restores the
+// one uploaded when u is pressed
+type transferComponent struct {
+	data backup
+}
+
+func (c *transferComponent) Init() Cmd {
+	return DownloadJSON("backup.json", c.data)
+}
+
+func (c *transferComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case KeyMsg:
+		if msg.String() == "u" {
+			return c, UploadJSON()
+		}
+	case UploadMsg:
+		if err := msg.DecodeJSON(&c.data); err != nil {
+			c.data.Todos = []string{err.Error()}
+		}
+	}
+	return c, nil
+}
+
+func (c *transferComponent) View() string {
+	return fmt.Sprintf("todos: %s", strings.Join(c.data.Todos, ", "))
+}
+
+func TestDownloadAndUpload(t *testing.T) {
+	// dial connects to a session of transferComponent
+	dial := func(t *testing.T) *websocket.Conn {
+		t.Helper()
+		program := NewProgram(func() Component {
+			return &transferComponent{data: backup{Todos: []string{"Write docs"}}}
+		})
+		server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+		t.Cleanup(server.Close)
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	// requestUpload presses u and waits for the file picker to open
+	requestUpload := func(t *testing.T, conn *websocket.Conn) {
+		t.Helper()
+		conn.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "runes", "runes": []string{"u"}}})
+		msg := readUntil(t, conn, `"type":"upload"`)
+		if msg.Data["accept"] != ".json,application/json" {
+			t.Errorf("Expected JSON files to be accepted, got %v", msg.Data["accept"])
+		}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs, capture := captureErrors()
+				startEngine(t, &transferComponent{}, capture)
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrTransferUnavailable) {
+						t.Errorf("Expected ErrTransferUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Sends the client a file to download",
+			test: func(t *testing.T) {
+				conn := dial(t)
+				msg := readUntil(t, conn, `"type":"download"`)
+				data, _ := base64.StdEncoding.DecodeString(msg.Data["data"].(string))
+				if msg.Data["filename"] != "backup.json" || msg.Data["mimeType"] != "application/json" ||
+					!strings.Contains(string(data), `"Write docs"`) {
+					t.Errorf("Expected the backup, got %v and %s", msg.Data, data)
+				}
+			},
+		},
+		{
+			name: "Receives the uploaded file",
+			test: func(t *testing.T) {
+				conn := dial(t)
+				requestUpload(t, conn)
+				conn.WriteJSON(ClientMessage{Type: "upload", Data: map[string]interface{}{
+					"filename": "restore.json",
+					"data":     base64.StdEncoding.EncodeToString([]byte(`{"Todos": ["Ship it"]}`)),
+				}})
+				readUntil(t, conn, "todos: Ship it")
+			},
+		},
+		{
+			name: "Reports a cancelled upload",
+			test: func(t *testing.T) {
+				conn := dial(t)
+				requestUpload(t, conn)
+				conn.WriteJSON(ClientMessage{Type: "upload", Data: map[string]interface{}{"cancelled": true}})
+				readUntil(t, conn, "todos: "+ErrUploadCancelled.Error())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
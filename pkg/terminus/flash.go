@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultFlashStyle inverts the cells of a flash, like a terminal's visual
+// bell
+var DefaultFlashStyle = NewStyle().Reverse(true)
+
+// DefaultFlashDuration is long enough for a flash to catch the eye without
+// getting in the way
+const DefaultFlashDuration = 200 * time.Millisecond
+
+// flashRequestMsg asks the engine to flash a region of the screen
+type flashRequestMsg struct {
+	region   Region
+	style    Style
+	duration time.Duration
+}
+
+// Flash returns a command that briefly draws the cells of region in style,
+// keeping their text, e.g. to draw the eye to the alerts panel when a new
+// alert arrives. The zero Region flashes the whole screen, as a visual
+// bell. The flash is drawn over the view and its surfaces, and ends on the
+// first frame of the animation clock after duration; a duration that isn't
+// positive is DefaultFlashDuration.
+//
+//	return m, terminus.Flash(m.alertsRegion, terminus.DefaultFlashStyle, terminus.DefaultFlashDuration)
+func Flash(region Region, style Style, duration time.Duration) Cmd {
+	if duration <= 0 {
+		duration = DefaultFlashDuration
+	}
+	return func() Msg {
+		return flashRequestMsg{region: region, style: style, duration: duration}
+	}
+}
+
+// flash is a region being flashed until a time
+type flash struct {
+	region Region
+	style  Style
+	until  time.Time
+}
+
+// flashes are the regions of a session being flashed. They start and end
+// on the update loop and are drawn by the renderer, so they are guarded
+// by a mutex.
+type flashes struct {
+	mu     sync.Mutex
+	active []flash
+}
+
+// add starts flashing a region
+func (f *flashes) add(req flashRequestMsg, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.active = append(f.active, flash{region: req.region, style: req.style, until: now.Add(req.duration)})
+}
+
+// expire ends the flashes that are over at now and reports whether any
+// are left
+func (f *flashes) expire(now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	active := f.active[:0]
+	for _, flash := range f.active {
+		if now.Before(flash.until) {
+			active = append(active, flash)
+		}
+	}
+	clear(f.active[len(active):])
+	f.active = active
+	return len(active) > 0
+}
+
+// draw restyles the cells of each flash, in the order they started
+func (f *flashes) draw(screen *Screen) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, flash := range f.active {
+		region := flash.region
+		if region == (Region{}) {
+			region = Region{Width: screen.width, Height: screen.height}
+		}
+		for y := max(region.Y, 0); y < min(region.Y+region.Height, screen.height); y++ {
+			for x := max(region.X, 0); x < min(region.X+region.Width, screen.width); x++ {
+				screen.lines[y][x].Style = flash.style
+			}
+		}
+	}
+}
+
+// startFlash starts a flash and the frames that end it
+func (e *Engine) startFlash(req flashRequestMsg) {
+	e.flashes.add(req, time.Now())
+	e.requestFrame()
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlash(t *testing.T) {
+	// flashed returns which cells of a screen showing view are drawn in
+	// style after the flashes
+	flashed := func(f *flashes, view string, style Style) []string {
+		screen := NewScreen(4, 2)
+		screen.RenderFromString(view)
+		f.draw(screen)
+		rows := make([]string, screen.height)
+		for y := range rows {
+			for x := 0; x < screen.width; x++ {
+				if screen.GetCell(x, y).Style.Equal(style) {
+					rows[y] += "#"
+				} else {
+					rows[y] += "."
+				}
+			}
+		}
+		return rows
+	}
+	// active returns how many flashes are drawn
+	active := func(f *flashes) int {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return len(f.active)
+	}
+	highlight := NewStyle().Background(Yellow)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Restyles the region, keeping its text",
+			test: func(t *testing.T) {
+				f := &flashes{}
+				now := time.Now()
+				f.add(flashRequestMsg{region: Region{X: 1, Y: 1, Width: 5, Height: 3}, style: highlight, duration: time.Second}, now)
+
+				rows := flashed(f, "ab\ncd", highlight)
+				if rows[0] != "...." || rows[1] != ".###" {
+					t.Errorf("Expected the region clipped to the screen, got %q", rows)
+				}
+			},
+		},
+		{
+			name: "Flashes the whole screen with the zero Region",
+			test: func(t *testing.T) {
+				f := &flashes{}
+				f.add(flashRequestMsg{style: DefaultFlashStyle, duration: time.Second}, time.Now())
+
+				screen := NewScreen(4, 2)
+				screen.RenderFromString("ab")
+				f.draw(screen)
+				if cell := screen.GetCell(0, 0); cell.Rune != 'a' || !cell.Style.Equal(DefaultFlashStyle) {
+					t.Errorf("Expected an inverted a, got %q in %v", cell.Rune, cell.Style)
+				}
+				if rows := flashed(f, "", DefaultFlashStyle); rows[0] != "####" || rows[1] != "####" {
+					t.Errorf("Expected every cell flashed, got %q", rows)
+				}
+			},
+		},
+		{
+			name: "Ends flashes that are over",
+			test: func(t *testing.T) {
+				f := &flashes{}
+				now := time.Now()
+				f.add(flashRequestMsg{duration: 100 * time.Millisecond}, now)
+				f.add(flashRequestMsg{duration: 300 * time.Millisecond}, now)
+
+				if !f.expire(now.Add(200*time.Millisecond)) || active(f) != 1 {
+					t.Errorf("Expected one flash left, got %d", active(f))
+				}
+				if f.expire(now.Add(300*time.Millisecond)) || active(f) != 0 {
+					t.Errorf("Expected no flashes left, got %d", active(f))
+				}
+			},
+		},
+		{
+			name: "Defaults the duration",
+			test: func(t *testing.T) {
+				req := Flash(Region{}, DefaultFlashStyle, 0)().(flashRequestMsg)
+				if req.duration != DefaultFlashDuration {
+					t.Errorf("Expected %v, got %v", DefaultFlashDuration, req.duration)
+				}
+			},
+		},
+		{
+			name: "Ends on a frame of the animation clock",
+			test: func(t *testing.T) {
+				engine, renders := startEngine(t, &testComponent{})
+				engine.SendMessage(Flash(Region{Width: 1, Height: 1}, highlight, 50*time.Millisecond)())
+
+				<-renders
+				if active(&engine.flashes) != 1 {
+					t.Fatal("Expected the flash to start")
+				}
+				deadline := time.After(time.Second)
+				for active(&engine.flashes) > 0 {
+					select {
+					case <-renders:
+					case <-deadline:
+						t.Fatal("Expected the flash to end")
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
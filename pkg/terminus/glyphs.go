@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "unicode/utf8"
+
+// maxGlyphWidth is the widest glyph a client may report, in cells
+const maxGlyphWidth = 2
+
+// GlyphWidthsMsg reports characters the browser draws at a different width
+// than RuneWidth gives, as measured in the user's font. The client measures
+// the characters of the frames it receives in the background, so the
+// message can arrive any time after a character is first shown.
+type GlyphWidthsMsg struct {
+	Widths map[rune]int // Cells each character takes in the browser
+}
+
+// WithEngineMeasuredGlyphWidths applies the glyph widths the client reports
+// with SetRuneWidth, before the component receives its GlyphWidthsMsg
+func WithEngineMeasuredGlyphWidths() EngineOption {
+	return func(e *Engine) {
+		e.applyGlyphWidths = true
+	}
+}
+
+// WithMeasuredGlyphWidths makes layout use the widths at which browsers
+// actually draw characters, such as emoji, instead of the built-in tables.
+// The widths are set with SetRuneWidth and so apply to every session; use it
+// when clients share a font, e.g. one the app's stylesheet provides.
+func WithMeasuredGlyphWidths() ProgramOption {
+	return func(p *Program) {
+		p.measureGlyphs = true
+	}
+}
+
+// glyphWidthsFromClient converts a report of measured glyph widths, keeping
+// the ones that differ from RuneWidth, and records them for GlyphWidths. It
+// returns nil if none differ.
+func (s *Session) glyphWidthsFromClient(data map[string]interface{}) Msg {
+	reported, _ := data["widths"].(map[string]interface{})
+	widths := make(map[rune]int)
+	for glyph, value := range reported {
+		width, ok := value.(float64)
+		r, size := utf8.DecodeRuneInString(glyph)
+		if !ok || size != len(glyph) || r == utf8.RuneError || width < 0 || width > maxGlyphWidth {
+			continue
+		}
+		if int(width) != RuneWidth(r) {
+			widths[r] = int(width)
+		}
+	}
+	if len(widths) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	if s.glyphWidths == nil {
+		s.glyphWidths = make(map[rune]int)
+	}
+	for r, width := range widths {
+		s.glyphWidths[r] = width
+	}
+	s.mu.Unlock()
+
+	if s.engine.applyGlyphWidths {
+		for r, width := range widths {
+			SetRuneWidth(r, width)
+		}
+	}
+	return GlyphWidthsMsg{Widths: widths}
+}
+
+// GlyphWidths returns the widths the client reported for characters it
+// draws differently than RuneWidth gives
+func (s *Session) GlyphWidths() map[rune]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	widths := make(map[rune]int, len(s.glyphWidths))
+	for r, width := range s.glyphWidths {
+		widths[r] = width
+	}
+	return widths
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// glyphComponent shows the glyph widths it was told about
+type glyphComponent struct {
+	widths map[rune]int
+}
+
+func (c *glyphComponent) Init() Cmd {
+	return nil
+}
+
+func (c *glyphComponent) Update(msg Msg) (Component, Cmd) {
+	if msg, ok := msg.(GlyphWidthsMsg); ok {
+		c.widths = msg.Widths
+	}
+	return c, nil
+}
+
+func (c *glyphComponent) View() string {
+	var glyphs []string
+	for r, width := range c.widths {
+		glyphs = append(glyphs, fmt.Sprintf("%U=%d", r, width))
+	}
+	sort.Strings(glyphs)
+	return "glyphs: " + strings.Join(glyphs, " ")
+}
+
+func TestGlyphWidths(t *testing.T) {
+	// report connects to a session of glyphComponent and reports the
+	// widths of some glyphs, waiting until the component has them
+	report := func(t *testing.T, opts ...ProgramOption) {
+		t.Helper()
+		program := NewProgram(func() Component { return &glyphComponent{} }, opts...)
+		server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+		t.Cleanup(server.Close)
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		readUntil(t, conn, "glyphs:")
+		conn.WriteJSON(ClientMessage{Type: "glyphs", Data: map[string]interface{}{
+			"widths": map[string]interface{}{"🍎": 1, "漢": 2, "a": 1, "★": 2, "ab": 2, "♥": 9},
+		}})
+		// Only the glyphs drawn differently than RuneWidth gives are reported
+		readUntil(t, conn, "glyphs: U+1F34E=1 U+2605=2")
+	}
+
+	t.Run("Reports glyphs the browser draws differently", func(t *testing.T) {
+		report(t)
+		if RuneWidth('★') != 1 {
+			t.Error("Expected the built-in widths to be kept")
+		}
+	})
+
+	t.Run("Applies the widths when asked to", func(t *testing.T) {
+		t.Cleanup(func() {
+			SetRuneWidth('🍎', -1)
+			SetRuneWidth('★', -1)
+		})
+		report(t, WithMeasuredGlyphWidths())
+		if RuneWidth('★') != 2 || RuneWidth('🍎') != 1 {
+			t.Errorf("Expected the measured widths, got %d and %d", RuneWidth('★'), RuneWidth('🍎'))
+		}
+	})
+}
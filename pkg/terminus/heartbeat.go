@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"time"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often an idle client is pinged
+	DefaultHeartbeatInterval = 15 * time.Second
+	// DefaultHeartbeatTimeout is how long a client may go without being
+	// heard from before its connection is considered dead
+	DefaultHeartbeatTimeout = 45 * time.Second
+)
+
+// PongConn is a Conn that hears the client's answers to pings, which
+// ReadMessage doesn't return. A session drops a PongConn whose client
+// hasn't been heard from within the heartbeat timeout, as happens when a
+// laptop is closed or its network vanishes without closing the connection.
+// Other connections are only dropped when a ping or write fails.
+type PongConn interface {
+	Conn
+	// LastPong returns when the client last answered a ping
+	LastPong() time.Time
+}
+
+// WithEngineHeartbeat sets how often the session pings its client and how
+// long the client may go unheard before its connection is dropped. The
+// session then waits out the reconnect window and ends, releasing its
+// goroutines and timers. A zero interval keeps the default; a zero timeout
+// never drops a connection that is still open.
+func WithEngineHeartbeat(interval, timeout time.Duration) EngineOption {
+	return func(e *Engine) {
+		if interval > 0 {
+			e.heartbeatInterval = interval
+		}
+		e.heartbeatTimeout = timeout
+	}
+}
+
+// touch records that the client was heard from
+func (c *connection) touch() {
+	c.seen.Store(time.Now().UnixNano())
+}
+
+// alive reports whether the client has been heard from within timeout.
+// Only connections that report pongs can tell an idle client from a dead
+// one.
+func (c *connection) alive(timeout time.Duration) bool {
+	pc, ok := c.conn.(PongConn)
+	if !ok || timeout <= 0 {
+		return true
+	}
+	seen := time.Unix(0, c.seen.Load())
+	if pong := pc.LastPong(); pong.After(seen) {
+		seen = pong
+	}
+	return time.Since(seen) < timeout
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pongPipeConn is a pipeConn whose client answers pings while answering is
+// set
+type pongPipeConn struct {
+	*pipeConn
+	answering atomic.Bool
+	lastPong  atomic.Int64
+}
+
+func newPongPipeConn(answering bool) *pongPipeConn {
+	c := &pongPipeConn{pipeConn: newPipeConn()}
+	c.answering.Store(answering)
+	c.lastPong.Store(time.Now().UnixNano())
+	return c
+}
+
+func (c *pongPipeConn) Ping() error {
+	if c.answering.Load() {
+		c.lastPong.Store(time.Now().UnixNano())
+	}
+	return nil
+}
+
+func (c *pongPipeConn) LastPong() time.Time {
+	return time.Unix(0, c.lastPong.Load())
+}
+
+// runHeartbeatSession runs a session that pings every 10ms and gives up on
+// its client after 50ms, reporting on the channel when the session ends
+func runHeartbeatSession(t *testing.T, conn Conn) chan struct{} {
+	t.Helper()
+	session := NewTransportSession("heartbeat", conn, &testComponent{},
+		WithEngineHeartbeat(10*time.Millisecond, 50*time.Millisecond),
+		WithEngineReconnectWindow(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	ended := make(chan struct{})
+	go func() {
+		session.Run(ctx)
+		close(ended)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-ended
+	})
+	return ended
+}
+
+func TestHeartbeat(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Ends the session of a client that stops answering",
+			test: func(t *testing.T) {
+				conn := newPongPipeConn(true)
+				ended := runHeartbeatSession(t, conn)
+				conn.answering.Store(false)
+
+				select {
+				case <-ended:
+				case <-time.After(2 * time.Second):
+					t.Fatal("Expected the session to end")
+				}
+			},
+		},
+		{
+			name: "Keeps a client that answers pings",
+			test: func(t *testing.T) {
+				ended := runHeartbeatSession(t, newPongPipeConn(true))
+
+				select {
+				case <-ended:
+					t.Fatal("Expected the session to keep running")
+				case <-time.After(200 * time.Millisecond):
+				}
+			},
+		},
+		{
+			name: "Counts messages from the client as answers",
+			test: func(t *testing.T) {
+				conn := newPongPipeConn(false)
+				ended := runHeartbeatSession(t, conn)
+
+				stop := time.After(200 * time.Millisecond)
+				for {
+					select {
+					case <-ended:
+						t.Fatal("Expected the session to keep running")
+					case <-stop:
+						return
+					case <-time.After(10 * time.Millisecond):
+						conn.fromClient <- []byte(`{"type":"refresh","data":{}}`)
+					}
+				}
+			},
+		},
+		{
+			name: "Keeps idle connections that don't report pongs",
+			test: func(t *testing.T) {
+				ended := runHeartbeatSession(t, newPipeConn())
+
+				select {
+				case <-ended:
+					t.Fatal("Expected the session to keep running")
+				case <-time.After(200 * time.Millisecond):
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
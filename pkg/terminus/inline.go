@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "strings"
+
+// inlineHeight returns the rows an inline terminal needs for view: one per
+// line, ignoring trailing blank lines, and no more than maxHeight if set.
+// Inline terminals are embedded in a page and grow to fit the view rather
+// than filling a fixed grid.
+func inlineHeight(view string, maxHeight int) int {
+	lines := strings.Split(strings.TrimRight(view, "\n"), "\n")
+	for len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	height := len(lines)
+	if maxHeight > 0 && height > maxHeight {
+		height = maxHeight
+	}
+	return height
+}
+
+// screenHeight returns the rows to draw view in: the client's height, or
+// just the rows the view needs if the terminal is inline. The caller holds
+// s.mu.
+func (s *Session) screenHeight(view string, height int) int {
+	if s.environment.Inline {
+		return inlineHeight(view, height)
+	}
+	return height
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// inlineComponent shows two lines and a trailing blank one
+type inlineComponent struct{}
+
+func (c *inlineComponent) Init() Cmd {
+	return nil
+}
+
+func (c *inlineComponent) Update(msg Msg) (Component, Cmd) {
+	return c, nil
+}
+
+func (c *inlineComponent) View() string {
+	return "one\ntwo\n\n"
+}
+
+func TestInlineHeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		view      string
+		maxHeight int
+		want      int
+	}{
+		{name: "One row per line", view: "a\nb\nc", want: 3},
+		{name: "Ignores trailing blank lines", view: "a\nb\n  \n\n", want: 2},
+		{name: "Keeps blank lines between", view: "a\n\nb", want: 3},
+		{name: "Empty view takes a row", view: "", want: 1},
+		{name: "Capped at the maximum", view: "a\nb\nc", maxHeight: 2, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inlineHeight(tt.view, tt.maxHeight); got != tt.want {
+				t.Errorf("inlineHeight(%q, %d) = %d, want %d", tt.view, tt.maxHeight, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		inline bool
+		rows   int
+	}{
+		{name: "Inline terminals fit the view", inline: true, rows: 2},
+		{name: "Other terminals fill the window", inline: false, rows: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newPipeConn()
+			session := NewTransportSession("inline", conn, &inlineComponent{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go session.Run(ctx)
+
+			env, _ := json.Marshal(ClientMessage{Type: "environment", Data: map[string]interface{}{"inline": tt.inline}})
+			conn.fromClient <- env
+			conn.fromClient <- []byte(`{"type":"resize","data":{"width":40,"height":10}}`)
+
+			// The first frame predates the environment; wait for the resize
+			timeout := time.After(2 * time.Second)
+			for {
+				select {
+				case data := <-conn.toClient:
+					var msg ServerMessage
+					if err := json.Unmarshal(data, &msg); err != nil {
+						t.Fatal(err)
+					}
+					if lines, ok := msg.Data["lines"].([]interface{}); ok && len(lines) == tt.rows {
+						if session.Environment().Inline != tt.inline {
+							t.Errorf("Expected Inline %v in the environment", tt.inline)
+						}
+						return
+					}
+				case <-timeout:
+					t.Fatalf("Never received a frame of %d rows", tt.rows)
+				}
+			}
+		})
+	}
+}
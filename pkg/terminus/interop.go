@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrClientUnavailable is reported when SendToClient is used without a
+// connected client
+var ErrClientUnavailable = errors.New("client unavailable")
+
+// ClientMsg is sent by custom JavaScript on the page with the terminal
+// client's send(channel, payload) method
+type ClientMsg struct {
+	Channel string
+	Payload interface{} // As decoded from JSON
+}
+
+// Decode decodes the payload into v, e.g. a struct with JSON tags
+func (m ClientMsg) Decode(v interface{}) error {
+	data, err := json.Marshal(m.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// clientSendMsg asks the engine to send a message to the page
+type clientSendMsg struct {
+	channel string
+	payload interface{}
+}
+
+// SendToClient returns a command that sends payload, encoded as JSON, to
+// the page's handlers for channel, registered with the terminal client's
+// on(channel, handler) method or listening for terminus:message events.
+// Pages use it for integrations such as chart overlays, browser
+// notifications or navigating the host page. The component receives
+// nothing unless the message can't be sent, when it receives an ErrMsg.
+func SendToClient(channel string, payload interface{}) Cmd {
+	return func() Msg {
+		return clientSendMsg{channel: channel, payload: payload}
+	}
+}
+
+// sendToClient sends a message to the page, returning an error message for
+// the component if it can't be encoded or there is no client, and
+// otherwise nil
+func (e *Engine) sendToClient(req clientSendMsg) Msg {
+	payload, err := json.Marshal(req.payload)
+	if err != nil {
+		return ErrMsg{Err: fmt.Errorf("encoding %s payload: %w", req.channel, err), Source: "SendToClient"}
+	}
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "custom",
+		Data: map[string]interface{}{
+			"channel": req.channel,
+			"payload": json.RawMessage(payload),
+		},
+	}) {
+		return ErrMsg{Err: ErrClientUnavailable, Source: "SendToClient"}
+	}
+	return nil
+}
+
+// clientMsgFromClient converts a custom message from the page
+func clientMsgFromClient(data map[string]interface{}) ClientMsg {
+	channel, _ := data["channel"].(string)
+	return ClientMsg{Channel: channel, Payload: data["payload"]}
+}
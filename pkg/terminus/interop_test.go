@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// interopComponent sends a payload to the page when initialized and shows
+// where the page asked to navigate
+type interopComponent struct {
+	payload interface{}
+	page    string
+}
+
+func (c *interopComponent) Init() Cmd {
+	return SendToClient("chart", c.payload)
+}
+
+func (c *interopComponent) Update(msg Msg) (Component, Cmd) {
+	if msg, ok := msg.(ClientMsg); ok && msg.Channel == "navigate" {
+		var nav struct {
+			Page string `json:"page"`
+		}
+		if err := msg.Decode(&nav); err == nil {
+			c.page = nav.Page
+		}
+	}
+	return c, nil
+}
+
+func (c *interopComponent) View() string {
+	return "page: " + c.page
+}
+
+// captureErrors returns middleware reporting the ErrMsgs a component
+// receives
+func captureErrors() (chan ErrMsg, EngineOption) {
+	errs := make(chan ErrMsg, 1)
+	capture := func(next Handler) Handler {
+		return func(ctx context.Context, msg Msg) Cmd {
+			if err, ok := msg.(ErrMsg); ok {
+				errs <- err
+			}
+			return next(ctx, msg)
+		}
+	}
+	return errs, WithEngineMessageMiddleware(capture)
+}
+
+func TestSendToClient(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs, capture := captureErrors()
+				startEngine(t, &interopComponent{payload: 1}, capture)
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrClientUnavailable) {
+						t.Errorf("Expected ErrClientUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Reports payloads that can't be encoded",
+			test: func(t *testing.T) {
+				errs, capture := captureErrors()
+				startEngine(t, &interopComponent{payload: func() {}}, capture)
+
+				select {
+				case err := <-errs:
+					if err.Err == nil || errors.Is(err.Err, ErrClientUnavailable) {
+						t.Errorf("Expected an encoding error, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Exchanges messages with the page",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component {
+					return &interopComponent{payload: map[string]interface{}{"points": []int{1, 2}}}
+				})
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+				conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+				if err != nil {
+					t.Fatalf("Failed to connect: %v", err)
+				}
+				defer conn.Close()
+
+				msg := readUntil(t, conn, `"type":"custom"`)
+				payload, _ := msg.Data["payload"].(map[string]interface{})
+				if msg.Data["channel"] != "chart" || len(payload["points"].([]interface{})) != 2 {
+					t.Errorf("Expected the chart payload, got %v", msg.Data)
+				}
+
+				conn.WriteJSON(ClientMessage{Type: "custom", Data: map[string]interface{}{
+					"channel": "navigate",
+					"payload": map[string]interface{}{"page": "settings"},
+				}})
+				readUntil(t, conn, "page: settings")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
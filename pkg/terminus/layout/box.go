@@ -16,6 +16,8 @@ package layout
 
 import (
 	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus/style"
 )
 
 // BoxStyle represents different box drawing styles
@@ -112,13 +114,13 @@ var boxChars = map[BoxStyle]struct {
 
 // Box represents a box with content
 type Box struct {
-	content     string
-	width       int
-	height      int
-	style       BoxStyle
-	title       string
-	padding     Padding
-	borderColor string
+	content string
+	width   int
+	height  int
+	style   BoxStyle
+	title   string
+	padding Padding
+	border  style.Style
 }
 
 // Padding represents spacing inside a box
@@ -162,6 +164,13 @@ func (b *Box) WithTitle(title string) *Box {
 	return b
 }
 
+// WithBorderStyle sets the style the border and title are drawn in, e.g.
+// a color to highlight the box
+func (b *Box) WithBorderStyle(border style.Style) *Box {
+	b.border = border
+	return b
+}
+
 // WithPadding sets the box padding
 func (b *Box) WithPadding(top, right, bottom, left int) *Box {
 	b.padding = Padding{top, right, bottom, left}
@@ -196,33 +205,32 @@ func (b *Box) Render() string {
 	var result strings.Builder
 
 	// Top border
-	result.WriteString(chars.TopLeft)
 	if b.title != "" && visibleLength(b.title) < innerWidth-2 {
 		titleLen := visibleLength(b.title)
 		titlePadding := (innerWidth - titleLen - 2) / 2
-		result.WriteString(strings.Repeat(chars.Horizontal, titlePadding))
-		result.WriteString(" " + b.title + " ")
-		result.WriteString(strings.Repeat(chars.Horizontal, innerWidth-titleLen-2-titlePadding))
+		result.WriteString(b.border.Render(chars.TopLeft + strings.Repeat(chars.Horizontal, titlePadding)))
+		result.WriteString(b.border.Render(" " + b.title + " "))
+		result.WriteString(b.border.Render(strings.Repeat(chars.Horizontal, innerWidth-titleLen-2-titlePadding) + chars.TopRight))
 	} else {
-		result.WriteString(strings.Repeat(chars.Horizontal, innerWidth))
+		result.WriteString(b.border.Render(chars.TopLeft + strings.Repeat(chars.Horizontal, innerWidth) + chars.TopRight))
 	}
-	result.WriteString(chars.TopRight)
 	result.WriteString("\n")
+	vertical := b.border.Render(chars.Vertical)
 
 	// Content lines
 	lines := strings.Split(b.content, "\n")
 
 	// Top padding
 	for i := 0; i < b.padding.Top; i++ {
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString(strings.Repeat(" ", innerWidth))
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString("\n")
 	}
 
 	// Content with padding
 	for i := 0; i < b.height; i++ {
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString(strings.Repeat(" ", b.padding.Left))
 
 		if i < len(lines) {
@@ -242,22 +250,20 @@ func (b *Box) Render() string {
 		}
 
 		result.WriteString(strings.Repeat(" ", b.padding.Right))
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString("\n")
 	}
 
 	// Bottom padding
 	for i := 0; i < b.padding.Bottom; i++ {
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString(strings.Repeat(" ", innerWidth))
-		result.WriteString(chars.Vertical)
+		result.WriteString(vertical)
 		result.WriteString("\n")
 	}
 
 	// Bottom border
-	result.WriteString(chars.BottomLeft)
-	result.WriteString(strings.Repeat(chars.Horizontal, innerWidth))
-	result.WriteString(chars.BottomRight)
+	result.WriteString(b.border.Render(chars.BottomLeft + strings.Repeat(chars.Horizontal, innerWidth) + chars.BottomRight))
 
 	return result.String()
 }
@@ -17,6 +17,8 @@ package layout
 import (
 	"strings"
 	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus/style"
 )
 
 func TestNewBox(t *testing.T) {
@@ -102,6 +104,17 @@ func TestBoxRender(t *testing.T) {
 				"+-----+",
 			},
 		},
+		{
+			name:    "Box with a border style",
+			content: "Red",
+			setup: func(b *Box) *Box {
+				return b.WithBorderStyle(style.New().Foreground(style.Red))
+			},
+			contains: []string{
+				style.New().Foreground(style.Red).Render("┌───┐"),
+				style.New().Foreground(style.Red).Render("│") + "Red",
+			},
+		},
 	}
 
 	for _, tt := range tests {
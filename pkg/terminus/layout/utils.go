@@ -16,18 +16,17 @@ package layout
 
 import (
 	"regexp"
-	"unicode/utf8"
+
+	"github.com/skaiser/terminusgo/pkg/terminus/style"
 )
 
 // ansiRegex matches ANSI escape sequences
 var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
-// visibleLength returns the visible length of a string (excluding ANSI escape sequences)
+// visibleLength returns the visible length of a string in terminal cells
+// (excluding ANSI escape sequences, and counting wide characters twice)
 func visibleLength(s string) int {
-	// Strip ANSI escape sequences
-	cleaned := ansiRegex.ReplaceAllString(s, "")
-	// Count runes, not bytes
-	return utf8.RuneCountInString(cleaned)
+	return style.Width(s)
 }
 
 // stripANSI removes all ANSI escape sequences from a string
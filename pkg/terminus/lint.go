@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// StyleIssueKind is a kind of styling bug found by the style lint
+type StyleIssueKind int
+
+const (
+	// StyleIssueNestedReset is a reset following another, as when a styled
+	// string is rendered inside another style's Render: the text after the
+	// inner reset loses the outer style
+	StyleIssueNestedReset StyleIssueKind = iota
+	// StyleIssueTruncated is an escape sequence or character cut short, or
+	// a style left open at the end of a view, as when a styled string is
+	// truncated by bytes or runes instead of cells
+	StyleIssueTruncated
+	// StyleIssueTooWide is a line wider than the screen, which is wrapped or
+	// cut
+	StyleIssueTooWide
+)
+
+// String returns the name of the kind
+func (k StyleIssueKind) String() string {
+	switch k {
+	case StyleIssueNestedReset:
+		return "nested reset"
+	case StyleIssueTruncated:
+		return "truncated"
+	case StyleIssueTooWide:
+		return "too wide"
+	default:
+		return "unknown"
+	}
+}
+
+// StyleIssue is a styling bug found in a view
+type StyleIssue struct {
+	Component string // The component whose view has the bug, e.g. "*main.app > sidebar"
+	Line      int    // The line of the view, from 0
+	Kind      StyleIssueKind
+	Message   string // What is wrong and how to fix it
+}
+
+// String returns the issue as a log line
+func (i StyleIssue) String() string {
+	if i.Component == "" {
+		return fmt.Sprintf("line %d: %s", i.Line+1, i.Message)
+	}
+	return fmt.Sprintf("%s: line %d: %s", i.Component, i.Line+1, i.Message)
+}
+
+// Composite is implemented by components made of named children, e.g.
+// widget.Compose. The style lint checks the children's views too, so a bug
+// is reported against the child it comes from.
+type Composite interface {
+	NamedChildren() []NamedComponent
+}
+
+// NamedComponent is a child of a Composite
+type NamedComponent struct {
+	Name      string
+	Component Component
+}
+
+// LintView checks a view for common styling bugs: nested renders whose
+// inner reset ends the outer style early, escape sequences and characters
+// cut short by truncating styled strings by length, styles left open at the
+// end of the view and, when width is above 0, lines wider than width cells.
+func LintView(view string, width int) []StyleIssue {
+	var issues []StyleIssue
+	report := func(line int, kind StyleIssueKind, format string, args ...any) {
+		issues = append(issues, StyleIssue{Line: line, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	parser := ANSIParser{current: NewStyle()}
+	afterReset := false // The last sequence reset the style, and nothing set it since
+	for y, line := range strings.Split(view, "\n") {
+		nested, truncated := false, false
+		for i := 0; i < len(line); {
+			if line[i] != '\x1b' {
+				r, size := utf8.DecodeRuneInString(line[i:])
+				if r == utf8.RuneError && size == 1 && !truncated {
+					report(y, StyleIssueTruncated, "a character is cut short at byte %d, as when a string is truncated by bytes; truncate by cells, e.g. with StringWidth", i)
+					truncated = true
+				}
+				i += size
+				continue
+			}
+
+			end, ok := sgrEnd(line, i)
+			if !ok {
+				if !truncated {
+					report(y, StyleIssueTruncated, "an escape sequence is cut short at byte %d, as when a styled string is truncated by len(); truncate by cells, e.g. with StringWidth", i)
+					truncated = true
+				}
+				i = end
+				continue
+			}
+			if line[end-1] == 'm' {
+				codes := line[i+2 : end-1]
+				reset := codes == "" || codes == "0"
+				if reset && afterReset && !nested {
+					report(y, StyleIssueNestedReset, "a reset follows another, as when a styled string is rendered inside another style's Render, so the text after the inner reset loses the outer style; render the parts separately and join them")
+					nested = true
+				}
+				parser.parseSGR(codes)
+				afterReset = reset
+			}
+			i = end
+		}
+		if width > 0 {
+			if w := StringWidth(line); w > width {
+				report(y, StyleIssueTooWide, "the line is %d cells wide, wider than the %d-cell screen; fit it to the width the component was given", w, width)
+			}
+		}
+	}
+	if !parser.current.Equal(NewStyle()) {
+		last := strings.Count(view, "\n")
+		report(last, StyleIssueTruncated, "the view ends with a style left open, as when a styled string is cut before its reset, so it leaks into what is drawn after it; truncate by cells, e.g. with StringWidth")
+	}
+	return issues
+}
+
+// sgrEnd returns the end of the escape sequence starting at i, and whether
+// the sequence is complete: ESC [, parameters and intermediate bytes, then
+// a final byte
+func sgrEnd(s string, i int) (int, bool) {
+	if i+1 >= len(s) || s[i+1] != '[' {
+		return i + 1, false
+	}
+	for j := i + 2; j < len(s); j++ {
+		switch c := s[j]; {
+		case c >= 0x20 && c <= 0x3f:
+			// Parameter or intermediate byte
+		case c >= 0x40 && c <= 0x7e:
+			return j + 1, true
+		default:
+			return j, false
+		}
+	}
+	return len(s), false
+}
+
+// maxLintReports bounds the issues a session remembers having reported
+const maxLintReports = 256
+
+// WithEngineStyleLint checks every view, and the views of the named
+// children of Composite components, for common styling bugs and calls
+// report with each bug found, once per component and kind. A nil report
+// logs the bugs. Children are rendered again to be checked, so the lint is
+// meant for development.
+func WithEngineStyleLint(report func(StyleIssue)) EngineOption {
+	return func(e *Engine) {
+		if report == nil {
+			report = func(issue StyleIssue) {
+				fmt.Printf("Style lint: %s\n", issue)
+			}
+		}
+		e.lint = &styleLint{report: report, seen: make(map[string]bool)}
+	}
+}
+
+// styleLint checks the views an engine renders and reports the bugs found
+type styleLint struct {
+	mu     sync.Mutex
+	report func(StyleIssue)
+	width  int
+	seen   map[string]bool // Component and kind of the bugs reported
+}
+
+// observe records the screen width
+func (l *styleLint) observe(msg Msg) {
+	if size, ok := msg.(WindowSizeMsg); ok {
+		l.mu.Lock()
+		l.width = size.Width
+		l.mu.Unlock()
+	}
+}
+
+// check checks the view of component and its children
+func (l *styleLint) check(component Component, view string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.walk(fmt.Sprintf("%T", component), component, view)
+}
+
+// walk checks the view of the component called name and of its children,
+// returning the kinds of bug found as a bit set. A bug the component's view
+// shares with a child's is reported against the child only.
+func (l *styleLint) walk(name string, component Component, view string) uint {
+	var inChildren uint
+	if composite, ok := component.(Composite); ok {
+		for _, child := range composite.NamedChildren() {
+			inChildren |= l.walk(name+" > "+child.Name, child.Component, child.Component.View())
+		}
+	}
+
+	found := inChildren
+	for _, issue := range LintView(view, l.width) {
+		kind := uint(1) << issue.Kind
+		found |= kind
+		key := name + "\x00" + issue.Kind.String()
+		if inChildren&kind != 0 || l.seen[key] || len(l.seen) >= maxLintReports {
+			continue
+		}
+		l.seen[key] = true
+		issue.Component = name
+		l.report(issue)
+	}
+	return found
+}
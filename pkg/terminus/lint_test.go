@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+)
+
+// lintComposite joins the views of its named children
+type lintComposite struct {
+	mockComponent
+	children []NamedComponent
+}
+
+func (c *lintComposite) NamedChildren() []NamedComponent {
+	return c.children
+}
+
+func (c *lintComposite) View() string {
+	var views []string
+	for _, child := range c.children {
+		views = append(views, child.Component.View())
+	}
+	return strings.Join(views, "\n")
+}
+
+func TestLintView(t *testing.T) {
+	bold, green := NewStyle().Bold(true), NewStyle().Foreground(Green)
+	styled := bold.Render("styled text")
+
+	tests := []struct {
+		name     string
+		view     string
+		width    int
+		expected []StyleIssueKind
+		line     int
+	}{
+		{name: "Clean view", view: styled + " " + green.Render("ok") + "\nplain", width: 20},
+		{name: "Nested render", view: "title\n" + bold.Render("a "+green.Render("b")+" c"), expected: []StyleIssueKind{StyleIssueNestedReset}, line: 1},
+		{name: "Escape cut by len()", view: styled[:3], expected: []StyleIssueKind{StyleIssueTruncated}},
+		{name: "Style left open", view: styled[:len(styled)-4], expected: []StyleIssueKind{StyleIssueTruncated}},
+		{name: "Character cut short", view: "naïve"[:3], expected: []StyleIssueKind{StyleIssueTruncated}},
+		{name: "Line too wide", view: "ok\n" + styled, width: 8, expected: []StyleIssueKind{StyleIssueTooWide}, line: 1},
+		{name: "Width unchecked", view: styled, width: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintView(tt.view, tt.width)
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, issues)
+			}
+			for i, issue := range issues {
+				if issue.Kind != tt.expected[i] || issue.Line != tt.line {
+					t.Errorf("Expected %v on line %d, got %v", tt.expected[i], tt.line, issue)
+				}
+			}
+		})
+	}
+}
+
+func TestStyleLint(t *testing.T) {
+	reports := make(chan StyleIssue, 10)
+	broken := NewStyle().Bold(true).Render("broken")
+	comp := &lintComposite{children: []NamedComponent{
+		{Name: "header", Component: &mockComponent{state: "header"}},
+		{Name: "body", Component: &mockComponent{state: broken[:len(broken)-4]}},
+	}}
+	engine, renders := startEngine(t, comp, WithEngineStyleLint(func(issue StyleIssue) {
+		reports <- issue
+	}))
+
+	issue := <-reports
+	if issue.Component != "*terminus.lintComposite > body" || issue.Kind != StyleIssueTruncated {
+		t.Errorf("Expected the truncated style to be reported against the child, got %v", issue)
+	}
+
+	// Each bug is reported once
+	engine.SendMessage(KeyMsg{Type: KeyDown})
+	<-renders
+	select {
+	case issue := <-reports:
+		t.Errorf("Expected no more reports, got %v", issue)
+	default:
+	}
+}
@@ -14,6 +14,8 @@
 
 package terminus
 
+import "time"
+
 // KeyType represents different types of keyboard input
 type KeyType int
 
@@ -80,6 +82,7 @@ type KeyMsg struct {
 	Alt   bool   // Alt modifier
 	Ctrl  bool   // Ctrl modifier
 	Shift bool   // Shift modifier
+	User  string // ID of the collaborator who pressed the key, "" for the session's owner
 }
 
 // String returns a human-readable representation of the key message
@@ -158,10 +161,44 @@ func (k KeyMsg) String() string {
 }
 
 // QuitMsg is a message type for signaling application quit
-type QuitMsg struct{}
+type QuitMsg struct {
+	// View is the exit screen left in the browser, or "" for the last view
+	View string
+	// Code is the exit code reported to WithOnSessionEnd hooks
+	Code int
+}
 
 // WindowSizeMsg is sent when the terminal window is resized
 type WindowSizeMsg struct {
 	Width  int
 	Height int
+}
+
+// EnvironmentMsg describes the client's browser. It is sent once when the
+// client connects, before the first WindowSizeMsg.
+type EnvironmentMsg struct {
+	Locale        string        // BCP 47 language tag, e.g. "en-GB"
+	TimeZone      string        // IANA name, e.g. "Europe/London"; may be empty
+	UTCOffset     time.Duration // Offset from UTC when the client connected
+	ColorDepth    int           // Bits per pixel of the display
+	ReducedMotion bool          // The user prefers reduced motion
+	UserAgent     string
+	// Inline is set when the terminal is embedded in a page, growing to fit
+	// the view. WindowSizeMsg then gives the most rows it may grow to.
+	Inline bool
+}
+
+// Location returns the client's time zone, for formatting timestamps in the
+// user's local time. It falls back to a fixed zone at UTCOffset when the
+// zone name is unknown on the server.
+func (e EnvironmentMsg) Location() *time.Location {
+	if e.TimeZone != "" {
+		if loc, err := time.LoadLocation(e.TimeZone); err == nil {
+			return loc
+		}
+	}
+	if e.UTCOffset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(e.TimeZone, int(e.UTCOffset/time.Second))
 }
\ No newline at end of file
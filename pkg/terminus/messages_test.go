@@ -16,6 +16,7 @@ package terminus
 
 import (
 	"testing"
+	"time"
 )
 
 func TestKeyMsgString(t *testing.T) {
@@ -142,4 +143,44 @@ func TestWindowSizeMsg(t *testing.T) {
 	if msg.Height != 24 {
 		t.Errorf("Expected height 24, got %d", msg.Height)
 	}
-}
\ No newline at end of file
+}
+
+func TestEnvironmentMsgLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      EnvironmentMsg
+		expected string
+		offset   int
+	}{
+		{
+			name:     "Named zone",
+			env:      EnvironmentMsg{TimeZone: "Asia/Tokyo", UTCOffset: 9 * time.Hour},
+			expected: "Asia/Tokyo",
+			offset:   9 * 60 * 60,
+		},
+		{
+			name:     "Unknown zone falls back to the offset",
+			env:      EnvironmentMsg{TimeZone: "Nowhere/Special", UTCOffset: -150 * time.Minute},
+			expected: "Nowhere/Special",
+			offset:   -150 * 60,
+		},
+		{
+			name:     "No zone",
+			env:      EnvironmentMsg{},
+			expected: "UTC",
+		},
+	}
+
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.env.Location()
+			if loc.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, loc)
+			}
+			if _, offset := at.In(loc).Zone(); offset != tt.offset {
+				t.Errorf("Expected offset %d, got %d", tt.offset, offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "context"
+
+// Handler delivers a message to the component and returns the command the
+// component produced. ctx is the session's context; SessionID(ctx) names
+// the session.
+type Handler func(ctx context.Context, msg Msg) Cmd
+
+// MessageMiddleware wraps the handler that delivers messages to the root
+// component. A middleware can inspect or log a message, replace it before
+// calling next, drop it by not calling next, or add commands of its own.
+type MessageMiddleware func(next Handler) Handler
+
+// WithEngineMessageMiddleware adds middleware around message delivery. The
+// first middleware is the outermost, so it sees each message first.
+func WithEngineMessageMiddleware(middleware ...MessageMiddleware) EngineOption {
+	return func(e *Engine) {
+		e.middleware = append(e.middleware, middleware...)
+	}
+}
+
+// chain wraps h in the middleware, outermost first
+func chain(h Handler, middleware []MessageMiddleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// CommandInfo describes a command passed to command middleware
+type CommandInfo struct {
+	Name   string // Function name, e.g. "main.(*Model).fetch.func1"
+	Stream bool   // The body of a Stream command, which may run for a long time
+}
+
+// CommandHandler runs a command and returns its message. ctx is the
+// session's context; SessionID(ctx) names the session.
+type CommandHandler func(ctx context.Context, cmd Cmd, info CommandInfo) Msg
+
+// CommandMiddleware wraps the execution of every command, including the
+// bodies of streams, e.g. to time or trace them
+type CommandMiddleware func(next CommandHandler) CommandHandler
+
+// WithEngineCommandMiddleware adds middleware around command execution. The
+// first middleware is the outermost.
+func WithEngineCommandMiddleware(middleware ...CommandMiddleware) EngineOption {
+	return func(e *Engine) {
+		e.cmdMiddleware = append(e.cmdMiddleware, middleware...)
+	}
+}
+
+// chainCommands wraps h in the middleware, outermost first
+func chainCommands(h CommandHandler, middleware []CommandMiddleware) CommandHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// execHandler is the innermost command handler
+func execHandler(ctx context.Context, cmd Cmd, info CommandInfo) Msg {
+	return execCmd(cmd)
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startEngine starts an engine and returns a channel of its rendered views
+func startEngine(t *testing.T, comp Component, opts ...EngineOption) (*Engine, chan string) {
+	t.Helper()
+	engine := NewEngine(comp, opts...)
+	renders := make(chan string, 10)
+	engine.SetRenderCallback(func(view string) { renders <- view })
+	if err := engine.Start(); err != nil {
+		t.Fatalf("Failed to start engine: %v", err)
+	}
+	t.Cleanup(engine.Stop)
+	<-renders
+	return engine, renders
+}
+
+func TestMessageMiddleware(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Runs middleware outermost first",
+			test: func(t *testing.T) {
+				var mu sync.Mutex
+				var order []string
+				trace := func(name string) MessageMiddleware {
+					return func(next Handler) Handler {
+						return func(ctx context.Context, msg Msg) Cmd {
+							mu.Lock()
+							order = append(order, name)
+							mu.Unlock()
+							return next(ctx, msg)
+						}
+					}
+				}
+
+				comp := &testComponent{}
+				engine, renders := startEngine(t, comp, WithEngineMessageMiddleware(trace("outer"), trace("inner")))
+				engine.SendMessage(testMsg{value: "hello"})
+				<-renders
+
+				mu.Lock()
+				defer mu.Unlock()
+				if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+					t.Errorf("Expected outer then inner, got %v", order)
+				}
+				if comp.getState() != "hello" {
+					t.Errorf("Expected the message delivered, got %q", comp.getState())
+				}
+			},
+		},
+		{
+			name: "Filters and transforms messages",
+			test: func(t *testing.T) {
+				dropKeys := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if _, ok := msg.(KeyMsg); ok {
+							return nil
+						}
+						return next(ctx, msg)
+					}
+				}
+				shout := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if m, ok := msg.(testMsg); ok {
+							msg = testMsg{value: m.value + "!"}
+						}
+						return next(ctx, msg)
+					}
+				}
+
+				comp := &testComponent{}
+				engine, renders := startEngine(t, comp, WithEngineMessageMiddleware(dropKeys, shout))
+				engine.SendMessage(KeyMsg{Type: KeyEnter})
+				<-renders
+				engine.SendMessage(testMsg{value: "hi"})
+				<-renders
+
+				if comp.getUpdates() != 1 || comp.getState() != "hi!" {
+					t.Errorf("Expected only the transformed message, got %d updates and %q", comp.getUpdates(), comp.getState())
+				}
+			},
+		},
+		{
+			name: "Adds commands",
+			test: func(t *testing.T) {
+				inject := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						cmd := next(ctx, msg)
+						if m, ok := msg.(testMsg); ok && m.value == "ping" {
+							return func() Msg { return testMsg{value: "pong"} }
+						}
+						return cmd
+					}
+				}
+
+				comp := &testComponent{}
+				engine, renders := startEngine(t, comp, WithEngineMessageMiddleware(inject))
+				engine.SendMessage(testMsg{value: "ping"})
+				<-renders
+
+				select {
+				case view := <-renders:
+					if view != "pong" {
+						t.Errorf("Expected the injected message, got %q", view)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Injected command never delivered")
+				}
+			},
+		},
+		{
+			name: "Passes the session ID",
+			test: func(t *testing.T) {
+				ids := make(chan string, 1)
+				capture := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						ids <- SessionID(ctx)
+						return next(ctx, msg)
+					}
+				}
+
+				engine, _ := startEngine(t, &testComponent{}, WithEngineMessageMiddleware(capture))
+				engine.setSessionID("session-1")
+				engine.SendMessage(testMsg{value: "x"})
+
+				if id := <-ids; id != "session-1" {
+					t.Errorf("Expected session-1, got %q", id)
+				}
+				if SessionID(context.Background()) != "" {
+					t.Error("Expected no ID outside a session")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// fetchGreeting is a named command for middleware tests
+func fetchGreeting() Msg {
+	return testMsg{value: "hello"}
+}
+
+func TestCommandMiddleware(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Wraps commands with their name and session",
+			test: func(t *testing.T) {
+				type call struct {
+					info    CommandInfo
+					session string
+					result  Msg
+				}
+				calls := make(chan call, 10)
+				trace := func(next CommandHandler) CommandHandler {
+					return func(ctx context.Context, cmd Cmd, info CommandInfo) Msg {
+						msg := next(ctx, cmd, info)
+						calls <- call{info: info, session: SessionID(ctx), result: msg}
+						return msg
+					}
+				}
+
+				comp := &testComponent{initCmd: fetchGreeting}
+				engine := NewEngine(comp, WithEngineCommandMiddleware(trace))
+				engine.setSessionID("s1")
+				engine.Start()
+				defer engine.Stop()
+
+				var c call
+				select {
+				case c = <-calls:
+				case <-time.After(time.Second):
+					t.Fatal("Command never passed through middleware")
+				}
+				if c.info.Name != "terminus.fetchGreeting" || c.info.Stream {
+					t.Errorf("Expected fetchGreeting, got %+v", c.info)
+				}
+				if c.session != "s1" {
+					t.Errorf("Expected session s1, got %q", c.session)
+				}
+				if m, ok := c.result.(testMsg); !ok || m.value != "hello" {
+					t.Errorf("Expected the command's message, got %v", c.result)
+				}
+			},
+		},
+		{
+			name: "Wraps stream bodies",
+			test: func(t *testing.T) {
+				streams := make(chan CommandInfo, 10)
+				trace := func(next CommandHandler) CommandHandler {
+					return func(ctx context.Context, cmd Cmd, info CommandInfo) Msg {
+						if info.Stream {
+							streams <- info
+						}
+						return next(ctx, cmd, info)
+					}
+				}
+
+				body := func(ctx context.Context, send func(Msg)) Msg {
+					send(testMsg{value: "chunk"})
+					return testMsg{value: "done"}
+				}
+				comp := &testComponent{initCmd: Stream(body)}
+				engine := NewEngine(comp, WithEngineCommandMiddleware(trace))
+				engine.Start()
+				defer engine.Stop()
+
+				select {
+				case info := <-streams:
+					if !strings.Contains(info.Name, "TestCommandMiddleware") {
+						t.Errorf("Expected the stream body's name, got %q", info.Name)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Stream body never passed through middleware")
+				}
+			},
+		},
+		{
+			name: "Recovers panics in middleware",
+			test: func(t *testing.T) {
+				broken := func(next CommandHandler) CommandHandler {
+					return func(ctx context.Context, cmd Cmd, info CommandInfo) Msg {
+						panic("tracer exploded")
+					}
+				}
+
+				errs := make(chan ErrMsg, 1)
+				capture := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if err, ok := msg.(ErrMsg); ok {
+							errs <- err
+						}
+						return next(ctx, msg)
+					}
+				}
+
+				comp := &testComponent{initCmd: fetchGreeting}
+				startEngine(t, comp, WithEngineCommandMiddleware(broken), WithEngineMessageMiddleware(capture))
+
+				select {
+				case err := <-errs:
+					if err.Source != "terminus.fetchGreeting" {
+						t.Errorf("Expected the panic attributed to the command, got %q", err.Source)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected the panic to be delivered as an ErrMsg")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
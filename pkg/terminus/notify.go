@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotificationsUnavailable is reported when DesktopNotify is used
+// without a connected client
+var ErrNotificationsUnavailable = errors.New("notifications unavailable")
+
+// ErrNotificationsDenied is reported when the user hasn't allowed the page
+// to show notifications, or the browser doesn't support them
+var ErrNotificationsDenied = errors.New("notifications denied")
+
+// maxPendingNotifications is how many notifications' click messages are
+// kept; the oldest are forgotten first
+const maxPendingNotifications = 64
+
+// NotifyOptions configures a desktop notification
+type NotifyOptions struct {
+	Tag     string // Replaces an earlier notification with the same tag
+	Icon    string // URL of an icon
+	Always  bool   // Show the notification even while the tab is in the foreground
+	OnClick Msg    // Sent to the component when the user clicks the notification
+}
+
+// notifyRequestMsg asks the client to show a notification
+type notifyRequestMsg struct {
+	title, body string
+	opts        NotifyOptions
+}
+
+// notificationEventMsg reports what happened to a notification: "click",
+// "close" or "denied"
+type notificationEventMsg struct {
+	id, event string
+}
+
+// DesktopNotify returns a command that shows an operating system
+// notification, e.g. for an alert that fires while the user is in another
+// tab. The browser asks the user's permission the first time. By default
+// the notification is only shown while the tab is in the background.
+// Clicking it brings the tab to the front and sends opts.OnClick to the
+// component. The component receives an ErrMsg if notifications are
+// unavailable or denied.
+func DesktopNotify(title, body string, opts NotifyOptions) Cmd {
+	return func() Msg {
+		return notifyRequestMsg{title: title, body: body, opts: opts}
+	}
+}
+
+// notify sends a notification to the client, remembering its click
+// message. It returns an error message for the component if there is no
+// client, and otherwise nil.
+func (e *Engine) notify(req notifyRequestMsg) Msg {
+	id := uuid.New().String()
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "notify",
+		Data: map[string]interface{}{
+			"id":     id,
+			"title":  req.title,
+			"body":   req.body,
+			"tag":    req.opts.Tag,
+			"icon":   req.opts.Icon,
+			"always": req.opts.Always,
+		},
+	}) {
+		return ErrMsg{Err: ErrNotificationsUnavailable, Source: "DesktopNotify"}
+	}
+
+	if req.opts.OnClick != nil {
+		if e.notifications == nil {
+			e.notifications = make(map[string]Msg)
+		}
+		e.notifications[id] = req.opts.OnClick
+		e.notificationOrder = append(e.notificationOrder, id)
+		if len(e.notificationOrder) > maxPendingNotifications {
+			delete(e.notifications, e.notificationOrder[0])
+			e.notificationOrder = e.notificationOrder[1:]
+		}
+	}
+	return nil
+}
+
+// notificationEvent returns the message for the component when something
+// happens to a notification, or nil if there is none
+func (e *Engine) notificationEvent(ev notificationEventMsg) Msg {
+	onClick := e.notifications[ev.id]
+	e.forgetNotification(ev.id)
+	switch ev.event {
+	case "click":
+		return onClick
+	case "denied":
+		return ErrMsg{Err: ErrNotificationsDenied, Source: "DesktopNotify"}
+	}
+	return nil
+}
+
+// forgetNotification drops a notification's click message
+func (e *Engine) forgetNotification(id string) {
+	if _, ok := e.notifications[id]; !ok {
+		return
+	}
+	delete(e.notifications, id)
+	for i, pending := range e.notificationOrder {
+		if pending == id {
+			e.notificationOrder = append(e.notificationOrder[:i], e.notificationOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// notificationEventFromClient converts a notification event sent by the
+// client
+func notificationEventFromClient(data map[string]interface{}) notificationEventMsg {
+	id, _ := data["id"].(string)
+	event, _ := data["event"].(string)
+	return notificationEventMsg{id: id, event: event}
+}
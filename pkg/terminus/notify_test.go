@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// alertClickedMsg is sent when the alert notification is clicked
+type alertClickedMsg struct{}
+
+// notifyComponent notifies when initialized and shows whether the
+// notification was clicked
+type notifyComponent struct {
+	status string
+}
+
+func (c *notifyComponent) Init() Cmd {
+	return DesktopNotify("CPU alert", "CPU above 90%", NotifyOptions{Tag: "cpu", OnClick: alertClickedMsg{}})
+}
+
+func (c *notifyComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case alertClickedMsg:
+		c.status = "clicked"
+	case ErrMsg:
+		c.status = "error: " + msg.Err.Error()
+	}
+	return c, nil
+}
+
+func (c *notifyComponent) View() string {
+	return "status: " + c.status
+}
+
+func TestDesktopNotify(t *testing.T) {
+	// dial connects to a session of notifyComponent and returns the id of
+	// its notification
+	dial := func(t *testing.T) (*websocket.Conn, string) {
+		t.Helper()
+		program := NewProgram(func() Component { return &notifyComponent{} })
+		server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+		t.Cleanup(server.Close)
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		msg := readUntil(t, conn, `"type":"notify"`)
+		if msg.Data["title"] != "CPU alert" || msg.Data["body"] != "CPU above 90%" || msg.Data["tag"] != "cpu" {
+			t.Errorf("Expected the notification, got %v", msg.Data)
+		}
+		id, _ := msg.Data["id"].(string)
+		return conn, id
+	}
+	event := func(id, event string) ClientMessage {
+		return ClientMessage{Type: "notification", Data: map[string]interface{}{"id": id, "event": event}}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs, capture := captureErrors()
+				startEngine(t, &notifyComponent{}, capture)
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrNotificationsUnavailable) {
+						t.Errorf("Expected ErrNotificationsUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Sends the click message when clicked",
+			test: func(t *testing.T) {
+				conn, id := dial(t)
+				conn.WriteJSON(event(id, "click"))
+				readUntil(t, conn, "status: clicked")
+			},
+		},
+		{
+			name: "Forgets closed notifications",
+			test: func(t *testing.T) {
+				conn, id := dial(t)
+				conn.WriteJSON(event(id, "close"))
+				conn.WriteJSON(event(id, "click"))
+				conn.WriteJSON(event("other", "denied"))
+				msg := readUntil(t, conn, "status: ")
+				for !strings.Contains(screenText(msg), "error") {
+					if strings.Contains(screenText(msg), "clicked") {
+						t.Fatal("Expected the closed notification's click to be ignored")
+					}
+					msg = readUntil(t, conn, "status: ")
+				}
+			},
+		},
+		{
+			name: "Reports denied notifications",
+			test: func(t *testing.T) {
+				conn, id := dial(t)
+				conn.WriteJSON(event(id, "denied"))
+				readUntil(t, conn, fmt.Sprintf("status: error: %v", ErrNotificationsDenied))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestPendingNotificationsAreBounded(t *testing.T) {
+	engine := NewEngine(&notifyComponent{})
+	engine.toClient = func(ServerMessage) bool { return true }
+	for i := 0; i < maxPendingNotifications+10; i++ {
+		engine.notify(notifyRequestMsg{opts: NotifyOptions{OnClick: alertClickedMsg{}}})
+	}
+	if len(engine.notifications) != maxPendingNotifications || len(engine.notificationOrder) != maxPendingNotifications {
+		t.Errorf("Expected %d pending notifications, got %d", maxPendingNotifications, len(engine.notifications))
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perf helps benchmark components, so rendering regressions in
+// widgets and apps show up in go test -bench:
+//
+//	func BenchmarkInbox(b *testing.B) {
+//		perf.Frames(b, newInbox(10000), 120, 40, terminus.KeyMsg{Type: terminus.KeyDown})
+//	}
+package perf
+
+import (
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// View benchmarks rendering the view of c as it is
+func View(b *testing.B, c terminus.Component) {
+	b.Helper()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.View()
+	}
+}
+
+// Frames benchmarks what a session spends on each message: c handles the
+// next of msgs, which are used in turn, renders its view and the view is
+// diffed against the previous frame on a width x height screen. Commands c
+// returns are not run. Besides time and allocations, it reports the diff
+// operations sent to the client per frame, in ops/frame.
+func Frames(b *testing.B, c terminus.Component, width, height int, msgs ...terminus.Msg) {
+	b.Helper()
+	differ := terminus.NewScreenDiffer(width, height)
+	c, _ = c.Update(terminus.WindowSizeMsg{Width: width, Height: height})
+	differ.Update(c.View())
+
+	ops := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(msgs) > 0 {
+			c, _ = c.Update(msgs[i%len(msgs)])
+		}
+		ops += len(differ.Update(c.View()))
+	}
+	b.ReportMetric(float64(ops)/float64(b.N), "ops/frame")
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// counter counts the keys it receives
+type counter struct {
+	keys  int
+	views int
+}
+
+func (c *counter) Init() terminus.Cmd {
+	return nil
+}
+
+func (c *counter) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if _, ok := msg.(terminus.KeyMsg); ok {
+		c.keys++
+	}
+	return c, nil
+}
+
+func (c *counter) View() string {
+	c.views++
+	return fmt.Sprintf("header\nkeys: %d", c.keys)
+}
+
+func TestView(t *testing.T) {
+	c := &counter{}
+	result := testing.Benchmark(func(b *testing.B) {
+		View(b, c)
+	})
+	if c.views < result.N || c.keys != 0 {
+		t.Errorf("Expected %d views and no updates, got %d and %d", result.N, c.views, c.keys)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	c := &counter{}
+	result := testing.Benchmark(func(b *testing.B) {
+		c.keys = 0
+		Frames(b, c, 20, 5, terminus.KeyMsg{Type: terminus.KeyDown})
+	})
+	if c.keys != result.N {
+		t.Errorf("Expected a message per frame, got %d for %d frames", c.keys, result.N)
+	}
+	// Only the count's line changes from frame to frame
+	if ops := result.Extra["ops/frame"]; ops != 1 {
+		t.Errorf("Expected 1 op per frame, got %v", ops)
+	}
+}
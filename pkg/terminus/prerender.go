@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// sizeCookie holds the size of the page's terminal as "<width>x<height>",
+// set by the client so later pages are prerendered at that size
+const sizeCookie = "terminus_size"
+
+// maxPrerenderSize bounds the terminal size taken from sizeCookie
+const maxPrerenderSize = 1000
+
+// WithPrerender serves the page with the first frame of the component
+// already in its #terminal element, so it shows at once rather than after
+// the WebSocket connects; the session's first frame then replaces it. The
+// frame comes from Prerender, on a component the factory creates for each
+// page load, so the factory shouldn't have side effects such as joining a
+// chat room.
+func WithPrerender() ProgramOption {
+	return func(p *Program) {
+		p.prerender = true
+	}
+}
+
+// Prerender returns the first view of component at the given size: it runs
+// Init and delivers a WindowSizeMsg, discarding the commands they return,
+// then renders the view. State that commands would load is not shown.
+func Prerender(component Component, width, height int) string {
+	component.Init()
+	component, _ = component.Update(WindowSizeMsg{Width: width, Height: height})
+	return component.View()
+}
+
+// prerenderHTML converts a view into the markup the client renders frames
+// as: lines of styled spans separated by <br>
+func prerenderHTML(view string) string {
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = htmlBody(line)
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// serveIndex returns a handler serving the page with the component
+// prerendered into it, falling back on next for other paths
+func (p *Program) serveIndex(page func() ([]byte, error), next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		data, err := page()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		width, height := prerenderSize(r)
+		if frame, ok := p.prerenderFrame(width, height); ok {
+			data = injectFrame(data, frame)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(data)
+	}
+}
+
+// prerenderFrame renders the first frame of a new component, reporting
+// false if the component panics
+func (p *Program) prerenderFrame(width, height int) (frame string, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Failed to prerender the page: %v\n", r)
+			ok = false
+		}
+	}()
+	return prerenderHTML(Prerender(p.rootComponentFactory(), width, height)), true
+}
+
+// prerenderSize returns the size of the requesting page's terminal from its
+// cookie, or the default size of sessions
+func prerenderSize(r *http.Request) (width, height int) {
+	width, height = 80, 24
+	cookie, err := r.Cookie(sizeCookie)
+	if err != nil {
+		return width, height
+	}
+	var w, h int
+	if _, err := fmt.Sscanf(cookie.Value, "%dx%d", &w, &h); err != nil ||
+		w <= 0 || h <= 0 || w > maxPrerenderSize || h > maxPrerenderSize {
+		return width, height
+	}
+	return w, h
+}
+
+// injectFrame replaces the content of the page's #terminal element, which
+// must be text such as "Connecting...", with frame and marks the element
+// data-prerendered. Pages without one are returned as they are.
+func injectFrame(page []byte, frame string) []byte {
+	id := bytes.Index(page, []byte(`id="terminal"`))
+	if id < 0 {
+		return page
+	}
+	tagEnd := bytes.IndexByte(page[id:], '>')
+	if tagEnd < 0 {
+		return page
+	}
+	tagEnd += id
+	contentEnd := bytes.Index(page[tagEnd:], []byte("</"))
+	if contentEnd < 0 {
+		return page
+	}
+	contentEnd += tagEnd
+
+	var out bytes.Buffer
+	out.Write(page[:tagEnd])
+	out.WriteString(" data-prerendered>")
+	out.WriteString(frame)
+	out.Write(page[contentEnd:])
+	return out.Bytes()
+}
+
+// staticIndex returns a function reading index.html from the static files
+func staticIndex(files fs.FS) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return fs.ReadFile(files, "index.html")
+	}
+}
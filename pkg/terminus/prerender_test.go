@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sizedComponent shows the size it was given, in bold
+type sizedComponent struct {
+	ready         bool
+	width, height int
+}
+
+func (c *sizedComponent) Init() Cmd {
+	c.ready = true
+	return nil
+}
+
+func (c *sizedComponent) Update(msg Msg) (Component, Cmd) {
+	if size, ok := msg.(WindowSizeMsg); ok {
+		c.width, c.height = size.Width, size.Height
+	}
+	return c, nil
+}
+
+func (c *sizedComponent) View() string {
+	return fmt.Sprintf("ready: %v\n%s", c.ready, NewStyle().Bold(true).Render(fmt.Sprintf("%dx%d", c.width, c.height)))
+}
+
+func TestPrerender(t *testing.T) {
+	// get requests the page from a program of sizedComponent
+	get := func(t *testing.T, req *http.Request, opts ...ProgramOption) string {
+		t.Helper()
+		program := NewProgram(func() Component { return &sizedComponent{} }, opts...)
+		handler, err := program.routes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Renders the first view",
+			test: func(t *testing.T) {
+				if got := Prerender(&sizedComponent{}, 40, 10); got != "ready: true\n\x1b[0;1m40x10\x1b[0m" {
+					t.Errorf("Unexpected view %q", got)
+				}
+			},
+		},
+		{
+			name: "Serves the page with the first frame",
+			test: func(t *testing.T) {
+				page := get(t, httptest.NewRequest("GET", "/", nil), WithPrerender())
+				want := `<div id="terminal" data-prerendered>ready: true<br><span style="font-weight:bold">80x24</span></div>`
+				if !strings.Contains(page, want) {
+					t.Errorf("Expected the page to contain %s, got %s", want, page)
+				}
+			},
+		},
+		{
+			name: "Renders at the size of the client's terminal",
+			test: func(t *testing.T) {
+				req := httptest.NewRequest("GET", "/", nil)
+				req.AddCookie(&http.Cookie{Name: sizeCookie, Value: "120x40"})
+				if page := get(t, req, WithPrerender()); !strings.Contains(page, "120x40") {
+					t.Errorf("Expected the page to be rendered at 120x40, got %s", page)
+				}
+
+				req = httptest.NewRequest("GET", "/", nil)
+				req.AddCookie(&http.Cookie{Name: sizeCookie, Value: "99999x1"})
+				if page := get(t, req, WithPrerender()); !strings.Contains(page, "80x24") {
+					t.Errorf("Expected an unreasonable size to be ignored, got %s", page)
+				}
+			},
+		},
+		{
+			name: "Serves the page as is by default",
+			test: func(t *testing.T) {
+				if page := get(t, httptest.NewRequest("GET", "/", nil)); !strings.Contains(page, `<div id="terminal">Connecting...</div>`) {
+					t.Errorf("Expected the page without a frame, got %s", page)
+				}
+			},
+		},
+		{
+			name: "Leaves pages without a terminal",
+			test: func(t *testing.T) {
+				page := []byte("<html><body>Hello</body></html>")
+				if got := injectFrame(page, "frame"); string(got) != string(page) {
+					t.Errorf("Expected the page unchanged, got %s", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
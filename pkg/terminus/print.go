@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"fmt"
+	"html"
+)
+
+// ErrPrintUnavailable is reported when PrintView is used without a
+// connected client to print
+var ErrPrintUnavailable = errors.New("printing unavailable")
+
+// printRequestMsg asks the client to print the view
+type printRequestMsg struct {
+	title string
+}
+
+// PrintView returns a command that opens the browser's print dialog for
+// the current view, rendered by RenderPrintHTML, e.g. to print a report
+// from a table. The component receives nothing unless printing is
+// unavailable, when it receives an ErrMsg.
+func PrintView(title string) Cmd {
+	return func() Msg {
+		return printRequestMsg{title: title}
+	}
+}
+
+// printView sends the client a printable page of the component's view,
+// returning an error message for the component if there is no client and
+// otherwise nil
+func (e *Engine) printView(req printRequestMsg) Msg {
+	e.mu.RLock()
+	view := e.component.View()
+	e.mu.RUnlock()
+	page := RenderPrintHTML(view, req.title)
+	if e.toClient == nil || !e.toClient(ServerMessage{
+		Type: "print",
+		Data: map[string]interface{}{"html": page},
+	}) {
+		return ErrMsg{Err: ErrPrintUnavailable, Source: "PrintView"}
+	}
+	return nil
+}
+
+// RenderPrintHTML converts a view into a standalone HTML document for
+// printing: black text on white, with bold, italic and underlined text kept
+// and colored backgrounds as light gray, under the title. Long views break
+// across pages between lines.
+func RenderPrintHTML(view, title string) string {
+	title = html.EscapeString(title)
+	return fmt.Sprintf(htmlPrintTemplate, title, title, htmlBody(view))
+}
+
+// htmlPrintTemplate is the page around a printed view. Inline colors are
+// overridden so the page prints well in monochrome.
+const htmlPrintTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>%s</title>
+<style>
+@page { margin: 1.5cm; }
+:root { --terminus-fg: #000; --terminus-bg: #fff; }
+body { margin: 0; background: #fff; color: #000; font-family: 'Consolas', 'Monaco', 'Courier New', monospace; }
+h1 { font-size: 12pt; margin: 0 0 0.5em; padding-bottom: 0.25em; border-bottom: 1px solid #000; }
+pre { margin: 0; font-family: inherit; font-size: 9pt; line-height: 1.25; white-space: pre-wrap; orphans: 3; widows: 3; }
+pre span { color: #000 !important; opacity: 1 !important; }
+pre span[style*="background"] { background: #e0e0e0 !important; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<pre>%s</pre>
+</body>
+</html>
+`
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// printComponent prints its view when initialized
+type printComponent struct{}
+
+func (c *printComponent) Init() Cmd                       { return PrintView("Report") }
+func (c *printComponent) Update(msg Msg) (Component, Cmd) { return c, nil }
+func (c *printComponent) View() string                    { return NewStyle().Bold(true).Render("Total") + " 42" }
+
+func TestRenderPrintHTML(t *testing.T) {
+	page := RenderPrintHTML(NewStyle().Reverse(true).Render("row")+"\nnext", "Q&A")
+	for _, want := range []string{
+		"<h1>Q&amp;A</h1>",
+		"--terminus-fg: #000; --terminus-bg: #fff;",
+		`<span style="color:var(--terminus-bg);background:var(--terminus-fg)">row` + "\n</span>next",
+		"@page",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in %q", want, page)
+		}
+	}
+}
+
+func TestPrintView(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs := make(chan ErrMsg, 1)
+				capture := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if err, ok := msg.(ErrMsg); ok {
+							errs <- err
+						}
+						return next(ctx, msg)
+					}
+				}
+				startEngine(t, &printComponent{}, WithEngineMessageMiddleware(capture))
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrPrintUnavailable) {
+						t.Errorf("Expected ErrPrintUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Sends the client a printable page",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component { return &printComponent{} })
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+				conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+				if err != nil {
+					t.Fatalf("Failed to connect: %v", err)
+				}
+				defer conn.Close()
+
+				msg := readUntil(t, conn, `"type":"print"`)
+				page, _ := msg.Data["html"].(string)
+				if !strings.Contains(page, "<h1>Report</h1>") || !strings.Contains(page, "font-weight:bold\">Total</span> 42") {
+					t.Errorf("Expected the view as a printable page, got %q", page)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
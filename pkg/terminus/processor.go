@@ -16,34 +16,162 @@ package terminus
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 )
 
+var (
+	// ErrQueueFull is reported when a command is rejected because every
+	// worker is busy and the queue has reached its limit
+	ErrQueueFull = errors.New("command queue full")
+
+	// ErrTooManyStreams is reported when a streaming command is rejected
+	// because the stream limit has been reached
+	ErrTooManyStreams = errors.New("too many streaming commands")
+)
+
+// WorkerPoolConfig controls how commands are executed for a session
+type WorkerPoolConfig struct {
+	Workers    int // Commands executed concurrently
+	QueueSize  int // Commands waiting for a worker before new ones are rejected
+	MaxStreams int // Streaming commands running at once, 0 for no limit
+
+	// Limiter caps concurrent commands across every pool sharing it
+	Limiter *CommandLimiter
+}
+
+// DefaultWorkerPoolConfig returns the configuration used when none is given
+func DefaultWorkerPoolConfig() WorkerPoolConfig {
+	return WorkerPoolConfig{
+		Workers:    4,
+		QueueSize:  100,
+		MaxStreams: 64,
+	}
+}
+
+// withDefaults fills in unset sizes from DefaultWorkerPoolConfig
+func (c WorkerPoolConfig) withDefaults() WorkerPoolConfig {
+	d := DefaultWorkerPoolConfig()
+	if c.Workers <= 0 {
+		c.Workers = d.Workers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = d.QueueSize
+	}
+	if c.MaxStreams < 0 {
+		c.MaxStreams = 0
+	}
+	return c
+}
+
+// CommandLimiter bounds how many commands run at once across several
+// worker pools, such as every session of a program
+type CommandLimiter struct {
+	slots  chan struct{}
+	active int64
+}
+
+// NewCommandLimiter creates a limiter allowing max concurrent commands
+func NewCommandLimiter(max int) *CommandLimiter {
+	if max <= 0 {
+		max = 1
+	}
+	return &CommandLimiter{slots: make(chan struct{}, max)}
+}
+
+// acquire waits for a free slot, returning false if ctx is cancelled first
+func (l *CommandLimiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		atomic.AddInt64(&l.active, 1)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot taken by acquire
+func (l *CommandLimiter) release() {
+	atomic.AddInt64(&l.active, -1)
+	<-l.slots
+}
+
+// Active returns the number of commands currently holding a slot
+func (l *CommandLimiter) Active() int {
+	return int(atomic.LoadInt64(&l.active))
+}
+
+// Limit returns the maximum number of concurrent commands
+func (l *CommandLimiter) Limit() int {
+	return cap(l.slots)
+}
+
+// WorkerPoolMetrics is a snapshot of a worker pool's activity
+type WorkerPoolMetrics struct {
+	Workers  int    // Configured workers
+	Queued   int    // Commands waiting for a worker
+	Active   int    // Commands currently executing
+	Streams  int    // Streaming commands currently running
+	Executed uint64 // Commands completed
+	Rejected uint64 // Commands and streams rejected by queue or stream limits
+}
+
+// Add returns the sum of two snapshots, used to aggregate sessions
+func (m WorkerPoolMetrics) Add(o WorkerPoolMetrics) WorkerPoolMetrics {
+	return WorkerPoolMetrics{
+		Workers:  m.Workers + o.Workers,
+		Queued:   m.Queued + o.Queued,
+		Active:   m.Active + o.Active,
+		Streams:  m.Streams + o.Streams,
+		Executed: m.Executed + o.Executed,
+		Rejected: m.Rejected + o.Rejected,
+	}
+}
+
 // CommandProcessor manages concurrent execution of commands
 type CommandProcessor struct {
 	ctx       context.Context
 	cancel    context.CancelFunc
 	wg        sync.WaitGroup
-	workerCount int
+	config    WorkerPoolConfig
 	cmdQueue  chan Cmd
 	msgSender func(Msg)
+	runner    func(Cmd) Msg // Runs a command, execCmd by default
+
+	// Guards cmdQueue against sends after Stop
+	mu      sync.RWMutex
+	stopped bool
+
+	// Metrics
+	active   int64
+	streams  int64
+	executed uint64
+	rejected uint64
 }
 
 // NewCommandProcessor creates a new command processor with the specified number of workers
 func NewCommandProcessor(workerCount int, msgSender func(Msg)) *CommandProcessor {
+	return NewCommandProcessorWithConfig(WorkerPoolConfig{Workers: workerCount}, msgSender)
+}
+
+// NewCommandProcessorWithConfig creates a command processor from a worker pool configuration
+func NewCommandProcessorWithConfig(config WorkerPoolConfig, msgSender func(Msg)) *CommandProcessor {
+	config = config.withDefaults()
 	ctx, cancel := context.WithCancel(context.Background())
 	return &CommandProcessor{
-		ctx:         ctx,
-		cancel:      cancel,
-		workerCount: workerCount,
-		cmdQueue:    make(chan Cmd, 100),
-		msgSender:   msgSender,
+		ctx:       ctx,
+		cancel:    cancel,
+		config:    config,
+		cmdQueue:  make(chan Cmd, config.QueueSize),
+		msgSender: msgSender,
+		runner:    execCmd,
 	}
 }
 
 // Start begins processing commands
 func (p *CommandProcessor) Start() {
-	for i := 0; i < p.workerCount; i++ {
+	for i := 0; i < p.config.Workers; i++ {
 		p.wg.Add(1)
 		go p.worker()
 	}
@@ -51,41 +179,109 @@ func (p *CommandProcessor) Start() {
 
 // Stop gracefully shuts down the processor
 func (p *CommandProcessor) Stop() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
 	p.cancel()
 	close(p.cmdQueue)
+	p.mu.Unlock()
+
 	p.wg.Wait()
 }
 
-// Execute queues a command for execution
-func (p *CommandProcessor) Execute(cmd Cmd) {
+// Execute queues a command for execution. It never blocks: if the queue is
+// full the command is dropped and ErrQueueFull is returned.
+func (p *CommandProcessor) Execute(cmd Cmd) error {
 	if cmd == nil {
-		return
+		return nil
 	}
-	
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped {
+		return nil
+	}
+
 	select {
 	case p.cmdQueue <- cmd:
-	case <-p.ctx.Done():
+		return nil
+	default:
+		atomic.AddUint64(&p.rejected, 1)
+		return ErrQueueFull
+	}
+}
+
+// acquireStream reserves a slot for a streaming command
+func (p *CommandProcessor) acquireStream() bool {
+	n := atomic.AddInt64(&p.streams, 1)
+	if p.config.MaxStreams > 0 && n > int64(p.config.MaxStreams) {
+		atomic.AddInt64(&p.streams, -1)
+		atomic.AddUint64(&p.rejected, 1)
+		return false
+	}
+	return true
+}
+
+// releaseStream frees a slot taken by acquireStream
+func (p *CommandProcessor) releaseStream() {
+	atomic.AddInt64(&p.streams, -1)
+}
+
+// Metrics returns a snapshot of the processor's activity
+func (p *CommandProcessor) Metrics() WorkerPoolMetrics {
+	return WorkerPoolMetrics{
+		Workers:  p.config.Workers,
+		Queued:   len(p.cmdQueue),
+		Active:   int(atomic.LoadInt64(&p.active)),
+		Streams:  int(atomic.LoadInt64(&p.streams)),
+		Executed: atomic.LoadUint64(&p.executed),
+		Rejected: atomic.LoadUint64(&p.rejected),
 	}
 }
 
 // worker processes commands from the queue
 func (p *CommandProcessor) worker() {
 	defer p.wg.Done()
-	
+
 	for {
 		select {
 		case cmd, ok := <-p.cmdQueue:
 			if !ok {
 				return
 			}
-			
-			// Execute the command
-			if msg := cmd(); msg != nil && p.msgSender != nil {
-				p.msgSender(msg)
-			}
-			
+			p.run(cmd)
+
 		case <-p.ctx.Done():
 			return
 		}
 	}
-}
\ No newline at end of file
+}
+
+// run executes a single command and delivers its result
+func (p *CommandProcessor) run(cmd Cmd) {
+	msg, ok := p.exec(cmd)
+	if ok && msg != nil && p.msgSender != nil {
+		p.msgSender(msg)
+	}
+}
+
+// exec executes a command, holding a limiter slot if one is configured. It
+// returns false if the processor stopped while waiting for a slot.
+func (p *CommandProcessor) exec(cmd Cmd) (Msg, bool) {
+	if l := p.config.Limiter; l != nil {
+		if !l.acquire(p.ctx) {
+			return nil, false
+		}
+		defer l.release()
+	}
+
+	atomic.AddInt64(&p.active, 1)
+	defer atomic.AddInt64(&p.active, -1)
+	defer atomic.AddUint64(&p.executed, 1)
+
+	// Execute the command, turning panics and errors into ErrMsg
+	return p.runner(cmd), true
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCommandProcessor(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Rejects commands when queue is full",
+			test: func(t *testing.T) {
+				p := NewCommandProcessorWithConfig(WorkerPoolConfig{Workers: 1, QueueSize: 2}, nil)
+				defer p.Stop()
+
+				noop := func() Msg { return nil }
+				for i := 0; i < 2; i++ {
+					if err := p.Execute(noop); err != nil {
+						t.Fatalf("Unexpected error queueing command %d: %v", i, err)
+					}
+				}
+				if err := p.Execute(noop); !errors.Is(err, ErrQueueFull) {
+					t.Errorf("Expected ErrQueueFull, got %v", err)
+				}
+
+				m := p.Metrics()
+				if m.Queued != 2 || m.Rejected != 1 {
+					t.Errorf("Unexpected metrics: %+v", m)
+				}
+			},
+		},
+		{
+			name: "Counts executed commands",
+			test: func(t *testing.T) {
+				var wg sync.WaitGroup
+				wg.Add(5)
+				p := NewCommandProcessor(2, func(Msg) { wg.Done() })
+				p.Start()
+				defer p.Stop()
+
+				for i := 0; i < 5; i++ {
+					p.Execute(func() Msg { return testMsg{value: "done"} })
+				}
+				wg.Wait()
+
+				if m := p.Metrics(); m.Executed != 5 || m.Workers != 2 {
+					t.Errorf("Unexpected metrics: %+v", m)
+				}
+			},
+		},
+		{
+			name: "Execute after stop is ignored",
+			test: func(t *testing.T) {
+				p := NewCommandProcessor(1, nil)
+				p.Start()
+				p.Stop()
+
+				if err := p.Execute(func() Msg { return nil }); err != nil {
+					t.Errorf("Expected stopped processor to ignore commands, got %v", err)
+				}
+			},
+		},
+		{
+			name: "Limiter is shared between pools",
+			test: func(t *testing.T) {
+				limiter := NewCommandLimiter(2)
+				config := WorkerPoolConfig{Workers: 4, Limiter: limiter}
+
+				var running, peak int64
+				var wg sync.WaitGroup
+				cmd := func() Msg {
+					defer wg.Done()
+					n := atomic.AddInt64(&running, 1)
+					for {
+						old := atomic.LoadInt64(&peak)
+						if n <= old || atomic.CompareAndSwapInt64(&peak, old, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt64(&running, -1)
+					return nil
+				}
+
+				a := NewCommandProcessorWithConfig(config, nil)
+				b := NewCommandProcessorWithConfig(config, nil)
+				a.Start()
+				b.Start()
+				defer a.Stop()
+				defer b.Stop()
+
+				wg.Add(8)
+				for i := 0; i < 4; i++ {
+					a.Execute(cmd)
+					b.Execute(cmd)
+				}
+				wg.Wait()
+
+				if peak > 2 {
+					t.Errorf("Expected at most 2 concurrent commands, got %d", peak)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestEngineStreamLimit(t *testing.T) {
+	received := make(chan Msg, 1)
+	comp := &recordingComponent{
+		onMsg: func(msg Msg) {
+			if _, ok := msg.(ErrMsg); ok {
+				received <- msg
+			}
+		},
+	}
+
+	engine := NewEngine(comp, WithEngineWorkerPool(WorkerPoolConfig{MaxStreams: 1}))
+	engine.Start()
+	defer engine.Stop()
+
+	block := Stream(func(ctx context.Context, send func(Msg)) Msg {
+		<-ctx.Done()
+		return nil
+	})
+	engine.deliver(block())
+	engine.deliver(block())
+
+	select {
+	case msg := <-received:
+		if !errors.Is(msg.(ErrMsg), ErrTooManyStreams) {
+			t.Errorf("Expected ErrTooManyStreams, got %v", msg)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected second stream to be rejected")
+	}
+
+	if m := engine.Metrics(); m.Streams != 1 || m.Rejected != 1 {
+		t.Errorf("Unexpected metrics: %+v", m)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Frame phases, also used as the value of the "terminus.phase" pprof label
+const (
+	PhaseView      = "view"
+	PhaseDiff      = "diff"
+	PhaseSerialize = "serialize"
+)
+
+// FrameTiming is the time spent producing one frame
+type FrameTiming struct {
+	At        time.Time
+	Component string // Type of the root component
+	View      time.Duration
+	Diff      time.Duration
+	Serialize time.Duration
+}
+
+// Total returns the time spent on the frame
+func (f FrameTiming) Total() time.Duration {
+	return f.View + f.Diff + f.Serialize
+}
+
+// Percentiles summarizes a set of durations
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// String formats the percentiles, e.g. "p50 1ms p90 2ms p99 5ms max 9ms"
+func (p Percentiles) String() string {
+	return fmt.Sprintf("p50 %s p90 %s p99 %s max %s", p.P50, p.P90, p.P99, p.Max)
+}
+
+// ProfileStats summarizes the recorded frames
+type ProfileStats struct {
+	Frames    int
+	View      Percentiles
+	Diff      Percentiles
+	Serialize Percentiles
+	Total     Percentiles
+}
+
+// Profiler records frame timings from every session it is attached to.
+// While profiling, each phase runs with pprof labels ("terminus.phase" and
+// "terminus.component"), so a CPU profile taken with WriteCPUProfile can be
+// filtered with go tool pprof -tagfocus to find slow components.
+type Profiler struct {
+	mu     sync.Mutex
+	frames []FrameTiming // Ring buffer
+	next   int
+	full   bool
+}
+
+// NewProfiler creates a profiler that keeps the last size frames. A size of
+// zero or less keeps 1000.
+func NewProfiler(size int) *Profiler {
+	if size <= 0 {
+		size = 1000
+	}
+	return &Profiler{frames: make([]FrameTiming, size)}
+}
+
+// WithEngineProfiling records the engine's frame timings in p
+func WithEngineProfiling(p *Profiler) EngineOption {
+	return func(e *Engine) {
+		e.profile = &frameProfile{profiler: p}
+	}
+}
+
+// Record adds a frame's timing
+func (p *Profiler) Record(frame FrameTiming) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.frames[p.next] = frame
+	p.next = (p.next + 1) % len(p.frames)
+	if p.next == 0 {
+		p.full = true
+	}
+}
+
+// Frames returns the recorded frames, oldest first
+func (p *Profiler) Frames() []FrameTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.full {
+		return append([]FrameTiming(nil), p.frames[:p.next]...)
+	}
+	return append(append([]FrameTiming(nil), p.frames[p.next:]...), p.frames[:p.next]...)
+}
+
+// Reset discards the recorded frames
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.next, p.full = 0, false
+}
+
+// Stats returns percentiles of the recorded frames
+func (p *Profiler) Stats() ProfileStats {
+	frames := p.Frames()
+	pick := func(phase func(FrameTiming) time.Duration) Percentiles {
+		durations := make([]time.Duration, len(frames))
+		for i, f := range frames {
+			durations[i] = phase(f)
+		}
+		return percentiles(durations)
+	}
+	return ProfileStats{
+		Frames:    len(frames),
+		View:      pick(func(f FrameTiming) time.Duration { return f.View }),
+		Diff:      pick(func(f FrameTiming) time.Duration { return f.Diff }),
+		Serialize: pick(func(f FrameTiming) time.Duration { return f.Serialize }),
+		Total:     pick(FrameTiming.Total),
+	}
+}
+
+// percentiles computes nearest-rank percentiles
+func percentiles(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	rank := func(p int) time.Duration {
+		i := (p*len(durations)+99)/100 - 1
+		if i < 0 {
+			i = 0
+		}
+		return durations[i]
+	}
+	return Percentiles{
+		P50: rank(50),
+		P90: rank(90),
+		P99: rank(99),
+		Max: durations[len(durations)-1],
+	}
+}
+
+// WriteCPUProfile takes a CPU profile for d, or until ctx is done, and
+// writes it to w in pprof format. Only one CPU profile can run at a time
+// in a process.
+func (p *Profiler) WriteCPUProfile(ctx context.Context, w io.Writer, d time.Duration) error {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	defer pprof.StopCPUProfile()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// frameProfile times the phases of one engine's frames
+type frameProfile struct {
+	profiler *Profiler
+	mu       sync.Mutex
+	frame    FrameTiming
+}
+
+// begin starts timing a frame of component
+func (f *frameProfile) begin(component Component) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	f.frame = FrameTiming{At: time.Now(), Component: fmt.Sprintf("%T", component)}
+	f.mu.Unlock()
+}
+
+// measure runs fn, adding its duration to the phase of the current frame.
+// fn runs with pprof labels naming the phase and component.
+func (f *frameProfile) measure(phase string, fn func()) {
+	if f == nil {
+		fn()
+		return
+	}
+	f.mu.Lock()
+	component := f.frame.Component
+	f.mu.Unlock()
+
+	start := time.Now()
+	labels := pprof.Labels("terminus.phase", phase, "terminus.component", component)
+	pprof.Do(context.Background(), labels, func(context.Context) { fn() })
+	took := time.Since(start)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch phase {
+	case PhaseView:
+		f.frame.View += took
+	case PhaseDiff:
+		f.frame.Diff += took
+	case PhaseSerialize:
+		f.frame.Serialize += took
+	}
+}
+
+// end records the current frame
+func (f *frameProfile) end() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	frame := f.frame
+	f.mu.Unlock()
+	f.profiler.Record(frame)
+}
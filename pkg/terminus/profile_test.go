@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowComponent takes a fixed time to render
+type slowComponent struct {
+	testComponent
+	delay time.Duration
+}
+
+func (s *slowComponent) View() string {
+	time.Sleep(s.delay)
+	return "slow"
+}
+
+func TestProfiler(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Computes percentiles",
+			test: func(t *testing.T) {
+				p := NewProfiler(0)
+				for i := 1; i <= 100; i++ {
+					p.Record(FrameTiming{View: time.Duration(i) * time.Millisecond, Diff: time.Millisecond})
+				}
+				stats := p.Stats()
+
+				if stats.Frames != 100 {
+					t.Errorf("Expected 100 frames, got %d", stats.Frames)
+				}
+				expected := Percentiles{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+				if stats.View != expected {
+					t.Errorf("Expected %v, got %v", expected, stats.View)
+				}
+				if stats.Total.Max != 101*time.Millisecond {
+					t.Errorf("Expected total to include every phase, got %v", stats.Total.Max)
+				}
+				if stats.Serialize != (Percentiles{}) {
+					t.Errorf("Expected zero serialize time, got %v", stats.Serialize)
+				}
+			},
+		},
+		{
+			name: "Keeps the most recent frames",
+			test: func(t *testing.T) {
+				p := NewProfiler(3)
+				for i := 1; i <= 5; i++ {
+					p.Record(FrameTiming{View: time.Duration(i)})
+				}
+				frames := p.Frames()
+				if len(frames) != 3 || frames[0].View != 3 || frames[2].View != 5 {
+					t.Errorf("Expected frames 3-5 oldest first, got %v", frames)
+				}
+
+				p.Reset()
+				if len(p.Frames()) != 0 || p.Stats().Frames != 0 {
+					t.Error("Expected no frames after Reset")
+				}
+			},
+		},
+		{
+			name: "Records engine frames",
+			test: func(t *testing.T) {
+				p := NewProfiler(10)
+				comp := &slowComponent{delay: 5 * time.Millisecond}
+				engine := NewEngine(comp, WithEngineProfiling(p))
+				renders := make(chan string, 10)
+				engine.SetRenderCallback(func(view string) {
+					engine.profile.measure(PhaseDiff, func() { time.Sleep(2 * time.Millisecond) })
+					renders <- view
+				})
+				engine.Start()
+				defer engine.Stop()
+				<-renders
+				engine.SendMessage(testMsg{value: "next"})
+				<-renders
+				time.Sleep(10 * time.Millisecond)
+
+				frames := p.Frames()
+				if len(frames) != 2 {
+					t.Fatalf("Expected 2 frames, got %d", len(frames))
+				}
+				f := frames[0]
+				if f.View < 5*time.Millisecond || f.Diff < 2*time.Millisecond {
+					t.Errorf("Expected view and diff time measured, got %+v", f)
+				}
+				if f.Component != "*terminus.slowComponent" {
+					t.Errorf("Expected the component type, got %q", f.Component)
+				}
+			},
+		},
+		{
+			name: "Shows percentiles in the debug overlay",
+			test: func(t *testing.T) {
+				p := NewProfiler(10)
+				p.Record(FrameTiming{View: 3 * time.Millisecond})
+				engine := NewEngine(&testComponent{}, WithEngineDebugOverlay(DefaultDebugKey), WithEngineProfiling(p))
+
+				text := strings.Join(engine.debug.lines(), "\n")
+				if !strings.Contains(text, "view    p50 3ms") || !strings.Contains(text, "encode  p50 0s") {
+					t.Errorf("Expected profile lines, got %q", text)
+				}
+			},
+		},
+		{
+			name: "Writes a CPU profile",
+			test: func(t *testing.T) {
+				var buf bytes.Buffer
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				if err := NewProfiler(1).WriteCPUProfile(ctx, &buf, time.Minute); err != nil {
+					t.Fatalf("WriteCPUProfile failed: %v", err)
+				}
+				// pprof output is gzip-compressed
+				if !bytes.HasPrefix(buf.Bytes(), []byte{0x1f, 0x8b}) {
+					t.Error("Expected a gzipped pprof profile")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
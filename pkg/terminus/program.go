@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"io/fs"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -32,6 +34,37 @@ type Program struct {
 	rootComponentFactory   func() Component
 	staticFS               embed.FS
 	staticPath             string
+	workerPool             WorkerPoolConfig
+	limiter                *CommandLimiter
+	debugKey               *KeyMsg
+	copyModeKey            *KeyMsg
+	scrollback             int
+	colorProfile           *ColorProfile
+	styleLint              func(StyleIssue)
+	lintStyles             bool
+	profiler               *Profiler
+	middleware             []MessageMiddleware
+	cmdMiddleware          []CommandMiddleware
+	recordDir              string
+	resizeDebounce         *time.Duration
+	minWidth, minHeight    int
+	tabWidth               int
+	wrap                   Wrap
+	shortcuts              []Shortcut
+	maxPendingFrames       int
+	reconnectWindow        *time.Duration
+	heartbeatInterval      time.Duration
+	heartbeatTimeout       *time.Duration
+	transports             []Transport
+	endpoints              []endpoint
+	onSessionEnd           []func(SessionEnd)
+	measureGlyphs          bool
+	csp                    *string
+	frameAncestors         []string
+	allowedOrigins         []string
+	onClientError          []func(ClientError)
+	prerender              bool
+	auth                   func(r *http.Request) (RoleSet, error)
 	
 	// Runtime state
 	server         *http.Server
@@ -45,6 +78,12 @@ type Program struct {
 // ProgramOption is a function that configures a Program
 type ProgramOption func(*Program)
 
+// endpoint serves sessions of another component over WebSockets
+type endpoint struct {
+	path    string
+	factory func() Component
+}
+
 // WithStaticFiles configures the program to serve static files from an embedded filesystem
 func WithStaticFiles(fs embed.FS, path string) ProgramOption {
 	return func(p *Program) {
@@ -60,6 +99,219 @@ func WithAddress(addr string) ProgramOption {
 	}
 }
 
+// WithWorkerPool configures the worker pool each session uses to execute
+// commands
+func WithWorkerPool(config WorkerPoolConfig) ProgramOption {
+	return func(p *Program) {
+		p.workerPool = config
+	}
+}
+
+// WithMaxConcurrentCommands limits how many commands run at once across all
+// sessions
+func WithMaxConcurrentCommands(n int) ProgramOption {
+	return func(p *Program) {
+		p.limiter = NewCommandLimiter(n)
+	}
+}
+
+// WithDebugOverlay lets each session toggle a developer overlay with key,
+// e.g. DefaultDebugKey. The overlay shows frame rate, render time, message
+// rate, the session ID, terminal size and the focus path.
+func WithDebugOverlay(key KeyMsg) ProgramOption {
+	return func(p *Program) {
+		p.debugKey = &key
+	}
+}
+
+// WithCopyMode lets each session enter copy mode with key, e.g.
+// DefaultCopyModeKey. Copy mode freezes the screen and any scrollback; the
+// arrow keys or hjkl move a cursor over them, v or Space starts a
+// selection, r makes it a rectangle, and Enter or y copies it, or the
+// cursor's line, to the clipboard. Esc, q or the key again leave it.
+func WithCopyMode(key KeyMsg) ProgramOption {
+	return func(p *Program) {
+		p.copyModeKey = &key
+	}
+}
+
+// WithScrollback keeps up to lines lines of each session's views that
+// scroll off the top of the screen, such as the older messages of a chat
+// that renders its whole history. Copy mode can move up into them,
+// ReadScrollback reads them, and widget.Pager's ShowScrollback pages them.
+func WithScrollback(lines int) ProgramOption {
+	return func(p *Program) {
+		p.scrollback = lines
+	}
+}
+
+// WithColorProfile renders every session in profile, e.g. Colors256 for
+// an audience of 256 color terminals, instead of the profile each client
+// advertises. RGB and 256 colors outside the profile are mapped to the
+// nearest inside it.
+func WithColorProfile(profile ColorProfile) ProgramOption {
+	return func(p *Program) {
+		p.colorProfile = &profile
+	}
+}
+
+// WithStyleLint checks every session's views for common styling bugs, such
+// as styled strings truncated by len() or lines wider than the screen, and
+// calls report with each, naming the component, or the child of a
+// Composite, it comes from. A nil report logs them. It is meant for
+// development, as it renders the children of composites again.
+func WithStyleLint(report func(StyleIssue)) ProgramOption {
+	return func(p *Program) {
+		p.lintStyles, p.styleLint = true, report
+	}
+}
+
+// WithProfiling records the frame timings of every session in profiler.
+// Percentiles are available from profiler.Stats and in the debug overlay.
+func WithProfiling(profiler *Profiler) ProgramOption {
+	return func(p *Program) {
+		p.profiler = profiler
+	}
+}
+
+// WithMessageMiddleware adds middleware around message delivery in every
+// session, e.g. to log, filter or rate-limit messages before they reach
+// Update. The first middleware sees each message first.
+func WithMessageMiddleware(middleware ...MessageMiddleware) ProgramOption {
+	return func(p *Program) {
+		p.middleware = append(p.middleware, middleware...)
+	}
+}
+
+// WithCommandMiddleware adds middleware around every command executed in
+// every session, e.g. to time commands or trace them as spans
+func WithCommandMiddleware(middleware ...CommandMiddleware) ProgramOption {
+	return func(p *Program) {
+		p.cmdMiddleware = append(p.cmdMiddleware, middleware...)
+	}
+}
+
+// WithRecording records every session to an asciinema cast file named
+// <session ID>.cast in dir. Play recordings back with asciinema or with
+// widget.Player.
+func WithRecording(dir string) ProgramOption {
+	return func(p *Program) {
+		p.recordDir = dir
+	}
+}
+
+// WithResizeDebounce sets how long browser resizes must settle before
+// components receive the final WindowSizeMsg (default DefaultResizeDebounce).
+// Zero delivers every resize.
+func WithResizeDebounce(d time.Duration) ProgramOption {
+	return func(p *Program) {
+		p.resizeDebounce = &d
+	}
+}
+
+// WithMinSize shows a standard "terminal too small" screen in place of the
+// view while a session's terminal is smaller than width x height
+func WithMinSize(width, height int) ProgramOption {
+	return func(p *Program) {
+		p.minWidth, p.minHeight = width, height
+	}
+}
+
+// WithTabWidth sets the distance between the tab stops views are rendered
+// with (default DefaultTabWidth). Pass the same width to widgets that expand
+// tabs, such as Pager, so they line up with the screen.
+func WithTabWidth(width int) ProgramOption {
+	return func(p *Program) {
+		p.tabWidth = width
+	}
+}
+
+// WithWrap sets how lines wider than the terminal are wrapped (default
+// WrapChar). Components that implement WrapPolicy choose their own.
+func WithWrap(wrap Wrap) ProgramOption {
+	return func(p *Program) {
+		p.wrap = wrap
+	}
+}
+
+// WithShortcuts registers application-global shortcuts, matched before keys
+// reach the component whichever widget has the focus. The component
+// receives a ShortcutMsg in place of the key.
+func WithShortcuts(shortcuts ...Shortcut) ProgramOption {
+	return func(p *Program) {
+		p.shortcuts = append(p.shortcuts, shortcuts...)
+	}
+}
+
+// WithMaxPendingFrames sets how many rendered frames may wait for a slow
+// client before they are dropped in favor of a redraw of the latest (default
+// DefaultMaxPendingFrames)
+func WithMaxPendingFrames(n int) ProgramOption {
+	return func(p *Program) {
+		p.maxPendingFrames = n
+	}
+}
+
+// WithReconnectWindow sets how long a session waits for its client to
+// reconnect after the connection drops (default DefaultReconnectWindow).
+// Zero ends sessions as soon as their connection drops.
+func WithReconnectWindow(d time.Duration) ProgramOption {
+	return func(p *Program) {
+		p.reconnectWindow = &d
+	}
+}
+
+// WithHeartbeat pings clients every interval and drops connections whose
+// client hasn't been heard from within timeout (defaults
+// DefaultHeartbeatInterval and DefaultHeartbeatTimeout), so sessions of
+// clients that vanished without closing their connection are cleaned up
+func WithHeartbeat(interval, timeout time.Duration) ProgramOption {
+	return func(p *Program) {
+		p.heartbeatInterval = interval
+		p.heartbeatTimeout = &timeout
+	}
+}
+
+// WithOnSessionEnd calls hook after each session ends, with its exit code
+// if the component quit. Hooks run in the order they were added.
+func WithOnSessionEnd(hook func(SessionEnd)) ProgramOption {
+	return func(p *Program) {
+		p.onSessionEnd = append(p.onSessionEnd, hook)
+	}
+}
+
+// WithEndpoint also serves sessions of another component over WebSockets on
+// path, e.g. "/ws/clock", so a page can embed several components with
+// Terminus.mount. The program's own component stays on /ws. Start returns
+// an error if path doesn't start with "/" or is already served, such as "/"
+// or "/ws".
+func WithEndpoint(path string, factory func() Component) ProgramOption {
+	return func(p *Program) {
+		p.endpoints = append(p.endpoints, endpoint{path: path, factory: factory})
+	}
+}
+
+// WithBinaryProtocol sends frames to clients that support it in a compact
+// binary encoding instead of JSON, negotiated with the BinaryProtocol
+// WebSocket subprotocol when the client connects. It saves bandwidth and
+// serialization time for views that change often. Observers of shared
+// sessions always receive JSON.
+func WithBinaryProtocol() ProgramOption {
+	return func(p *Program) {
+		p.upgrader.Subprotocols = []string{BinaryProtocol}
+	}
+}
+
+// WithTransport serves sessions over another transport alongside the
+// default WebSocket transport on /ws, on the same HTTP server. The bundled
+// client only connects to /ws, so other endpoints are for custom
+// frontends. Shared sessions are always served over WebSockets.
+func WithTransport(transport Transport) ProgramOption {
+	return func(p *Program) {
+		p.transports = append(p.transports, transport)
+	}
+}
+
 // NewProgram creates a new TerminusGo program
 func NewProgram(rootComponentFactory func() Component, opts ...ProgramOption) *Program {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -68,15 +320,11 @@ func NewProgram(rootComponentFactory func() Component, opts ...ProgramOption) *P
 		addr:                 ":8080",
 		rootComponentFactory: rootComponentFactory,
 		sessionManager:       NewSessionManager(),
-		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				// In production, implement proper origin checking
-				return true
-			},
-		},
-		ctx:    ctx,
-		cancel: cancel,
+		workerPool:           DefaultWorkerPoolConfig(),
+		ctx:                  ctx,
+		cancel:               cancel,
 	}
+	p.upgrader.CheckOrigin = p.checkOrigin
 	
 	// Apply options
 	for _, opt := range opts {
@@ -88,28 +336,14 @@ func NewProgram(rootComponentFactory func() Component, opts ...ProgramOption) *P
 
 // Start starts the TerminusGo program
 func (p *Program) Start() error {
-	mux := http.NewServeMux()
-	
-	// Serve static files if configured
-	if p.staticPath != "" {
-		// Create a sub-filesystem from the static path
-		subFS, err := fs.Sub(p.staticFS, p.staticPath)
-		if err != nil {
-			return fmt.Errorf("failed to create sub filesystem: %w", err)
-		}
-		fileServer := http.FileServer(http.FS(subFS))
-		mux.Handle("/", fileServer)
-	} else {
-		// Serve default HTML if no static files configured
-		mux.HandleFunc("/", p.handleIndex)
+	handler, err := p.routes()
+	if err != nil {
+		return err
 	}
 	
-	// WebSocket endpoint
-	mux.HandleFunc("/ws", p.handleWebSocket)
-	
 	p.server = &http.Server{
 		Addr:    p.addr,
-		Handler: mux,
+		Handler: handler,
 	}
 	
 	// Start server in goroutine
@@ -124,6 +358,63 @@ func (p *Program) Start() error {
 	return nil
 }
 
+// routes returns the program's handlers: static files, the WebSocket
+// endpoints and any other transports, behind the security headers. It
+// fails if an endpoint or transport path is invalid or already served.
+func (p *Program) routes() (http.Handler, error) {
+	mux := http.NewServeMux()
+	
+	// Serve static files if configured
+	if p.staticPath != "" {
+		// Create a sub-filesystem from the static path
+		subFS, err := fs.Sub(p.staticFS, p.staticPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sub filesystem: %w", err)
+		}
+		fileServer := http.FileServer(http.FS(subFS))
+		if p.prerender {
+			mux.Handle("/", p.serveIndex(staticIndex(subFS), fileServer))
+		} else {
+			mux.Handle("/", fileServer)
+		}
+	} else if p.prerender {
+		defaultPage := func() ([]byte, error) { return []byte(defaultHTML), nil }
+		mux.Handle("/", p.serveIndex(defaultPage, http.HandlerFunc(p.handleIndex)))
+	} else {
+		// Serve default HTML if no static files configured
+		mux.HandleFunc("/", p.handleIndex)
+	}
+	
+	// WebSocket endpoints, then any other transports. The mux panics on a
+	// path registered twice, so they are checked first.
+	served := map[string]bool{"/": true}
+	handle := func(path string, handler http.HandlerFunc) error {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("endpoint %q must start with /", path)
+		}
+		if served[path] {
+			return fmt.Errorf("endpoint %q is already served", path)
+		}
+		served[path] = true
+		mux.HandleFunc(path, handler)
+		return nil
+	}
+	if err := handle("/ws", p.handleWebSocket); err != nil {
+		return nil, err
+	}
+	for _, ep := range p.endpoints {
+		if err := handle(ep.path, p.webSocketHandler(ep.factory)); err != nil {
+			return nil, err
+		}
+	}
+	for _, transport := range p.transports {
+		if err := handle(transport.Path(), p.handleTransport(transport)); err != nil {
+			return nil, err
+		}
+	}
+	return p.secureHeaders(mux), nil
+}
+
 // Stop gracefully shuts down the program
 func (p *Program) Stop() error {
 	p.cancel()
@@ -146,6 +437,78 @@ func (p *Program) Stop() error {
 	return nil
 }
 
+// Metrics returns the combined command worker pool metrics of all sessions
+func (p *Program) Metrics() WorkerPoolMetrics {
+	return p.sessionManager.Metrics()
+}
+
+// engineOptions returns the options used to create each session's engine
+func (p *Program) engineOptions() []EngineOption {
+	pool := p.workerPool
+	if p.limiter != nil {
+		pool.Limiter = p.limiter
+	}
+	opts := []EngineOption{WithEngineWorkerPool(pool)}
+	if p.debugKey != nil {
+		opts = append(opts, WithEngineDebugOverlay(*p.debugKey))
+	}
+	if p.copyModeKey != nil {
+		opts = append(opts, WithEngineCopyMode(*p.copyModeKey))
+	}
+	if p.scrollback > 0 {
+		opts = append(opts, WithEngineScrollback(p.scrollback))
+	}
+	if p.colorProfile != nil {
+		opts = append(opts, WithEngineColorProfile(*p.colorProfile))
+	}
+	if p.lintStyles {
+		opts = append(opts, WithEngineStyleLint(p.styleLint))
+	}
+	if p.profiler != nil {
+		opts = append(opts, WithEngineProfiling(p.profiler))
+	}
+	if len(p.middleware) > 0 {
+		opts = append(opts, WithEngineMessageMiddleware(p.middleware...))
+	}
+	if len(p.cmdMiddleware) > 0 {
+		opts = append(opts, WithEngineCommandMiddleware(p.cmdMiddleware...))
+	}
+	if p.recordDir != "" {
+		opts = append(opts, WithEngineRecording(p.recordDir))
+	}
+	if p.resizeDebounce != nil {
+		opts = append(opts, WithEngineResizeDebounce(*p.resizeDebounce))
+	}
+	if p.minWidth > 0 || p.minHeight > 0 {
+		opts = append(opts, WithEngineMinSize(p.minWidth, p.minHeight))
+	}
+	if p.tabWidth > 0 {
+		opts = append(opts, WithEngineTabWidth(p.tabWidth))
+	}
+	if p.wrap != (Wrap{}) {
+		opts = append(opts, WithEngineWrap(p.wrap))
+	}
+	if len(p.shortcuts) > 0 {
+		opts = append(opts, WithEngineShortcuts(p.shortcuts...))
+	}
+	if p.maxPendingFrames > 0 {
+		opts = append(opts, WithEngineMaxPendingFrames(p.maxPendingFrames))
+	}
+	if p.reconnectWindow != nil {
+		opts = append(opts, WithEngineReconnectWindow(*p.reconnectWindow))
+	}
+	if p.heartbeatTimeout != nil {
+		opts = append(opts, WithEngineHeartbeat(p.heartbeatInterval, *p.heartbeatTimeout))
+	}
+	if p.measureGlyphs {
+		opts = append(opts, WithEngineMeasuredGlyphWidths())
+	}
+	for _, hook := range p.onClientError {
+		opts = append(opts, WithEngineOnClientError(hook))
+	}
+	return opts
+}
+
 // Wait blocks until the program is stopped
 func (p *Program) Wait() {
 	p.wg.Wait()
@@ -159,14 +522,83 @@ func (p *Program) handleIndex(w http.ResponseWriter, r *http.Request) {
 
 // handleWebSocket upgrades HTTP connections to WebSocket
 func (p *Program) handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := p.upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		fmt.Printf("WebSocket upgrade failed: %v\n", err)
-		return
+	p.webSocketHandler(p.rootComponentFactory)(w, r)
+}
+
+// webSocketHandler returns a handler serving sessions of factory's
+// components over WebSockets. Shared sessions of any component are
+// observed and joined the same way.
+func (p *Program) webSocketHandler(factory func() Component) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Has("observe") || query.Has("join") {
+			p.handleSharedSession(w, r)
+			return
+		}
+		p.acceptSessions(&WebSocketTransport{Upgrader: &p.upgrader}, factory)(w, r)
 	}
-	
-	// Create new session
-	session := p.sessionManager.CreateSession(conn, p.rootComponentFactory())
+}
+
+// handleTransport returns a handler that starts a session for each
+// connection the transport accepts from an allowed origin
+func (p *Program) handleTransport(transport Transport) http.HandlerFunc {
+	accept := p.acceptSessions(transport, p.rootComponentFactory)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !p.checkOrigin(r) {
+			http.Error(w, "Origin not allowed", http.StatusForbidden)
+			return
+		}
+		accept(w, r)
+	}
+}
+
+// acceptSessions returns a handler that starts a session of factory's
+// component for each connection the transport accepts
+func (p *Program) acceptSessions(transport Transport, factory func() Component) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roles, ok := p.authenticate(w, r)
+		if !ok {
+			return
+		}
+		
+		// A returning client picks up its session where it left off, if it
+		// holds the session's roles. A token still in use by a connected
+		// client isn't shared.
+		token := r.URL.Query().Get("resume")
+		var session *Session
+		if token != "" {
+			session = p.sessionManager.GetResumableSession(token)
+		}
+		if session != nil && p.auth != nil && !roles.Covers(session.Roles()) {
+			http.Error(w, "Insufficient permissions to resume", http.StatusForbidden)
+			return
+		}
+		
+		conn, err := transport.Accept(w, r)
+		if err != nil {
+			fmt.Printf("Connection to %s failed: %v\n", transport.Path(), err)
+			return
+		}
+		if session != nil {
+			if session.Resume(conn) {
+				return
+			}
+			token = ""
+		}
+		p.startSession(conn, factory(), token, roles)
+	}
+}
+
+// startSession creates a session of component for conn and runs it until
+// it ends. The client can reconnect to it with resumeToken, if set, and its
+// component is told roles if the program has an auth hook.
+func (p *Program) startSession(conn Conn, component Component, resumeToken string, roles RoleSet) {
+	opts := p.engineOptions()
+	if p.auth != nil {
+		opts = append(opts, WithEngineRoles(roles))
+	}
+	session := p.sessionManager.CreateTransportSession(conn, component, opts...)
+	session.allowResume(resumeToken)
 	
 	// Start session
 	p.wg.Add(1)
@@ -174,6 +606,56 @@ func (p *Program) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		defer p.wg.Done()
 		session.Run(p.ctx)
 		p.sessionManager.RemoveSession(session.ID())
+		
+		code, quit := session.ExitCode()
+		for _, hook := range p.onSessionEnd {
+			hook(SessionEnd{ID: session.ID(), Quit: quit, ExitCode: code})
+		}
+	}()
+}
+
+// handleSharedSession connects an observer or collaborator to a shared
+// session
+func (p *Program) handleSharedSession(w http.ResponseWriter, r *http.Request) {
+	roles, ok := p.authenticate(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	join := query.Get("join")
+	var session *Session
+	if join != "" {
+		session = p.sessionManager.GetInvitedSession(join)
+	} else if token := query.Get("observe"); token != "" {
+		session = p.sessionManager.GetSharedSession(token)
+	}
+	if session == nil {
+		http.Error(w, "Shared session not found", http.StatusNotFound)
+		return
+	}
+	// Collaborators' keys run with the owner's roles
+	if join != "" && p.auth != nil && !roles.Covers(session.Roles()) {
+		http.Error(w, "Insufficient permissions to join", http.StatusForbidden)
+		return
+	}
+	
+	// Shared sessions are streamed as JSON
+	upgrader := p.upgrader
+	upgrader.Subprotocols = nil
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		fmt.Printf("WebSocket upgrade failed: %v\n", err)
+		return
+	}
+	
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if join != "" {
+			session.Collaborate(p.ctx, conn, query.Get("name"))
+		} else {
+			session.Observe(p.ctx, conn)
+		}
 	}()
 }
 
@@ -195,6 +195,58 @@ func TestWebSocketConnection(t *testing.T) {
 	}
 }
 
+func TestEndpoints(t *testing.T) {
+	program := NewProgram(func() Component { return &mockProgramComponent{} },
+		WithEndpoint("/ws/other", func() Component { return &inlineComponent{} }))
+	defer program.Stop()
+	mux, err := program.routes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	for path, view := range map[string]string{"/ws": "initialized", "/ws/other": "one"} {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+path, nil)
+		if err != nil {
+			t.Fatalf("Failed to connect to %s: %v", path, err)
+		}
+		defer conn.Close()
+		readUntil(t, conn, view)
+	}
+	if program.sessionManager.Count() != 2 {
+		t.Errorf("Expected a session per connection, got %d", program.sessionManager.Count())
+	}
+}
+
+func TestEndpointPaths(t *testing.T) {
+	factory := func() Component { return &inlineComponent{} }
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "Refuses the program's own endpoint", paths: []string{"/ws"}},
+		{name: "Refuses the page", paths: []string{"/"}},
+		{name: "Refuses a path served twice", paths: []string{"/ws/clock", "/ws/clock"}},
+		{name: "Refuses a path without a leading slash", paths: []string{"ws/clock"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []ProgramOption
+			for _, path := range tt.paths {
+				opts = append(opts, WithEndpoint(path, factory))
+			}
+			program := NewProgram(factory, opts...)
+			defer program.Stop()
+			if _, err := program.routes(); err == nil {
+				t.Errorf("Expected an error for %v", tt.paths)
+			}
+		})
+	}
+}
+
 func TestSessionManager(t *testing.T) {
 	sm := NewSessionManager()
 	
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// progressInterval is the minimum time between delivered progress reports.
+// Faster reports are coalesced so tight loops don't flood the update loop.
+const progressInterval = 50 * time.Millisecond
+
+// ProgressMsg reports the progress of a long-running command
+type ProgressMsg struct {
+	ID      string // Identifies the operation when several run at once
+	Current int64  // Units of work completed
+	Total   int64  // Total units of work, or 0 if unknown
+	Label   string // Optional description of the current step
+}
+
+// Percent returns the completion percentage (0-100), or 0 if Total is unknown
+func (p ProgressMsg) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	pct := float64(p.Current) / float64(p.Total) * 100
+	if pct > 100 {
+		return 100
+	}
+	if pct < 0 {
+		return 0
+	}
+	return pct
+}
+
+// Done reports whether all work has completed
+func (p ProgressMsg) Done() bool {
+	return p.Total > 0 && p.Current >= p.Total
+}
+
+// WithProgress creates a command whose body can report progress while it
+// runs. Reports are delivered as ProgressMsg (at most one every 50ms, plus the
+// final one) followed by the message fn returns.
+func WithProgress(fn func(report func(ProgressMsg)) Msg) Cmd {
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		r := &progressReporter{send: send}
+		msg := fn(r.report)
+		r.flush()
+		return msg
+	})
+}
+
+// progressReporter coalesces progress reports to a bounded rate
+type progressReporter struct {
+	mu      sync.Mutex
+	send    func(Msg)
+	last    time.Time
+	pending *ProgressMsg
+}
+
+// report delivers p now if enough time has passed, otherwise keeps it as the
+// pending report
+func (r *progressReporter) report(p ProgressMsg) {
+	r.mu.Lock()
+	now := time.Now()
+	if !p.Done() && now.Sub(r.last) < progressInterval {
+		r.pending = &p
+		r.mu.Unlock()
+		return
+	}
+	r.last = now
+	r.pending = nil
+	r.mu.Unlock()
+
+	r.send(p)
+}
+
+// flush delivers the last coalesced report, if any
+func (r *progressReporter) flush() {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	if pending != nil {
+		r.send(*pending)
+	}
+}
+
+// ProgressReader wraps an io.Reader and reports progress as data is read.
+// Use it inside WithProgress to track downloads or file processing.
+type ProgressReader struct {
+	r      io.Reader
+	id     string
+	total  int64
+	read   int64
+	report func(ProgressMsg)
+}
+
+// NewProgressReader creates a reader that reports progress towards total bytes
+func NewProgressReader(r io.Reader, id string, total int64, report func(ProgressMsg)) *ProgressReader {
+	return &ProgressReader{
+		r:      r,
+		id:     id,
+		total:  total,
+		report: report,
+	}
+}
+
+// Read implements io.Reader
+func (p *ProgressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		if p.report != nil {
+			p.report(ProgressMsg{ID: p.id, Current: p.read, Total: p.total})
+		}
+	}
+	return n, err
+}
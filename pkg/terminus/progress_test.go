@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressMsg(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     ProgressMsg
+		percent float64
+		done    bool
+	}{
+		{"Unknown total", ProgressMsg{Current: 10}, 0, false},
+		{"Half way", ProgressMsg{Current: 50, Total: 100}, 50, false},
+		{"Complete", ProgressMsg{Current: 100, Total: 100}, 100, true},
+		{"Overshoot", ProgressMsg{Current: 150, Total: 100}, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.Percent(); got != tt.percent {
+				t.Errorf("Percent() = %v, expected %v", got, tt.percent)
+			}
+			if got := tt.msg.Done(); got != tt.done {
+				t.Errorf("Done() = %v, expected %v", got, tt.done)
+			}
+		})
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	t.Run("Reports are coalesced", func(t *testing.T) {
+		cmd := WithProgress(func(report func(ProgressMsg)) Msg {
+			for i := int64(1); i <= 1000; i++ {
+				report(ProgressMsg{ID: "job", Current: i, Total: 1000})
+			}
+			return testMsg{value: "finished"}
+		})
+
+		final, sent := runStream(t, cmd)
+
+		if final != (testMsg{value: "finished"}) {
+			t.Errorf("Expected final message, got %v", final)
+		}
+		if len(sent) == 0 || len(sent) > 10 {
+			t.Fatalf("Expected a handful of coalesced reports, got %d", len(sent))
+		}
+		last, ok := sent[len(sent)-1].(ProgressMsg)
+		if !ok || !last.Done() {
+			t.Errorf("Expected last report to be complete, got %v", sent[len(sent)-1])
+		}
+	})
+
+	t.Run("Pending report is flushed", func(t *testing.T) {
+		cmd := WithProgress(func(report func(ProgressMsg)) Msg {
+			report(ProgressMsg{Current: 1, Total: 10})
+			report(ProgressMsg{Current: 2, Total: 10})
+			return nil
+		})
+
+		_, sent := runStream(t, cmd)
+
+		if len(sent) != 2 {
+			t.Fatalf("Expected first and flushed reports, got %d", len(sent))
+		}
+		if sent[1].(ProgressMsg).Current != 2 {
+			t.Errorf("Expected flushed report to be the latest, got %v", sent[1])
+		}
+	})
+
+	t.Run("Slow reports are all delivered", func(t *testing.T) {
+		cmd := WithProgress(func(report func(ProgressMsg)) Msg {
+			for i := int64(1); i <= 3; i++ {
+				report(ProgressMsg{Current: i, Total: 10})
+				time.Sleep(progressInterval + 5*time.Millisecond)
+			}
+			return nil
+		})
+
+		if _, sent := runStream(t, cmd); len(sent) != 3 {
+			t.Errorf("Expected 3 reports, got %d", len(sent))
+		}
+	})
+}
+
+func TestProgressReader(t *testing.T) {
+	var reports []ProgressMsg
+	data := strings.Repeat("x", 100)
+	r := NewProgressReader(strings.NewReader(data), "download", int64(len(data)), func(p ProgressMsg) {
+		reports = append(reports, p)
+	})
+
+	buf := make([]byte, 30)
+	total := 0
+	for {
+		n, err := r.Read(buf)
+		total += n
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+	}
+
+	if total != 100 {
+		t.Errorf("Expected to read 100 bytes, got %d", total)
+	}
+	if len(reports) != 4 {
+		t.Fatalf("Expected 4 reports, got %d", len(reports))
+	}
+	last := reports[len(reports)-1]
+	if last.ID != "download" || !last.Done() {
+		t.Errorf("Unexpected final report: %+v", last)
+	}
+}
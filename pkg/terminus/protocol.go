@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "encoding/binary"
+
+// BinaryProtocol is the WebSocket subprotocol a client offers to receive
+// frames in the compact binary encoding. The server selects it when the
+// program is created with WithBinaryProtocol; otherwise the client falls
+// back to JSON.
+const BinaryProtocol = "terminus.binary.v1"
+
+// Binary frame operations. A binary message holds one frame: a sequence of
+// operations, each an opcode byte followed by its fields. Integers are
+// unsigned varints and strings are a varint byte length followed by UTF-8.
+const (
+	binaryClear      byte = 1 // No fields
+	binaryUpdateLine byte = 2 // y, content
+	binarySetCell    byte = 3 // x, y, rune, style
+	binaryRender     byte = 4 // line count, then each line
+)
+
+// encodeBinaryFrame encodes the diff operations of a frame as a single
+// binary message. A full redraw is encoded as every line of the screen.
+func encodeBinaryFrame(ops []DiffOp, height int) []byte {
+	var buf []byte
+	if len(ops) > 0 && ops[0].Type == DiffOpClear {
+		lines := make([]string, height)
+		for _, op := range ops {
+			if line, ok := op.Data.(UpdateLineOp); ok && line.Y >= 0 && line.Y < height {
+				lines[line.Y] = line.Content
+			}
+		}
+		buf = append(buf, binaryRender)
+		buf = binary.AppendUvarint(buf, uint64(len(lines)))
+		for _, line := range lines {
+			buf = appendString(buf, line)
+		}
+		return buf
+	}
+
+	for _, op := range ops {
+		switch op.Type {
+		case DiffOpClear:
+			buf = append(buf, binaryClear)
+		case DiffOpUpdateLine:
+			line := op.Data.(UpdateLineOp)
+			buf = append(buf, binaryUpdateLine)
+			buf = binary.AppendUvarint(buf, uint64(line.Y))
+			buf = appendString(buf, line.Content)
+		case DiffOpSetCell:
+			cell := op.Data.(SetCellOp)
+			buf = append(buf, binarySetCell)
+			buf = binary.AppendUvarint(buf, uint64(cell.X))
+			buf = binary.AppendUvarint(buf, uint64(cell.Y))
+			buf = appendString(buf, cell.Rune)
+			buf = appendString(buf, cell.Style)
+		}
+	}
+	return buf
+}
+
+// appendString appends s with its length
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
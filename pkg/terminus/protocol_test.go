@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errTruncatedFrame is returned for a binary frame that ends mid-operation
+var errTruncatedFrame = errors.New("truncated binary frame")
+
+// decodeBinaryFrame decodes a binary frame into the messages it stands for,
+// as the client does. Lines are decoded as []interface{}, as from JSON, for
+// screenText.
+func decodeBinaryFrame(data []byte) ([]ServerMessage, error) {
+	r := binaryReader{data: data}
+	var msgs []ServerMessage
+	for len(r.data) > 0 && r.err == nil {
+		op := r.data[0]
+		r.data = r.data[1:]
+		switch op {
+		case binaryClear:
+			msgs = append(msgs, ServerMessage{Type: "clear", Data: map[string]interface{}{}})
+		case binaryUpdateLine:
+			y := r.uint()
+			msgs = append(msgs, ServerMessage{Type: "updateLine", Data: map[string]interface{}{
+				"y": y, "content": r.string(),
+			}})
+		case binarySetCell:
+			x, y := r.uint(), r.uint()
+			msgs = append(msgs, ServerMessage{Type: "setCell", Data: map[string]interface{}{
+				"x": x, "y": y, "rune": r.string(), "style": r.string(),
+			}})
+		case binaryRender:
+			lines := make([]interface{}, r.uint())
+			for i := range lines {
+				lines[i] = r.string()
+			}
+			msgs = append(msgs, ServerMessage{Type: "render", Data: map[string]interface{}{"lines": lines}})
+		default:
+			return msgs, fmt.Errorf("unknown binary opcode %d", op)
+		}
+	}
+	return msgs, r.err
+}
+
+// binaryReader reads the fields of a binary frame, recording the first error
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+// uint reads a varint
+func (r *binaryReader) uint() int {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return int(v)
+}
+
+// string reads a string with its length
+func (r *binaryReader) string() string {
+	n := r.uint()
+	if n > len(r.data) {
+		r.fail()
+		return ""
+	}
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
+
+// fail records a truncated frame and stops reading
+func (r *binaryReader) fail() {
+	if r.err == nil {
+		r.err = errTruncatedFrame
+	}
+	r.data = nil
+}
+
+func TestBinaryFrames(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []DiffOp
+		want string
+	}{
+		{
+			name: "Line updates",
+			ops: []DiffOp{
+				{Type: DiffOpUpdateLine, Data: UpdateLineOp{Y: 3, Content: "\x1b[1mhéllo\x1b[0m"}},
+				{Type: DiffOpUpdateLine, Data: UpdateLineOp{Y: 300, Content: ""}},
+			},
+			want: `[{"type":"updateLine","data":{"content":"\u001b[1mhéllo\u001b[0m","y":3}},{"type":"updateLine","data":{"content":"","y":300}}]`,
+		},
+		{
+			name: "Cells",
+			ops: []DiffOp{
+				{Type: DiffOpSetCell, Data: SetCellOp{X: 1, Y: 2, Rune: "█", Style: "color:red"}},
+			},
+			want: `[{"type":"setCell","data":{"rune":"█","style":"color:red","x":1,"y":2}}]`,
+		},
+		{
+			name: "A full redraw is every line",
+			ops:  NewScreenDiffer(10, 3).Update("one\n\nthree"),
+			want: `[{"type":"render","data":{"lines":["one","","three"]}}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, err := decodeBinaryFrame(encodeBinaryFrame(tt.ops, 3))
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+			got, _ := json.Marshal(msgs)
+			if string(got) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Smaller than JSON", func(t *testing.T) {
+		ops := []DiffOp{{Type: DiffOpUpdateLine, Data: UpdateLineOp{Y: 5, Content: "cpu 42%"}}}
+		data, _ := json.Marshal(ServerMessage{Type: "updateLine", Data: map[string]interface{}{"y": 5, "content": "cpu 42%"}})
+		if size := len(encodeBinaryFrame(ops, 24)); size*3 > len(data) {
+			t.Errorf("Expected under a third of %d bytes, got %d", len(data), size)
+		}
+	})
+
+	t.Run("Reports truncated frames", func(t *testing.T) {
+		data := encodeBinaryFrame([]DiffOp{{Type: DiffOpUpdateLine, Data: UpdateLineOp{Y: 1, Content: "hello"}}}, 3)
+		if _, err := decodeBinaryFrame(data[:len(data)-2]); !errors.Is(err, errTruncatedFrame) {
+			t.Errorf("Expected a truncated frame error, got %v", err)
+		}
+	})
+}
+
+func TestBinaryProtocolNegotiation(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverOpts []ProgramOption
+		offer      []string
+		wantType   int
+	}{
+		{"Binary when both sides opt in", []ProgramOption{WithBinaryProtocol()}, []string{BinaryProtocol}, websocket.BinaryMessage},
+		{"JSON for clients that don't offer it", []ProgramOption{WithBinaryProtocol()}, nil, websocket.TextMessage},
+		{"JSON unless the program enables it", nil, []string{BinaryProtocol}, websocket.TextMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := NewProgram(func() Component { return &testComponent{} }, tt.serverOpts...)
+			server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+			defer server.Close()
+
+			dialer := websocket.Dialer{Subprotocols: tt.offer}
+			conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("Failed to read the first frame: %v", err)
+			}
+			if messageType != tt.wantType {
+				t.Fatalf("Expected message type %d, got %d", tt.wantType, messageType)
+			}
+			if messageType == websocket.BinaryMessage {
+				msgs, err := decodeBinaryFrame(data)
+				if err != nil || len(msgs) != 1 || !strings.HasPrefix(screenText(msgs[0]), "initialized") {
+					t.Errorf("Expected the first screen, got %+v (%v)", msgs, err)
+				}
+			}
+		})
+	}
+}
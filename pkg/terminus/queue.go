@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// DefaultMaxPendingFrames is how many rendered frames may wait to be sent
+// to a slow client before they are dropped in favor of the latest one
+const DefaultMaxPendingFrames = 4
+
+// WithEngineMaxPendingFrames sets how many rendered frames may wait to be
+// sent to a client. When a frame is rendered while that many are waiting,
+// the client is falling behind: the waiting frames are dropped and it is
+// sent a full redraw of the new one instead. Values below 1 use
+// DefaultMaxPendingFrames.
+func WithEngineMaxPendingFrames(n int) EngineOption {
+	return func(e *Engine) {
+		e.maxPendingFrames = n
+	}
+}
+
+// isPriority reports whether msg skips ahead of queued messages. Quitting
+// and resizing change what every later message means, so they aren't kept
+// waiting behind command results.
+func isPriority(msg Msg) bool {
+	switch msg.(type) {
+	case QuitMsg, confirmedQuitMsg, WindowSizeMsg:
+		return true
+	}
+	return false
+}
+
+// sendQueue holds a session's rendered frames until the writer sends them.
+// Each frame is the list of messages that draws it. Input never waits on
+// the queue: a render adds to it without blocking, and when the client
+// reads too slowly for the frames to drain, the ones still waiting are
+// replaced by a single full redraw of the latest. Control messages, such as
+// requests to the client, are sent ahead of frames and never dropped.
+type sendQueue struct {
+	mu      sync.Mutex
+	frames  [][][]byte
+	control [][]byte
+	max     int
+	dropped int
+	closed  bool
+	ready   chan struct{} // Signalled when there is something to send
+}
+
+// newSendQueue creates a queue holding up to max frames
+func newSendQueue(max int) *sendQueue {
+	if max < 1 {
+		max = DefaultMaxPendingFrames
+	}
+	return &sendQueue{
+		max:   max,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// push queues a frame. If the queue is full, the waiting frames are
+// dropped and redraw is called for a message that draws the whole of the
+// new frame, which is queued in their place.
+func (q *sendQueue) push(frame [][]byte, redraw func() []byte) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || len(frame) == 0 {
+		return
+	}
+	if len(q.frames) >= q.max {
+		q.dropped += len(q.frames)
+		q.frames = q.frames[:0]
+		frame = [][]byte{redraw()}
+	}
+	q.frames = append(q.frames, frame)
+	q.signal()
+}
+
+// pushControl queues a control message
+func (q *sendQueue) pushControl(data []byte) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.control = append(q.control, data)
+	q.signal()
+}
+
+// popControl removes the next control message to send, reporting false if
+// there is none
+func (q *sendQueue) popControl() ([]byte, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.control) == 0 {
+		return nil, false
+	}
+	data := q.control[0]
+	q.control = q.control[1:]
+	return data, true
+}
+
+// pop removes the next message of a frame to send, reporting false if there
+// is none
+func (q *sendQueue) pop() ([]byte, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.frames) == 0 {
+		return nil, false
+	}
+	data := q.frames[0][0]
+	if q.frames[0] = q.frames[0][1:]; len(q.frames[0]) == 0 {
+		q.frames = q.frames[1:]
+	}
+	return data, true
+}
+
+// reset drops the frames waiting to be sent. Control messages are kept.
+func (q *sendQueue) reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.frames = nil
+}
+
+// pending returns the number of frames waiting to be sent, including one
+// partly sent
+func (q *sendQueue) pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.frames)
+}
+
+// droppedFrames returns the number of frames dropped for a slow client
+func (q *sendQueue) droppedFrames() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dropped
+}
+
+// close stops the queue accepting frames. Frames already queued can still
+// be popped.
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.signal()
+}
+
+// isClosed reports whether close has been called
+func (q *sendQueue) isClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// signal wakes the writer. The caller holds q.mu.
+func (q *sendQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// redrawMessage encodes a full redraw, as produced by a ScreenDiffer without
+// a previous screen, as a single render message with every line
+func redrawMessage(ops []DiffOp, height int) ([]byte, error) {
+	lines := make([]string, height)
+	for _, op := range ops {
+		if line, ok := op.Data.(UpdateLineOp); ok && line.Y >= 0 && line.Y < height {
+			lines[line.Y] = line.Content
+		}
+	}
+	return json.Marshal(ServerMessage{
+		Type: "render",
+		Data: map[string]interface{}{"lines": lines},
+	})
+}
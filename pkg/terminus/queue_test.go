@@ -0,0 +1,278 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// drain pops every message waiting in q
+func drain(q *sendQueue) []string {
+	var sent []string
+	for {
+		data, ok := q.pop()
+		if !ok {
+			return sent
+		}
+		sent = append(sent, string(data))
+	}
+}
+
+// frame returns a frame of the given messages
+func frame(messages ...string) [][]byte {
+	f := make([][]byte, len(messages))
+	for i, m := range messages {
+		f[i] = []byte(m)
+	}
+	return f
+}
+
+// orderComponent records the messages it receives
+type orderComponent struct {
+	mu       sync.Mutex
+	received []string
+}
+
+func (c *orderComponent) Init() Cmd { return nil }
+
+func (c *orderComponent) Update(msg Msg) (Component, Cmd) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch msg := msg.(type) {
+	case testMsg:
+		c.received = append(c.received, msg.value)
+	case WindowSizeMsg:
+		c.received = append(c.received, fmt.Sprintf("%dx%d", msg.Width, msg.Height))
+	}
+	return c, nil
+}
+
+func (c *orderComponent) View() string { return "" }
+
+func (c *orderComponent) order() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return strings.Join(c.received, ",")
+}
+
+func TestSendQueue(t *testing.T) {
+	redraw := func() []byte { return []byte("redraw") }
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Sends frames in order",
+			test: func(t *testing.T) {
+				q := newSendQueue(4)
+				q.push(frame("a1", "a2"), redraw)
+				q.push(frame("b1"), redraw)
+				if q.pending() != 2 {
+					t.Errorf("Expected 2 pending frames, got %d", q.pending())
+				}
+				if got := strings.Join(drain(q), ","); got != "a1,a2,b1" {
+					t.Errorf("Expected every message in order, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Replaces waiting frames with a redraw when full",
+			test: func(t *testing.T) {
+				q := newSendQueue(2)
+				q.push(frame("a"), redraw)
+				q.push(frame("b1", "b2"), redraw)
+				q.push(frame("c"), redraw)
+				q.push(frame("d"), redraw)
+				if got := strings.Join(drain(q), ","); got != "redraw,d" {
+					t.Errorf("Expected a redraw then the next frame, got %s", got)
+				}
+				if q.droppedFrames() != 2 {
+					t.Errorf("Expected 2 dropped frames, got %d", q.droppedFrames())
+				}
+			},
+		},
+		{
+			name: "A partly sent frame is dropped too",
+			test: func(t *testing.T) {
+				q := newSendQueue(1)
+				q.push(frame("a1", "a2"), redraw)
+				q.pop()
+				q.push(frame("b"), redraw)
+				if got := strings.Join(drain(q), ","); got != "redraw" {
+					t.Errorf("Expected only the redraw, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Never drops control messages",
+			test: func(t *testing.T) {
+				q := newSendQueue(1)
+				q.push(frame("a"), redraw)
+				q.pushControl([]byte("request"))
+				q.push(frame("b"), redraw)
+				if data, ok := q.popControl(); !ok || string(data) != "request" {
+					t.Errorf("Expected the control message, got %q", data)
+				}
+				if _, ok := q.popControl(); ok {
+					t.Error("Expected a single control message")
+				}
+				if got := strings.Join(drain(q), ","); got != "redraw" {
+					t.Errorf("Expected only the redraw, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Ignores frames once closed",
+			test: func(t *testing.T) {
+				q := newSendQueue(0)
+				q.push(frame("a"), redraw)
+				q.close()
+				q.push(frame("b"), redraw)
+				if got := strings.Join(drain(q), ","); got != "a" || !q.isClosed() {
+					t.Errorf("Expected the frame queued before closing, got %s", got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// hookConn is a pipeConn that calls onWrite before writing each message
+type hookConn struct {
+	*pipeConn
+	onWrite func(data []byte)
+}
+
+func (c *hookConn) WriteMessage(data []byte, binary bool) error {
+	c.onWrite(data)
+	return c.pipeConn.WriteMessage(data, binary)
+}
+
+func TestWritePumpSendsEverythingQueuedBeforeClosing(t *testing.T) {
+	s := NewTransportSession("pump", nil, &testComponent{})
+	// The exit message is queued after the control messages were sent and
+	// while the frame is, then the queue closes
+	conn := &hookConn{pipeConn: newPipeConn(), onWrite: func(data []byte) {
+		if string(data) == "frame" {
+			s.outgoing.pushControl([]byte("exit"))
+			s.outgoing.close()
+		}
+	}}
+	s.outgoing.push(frame("frame"), nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.writePump(context.Background(), newConnection(conn))
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the writer to stop once the queue closed")
+	}
+
+	var sent []string
+	for len(conn.toClient) > 0 {
+		sent = append(sent, string(<-conn.toClient))
+	}
+	if got := strings.Join(sent, ","); got != "frame,exit" {
+		t.Errorf("Expected the frame then the exit message, got %s", got)
+	}
+}
+
+func TestRedrawWhileSessionIsContended(t *testing.T) {
+	s := NewSession("contended", nil, &testComponent{}, WithEngineMaxPendingFrames(1))
+
+	// Writers waiting on s.mu block new readers, so a redraw that took the
+	// lock again while the frame was queued would never finish
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+	writers.Add(1)
+	go func() {
+		defer writers.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				s.mu.Lock()
+				s.mu.Unlock()
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			s.handleRender(fmt.Sprintf("frame %d", i))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected frames to be queued while the session was contended")
+	}
+	close(stop)
+	writers.Wait()
+	s.Close()
+	if s.outgoing.droppedFrames() == 0 {
+		t.Error("Expected frames to be replaced by a redraw")
+	}
+}
+
+func TestRedrawMessage(t *testing.T) {
+	ops := NewScreenDiffer(10, 3).Update("one\n\nthree")
+	data, err := redrawMessage(ops, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg ServerMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "render" {
+		t.Errorf("Expected a render message, got %q", msg.Type)
+	}
+	if lines := screenText(msg); !strings.HasPrefix(lines, "one") || strings.Count(lines, "\n") != 2 {
+		t.Errorf("Expected every line, got %q", lines)
+	}
+}
+
+func TestPriorityMessages(t *testing.T) {
+	comp := &orderComponent{}
+	engine := NewEngine(comp)
+	engine.SendMessage(testMsg{value: "a"})
+	engine.SendMessage(testMsg{value: "b"})
+	engine.SendMessage(WindowSizeMsg{Width: 80, Height: 24})
+
+	engine.Start()
+	defer engine.Stop()
+	deadline := time.Now().Add(time.Second)
+	for comp.order() != "80x24,a,b" && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := comp.order(); got != "80x24,a,b" {
+		t.Errorf("Expected the resize first, got %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+// QuitGuard is implemented by components that can have unsaved state. While
+// InterceptQuit returns true, Quit doesn't end the session: the component
+// receives a QuitRequestedMsg instead, e.g. to ask "Save before exiting?",
+// and the browser asks the user to confirm before closing or reloading the
+// tab.
+type QuitGuard interface {
+	InterceptQuit() bool
+}
+
+// QuitRequestedMsg is sent to a QuitGuard component in place of quitting
+type QuitRequestedMsg struct {
+	Quit QuitMsg // The quit that was requested
+}
+
+// Confirm returns a command that quits as requested, whatever the component
+// reports from InterceptQuit
+func (m QuitRequestedMsg) Confirm() Cmd {
+	return func() Msg {
+		return confirmedQuitMsg(m.Quit)
+	}
+}
+
+// confirmedQuitMsg is a quit that is not intercepted
+type confirmedQuitMsg QuitMsg
+
+// interceptsQuit reports whether the component wants to intercept quitting
+func (e *Engine) interceptsQuit() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	guard, ok := e.component.(QuitGuard)
+	return ok && guard.InterceptQuit()
+}
+
+// guardQuit turns a quit into a QuitRequestedMsg when the component
+// intercepts it, and a confirmed quit back into a QuitMsg
+func (e *Engine) guardQuit(msg Msg) Msg {
+	switch msg := msg.(type) {
+	case confirmedQuitMsg:
+		return QuitMsg(msg)
+	case QuitMsg:
+		if e.interceptsQuit() {
+			return QuitRequestedMsg{Quit: msg}
+		}
+	}
+	return msg
+}
+
+// syncQuitGuard tells the client whether to ask before the tab is closed,
+// when that changes. A client that reconnects is told again.
+func (e *Engine) syncQuitGuard(msg Msg) {
+	if e.toClient == nil {
+		return
+	}
+	if state, ok := msg.(ConnectionStateMsg); ok && state.State == ConnectionConnected {
+		e.guarded = false
+	}
+	guarded := e.interceptsQuit()
+	if guarded == e.guarded {
+		return
+	}
+	if e.toClient(ServerMessage{Type: "guard", Data: map[string]interface{}{"enabled": guarded}}) {
+		e.guarded = guarded
+	}
+}
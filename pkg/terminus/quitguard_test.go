@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// guardComponent has unsaved changes after e is pressed, quits on q and
+// confirms quitting on y
+type guardComponent struct {
+	dirty   bool
+	request *QuitRequestedMsg
+}
+
+func (c *guardComponent) Init() Cmd {
+	return nil
+}
+
+func (c *guardComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case QuitRequestedMsg:
+		c.request = &msg
+	case KeyMsg:
+		switch msg.String() {
+		case "e":
+			c.dirty = true
+		case "q":
+			return c, QuitWithCode(2, "bye")
+		case "y":
+			if c.request != nil {
+				return c, c.request.Confirm()
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *guardComponent) View() string {
+	return fmt.Sprintf("dirty: %v, asking: %v", c.dirty, c.request != nil)
+}
+
+func (c *guardComponent) InterceptQuit() bool {
+	return c.dirty
+}
+
+// runeKey returns the KeyMsg of typing r
+func runeKey(r rune) KeyMsg {
+	return KeyMsg{Type: KeyRunes, Runes: []rune{r}}
+}
+
+func TestQuitGuard(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Quits when nothing is unsaved",
+			test: func(t *testing.T) {
+				engine, _ := startEngine(t, &guardComponent{})
+				engine.SendMessage(runeKey('q'))
+
+				select {
+				case <-engine.ctx.Done():
+				case <-time.After(time.Second):
+					t.Fatal("Expected the engine to quit")
+				}
+			},
+		},
+		{
+			name: "Asks the component before quitting with unsaved changes",
+			test: func(t *testing.T) {
+				engine, renders := startEngine(t, &guardComponent{})
+				engine.SendMessage(runeKey('e'))
+				engine.SendMessage(runeKey('q'))
+				waitForView(t, renders, "dirty: true, asking: true")
+				if engine.ctx.Err() != nil {
+					t.Fatal("Expected the engine to keep running")
+				}
+
+				engine.SendMessage(runeKey('y'))
+				select {
+				case <-engine.ctx.Done():
+				case <-time.After(time.Second):
+					t.Fatal("Expected the engine to quit once confirmed")
+				}
+				if engine.quit == nil || engine.quit.Code != 2 {
+					t.Errorf("Expected the requested quit, got %+v", engine.quit)
+				}
+			},
+		},
+		{
+			name: "Asks the browser to confirm closing the tab",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component { return &guardComponent{} })
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+				conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+				if err != nil {
+					t.Fatalf("Failed to connect: %v", err)
+				}
+				defer conn.Close()
+
+				readUntil(t, conn, "dirty: false")
+				conn.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "runes", "runes": []string{"e"}}})
+				msg := readUntil(t, conn, `"type":"guard"`)
+				if msg.Data["enabled"] != true {
+					t.Errorf("Expected the guard to be enabled, got %v", msg.Data)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// waitForView waits for a render of view
+func waitForView(t *testing.T, renders chan string, view string) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case got := <-renders:
+			if got == view {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Never rendered %q", view)
+		}
+	}
+}
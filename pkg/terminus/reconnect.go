@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReconnectWindow is how long a session waits for its client to
+// reconnect after the connection drops
+const DefaultReconnectWindow = 30 * time.Second
+
+// ConnectionState is the state of a session's connection to its client
+type ConnectionState int
+
+const (
+	// ConnectionConnected means the client is connected
+	ConnectionConnected ConnectionState = iota
+	// ConnectionReconnecting means the connection dropped and the client
+	// may still come back
+	ConnectionReconnecting
+	// ConnectionOffline means the client didn't come back in time. The
+	// session ends once the component has handled the message.
+	ConnectionOffline
+)
+
+// String returns the name of the state
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionConnected:
+		return "connected"
+	case ConnectionReconnecting:
+		return "reconnecting"
+	case ConnectionOffline:
+		return "offline"
+	}
+	return "unknown"
+}
+
+// ConnectionStateMsg is sent when the client's connection drops, when it
+// comes back and when the session gives up waiting for it. The view isn't
+// shown while the client is away, but state changes made meanwhile, such as
+// pausing expensive work, take effect at once, and the view is redrawn as
+// soon as the client reconnects.
+type ConnectionStateMsg struct {
+	State ConnectionState
+	// Downtime is how long the client was away, when it reconnects or the
+	// session goes offline
+	Downtime time.Duration
+}
+
+// WithEngineReconnectWindow sets how long a session waits for its client to
+// reconnect after the connection drops. Zero ends the session as soon as
+// the connection drops.
+func WithEngineReconnectWindow(d time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.reconnectWindow = d
+	}
+}
+
+// endSessionMsg ends the session like QuitMsg, but only after the messages
+// queued before it have been handled
+type endSessionMsg struct{}
+
+// connection is one connection of a session's client. A session outlives
+// its connections while the client reconnects.
+type connection struct {
+	conn    Conn
+	binary  bool          // Frames use BinaryProtocol rather than JSON
+	lost    chan struct{} // Closed when the connection is closed
+	flushed chan struct{} // Closed when the writer stops
+	once    sync.Once
+	seen    atomic.Int64 // When the client last sent a message, in Unix nanoseconds
+}
+
+// newConnection wraps a client's connection
+func newConnection(conn Conn) *connection {
+	c := &connection{
+		conn:    conn,
+		binary:  conn.Protocol() == BinaryProtocol,
+		lost:    make(chan struct{}),
+		flushed: make(chan struct{}),
+	}
+	c.touch()
+	return c
+}
+
+// close closes the connection
+func (c *connection) close() {
+	c.once.Do(func() {
+		close(c.lost)
+		c.conn.Close()
+	})
+}
+
+// minResumeTokenLength is the shortest resume token a client may choose
+const minResumeTokenLength = 16
+
+// ResumeToken returns the token a client presents to reconnect to the
+// session, or "" if the session can't be resumed
+func (s *Session) ResumeToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resumeToken
+}
+
+// allowResume lets the client reconnect to the session with token, which
+// it chose at random when it first connected. Short tokens are ignored, as
+// is every token when the reconnect window is zero.
+func (s *Session) allowResume(token string) {
+	if len(token) < minResumeTokenLength || s.engine.reconnectWindow <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resumeToken = token
+}
+
+// attach starts reading from and writing to a connection
+func (s *Session) attach(c *connection) {
+	s.pumps.Add(2)
+	go func() {
+		defer s.pumps.Done()
+		s.readPump(c)
+	}()
+	go func() {
+		defer s.pumps.Done()
+		defer close(c.flushed)
+		s.writePump(s.runCtx, c)
+	}()
+}
+
+// connectionLost is called when a connection closes. The session waits for
+// the client to reconnect, or ends if it can't be resumed.
+func (s *Session) connectionLost(c *connection) {
+	c.close()
+	s.mu.Lock()
+	if s.closed || s.current != c {
+		s.mu.Unlock()
+		return
+	}
+	s.current = nil
+	if s.resumeToken == "" {
+		s.mu.Unlock()
+		s.Close()
+		return
+	}
+	s.lostAt = time.Now()
+	s.offline = time.AfterFunc(s.engine.reconnectWindow, s.goOffline)
+	s.mu.Unlock()
+
+	s.engine.SendMessage(ConnectionStateMsg{State: ConnectionReconnecting})
+}
+
+// goOffline gives up waiting for the client and ends the session once the
+// component has been told
+func (s *Session) goOffline() {
+	s.mu.Lock()
+	if s.closed || s.current != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.resumeToken = ""
+	downtime := time.Since(s.lostAt)
+	s.mu.Unlock()
+
+	s.engine.SendMessage(ConnectionStateMsg{State: ConnectionOffline, Downtime: downtime})
+	s.engine.SendMessage(endSessionMsg{})
+}
+
+// Resume reconnects the session's client over conn, reporting false if the
+// session is closed or its client is still connected. The client is sent
+// the whole screen and the component a ConnectionStateMsg.
+func (s *Session) Resume(conn Conn) bool {
+	c := newConnection(conn)
+	s.mu.Lock()
+	if s.closed || s.current != nil || s.resumeToken == "" || s.runCtx == nil {
+		s.mu.Unlock()
+		return false
+	}
+	s.offline.Stop()
+	s.current = c
+	downtime := time.Since(s.lostAt)
+	view, surfaces := s.lastView, s.lastSurfaces
+	width, height := s.width, s.screenHeight(s.lastView, s.height)
+	profile := s.colorProfile
+	s.mu.Unlock()
+
+	// Frames queued while the client was away were drawn for the old
+	// connection; it starts again from the whole screen
+	s.outgoing.reset()
+	s.outgoing.push([][]byte{s.redraw(view, surfaces, width, height, profile, c.binary)}, func() []byte {
+		return s.redraw(view, surfaces, width, height, profile, c.binary)
+	})
+	s.attach(c)
+
+	s.engine.SendMessage(ConnectionStateMsg{State: ConnectionConnected, Downtime: downtime})
+	return true
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const testResumeToken = "0123456789abcdef0123456789abcdef"
+
+// connectionComponent shows the keys it has received and reports
+// connection state changes
+type connectionComponent struct {
+	keys   string
+	state  string
+	states chan ConnectionStateMsg
+}
+
+func (c *connectionComponent) Init() Cmd {
+	return nil
+}
+
+func (c *connectionComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case KeyMsg:
+		c.keys += msg.String()
+	case ConnectionStateMsg:
+		c.state = msg.State.String()
+		c.states <- msg
+	}
+	return c, nil
+}
+
+func (c *connectionComponent) View() string {
+	return "keys:" + c.keys + " " + c.state
+}
+
+// startReconnectServer serves a program whose components report connection
+// states on the returned channel
+func startReconnectServer(t *testing.T, opts ...ProgramOption) (*Program, string, chan ConnectionStateMsg) {
+	t.Helper()
+	states := make(chan ConnectionStateMsg, 10)
+	program := NewProgram(func() Component {
+		return &connectionComponent{states: states}
+	}, opts...)
+	server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+	t.Cleanup(func() {
+		server.Close()
+		program.Stop()
+	})
+	return program, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws", states
+}
+
+// dialResume connects with a resume token and waits for the first screen
+func dialResume(t *testing.T, url, token string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?resume="+token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	readUntil(t, conn, "keys:")
+	return conn
+}
+
+// expectState waits for the component to receive a connection state
+func expectState(t *testing.T, states chan ConnectionStateMsg, want ConnectionState) ConnectionStateMsg {
+	t.Helper()
+	select {
+	case msg := <-states:
+		if msg.State != want {
+			t.Fatalf("Expected state %v, got %v", want, msg.State)
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for state %v", want)
+		return ConnectionStateMsg{}
+	}
+}
+
+// waitForSessions waits until the program has n sessions
+func waitForSessions(t *testing.T, program *Program, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for program.sessionManager.Count() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d sessions, got %d", n, program.sessionManager.Count())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Resumes the session after the connection drops",
+			test: func(t *testing.T) {
+				program, url, states := startReconnectServer(t)
+				conn := dialResume(t, url, testResumeToken)
+				conn.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "runes", "runes": []string{"a"}}})
+				readUntil(t, conn, "keys:a")
+
+				conn.Close()
+				expectState(t, states, ConnectionReconnecting)
+
+				conn = dialResume(t, url, testResumeToken)
+				msg := expectState(t, states, ConnectionConnected)
+				if msg.Downtime <= 0 {
+					t.Errorf("Expected the downtime, got %v", msg.Downtime)
+				}
+				readUntil(t, conn, "keys:a connected")
+				if program.sessionManager.Count() != 1 {
+					t.Errorf("Expected the session to be resumed, got %d sessions", program.sessionManager.Count())
+				}
+			},
+		},
+		{
+			name: "Refuses to resume for users without the session's roles",
+			test: func(t *testing.T) {
+				// Users hold the roles listed in their X-Roles header
+				program, url, states := startReconnectServer(t, WithAuth(func(r *http.Request) (RoleSet, error) {
+					return NewRoleSet(strings.Split(r.Header.Get("X-Roles"), ",")...), nil
+				}))
+				dial := func(roles string) (*websocket.Conn, *http.Response, error) {
+					return websocket.DefaultDialer.Dial(url+"?resume="+testResumeToken, http.Header{"X-Roles": {roles}})
+				}
+				conn, _, err := dial("admin")
+				if err != nil {
+					t.Fatal(err)
+				}
+				readUntil(t, conn, "keys:")
+				conn.Close()
+				expectState(t, states, ConnectionReconnecting)
+
+				if conn, resp, err := dial("viewer"); err == nil {
+					conn.Close()
+					t.Error("Expected the resume to be refused")
+				} else if resp == nil || resp.StatusCode != http.StatusForbidden {
+					t.Errorf("Expected status %d, got %v", http.StatusForbidden, resp)
+				}
+
+				conn, _, err = dial("admin,viewer")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer conn.Close()
+				expectState(t, states, ConnectionConnected)
+				if program.sessionManager.Count() != 1 {
+					t.Errorf("Expected the session to be resumed, got %d sessions", program.sessionManager.Count())
+				}
+			},
+		},
+		{
+			name: "Goes offline when the client doesn't come back",
+			test: func(t *testing.T) {
+				program, url, states := startReconnectServer(t, WithReconnectWindow(50*time.Millisecond))
+				conn := dialResume(t, url, testResumeToken)
+
+				conn.Close()
+				expectState(t, states, ConnectionReconnecting)
+				msg := expectState(t, states, ConnectionOffline)
+				if msg.Downtime < 50*time.Millisecond {
+					t.Errorf("Expected at least the reconnect window, got %v", msg.Downtime)
+				}
+				waitForSessions(t, program, 0)
+
+				// The client gets a new session under the same token
+				dialResume(t, url, testResumeToken)
+				waitForSessions(t, program, 1)
+			},
+		},
+		{
+			name: "Ends the session at once without a reconnect window",
+			test: func(t *testing.T) {
+				program, url, states := startReconnectServer(t, WithReconnectWindow(0))
+				conn := dialResume(t, url, testResumeToken)
+
+				conn.Close()
+				waitForSessions(t, program, 0)
+				select {
+				case msg := <-states:
+					t.Errorf("Expected no state changes, got %v", msg.State)
+				default:
+				}
+			},
+		},
+		{
+			name: "Doesn't resume a connected session",
+			test: func(t *testing.T) {
+				program, url, _ := startReconnectServer(t)
+				dialResume(t, url, testResumeToken)
+				dialResume(t, url, testResumeToken)
+				waitForSessions(t, program, 2)
+			},
+		},
+		{
+			name: "Ignores short tokens",
+			test: func(t *testing.T) {
+				program, url, states := startReconnectServer(t)
+				conn := dialResume(t, url, "short")
+
+				conn.Close()
+				waitForSessions(t, program, 0)
+				select {
+				case msg := <-states:
+					t.Errorf("Expected no state changes, got %v", msg.State)
+				default:
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
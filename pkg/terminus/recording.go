@@ -0,0 +1,252 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cast event types
+const (
+	CastOutput = "o"
+	CastResize = "r"
+)
+
+// clearFrame homes the cursor and clears the screen before each recorded frame
+const clearFrame = "\x1b[H\x1b[2J"
+
+// Cast is a recording in the asciinema v2 format
+type Cast struct {
+	Width     int
+	Height    int
+	Timestamp time.Time
+	Events    []CastEvent
+}
+
+// CastEvent is one event of a cast
+type CastEvent struct {
+	Time time.Duration // Since the start of the recording
+	Type string        // CastOutput or CastResize
+	Data string        // Terminal output, or "WIDTHxHEIGHT" for resizes
+}
+
+// Duration returns the time of the last event
+func (c *Cast) Duration() time.Duration {
+	if len(c.Events) == 0 {
+		return 0
+	}
+	return c.Events[len(c.Events)-1].Time
+}
+
+// FrameAt returns the view on screen at time t of the recording, e.g. to
+// export it with RenderHTML
+func (c *Cast) FrameAt(t time.Duration) string {
+	var screen string
+	for _, event := range c.Events {
+		if event.Time > t {
+			break
+		}
+		if event.Type != CastOutput {
+			continue
+		}
+		// Each frame clears the screen first
+		if i := strings.LastIndex(event.Data, clearFrame); i >= 0 {
+			screen = event.Data[i+len(clearFrame):]
+		} else {
+			screen += event.Data
+		}
+	}
+	return strings.ReplaceAll(screen, "\r\n", "\n")
+}
+
+// castHeader is the first line of a cast file
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// ReadCast parses an asciinema v2 cast
+func ReadCast(r io.Reader) (*Cast, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cast: missing header")
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("cast: invalid header: %w", err)
+	}
+	if header.Version != 2 {
+		return nil, fmt.Errorf("cast: unsupported version %d", header.Version)
+	}
+
+	cast := &Cast{Width: header.Width, Height: header.Height}
+	if header.Timestamp != 0 {
+		cast.Timestamp = time.Unix(header.Timestamp, 0)
+	}
+	for line := 2; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("cast: line %d: %w", line, err)
+		}
+		if len(event) != 3 {
+			return nil, fmt.Errorf("cast: line %d: expected 3 fields, got %d", line, len(event))
+		}
+		seconds, ok1 := event[0].(float64)
+		kind, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf("cast: line %d: malformed event", line)
+		}
+		cast.Events = append(cast.Events, CastEvent{
+			Time: time.Duration(seconds * float64(time.Second)),
+			Type: kind,
+			Data: data,
+		})
+	}
+	return cast, scanner.Err()
+}
+
+// LoadCast reads a cast file
+func LoadCast(path string) (*Cast, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCast(f)
+}
+
+// Recorder writes a session's output as an asciinema v2 cast. Each frame is
+// written as a full redraw, so a recording can be played from any frame.
+type Recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	now    func() time.Time
+	start  time.Time
+	last   string
+	closed bool
+}
+
+// NewRecorder writes the cast header to w and returns a recorder for the
+// frames that follow
+func NewRecorder(w io.Writer, width, height int) (*Recorder, error) {
+	r := &Recorder{w: w, now: time.Now}
+	r.start = r.now()
+	header, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", header); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Frame records a rendered view. Views identical to the previous frame are
+// skipped.
+func (r *Recorder) Frame(view string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || view == r.last {
+		return nil
+	}
+	r.last = view
+	return r.write(CastOutput, clearFrame+strings.ReplaceAll(view, "\n", "\r\n"))
+}
+
+// Resize records a change in the terminal size
+func (r *Recorder) Resize(width, height int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	// The next frame must be redrawn at the new size
+	r.last = ""
+	return r.write(CastResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// write appends an event. The caller holds r.mu.
+func (r *Recorder) write(kind, data string) error {
+	seconds := r.now().Sub(r.start).Seconds()
+	event, err := json.Marshal([]interface{}{seconds, kind, data})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(r.w, "%s\n", event)
+	return err
+}
+
+// Close stops recording and closes the underlying writer if it is an
+// io.Closer
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if c, ok := r.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// WithEngineRecording records each session to dir/<session ID>.cast
+func WithEngineRecording(dir string) EngineOption {
+	return func(e *Engine) {
+		e.recordDir = dir
+	}
+}
+
+// createRecording creates the cast file for a session
+func createRecording(dir, id string, width, height int) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(filepath.Join(dir, id+".cast"))
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewRecorder(f, width, height)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
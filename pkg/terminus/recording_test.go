@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecording(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Writes an asciinema cast",
+			test: func(t *testing.T) {
+				var buf bytes.Buffer
+				r, err := NewRecorder(&buf, 80, 24)
+				if err != nil {
+					t.Fatalf("NewRecorder failed: %v", err)
+				}
+				start := r.start
+				r.now = func() time.Time { return start.Add(1500 * time.Millisecond) }
+				r.Frame("hello\nworld")
+				r.Frame("hello\nworld") // Unchanged, skipped
+				r.Resize(100, 30)
+
+				lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("Expected a header and 2 events, got %q", lines)
+				}
+				if !strings.HasPrefix(lines[0], `{"version":2,"width":80,"height":24`) {
+					t.Errorf("Unexpected header %q", lines[0])
+				}
+				if lines[1] != `[1.5,"o","\u001b[H\u001b[2Jhello\r\nworld"]` {
+					t.Errorf("Unexpected output event %q", lines[1])
+				}
+				if lines[2] != `[1.5,"r","100x30"]` {
+					t.Errorf("Unexpected resize event %q", lines[2])
+				}
+			},
+		},
+		{
+			name: "Reads a cast back",
+			test: func(t *testing.T) {
+				input := `{"version": 2, "width": 40, "height": 10, "timestamp": 1700000000}
+[0.25, "o", "hi"]
+
+[1.0, "r", "50x12"]
+`
+				cast, err := ReadCast(strings.NewReader(input))
+				if err != nil {
+					t.Fatalf("ReadCast failed: %v", err)
+				}
+				if cast.Width != 40 || cast.Height != 10 || cast.Timestamp.Unix() != 1700000000 {
+					t.Errorf("Unexpected header %+v", cast)
+				}
+				if len(cast.Events) != 2 {
+					t.Fatalf("Expected 2 events, got %d", len(cast.Events))
+				}
+				if e := cast.Events[0]; e.Time != 250*time.Millisecond || e.Type != CastOutput || e.Data != "hi" {
+					t.Errorf("Unexpected event %+v", e)
+				}
+				if cast.Duration() != time.Second {
+					t.Errorf("Expected a 1s cast, got %v", cast.Duration())
+				}
+			},
+		},
+		{
+			name: "Returns the frame on screen at a time",
+			test: func(t *testing.T) {
+				cast := &Cast{Events: []CastEvent{
+					{Time: 0, Type: CastOutput, Data: clearFrame + "first\r\nframe"},
+					{Time: time.Second, Type: CastResize, Data: "100x30"},
+					{Time: 2 * time.Second, Type: CastOutput, Data: clearFrame + "second"},
+				}}
+				if got := cast.FrameAt(1500 * time.Millisecond); got != "first\nframe" {
+					t.Errorf("Expected the first frame, got %q", got)
+				}
+				if got := cast.FrameAt(cast.Duration()); got != "second" {
+					t.Errorf("Expected the last frame, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Rejects invalid casts",
+			test: func(t *testing.T) {
+				for _, input := range []string{
+					"",
+					`{"version": 1}`,
+					"{\"version\": 2}\n[0.1, \"o\"]",
+				} {
+					if _, err := ReadCast(strings.NewReader(input)); err == nil {
+						t.Errorf("Expected an error for %q", input)
+					}
+				}
+			},
+		},
+		{
+			name: "Records sessions to a directory",
+			test: func(t *testing.T) {
+				dir := t.TempDir()
+				session := NewSession("abc", nil, &testComponent{}, WithEngineRecording(dir))
+				session.handleRender("frame one")
+				session.clientToTerminusMessage(ClientMessage{
+					Type: "resize",
+					Data: map[string]interface{}{"width": 100.0, "height": 40.0},
+				})
+				session.Close()
+
+				cast, err := LoadCast(filepath.Join(dir, "abc.cast"))
+				if err != nil {
+					t.Fatalf("LoadCast failed: %v", err)
+				}
+				if cast.Width != 80 || cast.Height != 24 {
+					t.Errorf("Expected an 80x24 cast, got %dx%d", cast.Width, cast.Height)
+				}
+				if len(cast.Events) != 2 || !strings.HasSuffix(cast.Events[0].Data, "frame one") ||
+					cast.Events[1].Data != "100x40" {
+					t.Errorf("Unexpected events %+v", cast.Events)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -15,14 +15,22 @@
 package terminus
 
 import (
+	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // Cell represents a single character cell in the terminal
 type Cell struct {
 	Rune  rune
 	Style Style
+
+	// Combining holds the zero-width marks drawn over Rune, e.g. Arabic
+	// vowel signs or a combining accent without a precomposed form
+	Combining string
 }
 
 // Line represents a line of cells
@@ -30,21 +38,33 @@ type Line []Cell
 
 // Screen represents the virtual terminal screen
 type Screen struct {
-	width  int
-	height int
-	lines  []Line
+	width    int
+	height   int
+	tabWidth int
+	wrap     Wrap
+	lines    []Line
 	cursor struct {
 		x int
 		y int
 	}
+
+	// Lines of content taller than the screen that scrolled off the top,
+	// kept up to overflowLimit for the scrollback
+	overflow      []Line
+	overflowLimit int
+
+	// The session's scrollback, oldest first, for overlays such as copy
+	// mode
+	scrollback []Line
 }
 
 // NewScreen creates a new virtual screen
 func NewScreen(width, height int) *Screen {
 	s := &Screen{
-		width:  width,
-		height: height,
-		lines:  make([]Line, height),
+		width:    width,
+		height:   height,
+		tabWidth: DefaultTabWidth,
+		lines:    make([]Line, height),
 	}
 	
 	// Initialize empty lines
@@ -58,6 +78,21 @@ func NewScreen(width, height int) *Screen {
 	return s
 }
 
+// SetTabWidth sets the distance between tab stops. Less than 1 uses
+// DefaultTabWidth.
+func (s *Screen) SetTabWidth(width int) {
+	if width < 1 {
+		width = DefaultTabWidth
+	}
+	s.tabWidth = width
+}
+
+// SetWrap sets how lines wider than the screen are wrapped. The zero Wrap
+// breaks them at the last column.
+func (s *Screen) SetWrap(wrap Wrap) {
+	s.wrap = wrap
+}
+
 // Clear clears the screen
 func (s *Screen) Clear() {
 	for i := range s.lines {
@@ -84,7 +119,8 @@ func (s *Screen) GetCell(x, y int) Cell {
 	return Cell{Rune: ' '}
 }
 
-// RenderFromString renders a string to the screen, handling ANSI codes
+// RenderFromString renders a string to the screen, handling ANSI codes.
+// Lines wider than the screen are wrapped as SetWrap says.
 func (s *Screen) RenderFromString(content string) {
 	s.Clear()
 	
@@ -93,53 +129,162 @@ func (s *Screen) RenderFromString(content string) {
 	s.cursor.x = 0
 	s.cursor.y = 0
 	
+	// The cell last written, which combining marks attach to
+	var base *Cell
+	// The column the word being written starts at, for WrapWord
+	wordStart := 0
+	// Whether the line is full and continues on the next one if anything
+	// but a line break follows, for WrapWord
+	pending := false
+	// Whether the rest of the line is cut off, for WrapNone
+	clipped := false
+	
 	for {
 		r, style, ok := parser.Next()
 		if !ok {
 			break
 		}
 		
+		// Combining marks and joiners share the cell before them
+		if base != nil && IsCombining(r) {
+			base.combine(r)
+			continue
+		}
+		
+		// A full line breaks before its next word; the space between the
+		// two is dropped
+		if pending {
+			pending = false
+			switch r {
+			case '\n', '\r':
+			case ' ', '\t':
+				base = nil
+				wordStart = 0
+				s.newline()
+				continue
+			default:
+				base = s.breakWord(wordStart)
+				wordStart = 0
+			}
+		}
+		
 		// Handle special characters
 		switch r {
 		case '\n':
-			s.cursor.x = 0
-			s.cursor.y++
-			if s.cursor.y >= s.height {
-				// Scroll up
-				s.scrollUp()
-				s.cursor.y = s.height - 1
-			}
+			base = nil
+			wordStart, clipped = 0, false
+			s.newline()
 		case '\r':
+			base = nil
+			wordStart, clipped = 0, false
 			s.cursor.x = 0
 		case '\t':
-			// Move to next tab stop (every 8 characters)
-			nextTab := ((s.cursor.x / 8) + 1) * 8
+			// Move to next tab stop
+			base = nil
+			nextTab := ((s.cursor.x / s.tabWidth) + 1) * s.tabWidth
 			if nextTab < s.width {
 				s.cursor.x = nextTab
+				wordStart = nextTab
 			}
 		default:
+			// Past the last column, which only WrapNone leaves the cursor
+			// at, the line is cut off
+			if s.cursor.x >= s.width || s.cursor.y >= s.height {
+				if !clipped && s.wrap.Overflow == OverflowEllipsis && s.width > 0 && s.cursor.y < s.height {
+					cell := &s.lines[s.cursor.y][s.width-1]
+					cell.Rune, cell.Combining = '…', ""
+				}
+				base = nil
+				clipped = true
+				continue
+			}
+			
 			// Regular character
-			if s.cursor.x < s.width && s.cursor.y < s.height {
+			if IsCombining(r) {
+				s.SetCell(s.cursor.x, s.cursor.y, ' ', style)
+				s.lines[s.cursor.y][s.cursor.x].Combining = string(r)
+			} else {
 				s.SetCell(s.cursor.x, s.cursor.y, r, style)
-				s.cursor.x++
-				
-				// Wrap to next line
-				if s.cursor.x >= s.width {
-					s.cursor.x = 0
-					s.cursor.y++
-					if s.cursor.y >= s.height {
-						// Scroll up
-						s.scrollUp()
-						s.cursor.y = s.height - 1
-					}
+			}
+			base = &s.lines[s.cursor.y][s.cursor.x]
+			s.cursor.x++
+			if r == ' ' {
+				wordStart = s.cursor.x
+			}
+			
+			// Wrap to next line
+			if s.cursor.x >= s.width {
+				switch s.wrap.Mode {
+				case WrapChar:
+					// Scrolling up keeps base's line
+					s.newline()
+				case WrapWord:
+					pending = true
 				}
 			}
 		}
 	}
 }
 
+// newline moves the cursor to the start of the next line, scrolling up at
+// the bottom of the screen
+func (s *Screen) newline() {
+	s.cursor.x = 0
+	s.cursor.y++
+	if s.cursor.y >= s.height {
+		s.scrollUp()
+		s.cursor.y = s.height - 1
+	}
+}
+
+// breakWord continues the full line on the next one, moving the word that
+// starts at column start along unless it starts the line, and so is longer
+// than one. It returns the last cell moved, if any.
+func (s *Screen) breakWord(start int) *Cell {
+	var word Line
+	if start > 0 && start < s.width {
+		line := s.lines[s.cursor.y]
+		word = append(word, line[start:]...)
+		for x := start; x < s.width; x++ {
+			line[x] = Cell{Rune: ' '}
+		}
+	}
+	
+	s.newline()
+	copy(s.lines[s.cursor.y], word)
+	s.cursor.x = len(word)
+	if len(word) == 0 {
+		return nil
+	}
+	return &s.lines[s.cursor.y][len(word)-1]
+}
+
+// IsCombining reports whether r is drawn over the character before it
+// rather than in a cell of its own: combining marks, joiners and variation
+// selectors
+func IsCombining(r rune) bool {
+	return RuneWidth(r) == 0 && !unicode.IsControl(r)
+}
+
+// combine attaches the combining mark r to the cell, composing the two
+// when Unicode has a precomposed character for them, e.g. e and U+0301
+// into é
+func (c *Cell) combine(r rune) {
+	cluster := norm.NFC.String(string(c.Rune) + c.Combining + string(r))
+	first, size := utf8.DecodeRuneInString(cluster)
+	c.Rune, c.Combining = first, cluster[size:]
+}
+
 // scrollUp scrolls the screen up by one line
 func (s *Screen) scrollUp() {
+	// Keep the line scrolling off for the scrollback
+	if s.overflowLimit > 0 {
+		s.overflow = append(s.overflow, s.lines[0])
+		if len(s.overflow) > s.overflowLimit {
+			s.overflow = s.overflow[1:]
+		}
+	}
+
 	// Move all lines up
 	copy(s.lines, s.lines[1:])
 	
@@ -157,6 +302,7 @@ func (s *Screen) ToString() string {
 	for y, line := range s.lines {
 		for _, cell := range line {
 			builder.WriteRune(cell.Rune)
+			builder.WriteString(cell.Combining)
 		}
 		if y < s.height-1 {
 			builder.WriteRune('\n')
@@ -265,6 +411,12 @@ func (p *ANSIParser) parseSGR(codes string) {
 		case "9":
 			// Crossed out
 			p.current = p.current.CrossOut(true)
+		case "21":
+			// Double underline
+			p.current = p.current.DoubleUnderline(true)
+		case "53":
+			// Overline
+			p.current = p.current.Overline(true)
 			
 		// Foreground colors
 		case "30":
@@ -338,7 +490,50 @@ func (p *ANSIParser) parseSGR(codes string) {
 		case "107":
 			p.current = p.current.Background(BrightWhite)
 			
-		// 256 color and RGB not implemented yet for simplicity
+		// Attribute resets
+		case "22":
+			p.current = p.current.Bold(false).Faint(false)
+		case "23":
+			p.current = p.current.Italic(false)
+		case "24":
+			p.current = p.current.Underline(false).DoubleUnderline(false)
+		case "25":
+			p.current = p.current.Blink(false)
+		case "27":
+			p.current = p.current.Reverse(false)
+		case "29":
+			p.current = p.current.CrossOut(false)
+		case "55":
+			p.current = p.current.Overline(false)
+			
+		// 256 color and RGB
+		case "38", "48":
+			color, n := extendedColor(parts[i+1:])
+			i += n
+			if n == 0 {
+				continue
+			}
+			if code == "38" {
+				p.current = p.current.Foreground(color)
+			} else {
+				p.current = p.current.Background(color)
+			}
 		}
 	}
+}
+
+// extendedColor parses the parameters after an extended color code, 5;n or
+// 2;r;g;b, returning the color and how many parameters it used
+func extendedColor(params []string) (Color, int) {
+	num := func(i int) int {
+		n, _ := strconv.Atoi(params[i])
+		return n
+	}
+	switch {
+	case len(params) >= 2 && params[0] == "5":
+		return ANSI256(num(1)), 2
+	case len(params) >= 4 && params[0] == "2":
+		return RGB(num(1), num(2), num(3)), 4
+	}
+	return Color{}, 0
 }
\ No newline at end of file
@@ -286,6 +286,29 @@ func TestANSIParser(t *testing.T) {
 				{r: 'l', style: "Style{}"},
 			},
 		},
+		{
+			name:  "256 and RGB colors",
+			input: "\x1b[38;5;208;48;2;0;0;255mA\x1b[22;1mB",
+			expected: []struct {
+				r     rune
+				style string
+			}{
+				{r: 'A', style: "Style{fg:ansi256(208), bg:rgb(0;0;255)}"},
+				{r: 'B', style: "Style{bold, fg:ansi256(208), bg:rgb(0;0;255)}"},
+			},
+		},
+		{
+			name:  "Lines",
+			input: "\x1b[9;21mA\x1b[53;24mB\x1b[29;55mC",
+			expected: []struct {
+				r     rune
+				style string
+			}{
+				{r: 'A', style: "Style{doubleunderline, crossout}"},
+				{r: 'B', style: "Style{overline, crossout}"},
+				{r: 'C', style: "Style{}"},
+			},
+		},
 		{
 			name:  "UTF-8 characters",
 			input: "Hello 世界",
@@ -416,4 +439,87 @@ func TestTabHandling(t *testing.T) {
 	if screen.GetCell(16, 0).Rune != 'C' {
 		t.Error("Expected 'C' at position 16")
 	}
-}
\ No newline at end of file
+}
+
+func TestTabWidth(t *testing.T) {
+	screen := NewScreen(20, 2)
+	screen.SetTabWidth(4)
+	screen.RenderFromString("A\tB\tC\nab\u4e16\tD")
+
+	for _, want := range []struct {
+		x, y int
+		r    rune
+	}{{0, 0, 'A'}, {4, 0, 'B'}, {8, 0, 'C'}, {4, 1, 'D'}} {
+		if got := screen.GetCell(want.x, want.y).Rune; got != want.r {
+			t.Errorf("Expected %q at (%d, %d), got %q", want.r, want.x, want.y, got)
+		}
+	}
+
+	screen.SetTabWidth(0)
+	screen.Clear()
+	screen.RenderFromString("A\tB")
+	if screen.GetCell(8, 0).Rune != 'B' {
+		t.Error("Expected the default tab width for 0")
+	}
+}
+
+func TestCombiningCharacters(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		cells []Cell
+		line  string
+	}{
+		{
+			name:  "Composes decomposed é",
+			input: "cafe\u0301!",
+			cells: []Cell{{Rune: 'c'}, {Rune: 'a'}, {Rune: 'f'}, {Rune: 'é'}, {Rune: '!'}},
+			line:  "café!",
+		},
+		{
+			name:  "Keeps Arabic vowel signs with their letters",
+			input: "\u0628\u0650\u0633\u0652\u0645\u0650",
+			cells: []Cell{{Rune: 'ب', Combining: "\u0650"}, {Rune: 'س', Combining: "\u0652"}, {Rune: 'م', Combining: "\u0650"}},
+			line:  "\u0628\u0650\u0633\u0652\u0645\u0650",
+		},
+		{
+			name:  "Stacks Thai marks on one cell",
+			input: "\u0e01\u0e35\u0e48\u0e44\u0e01\u0e48",
+			cells: []Cell{{Rune: 'ก', Combining: "\u0e35\u0e48"}, {Rune: 'ไ'}, {Rune: 'ก', Combining: "\u0e48"}},
+			line:  "\u0e01\u0e35\u0e48\u0e44\u0e01\u0e48",
+		},
+		{
+			name:  "Keeps the style of the base character",
+			input: "\x1b[0;1mn\x1b[0m\u0303x",
+			cells: []Cell{{Rune: 'ñ', Style: NewStyle().Bold(true)}, {Rune: 'x'}},
+			line:  "\x1b[1mñ\x1b[0mx",
+		},
+		{
+			name:  "Gives a mark without a base a cell",
+			input: "\u0301a",
+			cells: []Cell{{Rune: ' ', Combining: "\u0301"}, {Rune: 'a'}},
+			line:  " \u0301a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := NewScreen(10, 1)
+			screen.RenderFromString(tt.input)
+			for x, want := range tt.cells {
+				got := screen.GetCell(x, 0)
+				if got.Rune != want.Rune || got.Combining != want.Combining || !got.Style.Equal(want.Style) {
+					t.Errorf("Cell %d: expected %q+%q, got %q+%q", x, want.Rune, want.Combining, got.Rune, got.Combining)
+				}
+			}
+			if next := screen.GetCell(len(tt.cells), 0); next.Rune != ' ' || next.Combining != "" {
+				t.Errorf("Expected the line to end after %d cells, got %q", len(tt.cells), next.Rune)
+			}
+
+			differ := &Differ{newScreen: screen}
+			if line := differ.renderLine(screen, 0); line != tt.line {
+				t.Errorf("Expected line %q, got %q", tt.line, line)
+			}
+		})
+	}
+}
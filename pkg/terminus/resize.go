@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultResizeDebounce is how long a session waits for resizes to settle
+// before sending the final WindowSizeMsg
+const DefaultResizeDebounce = 50 * time.Millisecond
+
+// WithEngineResizeDebounce sets how long resizes must settle before the
+// component receives the final size. The first resize of a burst is
+// delivered at once. Zero or less delivers every resize.
+func WithEngineResizeDebounce(d time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.resizeDebounce = d
+	}
+}
+
+// WithEngineMinSize shows a "terminal too small" screen instead of the view
+// while the terminal is smaller than width x height
+func WithEngineMinSize(width, height int) EngineOption {
+	return func(e *Engine) {
+		e.minWidth, e.minHeight = width, height
+	}
+}
+
+// resizeDebouncer delivers the first resize of a burst immediately and the
+// last one once no resize has arrived for the debounce interval
+type resizeDebouncer struct {
+	delay     time.Duration
+	timer     *time.Timer
+	pending   *WindowSizeMsg
+	delivered WindowSizeMsg
+}
+
+// resize delivers a resize from the client to the component, debounced
+func (s *Session) resize(size WindowSizeMsg) {
+	s.mu.Lock()
+	d := s.debouncer
+	if d == nil || d.delay <= 0 {
+		s.mu.Unlock()
+		s.engine.SendMessage(size)
+		return
+	}
+
+	if d.timer != nil {
+		// Within a burst: keep the latest size for the end of it
+		d.pending = &size
+		d.timer.Reset(d.delay)
+		s.mu.Unlock()
+		return
+	}
+	d.delivered = size
+	d.timer = time.AfterFunc(d.delay, s.settleResize)
+	s.mu.Unlock()
+	s.engine.SendMessage(size)
+}
+
+// settleResize delivers the last size of a burst if it differs from the
+// size already delivered
+func (s *Session) settleResize() {
+	s.mu.Lock()
+	d := s.debouncer
+	pending := d.pending
+	d.timer, d.pending = nil, nil
+	if pending == nil || *pending == d.delivered {
+		s.mu.Unlock()
+		return
+	}
+	d.delivered = *pending
+	s.mu.Unlock()
+	s.engine.SendMessage(*pending)
+}
+
+// tooSmallView is shown in place of the view when the terminal is smaller
+// than the minimum size
+func tooSmallView(width, height, minWidth, minHeight int) string {
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("need %dx%d, have %dx%d", minWidth, minHeight, width, height),
+	}
+
+	top := (height - len(lines)) / 2
+	if top < 0 {
+		top = 0
+	}
+	var b strings.Builder
+	b.WriteString(strings.Repeat("\n", top))
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if pad := (width - len(line)) / 2; pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// queuedSizes drains the window sizes queued for the engine
+func queuedSizes(e *Engine) []WindowSizeMsg {
+	var sizes []WindowSizeMsg
+	for {
+		select {
+		case msg := <-e.priority:
+			if size, ok := msg.(WindowSizeMsg); ok {
+				sizes = append(sizes, size)
+			}
+		default:
+			return sizes
+		}
+	}
+}
+
+// resizeMsg is a resize from the client
+func resizeMsg(width, height int) ClientMessage {
+	return ClientMessage{
+		Type: "resize",
+		Data: map[string]interface{}{"width": float64(width), "height": float64(height)},
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Debounces bursts of resizes",
+			test: func(t *testing.T) {
+				session := NewSession("s", nil, &testComponent{}, WithEngineResizeDebounce(20*time.Millisecond))
+				defer session.Close()
+
+				for _, w := range []int{100, 110, 120} {
+					session.resize(WindowSizeMsg{Width: w, Height: 30})
+				}
+				if sizes := queuedSizes(session.engine); len(sizes) != 1 || sizes[0].Width != 100 {
+					t.Fatalf("Expected the first size at once, got %+v", sizes)
+				}
+
+				time.Sleep(60 * time.Millisecond)
+				if sizes := queuedSizes(session.engine); len(sizes) != 1 || sizes[0].Width != 120 {
+					t.Errorf("Expected the last size once settled, got %+v", sizes)
+				}
+
+				// A lone resize is not repeated
+				session.resize(WindowSizeMsg{Width: 90, Height: 30})
+				time.Sleep(60 * time.Millisecond)
+				if sizes := queuedSizes(session.engine); len(sizes) != 1 {
+					t.Errorf("Expected one delivery, got %+v", sizes)
+				}
+			},
+		},
+		{
+			name: "Delivers every resize without debouncing",
+			test: func(t *testing.T) {
+				session := NewSession("s", nil, &testComponent{}, WithEngineResizeDebounce(0))
+				defer session.Close()
+
+				session.resize(WindowSizeMsg{Width: 100, Height: 30})
+				session.resize(WindowSizeMsg{Width: 110, Height: 30})
+				if sizes := queuedSizes(session.engine); len(sizes) != 2 {
+					t.Errorf("Expected both sizes, got %+v", sizes)
+				}
+			},
+		},
+		{
+			name: "Shows a screen when the terminal is too small",
+			test: func(t *testing.T) {
+				session := NewSession("s", nil, &testComponent{}, WithEngineMinSize(80, 24))
+				defer session.Close()
+
+				session.clientToTerminusMessage(resizeMsg(60, 10))
+				session.handleRender("the app")
+				if !strings.Contains(session.lastView, "need 80x24, have 60x10") {
+					t.Errorf("Expected the too-small screen, got %q", session.lastView)
+				}
+
+				session.clientToTerminusMessage(resizeMsg(80, 24))
+				session.handleRender("the app")
+				if session.lastView != "the app" {
+					t.Errorf("Expected the view once large enough, got %q", session.lastView)
+				}
+			},
+		},
+		{
+			name: "Centres the too-small message",
+			test: func(t *testing.T) {
+				lines := strings.Split(tooSmallView(30, 6, 80, 24), "\n")
+				if len(lines) != 4 || lines[2] != "      Terminal too small" {
+					t.Errorf("Unexpected layout %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
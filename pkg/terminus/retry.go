@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how Retry re-runs a failing command
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// InitialDelay is the wait before the first retry
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between any two attempts
+	MaxDelay time.Duration
+	// Multiplier grows the delay after each failed attempt
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction (0 to 1)
+	Jitter float64
+	// ShouldRetry reports whether a command result is a failure worth
+	// retrying. Defaults to IsRetryable.
+	ShouldRetry func(Msg) bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential backoff
+// starting at 200ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 200 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// withDefaults fills zero-valued fields from DefaultRetryPolicy
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	def := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = def.InitialDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = def.MaxDelay
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = def.Multiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	if p.ShouldRetry == nil {
+		p.ShouldRetry = IsRetryable
+	}
+	return p
+}
+
+// Delay returns the backoff before the given retry (1 for the first retry),
+// without jitter applied
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	p = p.withDefaults()
+	delay := float64(p.InitialDelay)
+	for i := 1; i < retry; i++ {
+		delay *= p.Multiplier
+		if delay >= float64(p.MaxDelay) {
+			return p.MaxDelay
+		}
+	}
+	if delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// jittered spreads d randomly within ±Jitter of its value
+func (p RetryPolicy) jittered(d time.Duration) time.Duration {
+	if p.Jitter == 0 || d <= 0 {
+		return d
+	}
+	spread := float64(d) * p.Jitter
+	return time.Duration(float64(d) - spread + rand.Float64()*2*spread)
+}
+
+// RetryAttemptMsg is sent before each retry so the UI can show progress
+type RetryAttemptMsg struct {
+	Attempt     int           // The attempt about to run, starting at 2
+	MaxAttempts int           // Total attempts allowed by the policy
+	Delay       time.Duration // Wait before the attempt runs
+	LastResult  Msg           // The failed result that triggered the retry
+}
+
+// String returns a short status such as "retrying (2/5)…"
+func (m RetryAttemptMsg) String() string {
+	return fmt.Sprintf("retrying (%d/%d)…", m.Attempt, m.MaxAttempts)
+}
+
+// IsRetryable is the default failure check used by Retry. It treats errors,
+// timeouts, network failures and 5xx/429 HTTP responses as retryable.
+func IsRetryable(msg Msg) bool {
+	switch m := msg.(type) {
+	case error:
+		return true
+	case TimeoutMsg:
+		return true
+	case HTTPRequestMsg:
+		if m.Error != nil {
+			return true
+		}
+		code := m.StatusCode()
+		return code >= 500 || code == 429
+	}
+	return false
+}
+
+// Retry re-runs cmd with exponential backoff and jitter while its result is
+// a retryable failure. A RetryAttemptMsg is delivered before every retry and
+// the final result is returned once an attempt succeeds or the policy gives up.
+func Retry(cmd Cmd, policy RetryPolicy) Cmd {
+	if cmd == nil {
+		return nil
+	}
+	policy = policy.withDefaults()
+
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		var msg Msg
+		for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+			if attempt > 1 {
+				delay := policy.jittered(policy.Delay(attempt - 1))
+				send(RetryAttemptMsg{
+					Attempt:     attempt,
+					MaxAttempts: policy.MaxAttempts,
+					Delay:       delay,
+					LastResult:  msg,
+				})
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return msg
+				}
+			}
+
+			msg = runCmd(ctx, cmd, send)
+			if !policy.ShouldRetry(msg) {
+				return msg
+			}
+		}
+		return msg
+	})
+}
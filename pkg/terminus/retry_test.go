@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// runStream executes a command, running stream bodies inline and collecting
+// intermediate messages
+func runStream(t *testing.T, cmd Cmd) (Msg, []Msg) {
+	t.Helper()
+	var sent []Msg
+	final := runCmd(context.Background(), cmd, func(m Msg) {
+		sent = append(sent, m)
+	})
+	return final, sent
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     time.Second,
+		Multiplier:   2,
+	}
+
+	tests := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := p.Delay(tt.retry); got != tt.expected {
+			t.Errorf("Delay(%d) = %v, expected %v", tt.retry, got, tt.expected)
+		}
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	p := RetryPolicy{Jitter: 0.5}
+	base := 100 * time.Millisecond
+
+	for i := 0; i < 50; i++ {
+		d := p.jittered(base)
+		if d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatalf("jittered delay %v outside expected range", d)
+		}
+	}
+
+	p.Jitter = 0
+	if d := p.jittered(base); d != base {
+		t.Errorf("Expected no jitter, got %v", d)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      Msg
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"plain message", testMsg{value: "ok"}, false},
+		{"error", errors.New("boom"), true},
+		{"timeout", TimeoutMsg{Duration: time.Second}, true},
+		{"network error", HTTPRequestMsg{Error: errors.New("refused")}, true},
+		{"server error", HTTPRequestMsg{Response: &http.Response{StatusCode: 503}}, true},
+		{"rate limited", HTTPRequestMsg{Response: &http.Response{StatusCode: 429}}, true},
+		{"client error", HTTPRequestMsg{Response: &http.Response{StatusCode: 404}}, false},
+		{"success", HTTPRequestMsg{Response: &http.Response{StatusCode: 200}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.msg); got != tt.expected {
+				t.Errorf("IsRetryable() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+	}
+
+	t.Run("Succeeds after failures", func(t *testing.T) {
+		calls := 0
+		cmd := func() Msg {
+			calls++
+			if calls < 3 {
+				return errors.New("temporary")
+			}
+			return testMsg{value: "done"}
+		}
+
+		final, sent := runStream(t, Retry(cmd, policy))
+
+		if final != (testMsg{value: "done"}) {
+			t.Errorf("Expected final success message, got %v", final)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+		if len(sent) != 2 {
+			t.Fatalf("Expected 2 RetryAttemptMsg, got %d", len(sent))
+		}
+
+		attempt, ok := sent[1].(RetryAttemptMsg)
+		if !ok {
+			t.Fatalf("Expected RetryAttemptMsg, got %T", sent[1])
+		}
+		if attempt.Attempt != 3 || attempt.MaxAttempts != 5 {
+			t.Errorf("Unexpected attempt info: %+v", attempt)
+		}
+		if attempt.String() != "retrying (3/5)…" {
+			t.Errorf("Unexpected attempt string: %q", attempt.String())
+		}
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		failure := errors.New("permanent")
+		cmd := func() Msg {
+			calls++
+			return failure
+		}
+
+		final, sent := runStream(t, Retry(cmd, policy))
+
+		if err, ok := final.(error); !ok || !errors.Is(err, failure) {
+			t.Errorf("Expected last failure to be returned, got %v", final)
+		}
+		if calls != 5 {
+			t.Errorf("Expected 5 calls, got %d", calls)
+		}
+		if len(sent) != 4 {
+			t.Errorf("Expected 4 RetryAttemptMsg, got %d", len(sent))
+		}
+	})
+
+	t.Run("Custom ShouldRetry", func(t *testing.T) {
+		calls := 0
+		p := policy
+		p.ShouldRetry = func(msg Msg) bool {
+			m, ok := msg.(testMsg)
+			return ok && m.value == "again"
+		}
+		cmd := func() Msg {
+			calls++
+			if calls == 1 {
+				return testMsg{value: "again"}
+			}
+			return testMsg{value: "ok"}
+		}
+
+		final, _ := runStream(t, Retry(cmd, p))
+		if final != (testMsg{value: "ok"}) || calls != 2 {
+			t.Errorf("Expected success on second call, got %v after %d calls", final, calls)
+		}
+	})
+
+	t.Run("Stops when cancelled", func(t *testing.T) {
+		p := policy
+		p.InitialDelay = time.Hour
+		p.MaxDelay = time.Hour
+		cmd := func() Msg { return errors.New("fail") }
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan Msg, 1)
+		go func() {
+			done <- runCmd(ctx, Retry(cmd, p), func(Msg) {})
+		}()
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Retry did not stop after cancellation")
+		}
+	})
+
+	t.Run("Nil command", func(t *testing.T) {
+		if Retry(nil, policy) != nil {
+			t.Error("Retry(nil) should return nil")
+		}
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"sort"
+)
+
+// RoleSet is the set of roles a session's user holds, such as "admin" or
+// "operator", as the program's auth hook found them. The zero RoleSet holds
+// none.
+type RoleSet map[string]struct{}
+
+// NewRoleSet returns a set of roles
+func NewRoleSet(roles ...string) RoleSet {
+	set := make(RoleSet, len(roles))
+	for _, role := range roles {
+		set[role] = struct{}{}
+	}
+	return set
+}
+
+// Has reports whether the set holds role
+func (r RoleSet) Has(role string) bool {
+	_, ok := r[role]
+	return ok
+}
+
+// Allows reports whether the set holds any of required. Nothing is
+// required of anyone when required is empty.
+func (r RoleSet) Allows(required ...string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, role := range required {
+		if r.Has(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether the set holds every role of other
+func (r RoleSet) Covers(other RoleSet) bool {
+	for role := range other {
+		if !r.Has(role) {
+			return false
+		}
+	}
+	return true
+}
+
+// Roles returns the roles in the set, sorted
+func (r RoleSet) Roles() []string {
+	roles := make([]string, 0, len(r))
+	for role := range r {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// RolesMsg tells the component the roles of the session's user. It is sent
+// when a session of a program with an auth hook starts, after Init, and in
+// answer to RequestRoles.
+type RolesMsg struct {
+	Roles RoleSet
+}
+
+// rolesRequestMsg asks the engine for the session's roles
+type rolesRequestMsg struct{}
+
+// RequestRoles returns a command that sends the component a RolesMsg with
+// the session's roles again, for components created after the session
+// started, such as the children of a Lazy. Without an auth hook, nothing
+// is sent.
+func RequestRoles() Cmd {
+	return func() Msg {
+		return rolesRequestMsg{}
+	}
+}
+
+// WithEngineRoles sends the component a RolesMsg with roles when the engine
+// starts
+func WithEngineRoles(roles RoleSet) EngineOption {
+	return func(e *Engine) {
+		if roles == nil {
+			roles = RoleSet{}
+		}
+		e.roles = roles
+	}
+}
+
+// Roles returns the roles the engine was started with, or nil without
+// WithEngineRoles
+func (e *Engine) Roles() RoleSet {
+	return e.roles
+}
+
+// Roles returns the roles of the session's user, as sent to its component
+// in a RolesMsg
+func (s *Session) Roles() RoleSet {
+	return s.engine.Roles()
+}
+
+// WithAuth authenticates the requests that start, resume, observe or join
+// sessions with auth, e.g. from a cookie or a header set by a proxy. A
+// request it returns an error for is refused with 401 Unauthorized. The
+// roles it returns are sent to the session's component in a RolesMsg, for
+// widgets such as widget.Gate to hide what the user may not use.
+// Collaborators act in the owner's session with the owner's roles, so a
+// user may only join a session, or resume one, if they hold every role of
+// its owner; others are refused with 403 Forbidden.
+func WithAuth(auth func(r *http.Request) (RoleSet, error)) ProgramOption {
+	return func(p *Program) {
+		p.auth = auth
+	}
+}
+
+// authenticate runs the auth hook, if any, on r. It replies 401 and
+// returns false if the hook refuses it.
+func (p *Program) authenticate(w http.ResponseWriter, r *http.Request) (RoleSet, bool) {
+	if p.auth == nil {
+		return nil, true
+	}
+	roles, err := p.auth(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	return roles, true
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// rolesComponent shows the roles it is told
+type rolesComponent struct {
+	roles chan RoleSet
+}
+
+func (c *rolesComponent) Init() Cmd { return nil }
+
+func (c *rolesComponent) Update(msg Msg) (Component, Cmd) {
+	if msg, ok := msg.(RolesMsg); ok {
+		c.roles <- msg.Roles
+	}
+	return c, nil
+}
+
+func (c *rolesComponent) View() string { return "" }
+
+func TestRoleSet(t *testing.T) {
+	roles := NewRoleSet("viewer", "operator")
+	tests := []struct {
+		name     string
+		roles    RoleSet
+		required []string
+		allowed  bool
+	}{
+		{name: "Nothing required", roles: nil, allowed: true},
+		{name: "One held", roles: roles, required: []string{"operator"}, allowed: true},
+		{name: "Any held", roles: roles, required: []string{"admin", "viewer"}, allowed: true},
+		{name: "None held", roles: roles, required: []string{"admin"}},
+		{name: "No roles", roles: nil, required: []string{"viewer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.roles.Allows(tt.required...); got != tt.allowed {
+				t.Errorf("Expected Allows(%v) to be %v", tt.required, tt.allowed)
+			}
+		})
+	}
+	if got := strings.Join(roles.Roles(), ","); got != "operator,viewer" {
+		t.Errorf("Expected sorted roles, got %q", got)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	comp := &rolesComponent{roles: make(chan RoleSet, 1)}
+	program := NewProgram(func() Component { return comp },
+		WithAuth(func(r *http.Request) (RoleSet, error) {
+			if r.Header.Get("X-User") == "" {
+				return nil, errors.New("not signed in")
+			}
+			return NewRoleSet("admin"), nil
+		}))
+	defer program.Stop()
+	handler, err := program.routes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	url := "ws://" + strings.TrimPrefix(server.URL, "http://") + "/ws"
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Refuses requests the hook refuses",
+			test: func(t *testing.T) {
+				conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+				if err == nil {
+					conn.Close()
+					t.Fatal("Expected the connection to be refused")
+				}
+				if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("Expected 401 Unauthorized, got %v", resp)
+				}
+			},
+		},
+		{
+			name: "Tells the component the user's roles",
+			test: func(t *testing.T) {
+				conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"X-User": {"ada"}})
+				if err != nil {
+					t.Fatalf("Expected the connection to be accepted: %v", err)
+				}
+				defer conn.Close()
+				select {
+				case roles := <-comp.roles:
+					if !roles.Has("admin") || len(roles) != 1 {
+						t.Errorf("Expected the admin role, got %v", roles.Roles())
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected a RolesMsg")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestAuthJoin(t *testing.T) {
+	// Users hold the roles listed in their X-Roles header
+	program := NewProgram(func() Component { return &collabComponent{} },
+		WithAuth(func(r *http.Request) (RoleSet, error) {
+			return NewRoleSet(strings.Split(r.Header.Get("X-Roles"), ",")...), nil
+		}))
+	defer program.Stop()
+	server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	owner, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"X-Roles": {"admin,viewer"}})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer owner.Close()
+	content := screenText(readUntil(t, owner, "link /?join="))
+	join := wsURL + strings.Fields(content[strings.Index(content, "/?join="):])[0][1:]
+
+	tests := []struct {
+		name  string
+		roles string
+		code  int
+	}{
+		{name: "Refuses users without the owner's roles", roles: "viewer", code: http.StatusForbidden},
+		{name: "Admits users with the owner's roles", roles: "viewer,admin,auditor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, resp, err := websocket.DefaultDialer.Dial(join, http.Header{"X-Roles": {tt.roles}})
+			if tt.code == 0 {
+				if err != nil {
+					t.Fatalf("Expected to join: %v", err)
+				}
+				conn.Close()
+				return
+			}
+			if err == nil {
+				conn.Close()
+				t.Fatal("Expected the join to be refused")
+			}
+			if resp == nil || resp.StatusCode != tt.code {
+				t.Errorf("Expected status %d, got %v", tt.code, resp)
+			}
+		})
+	}
+}
+
+func TestEngineRoles(t *testing.T) {
+	comp := &rolesComponent{roles: make(chan RoleSet, 1)}
+	engine, _ := startEngine(t, comp, WithEngineRoles(nil))
+	select {
+	case roles := <-comp.roles:
+		if roles == nil || len(roles) != 0 || engine.Roles() == nil {
+			t.Errorf("Expected an empty set of roles, got %v", roles)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a RolesMsg")
+	}
+
+	// Components created later ask for them again
+	engine.SendMessage(RequestRoles()())
+	select {
+	case <-comp.roles:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a RolesMsg in answer to RequestRoles")
+	}
+
+	comp = &rolesComponent{roles: make(chan RoleSet, 1)}
+	engine, _ = startEngine(t, comp)
+	engine.SendMessage(RequestRoles()())
+	select {
+	case <-comp.roles:
+		t.Error("Expected no RolesMsg without roles")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
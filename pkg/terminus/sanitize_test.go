@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Plain text", "hello, 世界 🍎", "hello, 世界 🍎"},
+		{"Keeps newlines and tabs", "a\tb\nc", "a\tb\nc"},
+		{"SGR", "\x1b[1;31mred\x1b[0m", "red"},
+		{"Cursor movement", "ok\x1b[2J\x1b[Hgone", "okgone"},
+		{"OSC ended by BEL", "\x1b]0;pwned\atitle", "title"},
+		{"OSC ended by ST", "\x1b]8;;http://evil\x1b\\link", "link"},
+		{"Two-byte escape", "a\x1bcb", "ab"},
+		{"Unterminated escape", "a\x1b[31", "a"},
+		{"Trailing ESC", "a\x1b", "a"},
+		{"Control characters", "a\rb\x07c\x7fd\u009be", "abcde"},
+		{"Bidi overrides", "file\u202egnp.exe", "filegnp.exe"},
+		{"Invalid UTF-8", "a\xffb", "a�b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+
+	for _, expr := range invalid {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday, 2025-01-15 10:30:15
+	base := time.Date(2025, 1, 15, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"30 8 1 * *", time.Date(2025, 2, 1, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,20 * *", time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{"0 0 31 * fri", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			if got := s.Next(base); !got.Equal(tt.expected) {
+				t.Errorf("Next() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCronInvalidExpression(t *testing.T) {
+	msg := Cron("bogus", nil)()
+	if _, ok := msg.(ErrMsg); !ok {
+		t.Errorf("Expected ErrMsg for invalid expression, got %T", msg)
+	}
+}
+
+func TestAt(t *testing.T) {
+	t.Run("Fires at the given time", func(t *testing.T) {
+		target := time.Now().Add(20 * time.Millisecond)
+		msg, _ := runStream(t, At(target, func(now time.Time) Msg {
+			return testMsg{value: "fired"}
+		}))
+
+		if msg != (testMsg{value: "fired"}) {
+			t.Errorf("Expected fired message, got %v", msg)
+		}
+		if time.Now().Before(target) {
+			t.Error("At fired too early")
+		}
+	})
+
+	t.Run("Cancelled with the session", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan Msg, 1)
+		go func() {
+			done <- runCmd(ctx, At(time.Now().Add(time.Hour), nil), func(Msg) {})
+		}()
+
+		cancel()
+		select {
+		case msg := <-done:
+			if msg != nil {
+				t.Errorf("Expected nil after cancellation, got %v", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("At did not stop after cancellation")
+		}
+	})
+}
+
+// collectFor runs a stream command for the given duration and returns the
+// messages it sent
+func collectFor(cmd Cmd, d time.Duration) []Msg {
+	var mu sync.Mutex
+	var sent []Msg
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	runCmd(ctx, cmd, func(m Msg) {
+		mu.Lock()
+		sent = append(sent, m)
+		mu.Unlock()
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	return sent
+}
+
+func TestEvery(t *testing.T) {
+	sent := collectFor(Every(10*time.Millisecond, func(now time.Time) Msg {
+		return testMsg{value: "tick"}
+	}), 55*time.Millisecond)
+
+	if len(sent) < 3 {
+		t.Errorf("Expected at least 3 ticks, got %d", len(sent))
+	}
+	for _, m := range sent {
+		if m != (testMsg{value: "tick"}) {
+			t.Errorf("Unexpected message %v", m)
+		}
+	}
+
+	sent = collectFor(Every(10*time.Millisecond, nil), 25*time.Millisecond)
+	if len(sent) == 0 {
+		t.Fatal("Expected default tick messages")
+	}
+	if _, ok := sent[0].(TickMsg); !ok {
+		t.Errorf("Expected TickMsg, got %T", sent[0])
+	}
+}
+
+func TestIntervalCancel(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		collectFor(Interval("interval-test", 5*time.Millisecond, nil), time.Hour)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	Cancel("interval-test")
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Interval did not stop after Cancel")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"sync"
+)
+
+// sessionScope holds resources owned by a single session, such as outgoing
+// connections. Streams reach it through their context so commands from
+// different sessions never see each other's resources.
+type sessionScope struct {
+	mu    sync.Mutex
+	id    string
+	items map[interface{}]interface{}
+}
+
+// scopeKey is the context key for the session scope
+type scopeKey struct{}
+
+// withSessionScope returns a context carrying a new, empty session scope
+func withSessionScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &sessionScope{
+		items: make(map[interface{}]interface{}),
+	})
+}
+
+// scopeFrom returns the session scope carried by ctx, or nil if there is none
+func scopeFrom(ctx context.Context) *sessionScope {
+	s, _ := ctx.Value(scopeKey{}).(*sessionScope)
+	return s
+}
+
+// SessionID returns the ID of the session whose engine ctx belongs to, or ""
+// outside a session, e.g. in an engine created without one
+func SessionID(ctx context.Context) string {
+	s := scopeFrom(ctx)
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// setID records the ID of the session owning the scope
+func (s *sessionScope) setID(id string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = id
+}
+
+// get returns the resource stored under key
+func (s *sessionScope) get(key interface{}) (interface{}, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.items[key]
+	return v, ok
+}
+
+// swap stores v under key and returns the resource it replaced, if any
+func (s *sessionScope) swap(key, v interface{}) (interface{}, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.items[key]
+	s.items[key] = v
+	return old, ok
+}
+
+// remove deletes key, but only while it still refers to v
+func (s *sessionScope) remove(key, v interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items[key] == v {
+		delete(s.items, key)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrScreenshotUnavailable is reported when Screenshot is used without a
+// connected client to take it
+var ErrScreenshotUnavailable = errors.New("screenshot unavailable")
+
+// ErrScreenshotTimeout is reported when the client doesn't send a requested
+// screenshot in time
+var ErrScreenshotTimeout = errors.New("screenshot timed out")
+
+// screenshotTimeout is how long to wait for the client's screenshot
+var screenshotTimeout = 10 * time.Second
+
+// ScreenshotFormat is the image format of a screenshot
+type ScreenshotFormat string
+
+const (
+	ScreenshotPNG ScreenshotFormat = "png"
+	ScreenshotSVG ScreenshotFormat = "svg"
+)
+
+// ScreenshotMsg is sent in response to Screenshot, ScreenshotAs and
+// DownloadScreenshot
+type ScreenshotMsg struct {
+	Format   ScreenshotFormat
+	Data     []byte // The image
+	Filename string // The name it was downloaded as, if any
+	Err      error  // Set if the client couldn't take the screenshot
+}
+
+// screenshotRequestMsg asks the session's client for a screenshot
+type screenshotRequestMsg struct {
+	format   ScreenshotFormat
+	filename string
+}
+
+// Screenshot returns a command that asks the browser to rasterize the
+// terminal as a PNG, e.g. to attach to an alert. The component receives a
+// ScreenshotMsg with the image.
+func Screenshot() Cmd {
+	return ScreenshotAs(ScreenshotPNG)
+}
+
+// ScreenshotAs is like Screenshot, in the given format
+func ScreenshotAs(format ScreenshotFormat) Cmd {
+	return func() Msg {
+		return screenshotRequestMsg{format: format}
+	}
+}
+
+// DownloadScreenshot returns a command that has the browser save a
+// screenshot as filename, as SVG if it ends in ".svg" and otherwise PNG.
+// The component still receives a ScreenshotMsg.
+func DownloadScreenshot(filename string) Cmd {
+	format := ScreenshotPNG
+	if strings.HasSuffix(strings.ToLower(filename), ".svg") {
+		format = ScreenshotSVG
+	}
+	return func() Msg {
+		return screenshotRequestMsg{format: format, filename: filename}
+	}
+}
+
+// requestScreenshot passes a screenshot request to the session, whose reply
+// arrives as the result of a command. Without a session it returns an
+// error message for the component; otherwise nil.
+func (e *Engine) requestScreenshot(req screenshotRequestMsg) Msg {
+	if e.onScreenshot == nil {
+		return ErrMsg{Err: ErrScreenshotUnavailable, Source: "Screenshot"}
+	}
+	e.execute(e.onScreenshot(req))
+	return nil
+}
+
+// screenshot sends a screenshot request to the client and returns a command
+// waiting for the reply
+func (s *Session) screenshot(req screenshotRequestMsg) Cmd {
+	id := uuid.New().String()
+	reply := make(chan ScreenshotMsg, 1)
+	s.mu.Lock()
+	if s.screenshots == nil {
+		s.screenshots = make(map[string]chan ScreenshotMsg)
+	}
+	s.screenshots[id] = reply
+	s.mu.Unlock()
+
+	s.sendControl(ServerMessage{
+		Type: "screenshot",
+		Data: map[string]interface{}{
+			"id":       id,
+			"format":   string(req.format),
+			"filename": req.filename,
+		},
+	})
+
+	return func() Msg {
+		defer func() {
+			s.mu.Lock()
+			delete(s.screenshots, id)
+			s.mu.Unlock()
+		}()
+		msg := ScreenshotMsg{Format: req.format, Filename: req.filename}
+		select {
+		case msg = <-reply:
+		case <-time.After(screenshotTimeout):
+			msg.Err = ErrScreenshotTimeout
+		case <-s.done:
+			msg.Err = ErrScreenshotUnavailable
+		}
+		return msg
+	}
+}
+
+// receiveScreenshot hands a screenshot sent by the client to the command
+// waiting for it
+func (s *Session) receiveScreenshot(data map[string]interface{}) {
+	id, _ := data["id"].(string)
+	s.mu.RLock()
+	reply, ok := s.screenshots[id]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	format, _ := data["format"].(string)
+	filename, _ := data["filename"].(string)
+	msg := ScreenshotMsg{Format: ScreenshotFormat(format), Filename: filename}
+	if text, _ := data["error"].(string); text != "" {
+		msg.Err = fmt.Errorf("screenshot failed: %s", text)
+	} else {
+		encoded, _ := data["data"].(string)
+		image, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			msg.Err = fmt.Errorf("screenshot failed: %w", err)
+		}
+		msg.Data = image
+	}
+	select {
+	case reply <- msg:
+	default:
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// screenshotComponent asks for a screenshot when initialized and shows the
+// result
+type screenshotComponent struct {
+	cmd  Cmd
+	view string
+}
+
+func (c *screenshotComponent) Init() Cmd {
+	return c.cmd
+}
+
+func (c *screenshotComponent) Update(msg Msg) (Component, Cmd) {
+	if msg, ok := msg.(ScreenshotMsg); ok {
+		if msg.Err != nil {
+			c.view = "error: " + msg.Err.Error()
+		} else {
+			c.view = fmt.Sprintf("got %s %s as %s", msg.Format, msg.Data, msg.Filename)
+		}
+	}
+	return c, nil
+}
+
+func (c *screenshotComponent) View() string {
+	return c.view
+}
+
+func TestScreenshot(t *testing.T) {
+	// connect starts a session running comp and returns the client's end
+	connect := func(t *testing.T, comp Component) *websocket.Conn {
+		program := NewProgram(func() Component { return comp })
+		server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+		t.Cleanup(server.Close)
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs := make(chan ErrMsg, 1)
+				capture := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if err, ok := msg.(ErrMsg); ok {
+							errs <- err
+						}
+						return next(ctx, msg)
+					}
+				}
+				startEngine(t, &screenshotComponent{cmd: Screenshot()}, WithEngineMessageMiddleware(capture))
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrScreenshotUnavailable) {
+						t.Errorf("Expected ErrScreenshotUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Returns the image the client sends",
+			test: func(t *testing.T) {
+				conn := connect(t, &screenshotComponent{cmd: DownloadScreenshot("shot.svg")})
+				req := readUntil(t, conn, `"type":"screenshot"`)
+				if req.Data["format"] != "svg" || req.Data["filename"] != "shot.svg" {
+					t.Errorf("Unexpected request %+v", req.Data)
+				}
+				conn.WriteJSON(ClientMessage{Type: "screenshot", Data: map[string]interface{}{
+					"id":       req.Data["id"],
+					"format":   "svg",
+					"filename": "shot.svg",
+					"data":     base64.StdEncoding.EncodeToString([]byte("image")),
+				}})
+				readUntil(t, conn, "got svg image as shot.svg")
+			},
+		},
+		{
+			name: "Reports errors from the client",
+			test: func(t *testing.T) {
+				conn := connect(t, &screenshotComponent{cmd: Screenshot()})
+				req := readUntil(t, conn, `"type":"screenshot"`)
+				conn.WriteJSON(ClientMessage{Type: "screenshot", Data: map[string]interface{}{
+					"id":    req.Data["id"],
+					"error": "no canvas",
+				}})
+				readUntil(t, conn, "error: screenshot failed: no canvas")
+			},
+		},
+		{
+			name: "Times out without a reply",
+			test: func(t *testing.T) {
+				defer func(d time.Duration) { screenshotTimeout = d }(screenshotTimeout)
+				screenshotTimeout = 50 * time.Millisecond
+				conn := connect(t, &screenshotComponent{cmd: Screenshot()})
+				readUntil(t, conn, "error: "+ErrScreenshotTimeout.Error())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+// ScrollbackMsg is sent in response to ReadScrollback
+type ScrollbackMsg struct {
+	// Lines are the scrollback followed by the lines on screen, oldest
+	// first, with their styles as ANSI codes
+	Lines []string
+	// Screen is the index in Lines of the first line on screen
+	Screen int
+}
+
+// scrollbackRequestMsg asks the session for its scrollback
+type scrollbackRequestMsg struct{}
+
+// WithEngineScrollback keeps up to lines lines of views taller than the
+// screen that scrolled off its top
+func WithEngineScrollback(lines int) EngineOption {
+	return func(e *Engine) {
+		e.scrollbackLines = lines
+	}
+}
+
+// ReadScrollback returns a command that reads the session's scrollback,
+// e.g. to show it in a Pager, which widget.Pager's ShowScrollback does. The
+// component receives a ScrollbackMsg, which holds only the screen if the
+// program keeps no scrollback or there is no session.
+func ReadScrollback() Cmd {
+	return func() Msg {
+		return scrollbackRequestMsg{}
+	}
+}
+
+// readScrollback answers a scrollback request
+func (e *Engine) readScrollback() Msg {
+	if e.onScrollback == nil {
+		return ScrollbackMsg{}
+	}
+	return e.onScrollback()
+}
+
+// SetScrollback keeps up to lines lines that scroll off the top of the
+// screen when content is taller than it, or none if lines isn't positive
+func (sd *ScreenDiffer) SetScrollback(lines int) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.scrollbackLimit = max(0, lines)
+	if len(sd.scrollback) > sd.scrollbackLimit {
+		sd.scrollback = sd.scrollback[len(sd.scrollback)-sd.scrollbackLimit:]
+	}
+}
+
+// Scrollback returns the lines kept in the scrollback, oldest first, with
+// their styles as ANSI codes
+func (sd *ScreenDiffer) Scrollback() []string {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	lines := make([]string, len(sd.scrollback))
+	for i, line := range sd.scrollback {
+		lines[i] = renderCells(line)
+	}
+	return lines
+}
+
+// scrollbackMsg returns the scrollback and the screen last rendered
+func (sd *ScreenDiffer) scrollbackMsg() ScrollbackMsg {
+	msg := ScrollbackMsg{Lines: sd.Scrollback()}
+	msg.Screen = len(msg.Lines)
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if sd.oldScreen != nil {
+		for _, line := range sd.oldScreen.lines {
+			msg.Lines = append(msg.Lines, renderCells(line))
+		}
+	}
+	return msg
+}
+
+// keepOverflow adds the lines a frame scrolled off the screen to the
+// scrollback and returns it. Frames usually render the same content again
+// with more or fewer lines on top, so the lines the scrollback already
+// ends with are added once: a chat that renders its whole history adds
+// each message as it scrolls off, rather than the history every frame.
+func (sd *ScreenDiffer) keepOverflow(overflow []Line) []Line {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if len(overflow) == 0 || sameLines(overflow, sd.lastOverflow) {
+		return sd.scrollback
+	}
+	sd.lastOverflow = overflow
+
+	// Find the most lines the scrollback ends with that the overflow
+	// starts with
+	kept := 0
+	for n := min(len(sd.scrollback), len(overflow)); n > 0; n-- {
+		if sameLines(sd.scrollback[len(sd.scrollback)-n:], overflow[:n]) {
+			kept = n
+			break
+		}
+	}
+	scrollback := append(sd.scrollback, overflow[kept:]...)
+	if len(scrollback) > sd.scrollbackLimit {
+		scrollback = append([]Line(nil), scrollback[len(scrollback)-sd.scrollbackLimit:]...)
+	}
+	sd.scrollback = scrollback
+	return scrollback
+}
+
+// sameLines reports whether two runs of lines have the same text and
+// styles
+func sameLines(a, b []Line) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for y := range a {
+		if len(a[y]) != len(b[y]) {
+			return false
+		}
+		for x := range a[y] {
+			if a[y][x].Rune != b[y][x].Rune || a[y][x].Combining != b[y][x].Combining ||
+				!a[y][x].Style.Equal(b[y][x].Style) {
+				return false
+			}
+		}
+	}
+	return true
+}
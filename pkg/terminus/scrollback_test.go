@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScrollback(t *testing.T) {
+	// lines returns a view of count numbered lines
+	lines := func(count int) string {
+		rows := make([]string, count)
+		for i := range rows {
+			rows[i] = fmt.Sprintf("line %d", i+1)
+		}
+		return strings.Join(rows, "\n")
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Keeps nothing by default",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(10, 2)
+				differ.Update(lines(5))
+				if scrollback := differ.Scrollback(); len(scrollback) != 0 {
+					t.Errorf("Expected no scrollback, got %q", scrollback)
+				}
+			},
+		},
+		{
+			name: "Keeps the lines that scrolled off once",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(10, 2)
+				differ.SetScrollback(10)
+				differ.Update(lines(3))
+				differ.Update(lines(3))
+				differ.Update(lines(5))
+
+				expected := []string{"line 1", "line 2", "line 3"}
+				if scrollback := differ.Scrollback(); !reflect.DeepEqual(scrollback, expected) {
+					t.Errorf("Expected %q, got %q", expected, scrollback)
+				}
+			},
+		},
+		{
+			name: "Drops the oldest lines past the limit",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(10, 2)
+				differ.SetScrollback(2)
+				differ.Update(lines(6))
+
+				expected := []string{"line 3", "line 4"}
+				if scrollback := differ.Scrollback(); !reflect.DeepEqual(scrollback, expected) {
+					t.Errorf("Expected %q, got %q", expected, scrollback)
+				}
+			},
+		},
+		{
+			name: "Reads the scrollback and the screen",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(10, 2)
+				differ.SetScrollback(10)
+				differ.Update(lines(4))
+
+				msg := differ.scrollbackMsg()
+				if msg.Screen != 2 || len(msg.Lines) != 4 || msg.Lines[2] != "line 3" {
+					t.Errorf("Expected 2 lines of scrollback then the screen, got %d in %q", msg.Screen, msg.Lines)
+				}
+			},
+		},
+		{
+			name: "Reads an empty scrollback without a session",
+			test: func(t *testing.T) {
+				e := NewEngine(&testComponent{})
+				msg, ok := e.readScrollback().(ScrollbackMsg)
+				if !ok || len(msg.Lines) != 0 {
+					t.Errorf("Expected an empty ScrollbackMsg, got %#v", msg)
+				}
+			},
+		},
+		{
+			name: "Scrolls copy mode into the scrollback",
+			test: func(t *testing.T) {
+				differ := NewScreenDiffer(30, 2)
+				differ.SetScrollback(10)
+				differ.Update(lines(4))
+
+				c := newCopyMode(DefaultCopyModeKey)
+				c.handle(DefaultCopyModeKey)
+				screen := NewScreen(30, 2)
+				screen.scrollback = differ.oldScreen.scrollback
+				screen.RenderFromString("line 3\nline 4")
+				c.draw(screen)
+
+				c.handle(KeyMsg{Type: KeyUp})
+				c.handle(KeyMsg{Type: KeyUp})
+				screen = NewScreen(30, 2)
+				c.draw(screen)
+				if row := strings.Split(screen.ToString(), "\n")[0]; !strings.HasPrefix(row, "line 1") ||
+					!strings.Contains(row, "[2/2]") {
+					t.Errorf("Expected the top of the scrollback, got %q", row)
+				}
+
+				if _, copied := c.handle(KeyMsg{Type: KeyEnter}); copied != "line 1" {
+					t.Errorf("Expected the first line of the scrollback, got %q", copied)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
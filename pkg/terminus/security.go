@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultContentSecurityPolicy is the Content-Security-Policy sent with every
+// response unless WithContentSecurityPolicy replaces it. It lets pages load
+// scripts, styles and images only from the program itself and connect only
+// back to it. The client styles text with inline style attributes, so those
+// are allowed; inline scripts are not.
+const DefaultContentSecurityPolicy = "default-src 'self'; script-src 'self'; " +
+	"style-src 'self' 'unsafe-inline'; img-src 'self' data: blob:; font-src 'self' data:; " +
+	"connect-src 'self'; object-src 'none'; base-uri 'self'; form-action 'self'"
+
+// WithContentSecurityPolicy replaces DefaultContentSecurityPolicy, e.g. to
+// allow a CDN. The frame-ancestors directive is added from
+// WithFrameAncestors unless policy has its own. An empty policy sends only
+// frame-ancestors.
+func WithContentSecurityPolicy(policy string) ProgramOption {
+	return func(p *Program) {
+		p.csp = &policy
+	}
+}
+
+// WithFrameAncestors sets the origins whose pages may embed the program's
+// pages in frames, e.g. "https://intranet.example.com", or "*" for any. By
+// default only the program's own pages may. With no origins, the pages can't
+// be framed at all.
+func WithFrameAncestors(origins ...string) ProgramOption {
+	return func(p *Program) {
+		p.frameAncestors = append([]string{}, origins...)
+	}
+}
+
+// WithAllowedOrigins sets the origins, besides the program's own, whose pages
+// may connect to sessions, e.g. a site that embeds a terminal with
+// Terminus.mount. "*" allows any origin. Connections from other sites are
+// refused, so they can't use a signed-in user's cookies to drive a session.
+func WithAllowedOrigins(origins ...string) ProgramOption {
+	return func(p *Program) {
+		p.allowedOrigins = append(p.allowedOrigins, origins...)
+	}
+}
+
+// secureHeaders wraps next so responses carry the program's
+// Content-Security-Policy and other security headers
+func (p *Program) secureHeaders(next http.Handler) http.Handler {
+	policy := DefaultContentSecurityPolicy
+	if p.csp != nil {
+		policy = *p.csp
+	}
+	frameOptions := ""
+	if !strings.Contains(policy, "frame-ancestors") {
+		ancestors := "'self'"
+		switch {
+		case p.frameAncestors == nil:
+			frameOptions = "SAMEORIGIN"
+		case len(p.frameAncestors) == 0:
+			ancestors, frameOptions = "'none'", "DENY"
+		default:
+			// X-Frame-Options can't list origins; browsers that ignore
+			// frame-ancestors allow any
+			ancestors += " " + strings.Join(p.frameAncestors, " ")
+		}
+		policy = strings.TrimSuffix(strings.TrimSpace(policy), ";")
+		if policy != "" {
+			policy += "; "
+		}
+		policy += "frame-ancestors " + ancestors
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("Content-Security-Policy", policy)
+		if frameOptions != "" {
+			header.Set("X-Frame-Options", frameOptions)
+		}
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Referrer-Policy", "same-origin")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkOrigin reports whether a request to connect to a session comes from
+// the program's own pages or an allowed origin. Browsers always say where a
+// connection comes from, so this is what protects sessions from cross-site
+// request forgery. Clients other than browsers send no Origin and are
+// allowed.
+func (p *Program) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if p.allowedOrigin(origin) {
+		return true
+	}
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "same-origin":
+		return true
+	case "":
+		// Browsers without Fetch Metadata
+	default:
+		return false
+	}
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+// allowedOrigin reports whether origin was allowed with WithAllowedOrigins
+func (p *Program) allowedOrigin(origin string) bool {
+	for _, allowed := range p.allowedOrigins {
+		if allowed == "*" || origin != "" && strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []ProgramOption
+		csp          string
+		frameOptions string
+	}{
+		{
+			name:         "Defaults",
+			csp:          DefaultContentSecurityPolicy + "; frame-ancestors 'self'",
+			frameOptions: "SAMEORIGIN",
+		},
+		{
+			name: "Frame ancestors",
+			opts: []ProgramOption{WithFrameAncestors("https://intranet.example.com")},
+			csp:  DefaultContentSecurityPolicy + "; frame-ancestors 'self' https://intranet.example.com",
+		},
+		{
+			name:         "No frame ancestors",
+			opts:         []ProgramOption{WithFrameAncestors()},
+			csp:          DefaultContentSecurityPolicy + "; frame-ancestors 'none'",
+			frameOptions: "DENY",
+		},
+		{
+			name:         "Custom policy",
+			opts:         []ProgramOption{WithContentSecurityPolicy("default-src 'self' https://cdn.example.com;")},
+			csp:          "default-src 'self' https://cdn.example.com; frame-ancestors 'self'",
+			frameOptions: "SAMEORIGIN",
+		},
+		{
+			name: "Custom policy with frame ancestors",
+			opts: []ProgramOption{WithContentSecurityPolicy("frame-ancestors *")},
+			csp:  "frame-ancestors *",
+		},
+		{
+			name:         "Empty policy",
+			opts:         []ProgramOption{WithContentSecurityPolicy("")},
+			csp:          "frame-ancestors 'self'",
+			frameOptions: "SAMEORIGIN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := NewProgram(func() Component { return &mockProgramComponent{} }, tt.opts...)
+			handler, err := program.routes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			header := w.Header()
+			if got := header.Get("Content-Security-Policy"); got != tt.csp {
+				t.Errorf("Expected policy %q, got %q", tt.csp, got)
+			}
+			if got := header.Get("X-Frame-Options"); got != tt.frameOptions {
+				t.Errorf("Expected X-Frame-Options %q, got %q", tt.frameOptions, got)
+			}
+			if header.Get("X-Content-Type-Options") != "nosniff" {
+				t.Error("Expected X-Content-Type-Options: nosniff")
+			}
+		})
+	}
+}
+
+func TestAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ProgramOption
+		headers map[string]string
+		allowed bool
+	}{
+		{
+			name:    "No origin",
+			allowed: true,
+		},
+		{
+			name:    "Same origin",
+			headers: map[string]string{"Origin": "http://{host}"},
+			allowed: true,
+		},
+		{
+			name:    "Other origin",
+			headers: map[string]string{"Origin": "https://evil.example.com"},
+		},
+		{
+			name:    "Opaque origin",
+			headers: map[string]string{"Origin": "null"},
+		},
+		{
+			name:    "Allowed origin",
+			opts:    []ProgramOption{WithAllowedOrigins("https://app.example.com/")},
+			headers: map[string]string{"Origin": "https://app.example.com", "Sec-Fetch-Site": "cross-site"},
+			allowed: true,
+		},
+		{
+			name:    "Any origin",
+			opts:    []ProgramOption{WithAllowedOrigins("*")},
+			headers: map[string]string{"Origin": "https://evil.example.com"},
+			allowed: true,
+		},
+		{
+			name:    "Same origin behind a proxy",
+			headers: map[string]string{"Origin": "https://public.example.com", "Sec-Fetch-Site": "same-origin"},
+			allowed: true,
+		},
+		{
+			name:    "Same-site request",
+			headers: map[string]string{"Origin": "http://{host}", "Sec-Fetch-Site": "same-site"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := NewProgram(func() Component { return &mockProgramComponent{} }, tt.opts...)
+			defer program.Stop()
+			handler, err := program.routes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			host := strings.TrimPrefix(server.URL, "http://")
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, strings.ReplaceAll(value, "{host}", host))
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial("ws://"+host+"/ws", header)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("Expected the connection to be accepted: %v", err)
+				}
+				conn.Close()
+				return
+			}
+			if err == nil {
+				conn.Close()
+				t.Fatal("Expected the connection to be refused")
+			}
+			if resp == nil || resp.StatusCode != http.StatusForbidden {
+				t.Errorf("Expected 403 Forbidden, got %v", resp)
+			}
+		})
+	}
+}
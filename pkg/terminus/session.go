@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,16 +28,43 @@ import (
 // Session represents a single connected client
 type Session struct {
 	id        string
-	conn      *websocket.Conn
+	current   *connection // The client's connection, nil while it is away
 	component Component
 	engine    *Engine
 	
 	// Message channels
 	incoming chan []byte
-	outgoing chan []byte
+	outgoing *sendQueue
+	done     chan struct{} // Closed when the session closes
 	
 	// Rendering
 	screenDiffer *ScreenDiffer
+	recorder     *Recorder
+	lastView     string
+	lastSurfaces []Surface
+	
+	// Sharing
+	shareToken  string
+	inviteToken string
+	observers   map[*observer]struct{}
+	joined      int // Collaborators that have joined, for naming and colors
+	
+	// Screenshots requested from the client, by request ID
+	screenshots map[string]chan ScreenshotMsg
+	
+	// Reconnection
+	runCtx      context.Context
+	pumps       sync.WaitGroup
+	resumeToken string
+	lostAt      time.Time
+	offline     *time.Timer
+	
+	// Client environment
+	environment  EnvironmentMsg
+	colorProfile ColorProfile
+	glyphWidths  map[rune]int // Reported by the client; see GlyphWidths
+	clientErrors clientErrorLimiter
+	debouncer    *resizeDebouncer
 	
 	// State
 	mu       sync.RWMutex
@@ -46,23 +74,62 @@ type Session struct {
 	height   int
 }
 
-// NewSession creates a new session
-func NewSession(id string, conn *websocket.Conn, component Component) *Session {
+// NewSession creates a new session for a WebSocket connection
+func NewSession(id string, conn *websocket.Conn, component Component, opts ...EngineOption) *Session {
+	if conn == nil {
+		return NewTransportSession(id, nil, component, opts...)
+	}
+	return NewTransportSession(id, NewWebSocketConn(conn), component, opts...)
+}
+
+// NewTransportSession creates a new session for a connection over any
+// Transport
+func NewTransportSession(id string, conn Conn, component Component, opts ...EngineOption) *Session {
 	s := &Session{
 		id:           id,
-		conn:         conn,
 		component:    component,
 		incoming:     make(chan []byte, 100),
-		outgoing:     make(chan []byte, 100),
+		done:         make(chan struct{}),
 		width:        80,  // Default dimensions
 		height:       24,
 		screenDiffer: NewScreenDiffer(80, 24),
 	}
+	if conn != nil {
+		s.current = newConnection(conn)
+	}
 	
 	// Create engine with callbacks
-	s.engine = NewEngine(component)
+	s.engine = NewEngine(component, opts...)
+	s.outgoing = newSendQueue(s.engine.maxPendingFrames)
+	s.colorProfile = s.engine.clientColorProfile(EnvironmentMsg{})
 	s.engine.SetRenderCallback(s.handleRender)
 	s.engine.SetQuitCallback(s.handleQuit)
+	s.engine.setSessionID(id)
+	s.debouncer = &resizeDebouncer{delay: s.engine.resizeDebounce}
+	s.engine.onShare = func(collaborative bool) string {
+		if collaborative {
+			return s.Invite()
+		}
+		return s.Share()
+	}
+	s.engine.onScreenshot = s.screenshot
+	s.engine.onScrollback = s.screenDiffer.scrollbackMsg
+	s.screenDiffer.SetScrollback(s.engine.scrollbackLines)
+	s.engine.toClient = s.sendControl
+	s.engine.emitToClient = s.sendEncoded
+	s.screenDiffer.SetTabWidth(s.engine.tabWidth)
+	if s.engine.debug != nil {
+		s.engine.debug.sessionID = id
+		s.engine.debug.width, s.engine.debug.height = s.width, s.height
+	}
+	s.screenDiffer.SetOverlay(s.engine.drawOverlays)
+	if dir := s.engine.recordDir; dir != "" {
+		recorder, err := createRecording(dir, id, s.width, s.height)
+		if err != nil {
+			fmt.Printf("Failed to start recording for session %s: %v\n", id, err)
+		}
+		s.recorder = recorder
+	}
 	
 	return s
 }
@@ -72,6 +139,31 @@ func (s *Session) ID() string {
 	return s.id
 }
 
+// Environment returns the client's environment, as last sent to the
+// component in an EnvironmentMsg
+func (s *Session) Environment() EnvironmentMsg {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.environment
+}
+
+// Metrics returns a snapshot of the session's command worker pool
+func (s *Session) Metrics() WorkerPoolMetrics {
+	return s.engine.Metrics()
+}
+
+// PendingFrames returns the number of rendered frames waiting to be sent to
+// the client
+func (s *Session) PendingFrames() int {
+	return s.outgoing.pending()
+}
+
+// DroppedFrames returns the number of rendered frames the client was too
+// slow to receive, and that were replaced by a later frame
+func (s *Session) DroppedFrames() int {
+	return s.outgoing.droppedFrames()
+}
+
 // Run starts the session
 func (s *Session) Run(ctx context.Context) {
 	defer s.Close()
@@ -83,34 +175,31 @@ func (s *Session) Run(ctx context.Context) {
 	}
 	defer s.engine.Stop()
 	
-	// Start goroutines
-	var wg sync.WaitGroup
-	
-	// WebSocket reader
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		s.readPump()
-	}()
-	
-	// WebSocket writer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		s.writePump(ctx)
-	}()
-	
 	// Message processor
+	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		s.processMessages(ctx)
 	}()
 	
+	// Connection reader and writer
+	s.mu.Lock()
+	s.runCtx = ctx
+	current := s.current
+	s.mu.Unlock()
+	if current != nil {
+		s.attach(current)
+	}
+	
 	// Wait for context cancellation or session close
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case <-s.done:
+	}
 	s.Close()
 	wg.Wait()
+	s.pumps.Wait()
 }
 
 // Close closes the session
@@ -118,34 +207,37 @@ func (s *Session) Close() {
 	s.closeOnce.Do(func() {
 		s.mu.Lock()
 		s.closed = true
+		current := s.current
+		if s.offline != nil {
+			s.offline.Stop()
+		}
 		s.mu.Unlock()
 		
-		close(s.incoming)
-		close(s.outgoing)
-		if s.conn != nil {
-			s.conn.Close()
+		close(s.done)
+		s.outgoing.close()
+		s.closeObservers()
+		if current != nil {
+			current.close()
+		}
+		if s.recorder != nil {
+			s.recorder.Close()
 		}
 	})
 }
 
-// readPump reads messages from the WebSocket connection
-func (s *Session) readPump() {
-	defer s.Close()
-	
-	s.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	s.conn.SetPongHandler(func(string) error {
-		s.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
-	})
+// readPump reads messages from a connection until it is lost
+func (s *Session) readPump(c *connection) {
+	defer s.connectionLost(c)
 	
 	for {
-		_, message, err := s.conn.ReadMessage()
+		message, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				fmt.Printf("WebSocket error for session %s: %v\n", s.id, err)
+				fmt.Printf("Connection error for session %s: %v\n", s.id, err)
 			}
 			break
 		}
+		c.touch()
 		
 		s.mu.RLock()
 		closed := s.closed
@@ -155,38 +247,65 @@ func (s *Session) readPump() {
 			break
 		}
 		
+		// Input is never dropped; a full buffer holds up reading instead
 		select {
 		case s.incoming <- message:
-		default:
-			fmt.Printf("Incoming message buffer full for session %s\n", s.id)
+		case <-s.done:
+			return
 		}
 	}
 }
 
-// writePump writes messages to the WebSocket connection
-func (s *Session) writePump(ctx context.Context) {
-	ticker := time.NewTicker(54 * time.Second)
+// writePump writes messages to a connection until it is lost. A failed
+// write, or a client that stops answering pings, closes the connection, so
+// the reader notices it is lost.
+func (s *Session) writePump(ctx context.Context, c *connection) {
+	ticker := time.NewTicker(s.engine.heartbeatInterval)
 	defer ticker.Stop()
 	
 	for {
 		select {
-		case message, ok := <-s.outgoing:
-			s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				s.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
+		case <-s.outgoing.ready:
+			// Whatever was queued before the queue closed is sent, even
+			// if it was queued while the queues were being emptied
+			closed := s.outgoing.isClosed()
+			for {
+				message, ok := s.outgoing.popControl()
+				if !ok {
+					break
+				}
+				if err := c.conn.WriteMessage(message, false); err != nil {
+					c.close()
+					return
+				}
 			}
-			
-			if err := s.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			for {
+				message, ok := s.outgoing.pop()
+				if !ok {
+					break
+				}
+				if err := c.conn.WriteMessage(message, c.binary); err != nil {
+					c.close()
+					return
+				}
+			}
+			if closed {
 				return
 			}
 			
 		case <-ticker.C:
-			s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := s.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if !c.alive(s.engine.heartbeatTimeout) {
+				fmt.Printf("Client of session %s stopped responding\n", s.id)
+				c.close()
+				return
+			}
+			if err := c.conn.Ping(); err != nil {
+				c.close()
 				return
 			}
 			
+		case <-c.lost:
+			return
 		case <-ctx.Done():
 			return
 		}
@@ -197,10 +316,7 @@ func (s *Session) writePump(ctx context.Context) {
 func (s *Session) processMessages(ctx context.Context) {
 	for {
 		select {
-		case message, ok := <-s.incoming:
-			if !ok {
-				return
-			}
+		case message := <-s.incoming:
 			
 			// Parse message
 			var msg ClientMessage
@@ -210,11 +326,16 @@ func (s *Session) processMessages(ctx context.Context) {
 			}
 			
 			// Convert to terminus message
-			terminusMsg := s.clientToTerminusMessage(msg)
-			if terminusMsg != nil {
+			switch terminusMsg := s.clientToTerminusMessage(msg).(type) {
+			case nil:
+			case WindowSizeMsg:
+				s.resize(terminusMsg)
+			default:
 				s.engine.SendMessage(terminusMsg)
 			}
 			
+		case <-s.done:
+			return
 		case <-ctx.Done():
 			return
 		}
@@ -223,70 +344,199 @@ func (s *Session) processMessages(ctx context.Context) {
 
 // handleRender is called when the engine renders a new view
 func (s *Session) handleRender(view string) {
-	s.mu.RLock()
+	surfaces := s.engine.viewSurfaces()
+	s.mu.Lock()
 	width := s.width
 	height := s.height
-	s.mu.RUnlock()
+	if width < s.engine.minWidth || height < s.engine.minHeight {
+		view = tooSmallView(width, height, s.engine.minWidth, s.engine.minHeight)
+		surfaces = nil
+	}
+	s.lastView, s.lastSurfaces = view, surfaces
+	height = s.screenHeight(view, height)
+	profile := s.colorProfile
+	binary := s.current != nil && s.current.binary
+	s.mu.Unlock()
 	
 	// Ensure screen differ has correct dimensions
 	s.screenDiffer.Resize(width, height)
+	s.screenDiffer.SetColorProfile(profile)
+	s.screenDiffer.SetWrap(s.engine.viewWrap())
+	s.screenDiffer.SetSurfaces(surfaces)
+	
+	if s.recorder != nil {
+		if err := s.recorder.Frame(view); err != nil {
+			fmt.Printf("Failed to record frame for session %s: %v\n", s.id, err)
+		}
+	}
 	
 	// Compute diff operations
-	ops := s.screenDiffer.Update(view)
+	var ops []DiffOp
+	s.engine.profile.measure(PhaseDiff, func() {
+		ops = s.screenDiffer.Update(view)
+	})
+	
+	s.send(ops, height, binary, func() []byte {
+		return s.redraw(view, surfaces, width, height, profile, binary)
+	})
+}
+
+// encodeFrame converts diff operations into the messages that draw a frame:
+// a single message in the binary encoding, otherwise a JSON message per
+// operation. A full redraw, such as the first frame, is a single render
+// message.
+func (s *Session) encodeFrame(ops []DiffOp, height int, binary bool) [][]byte {
+	if binary {
+		if data := encodeBinaryFrame(ops, height); len(data) > 0 {
+			return [][]byte{data}
+		}
+		return nil
+	}
+	if len(ops) > 0 && ops[0].Type == DiffOpClear {
+		data, err := redrawMessage(ops, height)
+		if err != nil {
+			fmt.Printf("Failed to marshal render message for session %s: %v\n", s.id, err)
+			return nil
+		}
+		return [][]byte{data}
+	}
 	
-	// Convert diff ops to render commands
+	var frame [][]byte
 	for _, op := range ops {
-		var msg ServerMessage
-		
-		switch op.Type {
-		case DiffOpClear:
-			msg = ServerMessage{
-				Type: "clear",
-				Data: map[string]interface{}{},
-			}
-			
-		case DiffOpUpdateLine:
-			lineOp := op.Data.(UpdateLineOp)
-			msg = ServerMessage{
-				Type: "updateLine",
-				Data: map[string]interface{}{
-					"y":       lineOp.Y,
-					"content": lineOp.Content,
-				},
-			}
-			
-		case DiffOpSetCell:
-			cellOp := op.Data.(SetCellOp)
-			msg = ServerMessage{
-				Type: "setCell",
-				Data: map[string]interface{}{
-					"x":     cellOp.X,
-					"y":     cellOp.Y,
-					"rune":  cellOp.Rune,
-					"style": cellOp.Style,
-				},
-			}
-			
-		default:
+		msg, ok := renderMessage(op)
+		if !ok {
 			continue
 		}
-		
 		data, err := json.Marshal(msg)
 		if err != nil {
 			fmt.Printf("Failed to marshal render message for session %s: %v\n", s.id, err)
 			continue
 		}
+		frame = append(frame, data)
+	}
+	return frame
+}
+
+// newScreenDiffer returns a differ that renders views as the session's own
+// does, for a full redraw in the given color profile
+func (s *Session) newScreenDiffer(width, height int, profile ColorProfile) *ScreenDiffer {
+	differ := NewScreenDiffer(width, height)
+	differ.SetTabWidth(s.engine.tabWidth)
+	differ.SetWrap(s.engine.viewWrap())
+	differ.SetOverlay(s.engine.drawOverlays)
+	differ.SetColorProfile(profile)
+	return differ
+}
+
+// redraw encodes a full redraw of view and its surfaces, for a client that
+// has fallen behind. It is called from the send queue while s.mu is held, so
+// it takes everything it needs from the session as arguments.
+func (s *Session) redraw(view string, surfaces []Surface, width, height int, profile ColorProfile, binary bool) []byte {
+	differ := s.newScreenDiffer(width, height, profile)
+	differ.SetSurfaces(surfaces)
+	ops := differ.Update(view)
+	if binary {
+		return encodeBinaryFrame(ops, height)
+	}
+	data, err := redrawMessage(ops, height)
+	if err != nil {
+		fmt.Printf("Failed to marshal render message for session %s: %v\n", s.id, err)
+	}
+	return data
+}
+
+// send encodes the diff operations of a frame and queues it for the client
+// and any observers, which always use JSON. Frames rendered after the
+// session is closed are dropped.
+func (s *Session) send(ops []DiffOp, height int, binary bool, redraw func() []byte) {
+	var frame [][]byte
+	s.engine.profile.measure(PhaseSerialize, func() {
+		frame = s.encodeFrame(ops, height, binary)
+	})
+	
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed || len(frame) == 0 {
+		return
+	}
+	
+	s.outgoing.push(frame, redraw)
+	if len(s.observers) == 0 {
+		return
+	}
+	if binary {
+		frame = s.encodeFrame(ops, height, false)
+	}
+	s.broadcast(frame)
+}
+
+// sendControl queues a message for the client ahead of any frames, reporting
+// false if the session is closed
+func (s *Session) sendControl(msg ServerMessage) bool {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Printf("Failed to marshal %s message for session %s: %v\n", msg.Type, s.id, err)
+		return false
+	}
+	return s.pushControl(data)
+}
+
+// pushControl queues an encoded control message for the client, reporting
+// false if the session is closed
+func (s *Session) pushControl(data []byte) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+	s.outgoing.pushControl(data)
+	return true
+}
+
+// renderMessage converts a diff operation into a message for the client
+func renderMessage(op DiffOp) (ServerMessage, bool) {
+	switch op.Type {
+	case DiffOpClear:
+		return ServerMessage{
+			Type: "clear",
+			Data: map[string]interface{}{},
+		}, true
 		
-		select {
-		case s.outgoing <- data:
-		default:
-			fmt.Printf("Outgoing message buffer full for session %s\n", s.id)
-		}
+	case DiffOpUpdateLine:
+		lineOp := op.Data.(UpdateLineOp)
+		return ServerMessage{
+			Type: "updateLine",
+			Data: map[string]interface{}{
+				"y":       lineOp.Y,
+				"content": lineOp.Content,
+			},
+		}, true
+		
+	case DiffOpSetCell:
+		cellOp := op.Data.(SetCellOp)
+		return ServerMessage{
+			Type: "setCell",
+			Data: map[string]interface{}{
+				"x":     cellOp.X,
+				"y":     cellOp.Y,
+				"rune":  cellOp.Rune,
+				"style": cellOp.Style,
+			},
+		}, true
 	}
+	return ServerMessage{}, false
 }
 
-// handleQuit is called when the engine quits
+// handleQuit ends the session. A component that quit has its exit screen
+// sent before the connection closes, and the client told not to reconnect.
 func (s *Session) handleQuit() {
+	if code, quit := s.ExitCode(); quit {
+		s.sendControl(ServerMessage{
+			Type: "exit",
+			Data: map[string]interface{}{"code": code},
+		})
+		s.flush(exitFlushTimeout)
+	}
 	s.Close()
 }
 
@@ -294,44 +544,54 @@ func (s *Session) handleQuit() {
 func (s *Session) clientToTerminusMessage(msg ClientMessage) Msg {
 	switch msg.Type {
 	case "key":
-		if keyData, ok := msg.Data.(map[string]interface{}); ok {
-			keyType, _ := keyData["keyType"].(string)
-			
-			// Handle different key types
-			switch keyType {
-			case "runes":
-				if runesData, ok := keyData["runes"].([]interface{}); ok {
-					runes := make([]rune, 0, len(runesData))
-					for _, r := range runesData {
-						if str, ok := r.(string); ok && len(str) > 0 {
-							// Only take the first character from each string
-							// Client sends individual characters as separate strings
-							runes = append(runes, []rune(str)[0])
-						}
-					}
-					return KeyMsg{Type: KeyRunes, Runes: runes}
-				}
-			case "enter":
-				return KeyMsg{Type: KeyEnter}
-			case "space":
-				return KeyMsg{Type: KeySpace}
-			case "backspace":
-				return KeyMsg{Type: KeyBackspace}
-			case "tab":
-				return KeyMsg{Type: KeyTab}
-			case "escape":
-				return KeyMsg{Type: KeyEsc}
-			case "up":
-				return KeyMsg{Type: KeyUp}
-			case "down":
-				return KeyMsg{Type: KeyDown}
-			case "left":
-				return KeyMsg{Type: KeyLeft}
-			case "right":
-				return KeyMsg{Type: KeyRight}
-			case "ctrl+c":
-				return KeyMsg{Type: KeyCtrlC}
-			}
+		if key, ok := keyFromMessage(msg); ok {
+			return key
+		}
+		
+	case "screenshot":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			s.receiveScreenshot(data)
+		}
+		
+	case "notification":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			return notificationEventFromClient(data)
+		}
+		
+	case "upload":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			return uploadFromClient(data)
+		}
+		
+	case "error":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			s.reportClientError(data)
+		}
+		
+	case "glyphs":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			return s.glyphWidthsFromClient(data)
+		}
+		
+	case "visibility":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			visible, _ := data["visible"].(bool)
+			return VisibilityMsg{Visible: visible}
+		}
+		
+	case "custom":
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			return clientMsgFromClient(data)
+		}
+		
+	case "environment":
+		if envData, ok := msg.Data.(map[string]interface{}); ok {
+			env := environmentFromClient(envData)
+			s.mu.Lock()
+			s.environment = env
+			s.colorProfile = s.engine.clientColorProfile(env)
+			s.mu.Unlock()
+			return env
 		}
 		
 	case "resize":
@@ -346,18 +606,121 @@ func (s *Session) clientToTerminusMessage(msg ClientMessage) Msg {
 			s.mu.Unlock()
 			
 			// Update screen differ
-			s.screenDiffer.Resize(int(width), int(height))
+			if s.screenDiffer != nil {
+				s.screenDiffer.Resize(int(width), int(height))
+			}
+			if s.recorder != nil {
+				s.recorder.Resize(int(width), int(height))
+			}
 			
 			return WindowSizeMsg{
 				Width:  int(width),
 				Height: int(height),
 			}
 		}
+		
+	default:
+		return s.decodeClientMessage(msg)
 	}
 	
 	return nil
 }
 
+// environmentFromClient converts the environment sent by the client
+func environmentFromClient(data map[string]interface{}) EnvironmentMsg {
+	env := EnvironmentMsg{}
+	env.Locale, _ = data["locale"].(string)
+	env.TimeZone, _ = data["timeZone"].(string)
+	env.UserAgent, _ = data["userAgent"].(string)
+	env.ReducedMotion, _ = data["reducedMotion"].(bool)
+	env.Inline, _ = data["inline"].(bool)
+	if offset, ok := data["utcOffset"].(float64); ok {
+		env.UTCOffset = time.Duration(offset) * time.Minute
+	}
+	if depth, ok := data["colorDepth"].(float64); ok {
+		env.ColorDepth = int(depth)
+	}
+	return env
+}
+
+// keyFromMessage converts a key message from the client, including the
+// modifiers sent alongside the key, e.g. Ctrl+Up
+func keyFromMessage(msg ClientMessage) (KeyMsg, bool) {
+	keyData, ok := msg.Data.(map[string]interface{})
+	if !ok {
+		return KeyMsg{}, false
+	}
+	key, ok := keyFromClient(keyData)
+	if !ok {
+		return KeyMsg{}, false
+	}
+	if ctrl, _ := keyData["ctrl"].(bool); ctrl {
+		key.Ctrl = true
+	}
+	if alt, _ := keyData["alt"].(bool); alt {
+		key.Alt = true
+	}
+	if shift, _ := keyData["shift"].(bool); shift {
+		key.Shift = true
+	}
+	return key, true
+}
+
+// keyFromClient converts the key data sent by the client into a KeyMsg
+func keyFromClient(keyData map[string]interface{}) (KeyMsg, bool) {
+	keyType, _ := keyData["keyType"].(string)
+	
+	// Handle different key types
+	switch keyType {
+	case "runes":
+		if runesData, ok := keyData["runes"].([]interface{}); ok {
+			runes := make([]rune, 0, len(runesData))
+			for _, r := range runesData {
+				if str, ok := r.(string); ok && len(str) > 0 {
+					// Only take the first character from each string
+					// Client sends individual characters as separate strings
+					runes = append(runes, []rune(str)[0])
+				}
+			}
+			return KeyMsg{Type: KeyRunes, Runes: runes}, true
+		}
+	case "enter":
+		return KeyMsg{Type: KeyEnter}, true
+	case "space":
+		return KeyMsg{Type: KeySpace}, true
+	case "backspace":
+		return KeyMsg{Type: KeyBackspace}, true
+	case "tab":
+		return KeyMsg{Type: KeyTab}, true
+	case "escape":
+		return KeyMsg{Type: KeyEsc}, true
+	case "up":
+		return KeyMsg{Type: KeyUp}, true
+	case "down":
+		return KeyMsg{Type: KeyDown}, true
+	case "left":
+		return KeyMsg{Type: KeyLeft}, true
+	case "right":
+		return KeyMsg{Type: KeyRight}, true
+	case "ctrl+c":
+		return KeyMsg{Type: KeyCtrlC}, true
+	case "ctrl+d":
+		return KeyMsg{Type: KeyCtrlD}, true
+	case "ctrl+r":
+		return KeyMsg{Type: KeyCtrlR}, true
+	case "ctrl+s":
+		return KeyMsg{Type: KeyCtrlS}, true
+	case "ctrl+z":
+		return KeyMsg{Type: KeyCtrlZ}, true
+	}
+
+	// Other Ctrl+letter chords arrive as the letter with Ctrl set
+	if letter, ok := strings.CutPrefix(keyType, "ctrl+"); ok && len(letter) == 1 {
+		return KeyMsg{Type: KeyRunes, Runes: []rune(letter), Ctrl: true}, true
+	}
+	return KeyMsg{}, false
+}
+
 // ClientMessage represents a message from the client
 type ClientMessage struct {
 	Type string      `json:"type"`
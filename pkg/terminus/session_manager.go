@@ -35,10 +35,19 @@ func NewSessionManager() *SessionManager {
 	}
 }
 
-// CreateSession creates a new session
-func (sm *SessionManager) CreateSession(conn *websocket.Conn, component Component) *Session {
+// CreateSession creates a new session for a WebSocket connection
+func (sm *SessionManager) CreateSession(conn *websocket.Conn, component Component, opts ...EngineOption) *Session {
+	if conn == nil {
+		return sm.CreateTransportSession(nil, component, opts...)
+	}
+	return sm.CreateTransportSession(NewWebSocketConn(conn), component, opts...)
+}
+
+// CreateTransportSession creates a new session for a connection over any
+// Transport
+func (sm *SessionManager) CreateTransportSession(conn Conn, component Component, opts ...EngineOption) *Session {
 	id := uuid.New().String()
-	session := NewSession(id, conn, component)
+	session := NewTransportSession(id, conn, component, opts...)
 	
 	sm.mu.Lock()
 	sm.sessions[id] = session
@@ -64,6 +73,33 @@ func (sm *SessionManager) GetSession(id string) *Session {
 	return sm.sessions[id]
 }
 
+// GetSharedSession retrieves a session by its share token
+func (sm *SessionManager) GetSharedSession(token string) *Session {
+	return sm.find(func(s *Session) bool { return s.ShareToken() == token })
+}
+
+// GetResumableSession retrieves the session a reconnecting client left
+func (sm *SessionManager) GetResumableSession(token string) *Session {
+	return sm.find(func(s *Session) bool { return s.ResumeToken() == token })
+}
+
+// GetInvitedSession retrieves a session by its collaboration token
+func (sm *SessionManager) GetInvitedSession(token string) *Session {
+	return sm.find(func(s *Session) bool { return s.InviteToken() == token })
+}
+
+// find returns the first session that matches
+func (sm *SessionManager) find(match func(*Session) bool) *Session {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	for _, session := range sm.sessions {
+		if match(session) {
+			return session
+		}
+	}
+	return nil
+}
+
 // CloseAll closes all sessions
 func (sm *SessionManager) CloseAll() {
 	sm.mu.Lock()
@@ -80,4 +116,16 @@ func (sm *SessionManager) Count() int {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 	return len(sm.sessions)
-}
\ No newline at end of file
+}
+
+// Metrics returns the combined worker pool metrics of all sessions
+func (sm *SessionManager) Metrics() WorkerPoolMetrics {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var total WorkerPoolMetrics
+	for _, session := range sm.sessions {
+		total = total.Add(session.Metrics())
+	}
+	return total
+}
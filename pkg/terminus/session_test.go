@@ -17,6 +17,7 @@ package terminus
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestClientToTerminusMessage(t *testing.T) {
@@ -149,6 +150,38 @@ func TestClientToTerminusMessage(t *testing.T) {
 			},
 			expected: KeyMsg{Type: KeyCtrlC},
 		},
+		{
+			name: "Ctrl+Up",
+			input: ClientMessage{
+				Type: "key",
+				Data: map[string]interface{}{
+					"keyType": "up",
+					"ctrl":    true,
+				},
+			},
+			expected: KeyMsg{Type: KeyUp, Ctrl: true},
+		},
+		{
+			name: "Ctrl+Shift+D",
+			input: ClientMessage{
+				Type: "key",
+				Data: map[string]interface{}{
+					"keyType": "ctrl+d",
+					"shift":   true,
+				},
+			},
+			expected: KeyMsg{Type: KeyCtrlD, Shift: true},
+		},
+		{
+			name: "Ctrl+F",
+			input: ClientMessage{
+				Type: "key",
+				Data: map[string]interface{}{
+					"keyType": "ctrl+f",
+				},
+			},
+			expected: KeyMsg{Type: KeyRunes, Runes: []rune{'f'}, Ctrl: true},
+		},
 		{
 			name: "Window resize",
 			input: ClientMessage{
@@ -191,6 +224,10 @@ func TestClientToTerminusMessage(t *testing.T) {
 				if keyMsg.Type != expected.Type {
 					t.Errorf("Expected key type %v, got %v", expected.Type, keyMsg.Type)
 				}
+
+				if keyMsg.Ctrl != expected.Ctrl || keyMsg.Alt != expected.Alt || keyMsg.Shift != expected.Shift {
+					t.Errorf("Expected modifiers %+v, got %+v", expected, keyMsg)
+				}
 				
 				if len(keyMsg.Runes) != len(expected.Runes) {
 					t.Errorf("Expected %d runes, got %d", len(expected.Runes), len(keyMsg.Runes))
@@ -270,4 +307,36 @@ func TestServerMessage(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClientEnvironment(t *testing.T) {
+	session := NewSession("env", nil, &testComponent{})
+	defer session.Close()
+
+	msg := session.clientToTerminusMessage(ClientMessage{
+		Type: "environment",
+		Data: map[string]interface{}{
+			"locale":        "fr-FR",
+			"timeZone":      "Europe/Paris",
+			"utcOffset":     60.0,
+			"colorDepth":    30.0,
+			"reducedMotion": true,
+			"userAgent":     "Mozilla/5.0",
+		},
+	})
+
+	expected := EnvironmentMsg{
+		Locale:        "fr-FR",
+		TimeZone:      "Europe/Paris",
+		UTCOffset:     time.Hour,
+		ColorDepth:    30,
+		ReducedMotion: true,
+		UserAgent:     "Mozilla/5.0",
+	}
+	if msg != expected {
+		t.Errorf("Expected %+v, got %+v", expected, msg)
+	}
+	if session.Environment() != expected {
+		t.Errorf("Expected the session to keep the environment, got %+v", session.Environment())
+	}
+}
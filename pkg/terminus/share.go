@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// ErrSharingUnavailable is reported when ShareSession is used outside a
+// session, e.g. in an engine without a connected client
+var ErrSharingUnavailable = errors.New("session sharing unavailable")
+
+// ShareLinkMsg is sent in response to ShareSession and InviteCollaborators
+type ShareLinkMsg struct {
+	Token         string // Identifies the shared session
+	Path          string // Path and query to open on the app's host, e.g. "/?observe=…"
+	Collaborative bool   // Whether the link lets users send input
+}
+
+// shareRequestMsg asks the engine for the session's share link
+type shareRequestMsg struct {
+	collaborative bool
+}
+
+// ShareSession returns a command that creates a link to the running session.
+// Browsers that open the link watch the session as read-only observers: they
+// receive the same render stream but their input is ignored. The component
+// receives a ShareLinkMsg; calling ShareSession again returns the same link.
+func ShareSession() Cmd {
+	return func() Msg {
+		return shareRequestMsg{}
+	}
+}
+
+// shareLink answers a share request with the session's link
+func (e *Engine) shareLink(req shareRequestMsg) Msg {
+	if e.onShare == nil {
+		source := "ShareSession"
+		if req.collaborative {
+			source = "InviteCollaborators"
+		}
+		return ErrMsg{Err: ErrSharingUnavailable, Source: source}
+	}
+	token := e.onShare(req.collaborative)
+	param := "observe"
+	if req.collaborative {
+		param = "join"
+	}
+	return ShareLinkMsg{
+		Token:         token,
+		Path:          "/?" + param + "=" + url.QueryEscape(token),
+		Collaborative: req.collaborative,
+	}
+}
+
+// observer is a connection watching a shared session. Collaborators are
+// observers with a user, whose input is delivered to the component.
+type observer struct {
+	conn     *websocket.Conn
+	outgoing chan []byte
+	user     *User
+
+	// Set when a message was dropped, until a full redraw has been queued.
+	// Only the render loop uses it.
+	dirty bool
+}
+
+// Share enables sharing for the session and returns the token observers
+// connect with
+func (s *Session) Share() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shareToken == "" {
+		s.shareToken = uuid.New().String()
+	}
+	return s.shareToken
+}
+
+// ShareToken returns the session's share token, or "" if it is not shared
+func (s *Session) ShareToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shareToken
+}
+
+// Observers returns the number of connected observers
+func (s *Session) Observers() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.observers)
+}
+
+// Observe streams the session's output to conn until the observer
+// disconnects, the session ends or ctx is done. Anything the observer sends
+// is discarded.
+func (s *Session) Observe(ctx context.Context, conn *websocket.Conn) {
+	s.stream(ctx, &observer{conn: conn, outgoing: make(chan []byte, 100)}, nil)
+}
+
+// stream writes the session's output to o, passing anything it sends to
+// handle, until o disconnects, the session ends or ctx is done
+func (s *Session) stream(ctx context.Context, o *observer, handle func(ClientMessage)) {
+	conn := o.conn
+	if !s.addObserver(o) {
+		conn.Close()
+		return
+	}
+	if o.user != nil {
+		s.announcePresence()
+	}
+	defer func() {
+		if s.removeObserver(o) && o.user != nil {
+			s.announcePresence()
+		}
+	}()
+
+	// Read even when discarding input, so that closes and pongs are handled
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if handle == nil {
+				continue
+			}
+			var msg ClientMessage
+			if err := json.Unmarshal(message, &msg); err == nil {
+				handle(msg)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case message, ok := <-o.outgoing:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// addObserver registers o and queues the current screen for it, reporting
+// whether the session is still open
+func (s *Session) addObserver(o *observer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if s.observers == nil {
+		s.observers = make(map[*observer]struct{})
+	}
+	s.observers[o] = struct{}{}
+
+	if frame, err := s.currentFrame(); err != nil {
+		fmt.Printf("Failed to encode screen for observer of session %s: %v\n", s.id, err)
+	} else {
+		o.outgoing <- frame
+	}
+	return true
+}
+
+// currentFrame encodes a full redraw of the last view as a batch. The caller
+// holds s.mu.
+func (s *Session) currentFrame() ([]byte, error) {
+	var commands []ServerMessage
+	differ := s.newScreenDiffer(s.width, s.height, s.colorProfile)
+	differ.SetSurfaces(s.lastSurfaces)
+	for _, op := range differ.Update(s.lastView) {
+		if msg, ok := renderMessage(op); ok {
+			commands = append(commands, msg)
+		}
+	}
+	return json.Marshal(ServerMessage{
+		Type: "batch",
+		Data: map[string]interface{}{"commands": commands},
+	})
+}
+
+// removeObserver unregisters o and ends its stream, reporting whether it was
+// still registered
+func (s *Session) removeObserver(o *observer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.observers[o]; !ok {
+		return false
+	}
+	delete(s.observers, o)
+	close(o.outgoing)
+	o.conn.Close()
+	return true
+}
+
+// closeObservers disconnects every observer
+func (s *Session) closeObservers() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for o := range s.observers {
+		delete(s.observers, o)
+		close(o.outgoing)
+		o.conn.Close()
+	}
+}
+
+// broadcast sends a frame's render messages to every observer. Slow
+// observers miss messages rather than holding up the session; the diffs
+// that follow would draw on the wrong screen, so they are sent a full
+// redraw of the current frame instead as soon as there is room for it. The
+// caller holds s.mu.
+func (s *Session) broadcast(frame [][]byte) {
+	var redraw []byte
+	for o := range s.observers {
+		if !o.dirty {
+			for _, data := range frame {
+				if !o.offer(data) {
+					fmt.Printf("Observer buffer full for session %s\n", s.id)
+					o.dirty = true
+					break
+				}
+			}
+		}
+		if !o.dirty {
+			continue
+		}
+
+		if redraw == nil {
+			data, err := s.currentFrame()
+			if err != nil {
+				fmt.Printf("Failed to encode screen for observer of session %s: %v\n", s.id, err)
+				return
+			}
+			redraw = data
+		}
+		if o.offer(redraw) {
+			o.dirty = false
+		}
+	}
+}
+
+// offer queues a message for o, reporting false if its buffer is full
+func (o *observer) offer(data []byte) bool {
+	select {
+	case o.outgoing <- data:
+		return true
+	default:
+		return false
+	}
+}
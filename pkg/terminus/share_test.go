@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// shareComponent shares its session on start and shows the last key
+type shareComponent struct {
+	view string
+}
+
+func (c *shareComponent) Init() Cmd {
+	return ShareSession()
+}
+
+func (c *shareComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case ShareLinkMsg:
+		c.view = "shared at " + msg.Path
+	case KeyMsg:
+		c.view = "key: " + msg.String()
+	}
+	return c, nil
+}
+
+func (c *shareComponent) View() string {
+	return c.view
+}
+
+// readUntil reads server messages until one contains text
+func readUntil(t *testing.T, conn *websocket.Conn, text string) ServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Never received %q: %v", text, err)
+		}
+		if strings.Contains(string(data), text) {
+			var msg ServerMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("Invalid message %s: %v", data, err)
+			}
+			return msg
+		}
+	}
+}
+
+// screenText returns the text a render message draws: the updated line, or
+// every line of a full redraw
+func screenText(msg ServerMessage) string {
+	if content, ok := msg.Data["content"].(string); ok {
+		return content
+	}
+	lines, _ := msg.Data["lines"].([]interface{})
+	text := make([]string, len(lines))
+	for i, line := range lines {
+		text[i], _ = line.(string)
+	}
+	return strings.Join(text, "\n")
+}
+
+func TestShareSession(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports an error outside a session",
+			test: func(t *testing.T) {
+				errs := make(chan ErrMsg, 1)
+				capture := func(next Handler) Handler {
+					return func(ctx context.Context, msg Msg) Cmd {
+						if err, ok := msg.(ErrMsg); ok {
+							errs <- err
+						}
+						return next(ctx, msg)
+					}
+				}
+				startEngine(t, &shareComponent{}, WithEngineMessageMiddleware(capture))
+
+				select {
+				case err := <-errs:
+					if !errors.Is(err.Err, ErrSharingUnavailable) {
+						t.Errorf("Expected ErrSharingUnavailable, got %v", err.Err)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected an ErrMsg")
+				}
+			},
+		},
+		{
+			name: "Returns the same token each time",
+			test: func(t *testing.T) {
+				session := NewSession("s1", nil, &shareComponent{})
+				defer session.Close()
+				if session.ShareToken() != "" {
+					t.Error("Expected no token before sharing")
+				}
+				token := session.Share()
+				if token == "" || session.Share() != token || session.ShareToken() != token {
+					t.Errorf("Expected a stable token, got %q", token)
+				}
+			},
+		},
+		{
+			name: "Redraws the screen for observers that missed a frame",
+			test: func(t *testing.T) {
+				session := NewSession("slow", nil, &shareComponent{})
+				defer session.Close()
+				o := &observer{outgoing: make(chan []byte, 1)}
+				session.observers = map[*observer]struct{}{o: {}}
+				defer delete(session.observers, o)
+
+				session.handleRender("one")
+				session.handleRender("two")
+				if !o.dirty {
+					t.Fatal("Expected the observer to miss the second frame")
+				}
+				<-o.outgoing
+
+				session.handleRender("three")
+				var msg ServerMessage
+				if err := json.Unmarshal(<-o.outgoing, &msg); err != nil {
+					t.Fatal(err)
+				}
+				if msg.Type != "batch" || !strings.Contains(fmt.Sprint(msg.Data), "three") || o.dirty {
+					t.Errorf("Expected a redraw of the current frame, got %+v", msg)
+				}
+
+				session.handleRender("four")
+				if data := string(<-o.outgoing); !strings.Contains(data, "four") {
+					t.Errorf("Expected diffs to follow the redraw, got %s", data)
+				}
+			},
+		},
+		{
+			name: "Streams renders to read-only observers",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component { return &shareComponent{} })
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+				wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+				owner, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+				if err != nil {
+					t.Fatalf("Failed to connect: %v", err)
+				}
+				defer owner.Close()
+				msg := readUntil(t, owner, "shared at")
+				content := screenText(msg)
+				path := strings.Fields(content[strings.Index(content, "/?observe="):])[0]
+
+				observer, _, err := websocket.DefaultDialer.Dial(wsURL+strings.TrimPrefix(path, "/"), nil)
+				if err != nil {
+					t.Fatalf("Failed to connect observer: %v", err)
+				}
+				defer observer.Close()
+				if msg := readUntil(t, observer, "shared at"); msg.Type != "batch" {
+					t.Errorf("Expected the current screen as a batch, got %q", msg.Type)
+				}
+
+				// The owner's input is streamed to the observer
+				owner.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "enter"}})
+				readUntil(t, observer, "key: enter")
+
+				// The observer's input is ignored
+				observer.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "tab"}})
+				owner.WriteJSON(ClientMessage{Type: "key", Data: map[string]interface{}{"keyType": "space"}})
+				msg = readUntil(t, owner, "key: s")
+				if content := screenText(msg); !strings.Contains(content, "key: space") {
+					t.Errorf("Expected observer input to be ignored, got %q", content)
+				}
+				if session := program.sessionManager.GetSharedSession(path[len("/?observe="):]); session.Observers() != 1 {
+					t.Errorf("Expected 1 observer, got %d", session.Observers())
+				}
+			},
+		},
+		{
+			name: "Rejects unknown tokens",
+			test: func(t *testing.T) {
+				program := NewProgram(func() Component { return &shareComponent{} })
+				server := httptest.NewServer(http.HandlerFunc(program.handleWebSocket))
+				defer server.Close()
+
+				wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "?observe=nope"
+				_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+				if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+					t.Errorf("Expected 404 for an unknown token, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
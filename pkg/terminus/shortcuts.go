@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Shortcut is an application-global key, such as Ctrl+K for a command
+// palette or F1 for help. It is matched before the key reaches the
+// component, whichever widget has the focus, and the component receives a
+// ShortcutMsg in its place.
+type Shortcut struct {
+	Name string // Identifies the shortcut in its ShortcutMsg, e.g. "palette"
+	Key  KeyMsg
+	Help string // What the shortcut does, e.g. for a help screen
+}
+
+// ShortcutMsg is sent in place of the key of a global shortcut
+type ShortcutMsg struct {
+	Name string
+	Key  KeyMsg // The key as pressed, with the collaborator who pressed it
+}
+
+// AnyText stands for every printable key in KeyBindings, for widgets that
+// take text input
+var AnyText = KeyMsg{Type: KeyRunes}
+
+// KeyBinder is implemented by components and widgets that report the keys
+// they handle, so global shortcuts can be checked against them. Containers
+// report the bindings of their children along with their own.
+type KeyBinder interface {
+	KeyBindings() []KeyMsg
+}
+
+// ShortcutConflict is a global shortcut that takes a key something else
+// handles too
+type ShortcutConflict struct {
+	Shortcut Shortcut
+	With     string // The other shortcut, or the type of the KeyBinder
+}
+
+func (c ShortcutConflict) String() string {
+	return fmt.Sprintf("shortcut %q (%s) conflicts with %s", c.Shortcut.Name, keyLabel(c.Shortcut.Key), c.With)
+}
+
+// WithEngineShortcuts registers global shortcuts. Conflicts between them,
+// with the debug and copy mode keys and with the bindings of a root
+// component that is a KeyBinder are logged once.
+func WithEngineShortcuts(shortcuts ...Shortcut) EngineOption {
+	return func(e *Engine) {
+		e.shortcuts = append(e.shortcuts, shortcuts...)
+	}
+}
+
+// ShortcutConflicts returns the shortcuts that share a key with an earlier
+// one or with a binding of binders, e.g. to check an app's shortcuts in a
+// test. A shortcut without modifiers conflicts with AnyText if it types a
+// character.
+func ShortcutConflicts(shortcuts []Shortcut, binders ...KeyBinder) []ShortcutConflict {
+	var conflicts []ShortcutConflict
+	for i, s := range shortcuts {
+		for _, earlier := range shortcuts[:i] {
+			if sameKey(s.Key, earlier.Key) {
+				conflicts = append(conflicts, ShortcutConflict{Shortcut: s, With: fmt.Sprintf("shortcut %q", earlier.Name)})
+			}
+		}
+		for _, b := range binders {
+			for _, key := range b.KeyBindings() {
+				if bindingMatches(key, s.Key) {
+					conflicts = append(conflicts, ShortcutConflict{Shortcut: s, With: fmt.Sprintf("%T", b)})
+					break
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// bindingMatches reports whether key is handled by binding, which may be
+// AnyText
+func bindingMatches(binding, key KeyMsg) bool {
+	if binding.Type == KeyRunes && len(binding.Runes) == 0 {
+		return key.Type == KeyRunes && !key.Ctrl && !key.Alt
+	}
+	return sameKey(binding, key)
+}
+
+// shortcut returns the ShortcutMsg for key if it is a global shortcut
+func (e *Engine) shortcut(key KeyMsg) (Msg, bool) {
+	for _, s := range e.shortcuts {
+		if sameKey(s.Key, key) {
+			return ShortcutMsg{Name: s.Name, Key: key}, true
+		}
+	}
+	return nil, false
+}
+
+// reportedConflicts holds the conflicts already logged, so each is logged
+// once rather than for every session
+var reportedConflicts sync.Map
+
+// checkShortcuts logs conflicts of the engine's shortcuts
+func (e *Engine) checkShortcuts() {
+	if len(e.shortcuts) == 0 {
+		return
+	}
+	binders := []KeyBinder{}
+	if e.debug != nil {
+		binders = append(binders, debugKeyBinder{e.debug.key})
+	}
+	if e.copyMode != nil {
+		binders = append(binders, copyModeKeyBinder{e.copyMode.key})
+	}
+	if b, ok := e.component.(KeyBinder); ok {
+		binders = append(binders, b)
+	}
+	for _, c := range ShortcutConflicts(e.shortcuts, binders...) {
+		if _, seen := reportedConflicts.LoadOrStore(c.String(), true); !seen {
+			fmt.Printf("Warning: %s\n", c)
+		}
+	}
+}
+
+// debugKeyBinder reports the debug key as a binding
+type debugKeyBinder struct {
+	key KeyMsg
+}
+
+func (d debugKeyBinder) KeyBindings() []KeyMsg {
+	return []KeyMsg{d.key}
+}
+
+// copyModeKeyBinder reports the copy mode key as a binding
+type copyModeKeyBinder struct {
+	key KeyMsg
+}
+
+func (c copyModeKeyBinder) KeyBindings() []KeyMsg {
+	return []KeyMsg{c.key}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "testing"
+
+var paletteKey = KeyMsg{Type: KeyRunes, Runes: []rune("k"), Ctrl: true}
+
+func TestShortcuts(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Replaces the key with a ShortcutMsg",
+			test: func(t *testing.T) {
+				var msgs []Msg
+				comp := &recordingComponent{onMsg: func(msg Msg) { msgs = append(msgs, msg) }}
+				engine := NewEngine(comp, WithEngineShortcuts(
+					Shortcut{Name: "palette", Key: paletteKey, Help: "Open the command palette"},
+					Shortcut{Name: "help", Key: KeyMsg{Type: KeyF1}},
+				))
+
+				pressed := paletteKey
+				pressed.User = "guest"
+				engine.handleMessage(pressed)
+				engine.handleMessage(KeyMsg{Type: KeyRunes, Runes: []rune("k")})
+
+				if len(msgs) != 2 {
+					t.Fatalf("Expected 2 messages, got %d", len(msgs))
+				}
+				if msg, ok := msgs[0].(ShortcutMsg); !ok || msg.Name != "palette" || msg.Key.User != "guest" {
+					t.Errorf("Expected the palette shortcut, got %#v", msgs[0])
+				}
+				if _, ok := msgs[1].(KeyMsg); !ok {
+					t.Errorf("Expected k without Ctrl to reach the component, got %#v", msgs[1])
+				}
+			},
+		},
+		{
+			name: "Finds conflicts",
+			test: func(t *testing.T) {
+				shortcuts := []Shortcut{
+					{Name: "palette", Key: paletteKey},
+					{Name: "search", Key: KeyMsg{Type: KeyRunes, Runes: []rune("/")}},
+					{Name: "commands", Key: paletteKey},
+					{Name: "help", Key: KeyMsg{Type: KeyF1}},
+				}
+				input := bindings{KeyMsg{Type: KeyEnter}, AnyText}
+				list := bindings{KeyMsg{Type: KeyF1}}
+
+				conflicts := ShortcutConflicts(shortcuts, input, list)
+				want := []string{
+					`shortcut "search" (/) conflicts with terminus.bindings`,
+					`shortcut "commands" (ctrl+k) conflicts with shortcut "palette"`,
+					`shortcut "help" (f1) conflicts with terminus.bindings`,
+				}
+				if len(conflicts) != len(want) {
+					t.Fatalf("Expected %d conflicts, got %v", len(want), conflicts)
+				}
+				for i, c := range conflicts {
+					if c.String() != want[i] {
+						t.Errorf("Expected %q, got %q", want[i], c.String())
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// bindings is a KeyBinder of fixed keys
+type bindings []KeyMsg
+
+func (b bindings) KeyBindings() []KeyMsg { return b }
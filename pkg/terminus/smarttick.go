@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "time"
+
+// VisibilityMsg is sent when the browser tab showing the session is hidden
+// or shown again, and when the client connects
+type VisibilityMsg struct {
+	Visible bool
+}
+
+// SmartTickMsg is the message sent by SmartTick when fn is nil
+type SmartTickMsg struct {
+	Time    time.Time
+	Elapsed time.Duration // Time since the tick was scheduled
+}
+
+// smartTickRequestMsg asks the engine to schedule a SmartTick
+type smartTickRequestMsg struct {
+	d  time.Duration
+	fn func(time.Time, time.Duration) Msg
+}
+
+// smartTickFiredMsg is sent by a smart tick's timer
+type smartTickFiredMsg struct {
+	tick *smartTick
+}
+
+// smartTick is a SmartTick waiting to fire
+type smartTick struct {
+	start time.Time
+	d     time.Duration
+	fn    func(time.Time, time.Duration) Msg
+	stop  chan struct{} // Closed to stop the timer; nil while paused
+}
+
+// SmartTick is like Tick, but waits while nobody can see the session: while
+// the browser tab is hidden or the client is disconnected. A tick that came
+// due, or was still waiting, while the session was out of sight fires as soon
+// as the session is visible again, and elapsed tells how long it has been
+// since the tick was scheduled, so the component can catch up in one step.
+// A nil fn sends a SmartTickMsg.
+//
+// Polling dashboards that re-issue a SmartTick from Update stop polling in
+// background tabs and refresh as soon as the user comes back.
+func SmartTick(d time.Duration, fn func(t time.Time, elapsed time.Duration) Msg) Cmd {
+	return func() Msg {
+		return smartTickRequestMsg{d: d, fn: fn}
+	}
+}
+
+// paused reports whether smart ticks are waiting for the session to be
+// seen again
+func (e *Engine) paused() bool {
+	return e.hidden || e.offline
+}
+
+// observeActivity pauses and resumes smart ticks as the client hides, shows,
+// loses and regains the session
+func (e *Engine) observeActivity(msg Msg) {
+	hidden, offline := e.hidden, e.offline
+	switch msg := msg.(type) {
+	case VisibilityMsg:
+		hidden = !msg.Visible
+	case ConnectionStateMsg:
+		offline = msg.State != ConnectionConnected
+	default:
+		return
+	}
+
+	wasPaused := e.paused()
+	e.hidden, e.offline = hidden, offline
+	switch {
+	case !wasPaused && e.paused():
+		for tick := range e.smartTicks {
+			tick.pause()
+		}
+	case wasPaused && !e.paused():
+		for tick := range e.smartTicks {
+			e.startSmartTick(tick, 0)
+		}
+	}
+}
+
+// scheduleSmartTick starts the timer of a SmartTick, unless the session is
+// out of sight
+func (e *Engine) scheduleSmartTick(req smartTickRequestMsg) {
+	tick := &smartTick{start: time.Now(), d: req.d, fn: req.fn}
+	if e.smartTicks == nil {
+		e.smartTicks = make(map[*smartTick]struct{})
+	}
+	e.smartTicks[tick] = struct{}{}
+	if !e.paused() {
+		e.startSmartTick(tick, tick.d)
+	}
+}
+
+// startSmartTick fires tick after wait, unless it is paused or the engine
+// stops first
+func (e *Engine) startSmartTick(tick *smartTick, wait time.Duration) {
+	tick.pause()
+	stop := make(chan struct{})
+	tick.stop = stop
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			e.SendMessage(smartTickFiredMsg{tick: tick})
+		case <-stop:
+		case <-e.ctx.Done():
+		}
+	}()
+}
+
+// pause stops the tick's timer
+func (t *smartTick) pause() {
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+}
+
+// fireSmartTick returns the message of a tick whose timer fired, or nil if
+// the tick already fired or the session went out of sight meanwhile
+func (e *Engine) fireSmartTick(ev smartTickFiredMsg) Msg {
+	if _, pending := e.smartTicks[ev.tick]; !pending || e.paused() {
+		return nil
+	}
+	delete(e.smartTicks, ev.tick)
+	ev.tick.stop = nil
+
+	now := time.Now()
+	elapsed := now.Sub(ev.tick.start)
+	if ev.tick.fn != nil {
+		return ev.tick.fn(now, elapsed)
+	}
+	return SmartTickMsg{Time: now, Elapsed: elapsed}
+}
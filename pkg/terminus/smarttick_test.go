@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTickMsg asks smartTickComponent to schedule its tick
+type startTickMsg struct{}
+
+// pendingTicksMsg asks smartTickComponent to count its engine's waiting
+// ticks
+type pendingTicksMsg struct{}
+
+// smartTickComponent schedules a 30ms SmartTick when asked and shows how
+// long the tick took
+type smartTickComponent struct {
+	ticks   int
+	elapsed time.Duration
+
+	// Set to count the waiting ticks, which the update loop owns
+	engine    *Engine
+	pending   int
+	countedAt time.Time
+}
+
+func (c *smartTickComponent) Init() Cmd {
+	return nil
+}
+
+func (c *smartTickComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case startTickMsg:
+		return c, SmartTick(30*time.Millisecond, nil)
+	case SmartTickMsg:
+		c.ticks++
+		c.elapsed = msg.Elapsed
+	case pendingTicksMsg:
+		c.pending, c.countedAt = len(c.engine.smartTicks), time.Now()
+	}
+	return c, nil
+}
+
+func (c *smartTickComponent) View() string {
+	return fmt.Sprintf("ticks: %d after %d pending %d at %d", c.ticks, c.elapsed.Milliseconds(), c.pending, c.countedAt.UnixNano())
+}
+
+// expectScheduled waits until the engine has a tick waiting and returns a
+// time after it was scheduled
+func expectScheduled(t *testing.T, engine *Engine, renders chan string) time.Time {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	engine.SendMessage(pendingTicksMsg{})
+	for {
+		select {
+		case view := <-renders:
+			var ticks, pending int
+			var elapsed, countedAt int64
+			fmt.Sscanf(view, "ticks: %d after %d pending %d at %d", &ticks, &elapsed, &pending, &countedAt)
+			if pending == 1 {
+				return time.Unix(0, countedAt)
+			}
+			engine.SendMessage(pendingTicksMsg{})
+		case <-timeout:
+			t.Fatal("Expected a tick to be scheduled")
+			return time.Time{}
+		}
+	}
+}
+
+// expectTick waits for a view showing a tick and returns its elapsed
+// milliseconds
+func expectTick(t *testing.T, renders chan string) int64 {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case view := <-renders:
+			if strings.HasPrefix(view, "ticks: 1") {
+				var ticks int
+				var elapsed int64
+				fmt.Sscanf(view, "ticks: %d after %d", &ticks, &elapsed)
+				return elapsed
+			}
+		case <-timeout:
+			t.Fatal("Expected the tick to fire")
+			return 0
+		}
+	}
+}
+
+// expectNoTick fails if another tick fires within d, after ticks have
+func expectNoTick(t *testing.T, renders chan string, ticks int, d time.Duration) {
+	t.Helper()
+	timeout := time.After(d)
+	for {
+		select {
+		case view := <-renders:
+			if !strings.HasPrefix(view, fmt.Sprintf("ticks: %d ", ticks)) {
+				t.Fatalf("Expected the tick to wait, got %q", view)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func TestSmartTick(t *testing.T) {
+	tests := []struct {
+		name   string
+		pause  Msg
+		resume Msg
+	}{
+		{
+			name:   "Waits while the tab is hidden",
+			pause:  VisibilityMsg{Visible: false},
+			resume: VisibilityMsg{Visible: true},
+		},
+		{
+			name:   "Waits while the client is disconnected",
+			pause:  ConnectionStateMsg{State: ConnectionReconnecting},
+			resume: ConnectionStateMsg{State: ConnectionConnected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &smartTickComponent{}
+			engine, renders := startEngine(t, c)
+			c.engine = engine
+			engine.SendMessage(tt.pause)
+			engine.SendMessage(startTickMsg{})
+			scheduled := expectScheduled(t, engine, renders)
+			expectNoTick(t, renders, 0, 100*time.Millisecond)
+
+			// The tick was scheduled before scheduled and fires after the
+			// resume is sent, so it waited at least the time between them
+			waited := time.Since(scheduled).Milliseconds()
+			engine.SendMessage(tt.resume)
+			if elapsed := expectTick(t, renders); elapsed < waited {
+				t.Errorf("Expected the %dms spent waiting in the elapsed time, got %dms", waited, elapsed)
+			}
+		})
+	}
+
+	t.Run("Fires on time while visible", func(t *testing.T) {
+		engine, renders := startEngine(t, &smartTickComponent{})
+		engine.SendMessage(startTickMsg{})
+		if elapsed := expectTick(t, renders); elapsed < 30 || elapsed > 1000 {
+			t.Errorf("Expected the tick after about 30ms, got %dms", elapsed)
+		}
+	})
+
+	t.Run("Fires once when shown several times", func(t *testing.T) {
+		engine, renders := startEngine(t, &smartTickComponent{})
+		engine.SendMessage(VisibilityMsg{Visible: false})
+		engine.SendMessage(startTickMsg{})
+		engine.SendMessage(VisibilityMsg{Visible: true})
+		engine.SendMessage(VisibilityMsg{Visible: false})
+		engine.SendMessage(VisibilityMsg{Visible: true})
+		expectTick(t, renders)
+		expectNoTick(t, renders, 1, 100*time.Millisecond)
+	})
+}
@@ -0,0 +1,269 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEOptions configures an event-stream subscription
+type SSEOptions struct {
+	// ID names the subscription for SSEClose. Defaults to the URL.
+	ID string
+
+	Method string            // Defaults to GET
+	Body   []byte            // Request body, sent on every (re)connect
+	Header map[string]string // Extra request headers
+
+	// Reconnect controls whether the stream is reopened after the server
+	// closes it, waiting RetryDelay (or the server's retry: value) first
+	Reconnect  bool
+	RetryDelay time.Duration // Defaults to 3 seconds
+}
+
+// SSEEventMsg is sent for each event received on an SSE subscription
+type SSEEventMsg struct {
+	ID          string // Subscription ID
+	Event       string // Event type, "message" if the server didn't set one
+	Data        string
+	LastEventID string
+}
+
+// JSON decodes the event data into v
+func (m SSEEventMsg) JSON(v interface{}) error {
+	return json.Unmarshal([]byte(m.Data), v)
+}
+
+// SSEClosedMsg is sent when an SSE subscription ends. Err is nil if the
+// stream finished normally or was closed with SSEClose.
+type SSEClosedMsg struct {
+	ID  string
+	Err error
+}
+
+// sseKey identifies a subscription in the session scope
+type sseKey string
+
+// SSE subscribes to a server-sent event stream, delivering each event as an
+// SSEEventMsg until the stream ends or the session closes
+func SSE(url string) Cmd {
+	return SSEWithOptions(url, SSEOptions{})
+}
+
+// SSEWithOptions subscribes to a server-sent event stream with custom
+// request options
+func SSEWithOptions(url string, opts SSEOptions) Cmd {
+	id := opts.ID
+	if id == "" {
+		id = url
+	}
+	if opts.Method == "" {
+		opts.Method = string(GET)
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 3 * time.Second
+	}
+
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Register so SSEClose can stop the subscription
+		scope := scopeFrom(ctx)
+		handle := &cancel
+		if old, ok := scope.swap(sseKey(id), handle); ok {
+			(*old.(*context.CancelFunc))()
+		}
+		defer scope.remove(sseKey(id), handle)
+
+		lastEventID := ""
+		retry := opts.RetryDelay
+		for {
+			err := readSSE(ctx, url, opts, lastEventID, func(ev sseEvent) {
+				if ev.retry > 0 {
+					retry = ev.retry
+				}
+				lastEventID = ev.lastEventID
+				if ev.dispatch {
+					send(SSEEventMsg{
+						ID:          id,
+						Event:       ev.event,
+						Data:        ev.data,
+						LastEventID: ev.lastEventID,
+					})
+				}
+			})
+
+			if ctx.Err() != nil {
+				return SSEClosedMsg{ID: id}
+			}
+			if !opts.Reconnect || isPermanentSSEError(err) {
+				return SSEClosedMsg{ID: id, Err: err}
+			}
+
+			select {
+			case <-time.After(retry):
+			case <-ctx.Done():
+				return SSEClosedMsg{ID: id}
+			}
+		}
+	})
+}
+
+// SSEClose stops the subscription with the given ID
+func SSEClose(id string) Cmd {
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		if handle, ok := scopeFrom(ctx).get(sseKey(id)); ok {
+			(*handle.(*context.CancelFunc))()
+		}
+		return nil
+	})
+}
+
+// sseStatusError reports a response that cannot carry an event stream
+type sseStatusError struct {
+	code int
+}
+
+func (e *sseStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.code)
+}
+
+// isPermanentSSEError reports whether reconnecting is pointless, as for
+// client errors and 204 No Content
+func isPermanentSSEError(err error) bool {
+	if se, ok := err.(*sseStatusError); ok {
+		return se.code == http.StatusNoContent || (se.code >= 400 && se.code < 500)
+	}
+	return false
+}
+
+// readSSE opens a single connection and parses events until it ends
+func readSSE(ctx context.Context, url string, opts SSEOptions, lastEventID string, handle func(sseEvent)) error {
+	var body io.Reader
+	if opts.Body != nil {
+		body = strings.NewReader(string(opts.Body))
+	}
+	req, err := http.NewRequestWithContext(ctx, opts.Method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	for k, v := range opts.Header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &sseStatusError{code: resp.StatusCode}
+	}
+
+	return parseSSE(resp.Body, lastEventID, handle)
+}
+
+// sseEvent is a parsed block of event-stream fields
+type sseEvent struct {
+	event       string
+	data        string
+	lastEventID string
+	retry       time.Duration
+	dispatch    bool // False for blocks that only update id or retry
+}
+
+// parseSSE reads an event stream as defined by the HTML specification,
+// calling handle at the end of each block
+func parseSSE(r io.Reader, lastEventID string, handle func(sseEvent)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		event   string
+		data    strings.Builder
+		hasData bool
+		retry   time.Duration
+		fields  bool
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if fields {
+				ev := sseEvent{
+					event:       event,
+					lastEventID: lastEventID,
+					retry:       retry,
+					dispatch:    hasData,
+				}
+				if ev.event == "" {
+					ev.event = "message"
+				}
+				ev.data = data.String()
+				handle(ev)
+			}
+			event, hasData, retry, fields = "", false, 0, false
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		default:
+			continue
+		}
+		fields = true
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSSE(t *testing.T) {
+	stream := strings.Join([]string{
+		": comment",
+		"data: hello",
+		"",
+		"event: update",
+		"id: 42",
+		"data: line one",
+		"data:line two",
+		"",
+		"retry: 500",
+		"",
+		"data: {\"n\":1}",
+		"",
+		"data: unterminated",
+	}, "\n")
+
+	var events []sseEvent
+	err := parseSSE(strings.NewReader(stream), "", func(ev sseEvent) {
+		events = append(events, ev)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 blocks, got %d", len(events))
+	}
+
+	tests := []struct {
+		index    int
+		event    string
+		data     string
+		id       string
+		dispatch bool
+	}{
+		{0, "message", "hello", "", true},
+		{1, "update", "line one\nline two", "42", true},
+		{2, "message", "", "42", false},
+		{3, "message", "{\"n\":1}", "42", true},
+	}
+
+	for _, tt := range tests {
+		ev := events[tt.index]
+		if ev.event != tt.event || ev.data != tt.data || ev.lastEventID != tt.id || ev.dispatch != tt.dispatch {
+			t.Errorf("Block %d: got %+v", tt.index, ev)
+		}
+	}
+	if events[2].retry != 500*time.Millisecond {
+		t.Errorf("Expected retry of 500ms, got %v", events[2].retry)
+	}
+}
+
+func TestSSE(t *testing.T) {
+	t.Run("Delivers events until stream ends", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") != "text/event-stream" {
+				t.Errorf("Expected event-stream Accept header, got %q", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			for i := 1; i <= 3; i++ {
+				fmt.Fprintf(w, "id: %d\ndata: tick %d\n\n", i, i)
+				w.(http.Flusher).Flush()
+			}
+		}))
+		defer server.Close()
+
+		final, sent := runStream(t, SSEWithOptions(server.URL, SSEOptions{ID: "feed"}))
+
+		if closed, ok := final.(SSEClosedMsg); !ok || closed.Err != nil || closed.ID != "feed" {
+			t.Errorf("Expected clean SSEClosedMsg, got %#v", final)
+		}
+		if len(sent) != 3 {
+			t.Fatalf("Expected 3 events, got %d", len(sent))
+		}
+		last := sent[2].(SSEEventMsg)
+		if last.Data != "tick 3" || last.LastEventID != "3" || last.Event != "message" {
+			t.Errorf("Unexpected event %+v", last)
+		}
+	})
+
+	t.Run("Reconnects with Last-Event-ID", func(t *testing.T) {
+		lastIDs := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastIDs <- r.Header.Get("Last-Event-ID")
+			if r.Header.Get("Last-Event-ID") != "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fmt.Fprint(w, "id: abc\ndata: first\n\n")
+		}))
+		defer server.Close()
+
+		opts := SSEOptions{Reconnect: true, RetryDelay: time.Millisecond}
+		final, sent := runStream(t, SSEWithOptions(server.URL, opts))
+
+		if len(sent) != 1 {
+			t.Errorf("Expected 1 event, got %d", len(sent))
+		}
+		if <-lastIDs != "" || <-lastIDs != "abc" {
+			t.Error("Expected reconnect to send Last-Event-ID")
+		}
+		if closed, ok := final.(SSEClosedMsg); !ok || closed.Err == nil {
+			t.Errorf("Expected 204 to end the subscription with an error, got %#v", final)
+		}
+	})
+
+	t.Run("SSEClose stops the subscription", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "data: open\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		ctx := withSessionScope(context.Background())
+		opened := make(chan struct{})
+		done := make(chan Msg, 1)
+		go func() {
+			done <- runCmd(ctx, SSEWithOptions(server.URL, SSEOptions{ID: "live"}), func(Msg) {
+				close(opened)
+			})
+		}()
+
+		<-opened
+		runCmd(ctx, SSEClose("live"), func(Msg) {})
+
+		select {
+		case msg := <-done:
+			if closed, ok := msg.(SSEClosedMsg); !ok || closed.Err != nil {
+				t.Errorf("Expected clean close, got %#v", msg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Subscription did not stop")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileStorage keeps documents in a JSON file, an object from keys to
+// documents, that is rewritten on every change. It suits the small data of
+// examples and single-user tools; changes made to the file by other
+// processes aren't seen.
+type FileStorage struct {
+	path     string
+	mu       sync.Mutex
+	docs     map[string]json.RawMessage
+	watchers watchers
+}
+
+// NewFileStorage opens the storage kept in the file at path, which is
+// created with the first document if it doesn't exist
+func NewFileStorage(path string) (*FileStorage, error) {
+	f := &FileStorage{path: path, docs: make(map[string]json.RawMessage)}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return f, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &f.docs); err != nil {
+		return nil, fmt.Errorf("storage: reading %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Put implements the Storage interface
+func (f *FileStorage) Put(ctx context.Context, key string, value any) error {
+	doc, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old, existed := f.docs[key]
+	f.docs[key] = doc
+	if err := f.write(); err != nil {
+		if existed {
+			f.docs[key] = old
+		} else {
+			delete(f.docs, key)
+		}
+		return err
+	}
+	f.watchers.publish(Change{Key: key, Value: doc})
+	return nil
+}
+
+// Get implements the Storage interface
+func (f *FileStorage) Get(ctx context.Context, key string, value any) error {
+	f.mu.Lock()
+	doc, ok := f.docs[key]
+	f.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	return json.Unmarshal(doc, value)
+}
+
+// Delete implements the Storage interface
+func (f *FileStorage) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old, ok := f.docs[key]
+	if !ok {
+		return nil
+	}
+	delete(f.docs, key)
+	if err := f.write(); err != nil {
+		f.docs[key] = old
+		return err
+	}
+	f.watchers.publish(Change{Key: key, Deleted: true})
+	return nil
+}
+
+// List implements the Storage interface
+func (f *FileStorage) List(ctx context.Context, prefix string) ([]Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries := make([]Entry, 0)
+	for key, doc := range f.docs {
+		if strings.HasPrefix(key, prefix) {
+			entries = append(entries, Entry{Key: key, Value: doc})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// Watch implements the Storage interface
+func (f *FileStorage) Watch(ctx context.Context, prefix string) <-chan Change {
+	return f.watchers.watch(ctx, prefix)
+}
+
+// write replaces the file with the documents, through a temporary file so
+// a crash never leaves half a file behind
+func (f *FileStorage) write() error {
+	data, err := json.MarshalIndent(f.docs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
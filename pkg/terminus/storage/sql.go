@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// tableName matches the table names NewSQLStorage accepts
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLStorage keeps documents in a table of a SQL database, one row per
+// key. Its statements are written for SQLite, e.g. with the
+// modernc.org/sqlite or github.com/mattn/go-sqlite3 driver, and also work
+// with other databases that take ? placeholders and ON CONFLICT upserts.
+// Only changes made through the same SQLStorage are watched.
+type SQLStorage struct {
+	db       *sql.DB
+	table    string
+	watchers watchers
+}
+
+// NewSQLStorage keeps documents in table, creating it if it doesn't exist
+func NewSQLStorage(ctx context.Context, db *sql.DB, table string) (*SQLStorage, error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("storage: invalid table name %q", table)
+	}
+	s := &SQLStorage{db: db, table: table}
+	_, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+table+" (key TEXT PRIMARY KEY, value TEXT NOT NULL)")
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Put implements the Storage interface
+func (s *SQLStorage) Put(ctx context.Context, key string, value any) error {
+	doc, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO "+s.table+" (key, value) VALUES (?, ?) "+
+		"ON CONFLICT(key) DO UPDATE SET value = excluded.value", key, string(doc))
+	if err != nil {
+		return err
+	}
+	s.watchers.publish(Change{Key: key, Value: doc})
+	return nil
+}
+
+// Get implements the Storage interface
+func (s *SQLStorage) Get(ctx context.Context, key string, value any) error {
+	var doc string
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM "+s.table+" WHERE key = ?", key).Scan(&doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(doc), value)
+}
+
+// Delete implements the Storage interface
+func (s *SQLStorage) Delete(ctx context.Context, key string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM "+s.table+" WHERE key = ?", key)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		s.watchers.publish(Change{Key: key, Deleted: true})
+	}
+	return nil
+}
+
+// List implements the Storage interface
+func (s *SQLStorage) List(ctx context.Context, prefix string) ([]Entry, error) {
+	query, args := "SELECT key, value FROM "+s.table+" ORDER BY key", []any{}
+	if prefix != "" {
+		// Keys starting with prefix sort from prefix up to the next prefix
+		query, args = "SELECT key, value FROM "+s.table+" WHERE key >= ? ORDER BY key", []any{prefix}
+		if end, ok := prefixEnd(prefix); ok {
+			query = "SELECT key, value FROM " + s.table + " WHERE key >= ? AND key < ? ORDER BY key"
+			args = append(args, end)
+		}
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+	for rows.Next() {
+		var key, doc string
+		if err := rows.Scan(&key, &doc); err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Key: key, Value: json.RawMessage(doc)})
+	}
+	return entries, rows.Err()
+}
+
+// Watch implements the Storage interface
+func (s *SQLStorage) Watch(ctx context.Context, prefix string) <-chan Change {
+	return s.watchers.watch(ctx, prefix)
+}
+
+// prefixEnd returns the smallest string greater than every string starting
+// with prefix, or false if there is none
+func prefixEnd(prefix string) (string, bool) {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1]), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSQLStorage(t *testing.T) {
+	testStorage(t, func(t *testing.T) Storage {
+		db, err := sql.Open("storagetest", t.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		s, err := NewSQLStorage(context.Background(), db, "documents")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+
+	t.Run("Rejects invalid table names", func(t *testing.T) {
+		db, _ := sql.Open("storagetest", t.Name())
+		defer db.Close()
+		if _, err := NewSQLStorage(context.Background(), db, "docs; DROP TABLE users"); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestPrefixEnd(t *testing.T) {
+	if end, ok := prefixEnd("todo/"); !ok || end != "todo0" {
+		t.Errorf("Expected todo0, got %q", end)
+	}
+	if end, ok := prefixEnd("a\xff"); !ok || end != "b" {
+		t.Errorf("Expected b, got %q", end)
+	}
+	if _, ok := prefixEnd("\xff"); ok {
+		t.Error("Expected no end")
+	}
+}
+
+// testDriver is a database/sql driver that understands just the statements
+// SQLStorage runs, keeping a table per data source name in memory
+type testDriver struct {
+	mu     sync.Mutex
+	tables map[string]map[string]string
+}
+
+func init() {
+	sql.Register("storagetest", &testDriver{tables: make(map[string]map[string]string)})
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.tables[name] == nil {
+		d.tables[name] = make(map[string]string)
+	}
+	return &testConn{driver: d, rows: d.tables[name]}, nil
+}
+
+type testConn struct {
+	driver *testDriver
+	rows   map[string]string
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{conn: c, query: query}, nil
+}
+
+func (c *testConn) Close() error { return nil }
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+type testStmt struct {
+	conn  *testConn
+	query string
+}
+
+func (s *testStmt) Close() error  { return nil }
+func (s *testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	rows := s.conn.rows
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS "):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "INSERT INTO ") && strings.Contains(s.query, "ON CONFLICT(key)"):
+		rows[args[0].(string)] = args[1].(string)
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "DELETE FROM "):
+		key := args[0].(string)
+		if _, ok := rows[key]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		delete(rows, key)
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement %q", s.query)
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	result := &testRows{}
+	switch {
+	case strings.HasPrefix(s.query, "SELECT value FROM ") && strings.HasSuffix(s.query, "WHERE key = ?"):
+		result.columns = []string{"value"}
+		if value, ok := s.conn.rows[args[0].(string)]; ok {
+			result.rows = [][]driver.Value{{value}}
+		}
+	case strings.HasPrefix(s.query, "SELECT key, value FROM ") && strings.HasSuffix(s.query, "ORDER BY key"):
+		result.columns = []string{"key", "value"}
+		for key, value := range s.conn.rows {
+			if len(args) > 0 && key < args[0].(string) || len(args) > 1 && key >= args[1].(string) {
+				continue
+			}
+			result.rows = append(result.rows, []driver.Value{key, value})
+		}
+		sort.Slice(result.rows, func(i, j int) bool {
+			return result.rows[i][0].(string) < result.rows[j][0].(string)
+		})
+	default:
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+	return result, nil
+}
+
+type testRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *testRows) Columns() []string { return r.columns }
+func (r *testRows) Close() error      { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage keeps an application's data as JSON documents under
+// string keys, in a file or a SQL database, and tells components when
+// documents change:
+//
+//	store, err := storage.NewFileStorage("todos.json")
+//
+//	func (m *model) Init() terminus.Cmd {
+//		return terminus.Batch(storage.Load(m.store, "todo/"), storage.Subscribe(m.store, "todo/"))
+//	}
+//
+// Keys are usually paths like "todo/42", so a prefix lists or watches a
+// collection.
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// ErrNotFound is returned by Get for a key without a document
+var ErrNotFound = errors.New("storage: not found")
+
+// Storage stores JSON documents by key. Implementations are safe for
+// concurrent use, so sessions can share one.
+type Storage interface {
+	// Put stores value, encoded as JSON, under key
+	Put(ctx context.Context, key string, value any) error
+	// Get decodes the document under key into value, or returns
+	// ErrNotFound
+	Get(ctx context.Context, key string, value any) error
+	// Delete removes the document under key, if there is one
+	Delete(ctx context.Context, key string) error
+	// List returns the documents whose keys start with prefix, in key
+	// order
+	List(ctx context.Context, prefix string) ([]Entry, error)
+	// Watch returns a channel receiving the changes made through the
+	// storage to keys starting with prefix, until ctx is done. A watcher
+	// that falls behind receives the latest change of each key.
+	Watch(ctx context.Context, prefix string) <-chan Change
+}
+
+// Entry is a stored document
+type Entry struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// Decode decodes the document into v
+func (e Entry) Decode(v any) error {
+	return json.Unmarshal(e.Value, v)
+}
+
+// Change is a document being stored or deleted
+type Change struct {
+	Key     string
+	Value   json.RawMessage // nil when deleted
+	Deleted bool
+}
+
+// Decode decodes the changed document into v
+func (c Change) Decode(v any) error {
+	return json.Unmarshal(c.Value, v)
+}
+
+// ChangeMsg is sent by Subscribe for each change
+type ChangeMsg struct {
+	Storage Storage // Tells storages apart
+	Change
+}
+
+// LoadedMsg is sent by Load with the documents it read
+type LoadedMsg struct {
+	Storage Storage
+	Prefix  string
+	Entries []Entry
+}
+
+// Load returns a command that lists the documents under prefix and sends
+// them in a LoadedMsg, or an ErrMsg if they can't be read
+func Load(s Storage, prefix string) terminus.Cmd {
+	return func() terminus.Msg {
+		entries, err := s.List(context.Background(), prefix)
+		if err != nil {
+			return terminus.ErrMsg{Err: err, Source: "storage.Load"}
+		}
+		return LoadedMsg{Storage: s, Prefix: prefix, Entries: entries}
+	}
+}
+
+// Save returns a command that stores value under key. It sends nothing
+// unless the value can't be stored, when it sends an ErrMsg.
+func Save(s Storage, key string, value any) terminus.Cmd {
+	return func() terminus.Msg {
+		if err := s.Put(context.Background(), key, value); err != nil {
+			return terminus.ErrMsg{Err: err, Source: "storage.Save"}
+		}
+		return nil
+	}
+}
+
+// Remove returns a command that deletes the document under key. It sends
+// nothing unless the document can't be deleted, when it sends an ErrMsg.
+func Remove(s Storage, key string) terminus.Cmd {
+	return func() terminus.Msg {
+		if err := s.Delete(context.Background(), key); err != nil {
+			return terminus.ErrMsg{Err: err, Source: "storage.Remove"}
+		}
+		return nil
+	}
+}
+
+// Subscribe returns a command that sends a ChangeMsg for each change to a
+// key under prefix, including those made by other sessions, until the
+// session ends
+func Subscribe(s Storage, prefix string) terminus.Cmd {
+	return terminus.Stream(func(ctx context.Context, send func(terminus.Msg)) terminus.Msg {
+		for change := range s.Watch(ctx, prefix) {
+			send(ChangeMsg{Storage: s, Change: change})
+		}
+		return nil
+	})
+}
+
+// watchers delivers changes to the watchers of a storage
+type watchers struct {
+	mu   sync.Mutex
+	subs map[*watcher]struct{}
+}
+
+// watcher is a Watch channel and the changes waiting to be sent on it
+type watcher struct {
+	prefix  string
+	mu      sync.Mutex
+	pending []Change
+	wake    chan struct{}
+}
+
+// watch returns a channel of the changes published under prefix until ctx
+// is done
+func (w *watchers) watch(ctx context.Context, prefix string) <-chan Change {
+	sub := &watcher{prefix: prefix, wake: make(chan struct{}, 1)}
+	w.mu.Lock()
+	if w.subs == nil {
+		w.subs = make(map[*watcher]struct{})
+	}
+	w.subs[sub] = struct{}{}
+	w.mu.Unlock()
+
+	out := make(chan Change)
+	go func() {
+		defer close(out)
+		defer func() {
+			w.mu.Lock()
+			delete(w.subs, sub)
+			w.mu.Unlock()
+		}()
+		for {
+			select {
+			case <-sub.wake:
+			case <-ctx.Done():
+				return
+			}
+			sub.mu.Lock()
+			pending := sub.pending
+			sub.pending = nil
+			sub.mu.Unlock()
+			for _, change := range pending {
+				select {
+				case out <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// publish queues change for the watchers of its key, replacing a change to
+// the same key they haven't received yet
+func (w *watchers) publish(change Change) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.subs {
+		if !strings.HasPrefix(change.Key, sub.prefix) {
+			continue
+		}
+		sub.mu.Lock()
+		queued := false
+		for i, pending := range sub.pending {
+			if pending.Key == change.Key {
+				sub.pending[i], queued = change, true
+			}
+		}
+		if !queued {
+			sub.pending = append(sub.pending, change)
+		}
+		sub.mu.Unlock()
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// todo is the document the storage tests store
+type todo struct {
+	Text string
+	Done bool
+}
+
+// testStorage runs the tests every Storage has to pass against the storage
+// newStorage returns
+func testStorage(t *testing.T, newStorage func(t *testing.T) Storage) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Gets what was put",
+			test: func(t *testing.T) {
+				s := newStorage(t)
+				if err := s.Put(ctx, "todo/1", todo{Text: "Write docs"}); err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Put(ctx, "todo/1", todo{Text: "Write docs", Done: true}); err != nil {
+					t.Fatal(err)
+				}
+				var got todo
+				if err := s.Get(ctx, "todo/1", &got); err != nil {
+					t.Fatal(err)
+				}
+				if got != (todo{Text: "Write docs", Done: true}) {
+					t.Errorf("Expected the latest document, got %+v", got)
+				}
+			},
+		},
+		{
+			name: "Reports missing and deleted keys",
+			test: func(t *testing.T) {
+				s := newStorage(t)
+				var got todo
+				if err := s.Get(ctx, "todo/1", &got); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Expected ErrNotFound, got %v", err)
+				}
+				s.Put(ctx, "todo/1", todo{Text: "Write docs"})
+				if err := s.Delete(ctx, "todo/1"); err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Get(ctx, "todo/1", &got); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Expected ErrNotFound after Delete, got %v", err)
+				}
+				if err := s.Delete(ctx, "todo/1"); err != nil {
+					t.Errorf("Expected deleting a missing key to succeed, got %v", err)
+				}
+			},
+		},
+		{
+			name: "Lists keys under a prefix in order",
+			test: func(t *testing.T) {
+				s := newStorage(t)
+				s.Put(ctx, "todo/2", todo{Text: "Test"})
+				s.Put(ctx, "todo/1", todo{Text: "Write"})
+				s.Put(ctx, "todos", 2)
+				s.Put(ctx, "user/1", "ada")
+
+				entries, err := s.List(ctx, "todo/")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(entries) != 2 || entries[0].Key != "todo/1" || entries[1].Key != "todo/2" {
+					t.Fatalf("Expected todo/1 and todo/2, got %+v", entries)
+				}
+				var got todo
+				if err := entries[1].Decode(&got); err != nil || got.Text != "Test" {
+					t.Errorf("Expected the second todo, got %+v and %v", got, err)
+				}
+				if all, _ := s.List(ctx, ""); len(all) != 4 {
+					t.Errorf("Expected 4 documents in all, got %d", len(all))
+				}
+			},
+		},
+		{
+			name: "Watches changes under a prefix",
+			test: func(t *testing.T) {
+				s := newStorage(t)
+				ctx, cancel := context.WithCancel(ctx)
+				changes := s.Watch(ctx, "todo/")
+				s.Put(ctx, "user/1", "ada")
+				s.Put(ctx, "todo/1", todo{Text: "Write"})
+				s.Delete(ctx, "todo/1")
+
+				// The put is dropped if the delete replaces it before it is
+				// sent
+				change := receive(t, changes)
+				if change.Key == "todo/1" && !change.Deleted {
+					change = receive(t, changes)
+				}
+				if change.Key != "todo/1" || !change.Deleted {
+					t.Errorf("Expected the delete, got %+v", change)
+				}
+				s.Put(ctx, "todo/2", todo{Text: "Test"})
+				change = receive(t, changes)
+				var got todo
+				if change.Key != "todo/2" || change.Decode(&got) != nil || got.Text != "Test" {
+					t.Errorf("Expected todo/2, got %+v", change)
+				}
+
+				cancel()
+				for range changes {
+				}
+			},
+		},
+		{
+			name: "Sends changes as messages",
+			test: func(t *testing.T) {
+				s := newStorage(t)
+				s.Put(ctx, "todo/1", todo{Text: "Write"})
+				msg := Load(s, "todo/")()
+				if loaded, ok := msg.(LoadedMsg); !ok || len(loaded.Entries) != 1 || loaded.Storage != s {
+					t.Fatalf("Expected a LoadedMsg with one entry, got %#v", msg)
+				}
+
+				if msg := Save(s, "todo/2", todo{Text: "Test"})(); msg != nil {
+					t.Errorf("Expected Save to send nothing, got %#v", msg)
+				}
+				if msg := Save(s, "todo/3", func() {})(); !isErr(msg) {
+					t.Errorf("Expected an ErrMsg for a value that can't be stored, got %#v", msg)
+				}
+				if msg := Remove(s, "todo/2")(); msg != nil {
+					t.Errorf("Expected Remove to send nothing, got %#v", msg)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// receive returns the next change, failing if none comes
+func receive(t *testing.T, changes <-chan Change) Change {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("Expected a change")
+		return Change{}
+	}
+}
+
+// isErr returns whether msg is an ErrMsg
+func isErr(msg terminus.Msg) bool {
+	_, ok := msg.(terminus.ErrMsg)
+	return ok
+}
+
+func TestFileStorage(t *testing.T) {
+	testStorage(t, func(t *testing.T) Storage {
+		s, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+
+	t.Run("Reopens what was stored", func(t *testing.T) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "data.json")
+		s, _ := NewFileStorage(path)
+		s.Put(ctx, "todo/1", todo{Text: "Write"})
+
+		reopened, err := NewFileStorage(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got todo
+		if err := reopened.Get(ctx, "todo/1", &got); err != nil || got.Text != "Write" {
+			t.Errorf("Expected the stored todo, got %+v and %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"sync"
+)
+
+// StoreMsg is sent to a Store's subscribers when its state changes
+type StoreMsg[T any] struct {
+	Store *Store[T] // Tells stores of the same type apart
+	State T
+}
+
+// Store holds state shared between goroutines, commands and sessions.
+// Changes are made one at a time, from anywhere, with Set or Update, and
+// reach each subscribed component as a StoreMsg in its update loop, which
+// re-renders it. Components keep the state from the message rather than
+// reading the store from View, so they need no locks of their own.
+//
+// The state is handed out by value; if T holds maps, slices or pointers,
+// change copies of them rather than the values subscribers already have.
+type Store[T any] struct {
+	mu    sync.Mutex
+	state T
+	subs  map[chan T]struct{}
+}
+
+// NewStore creates a store holding initial
+func NewStore[T any](initial T) *Store[T] {
+	return &Store[T]{
+		state: initial,
+		subs:  make(map[chan T]struct{}),
+	}
+}
+
+// Get returns the current state
+func (s *Store[T]) Get() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Set replaces the state and notifies subscribers
+func (s *Store[T]) Set(state T) {
+	s.Update(func(T) T { return state })
+}
+
+// Update replaces the state with fn's result. Updates from different
+// goroutines run one at a time, each seeing the result of the last.
+func (s *Store[T]) Update(fn func(T) T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = fn(s.state)
+	for ch := range s.subs {
+		notify(ch, s.state)
+	}
+}
+
+// Dispatch returns a command that applies fn with Update. The component
+// hears about the change through its subscription, so the command sends no
+// message of its own.
+func (s *Store[T]) Dispatch(fn func(T) T) Cmd {
+	return func() Msg {
+		s.Update(fn)
+		return nil
+	}
+}
+
+// Subscribe returns a command that sends a StoreMsg with the current state,
+// then another after every change until the session ends. Changes made
+// faster than the component handles them are coalesced, so it always
+// receives the latest state but not necessarily every one.
+func (s *Store[T]) Subscribe() Cmd {
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		ch := make(chan T, 1)
+		s.mu.Lock()
+		s.subs[ch] = struct{}{}
+		state := s.state
+		s.mu.Unlock()
+		defer func() {
+			s.mu.Lock()
+			delete(s.subs, ch)
+			s.mu.Unlock()
+		}()
+
+		send(StoreMsg[T]{Store: s, State: state})
+		for {
+			select {
+			case state := <-ch:
+				send(StoreMsg[T]{Store: s, State: state})
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
+
+// Subscribers returns the number of active subscriptions
+func (s *Store[T]) Subscribers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subs)
+}
+
+// notify puts state in ch, replacing any state the subscriber hasn't
+// received yet
+func notify[T any](ch chan T, state T) {
+	for {
+		select {
+		case ch <- state:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// counterComponent shows the count from a store it subscribes to
+type counterComponent struct {
+	store *Store[int]
+	count int
+}
+
+func (c *counterComponent) Init() Cmd { return c.store.Subscribe() }
+
+func (c *counterComponent) Update(msg Msg) (Component, Cmd) {
+	if msg, ok := msg.(StoreMsg[int]); ok && msg.Store == c.store {
+		c.count = msg.State
+	}
+	return c, nil
+}
+
+func (c *counterComponent) View() string { return fmt.Sprintf("count: %d", c.count) }
+
+func TestStore(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Serializes concurrent updates",
+			test: func(t *testing.T) {
+				store := NewStore(0)
+				var wg sync.WaitGroup
+				for i := 0; i < 100; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						store.Dispatch(func(n int) int { return n + 1 })()
+					}()
+				}
+				wg.Wait()
+				if store.Get() != 100 {
+					t.Errorf("Expected 100, got %d", store.Get())
+				}
+			},
+		},
+		{
+			name: "Subscribers get the current and latest state",
+			test: func(t *testing.T) {
+				store := NewStore("a")
+				ctx, cancel := context.WithCancel(context.Background())
+				received := make(chan Msg, 10)
+				done := make(chan struct{})
+				go func() {
+					runCmd(ctx, store.Subscribe(), func(m Msg) { received <- m })
+					close(done)
+				}()
+
+				if msg := (<-received).(StoreMsg[string]); msg.State != "a" || msg.Store != store {
+					t.Errorf("Expected the initial state, got %+v", msg)
+				}
+				store.Set("b")
+				if msg := (<-received).(StoreMsg[string]); msg.State != "b" {
+					t.Errorf("Expected the new state, got %q", msg.State)
+				}
+
+				cancel()
+				<-done
+				if store.Subscribers() != 0 {
+					t.Errorf("Expected the subscription to end, got %d", store.Subscribers())
+				}
+			},
+		},
+		{
+			name: "Pending changes are coalesced",
+			test: func(t *testing.T) {
+				ch := make(chan int, 1)
+				notify(ch, 1)
+				notify(ch, 2)
+				if got := <-ch; got != 2 {
+					t.Errorf("Expected the latest state, got %d", got)
+				}
+			},
+		},
+		{
+			name: "Changes re-render subscribed components",
+			test: func(t *testing.T) {
+				store := NewStore(0)
+				engine := NewEngine(&counterComponent{store: store})
+				views := make(chan string, 100)
+				engine.SetRenderCallback(func(view string) { views <- view })
+				engine.Start()
+				defer engine.Stop()
+
+				go store.Update(func(n int) int { return n + 5 })
+				deadline := time.After(2 * time.Second)
+				for {
+					select {
+					case view := <-views:
+						if view == "count: 5" {
+							return
+						}
+					case <-deadline:
+						t.Fatal("Expected the component to show the new count")
+					}
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -18,8 +18,17 @@ import "github.com/skaiser/terminusgo/pkg/terminus/style"
 
 // Style exports
 type (
-	Style = style.Style
-	Color = style.Color
+	Style        = style.Style
+	Color        = style.Color
+	ColorProfile = style.ColorProfile
+)
+
+// Color profiles
+const (
+	TrueColor  = style.TrueColor
+	Colors256  = style.Colors256
+	Colors16   = style.Colors16
+	Monochrome = style.Monochrome
 )
 
 // Style constructors
@@ -30,6 +39,16 @@ var (
 	ColorFromString = style.ColorFromString
 	ANSI256         = style.ANSI256
 	RGB             = style.RGB
+	Blend           = style.Blend
+	Gradient        = style.Gradient
+
+	// Color degradation
+	ProfileForDepth = style.ProfileForDepth
+
+	// Text measurement
+	StringWidth  = style.Width
+	RuneWidth    = style.RuneWidth
+	SetRuneWidth = style.SetRuneWidth
 	
 	// Predefined colors
 	Black         = style.Black
@@ -221,4 +221,113 @@ func clamp(v, min, max int) int {
 		return max
 	}
 	return v
-}
\ No newline at end of file
+}
+
+// namedRGB holds the xterm default values of the named colors
+var namedRGB = map[string][3]int{
+	"30": {0, 0, 0},
+	"31": {205, 0, 0},
+	"32": {0, 205, 0},
+	"33": {205, 205, 0},
+	"34": {0, 0, 238},
+	"35": {205, 0, 205},
+	"36": {0, 205, 205},
+	"37": {229, 229, 229},
+	"90": {127, 127, 127},
+	"91": {255, 0, 0},
+	"92": {0, 255, 0},
+	"93": {255, 255, 0},
+	"94": {92, 92, 255},
+	"95": {255, 0, 255},
+	"96": {0, 255, 255},
+	"97": {255, 255, 255},
+}
+
+// rgb returns the red, green and blue components of the color. Named and
+// ANSI 256 colors use the xterm default palette.
+func (c Color) rgb() (r, g, b int) {
+	switch c.colorType {
+	case rgbColor:
+		fmt.Sscanf(c.value, "%d;%d;%d", &r, &g, &b)
+		return r, g, b
+	case ansi256Color:
+		var n int
+		fmt.Sscanf(c.value, "%d", &n)
+		switch {
+		case n < 8:
+			v := namedRGB[fmt.Sprintf("%d", 30+n)]
+			return v[0], v[1], v[2]
+		case n < 16:
+			v := namedRGB[fmt.Sprintf("%d", 82+n)]
+			return v[0], v[1], v[2]
+		case n < 232:
+			// 6x6x6 color cube
+			level := func(i int) int {
+				if i == 0 {
+					return 0
+				}
+				return 55 + i*40
+			}
+			n -= 16
+			return level(n / 36), level(n / 6 % 6), level(n % 6)
+		default:
+			v := 8 + (n-232)*10
+			return v, v, v
+		}
+	default:
+		v, ok := namedRGB[c.value]
+		if !ok {
+			v = namedRGB["37"]
+		}
+		return v[0], v[1], v[2]
+	}
+}
+
+// Hex returns the color as a CSS hex color, e.g. "#cd0000". Named and
+// ANSI 256 colors use the xterm default palette.
+func (c Color) Hex() string {
+	r, g, b := c.rgb()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// Blend returns the color a fraction t of the way from a to b, mixed in RGB.
+// t is clamped to 0-1.
+func Blend(a, b Color, t float64) Color {
+	if t <= 0 {
+		return a
+	}
+	if t >= 1 {
+		return b
+	}
+	ar, ag, ab := a.rgb()
+	br, bg, bb := b.rgb()
+	mix := func(x, y int) int {
+		return x + int(float64(y-x)*t+0.5)
+	}
+	return RGB(mix(ar, br), mix(ag, bg), mix(ab, bb))
+}
+
+// Gradient returns n colors spread evenly through the stops, starting at
+// the first and ending at the last
+func Gradient(n int, stops ...Color) []Color {
+	if n <= 0 || len(stops) == 0 {
+		return nil
+	}
+	colors := make([]Color, n)
+	if len(stops) == 1 || n == 1 {
+		for i := range colors {
+			colors[i] = stops[0]
+		}
+		return colors
+	}
+	segments := float64(len(stops) - 1)
+	for i := range colors {
+		pos := float64(i) / float64(n-1) * segments
+		seg := int(pos)
+		if seg >= len(stops)-1 {
+			seg = len(stops) - 2
+		}
+		colors[i] = Blend(stops[seg], stops[seg+1], pos-float64(seg))
+	}
+	return colors
+}
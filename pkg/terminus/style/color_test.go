@@ -209,4 +209,50 @@ func TestClamp(t *testing.T) {
 			t.Errorf("clamp(%d, %d, %d) = %d, expected %d", tt.v, tt.min, tt.max, result, tt.expected)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestBlend(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Color
+		t        float64
+		expected Color
+	}{
+		{name: "Start", a: RGB(0, 0, 0), b: RGB(255, 255, 255), t: 0, expected: RGB(0, 0, 0)},
+		{name: "End", a: RGB(0, 0, 0), b: RGB(255, 255, 255), t: 1, expected: RGB(255, 255, 255)},
+		{name: "Middle", a: RGB(0, 0, 0), b: RGB(200, 100, 50), t: 0.5, expected: RGB(100, 50, 25)},
+		{name: "Clamped", a: RGB(0, 0, 0), b: RGB(10, 10, 10), t: 2, expected: RGB(10, 10, 10)},
+		{name: "Named colors", a: Black, b: BrightWhite, t: 0.5, expected: RGB(128, 128, 128)},
+		{name: "ANSI cube", a: ANSI256(16), b: ANSI256(196), t: 0.5, expected: RGB(128, 0, 0)},
+		{name: "ANSI grayscale", a: ANSI256(232), b: ANSI256(255), t: 0.5, expected: RGB(123, 123, 123)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Blend(tt.a, tt.b, tt.t)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGradient(t *testing.T) {
+	colors := Gradient(5, RGB(0, 0, 0), RGB(100, 0, 0), RGB(100, 100, 0))
+	expected := []Color{RGB(0, 0, 0), RGB(50, 0, 0), RGB(100, 0, 0), RGB(100, 50, 0), RGB(100, 100, 0)}
+	if len(colors) != len(expected) {
+		t.Fatalf("Expected %d colors, got %d", len(expected), len(colors))
+	}
+	for i := range expected {
+		if colors[i] != expected[i] {
+			t.Errorf("Color %d: expected %v, got %v", i, expected[i], colors[i])
+		}
+	}
+
+	if colors := Gradient(3, Red); len(colors) != 3 || colors[2] != Red {
+		t.Errorf("Expected a single stop repeated, got %v", colors)
+	}
+	if Gradient(0, Red, Blue) != nil {
+		t.Error("Expected no colors for n = 0")
+	}
+}
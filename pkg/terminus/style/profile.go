@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import "sync"
+
+// ColorProfile is the set of colors a client can display. Colors outside
+// it are mapped to the nearest one inside it.
+type ColorProfile int
+
+const (
+	// TrueColor displays any RGB color; colors are left as they are
+	TrueColor ColorProfile = iota
+	// Colors256 displays the xterm 256 color palette
+	Colors256
+	// Colors16 displays the 16 named colors
+	Colors16
+	// Monochrome displays no colors
+	Monochrome
+)
+
+// ProfileForDepth returns the profile of a display with bits bits per
+// pixel, e.g. 24 for TrueColor or 8 for Colors256. Unknown depths, 0 or
+// less, are taken as TrueColor.
+func ProfileForDepth(bits int) ColorProfile {
+	switch {
+	case bits <= 0 || bits >= 24:
+		return TrueColor
+	case bits >= 8:
+		return Colors256
+	case bits >= 4:
+		return Colors16
+	default:
+		return Monochrome
+	}
+}
+
+// String returns the name of the profile
+func (p ColorProfile) String() string {
+	switch p {
+	case TrueColor:
+		return "truecolor"
+	case Colors256:
+		return "256"
+	case Colors16:
+		return "16"
+	case Monochrome:
+		return "monochrome"
+	default:
+		return "unknown"
+	}
+}
+
+// Convert returns the color of the profile that looks closest to c. Named
+// colors are kept by every profile but Monochrome, which has no colors and
+// returns c unchanged; use Style.Degrade to drop them.
+func (p ColorProfile) Convert(c Color) Color {
+	switch {
+	case p == TrueColor || p == Monochrome || c.colorType == namedColor:
+		return c
+	case p == Colors256 && c.colorType == ansi256Color:
+		return c
+	}
+
+	key := convertKey{c, p}
+	conversions.RLock()
+	converted, ok := conversions.m[key]
+	conversions.RUnlock()
+	if ok {
+		return converted
+	}
+
+	r, g, b := c.rgb()
+	if p == Colors256 {
+		converted = nearest256(r, g, b)
+	} else {
+		converted = nearest16(r, g, b)
+	}
+	conversions.Lock()
+	if len(conversions.m) < maxCachedStyles {
+		conversions.m[key] = converted
+	}
+	conversions.Unlock()
+	return converted
+}
+
+// convertKey identifies a color converted to a profile
+type convertKey struct {
+	color   Color
+	profile ColorProfile
+}
+
+// conversions caches converted colors, as a frame converts the same few
+// colors cell after cell
+var conversions = struct {
+	sync.RWMutex
+	m map[convertKey]Color
+}{m: make(map[convertKey]Color)}
+
+// monochromeBackground is the luminance, 0-255, above which a background
+// stands out from a dark terminal and is kept as reverse video
+const monochromeBackground = 40
+
+// Degrade returns the style with its colors converted to the profile. A
+// Monochrome style has no colors; a background that stands out, e.g. a
+// selection or highlight, becomes reverse video instead, so it still
+// stands out.
+func (s Style) Degrade(p ColorProfile) Style {
+	if p == Monochrome {
+		if s.background != nil && luminance(s.background.rgb()) > monochromeBackground {
+			s.reverse = !s.reverse
+		}
+		s.foreground, s.background = nil, nil
+		return s
+	}
+	if s.foreground != nil {
+		if c := p.Convert(*s.foreground); c != *s.foreground {
+			s.foreground = &c
+		}
+	}
+	if s.background != nil {
+		if c := p.Convert(*s.background); c != *s.background {
+			s.background = &c
+		}
+	}
+	return s
+}
+
+// luminance returns the perceived brightness of a color, 0-255
+func luminance(r, g, b int) int {
+	return (299*r + 587*g + 114*b) / 1000
+}
+
+// distance returns how different two colors look, weighting the channels
+// by the average red as the eye is more sensitive to green and, in reds,
+// to red
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	rmean := (r1 + r2) / 2
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return ((512+rmean)*dr*dr)>>8 + 4*dg*dg + ((767-rmean)*db*db)>>8
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube of the 256
+// color palette
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// nearest256 returns the color of the 256 color palette closest to the RGB
+// color, from the color cube or the gray ramp. The first 16 colors are
+// skipped, as terminals theme them.
+func nearest256(r, g, b int) Color {
+	level := func(v int) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(v-l) < abs(v-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	cr, cg, cb := level(r), level(g), level(b)
+	cube := 16 + 36*cr + 6*cg + cb
+	cubeDist := distance(r, g, b, cubeLevels[cr], cubeLevels[cg], cubeLevels[cb])
+
+	// The gray ramp runs from 8 to 238 in steps of 10
+	gray := clamp(((r+g+b)/3-3)/10, 0, 23)
+	v := 8 + gray*10
+	if distance(r, g, b, v, v, v) < cubeDist {
+		return ANSI256(232 + gray)
+	}
+	return ANSI256(cube)
+}
+
+// nearest16 returns the named color closest to the RGB color. Ties go to
+// the lower code, as the map is iterated in random order.
+func nearest16(r, g, b int) Color {
+	best, bestDist := White, -1
+	for code, v := range namedRGB {
+		d := distance(r, g, b, v[0], v[1], v[2])
+		if bestDist < 0 || d < bestDist || d == bestDist && code < best.value {
+			best, bestDist = Color{value: code, colorType: namedColor}, d
+		}
+	}
+	return best
+}
+
+// abs returns the absolute value of v
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
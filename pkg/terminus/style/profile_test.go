@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import "testing"
+
+func TestProfileForDepth(t *testing.T) {
+	tests := []struct {
+		bits     int
+		expected ColorProfile
+	}{
+		{0, TrueColor},
+		{24, TrueColor},
+		{30, TrueColor},
+		{8, Colors256},
+		{4, Colors16},
+		{1, Monochrome},
+	}
+
+	for _, tt := range tests {
+		if got := ProfileForDepth(tt.bits); got != tt.expected {
+			t.Errorf("Expected %d bits to be %v, got %v", tt.bits, tt.expected, got)
+		}
+	}
+}
+
+func TestColorProfileConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  ColorProfile
+		color    Color
+		expected Color
+	}{
+		{"True color keeps RGB", TrueColor, RGB(255, 128, 0), RGB(255, 128, 0)},
+		{"RGB to the color cube", Colors256, RGB(255, 128, 0), ANSI256(208)},
+		{"RGB gray to the gray ramp", Colors256, RGB(100, 100, 100), ANSI256(241)},
+		{"256 keeps 256", Colors256, ANSI256(42), ANSI256(42)},
+		{"Named colors are kept", Colors16, Red, Red},
+		{"RGB to named", Colors16, RGB(250, 10, 10), BrightRed},
+		{"256 to named", Colors16, ANSI256(16), Black},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Twice, the second time from the cache
+			for range 2 {
+				if got := tt.profile.Convert(tt.color); got != tt.expected {
+					t.Errorf("Expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStyleDegrade(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		profile  ColorProfile
+		expected string
+	}{
+		{"Converts both colors", New().Bold(true).Foreground(RGB(255, 128, 0)).Background(RGB(100, 100, 100)), Colors256, "Style{bold, fg:ansi256(208), bg:ansi256(241)}"},
+		{"Drops colors", New().Foreground(Red), Monochrome, "Style{}"},
+		{"Highlights become reverse", New().Foreground(Red).Background(ANSI256(237)), Monochrome, "Style{reverse}"},
+		{"Dark backgrounds are dropped", New().Background(RGB(20, 20, 20)), Monochrome, "Style{}"},
+		{"Reversed highlights are restored", New().Reverse(true).Background(White), Monochrome, "Style{}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Degrade(tt.profile).String(); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
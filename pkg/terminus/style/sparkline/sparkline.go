@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sparkline draws series of values as compact strings of block
+// characters, small enough to sit in a table cell or beside a label:
+//
+//	row := widget.TableRow{
+//		widget.NewSimpleTableCell(p.Name),
+//		widget.NewSimpleTableCell(sparkline.RenderRange(p.CPUHistory, 10, 0, 100)),
+//	}
+package sparkline
+
+import "math"
+
+// levels are the blocks drawn from the lowest value to the highest
+var levels = []rune("▁▂▃▄▅▆▇█")
+
+// Render returns the last width values as a sparkline scaled from the
+// lowest of them to the highest. A series of equal values is drawn at the
+// bottom, and NaNs as spaces. The result is shorter than width when there
+// are fewer values.
+func Render(values []float64, width int) string {
+	values = last(values, width)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	return render(values, lo, hi)
+}
+
+// RenderRange is like Render but scales values from min to max, so the
+// sparklines of different series, e.g. one per table row, compare.
+// Values outside the range are drawn at its ends.
+func RenderRange(values []float64, width int, min, max float64) string {
+	return render(last(values, width), min, max)
+}
+
+// last returns the last n values
+func last(values []float64, n int) []float64 {
+	if n <= 0 {
+		return nil
+	}
+	if len(values) > n {
+		return values[len(values)-n:]
+	}
+	return values
+}
+
+// render draws values scaled from lo to hi
+func render(values []float64, lo, hi float64) string {
+	line := make([]rune, len(values))
+	top := float64(len(levels) - 1)
+	for i, v := range values {
+		switch {
+		case math.IsNaN(v):
+			line[i] = ' '
+		case hi <= lo:
+			line[i] = levels[0]
+		default:
+			level := math.Round((v - lo) / (hi - lo) * top)
+			line[i] = levels[int(math.Max(0, math.Min(top, level)))]
+		}
+	}
+	return string(line)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		expected string
+	}{
+		{"Scales from lowest to highest", []float64{0, 1, 2, 3, 4, 5, 6, 7}, 10, "▁▂▃▄▅▆▇█"},
+		{"Keeps the last values", []float64{100, 0, 5, 10}, 3, "▁▅█"},
+		{"Draws equal values at the bottom", []float64{3, 3, 3}, 5, "▁▁▁"},
+		{"Leaves gaps for NaNs", []float64{0, math.NaN(), 7}, 5, "▁ █"},
+		{"Is empty without room", []float64{1, 2}, 0, ""},
+		{"Is empty without values", nil, 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.values, tt.width); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderRange(t *testing.T) {
+	if got := RenderRange([]float64{-10, 0, 50, 100, 200}, 10, 0, 100); got != "▁▁▅██" {
+		t.Errorf("Expected values clamped to the range, got %q", got)
+	}
+}
@@ -17,17 +17,20 @@ package style
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // Style represents text styling attributes
 type Style struct {
-	bold      bool
-	faint     bool
-	italic    bool
-	underline bool
-	crossOut  bool
-	reverse   bool
-	blink     bool
+	bold            bool
+	faint           bool
+	italic          bool
+	underline       bool
+	doubleUnderline bool
+	overline        bool
+	crossOut        bool
+	reverse         bool
+	blink           bool
 	
 	foreground *Color
 	background *Color
@@ -62,6 +65,19 @@ func (s Style) Underline(v bool) Style {
 	return s
 }
 
+// DoubleUnderline sets the double underline attribute. Terminals that
+// don't support it draw a single underline.
+func (s Style) DoubleUnderline(v bool) Style {
+	s.doubleUnderline = v
+	return s
+}
+
+// Overline sets the overline attribute, a line above the text
+func (s Style) Overline(v bool) Style {
+	s.overline = v
+	return s
+}
+
 // CrossOut sets the strikethrough attribute
 func (s Style) CrossOut(v bool) Style {
 	s.crossOut = v
@@ -94,54 +110,321 @@ func (s Style) Background(c Color) Style {
 
 // Render applies the style to the given text and returns styled string
 func (s Style) Render(text string) string {
-	if text == "" {
-		return ""
+	if text == "" || !s.styled() {
+		return text
 	}
-	
-	// Build style codes
-	var codes []string
-	
+	return string(s.AppendRender(make([]byte, 0, len(text)+24), text))
+}
+
+// AppendRender appends text rendered in the style to dst, as Render returns
+// it, and returns the extended buffer
+func (s Style) AppendRender(dst []byte, text string) []byte {
+	return s.AppendPadded(dst, text, 0, 0)
+}
+
+// AppendPadded appends text with left spaces before it and right spaces
+// after it, rendered in the style as a whole, to dst and returns the
+// extended buffer. Views use it to write aligned cells without building the
+// padded text first.
+func (s Style) AppendPadded(dst []byte, text string, left, right int) []byte {
+	if text == "" && left <= 0 && right <= 0 {
+		return dst
+	}
+	styled := s.styled()
+	if styled {
+		dst = s.appendCodes(dst)
+	}
+	dst = appendSpaces(dst, left)
+	dst = append(dst, text...)
+	dst = appendSpaces(dst, right)
+	if styled {
+		dst = append(dst, "\x1b[0m"...)
+	}
+	return dst
+}
+
+// styled reports whether the style sets any attribute
+func (s Style) styled() bool {
+	return s.bold || s.faint || s.italic || s.underline || s.doubleUnderline || s.overline ||
+		s.blink || s.reverse || s.crossOut || s.foreground != nil || s.background != nil
+}
+
+// appendCodes appends the escape sequence that resets the terminal to the
+// style
+func (s Style) appendCodes(dst []byte) []byte {
+	return append(dst, s.sequence()...)
+}
+
+// maxCachedStyles bounds the sequences cached, so apps rendering endless
+// colors, e.g. animated gradients, don't grow the cache without bound
+const maxCachedStyles = 4096
+
+// styleKey identifies a style by value, as Style holds its colors by
+// pointer
+type styleKey struct {
+	bold, faint, italic, underline, doubleUnderline, overline, blink, reverse, crossOut bool
+
+	fg, bg       Color
+	hasFg, hasBg bool
+}
+
+// sequences caches the escape sequence of each style rendered, since hot
+// render loops, e.g. a table's cells, use the same few styles over and over
+var sequences = struct {
+	sync.RWMutex
+	m map[styleKey]string
+}{m: make(map[styleKey]string)}
+
+// sequence returns the escape sequence that resets the terminal to the
+// style, from the cache if it was rendered before
+func (s Style) sequence() string {
+	key := styleKey{
+		bold: s.bold, faint: s.faint, italic: s.italic, underline: s.underline,
+		doubleUnderline: s.doubleUnderline, overline: s.overline,
+		blink: s.blink, reverse: s.reverse, crossOut: s.crossOut,
+	}
+	if s.foreground != nil {
+		key.fg, key.hasFg = *s.foreground, true
+	}
+	if s.background != nil {
+		key.bg, key.hasBg = *s.background, true
+	}
+
+	sequences.RLock()
+	seq, ok := sequences.m[key]
+	sequences.RUnlock()
+	if ok {
+		return seq
+	}
+
+	seq = s.compile()
+	sequences.Lock()
+	if len(sequences.m) < maxCachedStyles {
+		sequences.m[key] = seq
+	}
+	sequences.Unlock()
+	return seq
+}
+
+// compile builds the style's escape sequence
+func (s Style) compile() string {
 	// Reset all styles first
-	startCodes := []string{"0"}
-	
+	codes := []byte("\x1b[0")
+
 	// Text attributes
 	if s.bold {
-		startCodes = append(startCodes, "1")
+		codes = append(codes, ";1"...)
 	}
 	if s.faint {
-		startCodes = append(startCodes, "2")
+		codes = append(codes, ";2"...)
 	}
 	if s.italic {
-		startCodes = append(startCodes, "3")
+		codes = append(codes, ";3"...)
 	}
 	if s.underline {
-		startCodes = append(startCodes, "4")
+		codes = append(codes, ";4"...)
 	}
 	if s.blink {
-		startCodes = append(startCodes, "5")
+		codes = append(codes, ";5"...)
 	}
 	if s.reverse {
-		startCodes = append(startCodes, "7")
+		codes = append(codes, ";7"...)
 	}
 	if s.crossOut {
-		startCodes = append(startCodes, "9")
+		codes = append(codes, ";9"...)
 	}
-	
+	if s.doubleUnderline {
+		codes = append(codes, ";21"...)
+	}
+	if s.overline {
+		codes = append(codes, ";53"...)
+	}
+
 	// Colors
 	if s.foreground != nil {
-		startCodes = append(startCodes, s.foreground.Foreground())
+		codes = append(codes, ';')
+		codes = append(codes, s.foreground.Foreground()...)
 	}
 	if s.background != nil {
-		startCodes = append(startCodes, s.background.Background())
+		codes = append(codes, ';')
+		codes = append(codes, s.background.Background()...)
+	}
+	return string(append(codes, 'm'))
+}
+
+// Equal reports whether s and other render alike
+func (s Style) Equal(other Style) bool {
+	return s.bold == other.bold && s.faint == other.faint && s.italic == other.italic &&
+		s.underline == other.underline && s.blink == other.blink && s.reverse == other.reverse &&
+		s.crossOut == other.crossOut && s.doubleUnderline == other.doubleUnderline &&
+		s.overline == other.overline &&
+		colorsEqual(s.foreground, other.foreground) && colorsEqual(s.background, other.background)
+}
+
+// colorsEqual reports whether two colors, either of which may be unset, are
+// the same
+func colorsEqual(a, b *Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// AppendTransition appends the shortest escape sequence that changes text
+// rendered in from to text rendered in s, and returns the extended buffer.
+// Only the attributes that differ are set or cleared, unless resetting the
+// terminal and setting s whole is shorter. Nothing is appended when the
+// styles are equal.
+func (s Style) AppendTransition(dst []byte, from Style) []byte {
+	if s.Equal(from) {
+		return dst
+	}
+	if !s.styled() {
+		return append(dst, "\x1b[0m"...)
+	}
+
+	var codes [64]byte
+	delta := codes[:0]
+	add := func(code string) {
+		if len(delta) > 0 {
+			delta = append(delta, ';')
+		}
+		delta = append(delta, code...)
+	}
+
+	// Bold and faint are cleared together
+	if from.bold && !s.bold || from.faint && !s.faint {
+		add("22")
+		if s.bold {
+			add("1")
+		}
+		if s.faint {
+			add("2")
+		}
+	} else {
+		if s.bold && !from.bold {
+			add("1")
+		}
+		if s.faint && !from.faint {
+			add("2")
+		}
+	}
+	toggle := func(was, is bool, on, off string) {
+		switch {
+		case is && !was:
+			add(on)
+		case was && !is:
+			add(off)
+		}
 	}
+	toggle(from.italic, s.italic, "3", "23")
+
+	// Single and double underlines are cleared together
+	if from.underline && !s.underline || from.doubleUnderline && !s.doubleUnderline {
+		add("24")
+		if s.underline {
+			add("4")
+		}
+		if s.doubleUnderline {
+			add("21")
+		}
+	} else {
+		toggle(from.underline, s.underline, "4", "24")
+		toggle(from.doubleUnderline, s.doubleUnderline, "21", "24")
+	}
+	toggle(from.blink, s.blink, "5", "25")
+	toggle(from.reverse, s.reverse, "7", "27")
+	toggle(from.crossOut, s.crossOut, "9", "29")
+	toggle(from.overline, s.overline, "53", "55")
+
+	if !colorsEqual(from.foreground, s.foreground) {
+		if s.foreground == nil {
+			add("39")
+		} else {
+			add(s.foreground.Foreground())
+		}
+	}
+	if !colorsEqual(from.background, s.background) {
+		if s.background == nil {
+			add("49")
+		} else {
+			add(s.background.Background())
+		}
+	}
+
+	// A reset also clears what was set, so it's shorter when most changed
+	if reset := s.sequence(); len(reset) <= len(delta)+3 {
+		return append(dst, reset...)
+	}
+	dst = append(dst, "\x1b["...)
+	dst = append(dst, delta...)
+	return append(dst, 'm')
+}
+
+// appendSpaces appends n spaces to dst
+func appendSpaces(dst []byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// CSS returns the style as CSS declarations for an HTML element, e.g.
+// "font-weight:bold;color:#cd0000". Reverse video swaps the colors; an
+// unset color is taken from the CSS variables --terminus-fg and
+// --terminus-bg, which should hold the page's default colors.
+func (s Style) CSS() string {
+	var decls []string
 	
-	// Apply styles
-	if len(startCodes) > 1 || startCodes[0] != "0" {
-		codes = append(codes, strings.Join(startCodes, ";"))
-		return fmt.Sprintf("\x1b[%sm%s\x1b[0m", strings.Join(codes, ";"), text)
+	fg, bg := "", ""
+	if s.foreground != nil {
+		fg = s.foreground.Hex()
+	}
+	if s.background != nil {
+		bg = s.background.Hex()
+	}
+	if s.reverse {
+		if fg == "" {
+			fg = "var(--terminus-fg)"
+		}
+		if bg == "" {
+			bg = "var(--terminus-bg)"
+		}
+		fg, bg = bg, fg
+	}
+	if fg != "" {
+		decls = append(decls, "color:"+fg)
+	}
+	if bg != "" {
+		decls = append(decls, "background:"+bg)
 	}
 	
-	return text
+	if s.bold {
+		decls = append(decls, "font-weight:bold")
+	}
+	if s.faint {
+		decls = append(decls, "opacity:0.6")
+	}
+	if s.italic {
+		decls = append(decls, "font-style:italic")
+	}
+	var lines []string
+	if s.underline || s.doubleUnderline {
+		lines = append(lines, "underline")
+	}
+	if s.overline {
+		lines = append(lines, "overline")
+	}
+	if s.crossOut {
+		lines = append(lines, "line-through")
+	}
+	if s.doubleUnderline {
+		lines = append(lines, "double")
+	}
+	if len(lines) > 0 {
+		decls = append(decls, "text-decoration:"+strings.Join(lines, " "))
+	}
+	return strings.Join(decls, ";")
 }
 
 // String returns the style as a string representation
@@ -160,6 +443,12 @@ func (s Style) String() string {
 	if s.underline {
 		attrs = append(attrs, "underline")
 	}
+	if s.doubleUnderline {
+		attrs = append(attrs, "doubleunderline")
+	}
+	if s.overline {
+		attrs = append(attrs, "overline")
+	}
 	if s.crossOut {
 		attrs = append(attrs, "crossout")
 	}
@@ -15,6 +15,7 @@
 package style
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -108,6 +109,13 @@ func TestStyleBuilder(t *testing.T) {
 			text:     "Blink",
 			contains: []string{"\x1b[", "5", "Blink", "\x1b[0m"},
 		},
+		{
+			name:     "Double underline and overline",
+			style:    New().DoubleUnderline(true).Overline(true),
+			text:     "Lines",
+			expected: "\x1b[0;21;53mLines\x1b[0m",
+			exact:    true,
+		},
 	}
 	
 	for _, tt := range tests {
@@ -167,6 +175,28 @@ func TestStyleString(t *testing.T) {
 	}
 }
 
+func TestStyleCSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{name: "Empty style", style: New(), expected: ""},
+		{name: "Colors", style: New().Foreground(Red).Background(RGB(0, 0, 255)), expected: "color:#cd0000;background:#0000ff"},
+		{name: "Attributes", style: New().Bold(true).Underline(true).CrossOut(true), expected: "font-weight:bold;text-decoration:underline line-through"},
+		{name: "Reverse", style: New().Reverse(true).Foreground(Green), expected: "color:var(--terminus-bg);background:#00cd00"},
+		{name: "Lines", style: New().DoubleUnderline(true).Overline(true).CrossOut(true), expected: "text-decoration:underline overline line-through double"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.style.CSS(); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestStyleChaining(t *testing.T) {
 	// Test that style methods can be chained
 	style := New().
@@ -174,6 +204,8 @@ func TestStyleChaining(t *testing.T) {
 		Italic(true).
 		Underline(true).
 		CrossOut(true).
+		DoubleUnderline(true).
+		Overline(true).
 		Faint(true).
 		Reverse(true).
 		Blink(true).
@@ -182,7 +214,7 @@ func TestStyleChaining(t *testing.T) {
 	
 	// Test that all attributes are set
 	result := style.String()
-	expected := []string{"bold", "faint", "italic", "underline", "crossout", "reverse", "blink", "fg:red", "bg:blue"}
+	expected := []string{"bold", "faint", "italic", "underline", "doubleunderline", "overline", "crossout", "reverse", "blink", "fg:red", "bg:blue"}
 	
 	for _, attr := range expected {
 		if !strings.Contains(result, attr) {
@@ -204,4 +236,101 @@ func TestStyleImmutability(t *testing.T) {
 	if bold.String() != "Style{bold}" {
 		t.Error("Bold style not correctly set")
 	}
-}
\ No newline at end of file
+}
+
+func TestStyleAppendPadded(t *testing.T) {
+	tests := []struct {
+		name        string
+		style       Style
+		text        string
+		left, right int
+		expected    string
+	}{
+		{"Plain", New(), "ab", 1, 2, " ab  "},
+		{"Styled", New().Bold(true).Foreground(Red), "ab", 0, 1, "\x1b[0;1;31mab \x1b[0m"},
+		{"Padding only", New().Reverse(true), "", 2, 0, "\x1b[0;7m  \x1b[0m"},
+		{"Empty", New().Bold(true), "", 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(tt.style.AppendPadded([]byte(">"), tt.text, tt.left, tt.right))
+			if got != ">"+tt.expected {
+				t.Errorf("Expected %q, got %q", ">"+tt.expected, got)
+			}
+			if tt.left == 0 && tt.right == 0 {
+				if render := tt.style.Render(tt.text); string(tt.style.AppendRender(nil, tt.text)) != render {
+					t.Errorf("Expected AppendRender to match Render %q", render)
+				}
+			}
+		})
+	}
+}
+
+func TestStyleSequenceCache(t *testing.T) {
+	orange := New().Foreground(RGB(255, 128, 0)).Render("x")
+	if again := New().Foreground(RGB(255, 128, 0)).Render("x"); again != orange {
+		t.Errorf("Expected equal styles to render alike, got %q and %q", orange, again)
+	}
+	if blue := New().Foreground(RGB(0, 0, 255)).Render("x"); blue == orange {
+		t.Errorf("Expected different colors to render differently, got %q", blue)
+	}
+
+	// Styles past the cache's bound are still rendered
+	for i := 0; i < maxCachedStyles+10; i++ {
+		want := fmt.Sprintf("\x1b[0;38;2;%d;%d;%dmx\x1b[0m", i%256, i/256, 7)
+		if got := New().Foreground(RGB(i%256, i/256, 7)).Render("x"); got != want {
+			t.Fatalf("Expected %q, got %q", want, got)
+		}
+	}
+	if n := len(sequences.m); n > maxCachedStyles {
+		t.Errorf("Expected at most %d cached sequences, got %d", maxCachedStyles, n)
+	}
+}
+
+func TestStyleAppendTransition(t *testing.T) {
+	bold := New().Bold(true)
+	tests := []struct {
+		name     string
+		from, to Style
+		expected string
+	}{
+		{"Equal", bold.Foreground(Red), bold.Foreground(Red), ""},
+		{"To default", bold, New(), "\x1b[0m"},
+		{"Adds attributes", bold, bold.Italic(true).Background(Blue), "\x1b[3;44m"},
+		{"Clears faint keeping bold", bold.Faint(true).Underline(true).Foreground(Red), bold.Underline(true).Foreground(Red), "\x1b[22;1m"},
+		{"Clears colors", bold.Italic(true).Underline(true).Foreground(RGB(1, 2, 3)).Background(Red), bold.Italic(true).Underline(true), "\x1b[39;49m"},
+		{"Adds lines", bold, bold.Overline(true).CrossOut(true), "\x1b[9;53m"},
+		{"Clears overline", bold.Overline(true).Foreground(Red), bold.Foreground(Red), "\x1b[55m"},
+		{"Swaps underlines", bold.Underline(true).Italic(true).Foreground(Red), bold.DoubleUnderline(true).Italic(true).Foreground(Red), "\x1b[24;21m"},
+		{"Resets when shorter", bold.Italic(true).Underline(true).Reverse(true), New().Blink(true), "\x1b[0;5m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.to.AppendTransition(nil, tt.from)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// BenchmarkStyleRender renders table cells in the same few styles, as hot
+// render loops do
+func BenchmarkStyleRender(b *testing.B) {
+	styles := map[string]Style{
+		"Named":    New().Bold(true).Foreground(Cyan).Background(Blue),
+		"ANSI256":  New().Foreground(ANSI256(208)),
+		"RGB":      New().Italic(true).Foreground(RGB(255, 128, 0)).Background(RGB(20, 20, 20)),
+		"Unstyled": New(),
+	}
+	for name, style := range styles {
+		b.Run(name, func(b *testing.B) {
+			buf := make([]byte, 0, 64)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf = style.AppendRender(buf[:0], "user@example.com")
+			}
+		})
+	}
+}
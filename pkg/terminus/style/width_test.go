@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import "testing"
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want int
+	}{
+		{name: "ASCII", r: 'a', want: 1},
+		{name: "Latin", r: 'é', want: 1},
+		{name: "Box drawing", r: '│', want: 1},
+		{name: "CJK", r: '漢', want: 2},
+		{name: "Hangul", r: '한', want: 2},
+		{name: "Fullwidth", r: 'Ａ', want: 2},
+		{name: "Emoji", r: '🍎', want: 2},
+		{name: "Emoji symbol", r: '✅', want: 2},
+		{name: "Text symbol", r: '✓', want: 1},
+		{name: "Combining mark", r: '\u0301', want: 0},
+		{name: "Zero width joiner", r: '\u200d', want: 0},
+		{name: "Variation selector", r: '\ufe0f', want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuneWidth(tt.r); got != tt.want {
+				t.Errorf("RuneWidth(%q) = %d, want %d", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	styled := New().Bold(true).Foreground(Red).Render("🍎 Apple")
+	if got := Width(styled); got != 8 {
+		t.Errorf("Expected width 8, got %d", got)
+	}
+	if got := Width("e\u0301"); got != 1 {
+		t.Errorf("Expected a combined character to take one cell, got %d", got)
+	}
+}
+
+func TestSetRuneWidth(t *testing.T) {
+	SetRuneWidth('★', 2)
+	defer SetRuneWidth('★', -1)
+	if got := RuneWidth('★'); got != 2 {
+		t.Errorf("Expected the set width, got %d", got)
+	}
+	SetRuneWidth('★', -1)
+	if got := RuneWidth('★'); got != 1 {
+		t.Errorf("Expected the built-in width once removed, got %d", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTabWidth is the distance between tab stops, in columns
+const DefaultTabWidth = 8
+
+// WithEngineTabWidth sets the distance between the tab stops views are
+// rendered with. Less than 1 uses DefaultTabWidth.
+func WithEngineTabWidth(width int) EngineOption {
+	return func(e *Engine) {
+		e.tabWidth = width
+	}
+}
+
+// ExpandTabs replaces each tab in s with the spaces up to the next tab stop,
+// every tabWidth columns, as the screen renders it. Columns are counted in
+// cells from the start of each line and ANSI escape sequences take none.
+// Widgets that measure, wrap or scroll text expand its tabs first so their
+// layout matches the screen. Less than 1 uses DefaultTabWidth.
+func ExpandTabs(s string, tabWidth int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	if tabWidth < 1 {
+		tabWidth = DefaultTabWidth
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + tabWidth)
+	col := 0
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\x1b':
+			end := skipEscape(s, i)
+			b.WriteString(s[i:end])
+			i = end
+			continue
+		case '\t':
+			n := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			i++
+			continue
+		case '\n', '\r':
+			col = 0
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		col += RuneWidth(r)
+		i += size
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import "testing"
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tabWidth int
+		expected string
+	}{
+		{"No tabs", "plain", 4, "plain"},
+		{"Leading tab", "\tx", 4, "    x"},
+		{"Tab after text", "ab\tc", 4, "ab  c"},
+		{"Tab at a stop", "abcd\te", 4, "abcd    e"},
+		{"Default width", "a\tb", 0, "a       b"},
+		{"Wide characters", "世\tx", 4, "世  x"},
+		{"Escapes take no columns", "\x1b[1mab\x1b[0m\tc", 4, "\x1b[1mab\x1b[0m  c"},
+		{"Each line starts at column 0", "abc\tx\na\ty", 4, "abc x\na   y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandTabs(tt.input, tt.tabWidth); got != tt.expected {
+				t.Errorf("ExpandTabs(%q, %d) = %q, expected %q", tt.input, tt.tabWidth, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSessionTabWidth(t *testing.T) {
+	session := NewSession("tabs", nil, &testComponent{}, WithEngineTabWidth(4))
+	defer session.Close()
+
+	for _, op := range session.screenDiffer.Update("a\tb") {
+		if line, ok := op.Data.(UpdateLineOp); ok && line.Y == 0 {
+			if line.Content != "a   b" {
+				t.Errorf("Expected the session's 4 column tab stops, got %q", line.Content)
+			}
+			return
+		}
+	}
+	t.Error("Expected the first line to be drawn")
+}
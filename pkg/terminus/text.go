@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"strings"
+)
+
+// TextChunkMsg is sent for each piece of text produced by StreamText
+type TextChunkMsg struct {
+	ID    string
+	Chunk string // The newly received text
+	Text  string // Everything received so far, including Chunk
+}
+
+// TextDoneMsg is sent when a StreamText command finishes
+type TextDoneMsg struct {
+	ID   string
+	Text string // The complete text
+	Err  error  // Set if the producer failed; Text holds what arrived first
+}
+
+// TextStreamFunc produces text incrementally, calling emit for each chunk.
+// It should return promptly once ctx is cancelled.
+type TextStreamFunc func(ctx context.Context, emit func(chunk string)) error
+
+// StreamText runs fn and delivers each chunk it emits as a TextChunkMsg,
+// followed by a TextDoneMsg. It suits token-by-token sources such as LLM
+// responses, where waiting for the full text would leave the UI idle.
+func StreamText(id string, fn TextStreamFunc) Cmd {
+	if fn == nil {
+		return nil
+	}
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		var text strings.Builder
+		err := fn(ctx, func(chunk string) {
+			if chunk == "" {
+				return
+			}
+			text.WriteString(chunk)
+			send(TextChunkMsg{ID: id, Chunk: chunk, Text: text.String()})
+		})
+		return TextDoneMsg{ID: id, Text: text.String(), Err: err}
+	})
+}
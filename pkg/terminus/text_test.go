@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStreamText(t *testing.T) {
+	t.Run("Delivers chunks and accumulated text", func(t *testing.T) {
+		cmd := StreamText("reply", func(ctx context.Context, emit func(string)) error {
+			for _, chunk := range []string{"Hel", "", "lo", " world"} {
+				emit(chunk)
+			}
+			return nil
+		})
+
+		final, sent := runStream(t, cmd)
+
+		if len(sent) != 3 {
+			t.Fatalf("Expected 3 chunks (empty chunk skipped), got %d", len(sent))
+		}
+		second := sent[1].(TextChunkMsg)
+		if second.Chunk != "lo" || second.Text != "Hello" || second.ID != "reply" {
+			t.Errorf("Unexpected chunk %+v", second)
+		}
+
+		done, ok := final.(TextDoneMsg)
+		if !ok || done.Text != "Hello world" || done.Err != nil {
+			t.Errorf("Unexpected final message %#v", final)
+		}
+	})
+
+	t.Run("Reports producer errors with partial text", func(t *testing.T) {
+		failure := errors.New("stream broke")
+		cmd := StreamText("reply", func(ctx context.Context, emit func(string)) error {
+			emit("partial")
+			return failure
+		})
+
+		final, _ := runStream(t, cmd)
+
+		done := final.(TextDoneMsg)
+		if done.Err != failure || done.Text != "partial" {
+			t.Errorf("Unexpected final message %+v", done)
+		}
+	})
+
+	t.Run("Nil function", func(t *testing.T) {
+		if StreamText("x", nil) != nil {
+			t.Error("StreamText with nil function should return nil")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Conn is a connection to a client. It carries the same messages whatever
+// the transport: JSON ClientMessages in, and frames out as JSON
+// ServerMessages or, when Protocol returns BinaryProtocol, binary frames.
+// ReadMessage is called from one goroutine and the other methods from
+// another; Close may be called from any.
+type Conn interface {
+	// ReadMessage blocks until the client sends a message
+	ReadMessage() ([]byte, error)
+	// WriteMessage sends a message, as a binary message if binary is set
+	WriteMessage(data []byte, binary bool) error
+	// Ping checks that the client is still there. It is called regularly
+	// while the connection is idle.
+	Ping() error
+	// Protocol returns the frame encoding agreed with the client:
+	// BinaryProtocol, or "" for JSON
+	Protocol() string
+	// Close closes the connection
+	Close() error
+}
+
+// Transport accepts client connections on an HTTP endpoint of the program's
+// HTTP server. WebSockets are the default transport; others, e.g. long
+// polling for a custom frontend, are added with WithTransport. Transports
+// that need a listener of their own can't be served this way, so there is
+// no WebTransport transport: it needs an HTTP/3 server, which the standard
+// library doesn't provide.
+type Transport interface {
+	// Path returns the endpoint the transport is served on, e.g. "/ws"
+	Path() string
+	// Accept establishes a connection from a request to the endpoint. On
+	// error, Accept has already replied to the request.
+	Accept(w http.ResponseWriter, r *http.Request) (Conn, error)
+}
+
+// WebSocketTransport is the default transport, serving WebSockets on /ws
+type WebSocketTransport struct {
+	Upgrader *websocket.Upgrader
+}
+
+// Path implements the Transport interface
+func (t *WebSocketTransport) Path() string {
+	return "/ws"
+}
+
+// Accept implements the Transport interface
+func (t *WebSocketTransport) Accept(w http.ResponseWriter, r *http.Request) (Conn, error) {
+	conn, err := t.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewWebSocketConn(conn), nil
+}
+
+// webSocketConn is a Conn over a WebSocket
+type webSocketConn struct {
+	conn     *websocket.Conn
+	lastPong atomic.Int64 // Unix nanoseconds
+}
+
+// NewWebSocketConn wraps a WebSocket connection as a PongConn, so the
+// session notices when the client stops answering pings
+func NewWebSocketConn(conn *websocket.Conn) Conn {
+	c := &webSocketConn{conn: conn}
+	c.lastPong.Store(time.Now().UnixNano())
+	conn.SetPongHandler(func(string) error {
+		c.lastPong.Store(time.Now().UnixNano())
+		return nil
+	})
+	return c
+}
+
+func (c *webSocketConn) ReadMessage() ([]byte, error) {
+	_, message, err := c.conn.ReadMessage()
+	return message, err
+}
+
+func (c *webSocketConn) WriteMessage(data []byte, binary bool) error {
+	messageType := websocket.TextMessage
+	if binary {
+		messageType = websocket.BinaryMessage
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	return c.conn.WriteMessage(messageType, data)
+}
+
+func (c *webSocketConn) Ping() error {
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	return c.conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+func (c *webSocketConn) LastPong() time.Time {
+	return time.Unix(0, c.lastPong.Load())
+}
+
+func (c *webSocketConn) Protocol() string {
+	return c.conn.Subprotocol()
+}
+
+// Close sends the client a close message before closing the connection
+func (c *webSocketConn) Close() error {
+	c.conn.WriteControl(websocket.CloseMessage, []byte{}, time.Now().Add(time.Second))
+	return c.conn.Close()
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pipeConn is an in-memory Conn; the test plays the client
+type pipeConn struct {
+	fromClient chan []byte
+	toClient   chan []byte
+	closeOnce  sync.Once
+	done       chan struct{}
+}
+
+func newPipeConn() *pipeConn {
+	return &pipeConn{
+		fromClient: make(chan []byte, 10),
+		toClient:   make(chan []byte, 100),
+		done:       make(chan struct{}),
+	}
+}
+
+func (c *pipeConn) ReadMessage() ([]byte, error) {
+	select {
+	case data := <-c.fromClient:
+		return data, nil
+	case <-c.done:
+		return nil, errors.New("closed")
+	}
+}
+
+func (c *pipeConn) WriteMessage(data []byte, binary bool) error {
+	select {
+	case c.toClient <- data:
+		return nil
+	case <-c.done:
+		return errors.New("closed")
+	}
+}
+
+func (c *pipeConn) Ping() error      { return nil }
+func (c *pipeConn) Protocol() string { return "" }
+
+func (c *pipeConn) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
+
+// read returns the text of the next screen message the client receives
+func (c *pipeConn) read(t *testing.T) string {
+	t.Helper()
+	select {
+	case data := <-c.toClient:
+		var msg ServerMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatal(err)
+		}
+		return screenText(msg)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a message")
+		return ""
+	}
+}
+
+// pipeTransport hands out pipeConns on /pipe
+type pipeTransport struct {
+	conns chan *pipeConn
+}
+
+func (t *pipeTransport) Path() string { return "/pipe" }
+
+func (t *pipeTransport) Accept(w http.ResponseWriter, r *http.Request) (Conn, error) {
+	conn := newPipeConn()
+	t.conns <- conn
+	return conn, nil
+}
+
+func TestTransport(t *testing.T) {
+	transport := &pipeTransport{conns: make(chan *pipeConn, 1)}
+	program := NewProgram(func() Component { return &testComponent{} }, WithTransport(transport))
+	defer program.Stop()
+	server := httptest.NewServer(program.handleTransport(transport))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + transport.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	conn := <-transport.conns
+
+	if got := conn.read(t); !strings.HasPrefix(got, "initialized") {
+		t.Errorf("Expected the first screen, got %q", got)
+	}
+	conn.fromClient <- []byte(`{"type":"key","data":{"keyType":"runes","runes":["x"]}}`)
+	if got := conn.read(t); !strings.HasPrefix(got, "key: x") {
+		t.Errorf("Expected the key to reach the component, got %q", got)
+	}
+	if program.sessionManager.Count() != 1 {
+		t.Errorf("Expected 1 session, got %d", program.sessionManager.Count())
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+// DefaultUndoLimit is how many actions an UndoStack keeps by default
+const DefaultUndoLimit = 100
+
+// Shortcut names of UndoShortcuts
+const (
+	ShortcutUndo = "undo"
+	ShortcutRedo = "redo"
+)
+
+// UndoShortcuts are Ctrl+Z to undo and Ctrl+Shift+Z to redo, for
+// WithShortcuts. UndoStack.HandleShortcut acts on them.
+var UndoShortcuts = []Shortcut{
+	{Name: ShortcutUndo, Key: KeyMsg{Type: KeyCtrlZ}, Help: "Undo"},
+	{Name: ShortcutRedo, Key: KeyMsg{Type: KeyCtrlZ, Shift: true}, Help: "Redo"},
+}
+
+// Action is a change to an application's state that can be undone, such
+// as deleting a todo. Do and Undo change the state and may return a
+// command, e.g. to save it.
+type Action struct {
+	Name string // What the action did, e.g. "Delete todo", for history views
+	Do   func() Cmd
+	Undo func() Cmd
+}
+
+// UndoStack keeps the actions a component has done, so they can be undone
+// and redone in turn. Like the rest of a component's state it is used from
+// Update, not concurrently.
+type UndoStack struct {
+	actions []Action
+	done    int // The actions before done are done; the rest were undone
+	limit   int
+}
+
+// NewUndoStack returns a stack that keeps up to limit actions, forgetting
+// the oldest first, or DefaultUndoLimit if limit isn't positive
+func NewUndoStack(limit int) *UndoStack {
+	if limit <= 0 {
+		limit = DefaultUndoLimit
+	}
+	return &UndoStack{limit: limit}
+}
+
+// Do does action and pushes it, returning the command Do returned. The
+// actions undone before it can no longer be redone.
+func (s *UndoStack) Do(action Action) Cmd {
+	var cmd Cmd
+	if action.Do != nil {
+		cmd = action.Do()
+	}
+	s.Push(action)
+	return cmd
+}
+
+// Push pushes an action the component has already done
+func (s *UndoStack) Push(action Action) {
+	s.actions = append(s.actions[:s.done], action)
+	if len(s.actions) > s.limit {
+		s.actions = append(s.actions[:0], s.actions[len(s.actions)-s.limit:]...)
+	}
+	s.done = len(s.actions)
+}
+
+// Undo undoes the last action done, if any, returning the command its Undo
+// returned
+func (s *UndoStack) Undo() Cmd {
+	if !s.CanUndo() {
+		return nil
+	}
+	s.done--
+	if undo := s.actions[s.done].Undo; undo != nil {
+		return undo()
+	}
+	return nil
+}
+
+// Redo does the last action undone again, if any, returning the command its
+// Do returned
+func (s *UndoStack) Redo() Cmd {
+	if !s.CanRedo() {
+		return nil
+	}
+	s.done++
+	if do := s.actions[s.done-1].Do; do != nil {
+		return do()
+	}
+	return nil
+}
+
+// Goto undoes or redoes actions until the first n of History are done,
+// e.g. to go back to an entry picked in a history view. The commands of the
+// actions run in sequence.
+func (s *UndoStack) Goto(n int) Cmd {
+	n = max(0, min(n, len(s.actions)))
+	var cmds []Cmd
+	for s.done != n {
+		var cmd Cmd
+		if s.done > n {
+			cmd = s.Undo()
+		} else {
+			cmd = s.Redo()
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	switch len(cmds) {
+	case 0:
+		return nil
+	case 1:
+		return cmds[0]
+	}
+	return Sequence(cmds...)
+}
+
+// CanUndo returns whether there is an action to undo
+func (s *UndoStack) CanUndo() bool {
+	return s.done > 0
+}
+
+// CanRedo returns whether there is an undone action to redo
+func (s *UndoStack) CanRedo() bool {
+	return s.done < len(s.actions)
+}
+
+// History returns the actions, oldest first: the first Done of them are
+// done, and the rest were undone and can be redone
+func (s *UndoStack) History() []Action {
+	return append([]Action(nil), s.actions...)
+}
+
+// Done returns how many actions of History are done
+func (s *UndoStack) Done() int {
+	return s.done
+}
+
+// Clear forgets every action
+func (s *UndoStack) Clear() {
+	clear(s.actions)
+	s.actions = s.actions[:0]
+	s.done = 0
+}
+
+// HandleShortcut undoes or redoes on the ShortcutMsg of UndoShortcuts. It
+// returns the command of the action and whether msg was one of them.
+func (s *UndoStack) HandleShortcut(msg Msg) (Cmd, bool) {
+	shortcut, ok := msg.(ShortcutMsg)
+	if !ok {
+		return nil, false
+	}
+	switch shortcut.Name {
+	case ShortcutUndo:
+		return s.Undo(), true
+	case ShortcutRedo:
+		return s.Redo(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"fmt"
+	"testing"
+)
+
+// counterAction returns an action adding amount to *n, named after the
+// amount
+func counterAction(n *int, amount int) Action {
+	return Action{
+		Name: fmt.Sprintf("Add %d", amount),
+		Do:   func() Cmd { *n += amount; return nil },
+		Undo: func() Cmd { *n -= amount; return nil },
+	}
+}
+
+func TestUndoStack(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Undoes and redoes actions in turn",
+			test: func(t *testing.T) {
+				n := 0
+				stack := NewUndoStack(0)
+				stack.Do(counterAction(&n, 1))
+				stack.Do(counterAction(&n, 10))
+				if n != 11 || !stack.CanUndo() || stack.CanRedo() {
+					t.Fatalf("Expected both actions done, got %d", n)
+				}
+
+				stack.Undo()
+				stack.Undo()
+				stack.Undo()
+				if n != 0 || stack.CanUndo() || stack.Done() != 0 {
+					t.Errorf("Expected both actions undone, got %d", n)
+				}
+				stack.Redo()
+				if n != 1 || !stack.CanRedo() {
+					t.Errorf("Expected the first action redone, got %d", n)
+				}
+			},
+		},
+		{
+			name: "Forgets undone actions when a new one is done",
+			test: func(t *testing.T) {
+				n := 0
+				stack := NewUndoStack(0)
+				stack.Do(counterAction(&n, 1))
+				stack.Do(counterAction(&n, 10))
+				stack.Undo()
+				stack.Do(counterAction(&n, 100))
+
+				history := stack.History()
+				if n != 101 || len(history) != 2 || history[1].Name != "Add 100" || stack.CanRedo() {
+					t.Errorf("Expected Add 1 and Add 100, got %d and %+v", n, history)
+				}
+			},
+		},
+		{
+			name: "Keeps the newest actions up to the limit",
+			test: func(t *testing.T) {
+				n := 0
+				stack := NewUndoStack(2)
+				for _, amount := range []int{1, 10, 100} {
+					stack.Do(counterAction(&n, amount))
+				}
+				stack.Goto(0)
+				if n != 1 || len(stack.History()) != 2 {
+					t.Errorf("Expected only the last two actions undone, got %d", n)
+				}
+			},
+		},
+		{
+			name: "Goes to a point in the history",
+			test: func(t *testing.T) {
+				n := 0
+				stack := NewUndoStack(0)
+				for _, amount := range []int{1, 10, 100} {
+					stack.Do(counterAction(&n, amount))
+				}
+				stack.Goto(1)
+				if n != 1 || stack.Done() != 1 {
+					t.Errorf("Expected only the first action done, got %d", n)
+				}
+				stack.Goto(5)
+				if n != 111 || stack.Done() != 3 {
+					t.Errorf("Expected every action redone, got %d", n)
+				}
+			},
+		},
+		{
+			name: "Pushes actions already done",
+			test: func(t *testing.T) {
+				n := 5
+				stack := NewUndoStack(0)
+				stack.Push(counterAction(&n, 5))
+				stack.Undo()
+				if n != 0 {
+					t.Errorf("Expected the pushed action undone, got %d", n)
+				}
+				stack.Clear()
+				if stack.CanRedo() || len(stack.History()) != 0 {
+					t.Error("Expected an empty stack")
+				}
+			},
+		},
+		{
+			name: "Returns the commands of actions",
+			test: func(t *testing.T) {
+				stack := NewUndoStack(0)
+				action := Action{
+					Name: "Save",
+					Do:   func() Cmd { return func() Msg { return "saved" } },
+					Undo: func() Cmd { return func() Msg { return "restored" } },
+				}
+				if msg := stack.Do(action)(); msg != "saved" {
+					t.Errorf("Expected Do's command, got %v", msg)
+				}
+				if msg := stack.Undo()(); msg != "restored" {
+					t.Errorf("Expected Undo's command, got %v", msg)
+				}
+				if cmd := stack.Undo(); cmd != nil {
+					t.Error("Expected nothing to undo")
+				}
+			},
+		},
+		{
+			name: "Handles the undo shortcuts",
+			test: func(t *testing.T) {
+				n := 0
+				stack := NewUndoStack(0)
+				stack.Do(counterAction(&n, 1))
+
+				if _, ok := stack.HandleShortcut(ShortcutMsg{Name: ShortcutUndo, Key: UndoShortcuts[0].Key}); !ok || n != 0 {
+					t.Errorf("Expected Ctrl+Z to undo, got %d", n)
+				}
+				if _, ok := stack.HandleShortcut(ShortcutMsg{Name: ShortcutRedo, Key: UndoShortcuts[1].Key}); !ok || n != 1 {
+					t.Errorf("Expected Ctrl+Shift+Z to redo, got %d", n)
+				}
+				if _, ok := stack.HandleShortcut(ShortcutMsg{Name: "palette"}); ok {
+					t.Error("Expected other shortcuts to be left alone")
+				}
+				if conflicts := ShortcutConflicts(UndoShortcuts); len(conflicts) != 0 {
+					t.Errorf("Expected distinct keys, got %v", conflicts)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// maxPooledView is the largest buffer kept for reuse, so one huge view
+// doesn't pin its memory
+const maxPooledView = 1 << 20
+
+// RenderContext is a buffer a view is written into. Styled text is written
+// straight into it, without rendering each piece to a string first, and
+// contexts are pooled, so a view that renders into one allocates little
+// more than the string it returns.
+type RenderContext struct {
+	buf []byte
+}
+
+// ViewRenderer is implemented by components that can write their view into
+// a RenderContext. Such a component usually implements View with
+// RenderView, and containers pass their context on to it with WriteView.
+type ViewRenderer interface {
+	RenderView(ctx *RenderContext)
+}
+
+var renderContexts = sync.Pool{
+	New: func() any {
+		return &RenderContext{buf: make([]byte, 0, 4096)}
+	},
+}
+
+// AcquireRenderContext returns an empty context from the pool. Call Release
+// when done with it.
+func AcquireRenderContext() *RenderContext {
+	return renderContexts.Get().(*RenderContext)
+}
+
+// Release empties the context and returns it to the pool. The context must
+// not be used afterwards.
+func (c *RenderContext) Release() {
+	if cap(c.buf) > maxPooledView {
+		return
+	}
+	c.buf = c.buf[:0]
+	renderContexts.Put(c)
+}
+
+// RenderView returns the view v writes into a pooled context
+func RenderView(v ViewRenderer) string {
+	ctx := AcquireRenderContext()
+	defer ctx.Release()
+	v.RenderView(ctx)
+	return ctx.String()
+}
+
+// Grow grows the context's capacity to fit n more bytes, e.g. a width x
+// height view
+func (c *RenderContext) Grow(n int) {
+	if n > cap(c.buf)-len(c.buf) {
+		buf := make([]byte, len(c.buf), 2*cap(c.buf)+n)
+		copy(buf, c.buf)
+		c.buf = buf
+	}
+}
+
+// WriteString writes s
+func (c *RenderContext) WriteString(s string) {
+	c.buf = append(c.buf, s...)
+}
+
+// WriteByte writes b. The error is always nil.
+func (c *RenderContext) WriteByte(b byte) error {
+	c.buf = append(c.buf, b)
+	return nil
+}
+
+// WriteRune writes r
+func (c *RenderContext) WriteRune(r rune) {
+	c.buf = utf8.AppendRune(c.buf, r)
+}
+
+// WriteRepeat writes s n times, e.g. to pad a line or draw a rule
+func (c *RenderContext) WriteRepeat(s string, n int) {
+	for ; n > 0; n-- {
+		c.buf = append(c.buf, s...)
+	}
+}
+
+// WriteStyled writes text rendered in style, as style.Render(text) returns
+// it
+func (c *RenderContext) WriteStyled(style Style, text string) {
+	c.buf = style.AppendRender(c.buf, text)
+}
+
+// WritePadded writes text with left spaces before it and right spaces after
+// it, rendered in style as a whole
+func (c *RenderContext) WritePadded(style Style, text string, left, right int) {
+	c.buf = style.AppendPadded(c.buf, text, left, right)
+}
+
+// WriteView writes the view of component, rendering it into the context if
+// it is a ViewRenderer
+func (c *RenderContext) WriteView(component Component) {
+	if v, ok := component.(ViewRenderer); ok {
+		v.RenderView(c)
+		return
+	}
+	c.buf = append(c.buf, component.View()...)
+}
+
+// Len returns the number of bytes written
+func (c *RenderContext) Len() int {
+	return len(c.buf)
+}
+
+// Bytes returns what has been written. It is valid until the next write.
+func (c *RenderContext) Bytes() []byte {
+	return c.buf
+}
+
+// Truncate discards all but the first n bytes written, e.g. to replace
+// what a view wrote after Len returned n
+func (c *RenderContext) Truncate(n int) {
+	c.buf = c.buf[:n]
+}
+
+// String returns what has been written
+func (c *RenderContext) String() string {
+	return string(c.buf)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"testing"
+)
+
+// boxComponent writes a bordered line into the render context, around its
+// child's view
+type boxComponent struct {
+	child Component
+}
+
+func (c *boxComponent) Init() Cmd                       { return nil }
+func (c *boxComponent) Update(msg Msg) (Component, Cmd) { return c, nil }
+func (c *boxComponent) View() string                    { return RenderView(c) }
+
+func (c *boxComponent) RenderView(ctx *RenderContext) {
+	ctx.WriteRepeat("-", 3)
+	ctx.WriteByte('\n')
+	ctx.WriteStyled(NewStyle().Bold(true), "|")
+	ctx.WriteView(c.child)
+	ctx.WritePadded(NewStyle(), "|", 1, 0)
+}
+
+func TestRenderContext(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Renders views into the context",
+			test: func(t *testing.T) {
+				box := &boxComponent{child: &boxComponent{child: &testComponent{state: "ready"}}}
+				want := "---\n\x1b[0;1m|\x1b[0m---\n\x1b[0;1m|\x1b[0mready | |"
+				if got := box.View(); got != want {
+					t.Errorf("Expected %q, got %q", want, got)
+				}
+			},
+		},
+		{
+			name: "Replaces what was written",
+			test: func(t *testing.T) {
+				ctx := AcquireRenderContext()
+				defer ctx.Release()
+				ctx.WriteString("keep ")
+				n := ctx.Len()
+				ctx.WriteRune('é')
+				ctx.Truncate(n)
+				ctx.WriteString("this")
+				if got := ctx.String(); got != "keep this" {
+					t.Errorf("Expected %q, got %q", "keep this", got)
+				}
+			},
+		},
+		{
+			name: "Starts empty from the pool",
+			test: func(t *testing.T) {
+				ctx := AcquireRenderContext()
+				ctx.WriteString("used")
+				ctx.Release()
+				if ctx = AcquireRenderContext(); ctx.Len() != 0 {
+					t.Errorf("Expected an empty context, got %q", ctx.String())
+				}
+				ctx.Release()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// bannerHeight is the number of lines in a banner font glyph
+const bannerHeight = 5
+
+// bannerFont is a block-letter font. Each glyph is five rows where '#' is
+// drawn and '.' is blank. Lowercase letters use the uppercase glyphs.
+var bannerFont = map[rune][bannerHeight]string{
+	'A':  {".###.", "#...#", "#####", "#...#", "#...#"},
+	'B':  {"####.", "#...#", "####.", "#...#", "####."},
+	'C':  {".####", "#....", "#....", "#....", ".####"},
+	'D':  {"####.", "#...#", "#...#", "#...#", "####."},
+	'E':  {"#####", "#....", "####.", "#....", "#####"},
+	'F':  {"#####", "#....", "####.", "#....", "#...."},
+	'G':  {".####", "#....", "#..##", "#...#", ".###."},
+	'H':  {"#...#", "#...#", "#####", "#...#", "#...#"},
+	'I':  {"###", ".#.", ".#.", ".#.", "###"},
+	'J':  {"..###", "...#.", "...#.", "#..#.", ".##.."},
+	'K':  {"#...#", "#..#.", "###..", "#..#.", "#...#"},
+	'L':  {"#....", "#....", "#....", "#....", "#####"},
+	'M':  {"#...#", "##.##", "#.#.#", "#...#", "#...#"},
+	'N':  {"#...#", "##..#", "#.#.#", "#..##", "#...#"},
+	'O':  {".###.", "#...#", "#...#", "#...#", ".###."},
+	'P':  {"####.", "#...#", "####.", "#....", "#...."},
+	'Q':  {".###.", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R':  {"####.", "#...#", "####.", "#..#.", "#...#"},
+	'S':  {".####", "#....", ".###.", "....#", "####."},
+	'T':  {"#####", "..#..", "..#..", "..#..", "..#.."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", ".###."},
+	'V':  {"#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W':  {"#...#", "#...#", "#.#.#", "##.##", "#...#"},
+	'X':  {"#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'Y':  {"#...#", ".#.#.", "..#..", "..#..", "..#.."},
+	'Z':  {"#####", "...#.", "..#..", ".#...", "#####"},
+	'0':  {".###.", "#..##", "#.#.#", "##..#", ".###."},
+	'1':  {".#.", "##.", ".#.", ".#.", "###"},
+	'2':  {"####.", "....#", ".###.", "#....", "#####"},
+	'3':  {"####.", "....#", ".###.", "....#", "####."},
+	'4':  {"#...#", "#...#", "#####", "....#", "....#"},
+	'5':  {"#####", "#....", "####.", "....#", "####."},
+	'6':  {".###.", "#....", "####.", "#...#", ".###."},
+	'7':  {"#####", "....#", "...#.", "..#..", "..#.."},
+	'8':  {".###.", "#...#", ".###.", "#...#", ".###."},
+	'9':  {".###.", "#...#", ".####", "....#", ".###."},
+	' ':  {"...", "...", "...", "...", "..."},
+	'!':  {"#", "#", "#", ".", "#"},
+	'?':  {"###.", "...#", ".##.", "....", ".#.."},
+	'.':  {".", ".", ".", ".", "#"},
+	',':  {"..", "..", "..", ".#", "#."},
+	':':  {".", "#", ".", "#", "."},
+	'\'': {"#", "#", ".", ".", "."},
+	'-':  {"....", "....", "####", "....", "...."},
+	'+':  {".....", "..#..", "#####", "..#..", "....."},
+	'/':  {"....#", "...#.", "..#..", ".#...", "#...."},
+	'_':  {"....", "....", "....", "....", "####"},
+}
+
+// bannerGlyph returns the glyph for r, or '?' for characters the font lacks
+func bannerGlyph(r rune) [bannerHeight]string {
+	if glyph, ok := bannerFont[unicode.ToUpper(r)]; ok {
+		return glyph
+	}
+	return bannerFont['?']
+}
+
+// Banner renders text in large block letters, optionally colored with a
+// gradient, for title screens and headers
+type Banner struct {
+	Model
+
+	// Content
+	text string
+
+	// Configuration
+	char     string
+	spacing  int
+	center   bool
+	vertical bool
+
+	// Styling
+	style    terminus.Style
+	gradient []terminus.Color
+}
+
+// NewBanner creates a banner for text. With no size set the text is drawn
+// on as few lines as it needs; SetSize wraps words to fit the width.
+func NewBanner(text string) *Banner {
+	m := NewModel()
+	m.width = 0
+	m.height = 0
+	return &Banner{
+		Model:   m,
+		text:    text,
+		char:    "█",
+		spacing: 1,
+		style:   terminus.NewStyle().Bold(true),
+	}
+}
+
+// SetText sets the banner text
+func (b *Banner) SetText(text string) *Banner {
+	b.text = text
+	return b
+}
+
+// Text returns the banner text
+func (b *Banner) Text() string {
+	return b.text
+}
+
+// SetChar sets the character letters are drawn with
+func (b *Banner) SetChar(char string) *Banner {
+	b.char = char
+	return b
+}
+
+// SetSpacing sets the number of blank columns between letters
+func (b *Banner) SetSpacing(spacing int) *Banner {
+	if spacing < 0 {
+		spacing = 0
+	}
+	b.spacing = spacing
+	return b
+}
+
+// SetCenter sets whether each line is centred within the width
+func (b *Banner) SetCenter(center bool) *Banner {
+	b.center = center
+	return b
+}
+
+// SetStyle sets the style of the letters when no gradient is set
+func (b *Banner) SetStyle(style terminus.Style) *Banner {
+	b.style = style
+	return b
+}
+
+// SetGradient colors the letters with a gradient through the given colors,
+// left to right. With fewer than two colors the gradient is removed.
+func (b *Banner) SetGradient(stops ...terminus.Color) *Banner {
+	if len(stops) < 2 {
+		stops = nil
+	}
+	b.gradient = stops
+	return b
+}
+
+// SetVertical runs the gradient top to bottom instead of left to right
+func (b *Banner) SetVertical(vertical bool) *Banner {
+	b.vertical = vertical
+	return b
+}
+
+// Init implements the Component interface
+func (b *Banner) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (b *Banner) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return b, nil
+}
+
+// View implements the Component interface
+func (b *Banner) View() string {
+	var lines []string
+	for i, words := range b.wrap() {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, b.renderLine(words)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// measure returns the width of text drawn in the banner font
+func (b *Banner) measure(text string) int {
+	width := 0
+	for i, r := range []rune(text) {
+		if i > 0 {
+			width += b.spacing
+		}
+		width += len(bannerGlyph(r)[0])
+	}
+	return width
+}
+
+// wrap splits the text into banner lines that fit the width. Words wider
+// than the width are kept whole and clipped when drawn.
+func (b *Banner) wrap() []string {
+	var result []string
+	for _, paragraph := range strings.Split(b.text, "\n") {
+		if b.width <= 0 {
+			result = append(result, paragraph)
+			continue
+		}
+
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && b.measure(line+" "+word) > b.width {
+				result = append(result, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// renderLine draws one line of text as bannerHeight terminal lines
+func (b *Banner) renderLine(text string) []string {
+	// Lay the glyphs out as a grid of drawn cells
+	width := b.measure(text)
+	grid := make([][]bool, bannerHeight)
+	for y := range grid {
+		grid[y] = make([]bool, width)
+	}
+	x := 0
+	for i, r := range []rune(text) {
+		if i > 0 {
+			x += b.spacing
+		}
+		glyph := bannerGlyph(r)
+		for y, row := range glyph {
+			for dx, cell := range row {
+				grid[y][x+dx] = cell == '#'
+			}
+		}
+		x += len(glyph[0])
+	}
+
+	if b.width > 0 && width > b.width {
+		for y := range grid {
+			grid[y] = grid[y][:b.width]
+		}
+		width = b.width
+	}
+
+	pad := 0
+	if b.center && b.width > width {
+		pad = (b.width - width) / 2
+	}
+
+	// Each cell's color comes from its column or row
+	var colors []terminus.Color
+	if b.gradient != nil {
+		steps := width
+		if b.vertical {
+			steps = bannerHeight
+		}
+		colors = terminus.Gradient(steps, b.gradient...)
+	}
+	styleAt := func(x, y int) terminus.Style {
+		switch {
+		case colors == nil:
+			return b.style
+		case b.vertical:
+			return b.style.Foreground(colors[y])
+		default:
+			return b.style.Foreground(colors[x])
+		}
+	}
+	sameStyle := func(x1, x2 int) bool {
+		return colors == nil || b.vertical || colors[x1] == colors[x2]
+	}
+
+	lines := make([]string, bannerHeight)
+	for y, row := range grid {
+		var line strings.Builder
+		line.WriteString(strings.Repeat(" ", pad))
+
+		// Consecutive cells with the same style are rendered together
+		start := 0
+		for start < len(row) {
+			end := start + 1
+			for end < len(row) && row[end] == row[start] && (!row[start] || sameStyle(start, end)) {
+				end++
+			}
+			if row[start] {
+				line.WriteString(styleAt(start, y).Render(strings.Repeat(b.char, end-start)))
+			} else {
+				line.WriteString(strings.Repeat(" ", end-start))
+			}
+			start = end
+		}
+		lines[y] = strings.TrimRight(line.String(), " ")
+	}
+	return lines
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestBanner(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Draws block letters",
+			test: func(t *testing.T) {
+				b := NewBanner("Hi!").SetChar("#")
+				expected := []string{
+					"#   # ### #",
+					"#   #  #  #",
+					"#####  #  #",
+					"#   #  #",
+					"#   # ### #",
+				}
+				lines := strings.Split(plain(b.View()), "\n")
+				if len(lines) != len(expected) {
+					t.Fatalf("Expected %d lines, got %q", len(expected), lines)
+				}
+				for i, want := range expected {
+					if lines[i] != want {
+						t.Errorf("Line %d: expected %q, got %q", i, want, lines[i])
+					}
+				}
+			},
+		},
+		{
+			name: "Substitutes unknown characters",
+			test: func(t *testing.T) {
+				if plain(NewBanner("é").View()) != plain(NewBanner("?").View()) {
+					t.Error("Expected unknown characters drawn as '?'")
+				}
+				if NewBanner("abc").View() != NewBanner("ABC").View() {
+					t.Error("Expected lowercase drawn as uppercase")
+				}
+			},
+		},
+		{
+			name: "Wraps words to the width and centres them",
+			test: func(t *testing.T) {
+				b := NewBanner("GO GO").SetCenter(true)
+				b.SetSize(15, 0)
+				lines := strings.Split(plain(b.View()), "\n")
+
+				// Each word is 11 columns, so they go on separate lines
+				if len(lines) != 11 || lines[5] != "" {
+					t.Fatalf("Expected two banner lines separated by a blank, got %q", lines)
+				}
+				if !strings.HasPrefix(lines[2], "  █") {
+					t.Errorf("Expected 2 columns of centring, got %q", lines[2])
+				}
+			},
+		},
+		{
+			name: "Colors letters with a gradient",
+			test: func(t *testing.T) {
+				from, to := terminus.RGB(255, 0, 0), terminus.RGB(0, 0, 255)
+				view := NewBanner("II").SetGradient(from, to).View()
+				first := strings.Split(view, "\n")[0]
+
+				style := terminus.NewStyle().Bold(true)
+				if !strings.HasPrefix(first, style.Foreground(from).Render("█")) {
+					t.Errorf("Expected the first column in the start color, got %q", first)
+				}
+				if !strings.HasSuffix(first, style.Foreground(to).Render("█")) {
+					t.Errorf("Expected the last column in the end color, got %q", first)
+				}
+
+				vertical := NewBanner("I").SetGradient(from, to).SetVertical(true).View()
+				if strings.Split(vertical, "\n")[0] != style.Foreground(from).Render("███") {
+					t.Errorf("Expected whole rows in one color, got %q", vertical)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
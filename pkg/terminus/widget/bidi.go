@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Direction is the direction text is written in. Text is kept in logical
+// order, the order it is typed and read in; the browser lays out the
+// right-to-left runs of a line, so widgets only need to align text and move
+// their cursors the way it is read.
+type Direction int
+
+const (
+	// DirectionAuto takes the direction of each text from its first letter
+	DirectionAuto Direction = iota
+	// DirectionLTR is left to right, e.g. English
+	DirectionLTR
+	// DirectionRTL is right to left, e.g. Hebrew or Arabic
+	DirectionRTL
+)
+
+// rtlScripts are the scripts written right to left
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Hebrew,
+	unicode.Nko,
+	unicode.Samaritan,
+	unicode.Syriac,
+	unicode.Thaana,
+}
+
+// TextDirection returns the direction of s from its first letter: RTL for
+// Hebrew, Arabic and other scripts written right to left and LTR otherwise.
+// ANSI escape sequences are skipped.
+func TextDirection(s string) Direction {
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			i = escapeEnd(s, i)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if unicode.IsLetter(r) {
+			if unicode.In(r, rtlScripts...) {
+				return DirectionRTL
+			}
+			return DirectionLTR
+		}
+	}
+	return DirectionLTR
+}
+
+// resolve returns the direction of s when d is DirectionAuto, and d
+// otherwise
+func (d Direction) resolve(s string) Direction {
+	if d == DirectionAuto {
+		return TextDirection(s)
+	}
+	return d
+}
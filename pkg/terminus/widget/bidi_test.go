@@ -0,0 +1,42 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"testing"
+)
+
+func TestTextDirection(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected Direction
+	}{
+		{"English", "hello", DirectionLTR},
+		{"Hebrew", "שלום world", DirectionRTL},
+		{"Arabic after digits", "42 مرحبا", DirectionRTL},
+		{"English before Arabic", "re: مرحبا", DirectionLTR},
+		{"Styled", "\x1b[0;1mשלום\x1b[0m", DirectionRTL},
+		{"No letters", "123 !", DirectionLTR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TextDirection(tt.text); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// structField is a struct field shown as a column
+type structField struct {
+	index  []int
+	column TableColumn
+	format string
+}
+
+// structFields returns the fields of t shown as columns. Types other than
+// structs are shown as a single column.
+func structFields(t reflect.Type) []structField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return []structField{{column: TableColumn{Title: "Value", Sortable: true}, format: "%v"}}
+	}
+
+	var fields []structField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("table")
+		if tag == "-" {
+			continue
+		}
+		field := structField{
+			index:  f.Index,
+			column: TableColumn{Title: f.Name, Sortable: true},
+			format: "%v",
+		}
+		for i, opt := range strings.Split(tag, ",") {
+			if i == 0 {
+				if opt != "" {
+					field.column.Title = opt
+				}
+				continue
+			}
+			key, value, _ := strings.Cut(opt, "=")
+			switch key {
+			case "width":
+				field.column.Width, _ = strconv.Atoi(value)
+			case "min":
+				field.column.MinWidth, _ = strconv.Atoi(value)
+			case "max":
+				field.column.MaxWidth, _ = strconv.Atoi(value)
+			case "align":
+				field.column.Align = parseAlignment(value)
+			case "format":
+				field.format = value
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// parseAlignment returns the alignment named by a tag option
+func parseAlignment(name string) Alignment {
+	switch name {
+	case "center":
+		return AlignCenter
+	case "right":
+		return AlignRight
+	default:
+		return AlignLeft
+	}
+}
+
+// value returns the field's value in v, or nil if v is a nil pointer or
+// the field is reached through one
+func (f structField) value(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if f.index == nil {
+		return v.Interface()
+	}
+	field, err := v.FieldByIndexErr(f.index)
+	if err != nil {
+		return nil
+	}
+	return field.Interface()
+}
+
+// structCell is a table cell holding one field of the item at index
+type structCell struct {
+	text  string
+	value interface{}
+	index int
+}
+
+// Render implements TableCell interface
+func (c *structCell) Render() string {
+	return c.text
+}
+
+// String implements TableCell interface
+func (c *structCell) String() string {
+	return c.text
+}
+
+// Value implements TableCell interface
+func (c *structCell) Value() interface{} {
+	return c.value
+}
+
+// StructTable is a Table whose columns come from the fields of T. Call
+// SetData with fresh values to update it; the sort order, filters and
+// selection carry over.
+type StructTable[T any] struct {
+	*Table
+
+	fields []structField
+	data   []T
+}
+
+// NewTableFromStructs creates a table with a column per exported field of
+// T and a row per item of data. A field's `table` tag configures its
+// column:
+//
+//	CPU float64 `table:"CPU %,width=6,align=right,format=%.1f"`
+//
+// The first element is the title, defaulting to the field name; "-" hides
+// the field. The options are width, min and max (column widths), align
+// (left, center or right) and format (a fmt verb, %v by default).
+func NewTableFromStructs[T any](data []T) *StructTable[T] {
+	s := &StructTable[T]{
+		Table:  NewTable(),
+		fields: structFields(reflect.TypeOf((*T)(nil)).Elem()),
+	}
+	columns := make([]TableColumn, len(s.fields))
+	for i, f := range s.fields {
+		columns[i] = f.column
+	}
+	s.SetColumns(columns)
+	return s.SetData(data)
+}
+
+// SetData replaces the rows with one per item of data
+func (s *StructTable[T]) SetData(data []T) *StructTable[T] {
+	s.data = data
+	rows := make([]TableRow, len(data))
+	for i := range data {
+		v := reflect.ValueOf(&data[i]).Elem()
+		row := make(TableRow, len(s.fields))
+		for j, f := range s.fields {
+			value := f.value(v)
+			var text string
+			if value != nil {
+				text = fmt.Sprintf(f.format, value)
+			}
+			row[j] = &structCell{text: text, value: value, index: i}
+		}
+		rows[i] = row
+	}
+	s.SetRows(rows)
+	if s.sortOrder != SortNone {
+		s.SortByColumn(s.sortColumn, s.sortOrder)
+	}
+	return s
+}
+
+// Data returns the items shown
+func (s *StructTable[T]) Data() []T {
+	return s.data
+}
+
+// Item returns the item shown at row i, or false if there is none or it
+// is a group header
+func (s *StructTable[T]) Item(i int) (T, bool) {
+	var zero T
+	if i < 0 || i >= len(s.rows) || len(s.rows[i]) == 0 {
+		return zero, false
+	}
+	cell, ok := s.rows[i][0].(*structCell)
+	if !ok || cell.index >= len(s.data) {
+		return zero, false
+	}
+	return s.data[cell.index], true
+}
+
+// SelectedItem returns the item in the selected row
+func (s *StructTable[T]) SelectedItem() (T, bool) {
+	return s.Item(s.selectedRow)
+}
+
+// Update implements the Component interface
+func (s *StructTable[T]) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	_, cmd := s.Table.Update(msg)
+	return s, cmd
+}
+
+// structItem is a list item showing the fields of the item at index
+type structItem struct {
+	text  string
+	index int
+}
+
+// Render implements ListItem interface
+func (i *structItem) Render() string {
+	return i.text
+}
+
+// String implements ListItem interface
+func (i *structItem) String() string {
+	return i.text
+}
+
+// StructList is a List of T. Each item shows the fields of T in a row,
+// formatted and padded as StructTable would; types implementing
+// fmt.Stringer show their String instead.
+type StructList[T any] struct {
+	*List
+
+	fields []structField
+	data   []T
+}
+
+// NewListFromStructs creates a list with an item per item of data
+func NewListFromStructs[T any](data []T) *StructList[T] {
+	s := &StructList[T]{
+		List:   NewList(),
+		fields: structFields(reflect.TypeOf((*T)(nil)).Elem()),
+	}
+	return s.SetData(data)
+}
+
+// SetData replaces the items with data, keeping the selected index if it
+// is still in range
+func (s *StructList[T]) SetData(data []T) *StructList[T] {
+	selected := s.selectedIdx
+	s.data = data
+	items := make([]ListItem, len(data))
+	for i := range data {
+		items[i] = &structItem{text: s.itemText(&data[i]), index: i}
+	}
+	s.SetItems(items)
+	if selected < len(items) {
+		s.SetSelected(selected)
+	}
+	return s
+}
+
+// itemText returns the text of an item
+func (s *StructList[T]) itemText(item *T) string {
+	if stringer, ok := any(*item).(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	v := reflect.ValueOf(item).Elem()
+	parts := make([]string, 0, len(s.fields))
+	for _, f := range s.fields {
+		var text string
+		if value := f.value(v); value != nil {
+			text = fmt.Sprintf(f.format, value)
+		}
+		if f.column.Width > 0 {
+			text = alignCell(text, f.column.Width, f.column.Align)
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Data returns the items shown
+func (s *StructList[T]) Data() []T {
+	return s.data
+}
+
+// SelectedItem returns the selected item
+func (s *StructList[T]) SelectedItem() (T, bool) {
+	var zero T
+	item, ok := s.List.SelectedItem().(*structItem)
+	if !ok || item.index >= len(s.data) {
+		return zero, false
+	}
+	return s.data[item.index], true
+}
+
+// Update implements the Component interface
+func (s *StructList[T]) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	_, cmd := s.List.Update(msg)
+	return s, cmd
+}
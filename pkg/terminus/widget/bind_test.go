@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+type process struct {
+	PID    int     `table:"PID,width=5,align=right"`
+	Name   string  `table:",max=10"`
+	CPU    float64 `table:"CPU %,width=6,align=right,format=%.1f"`
+	secret string
+	Owner  string `table:"-"`
+}
+
+type host struct {
+	Name string
+	Up   bool
+}
+
+func (h host) String() string {
+	if h.Up {
+		return h.Name + " (up)"
+	}
+	return h.Name + " (down)"
+}
+
+func TestStructBinding(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Derives columns from tags",
+			test: func(t *testing.T) {
+				tbl := NewTableFromStructs([]process{{PID: 42, Name: "init", CPU: 1.5, secret: "x", Owner: "root"}})
+				if tbl.ColCount() != 3 {
+					t.Fatalf("Expected 3 columns, got %d", tbl.ColCount())
+				}
+				col := tbl.columns[2]
+				if col.Title != "CPU %" || col.Width != 6 || col.Align != AlignRight {
+					t.Errorf("Expected the tagged CPU column, got %+v", col)
+				}
+				if tbl.columns[1].Title != "Name" || tbl.columns[1].MaxWidth != 10 {
+					t.Errorf("Expected the field name as title, got %+v", tbl.columns[1])
+				}
+				if got := tbl.rows[0][2].String(); got != "1.5" {
+					t.Errorf("Expected the formatted CPU, got %q", got)
+				}
+				if tbl.rows[0][0].Value() != 42 {
+					t.Errorf("Expected the raw value, got %v", tbl.rows[0][0].Value())
+				}
+			},
+		},
+		{
+			name: "SetData keeps the sort order",
+			test: func(t *testing.T) {
+				tbl := NewTableFromStructs([]*process{{PID: 2, Name: "b"}, {PID: 1, Name: "a"}})
+				tbl.SortByColumn(1, SortAsc)
+				tbl.SetData([]*process{{PID: 3, Name: "c"}, {PID: 2, Name: "b"}, {PID: 1, Name: "a"}})
+				if tbl.RowCount() != 3 || tbl.rows[0][1].String() != "a" {
+					t.Fatalf("Expected the new rows sorted, got %q first", tbl.rows[0][1].String())
+				}
+				tbl.SetSelected(2, 0)
+				if p, ok := tbl.SelectedItem(); !ok || p.PID != 3 {
+					t.Errorf("Expected the selected row's item, got %+v", p)
+				}
+			},
+		},
+		{
+			name: "Update returns the bound table",
+			test: func(t *testing.T) {
+				tbl := NewTableFromStructs([]process{{PID: 1}, {PID: 2}})
+				tbl.Focus()
+				c, _ := tbl.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if c != tbl || tbl.SelectedRow() != 1 {
+					t.Errorf("Expected the StructTable back with row 1 selected, got %T", c)
+				}
+			},
+		},
+		{
+			name: "Lists lay out fields or use String",
+			test: func(t *testing.T) {
+				l := NewListFromStructs([]process{{PID: 7, Name: "sh", CPU: 3}})
+				if got := l.Items()[0].String(); got != "    7 sh    3.0" {
+					t.Errorf("Expected the fields in a row, got %q", got)
+				}
+
+				hosts := NewListFromStructs([]host{{"a", true}, {"b", false}})
+				hosts.SetSelected(1)
+				hosts.SetData([]host{{"a", true}, {"b", true}})
+				if h, ok := hosts.SelectedItem(); !ok || !h.Up || hosts.SelectedIndex() != 1 {
+					t.Errorf("Expected b still selected and up, got %+v", h)
+				}
+				if !strings.Contains(plain(hosts.View()), "b (up)") {
+					t.Errorf("Expected String to render items, got %q", plain(hosts.View()))
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Breadcrumbs shows where the user is in a hierarchy, such as the folders of
+// a file browser or the pages of nested settings, as a path of segments.
+// While focused, the user picks a segment with the arrow keys and presses
+// Enter to go back to it, or Backspace to go up one level; the widget then
+// drops the segments after it and calls the navigate callback.
+type Breadcrumbs struct {
+	Model
+
+	segments []string
+	selected int // Segment under the cursor while focused
+
+	// Configuration
+	separator string
+
+	// Styling
+	style          terminus.Style
+	currentStyle   terminus.Style
+	selectedStyle  terminus.Style
+	separatorStyle terminus.Style
+
+	// Events
+	onNavigate func(index int, path []string) terminus.Cmd
+}
+
+// NewBreadcrumbs creates breadcrumbs showing the given path, outermost
+// segment first
+func NewBreadcrumbs(segments ...string) *Breadcrumbs {
+	b := &Breadcrumbs{
+		Model:          NewModel(),
+		separator:      " › ",
+		style:          terminus.NewStyle(),
+		currentStyle:   terminus.NewStyle().Bold(true),
+		selectedStyle:  terminus.NewStyle().Reverse(true),
+		separatorStyle: terminus.NewStyle().Faint(true),
+	}
+	b.SetSize(0, 1)
+	b.SetPath(segments...)
+	return b
+}
+
+// SetPath replaces the path and puts the cursor on its last segment.
+// Segments are sanitized with terminus.Sanitize, as they often name things
+// users created.
+func (b *Breadcrumbs) SetPath(segments ...string) *Breadcrumbs {
+	b.segments = make([]string, len(segments))
+	for i, segment := range segments {
+		b.segments[i] = terminus.Sanitize(segment)
+	}
+	b.selected = len(b.segments) - 1
+	return b
+}
+
+// Push adds a segment to the end of the path, e.g. when the user opens a
+// folder
+func (b *Breadcrumbs) Push(segment string) *Breadcrumbs {
+	b.segments = append(b.segments, terminus.Sanitize(segment))
+	b.selected = len(b.segments) - 1
+	return b
+}
+
+// Pop removes the last segment and returns it, or "" if the path is empty
+func (b *Breadcrumbs) Pop() string {
+	if len(b.segments) == 0 {
+		return ""
+	}
+	last := b.segments[len(b.segments)-1]
+	b.segments = b.segments[:len(b.segments)-1]
+	b.selected = len(b.segments) - 1
+	return last
+}
+
+// Path returns a copy of the path
+func (b *Breadcrumbs) Path() []string {
+	return append([]string(nil), b.segments...)
+}
+
+// Current returns the last segment, or "" if the path is empty
+func (b *Breadcrumbs) Current() string {
+	if len(b.segments) == 0 {
+		return ""
+	}
+	return b.segments[len(b.segments)-1]
+}
+
+// Selected returns the index of the segment under the cursor
+func (b *Breadcrumbs) Selected() int {
+	return b.selected
+}
+
+// SetSeparator sets the text between segments
+func (b *Breadcrumbs) SetSeparator(separator string) *Breadcrumbs {
+	b.separator = separator
+	return b
+}
+
+// SetStyle sets the style of the segments leading to the current one
+func (b *Breadcrumbs) SetStyle(style terminus.Style) *Breadcrumbs {
+	b.style = style
+	return b
+}
+
+// SetCurrentStyle sets the style of the last segment
+func (b *Breadcrumbs) SetCurrentStyle(style terminus.Style) *Breadcrumbs {
+	b.currentStyle = style
+	return b
+}
+
+// SetSelectedStyle sets the style of the segment under the cursor
+func (b *Breadcrumbs) SetSelectedStyle(style terminus.Style) *Breadcrumbs {
+	b.selectedStyle = style
+	return b
+}
+
+// SetSeparatorStyle sets the style of the separators
+func (b *Breadcrumbs) SetSeparatorStyle(style terminus.Style) *Breadcrumbs {
+	b.separatorStyle = style
+	return b
+}
+
+// SetOnNavigate sets the callback for when the user goes back to a segment.
+// It receives the segment's index and the path that now ends with it.
+func (b *Breadcrumbs) SetOnNavigate(callback func(index int, path []string) terminus.Cmd) *Breadcrumbs {
+	b.onNavigate = callback
+	return b
+}
+
+// Navigate goes back to segment index, dropping the segments after it, and
+// returns the navigate callback's command. Indexes outside the path, and the
+// last segment, are ignored.
+func (b *Breadcrumbs) Navigate(index int) terminus.Cmd {
+	if index < 0 || index >= len(b.segments)-1 {
+		return nil
+	}
+	b.segments = b.segments[:index+1]
+	b.selected = index
+	if b.onNavigate != nil {
+		return b.onNavigate(index, b.Path())
+	}
+	return nil
+}
+
+// Init implements the Component interface
+func (b *Breadcrumbs) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (b *Breadcrumbs) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if !b.Focused() {
+		return b, nil
+	}
+
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return b, nil
+	}
+
+	switch keyMsg.Type {
+	case terminus.KeyLeft:
+		b.moveSelection(-1)
+	case terminus.KeyRight:
+		b.moveSelection(1)
+	case terminus.KeyHome:
+		b.selected = 0
+	case terminus.KeyEnd:
+		b.selected = len(b.segments) - 1
+	case terminus.KeyEnter:
+		return b, b.Navigate(b.selected)
+	case terminus.KeyBackspace:
+		return b, b.Navigate(len(b.segments) - 2)
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) != 1 {
+			break
+		}
+		switch keyMsg.Runes[0] {
+		case 'h':
+			b.moveSelection(-1)
+		case 'l':
+			b.moveSelection(1)
+		}
+	}
+	return b, nil
+}
+
+// moveSelection moves the cursor by delta segments, stopping at the ends
+func (b *Breadcrumbs) moveSelection(delta int) {
+	b.selected = max(0, min(len(b.segments)-1, b.selected+delta))
+}
+
+// View implements the Component interface
+func (b *Breadcrumbs) View() string {
+	// Leading segments give way to an ellipsis when the path is too wide
+	first := 0
+	if b.width > 0 {
+		for first < len(b.segments)-1 && b.plainWidth(first) > b.width {
+			first++
+		}
+	}
+
+	var parts []string
+	if first > 0 {
+		parts = append(parts, b.style.Render("…"))
+	}
+	for i := first; i < len(b.segments); i++ {
+		style := b.style
+		if i == len(b.segments)-1 {
+			style = b.currentStyle
+		}
+		if b.Focused() && i == b.selected {
+			style = b.selectedStyle
+		}
+		parts = append(parts, style.Render(b.segments[i]))
+	}
+	line := strings.Join(parts, b.separatorStyle.Render(b.separator))
+	if b.width > 0 && visibleWidth(line) > b.width {
+		line = fitWidth(line, b.width)
+	}
+	return line
+}
+
+// plainWidth returns the width of the path from segment first on, with an
+// ellipsis in place of any segments before it
+func (b *Breadcrumbs) plainWidth(first int) int {
+	parts := b.segments[first:]
+	if first > 0 {
+		parts = append([]string{"…"}, parts...)
+	}
+	return visibleWidth(strings.Join(parts, b.separator))
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// newPlainBreadcrumbs returns breadcrumbs without styling for easy
+// comparison
+func newPlainBreadcrumbs(segments ...string) *Breadcrumbs {
+	plain := terminus.NewStyle()
+	return NewBreadcrumbs(segments...).
+		SetSeparator(" / ").
+		SetStyle(plain).
+		SetCurrentStyle(plain).
+		SetSelectedStyle(plain).
+		SetSeparatorStyle(plain)
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Renders the path",
+			test: func(t *testing.T) {
+				b := newPlainBreadcrumbs("home", "docs").Push("reports")
+				if got := b.View(); got != "home / docs / reports" {
+					t.Errorf("Unexpected view %q", got)
+				}
+				if b.Pop() != "reports" || b.Current() != "docs" {
+					t.Errorf("Expected to pop back to docs, got %v", b.Path())
+				}
+			},
+		},
+		{
+			name: "Elides leading segments that don't fit",
+			test: func(t *testing.T) {
+				b := newPlainBreadcrumbs("home", "docs", "reports", "2025")
+				b.SetSize(20, 1)
+				if got := b.View(); got != "… / reports / 2025" {
+					t.Errorf("Unexpected view %q", got)
+				}
+			},
+		},
+		{
+			name: "Navigates back to the selected segment",
+			test: func(t *testing.T) {
+				var navigated []string
+				b := newPlainBreadcrumbs("settings", "display", "fonts").
+					SetOnNavigate(func(index int, path []string) terminus.Cmd {
+						navigated = path
+						return terminus.Quit
+					})
+				b.Focus()
+
+				b.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				b.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				b.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if b.Selected() != 0 {
+					t.Errorf("Expected the cursor to stop at the first segment, got %d", b.Selected())
+				}
+				_, cmd := b.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if cmd == nil || !reflect.DeepEqual(navigated, []string{"settings"}) {
+					t.Errorf("Expected to navigate to settings, got %v", navigated)
+				}
+				if !reflect.DeepEqual(b.Path(), []string{"settings"}) {
+					t.Errorf("Expected the later segments dropped, got %v", b.Path())
+				}
+			},
+		},
+		{
+			name: "Backspace goes up one level",
+			test: func(t *testing.T) {
+				b := newPlainBreadcrumbs("a", "b", "c")
+				b.Focus()
+				b.Update(terminus.KeyMsg{Type: terminus.KeyBackspace})
+				if got := b.View(); got != "a / b" {
+					t.Errorf("Unexpected view %q", got)
+				}
+				b.Update(terminus.KeyMsg{Type: terminus.KeyBackspace})
+				b.Update(terminus.KeyMsg{Type: terminus.KeyBackspace})
+				if got := b.View(); got != "a" {
+					t.Errorf("Expected to stop at the root, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Ignores keys when not focused",
+			test: func(t *testing.T) {
+				b := newPlainBreadcrumbs("a", "b")
+				b.Update(terminus.KeyMsg{Type: terminus.KeyBackspace})
+				if len(b.Path()) != 2 {
+					t.Errorf("Expected the path unchanged, got %v", b.Path())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// calendarCellWidth is the width of one day in the month grid
+const calendarCellWidth = 4
+
+// Calendar shows a month grid with a selectable day. Days with events are
+// marked, and the selected day's events can be listed below the grid as an
+// agenda.
+type Calendar struct {
+	Model
+
+	// State
+	selected time.Time // Midnight of the selected day
+	now      func() time.Time
+
+	// Configuration
+	weekStart  time.Weekday
+	showAgenda bool
+	marker     string
+
+	// Data
+	events func(day time.Time) []string
+
+	// Styling
+	headerStyle   terminus.Style
+	weekdayStyle  terminus.Style
+	selectedStyle terminus.Style
+	todayStyle    terminus.Style
+	markerStyle   terminus.Style
+	agendaStyle   terminus.Style
+
+	// Events
+	onSelect      func(day time.Time) terminus.Cmd
+	onMonthChange func(month time.Time) terminus.Cmd
+}
+
+// NewCalendar creates a calendar showing the current month with today
+// selected
+func NewCalendar() *Calendar {
+	m := NewModel()
+	m.width = 7 * calendarCellWidth
+	m.height = 8
+	c := &Calendar{
+		Model:         m,
+		now:           time.Now,
+		weekStart:     time.Sunday,
+		showAgenda:    true,
+		marker:        "•",
+		headerStyle:   terminus.NewStyle().Bold(true),
+		weekdayStyle:  terminus.NewStyle().Faint(true),
+		selectedStyle: terminus.NewStyle().Reverse(true),
+		todayStyle:    terminus.NewStyle().Underline(true).Foreground(terminus.Cyan),
+		markerStyle:   terminus.NewStyle().Foreground(terminus.Yellow),
+		agendaStyle:   terminus.NewStyle(),
+	}
+	c.selected = startOfDay(c.now())
+	return c
+}
+
+// startOfDay returns midnight of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// SetDate selects a day and shows its month
+func (c *Calendar) SetDate(day time.Time) *Calendar {
+	c.selected = startOfDay(day)
+	return c
+}
+
+// Selected returns midnight of the selected day
+func (c *Calendar) Selected() time.Time {
+	return c.selected
+}
+
+// Month returns the first day of the displayed month
+func (c *Calendar) Month() time.Time {
+	return time.Date(c.selected.Year(), c.selected.Month(), 1, 0, 0, 0, 0, c.selected.Location())
+}
+
+// SetWeekStart sets the first day of each week row
+func (c *Calendar) SetWeekStart(day time.Weekday) *Calendar {
+	c.weekStart = day
+	return c
+}
+
+// SetShowAgenda sets whether the selected day's events are listed below
+// the grid
+func (c *Calendar) SetShowAgenda(show bool) *Calendar {
+	c.showAgenda = show
+	return c
+}
+
+// SetMarker sets the character shown beside days with events
+func (c *Calendar) SetMarker(marker string) *Calendar {
+	c.marker = marker
+	return c
+}
+
+// SetEvents sets the callback that lists the events on a day. It is called
+// for every day drawn, so it should be cheap, e.g. a map lookup.
+func (c *Calendar) SetEvents(events func(day time.Time) []string) *Calendar {
+	c.events = events
+	return c
+}
+
+// SetHeaderStyle sets the style of the month title
+func (c *Calendar) SetHeaderStyle(style terminus.Style) *Calendar {
+	c.headerStyle = style
+	return c
+}
+
+// SetSelectedStyle sets the style of the selected day
+func (c *Calendar) SetSelectedStyle(style terminus.Style) *Calendar {
+	c.selectedStyle = style
+	return c
+}
+
+// SetTodayStyle sets the style of today's date
+func (c *Calendar) SetTodayStyle(style terminus.Style) *Calendar {
+	c.todayStyle = style
+	return c
+}
+
+// SetMarkerStyle sets the style of event markers
+func (c *Calendar) SetMarkerStyle(style terminus.Style) *Calendar {
+	c.markerStyle = style
+	return c
+}
+
+// SetOnSelect sets the callback triggered when Enter is pressed on a day
+func (c *Calendar) SetOnSelect(callback func(day time.Time) terminus.Cmd) *Calendar {
+	c.onSelect = callback
+	return c
+}
+
+// SetOnMonthChange sets the callback triggered when the displayed month
+// changes, e.g. to load that month's events
+func (c *Calendar) SetOnMonthChange(callback func(month time.Time) terminus.Cmd) *Calendar {
+	c.onMonthChange = callback
+	return c
+}
+
+// Init implements the Component interface
+func (c *Calendar) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (c *Calendar) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if !c.Focused() {
+		return c, nil
+	}
+
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	month := c.Month()
+	switch keyMsg.Type {
+	case terminus.KeyLeft:
+		c.selected = c.selected.AddDate(0, 0, -1)
+	case terminus.KeyRight:
+		c.selected = c.selected.AddDate(0, 0, 1)
+	case terminus.KeyUp:
+		c.selected = c.selected.AddDate(0, 0, -7)
+	case terminus.KeyDown:
+		c.selected = c.selected.AddDate(0, 0, 7)
+	case terminus.KeyPgUp:
+		c.addMonths(-1)
+	case terminus.KeyPgDown:
+		c.addMonths(1)
+	case terminus.KeyHome:
+		c.selected = startOfDay(c.now())
+	case terminus.KeyEnter:
+		if c.onSelect != nil {
+			return c, c.onSelect(c.selected)
+		}
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) != 1 {
+			break
+		}
+		switch keyMsg.Runes[0] {
+		case 'h':
+			c.selected = c.selected.AddDate(0, 0, -1)
+		case 'l':
+			c.selected = c.selected.AddDate(0, 0, 1)
+		case 'k':
+			c.selected = c.selected.AddDate(0, 0, -7)
+		case 'j':
+			c.selected = c.selected.AddDate(0, 0, 7)
+		case '<', '[':
+			c.addMonths(-1)
+		case '>', ']':
+			c.addMonths(1)
+		case 't':
+			c.selected = startOfDay(c.now())
+		}
+	}
+
+	if c.onMonthChange != nil && !c.Month().Equal(month) {
+		return c, c.onMonthChange(c.Month())
+	}
+	return c, nil
+}
+
+// addMonths moves the selection by months, keeping the day of the month
+// where it exists (Jan 31 becomes Feb 28)
+func (c *Calendar) addMonths(n int) {
+	day := c.selected.Day()
+	first := c.Month().AddDate(0, n, 0)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	c.selected = first.AddDate(0, 0, day-1)
+}
+
+// View implements the Component interface
+func (c *Calendar) View() string {
+	var lines []string
+
+	// Month title with navigation hints
+	gridWidth := 7 * calendarCellWidth
+	title := c.selected.Format("January 2006")
+	pad := gridWidth - 4 - len(title)
+	left := pad / 2
+	lines = append(lines, "‹ "+strings.Repeat(" ", left)+c.headerStyle.Render(title)+strings.Repeat(" ", pad-left)+" ›")
+
+	// Weekday names
+	var names strings.Builder
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((int(c.weekStart) + i) % 7)
+		names.WriteString(fmt.Sprintf("%-*s", calendarCellWidth, day.String()[:2]))
+	}
+	lines = append(lines, c.weekdayStyle.Render(strings.TrimRight(names.String(), " ")))
+
+	// Six week rows cover every month layout
+	month := c.Month()
+	offset := (int(month.Weekday()) - int(c.weekStart) + 7) % 7
+	day := month.AddDate(0, 0, -offset)
+	today := startOfDay(c.now())
+	for week := 0; week < 6; week++ {
+		var row strings.Builder
+		for i := 0; i < 7; i++ {
+			row.WriteString(c.renderDay(day, day.Month() == month.Month(), day.Equal(today)))
+			day = day.AddDate(0, 0, 1)
+		}
+		lines = append(lines, strings.TrimRight(row.String(), " "))
+	}
+
+	if c.showAgenda {
+		lines = append(lines, "", c.headerStyle.Render(c.selected.Format("Mon, Jan 2")))
+		events := c.eventsOn(c.selected)
+		if len(events) == 0 {
+			lines = append(lines, c.weekdayStyle.Render("  No events"))
+		}
+		for _, event := range events {
+			lines = append(lines, c.markerStyle.Render("  "+c.marker)+" "+c.agendaStyle.Render(event))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderDay renders one grid cell: the day number and an event marker
+func (c *Calendar) renderDay(day time.Time, inMonth, isToday bool) string {
+	if !inMonth {
+		return strings.Repeat(" ", calendarCellWidth)
+	}
+
+	number := fmt.Sprintf("%2d", day.Day())
+	switch {
+	case day.Equal(c.selected):
+		number = c.selectedStyle.Render(number)
+	case isToday:
+		number = c.todayStyle.Render(number)
+	}
+
+	marker := " "
+	if len(c.eventsOn(day)) > 0 {
+		marker = c.markerStyle.Render(c.marker)
+	}
+	return number + marker + " "
+}
+
+// eventsOn returns the events on a day
+func (c *Calendar) eventsOn(day time.Time) []string {
+	if c.events == nil {
+		return nil
+	}
+	return c.events(day)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// newTestCalendar returns a focused calendar where today is 2025-03-12
+func newTestCalendar() *Calendar {
+	c := NewCalendar()
+	c.now = func() time.Time { return time.Date(2025, 3, 12, 15, 4, 0, 0, time.UTC) }
+	c.SetDate(c.now())
+	c.Focus()
+	return c
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendar(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Lays out the month grid",
+			test: func(t *testing.T) {
+				c := newTestCalendar().SetShowAgenda(false)
+				lines := strings.Split(plain(c.View()), "\n")
+
+				if !strings.Contains(lines[0], "March 2025") {
+					t.Errorf("Expected month title, got %q", lines[0])
+				}
+				if lines[1] != "Su  Mo  Tu  We  Th  Fr  Sa" {
+					t.Errorf("Unexpected weekday row %q", lines[1])
+				}
+				// March 1st 2025 is a Saturday
+				if lines[2] != strings.Repeat(" ", 24)+" 1" {
+					t.Errorf("Expected the 1st under Saturday, got %q", lines[2])
+				}
+				if len(lines) != 8 {
+					t.Errorf("Expected 8 lines, got %d", len(lines))
+				}
+
+				c.SetWeekStart(time.Monday)
+				lines = strings.Split(plain(c.View()), "\n")
+				if !strings.HasPrefix(lines[1], "Mo") || lines[2] != strings.Repeat(" ", 20)+" 1   2" {
+					t.Errorf("Expected Monday-first layout, got %q", lines[1:3])
+				}
+			},
+		},
+		{
+			name: "Navigates by day, week and month",
+			test: func(t *testing.T) {
+				c := newTestCalendar()
+
+				press(c, terminus.KeyMsg{Type: terminus.KeyRight}, terminus.KeyMsg{Type: terminus.KeyDown})
+				if !c.Selected().Equal(date(2025, 3, 20)) {
+					t.Errorf("Expected Mar 20, got %v", c.Selected())
+				}
+
+				c.SetDate(date(2025, 1, 31))
+				press(c, terminus.KeyMsg{Type: terminus.KeyPgDown})
+				if !c.Selected().Equal(date(2025, 2, 28)) {
+					t.Errorf("Expected month step to clamp to Feb 28, got %v", c.Selected())
+				}
+
+				press(c, runeKey('t'))
+				if !c.Selected().Equal(date(2025, 3, 12)) {
+					t.Errorf("Expected t to return to today, got %v", c.Selected())
+				}
+			},
+		},
+		{
+			name: "Reports month changes and selection",
+			test: func(t *testing.T) {
+				var months []time.Time
+				var picked time.Time
+				c := newTestCalendar().
+					SetOnMonthChange(func(month time.Time) terminus.Cmd {
+						months = append(months, month)
+						return nil
+					}).
+					SetOnSelect(func(day time.Time) terminus.Cmd {
+						picked = day
+						return nil
+					})
+
+				press(c, terminus.KeyMsg{Type: terminus.KeyRight})
+				if len(months) != 0 {
+					t.Error("Expected no month change within March")
+				}
+				press(c, runeKey('>'), terminus.KeyMsg{Type: terminus.KeyEnter})
+				if len(months) != 1 || !months[0].Equal(date(2025, 4, 1)) {
+					t.Errorf("Expected a change to April, got %v", months)
+				}
+				if !picked.Equal(date(2025, 4, 13)) {
+					t.Errorf("Expected Apr 13 selected, got %v", picked)
+				}
+			},
+		},
+		{
+			name: "Marks days with events and lists the agenda",
+			test: func(t *testing.T) {
+				events := map[time.Time][]string{
+					date(2025, 3, 12): {"Standup", "Release"},
+					date(2025, 3, 20): {"Retro"},
+				}
+				c := newTestCalendar().
+					SetEvents(func(day time.Time) []string { return events[day] })
+				view := plain(c.View())
+
+				if !strings.Contains(view, "12• ") || !strings.Contains(view, "20•") {
+					t.Errorf("Expected markers on event days, got %q", view)
+				}
+				if strings.Contains(view, "13•") {
+					t.Error("Expected no marker on empty days")
+				}
+				if !strings.Contains(view, "Wed, Mar 12\n  • Standup\n  • Release") {
+					t.Errorf("Expected agenda for the selected day, got %q", view)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// ComposeDirection is the way a Compose lays out its children
+type ComposeDirection int
+
+const (
+	// ComposeVertical stacks children top to bottom
+	ComposeVertical ComposeDirection = iota
+	// ComposeHorizontal places children side by side
+	ComposeHorizontal
+)
+
+// focusable is a child that can take key presses
+type focusable interface {
+	Focus()
+	Blur()
+	Focused() bool
+}
+
+// composeChild is a named child of a Compose
+type composeChild struct {
+	name        string
+	component   terminus.Component
+	width       int // Column width when horizontal; 0 fits the view
+	allotted    int // Column width given by the layout when horizontal
+	hidden      bool
+	initialized bool
+}
+
+// Compose is a component made of named child components. It calls each
+// child's Init once, routes messages to the children, batches the commands
+// they return and joins their views.
+//
+// Key presses go to the focused child; Tab and Shift+Tab move focus between
+// the children that can take it (those with Focus, Blur and Focused
+// methods). Every other message, such as ticks, results and WindowSizeMsg,
+// goes to every child.
+type Compose struct {
+	children  []*composeChild
+	direction ComposeDirection
+	gap       int
+	focus     int // Index in children, or -1
+	started   bool
+	follow    bool // Focus follows activity
+	size      Size // Set by SetSize; 0x0 leaves children their sizes
+}
+
+// NewCompose creates an empty composition laid out in direction
+func NewCompose(direction ComposeDirection) *Compose {
+	return &Compose{
+		direction: direction,
+		focus:     -1,
+	}
+}
+
+// Add appends a child under name, replacing any child already using it.
+// A child added after Init is initialized with the next message. The first
+// child that can take focus gets it.
+func (c *Compose) Add(name string, child terminus.Component) *Compose {
+	if i := c.index(name); i >= 0 {
+		c.blur(i)
+		c.children[i] = &composeChild{name: name, component: child}
+		if c.focus == i {
+			c.focus = -1
+		}
+	} else {
+		c.children = append(c.children, &composeChild{name: name, component: child})
+	}
+	if c.focus < 0 {
+		c.step(1)
+	}
+	c.layout()
+	return c
+}
+
+// Remove removes a child
+func (c *Compose) Remove(name string) *Compose {
+	i := c.index(name)
+	if i < 0 {
+		return c
+	}
+	c.blur(i)
+	c.children = append(c.children[:i], c.children[i+1:]...)
+	switch {
+	case c.focus == i:
+		c.focus = -1
+		c.step(1)
+	case c.focus > i:
+		c.focus--
+	}
+	c.layout()
+	return c
+}
+
+// Child returns the child added under name, or nil
+func (c *Compose) Child(name string) terminus.Component {
+	if i := c.index(name); i >= 0 {
+		return c.children[i].component
+	}
+	return nil
+}
+
+// NamedChildren implements terminus.Composite, returning the visible
+// children, so the style lint reports bugs against the child they come from
+func (c *Compose) NamedChildren() []terminus.NamedComponent {
+	var children []terminus.NamedComponent
+	for _, child := range c.children {
+		if child.visible() {
+			children = append(children, terminus.NamedComponent{Name: child.name, Component: child.component})
+		}
+	}
+	return children
+}
+
+// ChildRegion returns where the named child is drawn in the Compose's
+// view, e.g. for a Tour to highlight it. Hidden children have no region.
+func (c *Compose) ChildRegion(name string) (terminus.Region, bool) {
+	var region terminus.Region
+	for _, child := range c.children {
+		if !child.visible() {
+			continue
+		}
+		lines := strings.Split(child.component.View(), "\n")
+		width := cmp.Or(child.width, child.allotted)
+		if width <= 0 {
+			for _, line := range lines {
+				width = max(width, visibleWidth(line))
+			}
+		}
+		region.Width, region.Height = width, len(lines)
+		if child.name == name {
+			return region, true
+		}
+		if c.direction == ComposeVertical {
+			region.Y += region.Height + c.gap
+		} else {
+			region.X += region.Width + c.gap
+		}
+	}
+	return terminus.Region{}, false
+}
+
+// Len returns the number of children
+func (c *Compose) Len() int {
+	return len(c.children)
+}
+
+// SetGap sets the number of blank lines, or columns when horizontal,
+// between children
+func (c *Compose) SetGap(gap int) *Compose {
+	c.gap = gap
+	return c
+}
+
+// SetSize divides width x height among the children by their constraints
+// (see Arrange) and sizes those that are widgets, so a parent sizes the
+// whole composition rather than each child. Children are laid out again
+// as they are added, removed, shown and hidden; call SetSize again after
+// showing or hiding a child's widget, or changing its constraints.
+func (c *Compose) SetSize(width, height int) {
+	c.size = Size{Width: width, Height: height}
+	c.layout()
+}
+
+// Measure returns the constraints of the children laid out together
+func (c *Compose) Measure() Constraints {
+	return combine(c.direction, c.gap, c.laidOut()...)
+}
+
+// laidOut returns the children's components, nil for those the Compose
+// hides
+func (c *Compose) laidOut() []terminus.Component {
+	components := make([]terminus.Component, len(c.children))
+	for i, child := range c.children {
+		if !child.hidden {
+			components[i] = child.component
+		}
+	}
+	return components
+}
+
+// layout sizes the children to fit the size set by SetSize, if any
+func (c *Compose) layout() {
+	if c.size == (Size{}) {
+		return
+	}
+	for i, size := range Arrange(c.direction, c.size, c.gap, c.laidOut()...) {
+		child := c.children[i]
+		if !child.visible() {
+			continue
+		}
+		child.allotted = size.Width
+		if w, ok := child.component.(interface{ SetSize(width, height int) }); ok {
+			w.SetSize(size.Width, size.Height)
+		}
+	}
+}
+
+// SetWidth sets the width of a child's column when laid out horizontally.
+// Its view is padded or truncated to fit; 0 uses the width SetSize gives
+// it, or the view's own width.
+func (c *Compose) SetWidth(name string, width int) *Compose {
+	if i := c.index(name); i >= 0 {
+		c.children[i].width = width
+	}
+	return c
+}
+
+// SetHidden hides or shows a child. Hidden children still receive messages
+// but take no space and can't be focused, as do widgets hidden with Hide.
+func (c *Compose) SetHidden(name string, hidden bool) *Compose {
+	i := c.index(name)
+	if i < 0 {
+		return c
+	}
+	c.children[i].hidden = hidden
+	if hidden && c.focus == i {
+		c.step(1)
+		if c.focus == i {
+			c.blur(i)
+			c.focus = -1
+		}
+	}
+	c.layout()
+	return c
+}
+
+// FocusChild moves focus to a child, if it can take it
+func (c *Compose) FocusChild(name string) *Compose {
+	i := c.index(name)
+	if i < 0 || !c.canFocus(i) {
+		return c
+	}
+	if c.focus >= 0 && c.focus != i {
+		c.blur(c.focus)
+	}
+	c.focus = i
+	c.children[i].component.(focusable).Focus()
+	return c
+}
+
+// SetFocusFollowsActivity sets whether a child Panel that requests
+// attention takes focus, e.g. so the user lands on an error as it arrives
+// rather than on the panel they were in
+func (c *Compose) SetFocusFollowsActivity(follow bool) *Compose {
+	c.follow = follow
+	return c
+}
+
+// FocusedChild returns the name of the focused child, or ""
+func (c *Compose) FocusedChild() string {
+	if c.focus < 0 {
+		return ""
+	}
+	return c.children[c.focus].name
+}
+
+// index returns the position of the child named name, or -1
+func (c *Compose) index(name string) int {
+	for i, child := range c.children {
+		if child.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// canFocus returns whether child i can take focus: it is focusable, laid
+// out and not disabled
+func (c *Compose) canFocus(i int) bool {
+	_, ok := c.children[i].component.(focusable)
+	if d, isWidget := c.children[i].component.(interface{ Disabled() bool }); isWidget && d.Disabled() {
+		return false
+	}
+	return ok && c.children[i].visible()
+}
+
+// visible returns whether the child is laid out: neither hidden by the
+// Compose nor a hidden widget
+func (child *composeChild) visible() bool {
+	return !child.hidden && Visible(child.component)
+}
+
+// blur removes focus from child i if it has it
+func (c *Compose) blur(i int) {
+	if f, ok := c.children[i].component.(focusable); ok && f.Focused() {
+		f.Blur()
+	}
+}
+
+// step moves focus to the next child, or the previous one when delta is
+// negative, that can take it
+func (c *Compose) step(delta int) {
+	n := len(c.children)
+	start := c.focus
+	if start < 0 {
+		start = n - 1
+		if delta < 0 {
+			start = 0
+		}
+	}
+	for k := 1; k <= n; k++ {
+		if i := ((start+delta*k)%n + n) % n; c.canFocus(i) {
+			c.FocusChild(c.children[i].name)
+			return
+		}
+	}
+}
+
+// initChildren initializes the children that haven't been and batches
+// their commands
+func (c *Compose) initChildren() terminus.Cmd {
+	var cmds []terminus.Cmd
+	for _, child := range c.children {
+		if !child.initialized {
+			child.initialized = true
+			cmds = append(cmds, child.component.Init())
+		}
+	}
+	return terminus.Batch(cmds...)
+}
+
+// Init implements the Component interface
+func (c *Compose) Init() terminus.Cmd {
+	c.started = true
+	return c.initChildren()
+}
+
+// Update implements the Component interface
+func (c *Compose) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	var cmds []terminus.Cmd
+	if c.started {
+		cmds = append(cmds, c.initChildren())
+	}
+
+	if attention, ok := msg.(AttentionMsg); ok && c.follow {
+		for _, child := range c.children {
+			if child.component == terminus.Component(attention.Panel) {
+				c.FocusChild(child.name)
+				break
+			}
+		}
+	}
+
+	if key, ok := msg.(terminus.KeyMsg); ok {
+		if key.Type == terminus.KeyTab && c.focus >= 0 {
+			if key.Shift {
+				c.step(-1)
+			} else {
+				c.step(1)
+			}
+			return c, terminus.Batch(cmds...)
+		}
+		if c.focus >= 0 {
+			cmds = append(cmds, c.updateChild(c.children[c.focus], msg))
+			return c, terminus.Batch(cmds...)
+		}
+	}
+
+	for _, child := range c.children {
+		cmds = append(cmds, c.updateChild(child, msg))
+	}
+	return c, terminus.Batch(cmds...)
+}
+
+// updateChild delivers msg to a child, keeping the component it returns
+func (c *Compose) updateChild(child *composeChild, msg terminus.Msg) terminus.Cmd {
+	updated, cmd := child.component.Update(msg)
+	if updated != nil {
+		child.component = updated
+	}
+	return cmd
+}
+
+// View implements the Component interface
+func (c *Compose) View() string {
+	var views []string
+	var widths []int
+	for _, child := range c.children {
+		if child.visible() {
+			views = append(views, child.component.View())
+			widths = append(widths, cmp.Or(child.width, child.allotted))
+		}
+	}
+	if c.direction == ComposeVertical {
+		return strings.Join(views, strings.Repeat("\n", c.gap+1))
+	}
+	return joinColumns(views, widths, c.gap)
+}
+
+// joinColumns places views side by side, padding each to its width, or to
+// its widest line when the width is 0
+func joinColumns(views []string, widths []int, gap int) string {
+	columns := make([][]string, len(views))
+	height := 0
+	for i, view := range views {
+		columns[i] = strings.Split(view, "\n")
+		if len(columns[i]) > height {
+			height = len(columns[i])
+		}
+		if widths[i] <= 0 {
+			for _, line := range columns[i] {
+				if w := visibleWidth(line); w > widths[i] {
+					widths[i] = w
+				}
+			}
+		}
+	}
+
+	lines := make([]string, height)
+	spacer := strings.Repeat(" ", gap)
+	for row := range lines {
+		var b strings.Builder
+		for i, column := range columns {
+			if i > 0 {
+				b.WriteString(spacer)
+			}
+			var line string
+			if row < len(column) {
+				line = column[row]
+			}
+			b.WriteString(fitWidth(line, widths[i]))
+		}
+		lines[row] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
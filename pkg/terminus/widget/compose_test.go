@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// probe records what a Compose does with it
+type probe struct {
+	view  string
+	inits int
+	msgs  []terminus.Msg
+}
+
+func (p *probe) Init() terminus.Cmd {
+	p.inits++
+	return func() terminus.Msg { return nil }
+}
+
+func (p *probe) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	p.msgs = append(p.msgs, msg)
+	return p, nil
+}
+
+func (p *probe) View() string { return p.view }
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Initializes each child once",
+			test: func(t *testing.T) {
+				a, b := &probe{}, &probe{}
+				c := NewCompose(ComposeVertical).Add("a", a)
+				if c.Init() == nil {
+					t.Error("Expected the child's command")
+				}
+				c.Add("b", b)
+				c.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+				c.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+				if a.inits != 1 || b.inits != 1 {
+					t.Errorf("Expected one Init each, got %d and %d", a.inits, b.inits)
+				}
+				if len(a.msgs) != 2 || len(b.msgs) != 2 {
+					t.Errorf("Expected every child to get every message, got %d and %d", len(a.msgs), len(b.msgs))
+				}
+			},
+		},
+		{
+			name: "Routes keys to the focused child",
+			test: func(t *testing.T) {
+				first := NewTextInput()
+				second := NewTextInput()
+				other := &probe{}
+				c := NewCompose(ComposeVertical).
+					Add("status", other).
+					Add("first", first).
+					Add("second", second)
+				c.Init()
+				if c.FocusedChild() != "first" || !first.Focused() {
+					t.Fatalf("Expected the first input focused, got %q", c.FocusedChild())
+				}
+
+				press(c, runeKey('x'), terminus.KeyMsg{Type: terminus.KeyTab}, runeKey('y'))
+				if first.Value() != "x" || second.Value() != "y" || first.Focused() {
+					t.Errorf("Expected x then y, got %q and %q", first.Value(), second.Value())
+				}
+				if len(other.msgs) != 0 {
+					t.Errorf("Expected no keys for the unfocused child, got %v", other.msgs)
+				}
+
+				press(c, terminus.KeyMsg{Type: terminus.KeyTab, Shift: true})
+				if c.FocusedChild() != "first" {
+					t.Errorf("Expected Shift+Tab to go back, got %q", c.FocusedChild())
+				}
+				c.Remove("first")
+				if c.FocusedChild() != "second" || !second.Focused() {
+					t.Errorf("Expected focus to move on, got %q", c.FocusedChild())
+				}
+			},
+		},
+		{
+			name: "Focuses a panel that requests attention when focus follows activity",
+			test: func(t *testing.T) {
+				logs, alerts := NewPanel("Logs", NewList()), NewPanel("Alerts", NewList())
+				c := NewCompose(ComposeHorizontal).Add("logs", logs).Add("alerts", alerts)
+				c.Update(AttentionMsg{Panel: alerts})
+				if c.FocusedChild() != "logs" {
+					t.Errorf("Expected focus to stay on logs, got %q", c.FocusedChild())
+				}
+
+				c.SetFocusFollowsActivity(true)
+				alerts.RequestAttention()
+				c.Update(AttentionMsg{Panel: alerts})
+				if c.FocusedChild() != "alerts" || alerts.WantsAttention() || logs.Focused() {
+					t.Errorf("Expected focus to move to alerts, got %q", c.FocusedChild())
+				}
+			},
+		},
+		{
+			name: "Joins views",
+			test: func(t *testing.T) {
+				c := NewCompose(ComposeHorizontal).
+					Add("left", &probe{view: "ab\nc"}).
+					Add("right", &probe{view: "de"}).
+					SetGap(1)
+				if got := c.View(); got != "ab de\nc    " {
+					t.Errorf("Expected columns, got %q", got)
+				}
+				c.SetWidth("left", 1)
+				if got := c.View(); got != "a de\nc   " {
+					t.Errorf("Expected a narrowed column, got %q", got)
+				}
+
+				c = NewCompose(ComposeVertical).
+					Add("top", &probe{view: "top"}).
+					Add("hidden", &probe{view: "x"}).
+					Add("bottom", &probe{view: "bottom"}).
+					SetHidden("hidden", true)
+				if got := c.View(); got != "top\nbottom" {
+					t.Errorf("Expected stacked views, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Names its visible children for the style lint",
+			test: func(t *testing.T) {
+				c := NewCompose(ComposeVertical).
+					Add("top", &probe{}).
+					Add("hidden", &probe{}).
+					SetHidden("hidden", true)
+				children := c.NamedChildren()
+				if len(children) != 1 || children[0].Name != "top" || children[0].Component != c.Child("top") {
+					t.Errorf("Expected only the visible child, got %+v", children)
+				}
+			},
+		},
+		{
+			name: "Finds where its children are drawn",
+			test: func(t *testing.T) {
+				c := NewCompose(ComposeHorizontal).
+					Add("left", &probe{view: "ab\nc"}).
+					Add("right", &probe{view: "de"}).
+					SetGap(1)
+				if region, ok := c.ChildRegion("right"); !ok || region != (terminus.Region{X: 3, Width: 2, Height: 1}) {
+					t.Errorf("Expected the right column, got %+v", region)
+				}
+
+				c = NewCompose(ComposeVertical).
+					Add("top", &probe{view: "a\nb"}).
+					Add("hidden", &probe{view: "x"}).
+					Add("bottom", &probe{view: "bottom"}).
+					SetHidden("hidden", true).
+					SetGap(1)
+				if region, ok := c.ChildRegion("bottom"); !ok || region != (terminus.Region{Y: 3, Width: 6, Height: 1}) {
+					t.Errorf("Expected the bottom row, got %+v", region)
+				}
+				if _, ok := c.ChildRegion("hidden"); ok {
+					t.Error("Expected no region for a hidden child")
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import "github.com/skaiser/terminusgo/pkg/terminus"
+
+// maxCount caps a count prefix, so a held key can't overflow it
+const maxCount = 99999
+
+// countPrefix accumulates the digits typed before a key, vim style, so 5j
+// moves down five lines. Widgets feed it every key and take the count when
+// a key that isn't a digit arrives.
+type countPrefix struct {
+	disabled bool
+	n        int
+}
+
+// feed adds the digit typed by key to the count and reports whether it was
+// one. 0 only continues a count, as in vim.
+func (c *countPrefix) feed(key terminus.KeyMsg) bool {
+	if c.disabled || key.Type != terminus.KeyRunes || len(key.Runes) != 1 || key.Ctrl || key.Alt {
+		return false
+	}
+	r := key.Runes[0]
+	if r < '0' || r > '9' || (r == '0' && c.n == 0) {
+		return false
+	}
+	c.n = min(c.n*10+int(r-'0'), maxCount)
+	return true
+}
+
+// take returns the count typed, or 0 if none was, and clears it
+func (c *countPrefix) take() int {
+	n := c.n
+	c.n = 0
+	return n
+}
+
+// digits returns the digits that start a count, for KeyBindings
+func (c *countPrefix) digits() string {
+	if c.disabled {
+		return ""
+	}
+	return "123456789"
+}
+
+// motion returns the arrow key that vim's j and k stand for, or key if it
+// is neither
+func motion(key terminus.KeyMsg) terminus.KeyMsg {
+	if key.Type == terminus.KeyRunes && len(key.Runes) == 1 && !key.Ctrl && !key.Alt {
+		switch key.Runes[0] {
+		case 'j':
+			return terminus.KeyMsg{Type: terminus.KeyDown, Shift: key.Shift, User: key.User}
+		case 'k':
+			return terminus.KeyMsg{Type: terminus.KeyUp, Shift: key.Shift, User: key.User}
+		}
+	}
+	return key
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestCountPrefix(t *testing.T) {
+	items := make([]string, 50)
+	for i := range items {
+		items[i] = fmt.Sprint("item ", i)
+	}
+	newList := func() *List {
+		list := NewList()
+		list.SetStringItems(items)
+		list.SetSize(20, 10)
+		list.Focus()
+		return list
+	}
+	newTable := func() *Table {
+		data := make([][]string, len(items))
+		for i, item := range items {
+			data[i] = []string{item}
+		}
+		table := NewTable().SetStringData([]string{"Item"}, data)
+		table.SetSize(20, 10)
+		table.Focus()
+		return table
+	}
+	newPager := func() *Pager {
+		pager := NewPager().SetContent(strings.Join(items, "\n"))
+		pager.SetSize(20, 10)
+		pager.Focus()
+		return pager
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "List moves by the count",
+			test: func(t *testing.T) {
+				list := newList()
+				typeLine(list, "5j")
+				if list.SelectedIndex() != 5 {
+					t.Errorf("Expected item 5, got %d", list.SelectedIndex())
+				}
+				typeLine(list, "12")
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				typeLine(list, "2k")
+				if list.SelectedIndex() != 15 {
+					t.Errorf("Expected item 15, got %d", list.SelectedIndex())
+				}
+				typeLine(list, "j")
+				if list.SelectedIndex() != 16 {
+					t.Errorf("Expected the count to be used up, got %d", list.SelectedIndex())
+				}
+			},
+		},
+		{
+			name: "Table stops at the ends",
+			test: func(t *testing.T) {
+				table := newTable()
+				typeLine(table, "10j")
+				if table.SelectedRow() != 10 {
+					t.Errorf("Expected row 10, got %d", table.SelectedRow())
+				}
+				typeLine(table, "99j")
+				if table.SelectedRow() != 49 {
+					t.Errorf("Expected the last row, got %d", table.SelectedRow())
+				}
+				typeLine(table, "100k")
+				if table.SelectedRow() != 0 {
+					t.Errorf("Expected the first row, got %d", table.SelectedRow())
+				}
+			},
+		},
+		{
+			name: "Pager goes to the counted line",
+			test: func(t *testing.T) {
+				pager := newPager()
+				typeLine(pager, "3j")
+				if pager.Line() != 4 {
+					t.Errorf("Expected line 4, got %d", pager.Line())
+				}
+				typeLine(pager, "20G")
+				if pager.Line() != 20 {
+					t.Errorf("Expected line 20, got %d", pager.Line())
+				}
+				typeLine(pager, "g")
+				if pager.Line() != 1 {
+					t.Errorf("Expected the top, got %d", pager.Line())
+				}
+			},
+		},
+		{
+			name: "Can be turned off",
+			test: func(t *testing.T) {
+				list := newList().SetCountPrefix(false)
+				typeLine(list, "5j")
+				if list.SelectedIndex() != 1 {
+					t.Errorf("Expected a single step, got %d", list.SelectedIndex())
+				}
+			},
+		},
+		{
+			name: "0 doesn't start a count",
+			test: func(t *testing.T) {
+				var c countPrefix
+				if c.feed(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("0")}) {
+					t.Error("Expected 0 to be a key of its own")
+				}
+				c.feed(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("1")})
+				c.feed(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("0")})
+				if n := c.take(); n != 10 {
+					t.Errorf("Expected 10, got %d", n)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Payload is what is dragged from one widget to another
+type Payload struct {
+	// Kind names what is dragged, e.g. "file" or "card". Targets only
+	// accept the kinds they know.
+	Kind string
+	// Items are the dragged items, e.g. ListItems
+	Items []any
+	// Source is the name of the child they are dragged from
+	Source string
+}
+
+// DragSource is a widget items can be dragged from
+type DragSource interface {
+	// DragStart returns the payload of the selected items, or false when
+	// nothing can be dragged
+	DragStart() (Payload, bool)
+	// DragEnd ends the drag started last, reporting whether a target took
+	// the payload. A source that moves items removes them once dropped.
+	DragEnd(payload Payload, dropped bool)
+}
+
+// DropTarget is a widget items can be dropped on
+type DropTarget interface {
+	// CanDrop returns whether the target accepts payload
+	CanDrop(payload Payload) bool
+	// Drop adds the payload's items to the target
+	Drop(payload Payload) terminus.Cmd
+}
+
+// DragDrop moves items between the children of a Compose. With the
+// keyboard, m picks up the selection of the focused child, Tab moves focus
+// to another child as usual and p drops the items there. Esc, or p on the
+// child they came from, puts them back. Children take part by implementing
+// DragSource and DropTarget, as a List with a drag kind does.
+type DragDrop struct {
+	compose  *Compose
+	dragging *Payload
+	markKey  terminus.KeyMsg
+	dropKey  terminus.KeyMsg
+
+	hintStyle terminus.Style
+
+	onDrop func(from, to string, payload Payload) terminus.Cmd
+}
+
+// NewDragDrop creates drag and drop between the children of compose
+func NewDragDrop(compose *Compose) *DragDrop {
+	return &DragDrop{
+		compose:   compose,
+		markKey:   terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("m")},
+		dropKey:   terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("p")},
+		hintStyle: terminus.NewStyle().Faint(true),
+	}
+}
+
+// SetKeys sets the keys that pick up and drop items, m and p by default
+func (d *DragDrop) SetKeys(mark, drop terminus.KeyMsg) *DragDrop {
+	d.markKey, d.dropKey = mark, drop
+	return d
+}
+
+// SetHintStyle sets the style of the line shown while dragging
+func (d *DragDrop) SetHintStyle(style terminus.Style) *DragDrop {
+	d.hintStyle = style
+	return d
+}
+
+// SetOnDrop sets the callback triggered when items are dropped, with the
+// names of the children they moved between, e.g. to save the move
+func (d *DragDrop) SetOnDrop(callback func(from, to string, payload Payload) terminus.Cmd) *DragDrop {
+	d.onDrop = callback
+	return d
+}
+
+// Dragging returns the payload being dragged, if any
+func (d *DragDrop) Dragging() (Payload, bool) {
+	if d.dragging == nil {
+		return Payload{}, false
+	}
+	return *d.dragging, true
+}
+
+// Cancel puts back the items being dragged
+func (d *DragDrop) Cancel() {
+	if d.dragging == nil {
+		return
+	}
+	payload := *d.dragging
+	d.dragging = nil
+	if source, ok := d.compose.Child(payload.Source).(DragSource); ok {
+		source.DragEnd(payload, false)
+	}
+}
+
+// KeyBindings returns the keys drag and drop handles, for checking global
+// shortcuts against
+func (d *DragDrop) KeyBindings() []terminus.KeyMsg {
+	return []terminus.KeyMsg{d.markKey, d.dropKey, {Type: terminus.KeyEsc}}
+}
+
+// Init implements the Component interface
+func (d *DragDrop) Init() terminus.Cmd {
+	return d.compose.Init()
+}
+
+// Update implements the Component interface
+func (d *DragDrop) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if key, ok := msg.(terminus.KeyMsg); ok {
+		if handled, cmd := d.handleKey(key); handled {
+			return d, cmd
+		}
+	}
+	_, cmd := d.compose.Update(msg)
+	return d, cmd
+}
+
+// handleKey picks up, drops or puts back items, reporting whether key was
+// one of its keys
+func (d *DragDrop) handleKey(key terminus.KeyMsg) (bool, terminus.Cmd) {
+	name := d.compose.FocusedChild()
+	switch {
+	case d.dragging == nil && sameKey(key, d.markKey):
+		source, ok := d.compose.Child(name).(DragSource)
+		if !ok {
+			return false, nil
+		}
+		payload, ok := source.DragStart()
+		if !ok {
+			return false, nil
+		}
+		payload.Source = name
+		d.dragging = &payload
+		return true, nil
+
+	case d.dragging != nil && key.Type == terminus.KeyEsc:
+		d.Cancel()
+		return true, nil
+
+	case d.dragging != nil && sameKey(key, d.dropKey):
+		payload := *d.dragging
+		if name == payload.Source {
+			d.Cancel()
+			return true, nil
+		}
+		target, ok := d.compose.Child(name).(DropTarget)
+		if !ok || !target.CanDrop(payload) {
+			// Keep dragging until a target takes it
+			return true, nil
+		}
+		d.dragging = nil
+		cmd := target.Drop(payload)
+		if source, ok := d.compose.Child(payload.Source).(DragSource); ok {
+			source.DragEnd(payload, true)
+		}
+		if d.onDrop != nil {
+			cmd = terminus.Batch(cmd, d.onDrop(payload.Source, name, payload))
+		}
+		return true, cmd
+	}
+	return false, nil
+}
+
+// View implements the Component interface. While dragging, a line below
+// the children says what is being moved.
+func (d *DragDrop) View() string {
+	view := d.compose.View()
+	if d.dragging == nil {
+		return view
+	}
+	n := len(d.dragging.Items)
+	what := d.dragging.Kind
+	if what == "" {
+		what = "item"
+	}
+	if n != 1 {
+		what += "s"
+	}
+	hint := fmt.Sprintf("Moving %d %s from %s: %v to drop, Esc to cancel", n, what, d.dragging.Source, d.dropKey)
+	return view + "\n" + d.hintStyle.Render(hint)
+}
+
+// sameKey returns whether a and b are the same key with the same modifiers
+func sameKey(a, b terminus.KeyMsg) bool {
+	return a.Type == b.Type && a.Ctrl == b.Ctrl && a.Alt == b.Alt && a.Shift == b.Shift &&
+		string(a.Runes) == string(b.Runes)
+}
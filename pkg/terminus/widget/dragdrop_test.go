@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestDragDrop(t *testing.T) {
+	// newBoard returns two lists of cards side by side
+	newBoard := func() (*DragDrop, *List, *List) {
+		todo := NewList().SetStringItems([]string{"write", "test", "ship"}).SetDragKind("card")
+		done := NewList().SetStringItems([]string{"plan"}).SetDragKind("card")
+		board := NewDragDrop(NewCompose(ComposeHorizontal).Add("todo", todo).Add("done", done))
+		board.Init()
+		return board, todo, done
+	}
+	press := func(d *DragDrop, keys ...terminus.KeyMsg) {
+		for _, key := range keys {
+			d.Update(key)
+		}
+	}
+	mark := terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("m")}
+	drop := terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("p")}
+	tab := terminus.KeyMsg{Type: terminus.KeyTab}
+	down := terminus.KeyMsg{Type: terminus.KeyDown}
+	texts := func(l *List) string {
+		var texts []string
+		for _, item := range l.Items() {
+			texts = append(texts, item.String())
+		}
+		return strings.Join(texts, ",")
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Moves the selected item to another list",
+			test: func(t *testing.T) {
+				board, todo, done := newBoard()
+				var from, to string
+				board.SetOnDrop(func(f, t string, payload Payload) terminus.Cmd {
+					from, to = f, t
+					return nil
+				})
+
+				press(board, down, mark)
+				if !strings.Contains(board.View(), "Moving 1 card from todo") {
+					t.Errorf("Expected the drag hint, got %q", board.View())
+				}
+				press(board, tab, drop)
+				if texts(todo) != "write,ship" || texts(done) != "test,plan" {
+					t.Errorf("Expected test moved, got %q and %q", texts(todo), texts(done))
+				}
+				if done.SelectedItem().String() != "test" || from != "todo" || to != "done" {
+					t.Errorf("Expected the dropped item selected and reported, got %v from %q to %q", done.SelectedItem(), from, to)
+				}
+				if _, dragging := board.Dragging(); dragging {
+					t.Error("Expected the drag to end")
+				}
+			},
+		},
+		{
+			name: "Moves the checked items",
+			test: func(t *testing.T) {
+				board, todo, done := newBoard()
+				todo.SetChecked(0, true).SetChecked(2, true)
+				press(board, mark, tab, drop)
+				if texts(todo) != "test" || texts(done) != "write,ship,plan" {
+					t.Errorf("Expected the checked items moved, got %q and %q", texts(todo), texts(done))
+				}
+			},
+		},
+		{
+			name: "Puts items back",
+			test: func(t *testing.T) {
+				board, todo, _ := newBoard()
+				press(board, mark, terminus.KeyMsg{Type: terminus.KeyEsc})
+				press(board, mark, drop)
+				if _, dragging := board.Dragging(); dragging || texts(todo) != "write,test,ship" {
+					t.Errorf("Expected the items put back, got %q", texts(todo))
+				}
+			},
+		},
+		{
+			name: "Only drops on targets of the same kind",
+			test: func(t *testing.T) {
+				board, todo, done := newBoard()
+				done.SetDragKind("file")
+				press(board, mark, tab, drop)
+				if _, dragging := board.Dragging(); !dragging || texts(todo) != "write,test,ship" {
+					t.Errorf("Expected the drag to go on, got %q", texts(todo))
+				}
+			},
+		},
+		{
+			name: "Leaves keys to lists that can't be dragged from",
+			test: func(t *testing.T) {
+				board, todo, _ := newBoard()
+				todo.SetDragKind("")
+				press(board, mark)
+				if _, dragging := board.Dragging(); dragging {
+					t.Error("Expected no drag")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// EmptyState is shown in place of content there is none of yet: a message,
+// an optional icon or piece of ASCII art above it and a hint of the key that
+// adds some, centered in the widget's size. List and Table show one when
+// given one with SetEmptyState; custom panels can render it directly.
+type EmptyState struct {
+	Model
+
+	icon    string
+	message string
+	key     string
+	action  string
+
+	// Styling
+	iconStyle    terminus.Style
+	messageStyle terminus.Style
+	hintStyle    terminus.Style
+	keyStyle     terminus.Style
+}
+
+// NewEmptyState creates an empty state showing message. It takes the size of
+// its content until given one with SetSize.
+func NewEmptyState(message string) *EmptyState {
+	e := &EmptyState{
+		Model:        NewModel(),
+		message:      message,
+		iconStyle:    terminus.NewStyle().Faint(true),
+		messageStyle: terminus.NewStyle().Bold(true),
+		hintStyle:    terminus.NewStyle().Faint(true),
+		keyStyle:     terminus.NewStyle().Bold(true),
+	}
+	e.SetSize(0, 0)
+	return e
+}
+
+// SetMessage sets the message
+func (e *EmptyState) SetMessage(message string) *EmptyState {
+	e.message = message
+	return e
+}
+
+// SetIcon sets an icon or ASCII art shown above the message. It may span
+// several lines, which are centered as a block.
+func (e *EmptyState) SetIcon(icon string) *EmptyState {
+	e.icon = strings.Trim(icon, "\n")
+	return e
+}
+
+// SetAction suggests a key, shown under the message as "Press key to
+// action". An empty key removes the hint.
+func (e *EmptyState) SetAction(key, action string) *EmptyState {
+	e.key, e.action = key, action
+	return e
+}
+
+// SetIconStyle sets the style of the icon
+func (e *EmptyState) SetIconStyle(style terminus.Style) *EmptyState {
+	e.iconStyle = style
+	return e
+}
+
+// SetMessageStyle sets the style of the message
+func (e *EmptyState) SetMessageStyle(style terminus.Style) *EmptyState {
+	e.messageStyle = style
+	return e
+}
+
+// SetHintStyle sets the style of the action hint
+func (e *EmptyState) SetHintStyle(style terminus.Style) *EmptyState {
+	e.hintStyle = style
+	return e
+}
+
+// SetKeyStyle sets the style of the key in the action hint
+func (e *EmptyState) SetKeyStyle(style terminus.Style) *EmptyState {
+	e.keyStyle = style
+	return e
+}
+
+// Message returns the message
+func (e *EmptyState) Message() string {
+	return e.message
+}
+
+// Init implements the Component interface
+func (e *EmptyState) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (e *EmptyState) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return e, nil
+}
+
+// View implements the Component interface
+func (e *EmptyState) View() string {
+	// Each block is centered on its own, so the icon's lines stay aligned.
+	// A nil block is a blank line. The icon is left out when it doesn't fit.
+	var blocks [][]string
+	if e.icon != "" {
+		icon := strings.Split(e.icon, "\n")
+		if e.height <= 0 || len(icon)+1+e.textLines() <= e.height {
+			blocks = append(blocks, e.styleLines(icon, e.iconStyle), nil)
+		}
+	}
+	blocks = append(blocks, []string{e.messageStyle.Render(e.message)})
+	if e.key != "" {
+		hint := e.hintStyle.Render("Press ") + e.keyStyle.Render(e.key) + e.hintStyle.Render(" to "+e.action)
+		blocks = append(blocks, nil, []string{hint})
+	}
+
+	width := e.width
+	if width <= 0 {
+		for _, block := range blocks {
+			for _, line := range block {
+				width = max(width, visibleWidth(line))
+			}
+		}
+	}
+
+	var lines []string
+	for _, block := range blocks {
+		if block == nil {
+			lines = append(lines, strings.Repeat(" ", width))
+			continue
+		}
+		blockWidth := 0
+		for _, line := range block {
+			blockWidth = max(blockWidth, visibleWidth(line))
+		}
+		indent := strings.Repeat(" ", max(0, (width-blockWidth)/2))
+		for _, line := range block {
+			lines = append(lines, fitWidth(indent+line, width))
+		}
+	}
+
+	// Center vertically when there is room
+	if e.height > len(lines) {
+		blank := strings.Repeat(" ", width)
+		top := (e.height - len(lines)) / 2
+		padded := make([]string, 0, e.height)
+		for i := 0; i < top; i++ {
+			padded = append(padded, blank)
+		}
+		padded = append(padded, lines...)
+		for len(padded) < e.height {
+			padded = append(padded, blank)
+		}
+		lines = padded
+	}
+	return strings.Join(lines, "\n")
+}
+
+// textLines returns the number of lines of the message and hint
+func (e *EmptyState) textLines() int {
+	if e.key != "" {
+		return 3
+	}
+	return 1
+}
+
+// styleLines renders each line in style
+func (e *EmptyState) styleLines(lines []string, style terminus.Style) []string {
+	styled := make([]string, len(lines))
+	for i, line := range lines {
+		styled[i] = style.Render(line)
+	}
+	return styled
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// newPlainEmptyState returns an empty state without styling for easy
+// comparison
+func newPlainEmptyState(message string) *EmptyState {
+	plain := terminus.NewStyle()
+	return NewEmptyState(message).
+		SetIconStyle(plain).
+		SetMessageStyle(plain).
+		SetHintStyle(plain).
+		SetKeyStyle(plain)
+}
+
+func TestEmptyState(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Takes the size of its content",
+			test: func(t *testing.T) {
+				e := newPlainEmptyState("No tasks").SetAction("n", "add one")
+				want := strings.Join([]string{
+					"     No tasks     ",
+					"                  ",
+					"Press n to add one",
+				}, "\n")
+				if got := e.View(); got != want {
+					t.Errorf("Unexpected view:\n%s", got)
+				}
+			},
+		},
+		{
+			name: "Centers the icon as a block",
+			test: func(t *testing.T) {
+				e := newPlainEmptyState("Empty").SetIcon("\n /\\\n/__\\\n")
+				e.SetSize(9, 5)
+				want := strings.Join([]string{
+					"   /\\    ",
+					"  /__\\   ",
+					"         ",
+					"  Empty  ",
+					"         ",
+				}, "\n")
+				if got := e.View(); got != want {
+					t.Errorf("Unexpected view:\n%s", got)
+				}
+			},
+		},
+		{
+			name: "Leaves out an icon that doesn't fit",
+			test: func(t *testing.T) {
+				e := newPlainEmptyState("Empty").SetIcon("[ ]").SetAction("a", "add")
+				e.SetSize(16, 4)
+				if got := e.View(); strings.Contains(got, "[ ]") || !strings.Contains(got, "Press a to add") {
+					t.Errorf("Expected the message and hint only, got:\n%s", got)
+				}
+			},
+		},
+		{
+			name: "Replaces the list's placeholder",
+			test: func(t *testing.T) {
+				l := NewList().SetEmptyState(newPlainEmptyState("Nothing here"))
+				l.SetSize(20, 3)
+				lines := strings.Split(l.View(), "\n")
+				if len(lines) != 3 || lines[1] != "    Nothing here    " {
+					t.Errorf("Expected the message centered in the list, got %q", lines)
+				}
+
+				l.SetStringItems([]string{"one"})
+				l.SetFilter("two")
+				if got := l.View(); !strings.Contains(got, "No items match filter") {
+					t.Errorf("Expected the filter message, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Fills the rows of an empty table",
+			test: func(t *testing.T) {
+				table := NewTable().
+					SetColumns([]TableColumn{{Title: "Name", Width: 10}, {Title: "Size", Width: 5}}).
+					SetEmptyState(newPlainEmptyState("No files"))
+				table.SetSize(16, 5)
+				lines := strings.Split(table.View(), "\n")
+				if len(lines) != 5 || strings.TrimSpace(lines[3]) != "No files" {
+					t.Errorf("Expected the message under the header, got %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compileFilter turns a filter expression into a case-insensitive pattern.
+// An expression wrapped in slashes, like /^err(or)?$/, is a regular
+// expression; anything else matches as plain text. A regular expression
+// that doesn't compile, such as one still being typed, matches as plain
+// text too. An empty expression returns nil.
+func compileFilter(expr string) *regexp.Regexp {
+	if expr == "" {
+		return nil
+	}
+	if len(expr) > 2 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
+		if re, err := regexp.Compile("(?i)" + expr[1:len(expr)-1]); err == nil {
+			return re
+		}
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(expr))
+}
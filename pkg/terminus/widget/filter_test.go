@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCompileFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		text  string
+		match bool
+	}{
+		{"Plain text ignores case", "ERR", "an error", true},
+		{"Plain text is literal", "a.c", "abc", false},
+		{"Slashes make a regexp", "/^4\\d\\d$/", "404", true},
+		{"Regexp is anchored as written", "/^4\\d\\d$/", "1404", false},
+		{"Regexp ignores case", "/^warn/", "WARNING", true},
+		{"Invalid regexp is literal", "/(ab/", "x/(ab/y", true},
+		{"A lone slash is literal", "/", "a/b", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compileFilter(tt.expr).MatchString(tt.text); got != tt.match {
+				t.Errorf("compileFilter(%q) on %q = %v, want %v", tt.expr, tt.text, got, tt.match)
+			}
+		})
+	}
+	if compileFilter("") != nil {
+		t.Error("Expected no pattern for an empty filter")
+	}
+}
+
+// filterTable has a status code and path per row
+func filterTable() *Table {
+	tbl := NewTable().SetStringData([]string{"Status", "Path"}, [][]string{
+		{"200", "/index.html"},
+		{"404", "/missing"},
+		{"500", "/api/users"},
+		{"201", "/api/users"},
+		{"403", "/admin"},
+	})
+	tbl.SetSize(40, 10)
+	return tbl
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "List filters by regexp",
+			test: func(t *testing.T) {
+				l := NewList().SetStringItems([]string{"todo: a", "done: b", "TODO: c", "note todo"})
+				l.SetFilter("/^todo:/")
+				if l.FilteredLen() != 2 {
+					t.Errorf("Expected 2 items, got %d", l.FilteredLen())
+				}
+				l.SetFilterRegexp(regexp.MustCompile(`b$`))
+				if l.FilteredLen() != 1 || l.Filter() != "/b$/" {
+					t.Errorf("Expected 1 item for %q, got %d", l.Filter(), l.FilteredLen())
+				}
+				l.SetFilterRegexp(nil)
+				if l.FilteredLen() != 4 {
+					t.Errorf("Expected the filter cleared, got %d", l.FilteredLen())
+				}
+			},
+		},
+		{
+			name: "Column filters must all match",
+			test: func(t *testing.T) {
+				tbl := filterTable().SetColumnFilter(0, "/^[45]/")
+				if tbl.RowCount() != 3 || tbl.TotalRowCount() != 5 {
+					t.Fatalf("Expected 3 of 5 rows, got %d of %d", tbl.RowCount(), tbl.TotalRowCount())
+				}
+				tbl.SetColumnFilter(1, "api")
+				if tbl.RowCount() != 1 || tbl.SourceRow(0) != 2 {
+					t.Errorf("Expected only the 500, got %d rows", tbl.RowCount())
+				}
+				if tbl.ColumnFilter(1) != "api" {
+					t.Errorf("Expected the column's filter, got %q", tbl.ColumnFilter(1))
+				}
+
+				tbl.SetColumnFilter(0, "")
+				if tbl.RowCount() != 2 {
+					t.Errorf("Expected both /api rows, got %d", tbl.RowCount())
+				}
+			},
+		},
+		{
+			name: "Table filter matches any cell",
+			test: func(t *testing.T) {
+				tbl := filterTable().SetFilterRegexp(regexp.MustCompile(`^/a`))
+				if tbl.RowCount() != 3 {
+					t.Errorf("Expected 3 rows, got %d", tbl.RowCount())
+				}
+				tbl.ClearFilters()
+				if tbl.RowCount() != 5 || tbl.Filter() != "" {
+					t.Errorf("Expected every row, got %d", tbl.RowCount())
+				}
+			},
+		},
+		{
+			name: "Keeps the selected row when it is still shown",
+			test: func(t *testing.T) {
+				tbl := filterTable()
+				tbl.SetSelected(2, 0)
+				tbl.SetFilter("api")
+				if tbl.SelectedRow() != 0 || tbl.SourceRow(tbl.SelectedRow()) != 2 {
+					t.Errorf("Expected the 500 still selected, got row %d", tbl.SelectedRow())
+				}
+				tbl.SetFilter("nothing")
+				if tbl.SelectedRow() != -1 || tbl.SelectedCell() != nil {
+					t.Errorf("Expected no selection, got row %d", tbl.SelectedRow())
+				}
+			},
+		},
+		{
+			name: "Added and sorted rows respect the filter",
+			test: func(t *testing.T) {
+				tbl := filterTable().SetColumnFilter(1, "/^\\/api/")
+				tbl.AddRow(TableRow{NewSimpleTableCell("204"), NewSimpleTableCell("/api/ping")})
+				tbl.AddRow(TableRow{NewSimpleTableCell("301"), NewSimpleTableCell("/old")})
+				if tbl.RowCount() != 3 || tbl.TotalRowCount() != 7 {
+					t.Fatalf("Expected 3 of 7 rows, got %d of %d", tbl.RowCount(), tbl.TotalRowCount())
+				}
+
+				tbl.SortByColumn(0, SortAsc)
+				var got []string
+				for i := 0; i < tbl.RowCount(); i++ {
+					got = append(got, tbl.rows[i][0].String())
+				}
+				if strings.Join(got, ",") != "201,204,500" {
+					t.Errorf("Expected the shown rows sorted, got %v", got)
+				}
+				tbl.ClearFilters()
+				if tbl.rows[0][0].String() != "200" || tbl.RowCount() != 7 {
+					t.Errorf("Expected every row sorted, got %v first", tbl.rows[0][0])
+				}
+			},
+		},
+		{
+			name: "Shows active filters",
+			test: func(t *testing.T) {
+				tbl := filterTable().SetShowFilterRow(true).
+					SetColumnFilter(0, "/^4/").
+					SetFilter("m")
+				lines := strings.Split(plain(tbl.View()), "\n")
+				if lines[0] != "Filter: m (2 of 5 rows)" {
+					t.Errorf("Expected the filter line, got %q", lines[0])
+				}
+				if !strings.HasPrefix(lines[1], "Status *") {
+					t.Errorf("Expected the filtered column marked, got %q", lines[1])
+				}
+				if !strings.HasPrefix(lines[3], "/^4/ ") {
+					t.Errorf("Expected the column filter row, got %q", lines[3])
+				}
+				if !strings.HasPrefix(lines[4], "404") || !strings.HasPrefix(lines[5], "403") {
+					t.Errorf("Expected the matching rows, got %q", lines[4:6])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Highlighter is a widget whose content can be searched, such as List,
+// Table and Pager. Matching ignores case.
+type Highlighter interface {
+	// Highlight marks every match of term, moves to the first one and
+	// returns how many there are. An empty term clears the highlight.
+	Highlight(term string) int
+	// MatchCount returns the number of matches
+	MatchCount() int
+	// MatchIndex returns the 0-based index of the current match
+	MatchIndex() int
+	// StepMatch moves to the next match, or the previous one when delta is
+	// negative, wrapping around
+	StepMatch(delta int)
+}
+
+// FindBar is a search field that highlights matches in a Highlighter as
+// you type. Down steps to the next match and Up to the previous one. Enter
+// blurs the bar and keeps the highlight, so that the widget itself steps
+// through the matches with n and N; Esc clears the search and blurs it.
+type FindBar struct {
+	Model
+
+	input  *TextInput
+	target Highlighter
+	prompt string
+
+	// Styling
+	promptStyle terminus.Style
+	countStyle  terminus.Style
+}
+
+// NewFindBar creates a new find bar searching target
+func NewFindBar(target Highlighter) *FindBar {
+	m := NewModel()
+	m.width = 40
+	input := NewTextInput().SetPlaceholder("search")
+	input.Focus()
+	return &FindBar{
+		Model:       m,
+		input:       input,
+		target:      target,
+		prompt:      "Find: ",
+		promptStyle: terminus.NewStyle().Bold(true),
+		countStyle:  terminus.NewStyle().Faint(true),
+	}
+}
+
+// SetTarget sets the widget to search, clearing the previous one's highlight
+func (f *FindBar) SetTarget(target Highlighter) *FindBar {
+	if f.target != nil {
+		f.target.Highlight("")
+	}
+	f.target = target
+	if target != nil && f.input.Value() != "" {
+		target.Highlight(f.input.Value())
+	}
+	return f
+}
+
+// SetPrompt sets the text shown before the search field
+func (f *FindBar) SetPrompt(prompt string) *FindBar {
+	f.prompt = prompt
+	return f
+}
+
+// SetPromptStyle sets the style of the prompt
+func (f *FindBar) SetPromptStyle(style terminus.Style) *FindBar {
+	f.promptStyle = style
+	return f
+}
+
+// SetCountStyle sets the style of the match count
+func (f *FindBar) SetCountStyle(style terminus.Style) *FindBar {
+	f.countStyle = style
+	return f
+}
+
+// Query returns the search term
+func (f *FindBar) Query() string {
+	return f.input.Value()
+}
+
+// Clear empties the search field and clears the highlight
+func (f *FindBar) Clear() {
+	f.input.Clear()
+	if f.target != nil {
+		f.target.Highlight("")
+	}
+}
+
+// Init implements the Component interface
+func (f *FindBar) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (f *FindBar) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if !f.Focused() {
+		return f, nil
+	}
+
+	key, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return f, nil
+	}
+	switch key.Type {
+	case terminus.KeyEsc:
+		f.Clear()
+		f.Blur()
+	case terminus.KeyEnter:
+		f.Blur()
+	case terminus.KeyDown:
+		f.step(1)
+	case terminus.KeyUp:
+		f.step(-1)
+	default:
+		before := f.input.Value()
+		f.input.Update(key)
+		if query := f.input.Value(); query != before && f.target != nil {
+			f.target.Highlight(query)
+		}
+	}
+	return f, nil
+}
+
+// step moves the target to another match
+func (f *FindBar) step(delta int) {
+	if f.target != nil && f.target.MatchCount() > 0 {
+		f.target.StepMatch(delta)
+	}
+}
+
+// View implements the Component interface
+func (f *FindBar) View() string {
+	var count string
+	if f.input.Value() != "" && f.target != nil {
+		if n := f.target.MatchCount(); n == 0 {
+			count = "No matches"
+		} else {
+			count = fmt.Sprintf("%d/%d", f.target.MatchIndex()+1, n)
+		}
+	}
+
+	// The field takes whatever the prompt and count leave
+	width := f.width - len([]rune(f.prompt))
+	if count != "" {
+		width -= len(count) + 2
+	}
+	if width < 1 {
+		width = 1
+	}
+	f.input.SetSize(width, 1)
+
+	view := f.promptStyle.Render(f.prompt) + fitWidth(f.input.View(), width)
+	if count != "" {
+		view += "  " + f.countStyle.Render(count)
+	}
+	return view
+}
+
+// highlightMatches renders text in base with every match of term in
+// highlight. Text that is already styled is rendered as it is, since its
+// escape sequences would be split.
+func highlightMatches(text, term string, base, highlight terminus.Style) string {
+	if term == "" || strings.Contains(text, "\x1b") {
+		return base.Render(text)
+	}
+	cols := matchColumns(text, term)
+	if len(cols) == 0 {
+		return base.Render(text)
+	}
+
+	runes := []rune(text)
+	n := len([]rune(term))
+	var b strings.Builder
+	prev := 0
+	for _, col := range cols {
+		if col > prev {
+			b.WriteString(base.Render(string(runes[prev:col])))
+		}
+		b.WriteString(highlight.Render(string(runes[col : col+n])))
+		prev = col + n
+	}
+	if prev < len(runes) {
+		b.WriteString(base.Render(string(runes[prev:])))
+	}
+	return b.String()
+}
+
+// stepIndex moves i by delta through n matches, wrapping around
+func stepIndex(i, delta, n int) int {
+	if n == 0 {
+		return 0
+	}
+	return ((i+delta)%n + n) % n
+}
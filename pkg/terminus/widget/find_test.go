@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Every searchable widget can sit behind a FindBar
+var (
+	_ Highlighter = (*List)(nil)
+	_ Highlighter = (*Table)(nil)
+	_ Highlighter = (*Pager)(nil)
+)
+
+func TestFindBar(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Highlights as you type and counts matches",
+			test: func(t *testing.T) {
+				l := NewList().SetStringItems([]string{"apple", "banana", "Grape", "cherry"})
+				l.SetSize(20, 4)
+				f := NewFindBar(l)
+				f.SetSize(40, 1)
+				f.Focus()
+
+				press(f, runeKey('a'), runeKey('p'))
+				if l.MatchCount() != 2 || l.SelectedIndex() != 0 {
+					t.Fatalf("Expected 2 matches with the first selected, got %d at %d", l.MatchCount(), l.SelectedIndex())
+				}
+				if got := plain(f.View()); !strings.HasPrefix(got, "Find: ap") || !strings.HasSuffix(got, "  1/2") {
+					t.Errorf("Expected the query and count, got %q", got)
+				}
+
+				press(f, terminus.KeyMsg{Type: terminus.KeyDown})
+				if l.SelectedIndex() != 2 || l.MatchIndex() != 1 {
+					t.Errorf("Expected Down to select Grape, got %d", l.SelectedIndex())
+				}
+				press(f, terminus.KeyMsg{Type: terminus.KeyUp})
+				if l.SelectedIndex() != 0 {
+					t.Errorf("Expected Up to go back to apple, got %d", l.SelectedIndex())
+				}
+
+				press(f, runeKey('z'))
+				if got := plain(f.View()); !strings.HasSuffix(got, "No matches") {
+					t.Errorf("Expected no matches, got %q", got)
+				}
+
+				press(f, terminus.KeyMsg{Type: terminus.KeyBackspace}, terminus.KeyMsg{Type: terminus.KeyEnter})
+				if f.Focused() || l.MatchCount() != 2 {
+					t.Errorf("Expected Enter to blur the bar and keep %d matches", l.MatchCount())
+				}
+			},
+		},
+		{
+			name: "Esc clears the highlight and blurs",
+			test: func(t *testing.T) {
+				p := NewPager().SetContent("one\ntwo\nthree")
+				f := NewFindBar(p)
+				f.Focus()
+				press(f, runeKey('t'))
+				if p.MatchCount() != 2 {
+					t.Fatalf("Expected 2 matching lines, got %d", p.MatchCount())
+				}
+
+				press(f, terminus.KeyMsg{Type: terminus.KeyEsc})
+				if f.Focused() || f.Query() != "" || p.MatchCount() != 0 {
+					t.Errorf("Expected a cleared, blurred bar, got %q with %d matches", f.Query(), p.MatchCount())
+				}
+			},
+		},
+		{
+			name: "Moves the highlight to a new target",
+			test: func(t *testing.T) {
+				a := NewList().SetStringItems([]string{"x", "xx"})
+				b := NewList().SetStringItems([]string{"x", "y"})
+				f := NewFindBar(a)
+				f.Focus()
+				press(f, runeKey('x'))
+
+				f.SetTarget(b)
+				if a.MatchCount() != 0 || b.MatchCount() != 1 {
+					t.Errorf("Expected the search to move, got %d and %d", a.MatchCount(), b.MatchCount())
+				}
+			},
+		},
+		{
+			name: "List steps through matches with n and N",
+			test: func(t *testing.T) {
+				l := NewList().SetStringItems([]string{"one", "two", "three", "four"})
+				l.SetSize(20, 4)
+				l.Focus()
+				if n := l.Highlight("o"); n != 3 {
+					t.Fatalf("Expected 3 matches, got %d", n)
+				}
+
+				press(l, runeKey('n'))
+				if l.SelectedIndex() != 1 {
+					t.Errorf("Expected n to select two, got %d", l.SelectedIndex())
+				}
+				press(l, runeKey('N'), runeKey('N'))
+				if l.SelectedIndex() != 3 {
+					t.Errorf("Expected N to wrap to four, got %d", l.SelectedIndex())
+				}
+				if !strings.Contains(l.View(), "\x1b[0;7mo\x1b[0m") {
+					t.Errorf("Expected matches highlighted, got %q", l.View())
+				}
+
+				l.SetFilter("t")
+				if l.MatchCount() != 1 {
+					t.Errorf("Expected matches to follow the filter, got %d", l.MatchCount())
+				}
+			},
+		},
+		{
+			name: "Table selects matching cells",
+			test: func(t *testing.T) {
+				tbl := NewTable().SetStringData([]string{"Name", "City"}, [][]string{
+					{"Ada", "London"},
+					{"Alan", "Wilmslow"},
+					{"Grace", "Arlington"},
+				})
+				tbl.SetSize(30, 5)
+				tbl.SetCellSelection(true)
+				tbl.Focus()
+
+				if n := tbl.Highlight("ON"); n != 2 {
+					t.Fatalf("Expected 2 matching cells, got %d", n)
+				}
+				if tbl.selectedRow != 0 || tbl.selectedCol != 1 {
+					t.Errorf("Expected London selected, got %d,%d", tbl.selectedRow, tbl.selectedCol)
+				}
+				press(tbl, runeKey('n'))
+				if tbl.selectedRow != 2 || tbl.selectedCol != 1 {
+					t.Errorf("Expected Arlington selected, got %d,%d", tbl.selectedRow, tbl.selectedCol)
+				}
+
+				tbl.SortByColumn(0, SortDesc)
+				if tbl.MatchCount() != 2 || tbl.matches[0].row != 0 {
+					t.Errorf("Expected matches to follow the sort, got %v", tbl.matches)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// GateMode is what a Gate shows in place of a component the user lacks the
+// roles for
+type GateMode int
+
+const (
+	// GateFallback shows a message, "Insufficient permissions" by default
+	GateFallback GateMode = iota
+	// GateDisable shows the component dimmed, taking no keys or focus
+	GateDisable
+	// GateHide shows nothing; the Gate is hidden and takes no space
+	GateHide
+)
+
+// Gate shows a component only to users holding one of its roles. The
+// session's roles arrive in a terminus.RolesMsg from the program's auth hook
+// (see terminus.WithAuth), which Init asks for, so a Gate created after the
+// session started learns them too; until then, and in programs without an
+// auth hook, the component is locked. A locked component still receives
+// every message but key presses, so it stays up to date for when it is
+// unlocked.
+type Gate struct {
+	Model
+
+	child   terminus.Component
+	roles   []string
+	allowed bool
+	mode    GateMode
+
+	fallback      string
+	fallbackStyle terminus.Style
+	disabledStyle terminus.Style
+}
+
+// NewGate creates a gate that shows child to users holding any of roles
+func NewGate(child terminus.Component, roles ...string) *Gate {
+	return &Gate{
+		Model:         NewModel(),
+		child:         child,
+		roles:         roles,
+		allowed:       len(roles) == 0,
+		fallback:      "Insufficient permissions",
+		fallbackStyle: terminus.NewStyle().Foreground(terminus.Red).Faint(true),
+		disabledStyle: terminus.NewStyle().Faint(true),
+	}
+}
+
+// SetMode sets what is shown while the component is locked
+func (g *Gate) SetMode(mode GateMode) *Gate {
+	g.mode = mode
+	return g
+}
+
+// SetFallback sets the message shown in GateFallback mode and its style
+func (g *Gate) SetFallback(text string, style terminus.Style) *Gate {
+	g.fallback, g.fallbackStyle = text, style
+	return g
+}
+
+// SetRoles sets the roles of the user, as a RolesMsg does
+func (g *Gate) SetRoles(roles terminus.RoleSet) *Gate {
+	g.allowed = roles.Allows(g.roles...)
+	if !g.allowed {
+		g.Blur()
+	}
+	return g
+}
+
+// Allowed reports whether the user holds one of the gate's roles
+func (g *Gate) Allowed() bool {
+	return g.allowed
+}
+
+// Child returns the gated component
+func (g *Gate) Child() terminus.Component {
+	return g.child
+}
+
+// Init implements the Component interface. It asks for the session's
+// roles.
+func (g *Gate) Init() terminus.Cmd {
+	return terminus.Batch(terminus.RequestRoles(), g.child.Init())
+}
+
+// Update implements the Component interface
+func (g *Gate) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if roles, ok := msg.(terminus.RolesMsg); ok {
+		g.SetRoles(roles.Roles)
+	}
+	if _, ok := msg.(terminus.KeyMsg); ok && !g.allowed {
+		return g, nil
+	}
+	updated, cmd := g.child.Update(msg)
+	if updated != nil {
+		g.child = updated
+	}
+	return g, cmd
+}
+
+// View implements the Component interface
+func (g *Gate) View() string {
+	if g.allowed {
+		return g.child.View()
+	}
+	switch g.mode {
+	case GateDisable:
+		lines := strings.Split(g.child.View(), "\n")
+		for i, line := range lines {
+			lines[i] = g.disabledStyle.Render(stripStyles(line))
+		}
+		return strings.Join(lines, "\n")
+	case GateHide:
+		return ""
+	}
+
+	// The message takes the component's place, so the layout doesn't shift
+	width, height := g.childSize()
+	if width <= 0 {
+		return g.fallbackStyle.Render(g.fallback)
+	}
+	lines := make([]string, max(height, 1))
+	lines[0] = g.fallbackStyle.Render(fitWidth(g.fallback, width))
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.Repeat(" ", width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// childSize returns the size of the component, if it has one
+func (g *Gate) childSize() (width, height int) {
+	if w, ok := g.child.(interface{ GetSize() (int, int) }); ok {
+		return w.GetSize()
+	}
+	return 0, 0
+}
+
+// Visible returns whether the gate is shown: false while locked in
+// GateHide mode or when the component is a hidden widget
+func (g *Gate) Visible() bool {
+	if !g.allowed && g.mode == GateHide {
+		return false
+	}
+	return g.Model.Visible() && Visible(g.child)
+}
+
+// Disabled returns whether the gate is disabled or locked, so containers
+// pass focus over it
+func (g *Gate) Disabled() bool {
+	return !g.allowed || g.Model.Disabled()
+}
+
+// Focus focuses the component, unless it is locked
+func (g *Gate) Focus() {
+	if !g.allowed {
+		return
+	}
+	g.Model.Focus()
+	if f, ok := g.child.(focusable); ok {
+		f.Focus()
+	}
+}
+
+// Blur removes focus from the component
+func (g *Gate) Blur() {
+	g.Model.Blur()
+	if f, ok := g.child.(focusable); ok {
+		f.Blur()
+	}
+}
+
+// SetSize sizes the component
+func (g *Gate) SetSize(width, height int) {
+	g.Model.SetSize(width, height)
+	if w, ok := g.child.(interface{ SetSize(width, height int) }); ok {
+		w.SetSize(width, height)
+	}
+}
+
+// Measure returns the constraints of the component, or none while the gate
+// is hidden
+func (g *Gate) Measure() Constraints {
+	if !g.Visible() {
+		return Constraints{}
+	}
+	if m, ok := g.child.(interface{ Measure() Constraints }); ok {
+		return m.Measure()
+	}
+	return g.Model.Measure()
+}
+
+// stripStyles removes the escape sequences from s
+func stripStyles(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			i = escapeEnd(s, i)
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
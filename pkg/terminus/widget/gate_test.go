@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestGate(t *testing.T) {
+	viewer := terminus.RolesMsg{Roles: terminus.NewRoleSet("viewer")}
+	admin := terminus.RolesMsg{Roles: terminus.NewRoleSet("admin")}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Shows the component once the user holds a role",
+			test: func(t *testing.T) {
+				p := &probe{view: "secret"}
+				gate := NewGate(p, "admin", "operator")
+				if gate.Allowed() || strings.Contains(gate.View(), "secret") {
+					t.Error("Expected the component to be locked before the roles arrive")
+				}
+				gate.Update(admin)
+				if !gate.Allowed() || gate.View() != "secret" {
+					t.Errorf("Expected the component, got %q", gate.View())
+				}
+			},
+		},
+		{
+			name: "Shows a fallback the size of the component",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a", "b"})
+				list.SetSize(30, 3)
+				gate := NewGate(list, "admin")
+				gate.Update(viewer)
+				lines := strings.Split(plain(gate.View()), "\n")
+				if len(lines) != 3 || strings.TrimSpace(lines[0]) != "Insufficient permissions" || len(lines[0]) != 30 {
+					t.Errorf("Expected the fallback in the list's place, got %q", lines)
+				}
+
+				gate.SetFallback("Admins only", terminus.NewStyle())
+				if !strings.HasPrefix(gate.View(), "Admins only") {
+					t.Errorf("Expected the custom fallback, got %q", gate.View())
+				}
+			},
+		},
+		{
+			name: "Disables or hides the component",
+			test: func(t *testing.T) {
+				gate := NewGate(&probe{view: terminus.NewStyle().Bold(true).Render("delete")}, "admin").SetMode(GateDisable)
+				gate.Update(viewer)
+				if got := gate.View(); got != terminus.NewStyle().Faint(true).Render("delete") {
+					t.Errorf("Expected the dimmed component, got %q", got)
+				}
+
+				gate.SetMode(GateHide)
+				c := NewCompose(ComposeVertical).Add("gate", gate).Add("rest", &probe{view: "rest"})
+				if c.View() != "rest" || Visible(gate) {
+					t.Errorf("Expected the gate to take no space, got %q", c.View())
+				}
+			},
+		},
+		{
+			name: "Keeps keys and focus from a locked component",
+			test: func(t *testing.T) {
+				input := NewTextInput()
+				c := NewCompose(ComposeVertical).
+					Add("search", NewTextInput()).
+					Add("gate", NewGate(input, "admin"))
+				c.Update(viewer)
+				c.FocusChild("search")
+				c.Update(terminus.KeyMsg{Type: terminus.KeyTab})
+				if input.Focused() {
+					t.Error("Expected focus to pass over the locked input")
+				}
+
+				gate := c.Child("gate").(*Gate)
+				gate.Focus()
+				gate.Update(runeKey('x'))
+				if input.Focused() || input.Value() != "" {
+					t.Errorf("Expected the locked input to take no keys, got %q", input.Value())
+				}
+
+				c.Update(admin)
+				c.Update(terminus.KeyMsg{Type: terminus.KeyTab})
+				c.Update(runeKey('x'))
+				if !input.Focused() || input.Value() != "x" {
+					t.Errorf("Expected the unlocked input to take keys, got %q", input.Value())
+				}
+			},
+		},
+		{
+			name: "Learns the roles when mounted after the session started",
+			test: func(t *testing.T) {
+				lazy := NewLazy(func() terminus.Component {
+					return NewGate(NewLabel("secret"), "admin")
+				})
+				engine := terminus.NewEngine(&lazyShower{Lazy: lazy}, terminus.WithEngineRoles(terminus.NewRoleSet("admin")))
+				renders := make(chan string, 100)
+				engine.SetRenderCallback(func(view string) { renders <- view })
+				if err := engine.Start(); err != nil {
+					t.Fatal(err)
+				}
+				defer engine.Stop()
+
+				engine.SendMessage(runeKey('s'))
+				timeout := time.After(2 * time.Second)
+				for {
+					select {
+					case view := <-renders:
+						if strings.Contains(view, "secret") {
+							return
+						}
+					case <-timeout:
+						t.Fatalf("Expected the late gate to unlock, got %q", lazy.View())
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// lazyShower shows its Lazy on any key
+type lazyShower struct {
+	*Lazy
+}
+
+func (s *lazyShower) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if _, ok := msg.(terminus.KeyMsg); ok {
+		return s, s.Show()
+	}
+	_, cmd := s.Lazy.Update(msg)
+	return s, cmd
+}
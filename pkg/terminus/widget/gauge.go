@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Gauge draws a value as a half-circle arc that fills from left to right,
+// with the reading in the middle and a label underneath
+type Gauge struct {
+	Model
+
+	// Value
+	value    float64
+	min, max float64
+	label    string
+	format   string // Applied to the percentage
+
+	// Configuration
+	radius    int
+	fillChar  string
+	emptyChar string
+
+	// Styling
+	fillStyle  terminus.Style
+	emptyStyle terminus.Style
+	valueStyle terminus.Style
+	labelStyle terminus.Style
+	zones      func(percent float64) terminus.Style
+}
+
+// NewGauge creates a gauge with a 0-100 range
+func NewGauge() *Gauge {
+	g := &Gauge{
+		Model:      NewModel(),
+		max:        100,
+		format:     "%.0f%%",
+		fillChar:   "█",
+		emptyChar:  "░",
+		fillStyle:  terminus.NewStyle().Foreground(terminus.Green),
+		emptyStyle: terminus.NewStyle().Faint(true),
+		valueStyle: terminus.NewStyle().Bold(true),
+		labelStyle: terminus.NewStyle(),
+	}
+	g.SetRadius(5)
+	return g
+}
+
+// SetValue sets the current reading
+func (g *Gauge) SetValue(value float64) *Gauge {
+	g.value = value
+	return g
+}
+
+// SetRange sets the values at the left and right ends of the arc
+func (g *Gauge) SetRange(min, max float64) *Gauge {
+	g.min, g.max = min, max
+	return g
+}
+
+// SetLabel sets the text shown under the arc
+func (g *Gauge) SetLabel(label string) *Gauge {
+	g.label = label
+	return g
+}
+
+// SetFormat sets the format of the reading, applied to the percentage
+func (g *Gauge) SetFormat(format string) *Gauge {
+	g.format = format
+	return g
+}
+
+// SetRadius sets the height of the arc in lines. The gauge is 4*radius+1
+// columns wide.
+func (g *Gauge) SetRadius(radius int) *Gauge {
+	if radius < 2 {
+		radius = 2
+	}
+	g.radius = radius
+	g.SetSize(4*radius+1, radius+2)
+	return g
+}
+
+// SetChars sets the characters for the filled and empty parts of the arc
+func (g *Gauge) SetChars(fill, empty string) *Gauge {
+	g.fillChar, g.emptyChar = fill, empty
+	return g
+}
+
+// SetFillStyle sets the style of the filled part of the arc
+func (g *Gauge) SetFillStyle(style terminus.Style) *Gauge {
+	g.fillStyle = style
+	return g
+}
+
+// SetEmptyStyle sets the style of the empty part of the arc
+func (g *Gauge) SetEmptyStyle(style terminus.Style) *Gauge {
+	g.emptyStyle = style
+	return g
+}
+
+// SetValueStyle sets the style of the reading
+func (g *Gauge) SetValueStyle(style terminus.Style) *Gauge {
+	g.valueStyle = style
+	return g
+}
+
+// SetLabelStyle sets the style of the label
+func (g *Gauge) SetLabelStyle(style terminus.Style) *Gauge {
+	g.labelStyle = style
+	return g
+}
+
+// SetZones sets a function choosing the fill style from the percentage,
+// e.g. to turn the arc red above a threshold. It overrides SetFillStyle.
+func (g *Gauge) SetZones(zones func(percent float64) terminus.Style) *Gauge {
+	g.zones = zones
+	return g
+}
+
+// Percent returns the reading as a percentage of the range, clamped to
+// 0-100
+func (g *Gauge) Percent() float64 {
+	if g.max <= g.min {
+		return 0
+	}
+	percent := (g.value - g.min) / (g.max - g.min) * 100
+	return math.Max(0, math.Min(100, percent))
+}
+
+// Init implements the Component interface
+func (g *Gauge) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (g *Gauge) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return g, nil
+}
+
+// View implements the Component interface
+func (g *Gauge) View() string {
+	r := float64(g.radius)
+	thickness := math.Max(1, r/3)
+	percent := g.Percent()
+	width := 4*g.radius + 1
+
+	fillStyle := g.fillStyle
+	if g.zones != nil {
+		fillStyle = g.zones(percent)
+	}
+
+	// The reading sits inside the arc on its bottom line
+	reading := fmt.Sprintf(g.format, percent)
+	readingStart := (width - len([]rune(reading))) / 2
+	readingEnd := readingStart + len([]rune(reading))
+
+	// Cells are drawn in runs so each run is styled once
+	const (
+		cellBlank = iota
+		cellFill
+		cellEmpty
+	)
+	lines := make([]string, 0, g.radius+2)
+	for y := 0; y <= g.radius; y++ {
+		var row strings.Builder
+		kind, run := cellBlank, 0
+		flush := func() {
+			switch kind {
+			case cellBlank:
+				row.WriteString(strings.Repeat(" ", run))
+			case cellFill:
+				row.WriteString(fillStyle.Render(strings.Repeat(g.fillChar, run)))
+			case cellEmpty:
+				row.WriteString(g.emptyStyle.Render(strings.Repeat(g.emptyChar, run)))
+			}
+			run = 0
+		}
+
+		for x := 0; x < width; x++ {
+			if y == g.radius && x >= readingStart && x < readingEnd {
+				if x == readingStart {
+					flush()
+					row.WriteString(g.valueStyle.Render(reading))
+				}
+				continue
+			}
+
+			// Cells are about twice as tall as wide, so halve dx
+			dx := float64(x-2*g.radius) / 2
+			dy := r - float64(y)
+			dist := math.Hypot(dx, dy)
+
+			// Fraction of the arc from the left end to this cell
+			along := 1 - math.Atan2(dy, dx)/math.Pi
+			next := cellEmpty
+			switch {
+			case dist > r+0.5 || dist <= r-thickness+0.5:
+				next = cellBlank
+			case along*100 < percent || percent >= 100:
+				next = cellFill
+			}
+
+			if next != kind {
+				flush()
+				kind = next
+			}
+			run++
+		}
+		flush()
+		lines = append(lines, row.String())
+	}
+
+	label := []rune(g.label)
+	if len(label) > width {
+		label = label[:width]
+	}
+	pad := (width - len(label)) / 2
+	lines = append(lines, strings.Repeat(" ", pad)+g.labelStyle.Render(string(label)))
+
+	return strings.Join(lines, "\n")
+}
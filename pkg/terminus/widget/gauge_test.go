@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestGauge(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Computes the percentage of the range",
+			test: func(t *testing.T) {
+				g := NewGauge().SetRange(200, 400).SetValue(250)
+				if g.Percent() != 25 {
+					t.Errorf("Expected 25%%, got %v", g.Percent())
+				}
+				if g.SetValue(500).Percent() != 100 || g.SetValue(0).Percent() != 0 {
+					t.Error("Expected out-of-range values to be clamped")
+				}
+				if NewGauge().SetRange(1, 1).Percent() != 0 {
+					t.Error("Expected an empty range to read 0")
+				}
+			},
+		},
+		{
+			name: "Draws the arc with reading and label",
+			test: func(t *testing.T) {
+				g := NewGauge().SetValue(72).SetLabel("CPU")
+				lines := strings.Split(plain(g.View()), "\n")
+
+				if len(lines) != 7 {
+					t.Fatalf("Expected radius+2 lines, got %d", len(lines))
+				}
+				for i, line := range lines[:6] {
+					if n := len([]rune(line)); n != 21 {
+						t.Errorf("Line %d: expected 21 columns, got %d", i, n)
+					}
+				}
+				if !strings.Contains(lines[5], "72%") {
+					t.Errorf("Expected reading on the bottom line, got %q", lines[5])
+				}
+				if strings.TrimSpace(lines[6]) != "CPU" {
+					t.Errorf("Expected centred label, got %q", lines[6])
+				}
+			},
+		},
+		{
+			name: "Fills from the left end",
+			test: func(t *testing.T) {
+				count := func(g *Gauge) (fill, empty int) {
+					view := plain(g.View())
+					return strings.Count(view, "█"), strings.Count(view, "░")
+				}
+
+				fill, empty := count(NewGauge().SetValue(0))
+				if fill != 0 || empty == 0 {
+					t.Errorf("Expected an empty arc at 0%%, got %d filled", fill)
+				}
+				fill, empty = count(NewGauge().SetValue(100))
+				if fill == 0 || empty != 0 {
+					t.Errorf("Expected a full arc at 100%%, got %d empty", empty)
+				}
+
+				lines := strings.Split(plain(NewGauge().SetValue(30).View()), "\n")
+				bottom := lines[len(lines)-2]
+				if !strings.HasPrefix(bottom, "█") || !strings.HasSuffix(bottom, "░") {
+					t.Errorf("Expected left end filled and right end empty, got %q", bottom)
+				}
+			},
+		},
+		{
+			name: "Colors the fill by zone",
+			test: func(t *testing.T) {
+				red := terminus.NewStyle().Foreground(terminus.Red)
+				g := NewGauge().SetValue(95).SetZones(func(percent float64) terminus.Style {
+					if percent > 90 {
+						return red
+					}
+					return terminus.NewStyle().Foreground(terminus.Green)
+				})
+				// Filled cells are rendered in runs, so match the run's start
+				start := strings.TrimSuffix(red.Render("█"), "\x1b[0m")
+				if !strings.Contains(g.View(), start) {
+					t.Error("Expected the fill in the zone style")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// LatLon is a position on the globe in degrees, north and east positive
+type LatLon struct {
+	Lat, Lon float64
+}
+
+// MapBounds is the area a Map shows, in degrees
+type MapBounds struct {
+	North, South, West, East float64
+}
+
+// WorldBounds shows the whole world but the polar seas
+var WorldBounds = MapBounds{North: 84, South: -60, West: -180, East: 180}
+
+// MapPoint is a marker plotted on a Map
+type MapPoint struct {
+	LatLon
+	Label string         // Shown right of the marker when there is room
+	Color terminus.Color // The zero Color uses the map's marker style
+}
+
+// brailleDots are the bits of the dots in a braille cell, by row and column
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// Map draws a map as braille dots, two across and four down in every cell,
+// with markers plotted at points given by latitude and longitude, e.g. the
+// origins of requests or the positions of a fleet. It shows a coarse
+// outline of the world by default; SetBounds zooms in on a region and
+// SetOutline draws another outline, e.g. a country's.
+type Map struct {
+	Model
+
+	// Data
+	bounds  MapBounds
+	outline [][]LatLon
+	points  []MapPoint
+
+	// Configuration
+	ascii  bool // Draw the outline with dots rather than braille
+	marker string
+
+	// Styling
+	outlineStyle terminus.Style
+	markerStyle  terminus.Style
+	labelStyle   terminus.Style
+}
+
+// NewMap creates a 60 by 20 map of the world
+func NewMap() *Map {
+	m := NewModel()
+	m.width, m.height = 60, 20
+	return &Map{
+		Model:        m,
+		bounds:       WorldBounds,
+		outline:      worldOutline,
+		marker:       "●",
+		outlineStyle: terminus.NewStyle().Foreground(terminus.Green).Faint(true),
+		markerStyle:  terminus.NewStyle().Foreground(terminus.Red).Bold(true),
+		labelStyle:   terminus.NewStyle(),
+	}
+}
+
+// SetBounds sets the area shown
+func (m *Map) SetBounds(bounds MapBounds) *Map {
+	m.bounds = bounds
+	return m
+}
+
+// SetOutline sets the shapes drawn under the points, each a closed ring of
+// positions. Nil draws no outline.
+func (m *Map) SetOutline(outline [][]LatLon) *Map {
+	m.outline = outline
+	return m
+}
+
+// SetPoints replaces the plotted points
+func (m *Map) SetPoints(points []MapPoint) *Map {
+	m.points = points
+	return m
+}
+
+// AddPoint plots a point
+func (m *Map) AddPoint(point MapPoint) *Map {
+	m.points = append(m.points, point)
+	return m
+}
+
+// Points returns the plotted points
+func (m *Map) Points() []MapPoint {
+	return m.points
+}
+
+// ClearPoints removes every point
+func (m *Map) ClearPoints() *Map {
+	m.points = nil
+	return m
+}
+
+// SetASCII sets whether the outline is drawn with periods rather than
+// braille, for fonts without braille
+func (m *Map) SetASCII(ascii bool) *Map {
+	m.ascii = ascii
+	return m
+}
+
+// SetMarker sets the text marking a point
+func (m *Map) SetMarker(marker string) *Map {
+	m.marker = marker
+	return m
+}
+
+// SetOutlineStyle sets the style of the outline
+func (m *Map) SetOutlineStyle(style terminus.Style) *Map {
+	m.outlineStyle = style
+	return m
+}
+
+// SetMarkerStyle sets the style of markers without a color of their own
+func (m *Map) SetMarkerStyle(style terminus.Style) *Map {
+	m.markerStyle = style
+	return m
+}
+
+// SetLabelStyle sets the style of point labels
+func (m *Map) SetLabelStyle(style terminus.Style) *Map {
+	m.labelStyle = style
+	return m
+}
+
+// Project returns the cell a position is drawn in, or false when it is
+// outside the bounds
+func (m *Map) Project(pos LatLon) (col, row int, ok bool) {
+	x, y := m.dot(pos)
+	col, row = int(x)/2, int(y)/4
+	if x < 0 || y < 0 || col >= m.width || row >= m.height {
+		return 0, 0, false
+	}
+	return col, row, true
+}
+
+// dot returns the position of pos in braille dots from the top left
+func (m *Map) dot(pos LatLon) (x, y float64) {
+	b := m.bounds
+	x = (pos.Lon - b.West) / (b.East - b.West) * float64(2*m.width)
+	y = (b.North - pos.Lat) / (b.North - b.South) * float64(4*m.height)
+	return x, y
+}
+
+// Init implements the Component interface
+func (m *Map) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (m *Map) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return m, nil
+}
+
+// mapCell is a cell of the drawn map
+type mapCell struct {
+	r     rune // 0 for the second column of a wide rune
+	point int  // Point whose marker or label is drawn, or -1 for the outline
+	label bool
+}
+
+// View implements the Component interface
+func (m *Map) View() string {
+	if m.width <= 0 || m.height <= 0 || m.bounds.East <= m.bounds.West || m.bounds.North <= m.bounds.South {
+		return ""
+	}
+
+	// Plot the outline in braille dots
+	dots := make([][]rune, m.height)
+	for row := range dots {
+		dots[row] = make([]rune, m.width)
+	}
+	plot := func(x, y int) {
+		if x >= 0 && y >= 0 && x < 2*m.width && y < 4*m.height {
+			dots[y/4][x/2] |= brailleDots[y%4][x%2]
+		}
+	}
+	for _, ring := range m.outline {
+		for i := range ring {
+			x0, y0 := m.dot(ring[i])
+			x1, y1 := m.dot(ring[(i+1)%len(ring)])
+			drawLine(int(x0), int(y0), int(x1), int(y1), plot)
+		}
+	}
+
+	cells := make([][]mapCell, m.height)
+	for row := range cells {
+		cells[row] = make([]mapCell, m.width)
+		for col, bits := range dots[row] {
+			cell := mapCell{r: ' ', point: -1}
+			switch {
+			case bits == 0:
+			case m.ascii:
+				cell.r = '.'
+			default:
+				cell.r = 0x2800 + bits
+			}
+			cells[row][col] = cell
+		}
+	}
+
+	// Markers go over the outline, with labels beside them
+	for i, p := range m.points {
+		col, row, ok := m.Project(p.LatLon)
+		if !ok {
+			continue
+		}
+		line := cells[row]
+		col = m.write(line, col, m.marker, mapCell{point: i})
+		if p.Label != "" {
+			m.write(line, col, " "+terminus.Sanitize(p.Label), mapCell{point: i, label: true})
+		}
+	}
+
+	lines := make([]string, m.height)
+	for row, line := range cells {
+		lines[row] = m.renderLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// write writes text into line from col as far as it fits and returns the
+// column after it
+func (m *Map) write(line []mapCell, col int, text string, cell mapCell) int {
+	for _, r := range text {
+		w := terminus.RuneWidth(r)
+		if col+w > len(line) {
+			break
+		}
+		cell.r = r
+		line[col] = cell
+		for i := 1; i < w; i++ {
+			line[col+i] = mapCell{point: cell.point, label: cell.label}
+		}
+		col += w
+	}
+	return col
+}
+
+// renderLine renders a line of cells, styling each run by what it shows
+func (m *Map) renderLine(cells []mapCell) string {
+	end := len(cells)
+	for end > 0 && cells[end-1].point < 0 && cells[end-1].r == ' ' {
+		end--
+	}
+
+	var b strings.Builder
+	for start := 0; start < end; {
+		first := cells[start]
+		var run strings.Builder
+		i := start
+		for ; i < end && cells[i].point == first.point && cells[i].label == first.label; i++ {
+			if cells[i].r != 0 {
+				run.WriteRune(cells[i].r)
+			}
+		}
+		b.WriteString(m.cellStyle(first).Render(run.String()))
+		start = i
+	}
+	return b.String()
+}
+
+// cellStyle returns the style of a cell
+func (m *Map) cellStyle(cell mapCell) terminus.Style {
+	switch {
+	case cell.point < 0:
+		return m.outlineStyle
+	case cell.label:
+		return m.labelStyle
+	case m.points[cell.point].Color != (terminus.Color{}):
+		return m.markerStyle.Foreground(m.points[cell.point].Color)
+	default:
+		return m.markerStyle
+	}
+}
+
+// drawLine calls plot for each point of the line between two points, using
+// Bresenham's algorithm
+func drawLine(x0, y0, x1, y1 int, plot func(x, y int)) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		plot(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// ring returns a closed ring from longitude, latitude pairs
+func ring(lonLats ...float64) []LatLon {
+	positions := make([]LatLon, len(lonLats)/2)
+	for i := range positions {
+		positions[i] = LatLon{Lat: lonLats[2*i+1], Lon: lonLats[2*i]}
+	}
+	return positions
+}
+
+// worldOutline is a coarse outline of the continents and larger islands,
+// enough to place points by eye at dashboard sizes
+var worldOutline = [][]LatLon{
+	// North America
+	ring(-168, 66, -156, 71, -141, 70, -125, 70, -110, 68, -95, 72, -82, 69, -80, 63, -92, 58, -87, 55,
+		-80, 52, -78, 60, -70, 60, -64, 60, -56, 52, -60, 47, -66, 44, -70, 42, -74, 40, -76, 35, -81, 31,
+		-80, 25, -82, 27, -84, 30, -90, 29, -94, 29, -97, 26, -97, 21, -92, 19, -87, 21, -88, 16, -83, 15,
+		-83, 10, -78, 8, -80, 7, -85, 10, -87, 13, -92, 14, -96, 16, -105, 20, -110, 23, -115, 29, -117, 33,
+		-121, 35, -124, 40, -124, 46, -123, 49, -128, 51, -133, 55, -140, 60, -148, 60, -152, 59, -158, 57,
+		-165, 55, -160, 58, -165, 60, -166, 62),
+	// Greenland
+	ring(-73, 78, -60, 82, -30, 83, -20, 80, -18, 75, -22, 70, -32, 68, -40, 65, -43, 60, -50, 62, -53, 67,
+		-55, 71, -66, 76),
+	// South America
+	ring(-80, 9, -75, 11, -72, 12, -63, 11, -60, 8, -52, 5, -50, 0, -44, -2, -35, -5, -35, -9, -39, -14,
+		-40, -22, -48, -26, -53, -34, -58, -35, -57, -38, -62, -39, -65, -42, -65, -47, -68, -50, -69, -53,
+		-68, -55, -72, -54, -75, -50, -74, -45, -73, -40, -72, -30, -71, -20, -76, -14, -80, -7, -81, -4,
+		-80, 0, -77, 4, -78, 7),
+	// Europe and Asia
+	ring(-9, 37, -9, 43, -2, 43.5, -1, 46, -4, 48, -2, 49, 2, 51, 5, 53, 8, 54, 8, 57, 10, 58, 11, 54,
+		14, 54, 19, 54.5, 21, 57, 24, 59.5, 30, 60, 23, 60.5, 21, 61, 21, 64, 25, 65.5, 22, 66, 17, 62,
+		18, 60, 16, 56, 12, 56, 11, 59, 6, 58, 5, 62, 14, 67, 20, 70, 28, 71, 33, 69.5, 41, 67, 44, 68,
+		53, 68, 60, 69, 68, 72, 73, 72, 80, 73, 88, 75, 100, 77, 105, 78, 113, 74, 130, 72, 140, 72,
+		150, 71, 160, 70, 170, 70, 180, 69, 180, 65, 175, 62, 165, 60, 160, 56, 156, 51, 155, 58, 150, 59,
+		142, 59, 137, 54, 141, 52, 140, 48, 135, 43, 130, 42, 129, 35, 126, 35, 126, 38, 125, 40, 121, 39,
+		122, 37, 120, 34, 122, 30, 121, 28, 118, 24, 113, 22, 108, 21, 106, 18, 109, 12, 105, 9, 103, 10,
+		100, 13, 100, 8, 103, 1.5, 101, 3, 98, 8, 98, 16, 94, 16, 92, 22, 87, 21, 80, 15, 80, 10, 77, 8,
+		73, 16, 73, 21, 69, 22, 67, 25, 62, 25, 57, 25.5, 56, 27, 52, 28, 48, 30, 50, 27, 56, 24, 59, 23,
+		58, 20, 55, 17, 52, 16, 44, 12.5, 43, 15, 39, 22, 35, 28, 32.5, 30, 34, 31.5, 35, 33, 36, 36,
+		32, 36.5, 28, 36.7, 26, 40, 24, 41, 23, 40, 22, 37, 20, 40, 19, 42, 13, 45.5, 12, 44, 16, 41,
+		16, 38, 15.5, 40, 12, 42, 10, 44, 6.5, 43, 3, 43, 3, 42, 0, 40, 0, 38.5, -2, 36.7, -5, 36, -6, 37),
+	// Africa
+	ring(-6, 36, -10, 30, -13, 27.5, -17, 21, -17, 15, -16, 12, -13, 9, -8, 4.5, -3, 5, 2, 6, 8, 4.5,
+		9.5, 3, 9, -1, 12, -5, 13, -9, 12, -17, 15, -27, 18, -32, 20, -35, 26, -34, 32, -29, 35, -24,
+		35, -20, 40, -15, 40, -10, 39, -5, 42, 0, 46, 2, 51, 11, 44, 11, 43, 12.5, 39, 16, 35, 24, 32.5, 30,
+		30, 31.5, 20, 30.5, 19, 32, 11, 33, 10, 37, 3, 37, -2, 35),
+	// Madagascar
+	ring(44, -25, 47, -25, 50, -15, 49, -12, 44, -17),
+	// Australia
+	ring(114, -22, 114, -26, 115, -34, 118, -35, 124, -34, 131, -31.5, 135, -35, 138, -35, 140, -38,
+		146, -39, 150, -37.5, 153, -32, 153, -25, 146, -19, 142, -10.5, 141, -17, 136, -12, 131, -11.5,
+		126, -14, 122, -17),
+	// Great Britain and Ireland
+	ring(-5, 50, 1.5, 51, 1.7, 52.7, 0, 53.5, -1.5, 55, -2, 57, -3.5, 58.6, -5, 58.6, -6, 56.5, -5, 55,
+		-3, 54, -4.5, 53.3, -5, 51.7),
+	ring(-10, 51.5, -6, 52, -6, 54, -8, 55.2, -10, 54),
+	// Iceland
+	ring(-24, 65.5, -22, 66.4, -15, 66.5, -13.5, 65, -18, 63.4, -22, 63.8),
+	// Cuba
+	ring(-85, 22, -82, 23, -77, 21, -74, 20, -78, 19.8, -82, 21.7),
+	// Japan
+	ring(130, 31, 132, 34, 135, 34, 140, 35, 141, 38, 142, 41, 140, 41.5, 140, 38, 137, 37, 133, 35.5,
+		130, 33.5),
+	// Borneo, Sumatra and New Guinea
+	ring(109, 2, 111, -3, 116, -4, 118, 1, 119, 5, 117, 7, 115, 5, 111, 2),
+	ring(95, 5.5, 98, 4, 104, -2, 106, -6, 104, -5.5, 101, -3, 98, 0, 95, 3),
+	ring(131, -1.5, 135, -3.5, 141, -2.5, 148, -6, 150, -10.5, 146, -8, 143, -9, 138, -8, 135, -4.5),
+	// New Zealand
+	ring(173, -35, 175, -37, 178, -37.5, 177, -39.5, 175, -41.5, 174, -39),
+	ring(172, -40.5, 174, -41.5, 171, -45, 168, -46.5, 166.5, -46, 170, -42.5),
+}
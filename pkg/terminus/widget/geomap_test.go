@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestMap(t *testing.T) {
+	plain := terminus.NewStyle()
+	// newMap returns an unstyled map of a 10 by 10 degree square without
+	// an outline
+	newMap := func() *Map {
+		m := NewMap().
+			SetBounds(MapBounds{North: 10, South: 0, West: 0, East: 10}).
+			SetOutline(nil).
+			SetOutlineStyle(plain).
+			SetMarkerStyle(plain)
+		m.SetSize(10, 5)
+		return m
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Projects positions to cells",
+			test: func(t *testing.T) {
+				m := newMap()
+				for _, tt := range []struct {
+					pos      LatLon
+					col, row int
+					ok       bool
+				}{
+					{LatLon{Lat: 10, Lon: 0}, 0, 0, true},
+					{LatLon{Lat: 5, Lon: 5}, 5, 2, true},
+					{LatLon{Lat: 0.1, Lon: 9.9}, 9, 4, true},
+					{LatLon{Lat: 11, Lon: 5}, 0, 0, false},
+					{LatLon{Lat: 5, Lon: 10}, 0, 0, false},
+				} {
+					col, row, ok := m.Project(tt.pos)
+					if col != tt.col || row != tt.row || ok != tt.ok {
+						t.Errorf("Expected %v at %d,%d (%v), got %d,%d (%v)", tt.pos, tt.col, tt.row, tt.ok, col, row, ok)
+					}
+				}
+			},
+		},
+		{
+			name: "Plots labelled markers",
+			test: func(t *testing.T) {
+				m := newMap().
+					AddPoint(MapPoint{LatLon: LatLon{Lat: 9, Lon: 1}, Label: "north"}).
+					AddPoint(MapPoint{LatLon: LatLon{Lat: 1, Lon: 7}, Label: "south"}).
+					AddPoint(MapPoint{LatLon: LatLon{Lat: -5, Lon: 5}, Label: "outside"})
+				expected := "" +
+					"\n ● north" +
+					"\n" +
+					"\n" +
+					"\n" +
+					"\n       ● s"
+				if view := m.View(); view != expected[1:] {
+					t.Errorf("Expected %q, got %q", expected[1:], view)
+				}
+			},
+		},
+		{
+			name: "Draws the outline in braille or periods",
+			test: func(t *testing.T) {
+				m := newMap().SetOutline([][]LatLon{{{Lat: 9, Lon: 0}, {Lat: 9, Lon: 9}}})
+				line := strings.Split(m.View(), "\n")[0]
+				if line != "⠤⠤⠤⠤⠤⠤⠤⠤⠤⠄" {
+					t.Errorf("Expected a braille line, got %q", line)
+				}
+				m.SetASCII(true)
+				if line := strings.Split(m.View(), "\n")[0]; line != ".........." {
+					t.Errorf("Expected a dotted line, got %q", line)
+				}
+			},
+		},
+		{
+			name: "Colors markers",
+			test: func(t *testing.T) {
+				m := newMap().AddPoint(MapPoint{LatLon: LatLon{Lat: 5, Lon: 5}, Color: terminus.Red})
+				if !strings.Contains(m.View(), terminus.NewStyle().Foreground(terminus.Red).Render("●")) {
+					t.Errorf("Expected a red marker, got %q", m.View())
+				}
+			},
+		},
+		{
+			name: "Draws the world by default",
+			test: func(t *testing.T) {
+				m := NewMap().SetOutlineStyle(plain)
+				if strings.TrimSpace(m.View()) == "" {
+					t.Error("Expected the world outline")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
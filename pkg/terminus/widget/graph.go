@@ -0,0 +1,683 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+const (
+	// graphNodeHeight is the height of a node's box
+	graphNodeHeight = 3
+	// graphLayerHeight is the height of a layer and the line its edges
+	// are routed on
+	graphLayerHeight = graphNodeHeight + 1
+	// graphGap is the space between the boxes of a layer
+	graphGap = 2
+)
+
+// GraphNode is a node of a Graph
+type GraphNode struct {
+	ID    string
+	Label string // Shown in the node's box; the ID when empty
+}
+
+// Graph shows a small directed graph, such as a service topology or a
+// dependency tree, as boxes joined by lines. Nodes are laid out in layers
+// from top to bottom, each node below the nodes with edges to it, and the
+// arrow keys move the selection between them.
+//
+// Edges that close a cycle are left out of the layout and not drawn. With a
+// size set, the view is cut to it and scrolls to keep the selected node in
+// sight.
+type Graph struct {
+	Model
+
+	// Data
+	nodes []GraphNode
+	index map[string]int // Position in nodes by ID
+	edges [][2]int       // From and to positions in nodes
+
+	// State
+	selected string
+	layout   *graphLayout // nil when the graph changed since it was laid out
+	left     int          // Columns scrolled past
+	top      int          // Lines scrolled past
+
+	// Styling
+	nodeStyle     terminus.Style
+	selectedStyle terminus.Style
+	edgeStyle     terminus.Style
+	nodeStyleFunc func(node GraphNode) terminus.Style
+
+	// Events
+	onSelect func(node GraphNode) terminus.Cmd
+	onChange func(node GraphNode) terminus.Cmd
+}
+
+// graphVertex is a box in the layout, or a column an edge runs down to
+// cross a layer
+type graphVertex struct {
+	node  int // Position in nodes, or -1 for an edge crossing the layer
+	layer int
+	pos   int // Position in its layer
+	x     int
+	width int
+	preds []int // Vertices with edges to this one, one layer up
+	succs []int // Vertices this one has edges to, one layer down
+}
+
+// center returns the column edges meet the vertex at
+func (v *graphVertex) center() int {
+	return v.x + v.width/2
+}
+
+// graphLayout is where a graph's vertices are drawn
+type graphLayout struct {
+	vertices []graphVertex
+	layers   [][]int // Vertices of each layer from left to right
+	width    int
+}
+
+// NewGraph creates an empty graph. Its size is 0 by 0, which shows the
+// whole graph.
+func NewGraph() *Graph {
+	m := NewModel()
+	m.width, m.height = 0, 0
+	return &Graph{
+		Model:         m,
+		index:         make(map[string]int),
+		nodeStyle:     terminus.NewStyle(),
+		selectedStyle: terminus.NewStyle().Reverse(true),
+		edgeStyle:     terminus.NewStyle().Faint(true),
+	}
+}
+
+// AddNode adds a node, or relabels the node with its ID
+func (g *Graph) AddNode(id, label string) *Graph {
+	if i, ok := g.index[id]; ok {
+		g.nodes[i].Label = label
+	} else {
+		g.index[id] = len(g.nodes)
+		g.nodes = append(g.nodes, GraphNode{ID: id, Label: label})
+	}
+	g.layout = nil
+	return g
+}
+
+// AddEdge adds an edge between two nodes, adding the nodes that are
+// missing
+func (g *Graph) AddEdge(from, to string) *Graph {
+	for _, id := range []string{from, to} {
+		if _, ok := g.index[id]; !ok {
+			g.AddNode(id, "")
+		}
+	}
+	edge := [2]int{g.index[from], g.index[to]}
+	for _, e := range g.edges {
+		if e == edge {
+			return g
+		}
+	}
+	g.edges = append(g.edges, edge)
+	g.layout = nil
+	return g
+}
+
+// Clear removes every node and edge
+func (g *Graph) Clear() *Graph {
+	g.nodes = nil
+	g.index = make(map[string]int)
+	g.edges = nil
+	g.layout = nil
+	return g
+}
+
+// Nodes returns the nodes in the order they were added
+func (g *Graph) Nodes() []GraphNode {
+	return g.nodes
+}
+
+// Select selects the node with id, if there is one
+func (g *Graph) Select(id string) *Graph {
+	if _, ok := g.index[id]; ok {
+		g.selected = id
+	}
+	return g
+}
+
+// Selected returns the selected node, or false when the graph is empty
+func (g *Graph) Selected() (GraphNode, bool) {
+	i := g.selectedIndex()
+	if i < 0 {
+		return GraphNode{}, false
+	}
+	return g.nodes[i], true
+}
+
+// selectedIndex returns the position of the selected node in nodes, the
+// first node when none was selected, or -1 when there are none
+func (g *Graph) selectedIndex() int {
+	if i, ok := g.index[g.selected]; ok {
+		return i
+	}
+	if len(g.nodes) == 0 {
+		return -1
+	}
+	return 0
+}
+
+// SetNodeStyle sets the style of the nodes
+func (g *Graph) SetNodeStyle(style terminus.Style) *Graph {
+	g.nodeStyle = style
+	return g
+}
+
+// SetSelectedStyle sets the style of the selected node
+func (g *Graph) SetSelectedStyle(style terminus.Style) *Graph {
+	g.selectedStyle = style
+	return g
+}
+
+// SetEdgeStyle sets the style of the lines between nodes
+func (g *Graph) SetEdgeStyle(style terminus.Style) *Graph {
+	g.edgeStyle = style
+	return g
+}
+
+// SetNodeStyleFunc sets a function that styles each node, e.g. by the
+// health of the service it stands for. The selected node keeps the
+// selected style.
+func (g *Graph) SetNodeStyleFunc(fn func(node GraphNode) terminus.Style) *Graph {
+	g.nodeStyleFunc = fn
+	return g
+}
+
+// SetOnSelect sets the callback triggered when Enter is pressed on a node
+func (g *Graph) SetOnSelect(callback func(node GraphNode) terminus.Cmd) *Graph {
+	g.onSelect = callback
+	return g
+}
+
+// SetOnChange sets the callback triggered when the selection moves
+func (g *Graph) SetOnChange(callback func(node GraphNode) terminus.Cmd) *Graph {
+	g.onChange = callback
+	return g
+}
+
+// KeyBindings returns the keys the graph handles, for checking global
+// shortcuts against
+func (g *Graph) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("hjkl", terminus.KeyUp, terminus.KeyDown, terminus.KeyLeft, terminus.KeyRight, terminus.KeyEnter)
+}
+
+// Init implements the Component interface
+func (g *Graph) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (g *Graph) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok || !g.Focused() || len(g.nodes) == 0 {
+		return g, nil
+	}
+
+	key := keyMsg.Type
+	if key == terminus.KeyRunes && len(keyMsg.Runes) == 1 {
+		key = map[rune]terminus.KeyType{
+			'h': terminus.KeyLeft,
+			'j': terminus.KeyDown,
+			'k': terminus.KeyUp,
+			'l': terminus.KeyRight,
+		}[keyMsg.Runes[0]]
+	}
+
+	selected := g.selectedIndex()
+	switch key {
+	case terminus.KeyEnter:
+		if g.onSelect != nil {
+			return g, g.onSelect(g.nodes[selected])
+		}
+		return g, nil
+	case terminus.KeyLeft:
+		g.step(selected, 0, -1)
+	case terminus.KeyRight:
+		g.step(selected, 0, 1)
+	case terminus.KeyUp:
+		g.step(selected, -1, 0)
+	case terminus.KeyDown:
+		g.step(selected, 1, 0)
+	}
+
+	if i := g.selectedIndex(); i != selected && g.onChange != nil {
+		return g, g.onChange(g.nodes[i])
+	}
+	return g, nil
+}
+
+// step selects the next node to the side in the selected node's layer, or
+// the node nearest below or above it in the next layer, preferring nodes it
+// shares an edge with
+func (g *Graph) step(selected, layers, side int) {
+	layout := g.laidOut()
+	from := &layout.vertices[layout.vertexOf(selected)]
+	if side != 0 {
+		row := layout.layers[from.layer]
+		for p := from.pos + side; p >= 0 && p < len(row); p += side {
+			if v := layout.vertices[row[p]]; v.node >= 0 {
+				g.selected = g.nodes[v.node].ID
+				return
+			}
+		}
+		return
+	}
+
+	layer := from.layer + layers
+	if layer < 0 || layer >= len(layout.layers) {
+		return
+	}
+	linked := make(map[int]bool)
+	for _, e := range g.edges {
+		if e[0] == selected {
+			linked[e[1]] = true
+		} else if e[1] == selected {
+			linked[e[0]] = true
+		}
+	}
+	best, bestLinked, distance := -1, false, 0
+	for _, vi := range layout.layers[layer] {
+		v := layout.vertices[vi]
+		if v.node < 0 || bestLinked && !linked[v.node] {
+			continue
+		}
+		d := abs(v.center() - from.center())
+		if best < 0 || linked[v.node] && !bestLinked || d < distance {
+			best, bestLinked, distance = v.node, linked[v.node], d
+		}
+	}
+	if best >= 0 {
+		g.selected = g.nodes[best].ID
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// vertexOf returns the vertex of node
+func (l *graphLayout) vertexOf(node int) int {
+	for i, v := range l.vertices {
+		if v.node == node {
+			return i
+		}
+	}
+	return -1
+}
+
+// laidOut returns the layout of the graph, laying it out if it changed
+func (g *Graph) laidOut() *graphLayout {
+	if g.layout == nil {
+		g.layout = g.layOut()
+	}
+	return g.layout
+}
+
+// layOut places every node in a layer below the nodes with edges to it,
+// adds vertices where edges cross layers, orders each layer to keep edges
+// short and assigns columns
+func (g *Graph) layOut() *graphLayout {
+	n := len(g.nodes)
+	out := make([][]int, n)
+	for _, e := range g.edges {
+		out[e[0]] = append(out[e[0]], e[1])
+	}
+
+	// Depth-first search in the order nodes were added finds the edges that
+	// close cycles and a topological order of the rest
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, n)
+	order := make([]int, 0, n) // Reverse topological order
+	var forward [][2]int
+	var visit func(u int)
+	visit = func(u int) {
+		state[u] = visiting
+		for _, v := range out[u] {
+			switch state[v] {
+			case visiting:
+				continue // Closes a cycle
+			case unvisited:
+				visit(v)
+			}
+			forward = append(forward, [2]int{u, v})
+		}
+		state[u] = visited
+		order = append(order, u)
+	}
+	for u := range g.nodes {
+		if state[u] == unvisited {
+			visit(u)
+		}
+	}
+
+	// Each node goes one layer below the lowest node with an edge to it
+	layer := make([]int, n)
+	in := make([][]int, n)
+	for _, e := range forward {
+		in[e[1]] = append(in[e[1]], e[0])
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		for _, u := range in[v] {
+			layer[v] = max(layer[v], layer[u]+1)
+		}
+	}
+
+	layout := &graphLayout{}
+	for u, node := range g.nodes {
+		label := node.Label
+		if label == "" {
+			label = node.ID
+		}
+		layout.add(graphVertex{node: u, layer: layer[u], width: visibleWidth(terminus.Sanitize(label)) + 4})
+	}
+	sort.SliceStable(forward, func(i, j int) bool { return forward[i][0] < forward[j][0] })
+	for _, e := range forward {
+		// Edges longer than a layer run down a column in each layer between
+		from := e[0]
+		for l := layer[e[0]] + 1; l < layer[e[1]]; l++ {
+			crossing := layout.add(graphVertex{node: -1, layer: l, width: 1})
+			layout.link(from, crossing)
+			from = crossing
+		}
+		layout.link(from, e[1])
+	}
+
+	layout.order()
+	layout.place()
+	return layout
+}
+
+// add adds a vertex to the end of its layer and returns its index
+func (l *graphLayout) add(v graphVertex) int {
+	for len(l.layers) <= v.layer {
+		l.layers = append(l.layers, nil)
+	}
+	v.pos = len(l.layers[v.layer])
+	l.layers[v.layer] = append(l.layers[v.layer], len(l.vertices))
+	l.vertices = append(l.vertices, v)
+	return len(l.vertices) - 1
+}
+
+// link adds an edge between vertices in neighbouring layers
+func (l *graphLayout) link(from, to int) {
+	l.vertices[from].succs = append(l.vertices[from].succs, to)
+	l.vertices[to].preds = append(l.vertices[to].preds, from)
+}
+
+// order sorts each layer by the mean position of the vertices linked to it
+// in the layer above, then in the layer below, so fewer edges cross
+func (l *graphLayout) order() {
+	sweep := func(layer int, linked func(v *graphVertex) []int) {
+		row := l.layers[layer]
+		keys := make(map[int]float64, len(row))
+		for _, vi := range row {
+			v := &l.vertices[vi]
+			keys[vi] = float64(v.pos)
+			if links := linked(v); len(links) > 0 {
+				sum := 0
+				for _, u := range links {
+					sum += l.vertices[u].pos
+				}
+				keys[vi] = float64(sum) / float64(len(links))
+			}
+		}
+		sort.SliceStable(row, func(i, j int) bool { return keys[row[i]] < keys[row[j]] })
+		for p, vi := range row {
+			l.vertices[vi].pos = p
+		}
+	}
+	for layer := 1; layer < len(l.layers); layer++ {
+		sweep(layer, func(v *graphVertex) []int { return v.preds })
+	}
+	for layer := len(l.layers) - 2; layer >= 0; layer-- {
+		sweep(layer, func(v *graphVertex) []int { return v.succs })
+	}
+}
+
+// place assigns columns, centering each layer under the widest
+func (l *graphLayout) place() {
+	widths := make([]int, len(l.layers))
+	for i, row := range l.layers {
+		for p, vi := range row {
+			if p > 0 {
+				widths[i] += graphGap
+			}
+			widths[i] += l.vertices[vi].width
+		}
+		l.width = max(l.width, widths[i])
+	}
+	for i, row := range l.layers {
+		x := (l.width - widths[i]) / 2
+		for _, vi := range row {
+			l.vertices[vi].x = x
+			x += l.vertices[vi].width + graphGap
+		}
+	}
+}
+
+// Line directions joined in a cell of an edge
+const (
+	edgeUp = 1 << iota
+	edgeDown
+	edgeLeft
+	edgeRight
+)
+
+// edgeRunes are the box-drawing characters joining each set of directions
+var edgeRunes = map[int]rune{
+	edgeUp | edgeDown:                        '│',
+	edgeLeft | edgeRight:                     '─',
+	edgeUp | edgeRight:                       '└',
+	edgeUp | edgeLeft:                        '┘',
+	edgeDown | edgeRight:                     '┌',
+	edgeDown | edgeLeft:                      '┐',
+	edgeUp | edgeDown | edgeRight:            '├',
+	edgeUp | edgeDown | edgeLeft:             '┤',
+	edgeUp | edgeLeft | edgeRight:            '┴',
+	edgeDown | edgeLeft | edgeRight:          '┬',
+	edgeUp | edgeDown | edgeLeft | edgeRight: '┼',
+}
+
+// graphCell is a cell of the drawn graph
+type graphCell struct {
+	r     rune // 0 for the second column of a wide rune
+	owner int  // Node drawn in the cell, graphEdge or graphEmpty
+}
+
+const (
+	graphEdge  = -1
+	graphEmpty = -2
+)
+
+// draw draws the graph into a grid of cells
+func (g *Graph) draw(layout *graphLayout) [][]graphCell {
+	height := len(layout.layers)*graphLayerHeight - 1
+	grid := make([][]graphCell, max(height, 0))
+	for y := range grid {
+		grid[y] = make([]graphCell, layout.width)
+		for x := range grid[y] {
+			grid[y][x] = graphCell{r: ' ', owner: graphEmpty}
+		}
+	}
+	masks := make(map[[2]int]int)
+
+	for _, v := range layout.vertices {
+		y := v.layer * graphLayerHeight
+		if v.node < 0 {
+			for dy := 0; dy < graphNodeHeight; dy++ {
+				masks[[2]int{v.x, y + dy}] |= edgeUp | edgeDown
+			}
+		} else {
+			g.drawBox(grid, v, y)
+		}
+
+		// Route each edge along the line below the layer
+		for _, s := range v.succs {
+			from, to := v.center(), layout.vertices[s].center()
+			line := y + graphNodeHeight
+			if v.node >= 0 {
+				grid[y+graphNodeHeight-1][from].r = '┬'
+			}
+			if layout.vertices[s].node >= 0 {
+				grid[line+1][to].r = '┴'
+			}
+			masks[[2]int{from, line}] |= edgeUp
+			masks[[2]int{to, line}] |= edgeDown
+			for x := min(from, to); x < max(from, to); x++ {
+				masks[[2]int{x, line}] |= edgeRight
+				masks[[2]int{x + 1, line}] |= edgeLeft
+			}
+		}
+	}
+
+	for cell, mask := range masks {
+		grid[cell[1]][cell[0]] = graphCell{r: edgeRunes[mask], owner: graphEdge}
+	}
+	return grid
+}
+
+// drawBox draws the box of a node with its top at line y
+func (g *Graph) drawBox(grid [][]graphCell, v graphVertex, y int) {
+	node := g.nodes[v.node]
+	label := node.Label
+	if label == "" {
+		label = node.ID
+	}
+	inner := v.width - 2
+	rows := []string{
+		"┌" + strings.Repeat("─", inner) + "┐",
+		"│ " + terminus.Sanitize(label) + " │",
+		"└" + strings.Repeat("─", inner) + "┘",
+	}
+	for dy, row := range rows {
+		x := v.x
+		for _, r := range row {
+			grid[y+dy][x] = graphCell{r: r, owner: v.node}
+			x++
+			for w := terminus.RuneWidth(r); w > 1; w-- {
+				grid[y+dy][x] = graphCell{owner: v.node}
+				x++
+			}
+		}
+	}
+}
+
+// View implements the Component interface
+func (g *Graph) View() string {
+	if len(g.nodes) == 0 {
+		return ""
+	}
+	layout := g.laidOut()
+	grid := g.draw(layout)
+	selected := g.selectedIndex()
+
+	// Scroll to keep the selected node in sight
+	width, height := len(grid[0]), len(grid)
+	v := layout.vertices[layout.vertexOf(selected)]
+	if g.width > 0 && width > g.width {
+		g.left = clampIndex(g.left, width-g.width+1)
+		if v.x < g.left {
+			g.left = v.x
+		} else if v.x+v.width > g.left+g.width {
+			g.left = v.x + v.width - g.width
+		}
+		width = g.width
+	} else {
+		g.left = 0
+	}
+	if g.height > 0 && height > g.height {
+		y := v.layer * graphLayerHeight
+		g.top = clampIndex(g.top, height-g.height+1)
+		if y < g.top {
+			g.top = y
+		} else if y+graphNodeHeight > g.top+g.height {
+			g.top = y + graphNodeHeight - g.height
+		}
+		height = g.height
+	} else {
+		g.top = 0
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		row := grid[g.top+i]
+		cells := row[g.left : g.left+width]
+		// Blank wide runes cut in half by the edges
+		if cells[0].r == 0 {
+			cells[0].r = ' '
+		}
+		if g.left+width < len(row) && row[g.left+width].r == 0 {
+			cells[width-1].r = ' '
+		}
+		lines[i] = g.renderLine(cells, selected)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderLine renders a line of cells, styling each run by what it shows
+func (g *Graph) renderLine(cells []graphCell, selected int) string {
+	end := len(cells)
+	for end > 0 && cells[end-1].owner == graphEmpty {
+		end--
+	}
+
+	var b strings.Builder
+	for start := 0; start < end; {
+		owner := cells[start].owner
+		var run strings.Builder
+		i := start
+		for ; i < end && cells[i].owner == owner; i++ {
+			if cells[i].r != 0 {
+				run.WriteRune(cells[i].r)
+			}
+		}
+		switch {
+		case owner == graphEdge:
+			b.WriteString(g.edgeStyle.Render(run.String()))
+		case owner == graphEmpty:
+			b.WriteString(run.String())
+		case owner == selected:
+			b.WriteString(g.selectedStyle.Render(run.String()))
+		case g.nodeStyleFunc != nil:
+			b.WriteString(g.nodeStyleFunc(g.nodes[owner]).Render(run.String()))
+		default:
+			b.WriteString(g.nodeStyle.Render(run.String()))
+		}
+		start = i
+	}
+	return b.String()
+}
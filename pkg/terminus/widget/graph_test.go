@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestGraph(t *testing.T) {
+	plain := terminus.NewStyle()
+	// newGraph returns an unstyled, focused graph
+	newGraph := func() *Graph {
+		g := NewGraph().SetSelectedStyle(plain).SetEdgeStyle(plain)
+		g.Focus()
+		return g
+	}
+	press := func(g *Graph, keys ...terminus.KeyType) {
+		for _, key := range keys {
+			g.Update(terminus.KeyMsg{Type: key})
+		}
+	}
+	selected := func(g *Graph) string {
+		node, _ := g.Selected()
+		return node.ID
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Draws nodes in layers joined by lines",
+			test: func(t *testing.T) {
+				g := newGraph().AddEdge("a", "b").AddEdge("a", "c")
+				expected := strings.Join([]string{
+					"   ┌───┐",
+					"   │ a │",
+					"   └─┬─┘",
+					"  ┌──┴───┐",
+					"┌───┐  ┌───┐",
+					"│ b │  │ c │",
+					"└───┘  └───┘",
+				}, "\n")
+				if view := g.View(); view != expected {
+					t.Errorf("Expected\n%s\ngot\n%s", expected, view)
+				}
+			},
+		},
+		{
+			name: "Places nodes below every node with an edge to them",
+			test: func(t *testing.T) {
+				g := newGraph().
+					AddEdge("lb", "web").
+					AddEdge("web", "api").
+					AddEdge("lb", "api").
+					AddEdge("api", "lb") // Closes a cycle
+				lines := strings.Split(g.View(), "\n")
+				for id, line := range map[string]int{"lb": 1, "web": 5, "api": 9} {
+					if !strings.Contains(lines[line], id) {
+						t.Errorf("Expected %s on line %d, got %q", id, line, lines[line])
+					}
+				}
+			},
+		},
+		{
+			name: "Moves the selection between nodes",
+			test: func(t *testing.T) {
+				g := newGraph().AddEdge("a", "b").AddEdge("a", "c").AddEdge("c", "d")
+				var changes []string
+				g.SetOnChange(func(node GraphNode) terminus.Cmd {
+					changes = append(changes, node.ID)
+					return nil
+				})
+
+				press(g, terminus.KeyDown)
+				if selected(g) != "b" {
+					t.Errorf("Expected the nearest node below, got %q", selected(g))
+				}
+				press(g, terminus.KeyRight, terminus.KeyRight, terminus.KeyDown)
+				if selected(g) != "d" {
+					t.Errorf("Expected d, got %q", selected(g))
+				}
+				press(g, terminus.KeyDown, terminus.KeyUp, terminus.KeyUp)
+				if selected(g) != "a" {
+					t.Errorf("Expected a, got %q", selected(g))
+				}
+				if got := strings.Join(changes, ","); got != "b,c,d,c,a" {
+					t.Errorf("Expected a change for each move, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Reports Enter on the selected node",
+			test: func(t *testing.T) {
+				g := newGraph().AddNode("db", "Postgres").Select("db")
+				var chosen GraphNode
+				g.SetOnSelect(func(node GraphNode) terminus.Cmd {
+					chosen = node
+					return nil
+				})
+				press(g, terminus.KeyEnter)
+				if chosen.ID != "db" || chosen.Label != "Postgres" || !strings.Contains(g.View(), "Postgres") {
+					t.Errorf("Expected db chosen and labelled, got %+v", chosen)
+				}
+			},
+		},
+		{
+			name: "Scrolls to keep the selected node in sight",
+			test: func(t *testing.T) {
+				g := newGraph().AddEdge("a", "b").AddEdge("a", "c").AddEdge("c", "d")
+				g.SetSize(6, 4)
+				g.Select("d")
+				view := g.View()
+				lines := strings.Split(view, "\n")
+				if len(lines) != 4 || !strings.Contains(view, "d") {
+					t.Fatalf("Expected d in a 4 line view, got %q", view)
+				}
+				for _, line := range lines {
+					if w := visibleWidth(line); w > 6 {
+						t.Errorf("Expected lines cut to 6 columns, got %q", line)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// gutterMarker is a symbol shown beside a line
+type gutterMarker struct {
+	text  string
+	style terminus.Style
+}
+
+// Gutter renders a column beside text with line numbers and markers such as
+// change indicators or breakpoints. Widgets draw it outside the scrolled
+// area, so it stays aligned when the text scrolls horizontally.
+type Gutter struct {
+	lineNumbers bool
+	markers     map[int]gutterMarker // By 1-based line
+	numberStyle terminus.Style
+}
+
+// NewGutter creates a new gutter showing line numbers
+func NewGutter() *Gutter {
+	return &Gutter{
+		lineNumbers: true,
+		markers:     make(map[int]gutterMarker),
+		numberStyle: terminus.NewStyle().Faint(true),
+	}
+}
+
+// SetLineNumbers sets whether line numbers are shown
+func (g *Gutter) SetLineNumbers(show bool) *Gutter {
+	g.lineNumbers = show
+	return g
+}
+
+// SetNumberStyle sets the style of line numbers
+func (g *Gutter) SetNumberStyle(style terminus.Style) *Gutter {
+	g.numberStyle = style
+	return g
+}
+
+// SetMarker shows marker beside the 1-based line, e.g. "+" for an added
+// line or "●" for a breakpoint. An empty marker removes it.
+func (g *Gutter) SetMarker(line int, marker string, style terminus.Style) *Gutter {
+	if marker == "" {
+		delete(g.markers, line)
+		return g
+	}
+	g.markers[line] = gutterMarker{text: marker, style: style}
+	return g
+}
+
+// Marker returns the marker beside the 1-based line, or ""
+func (g *Gutter) Marker(line int) string {
+	return g.markers[line].text
+}
+
+// ClearMarkers removes every marker
+func (g *Gutter) ClearMarkers() *Gutter {
+	g.markers = make(map[int]gutterMarker)
+	return g
+}
+
+// markerWidth returns the width of the widest marker
+func (g *Gutter) markerWidth() int {
+	width := 0
+	for _, m := range g.markers {
+		if w := utf8.RuneCountInString(m.text); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// numberWidth returns the width of line numbers for content of lines lines
+func (g *Gutter) numberWidth(lines int) int {
+	if !g.lineNumbers {
+		return 0
+	}
+	if lines < 1 {
+		lines = 1
+	}
+	return len(fmt.Sprint(lines))
+}
+
+// Width returns the columns the gutter takes beside content of lines lines,
+// including the space separating it from the text
+func (g *Gutter) Width(lines int) int {
+	width := g.markerWidth() + g.numberWidth(lines)
+	if width == 0 {
+		return 0
+	}
+	return width + 1
+}
+
+// Render renders the gutter for the 1-based line of content of lines lines.
+// Lines outside the content get a blank gutter.
+func (g *Gutter) Render(line, lines int) string {
+	width := g.Width(lines)
+	if width == 0 {
+		return ""
+	}
+	if line < 1 || line > lines {
+		return strings.Repeat(" ", width)
+	}
+
+	var b strings.Builder
+	if mw := g.markerWidth(); mw > 0 {
+		m, ok := g.markers[line]
+		if ok {
+			b.WriteString(m.style.Render(m.text))
+		}
+		b.WriteString(strings.Repeat(" ", mw-utf8.RuneCountInString(m.text)))
+	}
+	if nw := g.numberWidth(lines); nw > 0 {
+		b.WriteString(g.numberStyle.Render(fmt.Sprintf("%*d", nw, line)))
+	}
+	b.WriteString(" ")
+	return b.String()
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestGutter(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Right-aligns line numbers",
+			test: func(t *testing.T) {
+				g := NewGutter()
+				if g.Width(120) != 4 {
+					t.Errorf("Expected width 4, got %d", g.Width(120))
+				}
+				if got := plain(g.Render(7, 120)); got != "  7 " {
+					t.Errorf("Expected %q, got %q", "  7 ", got)
+				}
+				if got := g.Render(121, 120); got != "    " {
+					t.Errorf("Expected a blank gutter past the end, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Shows markers in their own column",
+			test: func(t *testing.T) {
+				g := NewGutter().
+					SetMarker(2, "+", terminus.NewStyle().Foreground(terminus.Green)).
+					SetMarker(3, "●", terminus.NewStyle().Foreground(terminus.Red))
+				if got := plain(g.Render(2, 9)); got != "+2 " {
+					t.Errorf("Expected %q, got %q", "+2 ", got)
+				}
+				if got := plain(g.Render(1, 9)); got != " 1 " {
+					t.Errorf("Expected %q, got %q", " 1 ", got)
+				}
+				if g.Marker(3) != "●" {
+					t.Errorf("Expected a breakpoint on line 3, got %q", g.Marker(3))
+				}
+
+				g.SetMarker(2, "", terminus.NewStyle())
+				if g.Marker(2) != "" {
+					t.Error("Expected an empty marker to remove it")
+				}
+				g.ClearMarkers().SetLineNumbers(false)
+				if g.Width(9) != 0 || g.Render(1, 9) != "" {
+					t.Errorf("Expected an empty gutter, got %q", g.Render(1, 9))
+				}
+			},
+		},
+		{
+			name: "Pager keeps the gutter when scrolling horizontally",
+			test: func(t *testing.T) {
+				lines := make([]string, 12)
+				for i := range lines {
+					lines[i] = fmt.Sprintf("line%d-abcdefghijklmnop", i+1)
+				}
+				p := NewPager().SetLines(lines).SetShowStatus(false).
+					SetGutter(NewGutter().SetMarker(1, "*", terminus.NewStyle()))
+				p.SetSize(12, 3)
+				p.Focus()
+
+				view := strings.Split(plain(p.View()), "\n")
+				if view[0] != "* 1 line1-ab" {
+					t.Errorf("Expected the numbered first line, got %q", view[0])
+				}
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				view = strings.Split(plain(p.View()), "\n")
+				if !strings.HasPrefix(view[0], "* 1 ") || !strings.HasPrefix(view[1], "  2 ") {
+					t.Errorf("Expected the gutter to stay put, got %q", view)
+				}
+				if strings.Contains(view[0], "line1") {
+					t.Errorf("Expected the text to scroll, got %q", view[0])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// barEighths are the blocks filling an eighth to all of a cell from the
+// bottom
+var barEighths = []rune(" ▁▂▃▄▅▆▇█")
+
+// Histogram draws values as vertical bars over labelled buckets, with the
+// scale on the left. The arrow keys select a bar, whose label and value are
+// shown above the chart. Push adds values as they arrive, keeping the
+// newest when a capacity is set, for live data like audio levels.
+type Histogram struct {
+	Model
+
+	// Data
+	values   []float64
+	labels   []string // Bucket labels, parallel to values
+	capacity int      // Buckets kept by Push, 0 for all
+
+	// State
+	selected int
+	offset   int // First bar shown
+
+	// Configuration
+	min, max    float64
+	fixedRange  bool // Whether min and max were set rather than fitted to the values
+	barWidth    int
+	gap         int
+	format      string
+	showTooltip bool
+
+	// Styling
+	barStyle      terminus.Style
+	selectedStyle terminus.Style
+	axisStyle     terminus.Style
+	labelStyle    terminus.Style
+	tooltipStyle  terminus.Style
+
+	// Events
+	onSelect func(index int, label string, value float64) terminus.Cmd
+}
+
+// NewHistogram creates an empty 40 by 10 histogram
+func NewHistogram() *Histogram {
+	m := NewModel()
+	m.width, m.height = 40, 10
+	return &Histogram{
+		Model:         m,
+		barWidth:      2,
+		gap:           1,
+		format:        "%.0f",
+		showTooltip:   true,
+		barStyle:      terminus.NewStyle().Foreground(terminus.Cyan),
+		selectedStyle: terminus.NewStyle().Foreground(terminus.Yellow),
+		axisStyle:     terminus.NewStyle().Faint(true),
+		labelStyle:    terminus.NewStyle().Faint(true),
+		tooltipStyle:  terminus.NewStyle().Bold(true),
+	}
+}
+
+// SetValues replaces the values. Labels name the buckets along the bottom
+// and may be nil.
+func (h *Histogram) SetValues(values []float64, labels []string) *Histogram {
+	h.values = append(h.values[:0], values...)
+	h.labels = make([]string, len(values))
+	copy(h.labels, labels)
+	h.trim()
+	h.selected = clampIndex(h.selected, len(h.values))
+	return h
+}
+
+// Push adds a bucket after the others, dropping the oldest beyond the
+// capacity. A selection of the newest bucket moves to the new one.
+func (h *Histogram) Push(label string, value float64) *Histogram {
+	newest := h.selected >= len(h.values)-1
+	h.values = append(h.values, value)
+	h.labels = append(h.labels, label)
+	h.selected -= h.trim()
+	if newest {
+		h.selected = len(h.values) - 1
+	}
+	h.selected = max(0, h.selected)
+	return h
+}
+
+// trim drops the oldest buckets beyond the capacity and returns how many
+// it dropped
+func (h *Histogram) trim() int {
+	n := len(h.values) - h.capacity
+	if h.capacity <= 0 || n <= 0 {
+		return 0
+	}
+	h.values = append(h.values[:0], h.values[n:]...)
+	h.labels = append(h.labels[:0], h.labels[n:]...)
+	return n
+}
+
+// Values returns the values
+func (h *Histogram) Values() []float64 {
+	return h.values
+}
+
+// SetCapacity sets how many buckets are kept, dropping the oldest. 0, the
+// default, keeps all.
+func (h *Histogram) SetCapacity(capacity int) *Histogram {
+	h.capacity = capacity
+	h.selected = max(0, h.selected-h.trim())
+	return h
+}
+
+// SetRange fixes the scale, which otherwise runs from 0, or the lowest
+// negative value, to the highest value
+func (h *Histogram) SetRange(min, max float64) *Histogram {
+	h.min, h.max, h.fixedRange = min, max, true
+	return h
+}
+
+// SetBarWidth sets the width of each bar and the space between bars
+func (h *Histogram) SetBarWidth(width, gap int) *Histogram {
+	h.barWidth, h.gap = max(1, width), max(0, gap)
+	return h
+}
+
+// SetFormat sets the fmt verb values are shown with on the scale and in
+// the tooltip
+func (h *Histogram) SetFormat(format string) *Histogram {
+	h.format = format
+	return h
+}
+
+// SetShowTooltip sets whether the line above the chart shows the selected
+// bucket while the histogram is focused
+func (h *Histogram) SetShowTooltip(show bool) *Histogram {
+	h.showTooltip = show
+	return h
+}
+
+// SetBarStyle sets the style of the bars
+func (h *Histogram) SetBarStyle(style terminus.Style) *Histogram {
+	h.barStyle = style
+	return h
+}
+
+// SetSelectedStyle sets the style of the selected bar
+func (h *Histogram) SetSelectedStyle(style terminus.Style) *Histogram {
+	h.selectedStyle = style
+	return h
+}
+
+// SetAxisStyle sets the style of the scale
+func (h *Histogram) SetAxisStyle(style terminus.Style) *Histogram {
+	h.axisStyle = style
+	return h
+}
+
+// SetLabelStyle sets the style of the bucket labels
+func (h *Histogram) SetLabelStyle(style terminus.Style) *Histogram {
+	h.labelStyle = style
+	return h
+}
+
+// SetTooltipStyle sets the style of the tooltip
+func (h *Histogram) SetTooltipStyle(style terminus.Style) *Histogram {
+	h.tooltipStyle = style
+	return h
+}
+
+// SetOnSelect sets the callback triggered when Enter is pressed on a bar
+func (h *Histogram) SetOnSelect(callback func(index int, label string, value float64) terminus.Cmd) *Histogram {
+	h.onSelect = callback
+	return h
+}
+
+// Selected returns the index of the selected bucket, or -1 when there are
+// none
+func (h *Histogram) Selected() int {
+	if len(h.values) == 0 {
+		return -1
+	}
+	return h.selected
+}
+
+// SetSelected selects a bucket
+func (h *Histogram) SetSelected(index int) *Histogram {
+	h.selected = clampIndex(index, len(h.values))
+	return h
+}
+
+// KeyBindings returns the keys the histogram handles, for checking global
+// shortcuts against
+func (h *Histogram) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("hl", terminus.KeyLeft, terminus.KeyRight, terminus.KeyHome, terminus.KeyEnd, terminus.KeyEnter)
+}
+
+// Init implements the Component interface
+func (h *Histogram) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (h *Histogram) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok || !h.Focused() || len(h.values) == 0 {
+		return h, nil
+	}
+
+	switch keyMsg.Type {
+	case terminus.KeyLeft:
+		h.SetSelected(h.selected - 1)
+	case terminus.KeyRight:
+		h.SetSelected(h.selected + 1)
+	case terminus.KeyHome:
+		h.SetSelected(0)
+	case terminus.KeyEnd:
+		h.SetSelected(len(h.values) - 1)
+	case terminus.KeyEnter:
+		if h.onSelect != nil {
+			return h, h.onSelect(h.selected, h.labels[h.selected], h.values[h.selected])
+		}
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) == 1 && keyMsg.Runes[0] == 'h' {
+			h.SetSelected(h.selected - 1)
+		} else if len(keyMsg.Runes) == 1 && keyMsg.Runes[0] == 'l' {
+			h.SetSelected(h.selected + 1)
+		}
+	}
+	return h, nil
+}
+
+// scale returns the values at the bottom and top of the chart
+func (h *Histogram) scale() (lo, hi float64) {
+	if h.fixedRange {
+		return h.min, h.max
+	}
+	for _, v := range h.values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+// View implements the Component interface
+func (h *Histogram) View() string {
+	var lines []string
+	rows := h.height
+	if h.showTooltip {
+		tooltip := ""
+		if h.Focused() && len(h.values) > 0 {
+			label := terminus.Sanitize(h.labels[h.selected])
+			if label == "" {
+				label = fmt.Sprintf("#%d", h.selected+1)
+			}
+			tooltip = h.tooltipStyle.Render(label + ": " + fmt.Sprintf(h.format, h.values[h.selected]))
+		}
+		lines = append(lines, tooltip)
+		rows--
+	}
+	hasLabels := false
+	for _, label := range h.labels {
+		hasLabels = hasLabels || label != ""
+	}
+	if hasLabels {
+		rows--
+	}
+	if rows < 1 {
+		return strings.Join(lines, "\n")
+	}
+
+	// The scale on the left shows the top and bottom values
+	lo, hi := h.scale()
+	top, bottom := fmt.Sprintf(h.format, hi), fmt.Sprintf(h.format, lo)
+	gutter := max(len(top), len(bottom))
+
+	// Show the bars that fit, scrolling to keep the selection in sight
+	step := h.barWidth + h.gap
+	fit := max(1, (h.width-gutter-1+h.gap)/step)
+	switch {
+	case len(h.values) <= fit:
+		h.offset = 0
+	case h.selected < h.offset:
+		h.offset = h.selected
+	case h.selected >= h.offset+fit:
+		h.offset = h.selected - fit + 1
+	}
+	h.offset = min(h.offset, max(0, len(h.values)-fit))
+	end := min(len(h.values), h.offset+fit)
+
+	for row := 0; row < rows; row++ {
+		var line strings.Builder
+		label, axis := "", "│"
+		switch row {
+		case 0:
+			label, axis = top, "┤"
+		case rows - 1:
+			label, axis = bottom, "┤"
+		}
+		line.WriteString(h.axisStyle.Render(fmt.Sprintf("%*s%s", gutter, label, axis)))
+
+		// Eighths of a cell filled below this row's top
+		level := (rows - row) * 8
+		for i := h.offset; i < end; i++ {
+			filled := 0
+			if hi > lo {
+				filled = int(math.Round((h.values[i] - lo) / (hi - lo) * float64(rows*8)))
+			}
+			cell := barEighths[max(0, min(8, filled-level+8))]
+			bar := strings.Repeat(string(cell), h.barWidth)
+			if i > h.offset {
+				line.WriteString(strings.Repeat(" ", h.gap))
+			}
+			switch {
+			case cell == ' ':
+				line.WriteString(bar)
+			case i == h.selected && h.Focused():
+				line.WriteString(h.selectedStyle.Render(bar))
+			default:
+				line.WriteString(h.barStyle.Render(bar))
+			}
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+
+	if hasLabels {
+		lines = append(lines, h.labelLine(gutter+1, end, step))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// labelLine returns the bucket labels under their bars, skipping labels
+// that would run into the one before
+func (h *Histogram) labelLine(indent, end, step int) string {
+	var line []rune
+	for i := h.offset; i < end; i++ {
+		label := []rune(terminus.Sanitize(h.labels[i]))
+		x := indent + (i-h.offset)*step
+		if len(label) == 0 || len(line) > x-1 && len(line) > 0 {
+			continue
+		}
+		for len(line) < x {
+			line = append(line, ' ')
+		}
+		line = append(line, label...)
+	}
+	if len(line) > h.width {
+		line = line[:h.width]
+	}
+	return h.labelStyle.Render(strings.TrimRight(string(line), " "))
+}
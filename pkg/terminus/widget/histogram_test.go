@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestHistogram(t *testing.T) {
+	plain := terminus.NewStyle()
+	// newHistogram returns an unstyled 20 by 6 histogram
+	newHistogram := func() *Histogram {
+		h := NewHistogram().
+			SetBarStyle(plain).
+			SetSelectedStyle(plain).
+			SetAxisStyle(plain).
+			SetLabelStyle(plain).
+			SetTooltipStyle(plain)
+		h.SetSize(20, 6)
+		return h
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Draws bars on a scale with labels",
+			test: func(t *testing.T) {
+				h := newHistogram().SetValues([]float64{1, 4, 8, 3}, []string{"0", "10", "20", "30"})
+				expected := strings.Join([]string{
+					"",
+					"8┤      ██",
+					" │      ██",
+					" │   ██ ██ ▄▄",
+					"0┤▄▄ ██ ██ ██",
+					"  0  10 20 30",
+				}, "\n")
+				if view := h.View(); view != expected {
+					t.Errorf("Expected\n%s\ngot\n%s", expected, view)
+				}
+			},
+		},
+		{
+			name: "Shows the selected bucket",
+			test: func(t *testing.T) {
+				h := newHistogram().SetValues([]float64{1, 4, 8, 3}, []string{"0-10ms", "10-20ms", "", ""})
+				h.Focus()
+				var chosen string
+				h.SetOnSelect(func(index int, label string, value float64) terminus.Cmd {
+					chosen = label
+					return nil
+				})
+
+				h.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				h.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if tooltip := strings.Split(h.View(), "\n")[0]; tooltip != "10-20ms: 4" || chosen != "10-20ms" {
+					t.Errorf("Expected the second bucket, got %q and %q", tooltip, chosen)
+				}
+				h.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+				if tooltip := strings.Split(h.View(), "\n")[0]; tooltip != "#4: 3" {
+					t.Errorf("Expected the last bucket by number, got %q", tooltip)
+				}
+			},
+		},
+		{
+			name: "Keeps the newest values pushed",
+			test: func(t *testing.T) {
+				h := newHistogram().SetCapacity(3)
+				for i := 1; i <= 5; i++ {
+					h.Push("", float64(i))
+				}
+				if got := h.Values(); len(got) != 3 || got[0] != 3 || got[2] != 5 {
+					t.Errorf("Expected [3 4 5], got %v", got)
+				}
+				if h.Selected() != 2 {
+					t.Errorf("Expected the selection to follow the newest, got %d", h.Selected())
+				}
+
+				h.SetSelected(1)
+				h.Push("", 6)
+				if h.Selected() != 0 {
+					t.Errorf("Expected the selection to stay on its bucket, got %d", h.Selected())
+				}
+			},
+		},
+		{
+			name: "Scrolls to the selected bar",
+			test: func(t *testing.T) {
+				h := newHistogram().SetShowTooltip(false).SetRange(0, 10)
+				h.SetSize(8, 2)
+				h.SetValues([]float64{10, 0, 0, 0, 5}, nil)
+				h.Focus()
+				if view := h.View(); view != "10┤██\n 0┤██" {
+					t.Errorf("Expected the first bar, got %q", view)
+				}
+				h.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+				if view := h.View(); view != "10┤\n 0┤   ██" {
+					t.Errorf("Expected the last bars, got %q", view)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,711 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// jsonKind is the type of a JSON value
+type jsonKind int
+
+const (
+	jsonObject jsonKind = iota
+	jsonArray
+	jsonString
+	jsonNumber
+	jsonBool
+	jsonNull
+)
+
+// jsonNode is a value in the tree. Object keys keep their document order.
+type jsonNode struct {
+	key       string // Object key, empty for array elements and the root
+	index     int    // Array index, or -1
+	kind      jsonKind
+	value     string // Scalars as JSON text
+	children  []*jsonNode
+	parent    *jsonNode
+	depth     int
+	collapsed bool
+}
+
+// isContainer reports whether the node is an object or array
+func (n *jsonNode) isContainer() bool {
+	return n.kind == jsonObject || n.kind == jsonArray
+}
+
+// path returns the node's location, such as $.users[0].name
+func (n *jsonNode) path() string {
+	if n.parent == nil {
+		return "$"
+	}
+	parent := n.parent.path()
+	if n.index >= 0 {
+		return fmt.Sprintf("%s[%d]", parent, n.index)
+	}
+	if isIdentifier(n.key) {
+		return parent + "." + n.key
+	}
+	return parent + "[" + strconv.Quote(n.key) + "]"
+}
+
+// isIdentifier reports whether a key can be written in dot notation
+func isIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonRow is one line of the rendered tree. Expanded containers get a
+// second row for their closing bracket.
+type jsonRow struct {
+	node    *jsonNode
+	closing bool
+}
+
+// JSONView is a collapsible tree inspector for JSON documents. Values are
+// colored by type, / searches keys and y hands the selected path to the
+// copy callback.
+type JSONView struct {
+	Model
+
+	// Data
+	root   *jsonNode
+	rows   []jsonRow
+	cursor int
+	offset int
+
+	// Search
+	query   string
+	matches []*jsonNode
+	prompt  bool
+	input   string
+	notice  string
+
+	// Configuration
+	showPath bool
+
+	// Styling
+	keyStyle       terminus.Style
+	stringStyle    terminus.Style
+	numberStyle    terminus.Style
+	boolStyle      terminus.Style
+	nullStyle      terminus.Style
+	punctStyle     terminus.Style
+	cursorStyle    terminus.Style
+	highlightStyle terminus.Style
+	pathStyle      terminus.Style
+
+	// Events
+	onCopy func(path string) terminus.Cmd
+}
+
+// NewJSONView creates a new JSON inspector
+func NewJSONView() *JSONView {
+	m := NewModel()
+	m.width = 80
+	m.height = 20
+	return &JSONView{
+		Model:          m,
+		showPath:       true,
+		keyStyle:       terminus.NewStyle().Foreground(terminus.Blue).Bold(true),
+		stringStyle:    terminus.NewStyle().Foreground(terminus.Green),
+		numberStyle:    terminus.NewStyle().Foreground(terminus.Cyan),
+		boolStyle:      terminus.NewStyle().Foreground(terminus.Yellow),
+		nullStyle:      terminus.NewStyle().Foreground(terminus.Magenta),
+		punctStyle:     terminus.NewStyle().Faint(true),
+		cursorStyle:    terminus.NewStyle().Foreground(terminus.Cyan).Bold(true),
+		highlightStyle: terminus.NewStyle().Reverse(true),
+		pathStyle:      terminus.NewStyle().Reverse(true),
+	}
+}
+
+// SetJSON parses and displays a JSON document
+func (j *JSONView) SetJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeJSONNode(dec, nil)
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err == nil {
+		return fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+
+	j.root = root
+	j.cursor, j.offset = 0, 0
+	j.query, j.matches = "", nil
+	j.buildRows()
+	return nil
+}
+
+// SetValue displays any value that encoding/json can marshal
+func (j *JSONView) SetValue(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return j.SetJSON(data)
+}
+
+// decodeJSONNode reads one value and its children from dec
+func decodeJSONNode(dec *json.Decoder, parent *jsonNode) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &jsonNode{index: -1, parent: parent}
+	if parent != nil {
+		node.depth = parent.depth + 1
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			node.kind = jsonObject
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := decodeJSONNode(dec, node)
+				if err != nil {
+					return nil, err
+				}
+				child.key = keyTok.(string)
+				node.children = append(node.children, child)
+			}
+		} else {
+			node.kind = jsonArray
+			for i := 0; dec.More(); i++ {
+				child, err := decodeJSONNode(dec, node)
+				if err != nil {
+					return nil, err
+				}
+				child.index = i
+				node.children = append(node.children, child)
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.kind = jsonString
+		node.value = strconv.Quote(v)
+	case json.Number:
+		node.kind = jsonNumber
+		node.value = v.String()
+	case bool:
+		node.kind = jsonBool
+		node.value = strconv.FormatBool(v)
+	case nil:
+		node.kind = jsonNull
+		node.value = "null"
+	}
+	return node, nil
+}
+
+// SetShowPath sets whether the selected path is shown on the bottom line
+func (j *JSONView) SetShowPath(show bool) *JSONView {
+	j.showPath = show
+	return j
+}
+
+// SetKeyStyle sets the style of object keys
+func (j *JSONView) SetKeyStyle(style terminus.Style) *JSONView {
+	j.keyStyle = style
+	return j
+}
+
+// SetValueStyles sets the styles of strings, numbers, booleans and null
+func (j *JSONView) SetValueStyles(str, number, boolean, null terminus.Style) *JSONView {
+	j.stringStyle = str
+	j.numberStyle = number
+	j.boolStyle = boolean
+	j.nullStyle = null
+	return j
+}
+
+// SetPunctuationStyle sets the style of brackets, commas and summaries
+func (j *JSONView) SetPunctuationStyle(style terminus.Style) *JSONView {
+	j.punctStyle = style
+	return j
+}
+
+// SetHighlightStyle sets the style of keys matching the search
+func (j *JSONView) SetHighlightStyle(style terminus.Style) *JSONView {
+	j.highlightStyle = style
+	return j
+}
+
+// SetOnCopy sets the callback that receives the selected path when y is
+// pressed, e.g. to put it on the clipboard
+func (j *JSONView) SetOnCopy(callback func(path string) terminus.Cmd) *JSONView {
+	j.onCopy = callback
+	return j
+}
+
+// SelectedPath returns the path of the selected value, such as
+// $.users[0].name
+func (j *JSONView) SelectedPath() string {
+	if node := j.selected(); node != nil {
+		return node.path()
+	}
+	return ""
+}
+
+// ExpandAll expands every node
+func (j *JSONView) ExpandAll() *JSONView {
+	j.setCollapsed(j.root, false, 0)
+	return j
+}
+
+// CollapseAll collapses every node below the root
+func (j *JSONView) CollapseAll() *JSONView {
+	j.setCollapsed(j.root, true, 1)
+	return j
+}
+
+// setCollapsed sets the state of containers at or below minDepth and keeps
+// the cursor on the same node where possible
+func (j *JSONView) setCollapsed(node *jsonNode, collapsed bool, minDepth int) {
+	if node == nil {
+		return
+	}
+	selected := j.selected()
+	var walk func(*jsonNode)
+	walk = func(n *jsonNode) {
+		if n.isContainer() && n.depth >= minDepth {
+			n.collapsed = collapsed
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	// Keep the cursor on the nearest visible ancestor
+	for selected != nil && selected.parent != nil && selected.parent.collapsed {
+		selected = selected.parent
+	}
+	j.buildRows()
+	j.moveTo(selected)
+}
+
+// Search selects the next key containing query, ignoring case, expanding
+// its parents as needed. An empty query clears the search.
+func (j *JSONView) Search(query string) *JSONView {
+	j.query = query
+	j.matches = nil
+	if query == "" || j.root == nil {
+		return j
+	}
+
+	q := strings.ToLower(query)
+	var walk func(*jsonNode)
+	walk = func(n *jsonNode) {
+		if n.key != "" && strings.Contains(strings.ToLower(n.key), q) {
+			j.matches = append(j.matches, n)
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(j.root)
+
+	if len(j.matches) == 0 {
+		j.notice = "Key not found"
+		return j
+	}
+	j.reveal(j.matches[0])
+	return j
+}
+
+// MatchCount returns the number of keys matching the search
+func (j *JSONView) MatchCount() int {
+	return len(j.matches)
+}
+
+// NextMatch selects the next matching key, wrapping at the end
+func (j *JSONView) NextMatch() *JSONView {
+	j.stepMatch(1)
+	return j
+}
+
+// PrevMatch selects the previous matching key, wrapping at the start
+func (j *JSONView) PrevMatch() *JSONView {
+	j.stepMatch(-1)
+	return j
+}
+
+// stepMatch moves to the match after or before the selected node
+func (j *JSONView) stepMatch(delta int) {
+	if len(j.matches) == 0 {
+		return
+	}
+	current := -1
+	for i, m := range j.matches {
+		if m == j.selected() {
+			current = i
+			break
+		}
+	}
+	next := 0
+	if current >= 0 {
+		next = (current + delta + len(j.matches)) % len(j.matches)
+	} else if delta < 0 {
+		next = len(j.matches) - 1
+	}
+	j.reveal(j.matches[next])
+}
+
+// reveal expands the ancestors of node and selects it
+func (j *JSONView) reveal(node *jsonNode) {
+	for p := node.parent; p != nil; p = p.parent {
+		p.collapsed = false
+	}
+	j.buildRows()
+	j.moveTo(node)
+}
+
+// selected returns the node under the cursor
+func (j *JSONView) selected() *jsonNode {
+	if j.cursor >= 0 && j.cursor < len(j.rows) {
+		return j.rows[j.cursor].node
+	}
+	return nil
+}
+
+// moveTo puts the cursor on the opening row of node
+func (j *JSONView) moveTo(node *jsonNode) {
+	for i, row := range j.rows {
+		if row.node == node && !row.closing {
+			j.cursor = i
+			break
+		}
+	}
+	j.updateOffset()
+}
+
+// buildRows flattens the visible part of the tree
+func (j *JSONView) buildRows() {
+	j.rows = j.rows[:0]
+	var walk func(*jsonNode)
+	walk = func(n *jsonNode) {
+		j.rows = append(j.rows, jsonRow{node: n})
+		if n.isContainer() && !n.collapsed && len(n.children) > 0 {
+			for _, child := range n.children {
+				walk(child)
+			}
+			j.rows = append(j.rows, jsonRow{node: n, closing: true})
+		}
+	}
+	if j.root != nil {
+		walk(j.root)
+	}
+	if j.cursor >= len(j.rows) {
+		j.cursor = len(j.rows) - 1
+	}
+	if j.cursor < 0 {
+		j.cursor = 0
+	}
+	j.updateOffset()
+}
+
+// treeLines returns the number of lines available to the tree
+func (j *JSONView) treeLines() int {
+	n := j.height
+	if j.showPath && n > 1 {
+		n--
+	}
+	return n
+}
+
+// updateOffset scrolls the cursor into view
+func (j *JSONView) updateOffset() {
+	lines := j.treeLines()
+	if j.cursor < j.offset {
+		j.offset = j.cursor
+	} else if j.cursor >= j.offset+lines {
+		j.offset = j.cursor - lines + 1
+	}
+	if last := len(j.rows) - lines; j.offset > last {
+		j.offset = last
+	}
+	if j.offset < 0 {
+		j.offset = 0
+	}
+}
+
+// Init implements the Component interface
+func (j *JSONView) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (j *JSONView) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if !j.Focused() || j.root == nil {
+		return j, nil
+	}
+
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return j, nil
+	}
+
+	if j.prompt {
+		j.updatePrompt(keyMsg)
+		return j, nil
+	}
+	j.notice = ""
+
+	key := keyMsg.Type
+	if key == terminus.KeyRunes && len(keyMsg.Runes) == 1 {
+		switch keyMsg.Runes[0] {
+		case 'k':
+			key = terminus.KeyUp
+		case 'j':
+			key = terminus.KeyDown
+		case 'h':
+			key = terminus.KeyLeft
+		case 'l':
+			key = terminus.KeyRight
+		case 'g':
+			key = terminus.KeyHome
+		case 'G':
+			key = terminus.KeyEnd
+		case '/':
+			j.prompt, j.input = true, ""
+		case 'n':
+			j.NextMatch()
+		case 'N':
+			j.PrevMatch()
+		case 'y':
+			return j, j.copyPath()
+		}
+	}
+
+	node := j.selected()
+	switch key {
+	case terminus.KeyUp:
+		if j.cursor > 0 {
+			j.cursor--
+		}
+	case terminus.KeyDown:
+		if j.cursor < len(j.rows)-1 {
+			j.cursor++
+		}
+	case terminus.KeyPgUp:
+		j.cursor -= j.treeLines()
+		if j.cursor < 0 {
+			j.cursor = 0
+		}
+	case terminus.KeyPgDown:
+		j.cursor += j.treeLines()
+		if j.cursor >= len(j.rows) {
+			j.cursor = len(j.rows) - 1
+		}
+	case terminus.KeyHome:
+		j.cursor = 0
+	case terminus.KeyEnd:
+		j.cursor = len(j.rows) - 1
+	case terminus.KeyLeft:
+		// Collapse, or climb to the parent
+		if node.isContainer() && !node.collapsed && len(node.children) > 0 {
+			j.toggle(node)
+		} else if node.parent != nil {
+			j.moveTo(node.parent)
+		}
+	case terminus.KeyRight:
+		// Expand, or step into the first child
+		if node.isContainer() && node.collapsed {
+			j.toggle(node)
+		} else if len(node.children) > 0 && !j.rows[j.cursor].closing {
+			j.moveTo(node.children[0])
+		}
+	case terminus.KeyEnter, terminus.KeySpace:
+		if node.isContainer() {
+			j.toggle(node)
+		}
+	}
+	j.updateOffset()
+
+	return j, nil
+}
+
+// toggle collapses or expands node and keeps it selected
+func (j *JSONView) toggle(node *jsonNode) {
+	node.collapsed = !node.collapsed
+	j.buildRows()
+	j.moveTo(node)
+}
+
+// copyPath hands the selected path to the copy callback
+func (j *JSONView) copyPath() terminus.Cmd {
+	path := j.SelectedPath()
+	j.notice = "Copied " + path
+	if j.onCopy != nil {
+		return j.onCopy(path)
+	}
+	return nil
+}
+
+// updatePrompt edits the search being typed
+func (j *JSONView) updatePrompt(msg terminus.KeyMsg) {
+	switch msg.Type {
+	case terminus.KeyEsc:
+		j.prompt = false
+	case terminus.KeyBackspace:
+		if j.input == "" {
+			j.prompt = false
+		} else {
+			runes := []rune(j.input)
+			j.input = string(runes[:len(runes)-1])
+		}
+	case terminus.KeySpace:
+		j.input += " "
+	case terminus.KeyRunes:
+		j.input += string(msg.Runes)
+	case terminus.KeyEnter:
+		j.prompt = false
+		j.Search(j.input)
+	}
+}
+
+// View implements the Component interface
+func (j *JSONView) View() string {
+	if j.root == nil {
+		return j.punctStyle.Render("No data")
+	}
+
+	lines := make([]string, 0, j.height)
+	end := j.offset + j.treeLines()
+	if end > len(j.rows) {
+		end = len(j.rows)
+	}
+	for i := j.offset; i < end; i++ {
+		prefix := "  "
+		if i == j.cursor {
+			prefix = j.cursorStyle.Render("> ")
+		}
+		lines = append(lines, prefix+j.renderRow(j.rows[i]))
+	}
+	for len(lines) < j.treeLines() {
+		lines = append(lines, "")
+	}
+
+	if j.showPath && j.height > 1 {
+		status := j.SelectedPath()
+		switch {
+		case j.prompt:
+			status = "/" + j.input
+		case j.notice != "":
+			status = j.notice
+		case len(j.matches) > 0:
+			status += fmt.Sprintf("  [%d matches]", len(j.matches))
+		}
+		if pad := j.width - len([]rune(status)); pad > 0 {
+			status += strings.Repeat(" ", pad)
+		}
+		lines = append(lines, j.pathStyle.Render(status))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderRow renders one line of the tree
+func (j *JSONView) renderRow(row jsonRow) string {
+	n := row.node
+	var b strings.Builder
+	b.WriteString(strings.Repeat("  ", n.depth))
+
+	openBr, closeBr := "{", "}"
+	if n.kind == jsonArray {
+		openBr, closeBr = "[", "]"
+	}
+
+	if row.closing {
+		b.WriteString(j.punctStyle.Render(closeBr))
+	} else {
+		if n.key != "" || (n.parent != nil && n.index < 0) {
+			b.WriteString(j.renderKey(n.key))
+			b.WriteString(j.punctStyle.Render(": "))
+		}
+
+		switch {
+		case n.isContainer() && len(n.children) == 0:
+			b.WriteString(j.punctStyle.Render(openBr + closeBr))
+		case n.isContainer() && n.collapsed:
+			unit := "items"
+			if n.kind == jsonObject {
+				unit = "keys"
+			}
+			b.WriteString(j.punctStyle.Render(fmt.Sprintf("%s…%s %d %s", openBr, closeBr, len(n.children), unit)))
+		case n.isContainer():
+			b.WriteString(j.punctStyle.Render(openBr))
+		default:
+			b.WriteString(j.valueStyle(n.kind).Render(n.value))
+		}
+
+		// Values and closing brackets are followed by a comma; open
+		// brackets aren't
+		if n.isContainer() && !n.collapsed && len(n.children) > 0 {
+			return b.String()
+		}
+	}
+
+	if n.parent != nil && n.parent.children[len(n.parent.children)-1] != n {
+		b.WriteString(j.punctStyle.Render(","))
+	}
+	return b.String()
+}
+
+// renderKey renders a quoted key, highlighting it if it matches the search
+func (j *JSONView) renderKey(key string) string {
+	if len(j.matches) > 0 && strings.Contains(strings.ToLower(key), strings.ToLower(j.query)) {
+		return j.highlightStyle.Render(strconv.Quote(key))
+	}
+	return j.keyStyle.Render(strconv.Quote(key))
+}
+
+// valueStyle returns the style for a scalar kind
+func (j *JSONView) valueStyle(kind jsonKind) terminus.Style {
+	switch kind {
+	case jsonString:
+		return j.stringStyle
+	case jsonNumber:
+		return j.numberStyle
+	case jsonBool:
+		return j.boolStyle
+	default:
+		return j.nullStyle
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// plain strips styling from rendered output
+func plain(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+const testDocument = `{
+  "name": "terminus",
+  "version": 2,
+  "tags": ["tui", "web"],
+  "owner": {"login": "skaiser", "admin": true, "email": null},
+  "empty": {},
+  "odd key": 1
+}`
+
+func newTestJSONView(t *testing.T) *JSONView {
+	j := NewJSONView()
+	if err := j.SetJSON([]byte(testDocument)); err != nil {
+		t.Fatalf("SetJSON failed: %v", err)
+	}
+	j.SetSize(60, 30)
+	j.Focus()
+	return j
+}
+
+func press(c terminus.Component, keys ...terminus.KeyMsg) {
+	for _, k := range keys {
+		c.Update(k)
+	}
+}
+
+func runeKey(r rune) terminus.KeyMsg {
+	return terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{r}}
+}
+
+func TestJSONView(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Pretty-prints in document order",
+			test: func(t *testing.T) {
+				j := newTestJSONView(t)
+				lines := strings.Split(plain(j.View()), "\n")
+
+				expected := []string{
+					`> {`,
+					`    "name": "terminus",`,
+					`    "version": 2,`,
+					`    "tags": [`,
+					`      "tui",`,
+					`      "web"`,
+					`    ],`,
+				}
+				for i, want := range expected {
+					if lines[i] != want {
+						t.Errorf("Line %d: expected %q, got %q", i, want, lines[i])
+					}
+				}
+				if !strings.Contains(plain(j.View()), `"empty": {},`) {
+					t.Error("Expected empty object rendered inline")
+				}
+			},
+		},
+		{
+			name: "Colors values by type",
+			test: func(t *testing.T) {
+				j := newTestJSONView(t)
+				view := j.View()
+
+				if !strings.Contains(view, terminus.NewStyle().Foreground(terminus.Green).Render(`"terminus"`)) {
+					t.Error("Expected strings in green")
+				}
+				if !strings.Contains(view, terminus.NewStyle().Foreground(terminus.Yellow).Render("true")) {
+					t.Error("Expected booleans in yellow")
+				}
+			},
+		},
+		{
+			name: "Collapses and expands nodes",
+			test: func(t *testing.T) {
+				j := newTestJSONView(t)
+				press(j, runeKey('j'), runeKey('j'), runeKey('j'))
+				if j.SelectedPath() != "$.tags" {
+					t.Fatalf("Expected $.tags, got %s", j.SelectedPath())
+				}
+
+				press(j, terminus.KeyMsg{Type: terminus.KeyLeft})
+				if !strings.Contains(plain(j.View()), `"tags": […] 2 items,`) {
+					t.Errorf("Expected collapsed array, got %q", plain(j.View()))
+				}
+
+				press(j, terminus.KeyMsg{Type: terminus.KeyRight}, terminus.KeyMsg{Type: terminus.KeyRight})
+				if j.SelectedPath() != "$.tags[0]" {
+					t.Errorf("Expected Right to expand then enter, got %s", j.SelectedPath())
+				}
+
+				press(j, terminus.KeyMsg{Type: terminus.KeyLeft})
+				if j.SelectedPath() != "$.tags" {
+					t.Errorf("Expected Left on a value to select the parent, got %s", j.SelectedPath())
+				}
+
+				j.CollapseAll()
+				if got := len(strings.Split(plain(j.View()), "\n")); !strings.Contains(plain(j.View()), `"owner": {…} 3 keys,`) || got != 30 {
+					t.Errorf("Expected collapsed children, got %q", plain(j.View()))
+				}
+			},
+		},
+		{
+			name: "Searches keys and reveals matches",
+			test: func(t *testing.T) {
+				j := newTestJSONView(t)
+				j.CollapseAll()
+
+				press(j, runeKey('/'), runeKey('m'), runeKey('a'), runeKey('i'), runeKey('l'),
+					terminus.KeyMsg{Type: terminus.KeyEnter})
+
+				if j.SelectedPath() != "$.owner.email" {
+					t.Errorf("Expected match inside collapsed owner, got %s", j.SelectedPath())
+				}
+				if j.MatchCount() != 1 {
+					t.Errorf("Expected 1 match, got %d", j.MatchCount())
+				}
+
+				j.Search("NAME")
+				press(j, runeKey('n'))
+				if j.SelectedPath() != "$.name" {
+					t.Errorf("Expected n to wrap to the first match, got %s", j.SelectedPath())
+				}
+			},
+		},
+		{
+			name: "Copies the selected path",
+			test: func(t *testing.T) {
+				var copied string
+				j := newTestJSONView(t).SetOnCopy(func(path string) terminus.Cmd {
+					copied = path
+					return nil
+				})
+				press(j, terminus.KeyMsg{Type: terminus.KeyEnd}, runeKey('k'), runeKey('y'))
+
+				if copied != `$["odd key"]` {
+					t.Errorf("Expected bracketed path, got %s", copied)
+				}
+				if !strings.Contains(plain(j.View()), `Copied $["odd key"]`) {
+					t.Error("Expected copy notice on the status line")
+				}
+			},
+		},
+		{
+			name: "Rejects invalid JSON",
+			test: func(t *testing.T) {
+				if err := NewJSONView().SetJSON([]byte(`{"a": }`)); err == nil {
+					t.Error("Expected an error for malformed JSON")
+				}
+				if err := NewJSONView().SetJSON([]byte(`{} {}`)); err == nil {
+					t.Error("Expected an error for trailing data")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
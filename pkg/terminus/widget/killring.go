@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import "github.com/skaiser/terminusgo/pkg/terminus"
+
+// DefaultKillRingSize is how many entries a KillRing keeps by default
+const DefaultKillRingSize = 20
+
+// KillRing is a clipboard of the last pieces of text copied or cut in a
+// session, shared by its widgets and independent of the host clipboard,
+// which pages may be denied. A Table copies with y; a TextInput cuts with
+// Ctrl+K and Ctrl+U, pastes the newest entry with Ctrl+Y and then cycles
+// through older ones with Alt+Y, as in Emacs.
+//
+// Create one in the root component, which each session has its own of, and
+// pass it to the widgets with their SetKillRing. It is used from the update
+// loop and isn't safe for concurrent use.
+type KillRing struct {
+	entries []string // Oldest first
+	size    int
+}
+
+// NewKillRing creates a ring keeping the last size entries. Less than 1
+// keeps DefaultKillRingSize.
+func NewKillRing(size int) *KillRing {
+	if size < 1 {
+		size = DefaultKillRingSize
+	}
+	return &KillRing{size: size}
+}
+
+// Push adds text as the newest entry, dropping the oldest once the ring is
+// full. Empty text and a repeat of the newest entry are ignored.
+func (k *KillRing) Push(text string) {
+	if text == "" || (len(k.entries) > 0 && k.entries[len(k.entries)-1] == text) {
+		return
+	}
+	if len(k.entries) == k.size {
+		k.entries = append(k.entries[:0], k.entries[1:]...)
+	}
+	k.entries = append(k.entries, text)
+}
+
+// Len returns the number of entries
+func (k *KillRing) Len() int {
+	return len(k.entries)
+}
+
+// At returns the entry i steps back from the newest, which is 0, wrapping
+// around past the oldest. It returns "" when the ring is empty.
+func (k *KillRing) At(i int) string {
+	n := len(k.entries)
+	if n == 0 {
+		return ""
+	}
+	i = ((i % n) + n) % n
+	return k.entries[n-1-i]
+}
+
+// Entries returns the entries, newest first
+func (k *KillRing) Entries() []string {
+	entries := make([]string, len(k.entries))
+	for i := range entries {
+		entries[i] = k.At(i)
+	}
+	return entries
+}
+
+// killRingKeys are the keys a TextInput with a kill ring handles
+var killRingKeys = []terminus.KeyMsg{
+	{Type: terminus.KeyRunes, Runes: []rune("k"), Ctrl: true},
+	{Type: terminus.KeyRunes, Runes: []rune("u"), Ctrl: true},
+	{Type: terminus.KeyRunes, Runes: []rune("y"), Ctrl: true},
+	{Type: terminus.KeyRunes, Runes: []rune("y"), Alt: true},
+}
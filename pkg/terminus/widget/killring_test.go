@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestKillRing(t *testing.T) {
+	ctrl := func(r rune) terminus.KeyMsg {
+		return terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{r}, Ctrl: true}
+	}
+	alt := func(r rune) terminus.KeyMsg {
+		return terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{r}, Alt: true}
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Keeps the newest entries first",
+			test: func(t *testing.T) {
+				ring := NewKillRing(2)
+				for _, text := range []string{"a", "", "b", "b", "c"} {
+					ring.Push(text)
+				}
+				if got := ring.Entries(); !reflect.DeepEqual(got, []string{"c", "b"}) {
+					t.Errorf("Expected [c b], got %q", got)
+				}
+				if ring.At(2) != "c" {
+					t.Errorf("Expected indices to wrap, got %q", ring.At(2))
+				}
+			},
+		},
+		{
+			name: "Cuts and yanks in a text input",
+			test: func(t *testing.T) {
+				ring := NewKillRing(DefaultKillRingSize)
+				input := NewTextInput().SetKillRing(ring)
+				input.Focus()
+				input.SetValue("hello world")
+				input.SetCursor(5)
+
+				input.Update(ctrl('k'))
+				if input.Value() != "hello" || ring.At(0) != " world" {
+					t.Fatalf("Expected the end cut, got %q and %q", input.Value(), ring.At(0))
+				}
+				input.Update(ctrl('u'))
+				input.Update(ctrl('y'))
+				if input.Value() != "hello" {
+					t.Errorf("Expected the start pasted back, got %q", input.Value())
+				}
+				input.Update(alt('y'))
+				if input.Value() != " world" {
+					t.Errorf("Expected the older entry, got %q", input.Value())
+				}
+				input.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				input.Update(alt('y'))
+				if input.Value() != " world" {
+					t.Errorf("Expected Alt+Y to need a yank first, got %q", input.Value())
+				}
+			},
+		},
+		{
+			name: "Copies table rows and cells",
+			test: func(t *testing.T) {
+				ring := NewKillRing(DefaultKillRingSize)
+				table := NewTable().SetKillRing(ring)
+				table.SetStringData([]string{"Name", "Role"}, [][]string{{"ada", "admin"}, {"bob", "dev"}})
+				table.Focus()
+
+				table.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				table.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("y")})
+				if ring.At(0) != "bob\tdev" {
+					t.Errorf("Expected the row, got %q", ring.At(0))
+				}
+				table.SetCellSelection(true)
+				table.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("y")})
+				if ring.At(0) != "bob" {
+					t.Errorf("Expected the cell, got %q", ring.At(0))
+				}
+
+				input := NewTextInput().SetKillRing(ring)
+				input.Focus()
+				input.Update(ctrl('y'))
+				input.Update(alt('y'))
+				if input.Value() != "bob dev" {
+					t.Errorf("Expected the row pasted with spaces, got %q", input.Value())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// KPI shows a key metric as a large number with its change and a trend
+// sparkline:
+//
+//	Requests/s
+//	╶─┐┌─┐┌─┐
+//	 ─┤├─┤│ │
+//	╶─┘└─┘└─┘
+//	▲ 12.5 vs last hour
+//	▁▂▂▃▅▄▆▇█
+type KPI struct {
+	Model
+
+	// Data
+	label      string
+	value      float64
+	delta      float64
+	hasDelta   bool
+	deltaLabel string
+	trend      []float64
+
+	// Configuration
+	format        string
+	deltaFormat   string
+	big           bool
+	lowerIsBetter bool
+
+	// Styling
+	labelStyle terminus.Style
+	valueStyle terminus.Style
+	upStyle    terminus.Style
+	downStyle  terminus.Style
+	flatStyle  terminus.Style
+	trendStyle terminus.Style
+}
+
+// NewKPI creates a KPI display
+func NewKPI() *KPI {
+	m := NewModel()
+	m.width = 20
+	return &KPI{
+		Model:       m,
+		format:      "%.0f",
+		deltaFormat: "%.1f",
+		big:         true,
+		labelStyle:  terminus.NewStyle().Faint(true),
+		valueStyle:  terminus.NewStyle().Bold(true),
+		upStyle:     terminus.NewStyle().Foreground(terminus.Green),
+		downStyle:   terminus.NewStyle().Foreground(terminus.Red),
+		flatStyle:   terminus.NewStyle().Faint(true),
+		trendStyle:  terminus.NewStyle().Foreground(terminus.Cyan),
+	}
+}
+
+// SetLabel sets the metric name shown above the value
+func (k *KPI) SetLabel(label string) *KPI {
+	k.label = label
+	return k
+}
+
+// SetValue sets the current value
+func (k *KPI) SetValue(value float64) *KPI {
+	k.value = value
+	return k
+}
+
+// SetFormat sets the fmt format of the value, e.g. "%.1f%%"
+func (k *KPI) SetFormat(format string) *KPI {
+	k.format = format
+	return k
+}
+
+// SetDelta sets the change shown under the value. Without it the change
+// between the last two trend points is shown.
+func (k *KPI) SetDelta(delta float64) *KPI {
+	k.delta, k.hasDelta = delta, true
+	return k
+}
+
+// SetDeltaFormat sets the fmt format of the change, e.g. "%.1f%%"
+func (k *KPI) SetDeltaFormat(format string) *KPI {
+	k.deltaFormat = format
+	return k
+}
+
+// SetDeltaLabel sets text shown after the change, e.g. "vs last week"
+func (k *KPI) SetDeltaLabel(label string) *KPI {
+	k.deltaLabel = label
+	return k
+}
+
+// SetLowerIsBetter colors decreases green and increases red, for metrics
+// such as latency or error rate
+func (k *KPI) SetLowerIsBetter(lower bool) *KPI {
+	k.lowerIsBetter = lower
+	return k
+}
+
+// SetTrend sets the recent values drawn as a sparkline. Only the last
+// width values are shown.
+func (k *KPI) SetTrend(values []float64) *KPI {
+	k.trend = values
+	return k
+}
+
+// Push appends a value to the trend and makes it the current value
+func (k *KPI) Push(value float64) *KPI {
+	k.trend = append(k.trend, value)
+	if extra := len(k.trend) - k.width; extra > 0 && k.width > 0 {
+		k.trend = k.trend[extra:]
+	}
+	k.value = value
+	return k
+}
+
+// SetBig sets whether the value is drawn in large digits
+func (k *KPI) SetBig(big bool) *KPI {
+	k.big = big
+	return k
+}
+
+// SetLabelStyle sets the style of the label
+func (k *KPI) SetLabelStyle(style terminus.Style) *KPI {
+	k.labelStyle = style
+	return k
+}
+
+// SetValueStyle sets the style of the value
+func (k *KPI) SetValueStyle(style terminus.Style) *KPI {
+	k.valueStyle = style
+	return k
+}
+
+// SetTrendStyle sets the style of the sparkline
+func (k *KPI) SetTrendStyle(style terminus.Style) *KPI {
+	k.trendStyle = style
+	return k
+}
+
+// Init implements the Component interface
+func (k *KPI) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (k *KPI) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return k, nil
+}
+
+// View implements the Component interface
+func (k *KPI) View() string {
+	var lines []string
+	if k.label != "" {
+		lines = append(lines, k.labelStyle.Render(k.label))
+	}
+
+	value := fmt.Sprintf(k.format, k.value)
+	if k.big {
+		for _, line := range bigText(value) {
+			lines = append(lines, k.valueStyle.Render(line))
+		}
+	} else {
+		lines = append(lines, k.valueStyle.Render(value))
+	}
+
+	if delta, ok := k.currentDelta(); ok {
+		lines = append(lines, k.renderDelta(delta))
+	}
+
+	if len(k.trend) > 0 {
+		lines = append(lines, k.trendStyle.Render(sparkline(k.trend, k.width)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// currentDelta returns the explicit delta, or the last change in the trend
+func (k *KPI) currentDelta() (float64, bool) {
+	if k.hasDelta {
+		return k.delta, true
+	}
+	if n := len(k.trend); n >= 2 {
+		return k.trend[n-1] - k.trend[n-2], true
+	}
+	return 0, false
+}
+
+// renderDelta renders the change with an arrow colored by whether it is
+// an improvement
+func (k *KPI) renderDelta(delta float64) string {
+	arrow, style := "▶", k.flatStyle
+	switch {
+	case delta > 0:
+		arrow, style = "▲", k.upStyle
+		if k.lowerIsBetter {
+			style = k.downStyle
+		}
+	case delta < 0:
+		arrow, style = "▼", k.downStyle
+		if k.lowerIsBetter {
+			style = k.upStyle
+		}
+	}
+
+	text := style.Render(arrow + " " + fmt.Sprintf(k.deltaFormat, math.Abs(delta)))
+	if k.deltaLabel != "" {
+		text += " " + k.flatStyle.Render(k.deltaLabel)
+	}
+	return text
+}
+
+// bigDigits is a three-line seven-segment font for numbers
+var bigDigits = map[rune][3]string{
+	'0': {"┌─┐", "│ │", "└─┘"},
+	'1': {"  ╷", "  │", "  ╵"},
+	'2': {"╶─┐", "┌─┘", "└─╴"},
+	'3': {"╶─┐", " ─┤", "╶─┘"},
+	'4': {"╷ ╷", "└─┤", "  ╵"},
+	'5': {"┌─╴", "└─┐", "╶─┘"},
+	'6': {"┌─╴", "├─┐", "└─┘"},
+	'7': {"╶─┐", "  │", "  ╵"},
+	'8': {"┌─┐", "├─┤", "└─┘"},
+	'9': {"┌─┐", "└─┤", "╶─┘"},
+	'-': {"   ", "╶─╴", "   "},
+	'+': {"   ", "╶┼╴", "   "},
+	'.': {" ", " ", "."},
+	',': {" ", " ", ","},
+	' ': {" ", " ", " "},
+}
+
+// bigText renders s in the three-line font. Characters the font lacks,
+// such as units, are written on the bottom line.
+func bigText(s string) [3]string {
+	var rows [3]strings.Builder
+	for _, r := range s {
+		glyph, ok := bigDigits[r]
+		if !ok {
+			glyph = [3]string{" ", " ", string(r)}
+		}
+		for i := range rows {
+			rows[i].WriteString(glyph[i])
+		}
+	}
+	return [3]string{rows[0].String(), rows[1].String(), rows[2].String()}
+}
+
+// sparkBars are the eighth-block characters used by sparkline
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the last width values as a row of bars scaled between
+// their minimum and maximum
+func sparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		level := len(sparkBars) / 2
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkBars)-1))
+		}
+		bars[i] = sparkBars[level]
+	}
+	return string(bars)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestKPI(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Draws the value in large digits",
+			test: func(t *testing.T) {
+				k := NewKPI().SetLabel("Orders").SetValue(42)
+				lines := strings.Split(plain(k.View()), "\n")
+
+				expected := []string{"Orders", "╷ ╷╶─┐", "└─┤┌─┘", "  ╵└─╴"}
+				if len(lines) != len(expected) {
+					t.Fatalf("Expected %d lines, got %q", len(expected), lines)
+				}
+				for i, want := range expected {
+					if lines[i] != want {
+						t.Errorf("Line %d: expected %q, got %q", i, want, lines[i])
+					}
+				}
+
+				k.SetBig(false).SetFormat("%.1f%%")
+				if !strings.Contains(plain(k.View()), "42.0%") {
+					t.Errorf("Expected formatted value, got %q", plain(k.View()))
+				}
+			},
+		},
+		{
+			name: "Colors the delta by direction",
+			test: func(t *testing.T) {
+				green := terminus.NewStyle().Foreground(terminus.Green)
+				red := terminus.NewStyle().Foreground(terminus.Red)
+
+				k := NewKPI().SetValue(10).SetDelta(2.5).SetDeltaLabel("vs yesterday")
+				if !strings.Contains(k.View(), green.Render("▲ 2.5")) {
+					t.Errorf("Expected a green up arrow, got %q", k.View())
+				}
+				if !strings.Contains(plain(k.View()), "vs yesterday") {
+					t.Error("Expected the delta label")
+				}
+
+				k.SetDelta(-1)
+				if !strings.Contains(k.View(), red.Render("▼ 1.0")) {
+					t.Errorf("Expected a red down arrow, got %q", k.View())
+				}
+
+				k.SetLowerIsBetter(true)
+				if !strings.Contains(k.View(), green.Render("▼ 1.0")) {
+					t.Error("Expected decreases in green when lower is better")
+				}
+
+				k.SetDelta(0)
+				if !strings.Contains(plain(k.View()), "▶ 0.0") {
+					t.Error("Expected a flat marker for no change")
+				}
+			},
+		},
+		{
+			name: "Draws the trend and derives the delta",
+			test: func(t *testing.T) {
+				k := NewKPI().SetBig(false).SetTrend([]float64{1, 2, 3, 4, 5, 6, 7, 8})
+				view := plain(k.View())
+
+				if !strings.Contains(view, "▁▂▃▄▅▆▇█") {
+					t.Errorf("Expected a rising sparkline, got %q", view)
+				}
+				if !strings.Contains(view, "▲ 1.0") {
+					t.Errorf("Expected the delta from the last two points, got %q", view)
+				}
+			},
+		},
+		{
+			name: "Keeps the trend within the width",
+			test: func(t *testing.T) {
+				k := NewKPI().SetBig(false)
+				k.SetSize(5, 4)
+				for i := 0; i < 8; i++ {
+					k.Push(float64(i))
+				}
+
+				if len(k.trend) != 5 || k.value != 7 {
+					t.Errorf("Expected the last 5 points and value 7, got %v and %v", k.trend, k.value)
+				}
+				if got := sparkline([]float64{3, 3, 3}, 10); got != "▅▅▅" {
+					t.Errorf("Expected a flat line for constant values, got %q", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Label is a widget that shows fixed text as it is, such as a help section
+// a parent shows and hides, or a view rendered elsewhere that is laid out
+// with Stack or Row
+type Label struct {
+	Model
+
+	text string
+}
+
+// NewLabel creates a widget showing text
+func NewLabel(text string) *Label {
+	return &Label{Model: NewModel(), text: text}
+}
+
+// SetText sets the text
+func (l *Label) SetText(text string) *Label {
+	l.text = text
+	return l
+}
+
+// Text returns the text
+func (l *Label) Text() string {
+	return l.text
+}
+
+// Measure returns the constraints set on the label, preferring the size
+// of its text where none is set
+func (l *Label) Measure() Constraints {
+	c := l.Model.Measure()
+	if l.hidden {
+		return c
+	}
+	lines := strings.Split(l.text, "\n")
+	if c.Preferred.Height == 0 {
+		c.Preferred.Height = len(lines)
+	}
+	if c.Preferred.Width == 0 {
+		for _, line := range lines {
+			c.Preferred.Width = max(c.Preferred.Width, visibleWidth(line))
+		}
+	}
+	return c
+}
+
+// Init implements the Component interface
+func (l *Label) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (l *Label) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return l, nil
+}
+
+// View implements the Component interface
+func (l *Label) View() string {
+	return l.text
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// lazyMountedMsg delivers the component a Lazy constructed
+type lazyMountedMsg struct {
+	lazy      *Lazy
+	component terminus.Component
+}
+
+// Lazy defers constructing a heavy child component, and running the
+// commands of its Init, until it is first shown, e.g. a dashboard tab that
+// loads data of its own. Show constructs the child in a command, off the
+// update loop; a placeholder is shown until it is ready. Pass Lazy all
+// messages, as any widget, and it passes them on to its child.
+type Lazy struct {
+	Model
+
+	factory     func() terminus.Component
+	child       terminus.Component
+	mounting    bool
+	placeholder terminus.Component
+}
+
+// NewLazy creates a Lazy child built by factory when first shown. Until then
+// it shows "Loading…", centered in its size.
+func NewLazy(factory func() terminus.Component) *Lazy {
+	return &Lazy{
+		Model:       NewModel(),
+		factory:     factory,
+		placeholder: NewEmptyState("Loading…").SetMessageStyle(terminus.NewStyle().Faint(true)),
+	}
+}
+
+// SetPlaceholder sets what is shown until the child is ready, e.g. a
+// Spinner, which receives messages meanwhile
+func (l *Lazy) SetPlaceholder(placeholder terminus.Component) *Lazy {
+	l.placeholder = placeholder
+	l.sizeWidget(placeholder)
+	return l
+}
+
+// Show shows the widget, if it was hidden, and returns a command that
+// constructs the child, the first time it is called; call it when the
+// child is first shown or focused, e.g. when its tab is selected. Later
+// calls return nil.
+func (l *Lazy) Show() terminus.Cmd {
+	l.Model.Show()
+	if l.child != nil || l.mounting {
+		return nil
+	}
+	l.mounting = true
+	return func() terminus.Msg {
+		return lazyMountedMsg{lazy: l, component: l.factory()}
+	}
+}
+
+// Mounted reports whether the child has been constructed
+func (l *Lazy) Mounted() bool {
+	return l.child != nil
+}
+
+// Component returns the child, or nil until it has been constructed
+func (l *Lazy) Component() terminus.Component {
+	return l.child
+}
+
+// SetSize sets the size of the widget and of its child and placeholder, if
+// they are widgets
+func (l *Lazy) SetSize(width, height int) {
+	l.Model.SetSize(width, height)
+	l.sizeWidget(l.placeholder)
+	l.sizeWidget(l.child)
+}
+
+// Focus focuses the widget and its child, if it is a widget
+func (l *Lazy) Focus() {
+	l.Model.Focus()
+	if w, ok := l.child.(Widget); ok {
+		w.Focus()
+	}
+}
+
+// Blur blurs the widget and its child, if it is a widget
+func (l *Lazy) Blur() {
+	l.Model.Blur()
+	if w, ok := l.child.(Widget); ok {
+		w.Blur()
+	}
+}
+
+// sizeWidget gives c the widget's size, if it is a widget
+func (l *Lazy) sizeWidget(c terminus.Component) {
+	if w, ok := c.(Widget); ok {
+		w.SetSize(l.width, l.height)
+	}
+}
+
+// Init implements the Component interface. The child is initialized when it
+// is constructed.
+func (l *Lazy) Init() terminus.Cmd {
+	if l.placeholder != nil {
+		return l.placeholder.Init()
+	}
+	return nil
+}
+
+// Update implements the Component interface
+func (l *Lazy) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if mounted, ok := msg.(lazyMountedMsg); ok {
+		if mounted.lazy != l {
+			return l, nil
+		}
+		l.mounting = false
+		l.child = mounted.component
+		l.sizeWidget(l.child)
+		if w, ok := l.child.(Widget); ok && l.focused {
+			w.Focus()
+		}
+		return l, l.child.Init()
+	}
+
+	var cmd terminus.Cmd
+	switch {
+	case l.child != nil:
+		l.child, cmd = l.child.Update(msg)
+	case l.placeholder != nil:
+		l.placeholder, cmd = l.placeholder.Update(msg)
+	}
+	return l, cmd
+}
+
+// View implements the Component interface
+func (l *Lazy) View() string {
+	return terminus.RenderView(l)
+}
+
+// RenderView writes the view of the child, or of the placeholder until the
+// child is ready, into ctx
+func (l *Lazy) RenderView(ctx *terminus.RenderContext) {
+	switch {
+	case l.child != nil:
+		ctx.WriteView(l.child)
+	case l.placeholder != nil:
+		ctx.WriteView(l.placeholder)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// loadedMsg is the data the lazy test child loads in Init
+type loadedMsg struct{}
+
+func TestLazy(t *testing.T) {
+	// newLazy returns a Lazy list that loads its items in Init, and how many
+	// times the list was built
+	newLazy := func() (*Lazy, *int) {
+		built := 0
+		lazy := NewLazy(func() terminus.Component {
+			built++
+			return &lazyChild{List: NewList()}
+		})
+		return lazy, &built
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Shows a placeholder until shown",
+			test: func(t *testing.T) {
+				lazy, built := newLazy()
+				lazy.SetSize(20, 3)
+				lazy.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+
+				if *built != 0 || lazy.Mounted() {
+					t.Error("Expected the child not to be built")
+				}
+				if !strings.Contains(lazy.View(), "Loading…") {
+					t.Errorf("Expected the placeholder, got %q", lazy.View())
+				}
+			},
+		},
+		{
+			name: "Builds and initializes the child once shown",
+			test: func(t *testing.T) {
+				lazy, built := newLazy()
+				lazy.SetSize(20, 3)
+				lazy.Focus()
+				cmd := lazy.Show()
+				if lazy.Show() != nil {
+					t.Error("Expected a single build")
+				}
+
+				_, initCmd := lazy.Update(cmd())
+				if *built != 1 || !lazy.Mounted() {
+					t.Fatal("Expected the child to be built")
+				}
+				child := lazy.Component().(*lazyChild)
+				if w, h := child.GetSize(); w != 20 || h != 3 || !child.Focused() {
+					t.Errorf("Expected the child to be sized and focused, got %dx%d and %v", w, h, child.Focused())
+				}
+
+				lazy.Update(initCmd())
+				if !strings.Contains(lazy.View(), "loaded") {
+					t.Errorf("Expected the child's view, got %q", lazy.View())
+				}
+			},
+		},
+		{
+			name: "Ignores children of other Lazies",
+			test: func(t *testing.T) {
+				lazy, _ := newLazy()
+				other, _ := newLazy()
+				lazy.Update(other.Show()())
+				if lazy.Mounted() {
+					t.Error("Expected the other child to be ignored")
+				}
+			},
+		},
+		{
+			name: "Uses a custom placeholder",
+			test: func(t *testing.T) {
+				lazy, _ := newLazy()
+				lazy.SetSize(30, 3)
+				lazy.SetPlaceholder(NewEmptyState("Fetching reports"))
+				if !strings.Contains(lazy.View(), "Fetching reports") {
+					t.Errorf("Expected the custom placeholder, got %q", lazy.View())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// lazyChild is a list whose items load in Init
+type lazyChild struct {
+	*List
+}
+
+func (c *lazyChild) Init() terminus.Cmd {
+	return func() terminus.Msg { return loadedMsg{} }
+}
+
+func (c *lazyChild) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if _, ok := msg.(loadedMsg); ok {
+		c.SetStringItems([]string{"loaded"})
+		return c, nil
+	}
+	_, cmd := c.List.Update(msg)
+	return c, cmd
+}
@@ -15,7 +15,10 @@
 package widget
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/skaiser/terminusgo/pkg/terminus"
 )
@@ -28,13 +31,35 @@ type ListItem interface {
 	String() string
 }
 
+// DirectionalListItem is a ListItem that knows the direction it is written
+// in, e.g. a chat message whose text, not its sender, decides it
+type DirectionalListItem interface {
+	ListItem
+	Direction() Direction
+}
+
+// SizedListItem is a ListItem that renders on more than one line. Height
+// reports how many lines Render produces at the given content width; items
+// that don't implement it occupy a single line.
+type SizedListItem interface {
+	ListItem
+	Height(width int) int
+}
+
 // SimpleListItem is a basic string-based list item
 type SimpleListItem struct {
 	text string
 }
 
-// NewSimpleListItem creates a new simple list item
+// NewSimpleListItem creates a new simple list item. The text is sanitized
+// with terminus.Sanitize, so it may come from users.
 func NewSimpleListItem(text string) *SimpleListItem {
+	return &SimpleListItem{text: terminus.Sanitize(text)}
+}
+
+// NewStyledListItem creates a list item from trusted text that is already
+// styled, which is shown as is
+func NewStyledListItem(text string) *SimpleListItem {
 	return &SimpleListItem{text: text}
 }
 
@@ -56,12 +81,15 @@ type List struct {
 	items        []ListItem
 	selectedIdx  int
 	scrollOffset int
+	count        countPrefix
 
 	// Display settings
 	showCursor      bool
 	cursorChar      string
 	selectedChar    string
 	unselectedChar  string
+	scrollbar       *Scrollbar // Replaces the ↑/↓ indicators when set
+	direction       Direction
 
 	// Styling
 	style              terminus.Style
@@ -70,18 +98,73 @@ type List struct {
 	selectedCursorStyle terminus.Style
 
 	// Behavior
-	wrap bool // Whether to wrap around at top/bottom
+	wrap        bool // Whether to wrap around at top/bottom
+	reorderable bool // Whether Ctrl+Up/Down moves the selected item
+
+	// Drag and drop
+	dragKind string // Kind of payload dragged from and dropped on the list, "" for none
+	dragged  []int  // Indices of the items being dragged
+
+	// Multi-select
+	multiSelect   bool
+	checked       []bool // parallel to items
+	checkedChar   string
+	uncheckedChar string
+	checkStyle    terminus.Style
+
+	// Grouping
+	groupBy     func(ListItem) string
+	groups      []listGroup // headers in the current view
+	collapsed   map[string]bool
+	headerStyle terminus.Style
+
+	// Load-more
+	reachEndThreshold int  // Rows from the end at which onReachEnd fires
+	hasMore           bool // False once the source is exhausted
+	loadingMore       bool
+	loadingText       string
+	loadingStyle      terminus.Style
+
+	empty *EmptyState // Shown instead of "No items" when set
 
 	// Events
-	onSelect func(int, ListItem) terminus.Cmd
-	onChange func(int, ListItem) terminus.Cmd
+	onSelect  func(int, ListItem) terminus.Cmd
+	onChange  func(int, ListItem) terminus.Cmd
+	onReorder func(from, to int) terminus.Cmd
+	onToggle  func(int, bool) terminus.Cmd
+
+	onReachEnd func() terminus.Cmd
+
+	// Highlighting
+	highlight      string
+	matches        []int // Filtered rows of items matching highlight
+	matchIdx       int
+	highlightStyle terminus.Style
 
 	// Filtering
 	filter         string
-	filteredItems  []int // indices of items that match filter
+	filterRe       *regexp.Regexp // Compiled filter, nil when unfiltered
+	filteredItems  []int // visible rows: indices of items that match filter, or headerRow values
 	filteredIdx    int   // selected index in filtered view
 }
 
+// listGroup is a section header shown above a run of items
+type listGroup struct {
+	name  string
+	count int // Items in the group that match the filter
+}
+
+// headerRow encodes group header k as a row in filteredItems. Item rows are
+// never negative, so headers are told apart by sign.
+func headerRow(k int) int {
+	return -(k + 1)
+}
+
+// headerIndex decodes a row created by headerRow
+func headerIndex(row int) int {
+	return -row - 1
+}
+
 // NewList creates a new list widget
 func NewList() *List {
 	return &List{
@@ -96,6 +179,16 @@ func NewList() *List {
 		cursorStyle:         terminus.NewStyle().Foreground(terminus.Cyan),
 		selectedCursorStyle: terminus.NewStyle().Foreground(terminus.Cyan).Bold(true),
 		wrap:                true,
+		checkedChar:         "[x] ",
+		uncheckedChar:       "[ ] ",
+		checkStyle:          terminus.NewStyle().Foreground(terminus.Green),
+		collapsed:           make(map[string]bool),
+		headerStyle:         terminus.NewStyle().Bold(true).Foreground(terminus.Magenta),
+		reachEndThreshold:   3,
+		hasMore:             true,
+		loadingText:         "loading more…",
+		loadingStyle:        terminus.NewStyle().Faint(true),
+		highlightStyle:      terminus.NewStyle().Reverse(true),
 		filteredItems:       make([]int, 0),
 	}
 }
@@ -103,6 +196,9 @@ func NewList() *List {
 // SetItems sets the list items
 func (l *List) SetItems(items []ListItem) *List {
 	l.items = items
+	l.checked = make([]bool, len(items))
+	l.filteredItems = l.filteredItems[:0]
+	l.loadingMore = false
 	l.selectedIdx = 0
 	l.scrollOffset = 0
 	l.updateFiltered()
@@ -112,11 +208,14 @@ func (l *List) SetItems(items []ListItem) *List {
 // AddItem adds a single item to the list
 func (l *List) AddItem(item ListItem) *List {
 	l.items = append(l.items, item)
+	l.checked = append(l.checked, false)
+	l.loadingMore = false
 	l.updateFiltered()
 	return l
 }
 
-// SetStringItems is a convenience method for setting string items
+// SetStringItems is a convenience method for setting string items. Items are
+// sanitized; use NewStyledListItem for styled items.
 func (l *List) SetStringItems(items []string) *List {
 	listItems := make([]ListItem, len(items))
 	for i, item := range items {
@@ -130,14 +229,18 @@ func (l *List) Items() []ListItem {
 	return l.items
 }
 
-// SelectedIndex returns the currently selected index in the full list
+// SelectedIndex returns the currently selected index in the full list, or
+// -1 when a group header is selected
 func (l *List) SelectedIndex() int {
-	if l.isFiltered() {
-		if l.filteredIdx >= 0 && l.filteredIdx < len(l.filteredItems) {
-			return l.filteredItems[l.filteredIdx]
+	if l.filteredIdx >= 0 && l.filteredIdx < len(l.filteredItems) {
+		if row := l.filteredItems[l.filteredIdx]; row >= 0 {
+			return row
 		}
 		return -1
 	}
+	if l.isFiltered() {
+		return -1
+	}
 	return l.selectedIdx
 }
 
@@ -156,23 +259,49 @@ func (l *List) SetSelected(index int) *List {
 		return l
 	}
 
-	if l.isFiltered() {
-		// Find the index in filtered view
-		for i, filteredIdx := range l.filteredItems {
-			if filteredIdx == index {
-				l.filteredIdx = i
-				break
-			}
+	// Find the row showing the item
+	l.selectedIdx = index
+	for i, row := range l.filteredItems {
+		if row == index {
+			l.filteredIdx = i
+			break
 		}
-	} else {
-		l.selectedIdx = index
-		l.filteredIdx = index // Keep them in sync when not filtered
 	}
 
 	l.updateScrollOffset()
 	return l
 }
 
+// KeyBindings returns the keys the list handles, for checking global
+// shortcuts against
+func (l *List) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("nNjk"+l.count.digits(), terminus.KeyUp, terminus.KeyDown, terminus.KeyHome, terminus.KeyEnd,
+		terminus.KeyPgUp, terminus.KeyPgDown, terminus.KeyEnter, terminus.KeySpace)
+}
+
+// SetCountPrefix sets whether digits typed before a key repeat it, vim
+// style: 5j or 5↓ moves down five items. It is on by default.
+func (l *List) SetCountPrefix(enabled bool) *List {
+	l.count.disabled = !enabled
+	return l
+}
+
+// ScrollState returns the selected item and the first row shown, for
+// ScrollStates
+func (l *List) ScrollState() ScrollState {
+	return ScrollState{Selected: l.SelectedIndex(), Top: l.scrollOffset}
+}
+
+// SetScrollState selects an item and scrolls to a row, keeping the
+// selection in view and both within the items the list has now
+func (l *List) SetScrollState(state ScrollState) {
+	l.scrollOffset = state.Top
+	if len(l.items) > 0 {
+		l.SetSelected(clampIndex(state.Selected, len(l.items)))
+	}
+	l.updateScrollOffset()
+}
+
 // SetCursorChar sets the cursor character
 func (l *List) SetCursorChar(char string) *List {
 	l.cursorChar = char
@@ -197,12 +326,115 @@ func (l *List) SetShowCursor(show bool) *List {
 	return l
 }
 
+// SetDirection sets the direction items are written in; right-to-left items
+// are aligned right. The default, DirectionAuto, takes each item's direction
+// from its first letter, or from the item if it is a DirectionalListItem.
+func (l *List) SetDirection(direction Direction) *List {
+	l.direction = direction
+	return l
+}
+
+// itemDirection returns the direction item is written in
+func (l *List) itemDirection(item ListItem) Direction {
+	if directional, ok := item.(DirectionalListItem); ok && l.direction == DirectionAuto {
+		return directional.Direction().resolve(item.String())
+	}
+	return l.direction.resolve(item.String())
+}
+
 // SetWrap sets whether to wrap around at top/bottom
 func (l *List) SetWrap(wrap bool) *List {
 	l.wrap = wrap
 	return l
 }
 
+// SetScrollbar shows a vertical scrollbar in the last column instead of the
+// ↑/↓ indicators. Pass nil to go back to the indicators.
+func (l *List) SetScrollbar(scrollbar *Scrollbar) *List {
+	l.scrollbar = scrollbar
+	return l
+}
+
+// SetReorderable sets whether Ctrl+Up/Down moves the selected item
+func (l *List) SetReorderable(reorderable bool) *List {
+	l.reorderable = reorderable
+	return l
+}
+
+// SetMultiSelect sets whether items show a checkbox that Space toggles
+func (l *List) SetMultiSelect(multiSelect bool) *List {
+	l.multiSelect = multiSelect
+	return l
+}
+
+// SetCheckChars sets the checkbox gutter drawn for checked and unchecked
+// items in multi-select mode
+func (l *List) SetCheckChars(checked, unchecked string) *List {
+	l.checkedChar = checked
+	l.uncheckedChar = unchecked
+	return l
+}
+
+// SetCheckStyle sets the style of the checkbox gutter
+func (l *List) SetCheckStyle(style terminus.Style) *List {
+	l.checkStyle = style
+	return l
+}
+
+// SetGroupBy groups items under section headers named by fn. Consecutive
+// items with the same group share a header, so items should be sorted by
+// group. The header of the section being scrolled through stays pinned to
+// the top line. Pass nil to disable grouping.
+func (l *List) SetGroupBy(fn func(ListItem) string) *List {
+	l.groupBy = fn
+	l.updateFiltered()
+	return l
+}
+
+// SetHeaderStyle sets the style of group headers
+func (l *List) SetHeaderStyle(style terminus.Style) *List {
+	l.headerStyle = style
+	return l
+}
+
+// CollapseGroup hides the items of a group, leaving its header
+func (l *List) CollapseGroup(name string) *List {
+	l.collapsed[name] = true
+	l.updateFiltered()
+	return l
+}
+
+// ExpandGroup shows the items of a collapsed group
+func (l *List) ExpandGroup(name string) *List {
+	delete(l.collapsed, name)
+	l.updateFiltered()
+	return l
+}
+
+// ToggleGroup collapses or expands a group
+func (l *List) ToggleGroup(name string) *List {
+	if l.collapsed[name] {
+		return l.ExpandGroup(name)
+	}
+	return l.CollapseGroup(name)
+}
+
+// IsCollapsed returns whether a group is collapsed
+func (l *List) IsCollapsed(name string) bool {
+	return l.collapsed[name]
+}
+
+// SelectedGroup returns the group of the selected header or item
+func (l *List) SelectedGroup() string {
+	if l.groupBy == nil || l.filteredIdx < 0 || l.filteredIdx >= len(l.filteredItems) {
+		return ""
+	}
+	if row := l.filteredItems[l.filteredIdx]; row >= 0 {
+		return l.groupBy(l.items[row])
+	}
+	return l.groups[headerIndex(l.filteredItems[l.filteredIdx])].name
+}
+
 // SetStyle sets the default style
 func (l *List) SetStyle(style terminus.Style) *List {
 	l.style = style
@@ -239,9 +471,377 @@ func (l *List) SetOnChange(callback func(int, ListItem) terminus.Cmd) *List {
 	return l
 }
 
-// SetFilter sets a filter string for the list
+// SetOnReorder sets the callback triggered when the user moves an item with
+// Ctrl+Up/Down. Indices refer to the full item list.
+func (l *List) SetOnReorder(callback func(from, to int) terminus.Cmd) *List {
+	l.onReorder = callback
+	return l
+}
+
+// SetOnToggle sets the callback triggered when the user checks or unchecks
+// an item with Space in multi-select mode
+func (l *List) SetOnToggle(callback func(int, bool) terminus.Cmd) *List {
+	l.onToggle = callback
+	return l
+}
+
+// SetOnReachEnd sets the callback triggered when the selection comes within
+// the reach-end threshold of the last item, so the next page can be fetched.
+// The list then shows a loading footer and doesn't call back again until
+// items are added or SetLoading(false) is called.
+func (l *List) SetOnReachEnd(callback func() terminus.Cmd) *List {
+	l.onReachEnd = callback
+	return l
+}
+
+// SetReachEndThreshold sets how many items from the end OnReachEnd fires
+func (l *List) SetReachEndThreshold(n int) *List {
+	if n < 0 {
+		n = 0
+	}
+	l.reachEndThreshold = n
+	return l
+}
+
+// SetHasMore sets whether more items can be loaded. OnReachEnd stops firing
+// once it is false.
+func (l *List) SetHasMore(hasMore bool) *List {
+	l.hasMore = hasMore
+	if !hasMore {
+		l.SetLoading(false)
+	}
+	return l
+}
+
+// SetLoading shows or hides the loading footer. Clearing it after a failed
+// fetch lets OnReachEnd fire again.
+func (l *List) SetLoading(loading bool) *List {
+	l.loadingMore = loading
+	l.updateScrollOffset()
+	return l
+}
+
+// Loading returns whether the list is waiting for more items
+func (l *List) Loading() bool {
+	return l.loadingMore
+}
+
+// SetLoadingText sets the text of the loading footer
+func (l *List) SetLoadingText(text string) *List {
+	l.loadingText = text
+	return l
+}
+
+// SetLoadingStyle sets the style of the loading footer
+func (l *List) SetLoadingStyle(style terminus.Style) *List {
+	l.loadingStyle = style
+	return l
+}
+
+// SetEmptyState sets what the list shows when it has no items, sized to
+// the list. Nil shows "No items".
+func (l *List) SetEmptyState(empty *EmptyState) *List {
+	l.empty = empty
+	return l
+}
+
+// SetHighlightStyle sets the style of highlighted matches
+func (l *List) SetHighlightStyle(style terminus.Style) *List {
+	l.highlightStyle = style
+	return l
+}
+
+// Highlight implements Highlighter. Items match by their String().
+func (l *List) Highlight(term string) int {
+	l.highlight = term
+	l.findMatches()
+	if len(l.matches) > 0 {
+		l.showMatch()
+	}
+	return len(l.matches)
+}
+
+// MatchCount implements Highlighter
+func (l *List) MatchCount() int {
+	return len(l.matches)
+}
+
+// MatchIndex implements Highlighter
+func (l *List) MatchIndex() int {
+	return l.matchIdx
+}
+
+// StepMatch implements Highlighter
+func (l *List) StepMatch(delta int) {
+	if len(l.matches) > 0 {
+		l.matchIdx = stepIndex(l.matchIdx, delta, len(l.matches))
+		l.showMatch()
+	}
+}
+
+// findMatches collects the visible rows whose items match the highlight
+func (l *List) findMatches() {
+	l.matches = l.matches[:0]
+	l.matchIdx = 0
+	if l.highlight == "" {
+		return
+	}
+	for i, row := range l.filteredItems {
+		if row >= 0 && len(matchColumns(l.items[row].String(), l.highlight)) > 0 {
+			l.matches = append(l.matches, i)
+		}
+	}
+}
+
+// showMatch selects the current match
+func (l *List) showMatch() {
+	l.filteredIdx = l.matches[l.matchIdx]
+	l.syncSelected()
+	l.updateScrollOffset()
+}
+
+// checkReachEnd fires OnReachEnd if the selection is near the last row
+func (l *List) checkReachEnd() terminus.Cmd {
+	if l.onReachEnd == nil || !l.hasMore || l.loadingMore {
+		return nil
+	}
+	if l.filteredIdx < len(l.filteredItems)-1-l.reachEndThreshold {
+		return nil
+	}
+	l.SetLoading(true)
+	return l.onReachEnd()
+}
+
+// SetChecked checks or unchecks the item at index
+func (l *List) SetChecked(index int, checked bool) *List {
+	if index >= 0 && index < len(l.checked) {
+		l.checked[index] = checked
+	}
+	return l
+}
+
+// IsChecked returns whether the item at index is checked
+func (l *List) IsChecked(index int) bool {
+	return index >= 0 && index < len(l.checked) && l.checked[index]
+}
+
+// SelectAll checks every visible item
+func (l *List) SelectAll() *List {
+	for _, row := range l.filteredItems {
+		if row >= 0 {
+			l.checked[row] = true
+		}
+	}
+	return l
+}
+
+// ClearSelection unchecks every item
+func (l *List) ClearSelection() *List {
+	for i := range l.checked {
+		l.checked[i] = false
+	}
+	return l
+}
+
+// AllSelected returns whether every visible item is checked. It is false
+// when no items are visible.
+func (l *List) AllSelected() bool {
+	visible := false
+	for _, row := range l.filteredItems {
+		if row < 0 {
+			continue
+		}
+		if !l.checked[row] {
+			return false
+		}
+		visible = true
+	}
+	return visible
+}
+
+// SelectedIndices returns the indices of the checked items in list order
+func (l *List) SelectedIndices() []int {
+	indices := make([]int, 0)
+	for i, checked := range l.checked {
+		if checked {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SelectedItems returns the checked items in list order
+func (l *List) SelectedItems() []ListItem {
+	items := make([]ListItem, 0)
+	for _, i := range l.SelectedIndices() {
+		items = append(items, l.items[i])
+	}
+	return items
+}
+
+// MoveItem moves the item at index from to index to, shifting the items in
+// between. The selection follows the item it was on.
+func (l *List) MoveItem(from, to int) *List {
+	if from < 0 || from >= len(l.items) || to < 0 || to >= len(l.items) || from == to {
+		return l
+	}
+
+	selected := l.SelectedIndex()
+
+	item, checked := l.items[from], l.checked[from]
+	if from < to {
+		copy(l.items[from:to], l.items[from+1:to+1])
+		copy(l.checked[from:to], l.checked[from+1:to+1])
+	} else {
+		copy(l.items[to+1:from+1], l.items[to:from])
+		copy(l.checked[to+1:from+1], l.checked[to:from])
+	}
+	l.items[to], l.checked[to] = item, checked
+
+	// Track the selected item to its new position
+	switch {
+	case selected == from:
+		selected = to
+	case from < selected && selected <= to:
+		selected--
+	case to <= selected && selected < from:
+		selected++
+	}
+	if selected >= 0 {
+		l.selectedIdx = selected
+	}
+
+	l.updateFiltered()
+	return l
+}
+
+// SetDragKind lets the list's items be dragged to other lists, and
+// payloads of kind dropped on it, within a DragDrop. The checked items are
+// dragged, or the selected one when none are; dropped items go before the
+// selected one. An empty kind, the default, turns dragging off.
+func (l *List) SetDragKind(kind string) *List {
+	l.dragKind = kind
+	return l
+}
+
+// DragStart implements the DragSource interface
+func (l *List) DragStart() (Payload, bool) {
+	if l.dragKind == "" {
+		return Payload{}, false
+	}
+	l.dragged = l.SelectedIndices()
+	if len(l.dragged) == 0 {
+		if i := l.SelectedIndex(); i >= 0 && i < len(l.items) {
+			l.dragged = []int{i}
+		}
+	}
+	if len(l.dragged) == 0 {
+		return Payload{}, false
+	}
+
+	items := make([]any, len(l.dragged))
+	for i, index := range l.dragged {
+		items[i] = l.items[index]
+	}
+	return Payload{Kind: l.dragKind, Items: items}, true
+}
+
+// DragEnd implements the DragSource interface. Dropped items are removed
+// from the list.
+func (l *List) DragEnd(payload Payload, dropped bool) {
+	dragged := l.dragged
+	l.dragged = nil
+	if !dropped {
+		return
+	}
+
+	remove := make(map[int]bool, len(dragged))
+	for _, i := range dragged {
+		remove[i] = true
+	}
+	selected := l.SelectedIndex()
+	items, checked := l.items[:0], l.checked[:0]
+	for i, item := range l.items {
+		if remove[i] {
+			if i < selected {
+				selected--
+			}
+			continue
+		}
+		items = append(items, item)
+		checked = append(checked, l.checked[i])
+	}
+	clear(l.items[len(items):])
+	l.items, l.checked = items, checked
+	l.selectedIdx = max(0, min(selected, len(l.items)-1))
+	l.updateFiltered()
+}
+
+// CanDrop implements the DropTarget interface
+func (l *List) CanDrop(payload Payload) bool {
+	if l.dragKind == "" || payload.Kind != l.dragKind {
+		return false
+	}
+	for _, item := range payload.Items {
+		if _, ok := item.(ListItem); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Drop implements the DropTarget interface. The dropped items are inserted
+// before the selected item, or at the end, and the first is selected.
+func (l *List) Drop(payload Payload) terminus.Cmd {
+	at := l.SelectedIndex()
+	if at < 0 || at >= len(l.items) {
+		at = len(l.items)
+	}
+	dropped := make([]ListItem, len(payload.Items))
+	for i, item := range payload.Items {
+		dropped[i] = item.(ListItem)
+	}
+	l.items = append(l.items[:at], append(dropped, l.items[at:]...)...)
+	l.checked = append(l.checked[:at], append(make([]bool, len(dropped)), l.checked[at:]...)...)
+	l.selectedIdx = at
+	l.updateFiltered()
+	l.SetSelected(at)
+	return nil
+}
+
+// moveSelected moves the selected item past its visible neighbour in the
+// given direction and returns its old and new indices. Items don't move
+// past group headers.
+func (l *List) moveSelected(delta int) (from, to int, ok bool) {
+	target := l.filteredIdx + delta
+	if l.filteredIdx < 0 || l.filteredIdx >= len(l.filteredItems) || target < 0 || target >= len(l.filteredItems) {
+		return 0, 0, false
+	}
+	from = l.filteredItems[l.filteredIdx]
+	to = l.filteredItems[target]
+	if from < 0 || to < 0 {
+		return 0, 0, false
+	}
+	l.MoveItem(from, to)
+	return from, to, true
+}
+
+// SetFilter sets a filter string for the list. Items match ignoring case;
+// a filter wrapped in slashes, like /^todo:/, is a regular expression.
 func (l *List) SetFilter(filter string) *List {
 	l.filter = filter
+	l.filterRe = compileFilter(filter)
+	l.updateFiltered()
+	return l
+}
+
+// SetFilterRegexp filters the list to items matching re. Pass nil to clear
+// the filter.
+func (l *List) SetFilterRegexp(re *regexp.Regexp) *List {
+	l.filter, l.filterRe = "", re
+	if re != nil {
+		l.filter = "/" + re.String() + "/"
+	}
 	l.updateFiltered()
 	return l
 }
@@ -253,49 +853,77 @@ func (l *List) Filter() string {
 
 // isFiltered returns whether filtering is active
 func (l *List) isFiltered() bool {
-	return l.filter != ""
+	return l.filterRe != nil
 }
 
-// updateFiltered updates the filtered items list
+// updateFiltered rebuilds the visible rows from the filter and groups
 func (l *List) updateFiltered() {
+	// Remember a selected header so it stays selected as its group toggles
+	selectedHeader, onHeader := "", false
+	if l.filteredIdx >= 0 && l.filteredIdx < len(l.filteredItems) {
+		if row := l.filteredItems[l.filteredIdx]; row < 0 {
+			selectedHeader, onHeader = l.groups[headerIndex(row)].name, true
+		}
+	}
+
 	l.filteredItems = l.filteredItems[:0] // Clear slice but keep capacity
+	l.groups = l.groups[:0]
 
-	if !l.isFiltered() {
-		// No filter, show all items
-		for i := range l.items {
-			l.filteredItems = append(l.filteredItems, i)
+	hiddenSelection := -1 // header row standing in for a collapsed selection
+	for i, item := range l.items {
+		if l.isFiltered() && !l.filterRe.MatchString(item.String()) {
+			continue
 		}
-		l.filteredIdx = l.selectedIdx
-	} else {
-		// Apply filter
-		filter := strings.ToLower(l.filter)
-		currentSelected := -1
-		if l.selectedIdx >= 0 && l.selectedIdx < len(l.items) {
-			currentSelected = l.selectedIdx
-		}
-		
-		for i, item := range l.items {
-			if strings.Contains(strings.ToLower(item.String()), filter) {
-				l.filteredItems = append(l.filteredItems, i)
+
+		if l.groupBy != nil {
+			// Start a new section whenever the group changes
+			name := l.groupBy(item)
+			if len(l.groups) == 0 || l.groups[len(l.groups)-1].name != name {
+				l.groups = append(l.groups, listGroup{name: name})
+				l.filteredItems = append(l.filteredItems, headerRow(len(l.groups)-1))
 			}
-		}
-		
-		// Try to preserve selection, otherwise reset to first item
-		l.filteredIdx = 0
-		if currentSelected >= 0 {
-			for i, filteredIdx := range l.filteredItems {
-				if filteredIdx == currentSelected {
-					l.filteredIdx = i
-					break
+			l.groups[len(l.groups)-1].count++
+
+			if l.collapsed[name] {
+				if i == l.selectedIdx {
+					hiddenSelection = len(l.filteredItems) - 1
 				}
+				continue
 			}
 		}
+
+		l.filteredItems = append(l.filteredItems, i)
 	}
 
+	// Try to preserve selection, otherwise reset to first row
+	l.filteredIdx = 0
+	if hiddenSelection >= 0 && !onHeader {
+		l.filteredIdx = hiddenSelection
+	}
+	for i, row := range l.filteredItems {
+		if onHeader && row < 0 && l.groups[headerIndex(row)].name == selectedHeader ||
+			!onHeader && row == l.selectedIdx {
+			l.filteredIdx = i
+			break
+		}
+	}
+
+	l.findMatches()
 	l.updateScrollOffset()
 }
 
-// updateScrollOffset updates the scroll offset based on selection
+// syncSelected points selectedIdx at the item under the cursor, so the
+// selection survives changes to the filter
+func (l *List) syncSelected() {
+	if l.filteredIdx >= 0 && l.filteredIdx < len(l.filteredItems) {
+		if row := l.filteredItems[l.filteredIdx]; row >= 0 {
+			l.selectedIdx = row
+		}
+	}
+}
+
+// updateScrollOffset updates the scroll offset based on selection. The
+// window is measured in lines, so it holds fewer items when they are tall.
 func (l *List) updateScrollOffset() {
 	if len(l.filteredItems) == 0 {
 		l.scrollOffset = 0
@@ -305,16 +933,13 @@ func (l *List) updateScrollOffset() {
 	currentIdx := l.filteredIdx
 	if currentIdx < l.scrollOffset {
 		l.scrollOffset = currentIdx
-	} else if currentIdx >= l.scrollOffset+l.height {
-		l.scrollOffset = currentIdx - l.height + 1
+	} else if first := l.firstFitting(currentIdx); first > l.scrollOffset {
+		// Scroll down just far enough for the selection to fit
+		l.scrollOffset = first
 	}
 
 	// Ensure scroll offset is valid
-	maxScroll := len(l.filteredItems) - l.height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if l.scrollOffset > maxScroll {
+	if maxScroll := l.maxScrollOffset(); l.scrollOffset > maxScroll {
 		l.scrollOffset = maxScroll
 	}
 	if l.scrollOffset < 0 {
@@ -322,6 +947,87 @@ func (l *List) updateScrollOffset() {
 	}
 }
 
+// gutterWidth returns the width of the marker and checkbox before item content
+func (l *List) gutterWidth() int {
+	width := utf8.RuneCountInString(l.cursorChar)
+	if l.multiSelect {
+		width += utf8.RuneCountInString(l.checkedChar)
+	}
+	return width
+}
+
+// contentWidth returns the width available to item content after the gutter
+// and before the scrollbar
+func (l *List) contentWidth() int {
+	width := l.width - l.gutterWidth()
+	if l.scrollbar != nil {
+		width--
+	}
+	if width < 1 {
+		width = 1
+	}
+	return width
+}
+
+// itemHeight returns the number of lines the row at i occupies
+func (l *List) itemHeight(i int) int {
+	row := l.filteredItems[i]
+	if row < 0 {
+		return 1
+	}
+	if sized, ok := l.items[row].(SizedListItem); ok {
+		if h := sized.Height(l.contentWidth()); h > 0 {
+			return h
+		}
+	}
+	return 1
+}
+
+// firstFitting returns the lowest filtered index from which the window can
+// show every line up to and including the item at last
+func (l *List) firstFitting(last int) int {
+	first := last
+	lines := l.itemHeight(last)
+	for first > 0 {
+		h := l.itemHeight(first - 1)
+		if lines+h > l.rowLines() {
+			break
+		}
+		lines += h
+		first--
+	}
+	return first
+}
+
+// rowLines returns the lines available to rows. Grouped lists keep the top
+// line free for the sticky header, and loading lists the bottom line for
+// the footer.
+func (l *List) rowLines() int {
+	lines := l.contentLines()
+	if l.groupBy != nil && lines > 1 {
+		lines--
+	}
+	return lines
+}
+
+// contentLines returns the lines available above the loading footer
+func (l *List) contentLines() int {
+	if l.loadingMore && l.height > 1 {
+		return l.height - 1
+	}
+	return l.height
+}
+
+// maxScrollOffset returns the largest scroll offset that keeps the window
+// filled down to the last item
+func (l *List) maxScrollOffset() int {
+	n := len(l.filteredItems)
+	if n == 0 {
+		return 0
+	}
+	return l.firstFitting(n - 1)
+}
+
 // Init implements the Component interface
 func (l *List) Init() terminus.Cmd {
 	return nil
@@ -337,15 +1043,40 @@ func (l *List) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 
 	switch msg := msg.(type) {
 	case terminus.KeyMsg:
+		if l.count.feed(msg) {
+			return l, nil
+		}
+		count := max(l.count.take(), 1)
+		msg = motion(msg)
+
+		if l.handleGroupKey(msg) {
+			return l, nil
+		}
+
+		if l.reorderable && msg.Ctrl && (msg.Type == terminus.KeyUp || msg.Type == terminus.KeyDown) {
+			delta := 1
+			if msg.Type == terminus.KeyUp {
+				delta = -1
+			}
+			if from, to, ok := l.moveSelected(delta); ok && l.onReorder != nil {
+				cmd = l.onReorder(from, to)
+			}
+			return l, cmd
+		}
+
 		switch msg.Type {
 		case terminus.KeyUp:
-			l.moveUp()
+			for i := 0; i < count; i++ {
+				l.moveUp()
+			}
 			if l.onChange != nil {
 				cmd = l.onChange(l.SelectedIndex(), l.SelectedItem())
 			}
 
 		case terminus.KeyDown:
-			l.moveDown()
+			for i := 0; i < count; i++ {
+				l.moveDown()
+			}
 			if l.onChange != nil {
 				cmd = l.onChange(l.SelectedIndex(), l.SelectedItem())
 			}
@@ -363,13 +1094,17 @@ func (l *List) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 			}
 
 		case terminus.KeyPgUp:
-			l.movePageUp()
+			for i := 0; i < count; i++ {
+				l.movePageUp()
+			}
 			if l.onChange != nil {
 				cmd = l.onChange(l.SelectedIndex(), l.SelectedItem())
 			}
 
 		case terminus.KeyPgDown:
-			l.movePageDown()
+			for i := 0; i < count; i++ {
+				l.movePageDown()
+			}
 			if l.onChange != nil {
 				cmd = l.onChange(l.SelectedIndex(), l.SelectedItem())
 			}
@@ -378,12 +1113,69 @@ func (l *List) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 			if l.onSelect != nil {
 				cmd = l.onSelect(l.SelectedIndex(), l.SelectedItem())
 			}
+			return l, cmd
+
+		case terminus.KeyRunes:
+			// Step through highlighted matches
+			if len(l.matches) > 0 && len(msg.Runes) == 1 && (msg.Runes[0] == 'n' || msg.Runes[0] == 'N') {
+				if msg.Runes[0] == 'n' {
+					l.StepMatch(1)
+				} else {
+					l.StepMatch(-1)
+				}
+				if l.onChange != nil {
+					cmd = l.onChange(l.SelectedIndex(), l.SelectedItem())
+				}
+			}
+			return l, cmd
+
+		case terminus.KeySpace:
+			if idx := l.SelectedIndex(); l.multiSelect && idx >= 0 && idx < len(l.items) {
+				l.checked[idx] = !l.checked[idx]
+				if l.onToggle != nil {
+					cmd = l.onToggle(idx, l.checked[idx])
+				}
+			}
+			return l, cmd
+		}
+
+		// Moving towards the end may need the next page
+		if msg.Type == terminus.KeyDown || msg.Type == terminus.KeyPgDown || msg.Type == terminus.KeyEnd {
+			if more := l.checkReachEnd(); more != nil {
+				if cmd != nil {
+					cmd = terminus.Batch(cmd, more)
+				} else {
+					cmd = more
+				}
+			}
 		}
 	}
 
 	return l, cmd
 }
 
+// handleGroupKey collapses and expands groups. Enter and Space toggle the
+// selected header, Left collapses the selected group and Right expands it.
+func (l *List) handleGroupKey(msg terminus.KeyMsg) bool {
+	if l.groupBy == nil || len(l.filteredItems) == 0 {
+		return false
+	}
+	group := l.SelectedGroup()
+	onHeader := l.SelectedIndex() < 0
+
+	switch {
+	case msg.Type == terminus.KeyLeft:
+		l.CollapseGroup(group)
+	case msg.Type == terminus.KeyRight && onHeader:
+		l.ExpandGroup(group)
+	case (msg.Type == terminus.KeyEnter || msg.Type == terminus.KeySpace) && onHeader:
+		l.ToggleGroup(group)
+	default:
+		return false
+	}
+	return true
+}
+
 // moveUp moves selection up one item
 func (l *List) moveUp() {
 	if len(l.filteredItems) == 0 {
@@ -396,9 +1188,7 @@ func (l *List) moveUp() {
 		l.filteredIdx = len(l.filteredItems) - 1
 	}
 
-	if !l.isFiltered() {
-		l.selectedIdx = l.filteredIdx
-	}
+	l.syncSelected()
 
 	l.updateScrollOffset()
 }
@@ -415,9 +1205,7 @@ func (l *List) moveDown() {
 		l.filteredIdx = 0
 	}
 
-	if !l.isFiltered() {
-		l.selectedIdx = l.filteredIdx
-	}
+	l.syncSelected()
 
 	l.updateScrollOffset()
 }
@@ -429,9 +1217,7 @@ func (l *List) moveToFirst() {
 	}
 
 	l.filteredIdx = 0
-	if !l.isFiltered() {
-		l.selectedIdx = 0
-	}
+	l.syncSelected()
 	l.updateScrollOffset()
 }
 
@@ -442,9 +1228,7 @@ func (l *List) moveToLast() {
 	}
 
 	l.filteredIdx = len(l.filteredItems) - 1
-	if !l.isFiltered() {
-		l.selectedIdx = l.filteredIdx
-	}
+	l.syncSelected()
 	l.updateScrollOffset()
 }
 
@@ -454,14 +1238,17 @@ func (l *List) movePageUp() {
 		return
 	}
 
-	l.filteredIdx -= l.height
-	if l.filteredIdx < 0 {
-		l.filteredIdx = 0
+	// Move back by as many items as fill one window
+	lines := 0
+	for l.filteredIdx > 0 {
+		lines += l.itemHeight(l.filteredIdx - 1)
+		if lines > l.height {
+			break
+		}
+		l.filteredIdx--
 	}
 
-	if !l.isFiltered() {
-		l.selectedIdx = l.filteredIdx
-	}
+	l.syncSelected()
 
 	l.updateScrollOffset()
 }
@@ -472,14 +1259,17 @@ func (l *List) movePageDown() {
 		return
 	}
 
-	l.filteredIdx += l.height
-	if l.filteredIdx >= len(l.filteredItems) {
-		l.filteredIdx = len(l.filteredItems) - 1
+	// Move forward by as many items as fill one window
+	lines := 0
+	for l.filteredIdx < len(l.filteredItems)-1 {
+		lines += l.itemHeight(l.filteredIdx + 1)
+		if lines > l.height {
+			break
+		}
+		l.filteredIdx++
 	}
 
-	if !l.isFiltered() {
-		l.selectedIdx = l.filteredIdx
-	}
+	l.syncSelected()
 
 	l.updateScrollOffset()
 }
@@ -487,112 +1277,175 @@ func (l *List) movePageDown() {
 // View implements the Component interface
 func (l *List) View() string {
 	if len(l.filteredItems) == 0 {
+		if l.loadingMore {
+			return l.loadingStyle.Render(l.loadingText)
+		}
 		if l.isFiltered() {
 			return l.style.Render("No items match filter")
 		}
+		if l.empty != nil {
+			l.empty.SetSize(l.width, l.height)
+			return l.empty.View()
+		}
 		return l.style.Render("No items")
 	}
 
-	var result strings.Builder
+	// Pin the header of the section scrolled into, unless it's on top anyway
+	var lines []string
+	if header := l.stickyHeader(); header >= 0 {
+		lines = append(lines, l.renderHeader(header))
+	}
 
-	// Calculate visible range
-	start := l.scrollOffset
-	end := start + l.height
-	if end > len(l.filteredItems) {
-		end = len(l.filteredItems)
+	// Render only the items that fall inside the window
+	height := l.contentLines()
+	next := l.scrollOffset
+	for next < len(l.filteredItems) && len(lines) < height {
+		lines = append(lines, l.renderItem(next)...)
+		next++
+	}
+	canScrollDown := next < len(l.filteredItems) || len(lines) > height
+	if len(lines) > height {
+		lines = lines[:height]
 	}
 
-	// Render visible items
-	for i := start; i < end; i++ {
-		if i > start {
-			result.WriteString("\n")
+	if l.height > 0 {
+		// Pad to fill height
+		for len(lines) < height {
+			lines = append(lines, "")
 		}
 
-		itemIdx := l.filteredItems[i]
-		item := l.items[itemIdx]
-		isSelected := (i == l.filteredIdx)
-
-		// Build the line
-		var line strings.Builder
-
-		// Add cursor or marker
-		if l.showCursor && isSelected {
-			cursorStyle := l.cursorStyle
-			if isSelected {
-				cursorStyle = l.selectedCursorStyle
-			}
-			line.WriteString(cursorStyle.Render(l.cursorChar))
-		} else if isSelected {
-			line.WriteString(l.selectedChar)
+		// Add a scrollbar or the scroll indicators
+		if l.scrollbar != nil {
+			cells := l.scrollbar.Cells(height, len(l.filteredItems), next-l.scrollOffset, l.scrollOffset)
+			lines = attachScrollbar(lines, l.width-1, cells)
 		} else {
-			line.WriteString(l.unselectedChar)
+			if l.scrollOffset > 0 {
+				lines[0] = l.addScrollIndicator(lines[0], "↑")
+			}
+			if canScrollDown {
+				lines[len(lines)-1] = l.addScrollIndicator(lines[len(lines)-1], "↓")
+			}
 		}
+	}
 
-		// Add item content
-		itemText := item.Render()
-		if isSelected {
-			itemText = l.selectedStyle.Render(itemText)
-		} else {
-			itemText = l.style.Render(itemText)
+	if height < l.height {
+		lines = append(lines, l.loadingStyle.Render(l.loadingText))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// stickyHeader returns the row of the header for the section at the top of
+// the window, or -1 if there is none or it is already the first row
+func (l *List) stickyHeader() int {
+	if l.groupBy == nil || l.height < 2 || l.scrollOffset >= len(l.filteredItems) {
+		return -1
+	}
+	if l.filteredItems[l.scrollOffset] < 0 {
+		return -1
+	}
+	for i := l.scrollOffset - 1; i >= 0; i-- {
+		if l.filteredItems[i] < 0 {
+			return i
 		}
-		line.WriteString(itemText)
+	}
+	return -1
+}
+
+// renderHeader renders the group header row at i
+func (l *List) renderHeader(i int) string {
+	group := l.groups[headerIndex(l.filteredItems[i])]
+
+	prefix := strings.Repeat(" ", utf8.RuneCountInString(l.cursorChar))
+	if i == l.filteredIdx && l.showCursor {
+		prefix = l.selectedCursorStyle.Render(l.cursorChar)
+	}
+
+	arrow := "▾ "
+	if l.collapsed[group.name] {
+		arrow = "▸ "
+	}
+	return prefix + l.headerStyle.Render(fmt.Sprintf("%s%s (%d)", arrow, group.name, group.count))
+}
 
-		// Truncate if too long
-		lineStr := line.String()
-		if len(lineStr) > l.width {
-			// This is a simplified truncation - in reality we'd need to handle ANSI codes properly
-			lineStr = lineStr[:l.width-3] + "..."
+// renderItem renders the filtered item at i as exactly itemHeight(i) lines.
+// The cursor or marker, and the checkbox in multi-select mode, go on the
+// first line and continuation lines are indented to match.
+func (l *List) renderItem(i int) []string {
+	if l.filteredItems[i] < 0 {
+		return []string{l.renderHeader(i)}
+	}
+
+	item := l.items[l.filteredItems[i]]
+	isSelected := (i == l.filteredIdx)
+
+	// Build the marker
+	var marker string
+	if l.showCursor && isSelected {
+		marker = l.selectedCursorStyle.Render(l.cursorChar)
+	} else if isSelected {
+		marker = l.selectedChar
+	} else {
+		marker = l.unselectedChar
+	}
+	if l.multiSelect {
+		box := l.uncheckedChar
+		if l.checked[l.filteredItems[i]] {
+			box = l.checkedChar
 		}
+		marker += l.checkStyle.Render(box)
+	}
+	indent := strings.Repeat(" ", l.gutterWidth())
+	rtl := l.itemDirection(item) == DirectionRTL
 
-		result.WriteString(lineStr)
+	height := l.itemHeight(i)
+	content := strings.Split(item.Render(), "\n")
+	if len(content) > height {
+		content = content[:height]
+	}
+	for len(content) < height {
+		content = append(content, "")
 	}
 
-	// Add scroll indicators if needed
-	if l.height > 0 {
-		totalLines := result.String()
-		lines := strings.Split(totalLines, "\n")
-		
-		// Pad to fill height
-		for len(lines) < l.height {
-			lines = append(lines, "")
+	lines := make([]string, height)
+	for j, text := range content {
+		// Add item content
+		if isSelected {
+			text = highlightMatches(text, l.highlight, l.selectedStyle, l.highlightStyle)
+		} else {
+			text = highlightMatches(text, l.highlight, l.style, l.highlightStyle)
 		}
 
-		// Add scroll indicators
-		if l.scrollOffset > 0 {
-			// Can scroll up
-			if len(lines) > 0 {
-				lines[0] = l.addScrollIndicator(lines[0], "↑")
-			}
+		prefix := indent
+		if j == 0 {
+			prefix = marker
 		}
-		if l.scrollOffset+l.height < len(l.filteredItems) {
-			// Can scroll down
-			if len(lines) > 0 {
-				lines[len(lines)-1] = l.addScrollIndicator(lines[len(lines)-1], "↓")
-			}
+		lineStr := prefix + text
+
+		// Align right-to-left text right, short of the scroll indicators
+		if pad := l.width - 1 - visibleWidth(lineStr); rtl && pad > 0 {
+			lineStr = prefix + spaces(pad) + text
 		}
 
-		result.Reset()
-		for i, line := range lines {
-			if i > 0 {
-				result.WriteString("\n")
-			}
-			result.WriteString(line)
+		// Truncate if too long, keeping highlights and styles intact
+		if l.width > 3 && visibleWidth(lineStr) > l.width {
+			lineStr = fitWidth(lineStr, l.width-3) + "..."
 		}
+		lines[j] = lineStr
 	}
-
-	return result.String()
+	return lines
 }
 
 // addScrollIndicator adds a scroll indicator to the end of a line
 func (l *List) addScrollIndicator(line, indicator string) string {
-	if len(line) < l.width-1 {
+	width := visibleWidth(line)
+	if width < l.width-1 {
 		// Pad the line and add indicator
-		padding := l.width - len(line) - 1
-		return line + strings.Repeat(" ", padding) + indicator
+		return fitWidth(line, l.width-1) + indicator
 	}
-	if len(line) >= 1 {
+	if width >= 1 {
 		// Replace last character with indicator
-		return line[:len(line)-1] + indicator
+		return fitWidth(line, width-1) + indicator
 	}
 	return indicator
 }
@@ -604,7 +1457,14 @@ func (l *List) Len() int {
 
 // FilteredLen returns the number of items matching the current filter
 func (l *List) FilteredLen() int {
-	return len(l.filteredItems)
+	if l.groupBy == nil {
+		return len(l.filteredItems)
+	}
+	n := 0
+	for _, group := range l.groups {
+		n += group.count
+	}
+	return n
 }
 
 // IsEmpty returns whether the list is empty
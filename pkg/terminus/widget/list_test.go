@@ -16,6 +16,7 @@ package widget
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/skaiser/terminusgo/pkg/terminus"
@@ -31,6 +32,16 @@ func TestSimpleListItem(t *testing.T) {
 	if item.String() != "test item" {
 		t.Errorf("Expected String() to return 'test item', got '%s'", item.String())
 	}
+
+	item = NewSimpleListItem("\x1b[31mfake prompt\x1b[0m")
+	if item.Render() != "fake prompt" {
+		t.Errorf("Expected the escapes to be removed, got %q", item.Render())
+	}
+
+	styled := terminus.NewStyle().Bold(true).Render("bold")
+	if item := NewStyledListItem(styled); item.Render() != styled {
+		t.Errorf("Expected styled text to be kept, got %q", item.Render())
+	}
 }
 
 func TestList(t *testing.T) {
@@ -393,4 +404,602 @@ func TestListChaining(t *testing.T) {
 	if list.SelectedIndex() != 1 {
 		t.Error("Method chaining should work correctly")
 	}
-}
\ No newline at end of file
+}
+
+// tallItem is a list item spanning several lines
+type tallItem struct {
+	lines []string
+}
+
+func (t tallItem) Render() string       { return strings.Join(t.lines, "\n") }
+func (t tallItem) String() string       { return t.lines[0] }
+func (t tallItem) Height(width int) int { return len(t.lines) }
+
+// newPlainList returns a list without styling for easy comparison
+func newPlainList(width, height int, items ...ListItem) *List {
+	list := NewList().
+		SetStyle(terminus.NewStyle()).
+		SetSelectedStyle(terminus.NewStyle()).
+		SetSelectedCursorStyle(terminus.NewStyle())
+	list.SetItems(items)
+	list.SetSize(width, height)
+	return list
+}
+
+func TestListVariableHeights(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Continuation lines are indented",
+			test: func(t *testing.T) {
+				list := newPlainList(20, 4,
+					tallItem{lines: []string{"alice:", "hi there"}},
+					NewSimpleListItem("bob"),
+				)
+
+				expected := "> alice:\n  hi there\n  bob\n"
+				if got := list.View(); got != expected {
+					t.Errorf("Expected %q, got %q", expected, got)
+				}
+			},
+		},
+		{
+			name: "Scroll window is measured in lines",
+			test: func(t *testing.T) {
+				list := newPlainList(20, 4,
+					tallItem{lines: []string{"a1", "a2", "a3"}},
+					tallItem{lines: []string{"b1", "b2"}},
+					tallItem{lines: []string{"c1", "c2"}},
+				)
+				list.Focus()
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if list.scrollOffset != 1 {
+					t.Errorf("Expected window to start at item 1, got %d", list.scrollOffset)
+				}
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if list.scrollOffset != 1 {
+					t.Errorf("Expected items 1 and 2 to share the window, got offset %d", list.scrollOffset)
+				}
+
+				lines := strings.Split(list.View(), "\n")
+				if len(lines) != 4 || !strings.HasPrefix(lines[2], "> c1") {
+					t.Errorf("Unexpected view %q", lines)
+				}
+			},
+		},
+		{
+			name: "Only visible items are rendered",
+			test: func(t *testing.T) {
+				rendered := 0
+				items := make([]ListItem, 1000)
+				for i := range items {
+					items[i] = countingItem{n: i, rendered: &rendered}
+				}
+				list := newPlainList(20, 5, items...)
+				list.SetSelected(500)
+				list.View()
+
+				if rendered > 5 {
+					t.Errorf("Expected at most 5 items rendered, got %d", rendered)
+				}
+			},
+		},
+		{
+			name: "Item taller than the window is clipped",
+			test: func(t *testing.T) {
+				list := newPlainList(20, 2,
+					tallItem{lines: []string{"1", "2", "3"}},
+				)
+
+				lines := strings.Split(list.View(), "\n")
+				if len(lines) != 2 || !strings.HasSuffix(lines[1], "↓") {
+					t.Errorf("Expected clipped item with scroll indicator, got %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// countingItem counts how often it is rendered
+type countingItem struct {
+	n        int
+	rendered *int
+}
+
+func (c countingItem) Render() string {
+	*c.rendered++
+	return fmt.Sprintf("item %d", c.n)
+}
+
+func (c countingItem) String() string { return fmt.Sprintf("item %d", c.n) }
+
+func TestListReorder(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "MoveItem shifts items and keeps selection",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a", "b", "c", "d"})
+				list.SetSelected(1)
+
+				list.MoveItem(0, 3)
+
+				if got := itemStrings(list); got != "b,c,d,a" {
+					t.Errorf("Expected b,c,d,a, got %s", got)
+				}
+				if list.SelectedItem().String() != "b" {
+					t.Errorf("Expected selection to stay on b, got %s", list.SelectedItem())
+				}
+
+				list.MoveItem(3, 1)
+				if got := itemStrings(list); got != "b,a,c,d" {
+					t.Errorf("Expected b,a,c,d, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Out of range moves are ignored",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a", "b"})
+				list.MoveItem(-1, 1).MoveItem(0, 5)
+
+				if got := itemStrings(list); got != "a,b" {
+					t.Errorf("Expected a,b, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Ctrl+Down moves the selected item",
+			test: func(t *testing.T) {
+				var from, to int
+				list := NewList().
+					SetStringItems([]string{"a", "b", "c"}).
+					SetReorderable(true).
+					SetOnReorder(func(f, t int) terminus.Cmd {
+						from, to = f, t
+						return nil
+					})
+				list.Focus()
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown, Ctrl: true})
+
+				if got := itemStrings(list); got != "b,a,c" {
+					t.Errorf("Expected b,a,c, got %s", got)
+				}
+				if from != 0 || to != 1 {
+					t.Errorf("Expected OnReorder(0, 1), got (%d, %d)", from, to)
+				}
+				if list.SelectedIndex() != 1 {
+					t.Errorf("Expected selection to follow the item, got %d", list.SelectedIndex())
+				}
+			},
+		},
+		{
+			name: "Reordering skips hidden items",
+			test: func(t *testing.T) {
+				list := NewList().
+					SetStringItems([]string{"apple", "kiwi", "avocado"}).
+					SetReorderable(true).
+					SetFilter("a")
+				list.Focus()
+				list.SetSelected(2)
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyUp, Ctrl: true})
+
+				if got := itemStrings(list); got != "avocado,apple,kiwi" {
+					t.Errorf("Expected avocado,apple,kiwi, got %s", got)
+				}
+			},
+		},
+		{
+			name: "Ctrl+Up is plain navigation when not reorderable",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a", "b"})
+				list.Focus()
+				list.SetSelected(1)
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyUp, Ctrl: true})
+
+				if got := itemStrings(list); got != "a,b" || list.SelectedIndex() != 0 {
+					t.Errorf("Expected selection to move without reordering, got %s", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// itemStrings joins the list's items for comparison
+func itemStrings(l *List) string {
+	var parts []string
+	for _, item := range l.Items() {
+		parts = append(parts, item.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestListMultiSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Space toggles the selected item",
+			test: func(t *testing.T) {
+				var toggled []int
+				list := NewList().
+					SetStringItems([]string{"a", "b", "c"}).
+					SetMultiSelect(true).
+					SetOnToggle(func(index int, checked bool) terminus.Cmd {
+						if checked {
+							toggled = append(toggled, index)
+						}
+						return nil
+					})
+				list.Focus()
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				list.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+
+				if got := list.SelectedIndices(); len(got) != 2 || got[0] != 0 || got[1] != 2 {
+					t.Errorf("Expected [0 2] checked, got %v", got)
+				}
+				if len(toggled) != 2 {
+					t.Errorf("Expected OnToggle for each check, got %v", toggled)
+				}
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+				if list.IsChecked(2) {
+					t.Error("Expected second Space to uncheck the item")
+				}
+			},
+		},
+		{
+			name: "Space does nothing without multi-select",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a"})
+				list.Focus()
+				list.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+
+				if len(list.SelectedIndices()) != 0 {
+					t.Error("Expected no checked items")
+				}
+			},
+		},
+		{
+			name: "SelectAll respects the filter",
+			test: func(t *testing.T) {
+				list := NewList().
+					SetStringItems([]string{"apple", "kiwi", "avocado"}).
+					SetMultiSelect(true).
+					SetFilter("a")
+
+				list.SelectAll()
+				if got := len(list.SelectedIndices()); got != 2 {
+					t.Errorf("Expected 2 checked items, got %d", got)
+				}
+				if !list.AllSelected() {
+					t.Error("Expected AllSelected with every visible item checked")
+				}
+
+				list.SetFilter("")
+				if list.AllSelected() {
+					t.Error("Expected AllSelected to be false once kiwi is visible")
+				}
+
+				list.ClearSelection()
+				if len(list.SelectedItems()) != 0 {
+					t.Error("Expected ClearSelection to uncheck everything")
+				}
+			},
+		},
+		{
+			name: "Checks follow moved items",
+			test: func(t *testing.T) {
+				list := NewList().SetStringItems([]string{"a", "b", "c"})
+				list.SetChecked(0, true)
+				list.MoveItem(0, 2)
+
+				if !list.IsChecked(2) || list.IsChecked(0) {
+					t.Errorf("Expected check to move with the item, got %v", list.SelectedIndices())
+				}
+			},
+		},
+		{
+			name: "Renders the checkbox gutter",
+			test: func(t *testing.T) {
+				list := NewList().
+					SetStringItems([]string{"a", "b"}).
+					SetMultiSelect(true).
+					SetCheckStyle(terminus.NewStyle())
+				list.SetSize(40, 2)
+				list.SetChecked(1, true)
+
+				lines := strings.Split(list.View(), "\n")
+				if !strings.Contains(lines[0], "[ ] ") || !strings.Contains(lines[1], "[x] b") {
+					t.Errorf("Unexpected checkbox rendering: %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// severityItem is a list item grouped by severity
+type severityItem struct {
+	severity string
+	text     string
+}
+
+func (s severityItem) Render() string { return s.text }
+func (s severityItem) String() string { return s.text }
+
+func newGroupedList(height int) *List {
+	var items []ListItem
+	for _, sev := range []string{"critical", "warning"} {
+		for i := 0; i < 4; i++ {
+			items = append(items, severityItem{sev, fmt.Sprintf("%s %d", sev, i)})
+		}
+	}
+	list := NewList().
+		SetItems(items).
+		SetHeaderStyle(terminus.NewStyle()).
+		SetGroupBy(func(item ListItem) string { return item.(severityItem).severity })
+	list.SetStyle(terminus.NewStyle()).SetSelectedStyle(terminus.NewStyle())
+	list.SetSelectedCursorStyle(terminus.NewStyle())
+	list.SetSize(40, height)
+	list.Focus()
+	return list
+}
+
+func TestListGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Headers precede each group",
+			test: func(t *testing.T) {
+				list := newGroupedList(20)
+				lines := strings.Split(list.View(), "\n")
+
+				if !strings.Contains(lines[0], "▾ critical (4)") || !strings.Contains(lines[5], "▾ warning (4)") {
+					t.Errorf("Unexpected headers: %q", lines[:6])
+				}
+				if list.SelectedIndex() != 0 {
+					t.Errorf("Expected first item selected, got %d", list.SelectedIndex())
+				}
+				if list.FilteredLen() != 8 {
+					t.Errorf("Expected 8 items, got %d", list.FilteredLen())
+				}
+			},
+		},
+		{
+			name: "Header sticks while scrolling through its section",
+			test: func(t *testing.T) {
+				list := newGroupedList(4)
+				for i := 0; i < 3; i++ {
+					list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				}
+
+				lines := strings.Split(list.View(), "\n")
+				if !strings.Contains(lines[0], "critical (4)") {
+					t.Errorf("Expected sticky critical header, got %q", lines)
+				}
+				if !strings.Contains(list.View(), "critical 3") {
+					t.Errorf("Expected selection to stay visible, got %q", lines)
+				}
+
+				list.SetSelected(7)
+				lines = strings.Split(list.View(), "\n")
+				if !strings.Contains(lines[0], "warning (4)") || !strings.Contains(lines[3], "warning 3") {
+					t.Errorf("Expected sticky warning header, got %q", lines)
+				}
+			},
+		},
+		{
+			name: "Left collapses and Enter expands",
+			test: func(t *testing.T) {
+				list := newGroupedList(20)
+				list.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+
+				if !list.IsCollapsed("critical") {
+					t.Fatal("Expected critical to collapse")
+				}
+				if list.SelectedIndex() != -1 || list.SelectedGroup() != "critical" {
+					t.Errorf("Expected collapsed header to be selected, got %d", list.SelectedIndex())
+				}
+				if strings.Contains(list.View(), "critical 0") || !strings.Contains(list.View(), "▸ critical (4)") {
+					t.Errorf("Expected items hidden behind header, got %q", list.View())
+				}
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if list.SelectedGroup() != "warning" || list.SelectedIndex() != -1 {
+					t.Errorf("Expected warning header next, got %d", list.SelectedIndex())
+				}
+				list.Update(terminus.KeyMsg{Type: terminus.KeyUp})
+				list.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+
+				if list.IsCollapsed("critical") || !strings.Contains(list.View(), "critical 0") {
+					t.Error("Expected Enter on header to expand")
+				}
+			},
+		},
+		{
+			name: "Reordering stays within a group",
+			test: func(t *testing.T) {
+				list := newGroupedList(20).SetReorderable(true)
+				list.SetSelected(4)
+				list.Update(terminus.KeyMsg{Type: terminus.KeyUp, Ctrl: true})
+
+				if list.Items()[4].String() != "warning 0" {
+					t.Errorf("Expected item not to cross the header, got %s", list.Items()[4])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// loadMoreMsg is returned by OnReachEnd in tests
+type loadMoreMsg struct{}
+
+func TestListLoadMore(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Fires once near the end",
+			test: func(t *testing.T) {
+				calls := 0
+				list := newPlainList(20, 6).
+					SetReachEndThreshold(1).
+					SetOnReachEnd(func() terminus.Cmd {
+						calls++
+						return func() terminus.Msg { return loadMoreMsg{} }
+					})
+				list.SetStringItems([]string{"a", "b", "c", "d"})
+				list.Focus()
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if calls != 0 {
+					t.Fatal("Expected no load away from the end")
+				}
+
+				_, cmd := list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if calls != 1 || cmd == nil {
+					t.Fatalf("Expected a load at the threshold, got %d calls", calls)
+				}
+				if _, ok := cmd().(loadMoreMsg); !ok {
+					t.Error("Expected the OnReachEnd command")
+				}
+				if !list.Loading() {
+					t.Error("Expected the list to be loading")
+				}
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if calls != 1 {
+					t.Error("Expected no second load while loading")
+				}
+
+				list.AddItem(NewSimpleListItem("e"))
+				if list.Loading() {
+					t.Error("Expected appending to clear loading")
+				}
+				list.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				if calls != 2 {
+					t.Errorf("Expected another load after appending, got %d calls", calls)
+				}
+			},
+		},
+		{
+			name: "Stops when there is nothing more",
+			test: func(t *testing.T) {
+				calls := 0
+				list := newPlainList(20, 6).SetOnReachEnd(func() terminus.Cmd {
+					calls++
+					return nil
+				})
+				list.SetStringItems([]string{"a", "b"})
+				list.SetHasMore(false)
+				list.Focus()
+
+				list.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+				if calls != 0 {
+					t.Error("Expected no load once HasMore is false")
+				}
+			},
+		},
+		{
+			name: "Shows the loading footer on the last line",
+			test: func(t *testing.T) {
+				list := newPlainList(20, 3).SetLoadingStyle(terminus.NewStyle())
+				list.SetStringItems([]string{"a", "b", "c", "d", "e"})
+				list.SetSelected(4)
+				list.SetLoading(true)
+
+				lines := strings.Split(list.View(), "\n")
+				if len(lines) != 3 || lines[2] != "loading more…" {
+					t.Fatalf("Expected footer on the last line, got %q", lines)
+				}
+				if !strings.Contains(lines[1], "e") {
+					t.Errorf("Expected selection to stay visible above the footer, got %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// chatItem is a message whose text decides its direction
+type chatItem struct {
+	user, text string
+}
+
+func (c chatItem) Render() string       { return c.user + ": " + c.text }
+func (c chatItem) String() string       { return c.Render() }
+func (c chatItem) Direction() Direction { return TextDirection(c.text) }
+
+func TestListRightToLeft(t *testing.T) {
+	list := NewList().SetStringItems([]string{"hello", "שלום"})
+	list.SetSize(16, 3)
+	list.SetShowCursor(false)
+	list.AddItem(chatItem{user: "dan", text: "مرحبا"})
+
+	lines := strings.Split(plain(list.View()), "\n")
+	if lines[0] != "• hello" {
+		t.Errorf("Expected English aligned left, got %q", lines[0])
+	}
+	if lines[1] != "           שלום" {
+		t.Errorf("Expected Hebrew aligned right of the indicator column, got %q", lines[1])
+	}
+	if lines[2] != "     dan: مرحبا" {
+		t.Errorf("Expected the message aligned by its text, got %q", lines[2])
+	}
+
+	list.SetDirection(DirectionLTR)
+	if lines = strings.Split(plain(list.View()), "\n"); lines[1] != "  שלום" {
+		t.Errorf("Expected a left-to-right list to align left, got %q", lines[1])
+	}
+}
+
+// BenchmarkListFilter filters a 10k item list, as typing a filter does
+func BenchmarkListFilter(b *testing.B) {
+	items := make([]string, 10000)
+	for i := range items {
+		items[i] = fmt.Sprintf("item %d: task %d", i, i%97)
+	}
+	list := NewList().SetStringItems(items)
+	filters := []string{"t", "ta", "task", "task 4", "task 42"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.SetFilter(filters[i%len(filters)])
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Size is a width and height in cells
+type Size struct {
+	Width, Height int
+}
+
+// Constraints are the sizes a widget can be laid out at. A preferred
+// dimension of 0 takes whatever space is left, and a maximum of 0 is
+// unbounded.
+type Constraints struct {
+	Min       Size
+	Preferred Size
+	Max       Size
+}
+
+// Measurer is implemented by widgets that report their constraints, so
+// their parent can size them. Model implements it with the sizes set by
+// SetMinSize, SetPreferredSize and SetMaxSize.
+type Measurer interface {
+	Measure() Constraints
+}
+
+// Measure returns the constraints of c: those it reports if it is a
+// Measurer, and otherwise the size of its view, which it can't be laid out
+// at any other. A hidden widget takes no space.
+func Measure(c terminus.Component) Constraints {
+	if !Visible(c) {
+		return Constraints{}
+	}
+	if m, ok := c.(Measurer); ok {
+		return m.Measure()
+	}
+	var size Size
+	for _, line := range strings.Split(c.View(), "\n") {
+		size.Width = max(size.Width, visibleWidth(line))
+		size.Height++
+	}
+	return Constraints{Min: size, Preferred: size, Max: size}
+}
+
+// Arrange divides size among the visible components, top to bottom or
+// side by side with gap cells between them, and returns the size of each,
+// 0x0 for hidden ones. Along the direction, each gets its preferred size,
+// or its minimum if it fills, and the space left is shared out among
+// those that fill, up to their maximum; when the space is short, each
+// shrinks towards its minimum. Across it, each gets the full size, within
+// its preferred and maximum size and no less than its minimum.
+//
+//	sizes := widget.Arrange(widget.ComposeVertical, widget.Size{Width: w, Height: h}, 0, m.header, m.table, m.input)
+func Arrange(direction ComposeDirection, size Size, gap int, components ...terminus.Component) []Size {
+	// Along and across the direction
+	along := func(s Size) int {
+		if direction == ComposeHorizontal {
+			return s.Width
+		}
+		return s.Height
+	}
+	across := func(s Size) int {
+		if direction == ComposeHorizontal {
+			return s.Height
+		}
+		return s.Width
+	}
+	sizeOf := func(along, across int) Size {
+		if direction == ComposeHorizontal {
+			return Size{Width: along, Height: across}
+		}
+		return Size{Width: across, Height: along}
+	}
+
+	constraints := make([]Constraints, len(components))
+	var shown []int
+	for i, c := range components {
+		constraints[i] = Measure(c)
+		if Visible(c) {
+			shown = append(shown, i)
+		}
+	}
+
+	lengths := make([]int, len(components))
+	space := along(size) - gap*max(0, len(shown)-1)
+	for _, i := range shown {
+		if lengths[i] = along(constraints[i].Preferred); lengths[i] == 0 {
+			lengths[i] = along(constraints[i].Min)
+		}
+		space -= lengths[i]
+	}
+
+	// Share out the space left a cell at a time among those that fill,
+	// or take it back from those above their minimum, last first
+	for space > 0 {
+		grown := false
+		for _, i := range shown {
+			c := constraints[i]
+			if space > 0 && along(c.Preferred) == 0 && (along(c.Max) == 0 || lengths[i] < along(c.Max)) {
+				lengths[i]++
+				space--
+				grown = true
+			}
+		}
+		if !grown {
+			break
+		}
+	}
+	for space < 0 {
+		shrunk := false
+		for k := len(shown) - 1; k >= 0; k-- {
+			if i := shown[k]; space < 0 && lengths[i] > along(constraints[i].Min) {
+				lengths[i]--
+				space++
+				shrunk = true
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+
+	sizes := make([]Size, len(components))
+	for _, i := range shown {
+		c := constraints[i]
+		breadth := across(size)
+		if preferred := across(c.Preferred); preferred > 0 {
+			breadth = min(breadth, preferred)
+		}
+		if most := across(c.Max); most > 0 {
+			breadth = min(breadth, most)
+		}
+		sizes[i] = sizeOf(lengths[i], max(breadth, across(c.Min)))
+	}
+	return sizes
+}
+
+// combine returns the constraints of components laid out by Arrange: the
+// sum of theirs along the direction, with the gaps, and the largest
+// across it. Along or across, one that fills or is unbounded makes the
+// whole fill or be unbounded.
+func combine(direction ComposeDirection, gap int, components ...terminus.Component) Constraints {
+	// split returns the size along and across the direction
+	split := func(s Size) (int, int) {
+		if direction == ComposeHorizontal {
+			return s.Width, s.Height
+		}
+		return s.Height, s.Width
+	}
+
+	var along, across [3]int // Min, Preferred and Max
+	var zeroAlong, zeroAcross [3]bool
+	shown := 0
+	for _, c := range components {
+		if !Visible(c) {
+			continue
+		}
+		shown++
+		m := Measure(c)
+		for k, s := range [3]Size{m.Min, m.Preferred, m.Max} {
+			a, b := split(s)
+			along[k] += a
+			across[k] = max(across[k], b)
+			zeroAlong[k] = zeroAlong[k] || a == 0
+			zeroAcross[k] = zeroAcross[k] || b == 0
+		}
+	}
+	if shown == 0 {
+		return Constraints{}
+	}
+
+	var sizes [3]Size
+	for k := range sizes {
+		a, b := along[k]+gap*(shown-1), across[k]
+		if k > 0 && zeroAlong[k] {
+			a = 0
+		}
+		if k > 0 && zeroAcross[k] {
+			b = 0
+		}
+		if direction == ComposeHorizontal {
+			sizes[k] = Size{Width: a, Height: b}
+		} else {
+			sizes[k] = Size{Width: b, Height: a}
+		}
+	}
+	return Constraints{Min: sizes[0], Preferred: sizes[1], Max: sizes[2]}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Reports the constraints set on a widget",
+			test: func(t *testing.T) {
+				list := NewList()
+				list.SetMinSize(10, 2)
+				list.SetPreferredSize(0, 5)
+				list.SetMaxSize(40, 0)
+				expected := Constraints{Min: Size{10, 2}, Preferred: Size{0, 5}, Max: Size{40, 0}}
+				if c := Measure(list); c != expected {
+					t.Errorf("Expected %+v, got %+v", expected, c)
+				}
+				list.Hide()
+				if c := Measure(list); c != (Constraints{}) {
+					t.Errorf("Expected a hidden widget to take no space, got %+v", c)
+				}
+			},
+		},
+		{
+			name: "Measures other components by their view",
+			test: func(t *testing.T) {
+				size := Size{Width: 5, Height: 2}
+				if c := Measure(&probe{view: "hello\nhi"}); c != (Constraints{Min: size, Preferred: size, Max: size}) {
+					t.Errorf("Expected a fixed 5x2, got %+v", c)
+				}
+				if c := Measure(NewLabel("a label")); c.Preferred != (Size{Width: 7, Height: 1}) {
+					t.Errorf("Expected a label to prefer its text, got %+v", c)
+				}
+			},
+		},
+		{
+			name: "Gives the space left to the widgets that fill",
+			test: func(t *testing.T) {
+				title, list, input := NewLabel("Todos"), NewList(), NewTextInput()
+				sizes := Arrange(ComposeVertical, Size{Width: 40, Height: 10}, 0, title, list, input)
+				expected := []Size{{5, 1}, {40, 8}, {40, 1}}
+				if !reflect.DeepEqual(sizes, expected) {
+					t.Errorf("Expected %v, got %v", expected, sizes)
+				}
+
+				list.SetMaxSize(30, 4)
+				sizes = Arrange(ComposeVertical, Size{Width: 40, Height: 10}, 0, title, list, input)
+				if expected := []Size{{5, 1}, {30, 4}, {40, 1}}; !reflect.DeepEqual(sizes, expected) {
+					t.Errorf("Expected the list held to its maximum, got %v", sizes)
+				}
+			},
+		},
+		{
+			name: "Shrinks widgets towards their minimum, last first",
+			test: func(t *testing.T) {
+				a, b := NewList(), NewList()
+				a.SetPreferredSize(0, 4)
+				b.SetPreferredSize(0, 4)
+				b.SetMinSize(0, 3)
+				sizes := Arrange(ComposeVertical, Size{Width: 10, Height: 5}, 0, a, b)
+				if expected := []Size{{10, 2}, {10, 3}}; !reflect.DeepEqual(sizes, expected) {
+					t.Errorf("Expected %v, got %v", expected, sizes)
+				}
+			},
+		},
+		{
+			name: "Lays out side by side, leaving hidden widgets out",
+			test: func(t *testing.T) {
+				sidebar, hidden, main := NewList(), NewList(), NewList()
+				sidebar.SetPreferredSize(10, 0)
+				hidden.Hide()
+				sizes := Arrange(ComposeHorizontal, Size{Width: 31, Height: 6}, 1, sidebar, hidden, main)
+				if expected := []Size{{10, 6}, {0, 0}, {20, 6}}; !reflect.DeepEqual(sizes, expected) {
+					t.Errorf("Expected %v, got %v", expected, sizes)
+				}
+			},
+		},
+		{
+			name: "Sizes the children of a Compose",
+			test: func(t *testing.T) {
+				sidebar, main := NewList(), NewList()
+				sidebar.SetPreferredSize(6, 0)
+				c := NewCompose(ComposeHorizontal).Add("sidebar", sidebar).Add("main", main).SetGap(1)
+				c.SetSize(20, 3)
+				if w, h := main.GetSize(); w != 13 || h != 3 {
+					t.Errorf("Expected the main list to fill 13x3, got %dx%d", w, h)
+				}
+				for _, line := range strings.Split(c.View(), "\n") {
+					if visibleWidth(line) != 20 {
+						t.Errorf("Expected each line padded to the columns, got %q", line)
+					}
+				}
+
+				c.SetHidden("sidebar", true)
+				if w, _ := main.GetSize(); w != 20 {
+					t.Errorf("Expected the main list to take the sidebar's space, got %d", w)
+				}
+			},
+		},
+		{
+			name: "Combines the constraints of the children",
+			test: func(t *testing.T) {
+				input, status := NewTextInput(), NewLabel("ready")
+				input.SetMinSize(10, 1)
+				c := NewCompose(ComposeVertical).Add("input", input).Add("status", status).SetGap(1)
+				expected := Constraints{Min: Size{10, 2}, Preferred: Size{0, 3}, Max: Size{0, 0}}
+				if got := c.Measure(); got != expected {
+					t.Errorf("Expected %+v, got %+v", expected, got)
+				}
+			},
+		},
+		{
+			name: "Sizes a panel's child inside its border",
+			test: func(t *testing.T) {
+				input := NewTextInput()
+				panel := NewPanel("Name", input).SetPadding(1)
+				if c := panel.Measure(); c.Min != (Size{4, 5}) || c.Preferred != (Size{0, 5}) {
+					t.Errorf("Expected the border and padding added, got %+v", c)
+				}
+
+				container := NewContainer()
+				container.AddChild(panel)
+				container.SetSize(30, 20)
+				if w, h := input.GetSize(); w != 26 || h != 1 {
+					t.Errorf("Expected the input 26x1, got %dx%d", w, h)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
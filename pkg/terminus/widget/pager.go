@@ -0,0 +1,704 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// pagerPrompt is the input the pager's status line is collecting
+type pagerPrompt int
+
+const (
+	promptNone pagerPrompt = iota
+	promptSearch
+	promptLine
+)
+
+// Pager is a less-style viewer for long text such as logs and files. It
+// scrolls vertically and horizontally, searches with / (n and N step
+// through matches) and jumps to a line with :.
+type Pager struct {
+	Model
+
+	// Content
+	lines []string
+	top   int // First visible line
+	left  int // First visible column
+
+	// Search
+	query    string
+	matches  []int // Lines containing the query
+	matchIdx int
+
+	// Status line input
+	prompt pagerPrompt
+	input  string
+	notice string // One-off message such as "Pattern not found"
+
+	// Set while waiting for the scrollback asked for by ShowScrollback
+	readingScrollback bool
+
+	// Configuration
+	showStatus bool
+	hscroll    int // Columns moved by Left/Right
+	tabWidth   int
+	count      countPrefix
+	scrollbar  *Scrollbar
+	hscrollbar *Scrollbar
+	gutter     *Gutter
+
+	// Styling
+	style          terminus.Style
+	highlightStyle terminus.Style
+	statusStyle    terminus.Style
+}
+
+// NewPager creates a new pager
+func NewPager() *Pager {
+	m := NewModel()
+	m.width = 80
+	m.height = 24
+	return &Pager{
+		Model:          m,
+		showStatus:     true,
+		hscroll:        8,
+		tabWidth:       terminus.DefaultTabWidth,
+		style:          terminus.NewStyle(),
+		highlightStyle: terminus.NewStyle().Reverse(true),
+		statusStyle:    terminus.NewStyle().Reverse(true),
+	}
+}
+
+// SetContent sets the text to page through
+func (p *Pager) SetContent(content string) *Pager {
+	return p.SetLines(strings.Split(strings.TrimSuffix(content, "\n"), "\n"))
+}
+
+// SetLines sets the lines to page through. Tabs are expanded to the next
+// tab stop, as the screen would.
+func (p *Pager) SetLines(lines []string) *Pager {
+	p.lines = make([]string, len(lines))
+	for i, line := range lines {
+		p.lines[i] = terminus.ExpandTabs(line, p.tabWidth)
+	}
+	p.top, p.left = 0, 0
+	p.findMatches()
+	return p
+}
+
+// ReplaceLines replaces the content like SetLines but keeps the position
+// and the search, for content that changes while it is viewed, such as a
+// log or a chat transcript. The current match stays current while its line
+// still matches, and a view at the bottom follows new lines unless a search
+// is active.
+func (p *Pager) ReplaceLines(lines []string) *Pager {
+	follow := p.query == "" && p.top >= len(p.lines)-p.pageLines()
+	matchLine := -1
+	if len(p.matches) > 0 {
+		matchLine = p.matches[p.matchIdx]
+	}
+
+	top, left := p.top, p.left
+	p.SetLines(lines)
+	p.top, p.left = top, left
+	for i, line := range p.matches {
+		if line >= matchLine {
+			p.matchIdx = i
+			break
+		}
+	}
+	if follow {
+		p.top = len(p.lines)
+	}
+	p.clampTop()
+	return p
+}
+
+// SetTabWidth sets the distance between the tab stops tabs in the content
+// are expanded to, DefaultTabWidth unless set. Set it before the content,
+// and to the width the program renders with (see terminus.WithTabWidth).
+func (p *Pager) SetTabWidth(width int) *Pager {
+	p.tabWidth = width
+	return p
+}
+
+// SetShowStatus sets whether the status line is shown
+func (p *Pager) SetShowStatus(show bool) *Pager {
+	p.showStatus = show
+	return p
+}
+
+// SetScrollbar shows a vertical scrollbar in the last column
+func (p *Pager) SetScrollbar(scrollbar *Scrollbar) *Pager {
+	p.scrollbar = scrollbar
+	return p
+}
+
+// SetHorizontalScrollbar shows a horizontal scrollbar below the text, sized
+// to the longest line
+func (p *Pager) SetHorizontalScrollbar(scrollbar *Scrollbar) *Pager {
+	p.hscrollbar = scrollbar
+	return p
+}
+
+// SetGutter shows a gutter with line numbers and markers beside the text.
+// Pass nil to hide it.
+func (p *Pager) SetGutter(gutter *Gutter) *Pager {
+	p.gutter = gutter
+	return p
+}
+
+// SetHorizontalStep sets how many columns Left and Right scroll
+func (p *Pager) SetHorizontalStep(columns int) *Pager {
+	if columns > 0 {
+		p.hscroll = columns
+	}
+	return p
+}
+
+// SetStyle sets the text style
+func (p *Pager) SetStyle(style terminus.Style) *Pager {
+	p.style = style
+	return p
+}
+
+// SetHighlightStyle sets the style of search matches
+func (p *Pager) SetHighlightStyle(style terminus.Style) *Pager {
+	p.highlightStyle = style
+	return p
+}
+
+// SetStatusStyle sets the style of the status line
+func (p *Pager) SetStatusStyle(style terminus.Style) *Pager {
+	p.statusStyle = style
+	return p
+}
+
+// LineCount returns the number of lines
+func (p *Pager) LineCount() int {
+	return len(p.lines)
+}
+
+// Line returns the 1-based number of the first visible line
+func (p *Pager) Line() int {
+	return p.top + 1
+}
+
+// Column returns the first visible column
+func (p *Pager) Column() int {
+	return p.left
+}
+
+// KeyBindings returns the keys the pager handles, for checking global
+// shortcuts against
+func (p *Pager) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("kjbfudgGhlnN/:"+p.count.digits(), terminus.KeyUp, terminus.KeyDown, terminus.KeyPgUp, terminus.KeyPgDown,
+		terminus.KeySpace, terminus.KeyHome, terminus.KeyEnd, terminus.KeyLeft, terminus.KeyRight)
+}
+
+// SetCountPrefix sets whether digits typed before a key repeat it, as in
+// less: 5j scrolls five lines and 40G goes to line 40. It is on by
+// default.
+func (p *Pager) SetCountPrefix(enabled bool) *Pager {
+	p.count.disabled = !enabled
+	return p
+}
+
+// ScrollState returns the first line and column shown, for ScrollStates
+func (p *Pager) ScrollState() ScrollState {
+	return ScrollState{Top: p.top, Left: p.left}
+}
+
+// SetScrollState scrolls to a line and column, within the lines the pager
+// has now
+func (p *Pager) SetScrollState(state ScrollState) {
+	p.top, p.left = state.Top, max(state.Left, 0)
+	p.clampTop()
+}
+
+// Percent returns how far through the content the bottom of the view is
+func (p *Pager) Percent() int {
+	if len(p.lines) == 0 {
+		return 100
+	}
+	bottom := p.top + p.pageLines()
+	if bottom > len(p.lines) {
+		bottom = len(p.lines)
+	}
+	return bottom * 100 / len(p.lines)
+}
+
+// GotoLine scrolls so that the given 1-based line is at the top
+func (p *Pager) GotoLine(line int) *Pager {
+	p.top = line - 1
+	p.clampTop()
+	return p
+}
+
+// ShowScrollback returns a command that reads the session's scrollback (see
+// terminus.WithScrollback) into the pager, without its styles, with the
+// first line that was on screen at the top
+func (p *Pager) ShowScrollback() terminus.Cmd {
+	p.readingScrollback = true
+	return terminus.ReadScrollback()
+}
+
+// Search highlights every occurrence of query, ignoring case, and scrolls
+// to the first match at or below the top of the view. An empty query clears
+// the search.
+func (p *Pager) Search(query string) *Pager {
+	p.query = query
+	p.findMatches()
+	if query == "" {
+		return p
+	}
+	if len(p.matches) == 0 {
+		p.notice = "Pattern not found"
+		return p
+	}
+
+	p.matchIdx = 0
+	for i, line := range p.matches {
+		if line >= p.top {
+			p.matchIdx = i
+			break
+		}
+	}
+	p.showMatch()
+	return p
+}
+
+// Query returns the current search
+func (p *Pager) Query() string {
+	return p.query
+}
+
+// MatchCount returns the number of lines matching the search
+func (p *Pager) MatchCount() int {
+	return len(p.matches)
+}
+
+// NextMatch scrolls to the next matching line, wrapping at the end
+func (p *Pager) NextMatch() *Pager {
+	if len(p.matches) > 0 {
+		p.matchIdx = (p.matchIdx + 1) % len(p.matches)
+		p.showMatch()
+	}
+	return p
+}
+
+// PrevMatch scrolls to the previous matching line, wrapping at the start
+func (p *Pager) PrevMatch() *Pager {
+	if len(p.matches) > 0 {
+		p.matchIdx = (p.matchIdx - 1 + len(p.matches)) % len(p.matches)
+		p.showMatch()
+	}
+	return p
+}
+
+// Highlight implements Highlighter. Matches are counted by line.
+func (p *Pager) Highlight(term string) int {
+	p.notice = ""
+	p.Search(term)
+	return len(p.matches)
+}
+
+// MatchIndex implements Highlighter
+func (p *Pager) MatchIndex() int {
+	return p.matchIdx
+}
+
+// StepMatch implements Highlighter
+func (p *Pager) StepMatch(delta int) {
+	if len(p.matches) > 0 {
+		p.matchIdx = stepIndex(p.matchIdx, delta, len(p.matches))
+		p.showMatch()
+	}
+}
+
+// findMatches collects the lines containing the query
+func (p *Pager) findMatches() {
+	p.matches = p.matches[:0]
+	p.matchIdx = 0
+	if p.query == "" {
+		return
+	}
+	for i, line := range p.lines {
+		if len(matchColumns(line, p.query)) > 0 {
+			p.matches = append(p.matches, i)
+		}
+	}
+}
+
+// showMatch scrolls the current match into view, including horizontally
+func (p *Pager) showMatch() {
+	line := p.matches[p.matchIdx]
+	p.top = line
+	p.clampTop()
+
+	// Bring the first occurrence on the line into view
+	col := matchColumns(p.lines[line], p.query)[0]
+	if width := p.textWidth(); col < p.left || col >= p.left+width {
+		p.left = col - width/2
+		if p.left < 0 {
+			p.left = 0
+		}
+	}
+}
+
+// pageLines returns the number of lines of content shown
+func (p *Pager) pageLines() int {
+	n := p.height
+	if p.showStatus {
+		n--
+	}
+	if p.hscrollbar != nil {
+		n--
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// gutterWidth returns the width of the gutter, if any
+func (p *Pager) gutterWidth() int {
+	if p.gutter == nil {
+		return 0
+	}
+	return p.gutter.Width(len(p.lines))
+}
+
+// textWidth returns the number of columns of text shown on each line,
+// between the gutter and the scrollbar
+func (p *Pager) textWidth() int {
+	width := p.width - p.gutterWidth()
+	if p.scrollbar != nil {
+		width--
+	}
+	if width < 1 {
+		width = 1
+	}
+	return width
+}
+
+// longestLine returns the width of the longest line, in columns
+func (p *Pager) longestLine() int {
+	longest := 0
+	for _, line := range p.lines {
+		if n := utf8.RuneCountInString(line); n > longest {
+			longest = n
+		}
+	}
+	return longest
+}
+
+// clampTop keeps the view within the content
+func (p *Pager) clampTop() {
+	if last := len(p.lines) - p.pageLines(); p.top > last {
+		p.top = last
+	}
+	if p.top < 0 {
+		p.top = 0
+	}
+}
+
+// Init implements the Component interface
+func (p *Pager) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (p *Pager) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if msg, ok := msg.(terminus.ScrollbackMsg); ok {
+		if p.readingScrollback {
+			p.readingScrollback = false
+			lines := make([]string, len(msg.Lines))
+			for i, line := range msg.Lines {
+				lines[i] = stripStyles(line)
+			}
+			p.SetLines(lines).GotoLine(msg.Screen + 1)
+		}
+		return p, nil
+	}
+	if !p.Focused() {
+		return p, nil
+	}
+
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	if p.prompt != promptNone {
+		p.updatePrompt(keyMsg)
+		return p, nil
+	}
+	p.notice = ""
+
+	// A count repeats the motion that follows it, and g and G go to the
+	// line it numbers
+	if p.count.feed(keyMsg) {
+		return p, nil
+	}
+	line := p.count.take()
+	count := max(line, 1)
+
+	switch keyMsg.Type {
+	case terminus.KeyUp:
+		p.scroll(-count)
+	case terminus.KeyDown, terminus.KeyEnter:
+		p.scroll(count)
+	case terminus.KeyPgUp:
+		p.scroll(-count * p.pageLines())
+	case terminus.KeyPgDown, terminus.KeySpace:
+		p.scroll(count * p.pageLines())
+	case terminus.KeyHome:
+		p.top = 0
+	case terminus.KeyEnd:
+		p.top = len(p.lines)
+		p.clampTop()
+	case terminus.KeyLeft:
+		p.scrollHorizontal(-count * p.hscroll)
+	case terminus.KeyRight:
+		p.scrollHorizontal(count * p.hscroll)
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) != 1 {
+			break
+		}
+		switch keyMsg.Runes[0] {
+		case 'k':
+			p.scroll(-count)
+		case 'j':
+			p.scroll(count)
+		case 'b':
+			p.scroll(-count * p.pageLines())
+		case 'f':
+			p.scroll(count * p.pageLines())
+		case 'u':
+			p.scroll(-count * p.pageLines() / 2)
+		case 'd':
+			p.scroll(count * p.pageLines() / 2)
+		case 'g', 'G':
+			switch {
+			case line > 0:
+				p.GotoLine(line)
+			case keyMsg.Runes[0] == 'g':
+				p.top = 0
+			default:
+				p.top = len(p.lines)
+				p.clampTop()
+			}
+		case 'h':
+			p.scrollHorizontal(-count * p.hscroll)
+		case 'l':
+			p.scrollHorizontal(count * p.hscroll)
+		case 'n':
+			p.NextMatch()
+		case 'N':
+			p.PrevMatch()
+		case '/':
+			p.prompt, p.input = promptSearch, ""
+		case ':':
+			p.prompt, p.input = promptLine, ""
+		}
+	}
+
+	return p, nil
+}
+
+// updatePrompt edits the search or line number being typed
+func (p *Pager) updatePrompt(msg terminus.KeyMsg) {
+	switch msg.Type {
+	case terminus.KeyEsc:
+		p.prompt = promptNone
+	case terminus.KeyBackspace:
+		if p.input == "" {
+			p.prompt = promptNone
+		} else {
+			runes := []rune(p.input)
+			p.input = string(runes[:len(runes)-1])
+		}
+	case terminus.KeySpace:
+		p.input += " "
+	case terminus.KeyRunes:
+		p.input += string(msg.Runes)
+	case terminus.KeyEnter:
+		prompt := p.prompt
+		p.prompt = promptNone
+		if prompt == promptSearch {
+			p.Search(p.input)
+		} else if line, err := strconv.Atoi(strings.TrimSpace(p.input)); err == nil {
+			p.GotoLine(line)
+		} else if p.input != "" {
+			p.notice = "Invalid line number"
+		}
+	}
+}
+
+// scroll moves the view by delta lines
+func (p *Pager) scroll(delta int) {
+	p.top += delta
+	p.clampTop()
+}
+
+// scrollHorizontal moves the view by delta columns
+func (p *Pager) scrollHorizontal(delta int) {
+	p.left += delta
+	if p.left < 0 {
+		p.left = 0
+	}
+}
+
+// View implements the Component interface
+func (p *Pager) View() string {
+	n := p.pageLines()
+	lines := make([]string, 0, p.height)
+	for i := p.top; i < p.top+n; i++ {
+		// The gutter stays put when the text scrolls horizontally
+		var gutter string
+		if p.gutter != nil {
+			gutter = p.gutter.Render(i+1, len(p.lines))
+		}
+		if i < len(p.lines) {
+			lines = append(lines, gutter+p.renderLine(p.lines[i]))
+		} else {
+			lines = append(lines, gutter+p.style.Render("~"))
+		}
+	}
+	if p.scrollbar != nil {
+		width := p.gutterWidth() + p.textWidth()
+		lines = attachScrollbar(lines, width, p.scrollbar.Cells(n, len(p.lines), n, p.top))
+	}
+	if p.hscrollbar != nil {
+		width := p.textWidth()
+		lines = append(lines, strings.Repeat(" ", p.gutterWidth())+
+			strings.Join(p.hscrollbar.Cells(width, p.longestLine(), width, p.left), ""))
+	}
+
+	if p.showStatus && p.height > 1 {
+		lines = append(lines, p.renderStatus())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderLine renders the visible columns of a line, highlighting matches
+func (p *Pager) renderLine(line string) string {
+	runes := []rune(line)
+	if p.left >= len(runes) {
+		return ""
+	}
+	end := p.left + p.textWidth()
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	// Mark which visible columns belong to a match
+	highlight := make([]bool, end-p.left)
+	if p.query != "" {
+		qlen := len([]rune(p.query))
+		for _, col := range matchColumns(line, p.query) {
+			for c := col; c < col+qlen; c++ {
+				if c >= p.left && c < end {
+					highlight[c-p.left] = true
+				}
+			}
+		}
+	}
+
+	// Render runs of plain and highlighted text
+	var b strings.Builder
+	start := p.left
+	for c := p.left; c <= end; c++ {
+		if c < end && highlight[c-p.left] == highlight[start-p.left] {
+			continue
+		}
+		text := string(runes[start:c])
+		if highlight[start-p.left] {
+			b.WriteString(p.highlightStyle.Render(text))
+		} else {
+			b.WriteString(p.style.Render(text))
+		}
+		start = c
+	}
+	return b.String()
+}
+
+// renderStatus renders the prompt, or the position and search state
+func (p *Pager) renderStatus() string {
+	var left string
+	switch {
+	case p.prompt == promptSearch:
+		left = "/" + p.input
+	case p.prompt == promptLine:
+		left = ":" + p.input
+	case p.notice != "":
+		left = p.notice
+	default:
+		bottom := p.top + p.pageLines()
+		if bottom > len(p.lines) {
+			bottom = len(p.lines)
+		}
+		left = fmt.Sprintf("lines %d-%d/%d", p.top+1, bottom, len(p.lines))
+		if p.left > 0 {
+			left += fmt.Sprintf(" col %d", p.left+1)
+		}
+		if len(p.matches) > 0 {
+			left += fmt.Sprintf(" [%d/%d matches]", p.matchIdx+1, len(p.matches))
+		}
+	}
+
+	right := fmt.Sprintf("%d%%", p.Percent())
+	gap := p.width - len([]rune(left)) - len(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return p.statusStyle.Render(left + strings.Repeat(" ", gap) + right)
+}
+
+// matchColumns returns the rune offsets where query occurs in line,
+// ignoring case
+func matchColumns(line, query string) []int {
+	lineRunes := foldRunes(line)
+	queryRunes := foldRunes(query)
+	if len(queryRunes) == 0 {
+		return nil
+	}
+
+	var cols []int
+	for i := 0; i+len(queryRunes) <= len(lineRunes); i++ {
+		if string(lineRunes[i:i+len(queryRunes)]) == string(queryRunes) {
+			cols = append(cols, i)
+			i += len(queryRunes) - 1
+		}
+	}
+	return cols
+}
+
+// foldRunes lower-cases s rune by rune, so offsets match the original
+func foldRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
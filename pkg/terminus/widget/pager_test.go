@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// newTestPager returns a focused, unstyled pager over 100 numbered lines
+func newTestPager(width, height int) *Pager {
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	p := NewPager().
+		SetLines(lines).
+		SetStyle(terminus.NewStyle()).
+		SetHighlightStyle(terminus.NewStyle()).
+		SetStatusStyle(terminus.NewStyle())
+	p.SetSize(width, height)
+	p.Focus()
+	return p
+}
+
+// typeKeys sends each rune of s to the pager, then Enter
+func typeKeys(p *Pager, s string) {
+	for _, r := range s {
+		p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{r}})
+	}
+	p.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Scrolls and reports position",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 11)
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+				if p.Line() != 11 {
+					t.Errorf("Expected a page down to line 11, got %d", p.Line())
+				}
+				if p.Percent() != 20 {
+					t.Errorf("Expected 20%%, got %d", p.Percent())
+				}
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{'G'}})
+				if p.Line() != 91 || p.Percent() != 100 {
+					t.Errorf("Expected end at line 91 and 100%%, got %d and %d", p.Line(), p.Percent())
+				}
+
+				lines := strings.Split(p.View(), "\n")
+				if len(lines) != 11 {
+					t.Fatalf("Expected 11 lines, got %d", len(lines))
+				}
+				if !strings.HasPrefix(lines[10], "lines 91-100/100") || !strings.HasSuffix(lines[10], "100%") {
+					t.Errorf("Unexpected status line %q", lines[10])
+				}
+			},
+		},
+		{
+			name: "Pages the scrollback it asked for",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 3)
+				p.Update(terminus.ScrollbackMsg{Lines: []string{"unasked"}})
+				if p.LineCount() != 100 {
+					t.Fatalf("Expected a scrollback it didn't ask for to be ignored, got %d lines", p.LineCount())
+				}
+
+				cmd := p.ShowScrollback()
+				if cmd == nil {
+					t.Fatal("Expected a command reading the scrollback")
+				}
+				p.Update(terminus.ScrollbackMsg{
+					Lines:  []string{"old 1", "old 2", "\x1b[1mscreen 1\x1b[0m", "screen 2"},
+					Screen: 2,
+				})
+				if p.LineCount() != 4 || p.Line() != 3 {
+					t.Errorf("Expected the screen's first line at the top, got line %d of %d", p.Line(), p.LineCount())
+				}
+				if lines := strings.Split(p.View(), "\n"); lines[0] != "screen 1" {
+					t.Errorf("Expected the lines without styles, got %q", lines[0])
+				}
+			},
+		},
+		{
+			name: "Jumps to a line",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 11)
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{':'}})
+				typeKeys(p, "42")
+
+				if p.Line() != 42 {
+					t.Errorf("Expected line 42, got %d", p.Line())
+				}
+				if first := strings.Split(p.View(), "\n")[0]; first != "line 42" {
+					t.Errorf("Expected line 42 on top, got %q", first)
+				}
+			},
+		},
+		{
+			name: "Searches and steps through matches",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 11).SetHighlightStyle(terminus.NewStyle().Reverse(true))
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{'/'}})
+				typeKeys(p, "LINE 5")
+
+				if p.MatchCount() != 11 {
+					t.Errorf("Expected 11 matching lines, got %d", p.MatchCount())
+				}
+				if p.Line() != 5 {
+					t.Errorf("Expected first match on line 5, got %d", p.Line())
+				}
+				if !strings.Contains(p.View(), "\x1b[0;7mline 5\x1b[0m") {
+					t.Errorf("Expected highlighted match, got %q", strings.Split(p.View(), "\n")[0])
+				}
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{'n'}})
+				if p.Line() != 50 {
+					t.Errorf("Expected next match on line 50, got %d", p.Line())
+				}
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{'N'}})
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{'N'}})
+				if p.Line() != 59 {
+					t.Errorf("Expected previous match to wrap to line 59, got %d", p.Line())
+				}
+			},
+		},
+		{
+			name: "Replaced lines keep the position and search",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 11)
+				more := func(n int) []string {
+					lines := append([]string(nil), p.lines...)
+					for i := 0; i < n; i++ {
+						lines = append(lines, fmt.Sprintf("line %d", len(lines)+1))
+					}
+					return lines
+				}
+
+				p.GotoLine(20).ReplaceLines(more(5))
+				if p.Line() != 20 {
+					t.Errorf("Expected to stay on line 20, got %d", p.Line())
+				}
+
+				p.GotoLine(p.LineCount()).ReplaceLines(more(5))
+				if p.Line() != 101 {
+					t.Errorf("Expected the bottom to follow to line 101, got %d", p.Line())
+				}
+
+				p.Search("line 7")
+				p.StepMatch(1)
+				p.ReplaceLines(append(more(0), "line 7 again"))
+				if p.MatchIndex() != 1 || p.Line() != 70 {
+					t.Errorf("Expected to stay on the match at line 70, got match %d at line %d", p.MatchIndex(), p.Line())
+				}
+				if p.MatchCount() != 12 {
+					t.Errorf("Expected the new line to match, got %d matches", p.MatchCount())
+				}
+			},
+		},
+		{
+			name: "Reports missing patterns",
+			test: func(t *testing.T) {
+				p := newTestPager(40, 5)
+				p.Search("nope")
+
+				if !strings.Contains(p.View(), "Pattern not found") {
+					t.Errorf("Expected not-found notice, got %q", p.View())
+				}
+			},
+		},
+		{
+			name: "Scrolls horizontally",
+			test: func(t *testing.T) {
+				p := NewPager().SetContent("0123456789abcdefghij\nshort").SetShowStatus(false)
+				p.SetSize(5, 2)
+				p.Focus()
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				lines := strings.Split(p.View(), "\n")
+				if lines[0] != "89abc" || lines[1] != "" {
+					t.Errorf("Expected columns 8-12, got %q", lines)
+				}
+
+				p.Search("j")
+				if p.Column() != 17 {
+					t.Errorf("Expected search to scroll to the match, got column %d", p.Column())
+				}
+			},
+		},
+		{
+			name: "Expands tabs to tab stops",
+			test: func(t *testing.T) {
+				p := NewPager().SetTabWidth(4).SetContent("a\tb\nabcd\tc").SetShowStatus(false)
+				p.SetSize(10, 2)
+
+				lines := strings.Split(p.View(), "\n")
+				if strings.TrimRight(lines[0], " ") != "a   b" || strings.TrimRight(lines[1], " ") != "abcd    c" {
+					t.Errorf("Expected 4 column tab stops, got %q", lines)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"math"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+	"github.com/skaiser/terminusgo/pkg/terminus/layout"
+)
+
+// PanelPulseDuration is how long the border of a Panel that requests
+// attention pulses; it stays highlighted afterwards until the panel is
+// focused
+const PanelPulseDuration = 1500 * time.Millisecond
+
+// panelPulses is how many times the border pulses
+const panelPulses = 3
+
+// AttentionMsg is sent when a Panel requests attention. A Compose with
+// focus following activity moves focus to the panel.
+type AttentionMsg struct {
+	Panel *Panel
+}
+
+// Panel draws a child in a box with a title, with a double border while it
+// is focused. A panel that isn't focused can request attention, e.g. when
+// an error lands in it while the user is looking elsewhere: its border and
+// title pulse, then stay highlighted until it is focused. Pass Panel all
+// messages, and it passes them on to its child.
+type Panel struct {
+	Model
+
+	child          terminus.Component
+	title          string
+	padding        int
+	attention      bool
+	pulse          *terminus.Animation
+	attentionColor terminus.Color
+}
+
+// NewPanel creates a panel titled title around child. It fits the child's
+// view until it is given a size.
+func NewPanel(title string, child terminus.Component) *Panel {
+	p := &Panel{
+		Model:          NewModel(),
+		child:          child,
+		title:          title,
+		attentionColor: terminus.Yellow,
+	}
+	p.width, p.height = 0, 0
+	return p
+}
+
+// SetTitle sets the title shown in the top border
+func (p *Panel) SetTitle(title string) *Panel {
+	p.title = title
+	return p
+}
+
+// SetPadding sets the blank cells between the border and the child
+func (p *Panel) SetPadding(padding int) *Panel {
+	p.padding = max(0, padding)
+	p.sizeChild()
+	return p
+}
+
+// SetAttentionColor sets the color the border is highlighted in while the
+// panel wants attention
+func (p *Panel) SetAttentionColor(color terminus.Color) *Panel {
+	p.attentionColor = color
+	return p
+}
+
+// Child returns the panel's child
+func (p *Panel) Child() terminus.Component {
+	return p.child
+}
+
+// RequestAttention highlights the panel until it is focused, and returns
+// the command that pulses its border and sends an AttentionMsg. A focused
+// panel is already being looked at, and nil is returned.
+func (p *Panel) RequestAttention() terminus.Cmd {
+	if p.focused {
+		return nil
+	}
+	p.attention = true
+	p.pulse = terminus.Animate(0, panelPulses, PanelPulseDuration, terminus.Linear)
+	return terminus.Batch(p.pulse.Start(), func() terminus.Msg {
+		return AttentionMsg{Panel: p}
+	})
+}
+
+// WantsAttention reports whether the panel has requested attention and
+// hasn't been focused since
+func (p *Panel) WantsAttention() bool {
+	return p.attention
+}
+
+// Focus focuses the panel and its child, if it is a widget, and clears
+// any request for attention
+func (p *Panel) Focus() {
+	p.Model.Focus()
+	p.attention, p.pulse = false, nil
+	if w, ok := p.child.(Widget); ok {
+		w.Focus()
+	}
+}
+
+// Blur blurs the panel and its child, if it is a widget
+func (p *Panel) Blur() {
+	p.Model.Blur()
+	if w, ok := p.child.(Widget); ok {
+		w.Blur()
+	}
+}
+
+// SetSize sets the size of the panel, border included, and gives the rest
+// to its child, if it is a widget
+func (p *Panel) SetSize(width, height int) {
+	p.Model.SetSize(width, height)
+	p.sizeChild()
+}
+
+// Measure returns the constraints of the child with the border and padding
+// around it
+func (p *Panel) Measure() Constraints {
+	if p.hidden {
+		return Constraints{}
+	}
+	frame := 2 + 2*p.padding
+	// grow adds the frame to the dimensions of s that are set, or all of
+	// them
+	grow := func(s Size, all bool) Size {
+		if all || s.Width > 0 {
+			s.Width += frame
+		}
+		if all || s.Height > 0 {
+			s.Height += frame
+		}
+		return s
+	}
+	c := Measure(p.child)
+	return Constraints{Min: grow(c.Min, true), Preferred: grow(c.Preferred, false), Max: grow(c.Max, false)}
+}
+
+// sizeChild gives the child the space inside the border and padding
+func (p *Panel) sizeChild() {
+	if w, ok := p.child.(Widget); ok && p.width > 0 && p.height > 0 {
+		w.SetSize(max(0, p.width-2-2*p.padding), max(0, p.height-2-2*p.padding))
+	}
+}
+
+// Init implements the Component interface
+func (p *Panel) Init() terminus.Cmd {
+	return p.child.Init()
+}
+
+// Update implements the Component interface
+func (p *Panel) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	var pulseCmd terminus.Cmd
+	if p.pulse != nil {
+		pulseCmd = p.pulse.Update(msg)
+	}
+	var cmd terminus.Cmd
+	p.child, cmd = p.child.Update(msg)
+	return p, terminus.Batch(pulseCmd, cmd)
+}
+
+// borderStyle returns the style of the border and title: a pulse between
+// gray and the attention color, then the attention color, while the panel
+// wants attention
+func (p *Panel) borderStyle() terminus.Style {
+	if !p.attention {
+		return terminus.NewStyle()
+	}
+	strength := 1.0
+	if p.pulse != nil && !p.pulse.Done() {
+		_, phase := math.Modf(p.pulse.Value())
+		strength = math.Abs(2*phase - 1)
+	}
+	return terminus.NewStyle().Bold(true).Foreground(terminus.Blend(terminus.BrightBlack, p.attentionColor, strength))
+}
+
+// View implements the Component interface
+func (p *Panel) View() string {
+	boxStyle := layout.BoxStyleSingle
+	if p.focused {
+		boxStyle = layout.BoxStyleDouble
+	}
+	box := layout.NewBox(p.child.View()).
+		WithStyle(boxStyle).
+		WithTitle(p.title).
+		WithUniformPadding(p.padding).
+		WithBorderStyle(p.borderStyle())
+	if p.width > 0 && p.height > 0 {
+		box.WithWidth(max(0, p.width-2-2*p.padding)).WithHeight(max(0, p.height-2-2*p.padding))
+	}
+	return box.Render()
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestPanel(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Fits the child and doubles the border when focused",
+			test: func(t *testing.T) {
+				p := NewPanel("Log", &probe{view: "hello"})
+				if view := p.View(); !strings.Contains(view, "│hello│") || !strings.HasPrefix(view, "┌") {
+					t.Errorf("Expected the child in a single border, got:\n%s", view)
+				}
+				p.Focus()
+				if view := p.View(); !strings.Contains(view, "║hello║") {
+					t.Errorf("Expected a double border, got:\n%s", view)
+				}
+			},
+		},
+		{
+			name: "Gives its child the space inside the border",
+			test: func(t *testing.T) {
+				list := NewList()
+				NewPanel("Items", list).SetPadding(1).SetSize(20, 8)
+				if width, height := list.GetSize(); width != 16 || height != 4 {
+					t.Errorf("Expected 16x4, got %dx%d", width, height)
+				}
+			},
+		},
+		{
+			name: "Highlights the border until focused",
+			test: func(t *testing.T) {
+				p := NewPanel("Alerts", &probe{view: "disk almost full"})
+				plain := p.View()
+				if p.RequestAttention() == nil || !p.WantsAttention() {
+					t.Fatal("Expected the panel to want attention")
+				}
+				p.pulse.Finish()
+				highlighted := terminus.NewStyle().Bold(true).Foreground(terminus.Blend(terminus.BrightBlack, terminus.Yellow, 1))
+				if view := p.View(); !strings.Contains(view, highlighted.Render(" Alerts ")) {
+					t.Errorf("Expected a highlighted title, got %q", view)
+				}
+
+				p.Focus()
+				if p.WantsAttention() || p.View() == plain {
+					t.Error("Expected focus to clear the highlight and double the border")
+				}
+				if p.RequestAttention() != nil || p.WantsAttention() {
+					t.Error("Expected a focused panel not to request attention")
+				}
+			},
+		},
+		{
+			name: "Pulses on frames of the animation clock",
+			test: func(t *testing.T) {
+				p := NewPanel("Alerts", &probe{view: "error"})
+				p.RequestAttention()
+				start := p.View()
+				p.Update(terminus.FrameMsg{Time: time.Now().Add(PanelPulseDuration / panelPulses / 2)})
+				if p.View() == start {
+					t.Error("Expected the border to change as it pulses")
+				}
+				if _, cmd := p.Update(terminus.FrameMsg{Time: time.Now().Add(PanelPulseDuration)}); cmd != nil || !p.pulse.Done() {
+					t.Error("Expected the pulse to end")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
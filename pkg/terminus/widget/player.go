@@ -0,0 +1,333 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// playerFrame is how often a playing Player advances
+const playerFrame = time.Second / 30
+
+// Playback speed limits
+const (
+	minPlayerSpeed = 0.125
+	maxPlayerSpeed = 16
+)
+
+// PlayerTickMsg advances a playing Player
+type PlayerTickMsg struct {
+	ID   string
+	Time time.Time
+	gen  int
+}
+
+// Player replays a recorded session, such as one written by
+// terminus.WithRecording. Space pauses and resumes, +/- change the speed,
+// left/right seek by five seconds and r restarts.
+type Player struct {
+	Model
+
+	id       string
+	cast     *terminus.Cast
+	speed    float64
+	autoplay bool
+	loop     bool
+	status   bool
+
+	playing  bool
+	gen      int // Invalidates ticks from earlier play/pause cycles
+	lastTick time.Time
+	position time.Duration
+	next     int // Index of the next event to apply
+	screen   string
+	now      func() time.Time
+
+	statusStyle terminus.Style
+}
+
+// NewPlayer creates a player for cast. With no size set it shows as many
+// lines as the recording's terminal had.
+func NewPlayer(cast *terminus.Cast) *Player {
+	m := NewModel()
+	m.width = 0
+	m.height = 0
+	return &Player{
+		Model:       m,
+		id:          "player",
+		cast:        cast,
+		speed:       1,
+		autoplay:    true,
+		status:      true,
+		now:         time.Now,
+		statusStyle: terminus.NewStyle().Faint(true),
+	}
+}
+
+// SetID sets the ID carried by the player's tick messages, needed when an
+// app shows more than one player
+func (p *Player) SetID(id string) *Player {
+	p.id = id
+	return p
+}
+
+// SetCast replaces the recording and rewinds to the start
+func (p *Player) SetCast(cast *terminus.Cast) *Player {
+	p.cast = cast
+	p.Seek(0)
+	return p
+}
+
+// SetSpeed sets the playback speed, e.g. 2 for double speed
+func (p *Player) SetSpeed(speed float64) *Player {
+	if speed < minPlayerSpeed {
+		speed = minPlayerSpeed
+	}
+	if speed > maxPlayerSpeed {
+		speed = maxPlayerSpeed
+	}
+	p.speed = speed
+	return p
+}
+
+// Speed returns the playback speed
+func (p *Player) Speed() float64 {
+	return p.speed
+}
+
+// SetAutoplay sets whether playback starts when the player is initialized
+func (p *Player) SetAutoplay(autoplay bool) *Player {
+	p.autoplay = autoplay
+	return p
+}
+
+// SetLoop sets whether playback restarts at the end
+func (p *Player) SetLoop(loop bool) *Player {
+	p.loop = loop
+	return p
+}
+
+// SetShowStatus sets whether the status line is shown below the recording
+func (p *Player) SetShowStatus(show bool) *Player {
+	p.status = show
+	return p
+}
+
+// SetStatusStyle sets the style of the status line
+func (p *Player) SetStatusStyle(style terminus.Style) *Player {
+	p.statusStyle = style
+	return p
+}
+
+// Playing reports whether playback is running
+func (p *Player) Playing() bool {
+	return p.playing
+}
+
+// Position returns the playback position
+func (p *Player) Position() time.Duration {
+	return p.position
+}
+
+// Duration returns the length of the recording
+func (p *Player) Duration() time.Duration {
+	if p.cast == nil {
+		return 0
+	}
+	return p.cast.Duration()
+}
+
+// Play starts or resumes playback and returns the command that drives it
+func (p *Player) Play() terminus.Cmd {
+	if p.playing || p.cast == nil {
+		return nil
+	}
+	if p.position >= p.Duration() {
+		p.Seek(0)
+	}
+	p.playing = true
+	p.gen++
+	p.lastTick = p.now()
+	return p.tick()
+}
+
+// Pause stops playback at the current position
+func (p *Player) Pause() *Player {
+	p.playing = false
+	p.gen++
+	return p
+}
+
+// Seek moves playback to position
+func (p *Player) Seek(position time.Duration) *Player {
+	if position < 0 {
+		position = 0
+	}
+	if d := p.Duration(); position > d {
+		position = d
+	}
+	if position < p.position {
+		p.screen, p.next = "", 0
+	}
+	p.position = position
+	p.apply()
+	return p
+}
+
+// tick schedules the next frame of playback
+func (p *Player) tick() terminus.Cmd {
+	id, gen := p.id, p.gen
+	return terminus.Tick(playerFrame, func(t time.Time) terminus.Msg {
+		return PlayerTickMsg{ID: id, Time: t, gen: gen}
+	})
+}
+
+// apply plays the events up to the current position
+func (p *Player) apply() {
+	if p.cast == nil {
+		return
+	}
+	for ; p.next < len(p.cast.Events); p.next++ {
+		event := p.cast.Events[p.next]
+		if event.Time > p.position {
+			break
+		}
+		if event.Type != terminus.CastOutput {
+			continue
+		}
+		// Recordings redraw the whole screen each frame, so only the
+		// output after the last clear is visible
+		if i := strings.LastIndex(event.Data, "\x1b[2J"); i >= 0 {
+			p.screen = event.Data[i+len("\x1b[2J"):]
+		} else {
+			p.screen += event.Data
+		}
+	}
+}
+
+// Init implements the Component interface
+func (p *Player) Init() terminus.Cmd {
+	p.apply()
+	if p.autoplay {
+		return p.Play()
+	}
+	return nil
+}
+
+// Update implements the Component interface
+func (p *Player) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if tick, ok := msg.(PlayerTickMsg); ok {
+		if tick.ID != p.id || tick.gen != p.gen || !p.playing {
+			return p, nil
+		}
+		elapsed := tick.Time.Sub(p.lastTick)
+		p.lastTick = tick.Time
+		p.position += time.Duration(float64(elapsed) * p.speed)
+		p.apply()
+
+		if p.position >= p.Duration() {
+			p.position = p.Duration()
+			if !p.loop {
+				p.Pause()
+				return p, nil
+			}
+			p.Seek(0)
+		}
+		return p, p.tick()
+	}
+
+	if !p.Focused() {
+		return p, nil
+	}
+
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	switch keyMsg.Type {
+	case terminus.KeySpace:
+		if p.playing {
+			p.Pause()
+			return p, nil
+		}
+		return p, p.Play()
+	case terminus.KeyLeft:
+		p.Seek(p.position - 5*time.Second)
+	case terminus.KeyRight:
+		p.Seek(p.position + 5*time.Second)
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) != 1 {
+			break
+		}
+		switch keyMsg.Runes[0] {
+		case '+', '=':
+			p.SetSpeed(p.speed * 2)
+		case '-':
+			p.SetSpeed(p.speed / 2)
+		case 'r':
+			p.Seek(0)
+		}
+	}
+	return p, nil
+}
+
+// View implements the Component interface
+func (p *Player) View() string {
+	lines := strings.Split(strings.ReplaceAll(p.screen, "\r\n", "\n"), "\n")
+
+	_, height := p.GetSize()
+	if height == 0 && p.cast != nil {
+		height = p.cast.Height
+		if p.status {
+			height++
+		}
+	}
+	if p.status {
+		height--
+	}
+	if height > 0 {
+		if len(lines) > height {
+			lines = lines[len(lines)-height:]
+		}
+		for len(lines) < height {
+			lines = append(lines, "")
+		}
+	}
+
+	if p.status {
+		lines = append(lines, p.statusStyle.Render(p.statusLine()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statusLine describes the playback state, e.g. "▶ 0:03 / 0:10  2x"
+func (p *Player) statusLine() string {
+	icon := "⏸"
+	if p.playing {
+		icon = "▶"
+	}
+	return fmt.Sprintf("%s %s / %s  %gx", icon, clock(p.position), clock(p.Duration()), p.speed)
+}
+
+// clock formats d as minutes and seconds
+func clock(d time.Duration) string {
+	s := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// testCast has a frame at 0s, 1s and 2s
+func testCast() *terminus.Cast {
+	frame := func(at time.Duration, view string) terminus.CastEvent {
+		return terminus.CastEvent{Time: at, Type: terminus.CastOutput, Data: "\x1b[H\x1b[2J" + view}
+	}
+	return &terminus.Cast{
+		Width:  20,
+		Height: 2,
+		Events: []terminus.CastEvent{
+			frame(0, "one"),
+			frame(time.Second, "two\r\nlines"),
+			frame(2*time.Second, "three"),
+		},
+	}
+}
+
+// startPlayer initializes a player on a fixed clock
+func startPlayer(p *Player) time.Time {
+	start := time.Unix(0, 0)
+	p.now = func() time.Time { return start }
+	p.Init()
+	return start
+}
+
+func TestPlayer(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Plays frames as time passes",
+			test: func(t *testing.T) {
+				p := NewPlayer(testCast()).SetShowStatus(false)
+				start := startPlayer(p)
+				if !p.Playing() || plain(p.View()) != "one\n" {
+					t.Fatalf("Expected the first frame playing, got %q", p.View())
+				}
+
+				_, cmd := p.Update(PlayerTickMsg{ID: "player", Time: start.Add(1100 * time.Millisecond), gen: p.gen})
+				if cmd == nil {
+					t.Error("Expected another tick while playing")
+				}
+				if plain(p.View()) != "two\nlines" {
+					t.Errorf("Expected the second frame, got %q", p.View())
+				}
+
+				_, cmd = p.Update(PlayerTickMsg{ID: "player", Time: start.Add(3 * time.Second), gen: p.gen})
+				if cmd != nil || p.Playing() || p.Position() != 2*time.Second {
+					t.Errorf("Expected playback to stop at the end, at %v", p.Position())
+				}
+			},
+		},
+		{
+			name: "Scales time by the speed",
+			test: func(t *testing.T) {
+				p := NewPlayer(testCast()).SetSpeed(4)
+				start := startPlayer(p)
+				p.Update(PlayerTickMsg{ID: "player", Time: start.Add(300 * time.Millisecond), gen: p.gen})
+				if p.Position() != 1200*time.Millisecond {
+					t.Errorf("Expected 1.2s at 4x, got %v", p.Position())
+				}
+				if p.SetSpeed(100).Speed() != maxPlayerSpeed {
+					t.Errorf("Expected speed clamped to %v, got %v", maxPlayerSpeed, p.Speed())
+				}
+			},
+		},
+		{
+			name: "Ignores stale and foreign ticks",
+			test: func(t *testing.T) {
+				p := NewPlayer(testCast())
+				start := startPlayer(p)
+				stale := p.gen
+				p.Pause()
+				p.Play()
+				p.Update(PlayerTickMsg{ID: "player", Time: start.Add(time.Second), gen: stale})
+				p.Update(PlayerTickMsg{ID: "other", Time: start.Add(time.Second), gen: p.gen})
+				if p.Position() != 0 {
+					t.Errorf("Expected no progress, got %v", p.Position())
+				}
+			},
+		},
+		{
+			name: "Handles keys when focused",
+			test: func(t *testing.T) {
+				p := NewPlayer(testCast()).SetAutoplay(false)
+				startPlayer(p)
+				p.Focus()
+
+				p.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				if p.Position() != 2*time.Second || plain(p.View()) != "three\n\n⏸ 0:02 / 0:02  1x" {
+					t.Errorf("Expected seek to the end, got %q", p.View())
+				}
+				p.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if p.Position() != 0 || !strings.HasPrefix(plain(p.View()), "one") {
+					t.Errorf("Expected seek back to the start, got %q", p.View())
+				}
+
+				p.Update(runeKey('+'))
+				p.Update(runeKey('+'))
+				p.Update(runeKey('-'))
+				if p.Speed() != 2 {
+					t.Errorf("Expected 2x, got %v", p.Speed())
+				}
+
+				if _, cmd := p.Update(terminus.KeyMsg{Type: terminus.KeySpace}); cmd == nil || !p.Playing() {
+					t.Error("Expected space to start playback")
+				}
+				p.Update(terminus.KeyMsg{Type: terminus.KeySpace})
+				if p.Playing() {
+					t.Error("Expected space to pause playback")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// ProgressBar displays the completion of a long-running operation. It
+// updates itself from terminus.ProgressMsg reports.
+type ProgressBar struct {
+	Model
+
+	// Progress state
+	id      string
+	percent float64
+	label   string
+	anim    *terminus.Animation // Moves percent towards a target, if set
+
+	// Configuration
+	fillChar    string
+	emptyChar   string
+	showPercent bool
+
+	// Styling
+	fillStyle  terminus.Style
+	emptyStyle terminus.Style
+	labelStyle terminus.Style
+}
+
+// NewProgressBar creates a new progress bar
+func NewProgressBar() *ProgressBar {
+	m := NewModel()
+	m.width = 30
+	m.singleLine()
+	return &ProgressBar{
+		Model:       m,
+		fillChar:    "█",
+		emptyChar:   "░",
+		showPercent: true,
+		fillStyle:   terminus.NewStyle().Foreground(terminus.Green),
+		emptyStyle:  terminus.NewStyle().Faint(true),
+		labelStyle:  terminus.NewStyle(),
+	}
+}
+
+// SetID sets which ProgressMsg reports the bar follows. An empty ID
+// follows every report.
+func (p *ProgressBar) SetID(id string) *ProgressBar {
+	p.id = id
+	return p
+}
+
+// SetPercent sets the completion percentage (0-100)
+func (p *ProgressBar) SetPercent(percent float64) *ProgressBar {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	p.percent = percent
+	return p
+}
+
+// AnimateTo moves the bar smoothly from its current percentage to percent
+// over duration. Return the command from Update and keep passing the bar
+// its messages, which advance the animation on each terminus.FrameMsg.
+func (p *ProgressBar) AnimateTo(percent float64, duration time.Duration) terminus.Cmd {
+	p.anim = terminus.Animate(p.percent, percent, duration, terminus.EaseOut)
+	return p.anim.Start()
+}
+
+// SetLabel sets the text shown after the bar
+func (p *ProgressBar) SetLabel(label string) *ProgressBar {
+	p.label = label
+	return p
+}
+
+// SetChars sets the characters used for the filled and empty portions
+func (p *ProgressBar) SetChars(fill, empty string) *ProgressBar {
+	p.fillChar = fill
+	p.emptyChar = empty
+	return p
+}
+
+// SetShowPercent sets whether the percentage is displayed
+func (p *ProgressBar) SetShowPercent(show bool) *ProgressBar {
+	p.showPercent = show
+	return p
+}
+
+// SetFillStyle sets the style of the filled portion
+func (p *ProgressBar) SetFillStyle(style terminus.Style) *ProgressBar {
+	p.fillStyle = style
+	return p
+}
+
+// SetEmptyStyle sets the style of the empty portion
+func (p *ProgressBar) SetEmptyStyle(style terminus.Style) *ProgressBar {
+	p.emptyStyle = style
+	return p
+}
+
+// SetLabelStyle sets the style of the label
+func (p *ProgressBar) SetLabelStyle(style terminus.Style) *ProgressBar {
+	p.labelStyle = style
+	return p
+}
+
+// Percent returns the current completion percentage
+func (p *ProgressBar) Percent() float64 {
+	return p.percent
+}
+
+// Label returns the current label
+func (p *ProgressBar) Label() string {
+	return p.label
+}
+
+// Complete returns whether the bar has reached 100%
+func (p *ProgressBar) Complete() bool {
+	return p.percent >= 100
+}
+
+// Init implements the Component interface
+func (p *ProgressBar) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (p *ProgressBar) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if _, ok := msg.(terminus.FrameMsg); ok && p.anim != nil {
+		cmd := p.anim.Update(msg)
+		p.SetPercent(p.anim.Value())
+		if p.anim.Done() {
+			p.anim = nil
+		}
+		return p, cmd
+	}
+	if msg, ok := msg.(terminus.ProgressMsg); ok {
+		if p.id != "" && msg.ID != p.id {
+			return p, nil
+		}
+		p.anim = nil
+		p.SetPercent(msg.Percent())
+		if msg.Label != "" {
+			p.label = msg.Label
+		}
+	}
+	return p, nil
+}
+
+// View implements the Component interface
+func (p *ProgressBar) View() string {
+	width := p.width
+	if width < 1 {
+		width = 1
+	}
+
+	filled := int(p.percent / 100 * float64(width))
+	if filled > width {
+		filled = width
+	}
+
+	var b strings.Builder
+	if filled > 0 {
+		b.WriteString(p.fillStyle.Render(strings.Repeat(p.fillChar, filled)))
+	}
+	if filled < width {
+		b.WriteString(p.emptyStyle.Render(strings.Repeat(p.emptyChar, width-filled)))
+	}
+	if p.showPercent {
+		b.WriteString(fmt.Sprintf(" %3.0f%%", p.percent))
+	}
+	if p.label != "" {
+		b.WriteString(" ")
+		b.WriteString(p.labelStyle.Render(p.label))
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// newPlainProgressBar returns a progress bar without styling for easy comparison
+func newPlainProgressBar(width int) *ProgressBar {
+	p := NewProgressBar().
+		SetChars("#", "-").
+		SetFillStyle(terminus.NewStyle()).
+		SetEmptyStyle(terminus.NewStyle())
+	p.SetSize(width, 1)
+	return p
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Renders percentage",
+			test: func(t *testing.T) {
+				p := newPlainProgressBar(10).SetPercent(40)
+				if got := p.View(); got != "####------  40%" {
+					t.Errorf("Unexpected view %q", got)
+				}
+			},
+		},
+		{
+			name: "Clamps percentage",
+			test: func(t *testing.T) {
+				p := newPlainProgressBar(10)
+				if p.SetPercent(150).Percent() != 100 {
+					t.Error("Expected percentage to be clamped to 100")
+				}
+				if p.SetPercent(-5).Percent() != 0 {
+					t.Error("Expected percentage to be clamped to 0")
+				}
+			},
+		},
+		{
+			name: "Updates from ProgressMsg",
+			test: func(t *testing.T) {
+				p := newPlainProgressBar(10).SetShowPercent(false)
+				p.Update(terminus.ProgressMsg{Current: 5, Total: 10, Label: "copying"})
+
+				if p.Percent() != 50 {
+					t.Errorf("Expected 50%%, got %v", p.Percent())
+				}
+				if got := p.View(); got != "#####----- copying" {
+					t.Errorf("Unexpected view %q", got)
+				}
+			},
+		},
+		{
+			name: "Animates towards a percentage",
+			test: func(t *testing.T) {
+				p := newPlainProgressBar(10)
+				if p.AnimateTo(80, 100*time.Millisecond) == nil {
+					t.Fatal("Expected a command for the first frame")
+				}
+				p.Update(terminus.FrameMsg{Time: time.Now().Add(50 * time.Millisecond)})
+				if p.Percent() <= 0 || p.Percent() >= 80 {
+					t.Errorf("Expected a percentage between 0 and 80 halfway, got %v", p.Percent())
+				}
+				if _, cmd := p.Update(terminus.FrameMsg{Time: time.Now().Add(time.Second)}); cmd != nil || p.Percent() != 80 {
+					t.Errorf("Expected the animation to end at 80%%, got %v", p.Percent())
+				}
+			},
+		},
+		{
+			name: "Ignores other IDs",
+			test: func(t *testing.T) {
+				p := newPlainProgressBar(10).SetID("upload")
+				p.Update(terminus.ProgressMsg{ID: "download", Current: 10, Total: 10})
+				if p.Percent() != 0 {
+					t.Error("Progress for another ID should be ignored")
+				}
+
+				p.Update(terminus.ProgressMsg{ID: "upload", Current: 10, Total: 10})
+				if !p.Complete() {
+					t.Error("Expected bar to be complete")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
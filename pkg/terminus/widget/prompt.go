@@ -0,0 +1,410 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// maxPromptHistory is the number of command lines a CommandPrompt keeps
+const maxPromptHistory = 100
+
+// CommandMsg is sent when a CommandPrompt runs a command without a Run
+// function, or one it doesn't know
+type CommandMsg struct {
+	Name  string   // The first word of the line
+	Args  []string // The remaining words; quoted words may contain spaces
+	Line  string   // The line as typed
+	Known bool     // Whether the command was added to the prompt
+}
+
+// PromptCommand is a command a CommandPrompt can complete and run
+type PromptCommand struct {
+	Name  string
+	Usage string // Arguments, e.g. "<name>", for help
+	Help  string // One-line description
+
+	// Complete returns the possible values of the argument being typed,
+	// given the arguments before it. Optional.
+	Complete func(args []string, prefix string) []string
+	// Run runs the command. Without it, the prompt sends a CommandMsg.
+	Run func(args []string) terminus.Cmd
+	// Roles lists the roles allowed to run the command; any one will do.
+	// Without them, anyone can.
+	Roles []string
+}
+
+// CommandPrompt is a vim-style command line. The trigger key, ":" by
+// default, opens it over the bottom line of the view; Enter parses the line
+// and runs the command, Esc closes it, Up and Down recall earlier lines and
+// Tab completes command names and arguments.
+type CommandPrompt struct {
+	Model
+
+	input    *TextInput
+	commands map[string]PromptCommand
+	trigger  rune
+
+	// History, most recent last
+	history    []string
+	historyIdx int    // len(history) when not browsing
+	draft      string // The line being typed before browsing
+
+	candidates []string // Shown after an ambiguous completion
+	message    string   // Shown while closed, e.g. an error
+	roles      terminus.RoleSet
+
+	// Styling
+	promptStyle    terminus.Style
+	candidateStyle terminus.Style
+	messageStyle   terminus.Style
+}
+
+// NewCommandPrompt creates a new command prompt
+func NewCommandPrompt() *CommandPrompt {
+	m := NewModel()
+	m.width = 80
+	input := NewTextInput().SetFocusStyle(terminus.NewStyle())
+	input.SetMaxLength(512)
+	input.Focus()
+	return &CommandPrompt{
+		Model:          m,
+		input:          input,
+		commands:       make(map[string]PromptCommand),
+		trigger:        ':',
+		promptStyle:    terminus.NewStyle().Bold(true),
+		candidateStyle: terminus.NewStyle().Faint(true),
+		messageStyle:   terminus.NewStyle(),
+	}
+}
+
+// AddCommand adds a command, replacing any with the same name
+func (p *CommandPrompt) AddCommand(cmd PromptCommand) *CommandPrompt {
+	p.commands[cmd.Name] = cmd
+	return p
+}
+
+// SetRoles sets the roles of the user, as a RolesMsg does. Commands that
+// need a role the user doesn't hold are left out of Commands and
+// completion, and refused if typed.
+func (p *CommandPrompt) SetRoles(roles terminus.RoleSet) *CommandPrompt {
+	p.roles = roles
+	return p
+}
+
+// allowed reports whether the user may run cmd
+func (p *CommandPrompt) allowed(cmd PromptCommand) bool {
+	return p.roles.Allows(cmd.Roles...)
+}
+
+// Commands returns the commands the user may run, sorted by name
+func (p *CommandPrompt) Commands() []PromptCommand {
+	cmds := make([]PromptCommand, 0, len(p.commands))
+	for _, cmd := range p.commands {
+		if p.allowed(cmd) {
+			cmds = append(cmds, cmd)
+		}
+	}
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Name < cmds[j].Name
+	})
+	return cmds
+}
+
+// SetTrigger sets the key that opens the prompt
+func (p *CommandPrompt) SetTrigger(r rune) *CommandPrompt {
+	p.trigger = r
+	return p
+}
+
+// SetPromptStyle sets the style of the trigger shown before the line
+func (p *CommandPrompt) SetPromptStyle(style terminus.Style) *CommandPrompt {
+	p.promptStyle = style
+	return p
+}
+
+// SetCandidateStyle sets the style of completion candidates
+func (p *CommandPrompt) SetCandidateStyle(style terminus.Style) *CommandPrompt {
+	p.candidateStyle = style
+	return p
+}
+
+// SetMessage shows text on the bottom line while the prompt is closed,
+// until it next opens
+func (p *CommandPrompt) SetMessage(text string, style terminus.Style) *CommandPrompt {
+	p.message = text
+	p.messageStyle = style
+	return p
+}
+
+// History returns the lines entered, oldest first
+func (p *CommandPrompt) History() []string {
+	return p.history
+}
+
+// Open empties the line and focuses the prompt
+func (p *CommandPrompt) Open() {
+	p.input.Clear()
+	p.historyIdx = len(p.history)
+	p.candidates = nil
+	p.message = ""
+	p.Focus()
+}
+
+// Close blurs the prompt, discarding the line
+func (p *CommandPrompt) Close() {
+	p.input.Clear()
+	p.candidates = nil
+	p.Blur()
+}
+
+// OpenOn opens the prompt if key is its trigger and reports whether it did.
+// Call it for keys the rest of the application would otherwise handle.
+func (p *CommandPrompt) OpenOn(key terminus.KeyMsg) bool {
+	if p.Focused() || key.Type != terminus.KeyRunes || len(key.Runes) != 1 || key.Runes[0] != p.trigger {
+		return false
+	}
+	p.Open()
+	return true
+}
+
+// Init implements the Component interface. It asks for the session's
+// roles, for commands that need them.
+func (p *CommandPrompt) Init() terminus.Cmd {
+	return terminus.RequestRoles()
+}
+
+// Update implements the Component interface
+func (p *CommandPrompt) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if roles, ok := msg.(terminus.RolesMsg); ok {
+		p.SetRoles(roles.Roles)
+	}
+	if !p.Focused() {
+		return p, nil
+	}
+
+	key, ok := msg.(terminus.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	switch key.Type {
+	case terminus.KeyEsc:
+		p.Close()
+	case terminus.KeyEnter:
+		line := p.input.Value()
+		p.Close()
+		return p, p.run(line)
+	case terminus.KeyBackspace:
+		if p.input.Value() == "" {
+			p.Close()
+			return p, nil
+		}
+		p.edit(key)
+	case terminus.KeyUp:
+		p.recall(-1)
+	case terminus.KeyDown:
+		p.recall(1)
+	case terminus.KeyTab:
+		p.complete()
+	default:
+		p.edit(key)
+	}
+	return p, nil
+}
+
+// edit passes a key to the line
+func (p *CommandPrompt) edit(key terminus.KeyMsg) {
+	p.input.Update(key)
+	p.candidates = nil
+}
+
+// recall moves through the history, keeping the line being typed to come
+// back to
+func (p *CommandPrompt) recall(delta int) {
+	i := p.historyIdx + delta
+	if i < 0 || i > len(p.history) {
+		return
+	}
+	if p.historyIdx == len(p.history) {
+		p.draft = p.input.Value()
+	}
+	p.historyIdx = i
+	if i == len(p.history) {
+		p.input.SetValue(p.draft)
+	} else {
+		p.input.SetValue(p.history[i])
+	}
+	p.candidates = nil
+}
+
+// complete completes the word before the cursor: the command name, or an
+// argument from the command's Complete function. An ambiguous word is
+// completed as far as the candidates agree and they are shown.
+func (p *CommandPrompt) complete() {
+	line := p.input.Value()
+	words := parseCommandLine(line)
+	prefix := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		prefix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if !strings.HasSuffix(line, prefix) {
+		return // A quoted word
+	}
+
+	var options []string
+	if len(words) == 0 {
+		for name, cmd := range p.commands {
+			if p.allowed(cmd) {
+				options = append(options, name)
+			}
+		}
+	} else if cmd, ok := p.commands[words[0]]; ok && p.allowed(cmd) && cmd.Complete != nil {
+		options = cmd.Complete(words[1:], prefix)
+	}
+
+	var matches []string
+	for _, option := range options {
+		if strings.HasPrefix(option, prefix) {
+			matches = append(matches, option)
+		}
+	}
+	sort.Strings(matches)
+	p.candidates = nil
+	switch len(matches) {
+	case 0:
+		return
+	case 1:
+		p.input.SetValue(line[:len(line)-len(prefix)] + quoteWord(matches[0]) + " ")
+	default:
+		common := matches[0]
+		for _, m := range matches[1:] {
+			for !strings.HasPrefix(m, common) {
+				common = common[:len(common)-1]
+			}
+		}
+		p.input.SetValue(line[:len(line)-len(prefix)] + common)
+		p.candidates = matches
+	}
+}
+
+// run parses a line, records it and runs its command
+func (p *CommandPrompt) run(line string) terminus.Cmd {
+	words := parseCommandLine(line)
+	if len(words) == 0 {
+		return nil
+	}
+	if n := len(p.history); n == 0 || p.history[n-1] != line {
+		p.history = append(p.history, line)
+		if len(p.history) > maxPromptHistory {
+			p.history = p.history[1:]
+		}
+	}
+
+	cmd, known := p.commands[words[0]]
+	if known && !p.allowed(cmd) {
+		p.SetMessage("Insufficient permissions to run "+cmd.Name, terminus.NewStyle().Foreground(terminus.Red))
+		return nil
+	}
+	if known && cmd.Run != nil {
+		return cmd.Run(words[1:])
+	}
+	msg := CommandMsg{Name: words[0], Args: words[1:], Line: line, Known: known}
+	return func() terminus.Msg { return msg }
+}
+
+// View implements the Component interface. It is the prompt line while
+// the prompt is open, the message while it is closed, and otherwise empty.
+func (p *CommandPrompt) View() string {
+	if !p.Focused() {
+		if p.message == "" {
+			return ""
+		}
+		return p.messageStyle.Render(fitWidth(p.message, p.width))
+	}
+
+	width := p.width - 1
+	var hint string
+	if len(p.candidates) > 0 {
+		hint = "  " + strings.Join(p.candidates, " ")
+	}
+	inputWidth := visibleWidth(p.input.Value()) + 1
+	if inputWidth > width {
+		inputWidth = width
+	}
+	p.input.SetSize(inputWidth, 1)
+	view := p.promptStyle.Render(string(p.trigger)) + p.input.View()
+	if rest := width - inputWidth; rest > 0 {
+		view += p.candidateStyle.Render(fitWidth(hint, rest))
+	}
+	return view
+}
+
+// Overlay puts the prompt over the last line of view while it is open or
+// showing a message
+func (p *CommandPrompt) Overlay(view string) string {
+	line := p.View()
+	if line == "" {
+		return view
+	}
+	if i := strings.LastIndex(view, "\n"); i >= 0 {
+		return view[:i+1] + line
+	}
+	return line
+}
+
+// parseCommandLine splits a line into words at spaces. Double quotes group
+// words, and a backslash escapes the next character.
+func parseCommandLine(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '"':
+			quoted, inWord = !quoted, true
+		case r == ' ' && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// quoteWord quotes a completed word that contains spaces
+func quoteWord(word string) string {
+	if strings.ContainsAny(word, " \"") {
+		return `"` + strings.ReplaceAll(word, `"`, `\"`) + `"`
+	}
+	return word
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// typeLine types text into c one rune at a time
+func typeLine(c terminus.Component, text string) {
+	for _, r := range text {
+		c.Update(runeKey(r))
+	}
+}
+
+func TestParseCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"nick Ada", []string{"nick", "Ada"}},
+		{"  set   width  80 ", []string{"set", "width", "80"}},
+		{`nick "Ada Lovelace"`, []string{"nick", "Ada Lovelace"}},
+		{`say \"hi\"`, []string{"say", `"hi"`}},
+		{`open ""`, []string{"open", ""}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := parseCommandLine(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCommandLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCommandPrompt(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Opens on the trigger and dispatches commands",
+			test: func(t *testing.T) {
+				var got []string
+				p := NewCommandPrompt().AddCommand(PromptCommand{
+					Name: "nick",
+					Run: func(args []string) terminus.Cmd {
+						got = args
+						return nil
+					},
+				})
+				if p.OpenOn(runeKey('x')) || !p.OpenOn(runeKey(':')) || !p.Focused() {
+					t.Fatal("Expected only ':' to open the prompt")
+				}
+				typeLine(p, `nick "Ada L"`)
+				if !strings.HasPrefix(plain(p.View()), `:nick "Ada L"`) {
+					t.Errorf("Expected the line, got %q", plain(p.View()))
+				}
+				press(p, terminus.KeyMsg{Type: terminus.KeyEnter})
+				if p.Focused() || !reflect.DeepEqual(got, []string{"Ada L"}) {
+					t.Errorf("Expected nick to run with one argument, got %q", got)
+				}
+
+				p.Open()
+				typeLine(p, "gc now")
+				_, cmd := p.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				msg, ok := cmd().(CommandMsg)
+				if !ok || msg.Name != "gc" || msg.Known || msg.Args[0] != "now" {
+					t.Errorf("Expected a CommandMsg for gc, got %+v", msg)
+				}
+			},
+		},
+		{
+			name: "Recalls history",
+			test: func(t *testing.T) {
+				p := NewCommandPrompt()
+				for _, line := range []string{"one", "two", "two"} {
+					p.Open()
+					typeLine(p, line)
+					press(p, terminus.KeyMsg{Type: terminus.KeyEnter})
+				}
+				if !reflect.DeepEqual(p.History(), []string{"one", "two"}) {
+					t.Fatalf("Expected repeats skipped, got %q", p.History())
+				}
+
+				p.Open()
+				typeLine(p, "dr")
+				press(p, terminus.KeyMsg{Type: terminus.KeyUp}, terminus.KeyMsg{Type: terminus.KeyUp})
+				if p.input.Value() != "one" {
+					t.Errorf("Expected the oldest line, got %q", p.input.Value())
+				}
+				press(p, terminus.KeyMsg{Type: terminus.KeyDown}, terminus.KeyMsg{Type: terminus.KeyDown})
+				if p.input.Value() != "dr" {
+					t.Errorf("Expected the draft back, got %q", p.input.Value())
+				}
+			},
+		},
+		{
+			name: "Completes names and arguments",
+			test: func(t *testing.T) {
+				p := NewCommandPrompt().
+					AddCommand(PromptCommand{Name: "set", Complete: func(args []string, prefix string) []string {
+						return []string{"width", "wrap", "height"}
+					}}).
+					AddCommand(PromptCommand{Name: "stats"}).
+					AddCommand(PromptCommand{Name: "quit"})
+				p.SetSize(60, 1)
+				p.Open()
+				typeLine(p, "s")
+				press(p, terminus.KeyMsg{Type: terminus.KeyTab})
+				if p.input.Value() != "s" || !strings.Contains(plain(p.View()), "set stats") {
+					t.Errorf("Expected both candidates shown, got %q", plain(p.View()))
+				}
+				typeLine(p, "e")
+				press(p, terminus.KeyMsg{Type: terminus.KeyTab})
+				if p.input.Value() != "set " {
+					t.Errorf("Expected set completed, got %q", p.input.Value())
+				}
+				typeLine(p, "w")
+				press(p, terminus.KeyMsg{Type: terminus.KeyTab})
+				if p.input.Value() != "set w" || len(p.candidates) != 2 {
+					t.Errorf("Expected width and wrap offered, got %q", p.candidates)
+				}
+				typeLine(p, "i")
+				press(p, terminus.KeyMsg{Type: terminus.KeyTab})
+				if p.input.Value() != "set width " {
+					t.Errorf("Expected width completed, got %q", p.input.Value())
+				}
+			},
+		},
+		{
+			name: "Overlays the bottom line",
+			test: func(t *testing.T) {
+				p := NewCommandPrompt()
+				view := "top\nmiddle\nstatus"
+				if p.Overlay(view) != view {
+					t.Error("Expected a closed prompt to leave the view alone")
+				}
+				p.Open()
+				typeLine(p, "q")
+				if lines := strings.Split(plain(p.Overlay(view)), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[2], ":q") {
+					t.Errorf("Expected the prompt on the last line, got %q", lines)
+				}
+
+				press(p, terminus.KeyMsg{Type: terminus.KeyBackspace}, terminus.KeyMsg{Type: terminus.KeyBackspace})
+				p.SetMessage("Unknown command: q", terminus.NewStyle())
+				if p.Focused() || !strings.HasSuffix(plain(p.Overlay(view)), "\nUnknown command: q"+strings.Repeat(" ", 62)) {
+					t.Errorf("Expected the message, got %q", plain(p.Overlay(view)))
+				}
+			},
+		},
+		{
+			name: "Keeps commands from users without their roles",
+			test: func(t *testing.T) {
+				ran := false
+				p := NewCommandPrompt().
+					AddCommand(PromptCommand{Name: "drop", Roles: []string{"admin"}, Run: func([]string) terminus.Cmd {
+						ran = true
+						return nil
+					}}).
+					AddCommand(PromptCommand{Name: "describe"})
+				p.Update(terminus.RolesMsg{Roles: terminus.NewRoleSet("viewer")})
+				if cmds := p.Commands(); len(cmds) != 1 || cmds[0].Name != "describe" {
+					t.Errorf("Expected only the allowed command, got %v", cmds)
+				}
+
+				p.Open()
+				typeLine(p, "d")
+				press(p, terminus.KeyMsg{Type: terminus.KeyTab})
+				if got := p.input.Value(); got != "describe " {
+					t.Errorf("Expected only the allowed command to complete, got %q", got)
+				}
+				p.Close()
+				p.Open()
+				typeLine(p, "drop")
+				press(p, terminus.KeyMsg{Type: terminus.KeyEnter})
+				if ran || !strings.Contains(p.View(), "Insufficient permissions to run drop") {
+					t.Errorf("Expected the command to be refused, got %q", p.View())
+				}
+
+				p.SetRoles(terminus.NewRoleSet("admin"))
+				p.Open()
+				typeLine(p, "drop")
+				press(p, terminus.KeyMsg{Type: terminus.KeyEnter})
+				if !ran {
+					t.Error("Expected an admin to run the command")
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Declarative is an app described by a tree of widgets rather than a view
+// string. Render builds the tree from the app's state on every update, as
+// new values, and a Reconciler keeps the widget instances behind it, so
+// their cursors, selections and scrolling survive.
+type Declarative interface {
+	Init() terminus.Cmd
+	// Update handles every message before the widgets do
+	Update(msg terminus.Msg) terminus.Cmd
+	Render() Node
+}
+
+// Node is a part of a declarative tree: an element, text or a stack of
+// nodes
+type Node interface {
+	reconcile(r *Reconciler) []terminus.Cmd
+	render(r *Reconciler) string
+}
+
+// Element is a widget in a declarative tree, identified by its key. The
+// widget is created the first time its key appears and kept while the key
+// stays in the tree; props configure it on every render.
+type Element struct {
+	key     string
+	create  func() Widget
+	accepts func(Widget) bool
+	props   func(Widget)
+}
+
+// El returns an element for the widget of type W under key, created with
+// create and configured with props on every render. Props should set what
+// the app owns, such as the data and the size, and leave what the user
+// changes, such as a selection, to the widget; a List, Table or Pager keeps
+// its position across props that replace its content.
+//
+//	widget.El("inbox", widget.NewList, func(l *widget.List) {
+//		l.SetStringItems(m.subjects)
+//		l.SetSize(40, 10)
+//	})
+func El[W Widget](key string, create func() W, props func(W)) *Element {
+	return &Element{
+		key:    key,
+		create: func() Widget { return create() },
+		accepts: func(w Widget) bool {
+			_, ok := w.(W)
+			return ok
+		},
+		props: func(w Widget) {
+			if props != nil {
+				props(w.(W))
+			}
+		},
+	}
+}
+
+func (e *Element) reconcile(r *Reconciler) []terminus.Cmd {
+	var cmds []terminus.Cmd
+	w, ok := r.instances[e.key]
+	if !ok || !e.accepts(w) {
+		// New, or a different widget under the same key
+		w = e.create()
+		r.instances[e.key] = w
+		if e.key == r.focused {
+			w.Focus()
+		}
+		cmds = append(cmds, w.Init())
+	}
+	if s, ok := w.(Scrollable); ok {
+		state := s.ScrollState()
+		e.props(w)
+		s.SetScrollState(state)
+	} else {
+		e.props(w)
+	}
+	r.order = append(r.order, e.key)
+	return cmds
+}
+
+func (e *Element) render(r *Reconciler) string {
+	return r.instances[e.key].View()
+}
+
+// textNode is fixed text in a declarative tree
+type textNode string
+
+// Text returns a node showing s
+func Text(s string) Node {
+	return textNode(s)
+}
+
+func (t textNode) reconcile(*Reconciler) []terminus.Cmd { return nil }
+
+func (t textNode) render(*Reconciler) string { return string(t) }
+
+// stack lays out nodes one below the other or side by side
+type stack struct {
+	children   []Node
+	horizontal bool
+}
+
+// VStack returns a node showing children one below the other
+func VStack(children ...Node) Node {
+	return &stack{children: children}
+}
+
+// HStack returns a node showing children side by side, each as wide as its
+// widest line and separated by a space
+func HStack(children ...Node) Node {
+	return &stack{children: children, horizontal: true}
+}
+
+func (s *stack) reconcile(r *Reconciler) []terminus.Cmd {
+	var cmds []terminus.Cmd
+	for _, child := range s.children {
+		cmds = append(cmds, child.reconcile(r)...)
+	}
+	return cmds
+}
+
+func (s *stack) render(r *Reconciler) string {
+	views := make([]string, len(s.children))
+	for i, child := range s.children {
+		views[i] = child.render(r)
+	}
+	if !s.horizontal {
+		return strings.Join(views, "\n")
+	}
+
+	// Pad each column to its width and the whole to the tallest
+	columns := make([][]string, len(views))
+	widths := make([]int, len(views))
+	height := 0
+	for i, view := range views {
+		columns[i] = strings.Split(view, "\n")
+		for _, line := range columns[i] {
+			widths[i] = max(widths[i], visibleWidth(line))
+		}
+		height = max(height, len(columns[i]))
+	}
+	lines := make([]string, height)
+	for y := range lines {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			line := ""
+			if y < len(column) {
+				line = column[y]
+			}
+			cells[i] = fitWidth(line, widths[i])
+		}
+		lines[y] = strings.TrimRight(strings.Join(cells, " "), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reconciler runs a Declarative app as a component. After every update it
+// renders the app's tree and reconciles it with the widgets of the last:
+// widgets whose keys remain are kept and configured anew, new keys create
+// widgets, whose Init commands run, and keys that are gone drop theirs.
+//
+// Messages go to the app first. Tab and Shift+Tab then move the focus
+// between the widgets in tree order, other keys go to the focused widget
+// and all other messages to every widget.
+type Reconciler struct {
+	app       Declarative
+	root      Node
+	instances map[string]Widget
+	order     []string // Keys of the elements in tree order
+	focused   string
+}
+
+// NewReconciler creates a component running app
+func NewReconciler(app Declarative) *Reconciler {
+	return &Reconciler{
+		app:       app,
+		instances: make(map[string]Widget),
+	}
+}
+
+// Widget returns the widget under key, or nil if it isn't in the tree
+func (r *Reconciler) Widget(key string) Widget {
+	return r.instances[key]
+}
+
+// Focused returns the key of the focused widget
+func (r *Reconciler) Focused() string {
+	return r.focused
+}
+
+// Focus moves the focus to the widget under key
+func (r *Reconciler) Focus(key string) {
+	if w, ok := r.instances[key]; ok {
+		if current, ok := r.instances[r.focused]; ok {
+			current.Blur()
+		}
+		r.focused = key
+		w.Focus()
+	}
+}
+
+// Init implements the Component interface
+func (r *Reconciler) Init() terminus.Cmd {
+	return terminus.Batch(r.app.Init(), r.reconcile())
+}
+
+// Update implements the Component interface
+func (r *Reconciler) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	cmds := []terminus.Cmd{r.app.Update(msg)}
+
+	if key, ok := msg.(terminus.KeyMsg); ok {
+		if key.Type == terminus.KeyTab && len(r.order) > 0 {
+			r.moveFocus(key.Shift)
+		} else if w, ok := r.instances[r.focused]; ok {
+			cmds = append(cmds, r.update(r.focused, w, msg))
+		}
+	} else {
+		for _, k := range r.order {
+			cmds = append(cmds, r.update(k, r.instances[k], msg))
+		}
+	}
+
+	cmds = append(cmds, r.reconcile())
+	return r, terminus.Batch(cmds...)
+}
+
+// update passes msg to the widget under key, keeping the widget it returns
+func (r *Reconciler) update(key string, w Widget, msg terminus.Msg) terminus.Cmd {
+	updated, cmd := w.Update(msg)
+	if u, ok := updated.(Widget); ok {
+		r.instances[key] = u
+	}
+	return cmd
+}
+
+// moveFocus focuses the next widget in tree order, or the previous one
+func (r *Reconciler) moveFocus(back bool) {
+	i := 0
+	for j, k := range r.order {
+		if k == r.focused {
+			i = j
+		}
+	}
+	if back {
+		i = (i - 1 + len(r.order)) % len(r.order)
+	} else {
+		i = (i + 1) % len(r.order)
+	}
+	r.Focus(r.order[i])
+}
+
+// reconcile renders the app's tree and brings the widgets in line with it,
+// returning the Init commands of new widgets
+func (r *Reconciler) reconcile() terminus.Cmd {
+	r.root = r.app.Render()
+	r.order = r.order[:0]
+	var cmds []terminus.Cmd
+	if r.root != nil {
+		cmds = r.root.reconcile(r)
+	}
+
+	// Drop the widgets whose keys are gone
+	seen := make(map[string]bool, len(r.order))
+	for _, k := range r.order {
+		seen[k] = true
+	}
+	for k := range r.instances {
+		if !seen[k] {
+			delete(r.instances, k)
+		}
+	}
+
+	// Keep a widget focused
+	if !seen[r.focused] {
+		r.focused = ""
+		if len(r.order) > 0 {
+			r.Focus(r.order[0])
+		}
+	}
+	return terminus.Batch(cmds...)
+}
+
+// View implements the Component interface
+func (r *Reconciler) View() string {
+	if r.root == nil {
+		return ""
+	}
+	return r.root.render(r)
+}
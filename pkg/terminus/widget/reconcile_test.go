@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// inboxApp is a declarative app with a list of subjects and, optionally, a
+// search input
+type inboxApp struct {
+	subjects  []string
+	searching bool
+	loading   bool
+}
+
+func (a *inboxApp) Init() terminus.Cmd {
+	return nil
+}
+
+func (a *inboxApp) Update(msg terminus.Msg) terminus.Cmd {
+	if key, ok := msg.(terminus.KeyMsg); ok && key.Type == terminus.KeyRunes && string(key.Runes) == "/" {
+		a.searching = true
+	}
+	return nil
+}
+
+func (a *inboxApp) Render() Node {
+	list := El("inbox", NewList, func(l *List) {
+		l.SetStringItems(a.subjects)
+		l.SetSize(20, 3)
+	})
+	nodes := []Node{Text("Inbox"), list}
+	if a.searching {
+		nodes = append(nodes, El("search", NewTextInput, func(t *TextInput) {
+			t.SetPlaceholder("Search")
+		}))
+	}
+	if a.loading {
+		nodes = append(nodes, El("loading", newLoader, nil))
+	}
+	return VStack(nodes...)
+}
+
+func TestReconciler(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Keeps widget state across renders",
+			test: func(t *testing.T) {
+				app := &inboxApp{subjects: []string{"a", "b", "c", "d"}}
+				r := NewReconciler(app)
+				r.Init()
+				list := r.Widget("inbox")
+
+				r.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				r.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				app.subjects = append(app.subjects, "e")
+				r.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+
+				if r.Widget("inbox") != list {
+					t.Error("Expected the same list instance")
+				}
+				if got := list.(*List).SelectedIndex(); got != 2 {
+					t.Errorf("Expected the selection to survive, got %d", got)
+				}
+				if view := r.View(); !strings.HasPrefix(view, "Inbox\n") || !strings.Contains(view, "c") {
+					t.Errorf("Expected the tree's view, got %q", view)
+				}
+			},
+		},
+		{
+			name: "Creates and drops widgets as keys come and go",
+			test: func(t *testing.T) {
+				app := &inboxApp{subjects: []string{"a"}}
+				r := NewReconciler(app)
+				r.Init()
+				if r.Widget("search") != nil {
+					t.Fatal("Expected no search input yet")
+				}
+
+				r.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("/")})
+				if _, ok := r.Widget("search").(*TextInput); !ok {
+					t.Fatal("Expected a search input")
+				}
+
+				app.searching = false
+				r.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+				if r.Widget("search") != nil {
+					t.Error("Expected the search input to be dropped")
+				}
+			},
+		},
+		{
+			name: "Runs the Init of new widgets",
+			test: func(t *testing.T) {
+				app := &inboxApp{}
+				r := NewReconciler(app)
+				r.Init()
+
+				app.loading = true
+				_, cmd := r.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+				if cmd == nil {
+					t.Error("Expected the loader's Init command")
+				}
+			},
+		},
+		{
+			name: "Moves the focus with Tab in tree order",
+			test: func(t *testing.T) {
+				app := &inboxApp{searching: true}
+				r := NewReconciler(app)
+				r.Init()
+				if r.Focused() != "inbox" || !r.Widget("inbox").Focused() {
+					t.Fatalf("Expected the first widget focused, got %q", r.Focused())
+				}
+
+				r.Update(terminus.KeyMsg{Type: terminus.KeyTab})
+				if r.Focused() != "search" || r.Widget("inbox").Focused() {
+					t.Errorf("Expected the focus to move to the search input, got %q", r.Focused())
+				}
+				r.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("x")})
+				if got := r.Widget("search").(*TextInput).Value(); got != "x" {
+					t.Errorf("Expected keys to reach the focused widget, got %q", got)
+				}
+
+				app.searching = false
+				r.Update(terminus.WindowSizeMsg{Width: 80, Height: 24})
+				if r.Focused() != "inbox" {
+					t.Errorf("Expected the focus to return to the list, got %q", r.Focused())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestHStack(t *testing.T) {
+	r := NewReconciler(nil)
+	view := HStack(Text("ab\nc"), Text("xyz")).render(r)
+	if view != "ab xyz\nc" {
+		t.Errorf("Expected the columns side by side, got %q", view)
+	}
+}
+
+// loader is a widget that starts loading in Init
+type loader struct {
+	Model
+}
+
+func newLoader() *loader {
+	return &loader{Model: NewModel()}
+}
+
+func (l *loader) Init() terminus.Cmd {
+	return func() terminus.Msg { return loadedMsg{} }
+}
+
+func (l *loader) Update(terminus.Msg) (terminus.Component, terminus.Cmd) {
+	return l, nil
+}
+
+func (l *loader) View() string {
+	return "Loading…"
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// ScrollbarOrientation is the direction a scrollbar runs in
+type ScrollbarOrientation int
+
+const (
+	// ScrollbarVertical runs top to bottom, one cell per line
+	ScrollbarVertical ScrollbarOrientation = iota
+	// ScrollbarHorizontal runs left to right along a single line
+	ScrollbarHorizontal
+)
+
+// Scrollbar renders a track with a thumb whose size and position show how
+// much of the content is visible and where. It holds no scroll state of its
+// own: widgets pass their content size and offset on every render, so one
+// Scrollbar can be shared by several widgets.
+type Scrollbar struct {
+	orientation ScrollbarOrientation
+	trackChar   string
+	thumbChar   string
+	trackStyle  terminus.Style
+	thumbStyle  terminus.Style
+}
+
+// NewScrollbar creates a new scrollbar
+func NewScrollbar(orientation ScrollbarOrientation) *Scrollbar {
+	track := "│"
+	if orientation == ScrollbarHorizontal {
+		track = "─"
+	}
+	return &Scrollbar{
+		orientation: orientation,
+		trackChar:   track,
+		thumbChar:   "█",
+		trackStyle:  terminus.NewStyle().Faint(true),
+		thumbStyle:  terminus.NewStyle(),
+	}
+}
+
+// Orientation returns the direction the scrollbar runs in
+func (s *Scrollbar) Orientation() ScrollbarOrientation {
+	return s.orientation
+}
+
+// SetChars sets the characters drawn for the track and the thumb
+func (s *Scrollbar) SetChars(track, thumb string) *Scrollbar {
+	s.trackChar = track
+	s.thumbChar = thumb
+	return s
+}
+
+// SetTrackStyle sets the style of the track
+func (s *Scrollbar) SetTrackStyle(style terminus.Style) *Scrollbar {
+	s.trackStyle = style
+	return s
+}
+
+// SetThumbStyle sets the style of the thumb
+func (s *Scrollbar) SetThumbStyle(style terminus.Style) *Scrollbar {
+	s.thumbStyle = style
+	return s
+}
+
+// Thumb returns where the thumb starts on a track of length cells, and how
+// many cells it covers, when visible of total units of content are shown
+// starting at offset. Content that fits entirely fills the track.
+func (s *Scrollbar) Thumb(length, total, visible, offset int) (start, size int) {
+	if length <= 0 {
+		return 0, 0
+	}
+	if total <= visible || visible <= 0 {
+		return 0, length
+	}
+
+	// Round the size, but never hide the thumb
+	size = (length*visible + total/2) / total
+	if size < 1 {
+		size = 1
+	}
+	if size > length {
+		size = length
+	}
+
+	maxOffset := total - visible
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	start = (offset*(length-size) + maxOffset/2) / maxOffset
+	return start, size
+}
+
+// Cells renders the scrollbar as length styled cells, from the top or left
+func (s *Scrollbar) Cells(length, total, visible, offset int) []string {
+	start, size := s.Thumb(length, total, visible, offset)
+	track := s.trackStyle.Render(s.trackChar)
+	thumb := s.thumbStyle.Render(s.thumbChar)
+
+	cells := make([]string, length)
+	for i := range cells {
+		if i >= start && i < start+size {
+			cells[i] = thumb
+		} else {
+			cells[i] = track
+		}
+	}
+	return cells
+}
+
+// Render renders the scrollbar: one cell per line when vertical, or a single
+// line when horizontal
+func (s *Scrollbar) Render(length, total, visible, offset int) string {
+	sep := "\n"
+	if s.orientation == ScrollbarHorizontal {
+		sep = ""
+	}
+	return strings.Join(s.Cells(length, total, visible, offset), sep)
+}
+
+// attachScrollbar fits each line to width and appends the matching cell of
+// a vertical scrollbar. Lines beyond the cells are left as they are.
+func attachScrollbar(lines []string, width int, cells []string) []string {
+	for i := range lines {
+		if i >= len(cells) {
+			break
+		}
+		lines[i] = fitWidth(lines[i], width) + cells[i]
+	}
+	return lines
+}
+
+// fitWidth pads or truncates s to width visible columns. ANSI escape
+// sequences take no columns and are kept intact.
+func fitWidth(s string, width int) string {
+	var b strings.Builder
+	cols := 0
+	styled := false
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			end := escapeEnd(s, i)
+			b.WriteString(s[i:end])
+			styled = true
+			i = end
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := terminus.RuneWidth(r)
+		if cols+w > width {
+			// Truncated: pad a wide character cut in half and make sure no
+			// style leaks past the cut
+			b.WriteString(strings.Repeat(" ", width-cols))
+			if styled {
+				b.WriteString("\x1b[0m")
+			}
+			return b.String()
+		}
+		b.WriteString(s[i : i+size])
+		cols += w
+		i += size
+	}
+	if cols < width {
+		b.WriteString(strings.Repeat(" ", width-cols))
+	}
+	return b.String()
+}
+
+// visibleWidth returns the number of columns s takes, ignoring ANSI escapes
+func visibleWidth(s string) int {
+	cols := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			i = escapeEnd(s, i)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cols += terminus.RuneWidth(r)
+		i += size
+	}
+	return cols
+}
+
+// escapeEnd returns the index just past the escape sequence starting at i
+func escapeEnd(s string, i int) int {
+	j := i + 1
+	if j < len(s) && s[j] == '[' {
+		// CSI: parameters and intermediates up to a final byte in @–~
+		j++
+		for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+			j++
+		}
+	}
+	if j < len(s) {
+		j++
+	}
+	return j
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestScrollbarThumb(t *testing.T) {
+	bar := NewScrollbar(ScrollbarVertical)
+	tests := []struct {
+		name                           string
+		length, total, visible, offset int
+		wantStart, wantSize            int
+	}{
+		{"Content fits", 10, 5, 10, 0, 0, 10},
+		{"Top of long content", 10, 100, 10, 0, 0, 1},
+		{"Bottom of long content", 10, 100, 10, 90, 9, 1},
+		{"Half visible at top", 10, 20, 10, 0, 0, 5},
+		{"Half visible at bottom", 10, 20, 10, 10, 5, 5},
+		{"Middle", 10, 40, 10, 15, 4, 3},
+		{"Offset past the end", 10, 20, 10, 50, 5, 5},
+		{"Empty track", 0, 20, 10, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, size := bar.Thumb(tt.length, tt.total, tt.visible, tt.offset)
+			if start != tt.wantStart || size != tt.wantSize {
+				t.Errorf("Thumb() = %d, %d, want %d, %d", start, size, tt.wantStart, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestScrollbar(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Renders vertically and horizontally",
+			test: func(t *testing.T) {
+				v := NewScrollbar(ScrollbarVertical).SetChars("|", "#")
+				if got := plain(v.Render(4, 8, 4, 4)); got != "|\n|\n#\n#" {
+					t.Errorf("Expected the thumb at the bottom, got %q", got)
+				}
+				h := NewScrollbar(ScrollbarHorizontal).SetChars("-", "=")
+				if got := plain(h.Render(4, 8, 4, 0)); got != "==--" {
+					t.Errorf("Expected the thumb on the left, got %q", got)
+				}
+			},
+		},
+		{
+			name: "Styles the thumb and track",
+			test: func(t *testing.T) {
+				bar := NewScrollbar(ScrollbarVertical).
+					SetTrackStyle(terminus.NewStyle()).
+					SetThumbStyle(terminus.NewStyle().Reverse(true))
+				cells := bar.Cells(2, 4, 2, 0)
+				if cells[0] == "█" || cells[1] != "│" {
+					t.Errorf("Expected only the thumb styled, got %q", cells)
+				}
+			},
+		},
+		{
+			name: "Fits styled lines to a width",
+			test: func(t *testing.T) {
+				styled := terminus.NewStyle().Bold(true).Render("abcdef")
+				if got := fitWidth(styled, 3); plain(got) != "abc" || !strings.HasSuffix(got, "\x1b[0m") {
+					t.Errorf("Expected a truncated line with its style reset, got %q", got)
+				}
+				if got := fitWidth("ab", 4); got != "ab  " {
+					t.Errorf("Expected a padded line, got %q", got)
+				}
+				if got := visibleWidth(styled + "é"); got != 7 {
+					t.Errorf("Expected width 7, got %d", got)
+				}
+				if got := fitWidth("🍎 Apple", 5); got != "🍎 Ap" {
+					t.Errorf("Expected the emoji to take two columns, got %q", got)
+				}
+				if got := fitWidth("a🍎", 2); got != "a " {
+					t.Errorf("Expected a half-cut emoji to be padded, got %q", got)
+				}
+			},
+		},
+		{
+			name: "List replaces the indicators",
+			test: func(t *testing.T) {
+				items := make([]string, 20)
+				for i := range items {
+					items[i] = fmt.Sprintf("item %d", i)
+				}
+				l := NewList().SetStringItems(items)
+				l.SetSize(12, 4)
+				l.SetScrollbar(NewScrollbar(ScrollbarVertical).SetChars("|", "#"))
+				l.SetSelected(19)
+
+				lines := strings.Split(plain(l.View()), "\n")
+				if len(lines) != 4 {
+					t.Fatalf("Expected 4 lines, got %q", lines)
+				}
+				for i, line := range lines {
+					if len(line) != 12 {
+						t.Errorf("Expected line %d to fill the width, got %q", i, line)
+					}
+					if strings.ContainsAny(line, "↑↓") {
+						t.Errorf("Expected no indicators, got %q", line)
+					}
+				}
+				if !strings.HasSuffix(lines[0], "|") || !strings.HasSuffix(lines[3], "#") {
+					t.Errorf("Expected the thumb at the bottom, got %q", lines)
+				}
+			},
+		},
+		{
+			name: "Table shows the bar beside the rows",
+			test: func(t *testing.T) {
+				data := make([][]string, 10)
+				for i := range data {
+					data[i] = []string{fmt.Sprintf("%d", i)}
+				}
+				tbl := NewTable().SetStringData([]string{"N"}, data)
+				tbl.SetSize(20, 7)
+				tbl.SetScrollbar(NewScrollbar(ScrollbarVertical).SetChars("|", "#"))
+
+				lines := strings.Split(plain(tbl.View()), "\n")
+				if strings.HasSuffix(lines[0], "#") || strings.HasSuffix(lines[0], "|") {
+					t.Errorf("Expected no bar beside the header, got %q", lines[0])
+				}
+				rows := lines[2:]
+				if !strings.HasSuffix(rows[0], "#") || !strings.HasSuffix(rows[4], "|") {
+					t.Errorf("Expected the thumb at the top, got %q", rows)
+				}
+			},
+		},
+		{
+			name: "Pager shows both bars",
+			test: func(t *testing.T) {
+				content := strings.Repeat("x\n", 9) + strings.Repeat("y", 40)
+				p := NewPager().SetContent(content).SetShowStatus(false).
+					SetScrollbar(NewScrollbar(ScrollbarVertical).SetChars("|", "#")).
+					SetHorizontalScrollbar(NewScrollbar(ScrollbarHorizontal).SetChars("-", "="))
+				p.SetSize(11, 6)
+
+				lines := strings.Split(plain(p.View()), "\n")
+				if len(lines) != 6 {
+					t.Fatalf("Expected 5 lines and a bar, got %q", lines)
+				}
+				if lines[0] != "x         #" || !strings.HasSuffix(lines[4], "|") {
+					t.Errorf("Expected the thumb at the top, got %q", lines)
+				}
+				if lines[5] != "===-------" {
+					t.Errorf("Expected the horizontal thumb on the left, got %q", lines[5])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
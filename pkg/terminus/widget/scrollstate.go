@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import "github.com/skaiser/terminusgo/pkg/terminus"
+
+// ScrollState is where a scrolling widget is: what is selected and the
+// first row and column shown
+type ScrollState struct {
+	Selected int // Selected item or row
+	Column   int // Selected column, for a Table
+	Top      int // First row shown
+	Left     int // First column shown
+}
+
+// Scrollable is implemented by widgets whose position can be saved and
+// restored: List, Table and Pager
+type Scrollable interface {
+	terminus.Component
+	ScrollState() ScrollState
+	SetScrollState(state ScrollState)
+}
+
+// ScrollStates keeps the positions of scrolling widgets by key, so they
+// survive a parent that rebuilds its children, e.g. from fresh data on
+// every render. The parent keeps the ScrollStates, which outlives the
+// widgets, and gives each widget a key of its own:
+//
+//	table := widget.NewTable().SetColumns(columns).SetRows(m.rows())
+//	cmd := m.scroll.Update("events", table, msg)
+type ScrollStates struct {
+	states map[string]ScrollState
+}
+
+// NewScrollStates creates an empty set of positions
+func NewScrollStates() *ScrollStates {
+	return &ScrollStates{states: make(map[string]ScrollState)}
+}
+
+// Restore moves w to the position saved under key, if any, clamped to the
+// content w has now. It returns w.
+func (s *ScrollStates) Restore(key string, w Scrollable) Scrollable {
+	if state, ok := s.states[key]; ok {
+		w.SetScrollState(state)
+	}
+	return w
+}
+
+// Save saves the position of w under key
+func (s *ScrollStates) Save(key string, w Scrollable) {
+	s.states[key] = w.ScrollState()
+}
+
+// Update restores the position of w saved under key, passes msg to w and
+// saves where w ends up
+func (s *ScrollStates) Update(key string, w Scrollable, msg terminus.Msg) terminus.Cmd {
+	s.Restore(key, w)
+	_, cmd := w.Update(msg)
+	s.Save(key, w)
+	return cmd
+}
+
+// Get returns the position saved under key
+func (s *ScrollStates) Get(key string) (ScrollState, bool) {
+	state, ok := s.states[key]
+	return state, ok
+}
+
+// Forget drops the position saved under key, e.g. once its widget is gone
+// for good
+func (s *ScrollStates) Forget(key string) {
+	delete(s.states, key)
+}
+
+// clampIndex returns i within [0, n), or 0 when n is 0
+func clampIndex(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestScrollStates(t *testing.T) {
+	// newTable builds the table afresh, as a parent does on every render
+	newTable := func(rows int) *Table {
+		data := make([][]string, rows)
+		for i := range data {
+			data[i] = []string{fmt.Sprint(i), "x"}
+		}
+		table := NewTable().SetStringData([]string{"N", "X"}, data)
+		table.SetSize(10, 5)
+		table.Focus()
+		return table
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Keeps a rebuilt table's position",
+			test: func(t *testing.T) {
+				scroll := NewScrollStates()
+				for i := 0; i < 12; i++ {
+					scroll.Update("events", newTable(50), terminus.KeyMsg{Type: terminus.KeyDown})
+				}
+
+				table := newTable(50)
+				scroll.Restore("events", table)
+				if table.SelectedRow() != 12 {
+					t.Errorf("Expected row 12 selected, got %d", table.SelectedRow())
+				}
+				if state := table.ScrollState(); state.Top == 0 {
+					t.Errorf("Expected the table to stay scrolled, got %+v", state)
+				}
+			},
+		},
+		{
+			name: "Clamps to content that shrank",
+			test: func(t *testing.T) {
+				scroll := NewScrollStates()
+				list := NewList()
+				list.SetSize(10, 3)
+				list.SetStringItems(strings.Split("a b c d e f g h", " "))
+				list.SetSelected(7)
+				scroll.Save("inbox", list)
+
+				list = NewList()
+				list.SetSize(10, 3)
+				list.SetStringItems([]string{"a", "b", "c", "d"})
+				scroll.Restore("inbox", list)
+				if state := list.ScrollState(); state.Selected != 3 || state.Top != 1 {
+					t.Errorf("Expected the last item selected and in view, got %+v", state)
+				}
+			},
+		},
+		{
+			name: "Keeps a pager's position",
+			test: func(t *testing.T) {
+				scroll := NewScrollStates()
+				content := strings.Repeat("line\n", 30)
+				newPager := func() *Pager {
+					pager := NewPager().SetContent(content)
+					pager.SetSize(20, 5)
+					pager.Focus()
+					return pager
+				}
+				scroll.Update("log", newPager(), terminus.KeyMsg{Type: terminus.KeyPgDown})
+
+				pager := newPager()
+				scroll.Restore("log", pager)
+				if pager.Line() == 1 {
+					t.Error("Expected the pager to stay scrolled")
+				}
+
+				scroll.Forget("log")
+				if _, ok := scroll.Get("log"); ok {
+					t.Error("Expected the position to be forgotten")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
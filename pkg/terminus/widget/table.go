@@ -15,8 +15,11 @@
 package widget
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/skaiser/terminusgo/pkg/terminus"
@@ -37,8 +40,15 @@ type SimpleTableCell struct {
 	text string
 }
 
-// NewSimpleTableCell creates a new simple table cell
+// NewSimpleTableCell creates a new simple table cell. The text is sanitized
+// with terminus.Sanitize, so it may come from users.
 func NewSimpleTableCell(text string) *SimpleTableCell {
+	return &SimpleTableCell{text: terminus.Sanitize(text)}
+}
+
+// NewStyledTableCell creates a cell from trusted text that is already
+// styled, which is shown as is
+func NewStyledTableCell(text string) *SimpleTableCell {
 	return &SimpleTableCell{text: text}
 }
 
@@ -85,7 +95,9 @@ type Table struct {
 
 	// Data
 	columns     []TableColumn
-	rows        []TableRow
+	rows        []TableRow // Rows shown, after filtering
+	all         []TableRow // Every row, in sort order
+	visible     []int      // Index in all of each row shown; nil when unfiltered
 	selectedRow int
 	selectedCol int
 
@@ -95,6 +107,7 @@ type Table struct {
 	borderStyle    BorderStyle
 	scrollOffsetX  int
 	scrollOffsetY  int
+	scrollbar      *Scrollbar
 
 	// Styling
 	style           terminus.Style
@@ -102,6 +115,32 @@ type Table struct {
 	selectedStyle   terminus.Style
 	borderColor     terminus.Style
 	rowNumberStyle  terminus.Style
+	rowStyleFunc    func(row int, data TableRow) terminus.Style
+	cellStyleFunc   func(row, col int, cell TableCell) terminus.Style
+
+	// Filtering
+	filter         tableFilter
+	columnFilters  map[int]tableFilter
+	showFilterRow  bool
+	filterMarker   string
+	filterStyle    terminus.Style
+
+	// Grouping
+	groupBy          int // Column rows are grouped by, -1 for none
+	groups           []tableGroup
+	collapsed        map[string]bool
+	groupHeaderStyle terminus.Style
+
+	// Aggregates
+	aggregates  map[int]Aggregate
+	footerLabel string
+	footerStyle terminus.Style
+
+	// Highlighting
+	highlight      string
+	matches        []tableMatch
+	matchIdx       int
+	highlightStyle terminus.Style
 
 	// Sorting
 	sortColumn int
@@ -109,10 +148,60 @@ type Table struct {
 
 	// Selection
 	cellSelection bool // If true, individual cells can be selected
+	count         countPrefix
+	killRing      *KillRing
+
+	// Load-more
+	reachEndThreshold int  // Rows from the end at which onReachEnd fires
+	hasMore           bool // False once the source is exhausted
+	loadingMore       bool
+	loadingText       string
+	loadingStyle      terminus.Style
+
+	empty *EmptyState // Shown in place of the rows when there is no data
+
+	// Position footer
+	showPosition  bool
+	positionStyle terminus.Style
 
 	// Events
-	onSelect func(row, col int, cell TableCell) terminus.Cmd
-	onSort   func(column int, order SortOrder) terminus.Cmd
+	onSelect   func(row, col int, cell TableCell) terminus.Cmd
+	onSort     func(column int, order SortOrder) terminus.Cmd
+	onReachEnd func() terminus.Cmd
+}
+
+// Aggregate reduces the cells of a column to the text shown in the footer
+// and group headers, such as their sum
+type Aggregate func(cells []TableCell) string
+
+// tableGroup is a run of rows sharing a value in the grouped column
+type tableGroup struct {
+	name string
+	rows []int // Indices in all of the rows that pass the filters
+}
+
+// tableFilter is a filter expression and its compiled pattern
+type tableFilter struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+// newTableFilter compiles a filter expression
+func newTableFilter(expr string) tableFilter {
+	return tableFilter{expr: expr, re: compileFilter(expr)}
+}
+
+// regexpFilter wraps a compiled pattern, nil for none
+func regexpFilter(re *regexp.Regexp) tableFilter {
+	if re == nil {
+		return tableFilter{}
+	}
+	return tableFilter{expr: "/" + re.String() + "/", re: re}
+}
+
+// tableMatch is a cell matching the highlight
+type tableMatch struct {
+	row, col int
 }
 
 // BorderStyle represents the style of table borders
@@ -137,21 +226,36 @@ const (
 // NewTable creates a new table widget
 func NewTable() *Table {
 	return &Table{
-		Model:          NewModel(),
-		columns:        make([]TableColumn, 0),
-		rows:           make([]TableRow, 0),
-		selectedRow:    0,
-		selectedCol:    0,
-		showHeader:     true,
-		showRowNumbers: false,
-		borderStyle:    BorderSimple,
-		style:          terminus.NewStyle(),
-		headerStyle:    terminus.NewStyle().Bold(true),
-		selectedStyle:  terminus.NewStyle().Reverse(true),
-		rowNumberStyle: terminus.NewStyle().Faint(true),
-		sortColumn:     -1,
-		sortOrder:      SortNone,
-		cellSelection:  false,
+		Model:             NewModel(),
+		columns:           make([]TableColumn, 0),
+		rows:              make([]TableRow, 0),
+		selectedRow:       0,
+		selectedCol:       0,
+		showHeader:        true,
+		showRowNumbers:    false,
+		borderStyle:       BorderSimple,
+		style:             terminus.NewStyle(),
+		headerStyle:       terminus.NewStyle().Bold(true),
+		selectedStyle:     terminus.NewStyle().Reverse(true),
+		rowNumberStyle:    terminus.NewStyle().Faint(true),
+		sortColumn:        -1,
+		sortOrder:         SortNone,
+		cellSelection:     false,
+		reachEndThreshold: 3,
+		hasMore:           true,
+		loadingText:       "loading more…",
+		loadingStyle:      terminus.NewStyle().Faint(true),
+		columnFilters:     make(map[int]tableFilter),
+		groupBy:           -1,
+		collapsed:         make(map[string]bool),
+		groupHeaderStyle:  terminus.NewStyle().Bold(true).Foreground(terminus.Magenta),
+		aggregates:        make(map[int]Aggregate),
+		footerLabel:       "Total",
+		footerStyle:       terminus.NewStyle().Bold(true),
+		filterMarker:      " *",
+		filterStyle:       terminus.NewStyle().Foreground(terminus.Yellow),
+		highlightStyle:    terminus.NewStyle().Background(terminus.Yellow).Foreground(terminus.Black),
+		positionStyle:     terminus.NewStyle().Faint(true),
 	}
 }
 
@@ -176,7 +280,9 @@ func (t *Table) AddColumn(column TableColumn) *Table {
 
 // SetRows sets the table rows
 func (t *Table) SetRows(rows []TableRow) *Table {
-	t.rows = rows
+	t.all = rows
+	t.refilter()
+	t.loadingMore = false
 	// Adjust selected row if necessary
 	if t.selectedRow >= len(t.rows) {
 		t.selectedRow = len(t.rows) - 1
@@ -184,23 +290,43 @@ func (t *Table) SetRows(rows []TableRow) *Table {
 	if t.selectedRow < 0 && len(t.rows) > 0 {
 		t.selectedRow = 0
 	}
+	t.findMatches()
 	return t
 }
 
 // AddRow adds a single row
 func (t *Table) AddRow(row TableRow) *Table {
-	t.rows = append(t.rows, row)
+	t.all = append(t.all, row)
+	t.loadingMore = false
+	switch {
+	case t.groupBy >= 0:
+		// The row may start a group or land in the middle of one
+		t.refilter()
+		t.findMatches()
+		return t
+	case !t.isFiltered():
+		t.rows = t.all
+	case t.showsRow(row):
+		t.rows = append(t.rows, row)
+		t.visible = append(t.visible, len(t.all)-1)
+	default:
+		return t
+	}
+	if t.highlight != "" {
+		t.matches = append(t.matches, t.rowMatches(len(t.rows)-1)...)
+	}
 	return t
 }
 
-// SetStringData is a convenience method for setting string data
+// SetStringData is a convenience method for setting string data. Headers
+// and cells are sanitized; use NewStyledTableCell for styled cells.
 func (t *Table) SetStringData(headers []string, data [][]string) *Table {
 	// Set up columns
 	columns := make([]TableColumn, len(headers))
 	for i, header := range headers {
 		columns[i] = TableColumn{
-			Title:    header,
-			Width:    15, // Default width
+			Title:    terminus.Sanitize(header),
+			Width:    0, // Sized to content
 			MinWidth: 5,
 			MaxWidth: 50,
 			Sortable: true,
@@ -235,6 +361,34 @@ func (t *Table) SetShowRowNumbers(show bool) *Table {
 	return t
 }
 
+// SetShowPosition sets whether a footer shows the rows in view, e.g.
+// "rows 20–40 of 500"
+func (t *Table) SetShowPosition(show bool) *Table {
+	t.showPosition = show
+	t.updateScrollOffset()
+	return t
+}
+
+// SetPositionStyle sets the style of the position footer
+func (t *Table) SetPositionStyle(style terminus.Style) *Table {
+	t.positionStyle = style
+	return t
+}
+
+// SetSize sets the table's size, scrolling to keep the selected row in
+// view
+func (t *Table) SetSize(width, height int) {
+	t.Model.SetSize(width, height)
+	t.updateScrollOffset()
+}
+
+// SetScrollbar shows a vertical scrollbar beside the rows. Pass nil to hide
+// it.
+func (t *Table) SetScrollbar(scrollbar *Scrollbar) *Table {
+	t.scrollbar = scrollbar
+	return t
+}
+
 // SetBorderStyle sets the border style
 func (t *Table) SetBorderStyle(style BorderStyle) *Table {
 	t.borderStyle = style
@@ -271,6 +425,44 @@ func (t *Table) SetRowNumberStyle(style terminus.Style) *Table {
 	return t
 }
 
+// SetRowStyleFunc styles rows by their data, e.g. to color rows over a
+// threshold. row counts the rows shown. Returning an empty style leaves the
+// row in the table's style; the selection style still marks the selected
+// row.
+func (t *Table) SetRowStyleFunc(fn func(row int, data TableRow) terminus.Style) *Table {
+	t.rowStyleFunc = fn
+	return t
+}
+
+// SetCellStyleFunc styles individual cells, taking precedence over
+// SetRowStyleFunc. Returning an empty style leaves the cell in its row's
+// style.
+func (t *Table) SetCellStyleFunc(fn func(row, col int, cell TableCell) terminus.Style) *Table {
+	t.cellStyleFunc = fn
+	return t
+}
+
+// rowStyle returns the style of row i
+func (t *Table) rowStyle(i int, row TableRow) terminus.Style {
+	if t.rowStyleFunc != nil {
+		if style := t.rowStyleFunc(i, row); style != terminus.NewStyle() {
+			return style
+		}
+	}
+	return t.style
+}
+
+// cellStyle returns the style of the cell at row i, col in a row styled
+// rowStyle
+func (t *Table) cellStyle(i, col int, row TableRow, rowStyle terminus.Style) terminus.Style {
+	if t.cellStyleFunc != nil && col < len(row) && row[col] != nil {
+		if style := t.cellStyleFunc(i, col, row[col]); style != terminus.NewStyle() {
+			return style
+		}
+	}
+	return rowStyle
+}
+
 // SetOnSelect sets the selection callback
 func (t *Table) SetOnSelect(callback func(row, col int, cell TableCell) terminus.Cmd) *Table {
 	t.onSelect = callback
@@ -283,6 +475,549 @@ func (t *Table) SetOnSort(callback func(column int, order SortOrder) terminus.Cm
 	return t
 }
 
+// SetOnReachEnd sets the callback triggered when the selection comes within
+// the reach-end threshold of the last row, so the next page can be fetched.
+// The table then shows a loading footer and doesn't call back again until
+// rows are added or SetLoading(false) is called.
+func (t *Table) SetOnReachEnd(callback func() terminus.Cmd) *Table {
+	t.onReachEnd = callback
+	return t
+}
+
+// SetReachEndThreshold sets how many rows from the end OnReachEnd fires
+func (t *Table) SetReachEndThreshold(n int) *Table {
+	if n < 0 {
+		n = 0
+	}
+	t.reachEndThreshold = n
+	return t
+}
+
+// SetHasMore sets whether more rows can be loaded. OnReachEnd stops firing
+// once it is false.
+func (t *Table) SetHasMore(hasMore bool) *Table {
+	t.hasMore = hasMore
+	if !hasMore {
+		t.SetLoading(false)
+	}
+	return t
+}
+
+// SetLoading shows or hides the loading footer. Clearing it after a failed
+// fetch lets OnReachEnd fire again.
+func (t *Table) SetLoading(loading bool) *Table {
+	t.loadingMore = loading
+	t.updateScrollOffset()
+	return t
+}
+
+// Loading returns whether the table is waiting for more rows
+func (t *Table) Loading() bool {
+	return t.loadingMore
+}
+
+// SetLoadingText sets the text of the loading footer
+func (t *Table) SetLoadingText(text string) *Table {
+	t.loadingText = text
+	return t
+}
+
+// SetLoadingStyle sets the style of the loading footer
+func (t *Table) SetLoadingStyle(style terminus.Style) *Table {
+	t.loadingStyle = style
+	return t
+}
+
+// SetEmptyState sets what the table shows under its header when it has no
+// rows at all, sized to the space the rows would take
+func (t *Table) SetEmptyState(empty *EmptyState) *Table {
+	t.empty = empty
+	return t
+}
+
+// SetFilter shows only rows with a cell matching expr, ignoring case. An
+// expression wrapped in slashes, like /^4\d\d$/, is a regular expression.
+// An empty expression clears the filter.
+func (t *Table) SetFilter(expr string) *Table {
+	t.filter = newTableFilter(expr)
+	t.applyFilters()
+	return t
+}
+
+// SetFilterRegexp shows only rows with a cell matching re. Pass nil to clear
+// the filter.
+func (t *Table) SetFilterRegexp(re *regexp.Regexp) *Table {
+	t.filter = regexpFilter(re)
+	t.applyFilters()
+	return t
+}
+
+// Filter returns the filter applied to every column
+func (t *Table) Filter() string {
+	return t.filter.expr
+}
+
+// SetColumnFilter shows only rows whose cell in col matches expr, as
+// SetFilter does for the whole row. Filters on different columns must all
+// match. An empty expression clears the column's filter.
+func (t *Table) SetColumnFilter(col int, expr string) *Table {
+	return t.setColumnFilter(col, newTableFilter(expr))
+}
+
+// SetColumnFilterRegexp shows only rows whose cell in col matches re. Pass
+// nil to clear the column's filter.
+func (t *Table) SetColumnFilterRegexp(col int, re *regexp.Regexp) *Table {
+	return t.setColumnFilter(col, regexpFilter(re))
+}
+
+// setColumnFilter sets or, when it is empty, clears the filter on col
+func (t *Table) setColumnFilter(col int, f tableFilter) *Table {
+	if col < 0 || col >= len(t.columns) {
+		return t
+	}
+	if f.re == nil {
+		delete(t.columnFilters, col)
+	} else {
+		t.columnFilters[col] = f
+	}
+	t.applyFilters()
+	return t
+}
+
+// ColumnFilter returns the filter on col, or ""
+func (t *Table) ColumnFilter(col int) string {
+	return t.columnFilters[col].expr
+}
+
+// ClearFilters removes the table's filter and every column filter
+func (t *Table) ClearFilters() *Table {
+	t.filter = tableFilter{}
+	t.columnFilters = make(map[int]tableFilter)
+	t.applyFilters()
+	return t
+}
+
+// SetShowFilterRow sets whether a row under the header shows each column's
+// filter, and a line above the table shows the table's filter and how many
+// rows match it
+func (t *Table) SetShowFilterRow(show bool) *Table {
+	t.showFilterRow = show
+	return t
+}
+
+// SetFilterMarker sets the text appended to the titles of filtered columns
+func (t *Table) SetFilterMarker(marker string) *Table {
+	t.filterMarker = marker
+	return t
+}
+
+// SetFilterStyle sets the style of the filter row and line
+func (t *Table) SetFilterStyle(style terminus.Style) *Table {
+	t.filterStyle = style
+	return t
+}
+
+// SourceRow returns the index in the rows passed to SetRows of the row
+// shown at i, or -1 if there is none or it is a group header
+func (t *Table) SourceRow(i int) int {
+	if i < 0 || i >= len(t.rows) {
+		return -1
+	}
+	if t.visible == nil {
+		return i
+	}
+	if t.visible[i] < 0 {
+		return -1
+	}
+	return t.visible[i]
+}
+
+// TotalRowCount returns the number of rows, including filtered out ones
+func (t *Table) TotalRowCount() int {
+	return len(t.all)
+}
+
+// SetGroupBy groups rows by their value in col, under collapsible headers
+// showing each group's size and aggregates. Groups appear in the order of
+// their first row. Pass -1 to stop grouping.
+func (t *Table) SetGroupBy(col int) *Table {
+	if col >= len(t.columns) {
+		return t
+	}
+	if col < 0 {
+		col = -1
+	}
+	t.groupBy = col
+	t.applyFilters()
+	return t
+}
+
+// GroupBy returns the column rows are grouped by, or -1
+func (t *Table) GroupBy() int {
+	return t.groupBy
+}
+
+// SetGroupHeaderStyle sets the style of group headers
+func (t *Table) SetGroupHeaderStyle(style terminus.Style) *Table {
+	t.groupHeaderStyle = style
+	return t
+}
+
+// CollapseGroup hides the rows of a group, leaving its header
+func (t *Table) CollapseGroup(name string) *Table {
+	t.collapsed[name] = true
+	t.applyFilters()
+	return t
+}
+
+// ExpandGroup shows the rows of a collapsed group
+func (t *Table) ExpandGroup(name string) *Table {
+	delete(t.collapsed, name)
+	t.applyFilters()
+	return t
+}
+
+// ToggleGroup collapses or expands a group
+func (t *Table) ToggleGroup(name string) *Table {
+	if t.collapsed[name] {
+		return t.ExpandGroup(name)
+	}
+	return t.CollapseGroup(name)
+}
+
+// IsCollapsed returns whether a group is collapsed
+func (t *Table) IsCollapsed(name string) bool {
+	return t.collapsed[name]
+}
+
+// SelectedGroup returns the group of the selected header or row
+func (t *Table) SelectedGroup() string {
+	if k := t.groupAt(t.selectedRow); k >= 0 {
+		return t.groups[k].name
+	}
+	if src := t.SourceRow(t.selectedRow); src >= 0 && t.groupBy >= 0 {
+		return t.groupKey(t.all[src])
+	}
+	return ""
+}
+
+// groupAt returns the group whose header is shown at i, or -1
+func (t *Table) groupAt(i int) int {
+	if t.visible == nil || i < 0 || i >= len(t.visible) || t.visible[i] >= 0 {
+		return -1
+	}
+	return headerIndex(t.visible[i])
+}
+
+// groupKey returns the value row is grouped by
+func (t *Table) groupKey(row TableRow) string {
+	if t.groupBy < len(row) && row[t.groupBy] != nil {
+		return row[t.groupBy].String()
+	}
+	return ""
+}
+
+// SetAggregate shows agg's result for col in a footer under the rows and
+// in group headers. The footer covers every row that passes the filters,
+// including those in collapsed groups. Pass nil to remove it.
+func (t *Table) SetAggregate(col int, agg Aggregate) *Table {
+	if agg == nil {
+		delete(t.aggregates, col)
+	} else if col >= 0 && col < len(t.columns) {
+		t.aggregates[col] = agg
+	}
+	return t
+}
+
+// SetFooterLabel sets the text shown in the footer's first column when it
+// has no aggregate
+func (t *Table) SetFooterLabel(label string) *Table {
+	t.footerLabel = label
+	return t
+}
+
+// SetFooterStyle sets the style of the aggregate footer
+func (t *Table) SetFooterStyle(style terminus.Style) *Table {
+	t.footerStyle = style
+	return t
+}
+
+// aggregate applies col's aggregate to the cells of the given rows of all
+func (t *Table) aggregate(col int, rows []int) string {
+	cells := make([]TableCell, 0, len(rows))
+	for _, i := range rows {
+		if row := t.all[i]; col < len(row) && row[col] != nil {
+			cells = append(cells, row[col])
+		}
+	}
+	return t.aggregates[col](cells)
+}
+
+// shownRows returns the indices in all of the rows that pass the filters
+func (t *Table) shownRows() []int {
+	var rows []int
+	for i, row := range t.all {
+		if !t.isFiltered() || t.showsRow(row) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// AggregateSum adds up a column's numeric cells, formatting the total with
+// format, e.g. "%.2f"
+func AggregateSum(format string) Aggregate {
+	return func(cells []TableCell) string {
+		sum := 0.0
+		for _, cell := range cells {
+			if n, ok := cellNumber(cell); ok {
+				sum += n
+			}
+		}
+		return fmt.Sprintf(format, sum)
+	}
+}
+
+// AggregateAverage averages a column's numeric cells, formatting the mean
+// with format. Columns without numbers show nothing.
+func AggregateAverage(format string) Aggregate {
+	return func(cells []TableCell) string {
+		sum, count := 0.0, 0
+		for _, cell := range cells {
+			if n, ok := cellNumber(cell); ok {
+				sum += n
+				count++
+			}
+		}
+		if count == 0 {
+			return ""
+		}
+		return fmt.Sprintf(format, sum/float64(count))
+	}
+}
+
+// AggregateCount counts a column's non-empty cells
+func AggregateCount() Aggregate {
+	return func(cells []TableCell) string {
+		count := 0
+		for _, cell := range cells {
+			if cell.String() != "" {
+				count++
+			}
+		}
+		return strconv.Itoa(count)
+	}
+}
+
+// cellNumber returns the numeric value of a cell, from its Value if that
+// is a number and otherwise by parsing its text
+func cellNumber(cell TableCell) (float64, bool) {
+	switch v := cell.Value().(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(cell.String()), 64)
+	return n, err == nil
+}
+
+// isFiltered returns whether any filter is active
+func (t *Table) isFiltered() bool {
+	return t.filter.re != nil || len(t.columnFilters) > 0
+}
+
+// showsRow returns whether row passes the filters
+func (t *Table) showsRow(row TableRow) bool {
+	text := func(col int) string {
+		if col < len(row) && row[col] != nil {
+			return row[col].String()
+		}
+		return ""
+	}
+
+	for col, f := range t.columnFilters {
+		if !f.re.MatchString(text(col)) {
+			return false
+		}
+	}
+	if t.filter.re == nil {
+		return true
+	}
+	for col := range row {
+		if t.filter.re.MatchString(text(col)) {
+			return true
+		}
+	}
+	return false
+}
+
+// refilter rebuilds the rows shown from every row. Group headers are shown
+// as nil rows.
+func (t *Table) refilter() {
+	t.groups = t.groups[:0]
+	if !t.isFiltered() && t.groupBy < 0 {
+		t.rows, t.visible = t.all, nil
+		return
+	}
+	t.rows = make([]TableRow, 0, len(t.all))
+	t.visible = make([]int, 0, len(t.all))
+	if t.groupBy < 0 {
+		for _, i := range t.shownRows() {
+			t.rows = append(t.rows, t.all[i])
+			t.visible = append(t.visible, i)
+		}
+		return
+	}
+
+	// Gather rows into groups, in order of their first row
+	index := make(map[string]int)
+	for _, i := range t.shownRows() {
+		name := t.groupKey(t.all[i])
+		k, ok := index[name]
+		if !ok {
+			k = len(t.groups)
+			index[name] = k
+			t.groups = append(t.groups, tableGroup{name: name})
+		}
+		t.groups[k].rows = append(t.groups[k].rows, i)
+	}
+	for k, group := range t.groups {
+		t.rows = append(t.rows, nil)
+		t.visible = append(t.visible, headerRow(k))
+		if t.collapsed[group.name] {
+			continue
+		}
+		for _, i := range group.rows {
+			t.rows = append(t.rows, t.all[i])
+			t.visible = append(t.visible, i)
+		}
+	}
+}
+
+// applyFilters refilters and regroups the rows, keeping the selected row
+// selected if it is still shown, or else its group's header
+func (t *Table) applyFilters() {
+	selected := t.SourceRow(t.selectedRow)
+	group := t.SelectedGroup()
+	t.refilter()
+
+	t.selectedRow = t.findRow(selected, group)
+	if len(t.rows) == 0 {
+		t.selectedRow = -1
+	}
+	t.updateScrollOffset()
+	t.findMatches()
+}
+
+// findRow returns where the row at src in all is shown, else the header of
+// group, else the first row after src
+func (t *Table) findRow(src int, group string) int {
+	if src >= 0 {
+		for i := range t.rows {
+			if t.SourceRow(i) == src {
+				return i
+			}
+		}
+	}
+	for i := range t.rows {
+		if k := t.groupAt(i); k >= 0 && t.groups[k].name == group {
+			return i
+		}
+	}
+	for i := range t.rows {
+		if t.SourceRow(i) > src {
+			return i
+		}
+	}
+	return 0
+}
+
+// SetHighlightStyle sets the style of highlighted matches
+func (t *Table) SetHighlightStyle(style terminus.Style) *Table {
+	t.highlightStyle = style
+	return t
+}
+
+// Highlight implements Highlighter. Cells match by their String(), and
+// each matching cell counts once.
+func (t *Table) Highlight(term string) int {
+	t.highlight = term
+	t.findMatches()
+	if len(t.matches) > 0 {
+		t.showMatch()
+	}
+	return len(t.matches)
+}
+
+// MatchCount implements Highlighter
+func (t *Table) MatchCount() int {
+	return len(t.matches)
+}
+
+// MatchIndex implements Highlighter
+func (t *Table) MatchIndex() int {
+	return t.matchIdx
+}
+
+// StepMatch implements Highlighter
+func (t *Table) StepMatch(delta int) {
+	if len(t.matches) > 0 {
+		t.matchIdx = stepIndex(t.matchIdx, delta, len(t.matches))
+		t.showMatch()
+	}
+}
+
+// findMatches collects the cells matching the highlight, row by row
+func (t *Table) findMatches() {
+	t.matches = t.matches[:0]
+	t.matchIdx = 0
+	if t.highlight == "" {
+		return
+	}
+	for i := range t.rows {
+		t.matches = append(t.matches, t.rowMatches(i)...)
+	}
+}
+
+// rowMatches returns the cells of row i matching the highlight
+func (t *Table) rowMatches(i int) []tableMatch {
+	var matches []tableMatch
+	for col, cell := range t.rows[i] {
+		if cell != nil && len(matchColumns(cell.String(), t.highlight)) > 0 {
+			matches = append(matches, tableMatch{row: i, col: col})
+		}
+	}
+	return matches
+}
+
+// showMatch selects the row, and in cell selection mode the cell, of the
+// current match
+func (t *Table) showMatch() {
+	m := t.matches[t.matchIdx]
+	t.selectedRow = m.row
+	if t.cellSelection {
+		t.selectedCol = m.col
+	}
+	t.updateScrollOffset()
+}
+
+// checkReachEnd fires OnReachEnd if the selection is near the last row
+func (t *Table) checkReachEnd() terminus.Cmd {
+	if t.onReachEnd == nil || !t.hasMore || t.loadingMore {
+		return nil
+	}
+	if t.selectedRow < len(t.rows)-1-t.reachEndThreshold {
+		return nil
+	}
+	t.SetLoading(true)
+	return t.onReachEnd()
+}
+
 // SelectedRow returns the selected row index
 func (t *Table) SelectedRow() int {
 	return t.selectedRow
@@ -314,6 +1049,65 @@ func (t *Table) SetSelected(row, col int) *Table {
 	return t
 }
 
+// KeyBindings returns the keys the table handles, for checking global
+// shortcuts against
+func (t *Table) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("nNsSjky "+t.count.digits(), terminus.KeyUp, terminus.KeyDown, terminus.KeyLeft, terminus.KeyRight,
+		terminus.KeyHome, terminus.KeyEnd, terminus.KeyEnter)
+}
+
+// SetKillRing sets the clipboard shared with other widgets of the session,
+// which y copies the selected cell to, or the selected row with its cells
+// separated by tabs
+func (t *Table) SetKillRing(ring *KillRing) *Table {
+	t.killRing = ring
+	return t
+}
+
+// selectionText returns the text of the selected cell, or of the selected
+// row unless cells are selected
+func (t *Table) selectionText() string {
+	if t.cellSelection {
+		if cell := t.SelectedCell(); cell != nil {
+			return cell.String()
+		}
+		return ""
+	}
+	if t.selectedRow < 0 || t.selectedRow >= len(t.rows) {
+		return ""
+	}
+	cells := make([]string, len(t.rows[t.selectedRow]))
+	for i, cell := range t.rows[t.selectedRow] {
+		cells[i] = cell.String()
+	}
+	return strings.Join(cells, "\t")
+}
+
+// SetCountPrefix sets whether digits typed before a key repeat it, vim
+// style: 5j or 5↓ moves down five rows. It is on by default.
+func (t *Table) SetCountPrefix(enabled bool) *Table {
+	t.count.disabled = !enabled
+	return t
+}
+
+// ScrollState returns the selected cell and the first row and column shown,
+// for ScrollStates
+func (t *Table) ScrollState() ScrollState {
+	return ScrollState{
+		Selected: t.selectedRow,
+		Column:   t.selectedCol,
+		Top:      t.scrollOffsetY,
+		Left:     t.scrollOffsetX,
+	}
+}
+
+// SetScrollState selects a cell and scrolls to a row and column, keeping
+// the selection in view and all within the rows the table has now
+func (t *Table) SetScrollState(state ScrollState) {
+	t.scrollOffsetY, t.scrollOffsetX = state.Top, state.Left
+	t.SetSelected(clampIndex(state.Selected, len(t.rows)), clampIndex(state.Column, len(t.columns)))
+}
+
 // SortByColumn sorts the table by the specified column
 func (t *Table) SortByColumn(column int, order SortOrder) *Table {
 	if column < 0 || column >= len(t.columns) || !t.columns[column].Sortable {
@@ -347,14 +1141,14 @@ func (t *Table) SortByColumn(column int, order SortOrder) *Table {
 			selectedCell = t.rows[t.selectedRow][t.selectedCol]
 		}
 
-		// Sort the rows
-		sort.Slice(t.rows, func(i, j int) bool {
-			if column >= len(t.rows[i]) || column >= len(t.rows[j]) {
+		// Sort every row, then pick out the ones shown
+		sort.Slice(t.all, func(i, j int) bool {
+			if column >= len(t.all[i]) || column >= len(t.all[j]) {
 				return false
 			}
 
-			cell1 := t.rows[i][column]
-			cell2 := t.rows[j][column]
+			cell1 := t.all[i][column]
+			cell2 := t.all[j][column]
 
 			// Compare values
 			val1 := cell1.Value()
@@ -370,6 +1164,7 @@ func (t *Table) SortByColumn(column int, order SortOrder) *Table {
 			}
 			return result < 0
 		})
+		t.refilter()
 
 		// Try to restore selection
 		if selectedCell != nil {
@@ -380,6 +1175,7 @@ func (t *Table) SortByColumn(column int, order SortOrder) *Table {
 				}
 			}
 		}
+		t.findMatches()
 	}
 
 	return t
@@ -387,11 +1183,9 @@ func (t *Table) SortByColumn(column int, order SortOrder) *Table {
 
 // updateScrollOffset updates scroll offsets based on selection
 func (t *Table) updateScrollOffset() {
-	// Vertical scrolling
-	visibleRows := t.height
-	if t.showHeader {
-		visibleRows--
-	}
+	// Vertical scrolling. A table too short for any row still scrolls as
+	// if one fit, so the selection stays where it will be shown.
+	visibleRows := max(t.pageRows(), 1)
 
 	if t.selectedRow < t.scrollOffsetY {
 		t.scrollOffsetY = t.selectedRow
@@ -432,16 +1226,25 @@ func (t *Table) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 
 	switch msg := msg.(type) {
 	case terminus.KeyMsg:
+		if t.count.feed(msg) {
+			return t, nil
+		}
+		count := max(t.count.take(), 1)
+		msg = motion(msg)
+
+		if t.handleGroupKey(msg) {
+			return t, nil
+		}
 		switch msg.Type {
 		case terminus.KeyUp:
 			if t.selectedRow > 0 {
-				t.selectedRow--
+				t.selectedRow = max(t.selectedRow-count, 0)
 				t.updateScrollOffset()
 			}
 
 		case terminus.KeyDown:
 			if t.selectedRow < len(t.rows)-1 {
-				t.selectedRow++
+				t.selectedRow = min(t.selectedRow+count, len(t.rows)-1)
 				t.updateScrollOffset()
 			}
 
@@ -481,6 +1284,18 @@ func (t *Table) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 		case terminus.KeyRunes:
 			if len(msg.Runes) > 0 {
 				switch msg.Runes[0] {
+				case 'n', 'N':
+					// Step through highlighted matches
+					if msg.Runes[0] == 'n' {
+						t.StepMatch(1)
+					} else {
+						t.StepMatch(-1)
+					}
+				case 'y':
+					// Copy the selection
+					if t.killRing != nil {
+						t.killRing.Push(t.selectionText())
+					}
 				case 's', 'S':
 					// Sort by current column
 					if t.selectedCol >= 0 && t.selectedCol < len(t.columns) {
@@ -492,108 +1307,153 @@ func (t *Table) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
 				}
 			}
 		}
+
+		// Moving towards the end may need the next page
+		if msg.Type == terminus.KeyDown || msg.Type == terminus.KeyEnd {
+			if more := t.checkReachEnd(); more != nil {
+				if cmd != nil {
+					cmd = terminus.Batch(cmd, more)
+				} else {
+					cmd = more
+				}
+			}
+		}
 	}
 
 	return t, cmd
 }
 
+// handleGroupKey collapses and expands groups when a header is selected:
+// Left collapses its group, Right expands it, and Enter or Space toggles it.
+// Other rows keep Left and Right for moving between cells. It returns
+// whether the key was used.
+func (t *Table) handleGroupKey(msg terminus.KeyMsg) bool {
+	if t.groupBy < 0 {
+		return false
+	}
+	onHeader := t.groupAt(t.selectedRow) >= 0
+	group := t.SelectedGroup()
+	switch {
+	case msg.Type == terminus.KeyLeft && onHeader:
+		t.CollapseGroup(group)
+	case msg.Type == terminus.KeyRight && onHeader:
+		t.ExpandGroup(group)
+	case onHeader && (msg.Type == terminus.KeyEnter || msg.Type == terminus.KeySpace ||
+		msg.Type == terminus.KeyRunes && string(msg.Runes) == " "):
+		t.ToggleGroup(group)
+	default:
+		return false
+	}
+	return true
+}
+
 // View implements the Component interface
 func (t *Table) View() string {
+	return terminus.RenderView(t)
+}
+
+// RenderView writes the table's view into ctx. Cells are written in their
+// styles as they are laid out, so only the rows in view cost anything.
+func (t *Table) RenderView(ctx *terminus.RenderContext) {
 	if len(t.columns) == 0 {
-		return t.style.Render("No columns defined")
+		ctx.WriteStyled(t.style, "No columns defined")
+		return
 	}
 
-	var result strings.Builder
+	start := ctx.Len()
+	ctx.Grow((t.width + 1) * t.height)
 
-	// Calculate column widths (simplified)
-	colWidths := make([]int, len(t.columns))
-	for i, col := range t.columns {
-		colWidths[i] = col.Width
-		if colWidths[i] <= 0 {
-			colWidths[i] = 10 // Default width
-		}
-	}
+	colWidths := t.columnWidths()
+	rowNumWidth := t.rowNumberWidth()
 
-	rowNumWidth := 0
-	if t.showRowNumbers {
-		rowNumWidth = len(fmt.Sprintf("%d", len(t.rows))) + 2
+	// Render the table's filter and how many rows pass
+	header := t.header()
+	if header.status {
+		status := fmt.Sprintf("Filter: %s (%d of %d rows)", t.filter.expr, len(t.rows), len(t.all))
+		ctx.WriteStyled(t.filterStyle, status)
+		ctx.WriteByte('\n')
 	}
 
-	// Render header
-	if t.showHeader {
+	// Render the header, which stays in place as the rows scroll under it
+	if header.titles {
 		if t.showRowNumbers {
-			result.WriteString(t.rowNumberStyle.Render(fmt.Sprintf("%*s", rowNumWidth, "")))
+			ctx.WritePadded(t.rowNumberStyle, "", rowNumWidth, 0)
 		}
 
 		for i, col := range t.columns {
 			if i > 0 || t.showRowNumbers {
-				result.WriteString("|")
+				ctx.WriteByte('|')
 			}
+			writeCell(ctx, t.headerTitle(i), colWidths[i], col.Align, t.headerStyle)
+		}
+		ctx.WriteByte('\n')
+	}
 
-			header := col.Title
-			if t.sortColumn == i {
-				switch t.sortOrder {
-				case SortAsc:
-					header += " ↑"
-				case SortDesc:
-					header += " ↓"
-				}
+	// Header separator
+	if header.separator {
+		if t.showRowNumbers {
+			ctx.WriteRepeat("-", rowNumWidth)
+		}
+		for i := range t.columns {
+			if i > 0 || t.showRowNumbers {
+				ctx.WriteByte('+')
 			}
-
-			header = t.alignText(header, colWidths[i], col.Align)
-			result.WriteString(t.headerStyle.Render(header))
+			ctx.WriteRepeat("-", colWidths[i])
 		}
-		result.WriteString("\n")
+		ctx.WriteByte('\n')
+	}
 
-		// Header separator
+	// Column filters
+	if header.filters {
 		if t.showRowNumbers {
-			result.WriteString(strings.Repeat("-", rowNumWidth))
+			ctx.WriteRepeat(" ", rowNumWidth)
 		}
-		for i := range t.columns {
+		for i, col := range t.columns {
 			if i > 0 || t.showRowNumbers {
-				result.WriteString("+")
+				ctx.WriteByte('|')
 			}
-			result.WriteString(strings.Repeat("-", colWidths[i]))
+			writeCell(ctx, t.columnFilters[i].expr, colWidths[i], col.Align, t.filterStyle)
 		}
-		result.WriteString("\n")
+		ctx.WriteByte('\n')
 	}
 
 	// Calculate visible rows
-	visibleRows := t.height
-	if t.showHeader {
-		visibleRows -= 2 // Header + separator
-	}
+	visibleRows := t.pageRows()
 
 	// Render visible rows
-	start := t.scrollOffsetY
-	end := start + visibleRows
+	first := t.scrollOffsetY
+	end := first + visibleRows
 	if end > len(t.rows) {
 		end = len(t.rows)
 	}
 
-	for rowIdx := start; rowIdx < end; rowIdx++ {
-		if rowIdx > start {
-			result.WriteString("\n")
+	for rowIdx := first; rowIdx < end; rowIdx++ {
+		if rowIdx > first {
+			ctx.WriteByte('\n')
 		}
 
 		row := t.rows[rowIdx]
 		isSelected := (rowIdx == t.selectedRow)
+		if k := t.groupAt(rowIdx); k >= 0 {
+			ctx.WriteString(t.renderGroupHeader(k, isSelected, colWidths, rowNumWidth))
+			continue
+		}
+		rowStyle := t.rowStyle(rowIdx, row)
 
 		// Row number
 		if t.showRowNumbers {
-			rowNum := fmt.Sprintf("%*d ", rowNumWidth-1, rowIdx+1)
+			style := t.rowNumberStyle
 			if isSelected && !t.cellSelection {
-				rowNum = t.selectedStyle.Render(rowNum)
-			} else {
-				rowNum = t.rowNumberStyle.Render(rowNum)
+				style = t.selectedStyle
 			}
-			result.WriteString(rowNum)
+			rowNum := strconv.Itoa(rowIdx + 1)
+			ctx.WritePadded(style, rowNum, rowNumWidth-1-len(rowNum), 1)
 		}
 
 		// Cells
 		for colIdx, col := range t.columns {
 			if colIdx > 0 || t.showRowNumbers {
-				result.WriteString("|")
+				ctx.WriteByte('|')
 			}
 
 			var cellText string
@@ -601,36 +1461,386 @@ func (t *Table) View() string {
 				cellText = row[colIdx].Render()
 			}
 
-			cellText = t.alignText(cellText, colWidths[colIdx], col.Align)
-
 			// Apply styling
-			if isSelected && (t.cellSelection && colIdx == t.selectedCol || !t.cellSelection) {
-				cellText = t.selectedStyle.Render(cellText)
-			} else {
-				cellText = t.style.Render(cellText)
+			style := t.selectedStyle
+			if !isSelected || t.cellSelection && colIdx != t.selectedCol {
+				style = t.cellStyle(rowIdx, colIdx, row, rowStyle)
 			}
+			if t.highlight != "" {
+				cellText = t.alignText(cellText, colWidths[colIdx], col.Align)
+				ctx.WriteString(highlightMatches(cellText, t.highlight, style, t.highlightStyle))
+				continue
+			}
+			writeCell(ctx, cellText, colWidths[colIdx], col.Align, style)
+		}
+	}
+
+	if len(t.all) == 0 && t.empty != nil && !t.loadingMore {
+		t.empty.SetSize(t.totalWidth(colWidths, rowNumWidth), visibleRows)
+		ctx.WriteView(t.empty)
+	}
 
-			result.WriteString(cellText)
+	if t.loadingMore {
+		if end > first {
+			ctx.WriteByte('\n')
 		}
+		ctx.WriteStyled(t.loadingStyle, t.loadingText)
 	}
 
-	// Pad remaining height
-	currentLines := strings.Count(result.String(), "\n") + 1
-	for currentLines < t.height {
-		result.WriteString("\n")
-		currentLines++
+	// Pad remaining height, keeping the footers at the bottom
+	footer := 0
+	if len(t.aggregates) > 0 {
+		footer = 2
+	}
+	if t.showPosition {
+		footer++
+	}
+	currentLines := bytes.Count(ctx.Bytes()[start:], newline) + 1
+	if pad := t.height - footer - currentLines; pad > 0 {
+		ctx.WriteRepeat("\n", pad)
+	}
+	if len(t.aggregates) > 0 {
+		ctx.WriteByte('\n')
+		ctx.WriteString(t.renderFooter(colWidths, rowNumWidth))
+	}
+	if t.showPosition {
+		ctx.WriteByte('\n')
+		width := max(t.width, t.totalWidth(colWidths, rowNumWidth))
+		ctx.WriteStyled(t.positionStyle, fitWidth(t.position(first, end), width))
 	}
 
-	return result.String()
+	if t.scrollbar != nil {
+		view := string(ctx.Bytes()[start:])
+		ctx.Truncate(start)
+		ctx.WriteString(t.withScrollbar(view, visibleRows))
+	}
+}
+
+// renderGroupHeader renders the header of group k, spanning the table when
+// there are no aggregates and otherwise showing the group's aggregates in
+// their columns
+func (t *Table) renderGroupHeader(k int, selected bool, colWidths []int, rowNumWidth int) string {
+	group := t.groups[k]
+	icon := "▾"
+	if t.collapsed[group.name] {
+		icon = "▸"
+	}
+	label := fmt.Sprintf("%s %s (%d)", icon, group.name, len(group.rows))
+	style := t.groupHeaderStyle
+	if selected {
+		style = t.selectedStyle
+	}
+
+	if len(t.aggregates) == 0 {
+		return style.Render(fitWidth(label, t.totalWidth(colWidths, rowNumWidth)))
+	}
+	return t.renderAggregates(group.rows, label, style, colWidths, rowNumWidth)
+}
+
+// totalWidth returns the width of a row, separators included
+func (t *Table) totalWidth(colWidths []int, rowNumWidth int) int {
+	width := len(colWidths) - 1
+	for _, w := range colWidths {
+		width += w
+	}
+	if t.showRowNumbers {
+		width += rowNumWidth + 1
+	}
+	return width
+}
+
+// renderFooter renders a separator and the aggregates of every row that
+// passes the filters
+func (t *Table) renderFooter(colWidths []int, rowNumWidth int) string {
+	var b strings.Builder
+	if t.showRowNumbers {
+		b.WriteString(strings.Repeat("-", rowNumWidth))
+	}
+	for i := range t.columns {
+		if i > 0 || t.showRowNumbers {
+			b.WriteString("+")
+		}
+		b.WriteString(strings.Repeat("-", colWidths[i]))
+	}
+	b.WriteString("\n")
+	b.WriteString(t.renderAggregates(t.shownRows(), t.footerLabel, t.footerStyle, colWidths, rowNumWidth))
+	return b.String()
+}
+
+// renderAggregates renders a line of the aggregates of the given rows of
+// all, with label in the first column if it has no aggregate
+func (t *Table) renderAggregates(rows []int, label string, style terminus.Style, colWidths []int, rowNumWidth int) string {
+	var b strings.Builder
+	if t.showRowNumbers {
+		b.WriteString(style.Render(strings.Repeat(" ", rowNumWidth)))
+	}
+	for i, col := range t.columns {
+		if i > 0 || t.showRowNumbers {
+			b.WriteString("|")
+		}
+		var text string
+		if _, ok := t.aggregates[i]; ok {
+			text = t.alignText(t.aggregate(i, rows), colWidths[i], col.Align)
+		} else if i == 0 {
+			text = fitWidth(label, colWidths[i])
+		} else {
+			text = strings.Repeat(" ", colWidths[i])
+		}
+		b.WriteString(style.Render(text))
+	}
+	return b.String()
+}
+
+// headerTitle returns a column's title with its sort and filter markers
+func (t *Table) headerTitle(i int) string {
+	header := t.columns[i].Title
+	if t.sortColumn == i {
+		switch t.sortOrder {
+		case SortAsc:
+			header += " ↑"
+		case SortDesc:
+			header += " ↓"
+		}
+	}
+	if _, ok := t.columnFilters[i]; ok {
+		header += t.filterMarker
+	}
+	return header
+}
+
+// rowNumberWidth returns the width of the row number column, or 0
+func (t *Table) rowNumberWidth() int {
+	if !t.showRowNumbers {
+		return 0
+	}
+	return len(fmt.Sprintf("%d", len(t.rows))) + 2
+}
+
+// AutoSizeColumns sets each column's Width to fit its title and cells,
+// within its MinWidth and MaxWidth. If the columns are wider than
+// maxWidth, the widest are narrowed first, down to their MinWidth. A
+// maxWidth of 0 uses the table's width.
+func (t *Table) AutoSizeColumns(maxWidth int) *Table {
+	if maxWidth <= 0 {
+		maxWidth = t.width
+	}
+	widths := make([]int, len(t.columns))
+	auto := make([]bool, len(t.columns))
+	for i := range t.columns {
+		widths[i] = t.contentWidth(i)
+		auto[i] = true
+	}
+	t.shrinkColumns(widths, auto, maxWidth)
+	for i := range t.columns {
+		t.columns[i].Width = widths[i]
+	}
+	return t
+}
+
+// columnWidths returns the width of each column. Columns without a Width
+// are sized to their content and share what the others leave of the
+// table's width.
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.columns))
+	auto := make([]bool, len(t.columns))
+	for i, col := range t.columns {
+		widths[i] = col.Width
+		if widths[i] <= 0 {
+			widths[i] = t.contentWidth(i)
+			auto[i] = true
+		}
+	}
+	t.shrinkColumns(widths, auto, t.width)
+	return widths
+}
+
+// contentWidth returns the width of column i's widest title or cell,
+// within the column's limits
+func (t *Table) contentWidth(i int) int {
+	col := t.columns[i]
+	width := visibleWidth(t.headerTitle(i))
+	for _, row := range t.all {
+		if i < len(row) && row[i] != nil {
+			if w := visibleWidth(row[i].Render()); w > width {
+				width = w
+			}
+		}
+	}
+	if col.MaxWidth > 0 && width > col.MaxWidth {
+		width = col.MaxWidth
+	}
+	if width < t.minColumnWidth(i) {
+		width = t.minColumnWidth(i)
+	}
+	return width
+}
+
+// minColumnWidth returns the narrowest column i may be
+func (t *Table) minColumnWidth(i int) int {
+	if t.columns[i].MinWidth > 1 {
+		return t.columns[i].MinWidth
+	}
+	return 1
+}
+
+// shrinkColumns narrows the widest auto-sized columns until the table fits
+// in maxWidth or none can be narrowed further
+func (t *Table) shrinkColumns(widths []int, auto []bool, maxWidth int) {
+	available := maxWidth - (len(widths) - 1) // Separators
+	if t.showRowNumbers {
+		available -= t.rowNumberWidth() + 1
+	}
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > available {
+		widest := -1
+		for i, w := range widths {
+			if auto[i] && w > t.minColumnWidth(i) && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// position returns the text of the position footer for the rows from
+// first up to end
+func (t *Table) position(first, end int) string {
+	if end <= first {
+		return fmt.Sprintf("%d rows", len(t.rows))
+	}
+	return fmt.Sprintf("rows %d–%d of %d", first+1, end, len(t.rows))
+}
+
+// pageRows returns the number of rows that fit between the header and the
+// footers
+func (t *Table) pageRows() int {
+	return t.height - t.headerLines() - t.footerLines()
+}
+
+// footerLines returns the number of lines below the rows
+func (t *Table) footerLines() int {
+	lines := 0
+	if len(t.aggregates) > 0 {
+		lines += 2 // Separator + aggregates
+	}
+	if t.loadingMore {
+		lines++ // Loading footer
+	}
+	if t.showPosition {
+		lines++
+	}
+	return lines
+}
+
+// tableHeader is the lines shown above the rows
+type tableHeader struct {
+	status    bool // The table's filter and how many rows pass
+	titles    bool // The column titles
+	separator bool
+	filters   bool // The column filters
+}
+
+// header returns the lines shown above the rows. When the table is too
+// short for all of them and a row, the least useful are left out first:
+// the column filters, the filter status, then the separator, keeping the
+// column titles while there is room for them and a row.
+func (t *Table) header() tableHeader {
+	room := t.height - t.footerLines() - 1 // Keep a line for a row
+	if t.height <= 0 {
+		room = 4
+	}
+	keep := func(want bool) bool {
+		if want && room > 0 {
+			room--
+			return true
+		}
+		return false
+	}
+
+	var header tableHeader
+	header.titles = keep(t.showHeader)
+	header.separator = keep(t.showHeader)
+	header.status = keep(t.showFilterRow && t.filter.re != nil)
+	header.filters = keep(t.showHeader && t.showFilterRow)
+	return header
+}
+
+// headerLines returns the number of lines above the rows
+func (t *Table) headerLines() int {
+	header := t.header()
+	lines := 0
+	for _, shown := range []bool{header.status, header.titles, header.separator, header.filters} {
+		if shown {
+			lines++
+		}
+	}
+	return lines
+}
+
+// withScrollbar adds a scrollbar beside the rows of a rendered table
+func (t *Table) withScrollbar(view string, visibleRows int) string {
+	lines := strings.Split(view, "\n")
+	first := t.headerLines()
+	if visibleRows <= 0 || first >= len(lines) {
+		return view
+	}
+
+	width := 0
+	for _, line := range lines {
+		if w := visibleWidth(line); w > width {
+			width = w
+		}
+	}
+	rows := lines[first:]
+	if len(rows) > visibleRows {
+		rows = rows[:visibleRows]
+	}
+	attachScrollbar(rows, width, t.scrollbar.Cells(len(rows), len(t.rows), visibleRows, t.scrollOffsetY))
+	return strings.Join(lines, "\n")
 }
 
 // alignText aligns text within the given width
 func (t *Table) alignText(text string, width int, align Alignment) string {
-	if len(text) >= width {
-		return text[:width]
+	return alignCell(text, width, align)
+}
+
+// newline separates the lines of a view
+var newline = []byte("\n")
+
+// writeCell writes text aligned in width and rendered in style, as
+// style.Render(alignCell(text, width, align)) would
+func writeCell(ctx *terminus.RenderContext, text string, width int, align Alignment, style terminus.Style) {
+	textWidth := visibleWidth(text)
+	if textWidth > width {
+		ctx.WriteStyled(style, fitWidth(text, width))
+		return
+	}
+
+	padding := width - textWidth
+	switch align {
+	case AlignRight:
+		ctx.WritePadded(style, text, padding, 0)
+	case AlignCenter:
+		ctx.WritePadded(style, text, padding/2, padding-padding/2)
+	default:
+		ctx.WritePadded(style, text, 0, padding)
+	}
+}
+
+// alignCell pads or truncates text to width, aligned within it
+func alignCell(text string, width int, align Alignment) string {
+	if visibleWidth(text) >= width {
+		return fitWidth(text, width)
 	}
 
-	padding := width - len(text)
+	padding := width - visibleWidth(text)
 	switch align {
 	case AlignLeft:
 		return text + strings.Repeat(" ", padding)
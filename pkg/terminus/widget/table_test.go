@@ -15,10 +15,13 @@
 package widget
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/skaiser/terminusgo/pkg/terminus"
+	"github.com/skaiser/terminusgo/pkg/terminus/perf"
 )
 
 func TestSimpleTableCell(t *testing.T) {
@@ -35,6 +38,15 @@ func TestSimpleTableCell(t *testing.T) {
 	if cell.Value() != "test cell" {
 		t.Errorf("Expected Value() to return 'test cell', got '%v'", cell.Value())
 	}
+
+	cell = NewSimpleTableCell("evil\x1b]0;title\a\r")
+	if cell.Render() != "evil" {
+		t.Errorf("Expected the escapes to be removed, got %q", cell.Render())
+	}
+
+	if cell := NewStyledTableCell("\x1b[1mbold\x1b[0m"); cell.Render() != "\x1b[1mbold\x1b[0m" {
+		t.Errorf("Expected styled text to be kept, got %q", cell.Render())
+	}
 }
 
 func TestTable(t *testing.T) {
@@ -473,6 +485,50 @@ func TestTable(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Keeps the header while rows scroll under it",
+			test: func(t *testing.T) {
+				data := make([][]string, 10)
+				for i := range data {
+					data[i] = []string{strconv.Itoa(i)}
+				}
+				table := NewTable().SetStringData([]string{"N"}, data)
+				table.SetSize(10, 2)
+				table.Focus()
+				for range 5 {
+					table.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+				}
+
+				lines := strings.Split(plain(table.View()), "\n")
+				if len(lines) != 2 || !strings.HasPrefix(lines[0], "N") || !strings.HasPrefix(lines[1], "5") {
+					t.Errorf("Expected the header over the selected row, got %q", lines)
+				}
+
+				// With room, the separator is back
+				table.SetSize(10, 4)
+				lines = strings.Split(plain(table.View()), "\n")
+				if len(lines) != 4 || !strings.HasPrefix(lines[1], "-") || !strings.HasPrefix(lines[2], "5") {
+					t.Errorf("Expected the header, separator and rows from the selection, got %q", lines)
+				}
+			},
+		},
+		{
+			name: "Shows the rows in view",
+			test: func(t *testing.T) {
+				data := make([][]string, 500)
+				for i := range data {
+					data[i] = []string{strconv.Itoa(i)}
+				}
+				table := NewTable().SetStringData([]string{"Number"}, data).SetShowPosition(true)
+				table.SetSize(20, 23)
+				table.SetSelected(39, 0)
+
+				lines := strings.Split(plain(table.View()), "\n")
+				if len(lines) != 23 || strings.TrimSpace(lines[22]) != "rows 21–40 of 500" || !strings.HasPrefix(lines[21], "39") {
+					t.Errorf("Expected 20 rows above the position, got %q", lines)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -504,4 +560,291 @@ func TestTableChaining(t *testing.T) {
 	if table.SelectedCol() != 1 {
 		t.Error("Method chaining should work correctly")
 	}
-}
\ No newline at end of file
+}
+
+func TestTableLoadMore(t *testing.T) {
+	calls := 0
+	table := NewTable().
+		SetStringData([]string{"ID"}, [][]string{{"1"}, {"2"}, {"3"}}).
+		SetReachEndThreshold(0).
+		SetLoadingStyle(terminus.NewStyle()).
+		SetOnReachEnd(func() terminus.Cmd {
+			calls++
+			return nil
+		})
+	table.SetSize(20, 6)
+	table.Focus()
+
+	table.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+	if calls != 0 {
+		t.Fatal("Expected no load before the last row")
+	}
+
+	table.Update(terminus.KeyMsg{Type: terminus.KeyDown})
+	if calls != 1 || !table.Loading() {
+		t.Fatalf("Expected a load on the last row, got %d calls", calls)
+	}
+	if !strings.Contains(table.View(), "loading more…") {
+		t.Errorf("Expected loading footer, got %q", table.View())
+	}
+
+	table.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+	if calls != 1 {
+		t.Error("Expected no second load while loading")
+	}
+
+	table.AddRow(TableRow{NewSimpleTableCell("4")})
+	if table.Loading() || strings.Contains(table.View(), "loading more…") {
+		t.Error("Expected adding a row to clear loading")
+	}
+}
+
+func TestTableStyleFuncs(t *testing.T) {
+	red := terminus.NewStyle().Foreground(terminus.Red)
+	green := terminus.NewStyle().Foreground(terminus.Green)
+	table := NewTable().SetStringData([]string{"Name", "CPU"}, [][]string{
+		{"idle", "2"},
+		{"busy", "95"},
+	})
+	table.SetColumns([]TableColumn{{Title: "Name", Width: 6}, {Title: "CPU", Width: 4}})
+	table.SetSize(20, 4)
+	table.SetSelected(1, 0)
+	table.SetRowStyleFunc(func(row int, data TableRow) terminus.Style {
+		if data[1].String() == "95" {
+			return red
+		}
+		return terminus.NewStyle()
+	})
+	table.SetCellStyleFunc(func(row, col int, cell TableCell) terminus.Style {
+		if col == 0 && cell.String() == "idle" {
+			return green
+		}
+		return terminus.NewStyle()
+	})
+
+	lines := strings.Split(table.View(), "\n")
+	if !strings.Contains(lines[2], green.Render("idle  ")) || strings.Contains(lines[2], "\x1b[0;31m") {
+		t.Errorf("Expected only the idle cell styled, got %q", lines[2])
+	}
+	if strings.Contains(lines[3], red.Render("busy  ")) {
+		t.Errorf("Expected the selection to mark the selected row, got %q", lines[3])
+	}
+
+	table.SetSelected(0, 0)
+	lines = strings.Split(table.View(), "\n")
+	if !strings.Contains(lines[3], red.Render("busy  ")) || !strings.Contains(lines[3], red.Render("95  ")) {
+		t.Errorf("Expected the busy row red, got %q", lines[3])
+	}
+	if plain(lines[3]) != "busy  |95  " {
+		t.Errorf("Expected styles not to change the layout, got %q", plain(lines[3]))
+	}
+}
+
+// groupTable has a team and hours per row
+func groupTable() *Table {
+	table := NewTable().SetStringData([]string{"Team", "Hours"}, [][]string{
+		{"web", "3"},
+		{"api", "5"},
+		{"web", "4.5"},
+		{"api", "1"},
+		{"ops", "2"},
+	})
+	table.SetColumns([]TableColumn{{Title: "Team", Width: 12}, {Title: "Hours", Width: 6, Align: AlignRight}})
+	table.SetSize(30, 12)
+	return table
+}
+
+func TestTableGrouping(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Groups rows under headers",
+			test: func(t *testing.T) {
+				table := groupTable().SetGroupBy(0)
+				if table.RowCount() != 8 {
+					t.Fatalf("Expected 3 headers and 5 rows, got %d", table.RowCount())
+				}
+				lines := strings.Split(plain(table.View()), "\n")
+				want := []string{"▾ web (2)", "web", "web", "▾ api (2)", "api", "api", "▾ ops (1)", "ops"}
+				for i, prefix := range want {
+					if !strings.HasPrefix(lines[i+2], prefix) {
+						t.Errorf("Line %d: expected %q, got %q", i+2, prefix, lines[i+2])
+					}
+				}
+				if table.SourceRow(0) != -1 || table.SourceRow(2) != 2 {
+					t.Errorf("Expected headers to have no source row, got %d and %d", table.SourceRow(0), table.SourceRow(2))
+				}
+
+				table.AddRow(TableRow{NewSimpleTableCell("api"), NewSimpleTableCell("2")})
+				if table.RowCount() != 9 || table.SourceRow(6) != 5 {
+					t.Errorf("Expected the added row in its group, got %d rows", table.RowCount())
+				}
+
+				table.SetGroupBy(-1)
+				if table.RowCount() != 6 {
+					t.Errorf("Expected the rows ungrouped, got %d", table.RowCount())
+				}
+			},
+		},
+		{
+			name: "Collapses groups from the keyboard",
+			test: func(t *testing.T) {
+				table := groupTable().SetGroupBy(0)
+				table.Focus()
+				table.SetSelected(0, 0)
+
+				press(table, terminus.KeyMsg{Type: terminus.KeyLeft})
+				if !table.IsCollapsed("web") || table.RowCount() != 6 {
+					t.Fatalf("Expected web collapsed, got %d rows", table.RowCount())
+				}
+				if table.SelectedRow() != 0 || table.SelectedGroup() != "web" {
+					t.Errorf("Expected its header selected, got row %d", table.SelectedRow())
+				}
+				if !strings.HasPrefix(plain(table.View()), "Team") ||
+					!strings.Contains(plain(table.View()), "▸ web (2)") {
+					t.Errorf("Expected a collapsed header, got %q", plain(table.View()))
+				}
+
+				press(table, terminus.KeyMsg{Type: terminus.KeyEnter})
+				if table.IsCollapsed("web") || table.RowCount() != 8 {
+					t.Errorf("Expected Enter to expand web, got %d rows", table.RowCount())
+				}
+				press(table, terminus.KeyMsg{Type: terminus.KeyLeft}, terminus.KeyMsg{Type: terminus.KeyRight})
+				if table.IsCollapsed("web") {
+					t.Error("Expected Right to expand the header's group")
+				}
+
+				// Left on a row leaves the group alone, and moves between
+				// cells if they are selectable
+				press(table, terminus.KeyMsg{Type: terminus.KeyDown}, terminus.KeyMsg{Type: terminus.KeyLeft})
+				if table.IsCollapsed("web") {
+					t.Error("Expected Left on a row to leave its group expanded")
+				}
+				table.SetCellSelection(true)
+				press(table, terminus.KeyMsg{Type: terminus.KeyRight}, terminus.KeyMsg{Type: terminus.KeyLeft})
+				if table.IsCollapsed("web") || table.SelectedCol() != 0 {
+					t.Errorf("Expected Left to move to column 0 with web expanded, got column %d", table.SelectedCol())
+				}
+			},
+		},
+		{
+			name: "Shows aggregates per group and in the footer",
+			test: func(t *testing.T) {
+				table := groupTable().SetGroupBy(0).
+					SetAggregate(1, AggregateSum("%.1f")).
+					CollapseGroup("api")
+				lines := strings.Split(plain(table.View()), "\n")
+				if lines[2] != "▾ web (2)   |   7.5" {
+					t.Errorf("Expected the group's sum, got %q", lines[2])
+				}
+				if lines[5] != "▸ api (2)   |   6.0" {
+					t.Errorf("Expected the collapsed group's sum, got %q", lines[5])
+				}
+				if len(lines) != 12 || lines[11] != "Total       |  15.5" {
+					t.Errorf("Expected the footer on the last line, got %q", lines[len(lines)-1])
+				}
+
+				table.SetFilter("web")
+				lines = strings.Split(plain(table.View()), "\n")
+				if lines[11] != "Total       |   7.5" {
+					t.Errorf("Expected the footer to follow the filter, got %q", lines[11])
+				}
+			},
+		},
+		{
+			name: "Reduces cells",
+			test: func(t *testing.T) {
+				cells := []TableCell{NewSimpleTableCell("2"), NewSimpleTableCell(" 4 "), NewSimpleTableCell("n/a"), NewSimpleTableCell("")}
+				if got := AggregateSum("%.0f")(cells); got != "6" {
+					t.Errorf("Expected a sum of 6, got %q", got)
+				}
+				if got := AggregateAverage("%.1f")(cells); got != "3.0" {
+					t.Errorf("Expected an average of 3.0, got %q", got)
+				}
+				if got := AggregateCount()(cells); got != "3" {
+					t.Errorf("Expected 3 non-empty cells, got %q", got)
+				}
+				if got := AggregateAverage("%.1f")(nil); got != "" {
+					t.Errorf("Expected no average of nothing, got %q", got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestTableAutoSizeColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Fits columns to their content",
+			test: func(t *testing.T) {
+				table := NewTable().SetStringData([]string{"ID", "Name"}, [][]string{
+					{"1", "Ada"},
+					{"22", "\x1b[1mGrace\x1b[0m"},
+				})
+				table.SetColumns([]TableColumn{{Title: "ID", MinWidth: 3}, {Title: "Name"}})
+				table.AutoSizeColumns(40)
+				if table.columns[0].Width != 3 || table.columns[1].Width != 5 {
+					t.Errorf("Expected widths 3 and 5, got %d and %d", table.columns[0].Width, table.columns[1].Width)
+				}
+			},
+		},
+		{
+			name: "Narrows the widest columns first",
+			test: func(t *testing.T) {
+				table := NewTable().SetStringData([]string{"A", "B", "C"}, [][]string{
+					{"xx", strings.Repeat("y", 20), strings.Repeat("z", 10)},
+				})
+				table.SetColumns([]TableColumn{{Title: "A"}, {Title: "B", MinWidth: 14}, {Title: "C", MaxWidth: 8}})
+				table.AutoSizeColumns(20)
+				got := []int{table.columns[0].Width, table.columns[1].Width, table.columns[2].Width}
+				if got[0] != 2 || got[1] != 14 || got[2] != 2 {
+					t.Errorf("Expected widths [2 14 2], got %v", got)
+				}
+			},
+		},
+		{
+			name: "Sizes columns without a width when rendering",
+			test: func(t *testing.T) {
+				table := NewTable().SetStringData([]string{"Key", "Value"}, [][]string{
+					{"colour", "blue"},
+					{"size", "a rather long value"},
+				})
+				table.SetSize(20, 4)
+				lines := strings.Split(plain(table.View()), "\n")
+				if lines[0] != "Key   |Value        " || lines[3] != "size  |a rather long" {
+					t.Errorf("Expected the value column narrowed to fit, got %q", lines)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+// BenchmarkTableView renders a screenful of a 10k row table, scrolling down a
+// row per frame
+func BenchmarkTableView(b *testing.B) {
+	data := make([][]string, 10000)
+	for i := range data {
+		data[i] = []string{fmt.Sprint(i), fmt.Sprintf("user%d@example.com", i), "active"}
+	}
+	table := NewTable().SetStringData([]string{"ID", "Email", "Status"}, data)
+	table.Focus()
+
+	b.Run("View", func(b *testing.B) {
+		table.SetSize(120, 40)
+		perf.View(b, table)
+	})
+	b.Run("Frames", func(b *testing.B) {
+		perf.Frames(b, table, 120, 40, terminus.KeyMsg{Type: terminus.KeyDown})
+	})
+}
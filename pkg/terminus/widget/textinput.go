@@ -25,10 +25,11 @@ import (
 type TextInput struct {
 	Model
 	
-	// Input state
-	value       string
+	// Input state, with the cursor counted in runes
+	value       []rune
 	placeholder string
 	cursor      int
+	direction   Direction
 	
 	// Display settings
 	showCursor   bool
@@ -44,6 +45,10 @@ type TextInput struct {
 	// Validation
 	validator func(string) bool
 	
+	// Clipboard
+	killRing *KillRing
+	yank     *yankSpan // The text just pasted, which Alt+Y replaces
+	
 	// Events
 	onSubmit func(string) terminus.Cmd
 	onChange func(string) terminus.Cmd
@@ -51,7 +56,7 @@ type TextInput struct {
 
 // NewTextInput creates a new text input widget
 func NewTextInput() *TextInput {
-	return &TextInput{
+	t := &TextInput{
 		Model:           NewModel(),
 		showCursor:      true,
 		cursorChar:      '|',
@@ -61,18 +66,48 @@ func NewTextInput() *TextInput {
 		placeholderStyle: terminus.NewStyle().Faint(true),
 		cursorStyle:     terminus.NewStyle().Reverse(true),
 	}
+	t.singleLine()
+	return t
 }
 
-// SetValue sets the input value
+// SetValue sets the input value, without escape sequences or control
+// characters
 func (t *TextInput) SetValue(value string) *TextInput {
-	t.value = value
+	t.value = []rune(strings.NewReplacer("\n", " ", "\t", " ").Replace(terminus.Sanitize(value)))
 	t.cursor = len(t.value) // Move cursor to end of new value
 	return t
 }
 
 // Value returns the current input value
 func (t *TextInput) Value() string {
-	return t.value
+	return string(t.value)
+}
+
+// SetDirection sets the direction the input is written in. Right-to-left
+// input is aligned right and Left moves the cursor toward its end. The
+// default, DirectionAuto, follows the first letter typed.
+func (t *TextInput) SetDirection(direction Direction) *TextInput {
+	t.direction = direction
+	return t
+}
+
+// Direction returns the direction the input is currently written in
+func (t *TextInput) Direction() Direction {
+	if len(t.value) == 0 {
+		return t.direction.resolve(t.placeholder)
+	}
+	return t.direction.resolve(string(t.value))
+}
+
+// KeyBindings returns the keys the input handles, every printable one
+// included, for checking global shortcuts against
+func (t *TextInput) KeyBindings() []terminus.KeyMsg {
+	keys := append(keyBindings("", terminus.KeyEnter, terminus.KeyBackspace, terminus.KeyDelete,
+		terminus.KeyLeft, terminus.KeyRight, terminus.KeyHome, terminus.KeyEnd, terminus.KeySpace), terminus.AnyText)
+	if t.killRing != nil {
+		keys = append(keys, killRingKeys...)
+	}
+	return keys
 }
 
 // SetPlaceholder sets the placeholder text
@@ -150,39 +185,51 @@ func (t *TextInput) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 	
 	switch msg := msg.(type) {
 	case terminus.KeyMsg:
+		if t.killRing != nil && t.killRingKey(msg) {
+			if t.onChange != nil {
+				cmd = t.onChange(string(t.value))
+			}
+			return t, cmd
+		}
+		
 		switch msg.Type {
 		case terminus.KeyEnter:
 			if t.onSubmit != nil {
-				cmd = t.onSubmit(t.value)
+				cmd = t.onSubmit(string(t.value))
 			}
 			
 		case terminus.KeyBackspace:
 			if t.cursor > 0 && len(t.value) > 0 {
 				// Remove character before cursor
-				t.value = t.value[:t.cursor-1] + t.value[t.cursor:]
+				t.value = append(t.value[:t.cursor-1], t.value[t.cursor:]...)
 				t.cursor--
 				if t.onChange != nil {
-					cmd = t.onChange(t.value)
+					cmd = t.onChange(string(t.value))
 				}
 			}
 			
 		case terminus.KeyDelete:
 			if t.cursor < len(t.value) {
 				// Remove character at cursor
-				t.value = t.value[:t.cursor] + t.value[t.cursor+1:]
+				t.value = append(t.value[:t.cursor], t.value[t.cursor+1:]...)
 				if t.onChange != nil {
-					cmd = t.onChange(t.value)
+					cmd = t.onChange(string(t.value))
 				}
 			}
 			
 		case terminus.KeyLeft:
-			if t.cursor > 0 {
-				t.cursor--
+			// Right-to-left text is read leftwards
+			if t.Direction() == DirectionRTL {
+				t.moveCursor(1)
+			} else {
+				t.moveCursor(-1)
 			}
 			
 		case terminus.KeyRight:
-			if t.cursor < len(t.value) {
-				t.cursor++
+			if t.Direction() == DirectionRTL {
+				t.moveCursor(-1)
+			} else {
+				t.moveCursor(1)
 			}
 			
 		case terminus.KeyHome:
@@ -193,31 +240,21 @@ func (t *TextInput) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 			
 		case terminus.KeySpace:
 			// Handle space key
-			if len(t.value) < t.maxLength {
-				testValue := t.value[:t.cursor] + " " + t.value[t.cursor:]
-				if t.validator == nil || t.validator(testValue) {
-					t.value = testValue
-					t.cursor++
-					if t.onChange != nil {
-						cmd = t.onChange(t.value)
-					}
+			if len(t.value) < t.maxLength && t.insert(' ') {
+				if t.onChange != nil {
+					cmd = t.onChange(string(t.value))
 				}
 			}
 			
 		case terminus.KeyRunes:
 			// Insert characters at cursor position
 			for _, r := range msg.Runes {
-				if unicode.IsPrint(r) && len(t.value) < t.maxLength {
-					// Validate input if validator is set
-					testValue := t.value[:t.cursor] + string(r) + t.value[t.cursor:]
-					if t.validator == nil || t.validator(testValue) {
-						t.value = testValue
-						t.cursor++
-					}
+				if (unicode.IsPrint(r) || terminus.IsCombining(r)) && len(t.value) < t.maxLength {
+					t.insert(r)
 				}
 			}
 			if t.onChange != nil {
-				cmd = t.onChange(t.value)
+				cmd = t.onChange(string(t.value))
 			}
 		}
 	}
@@ -225,43 +262,146 @@ func (t *TextInput) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 	return t, cmd
 }
 
-// View implements the Component interface
+// yankSpan is text pasted from the kill ring
+type yankSpan struct {
+	start, end int // Runes of the value
+	index      int // Entry of the ring
+}
+
+// SetKillRing sets the clipboard shared with other widgets of the session.
+// With one, Ctrl+K cuts to the end of the input and Ctrl+U to its start,
+// Ctrl+Y pastes the newest entry and Alt+Y right after it replaces the
+// paste with the entry before.
+func (t *TextInput) SetKillRing(ring *KillRing) *TextInput {
+	t.killRing = ring
+	return t
+}
+
+// killRingKey cuts or pastes if key is one of the kill ring's and reports
+// whether it was
+func (t *TextInput) killRingKey(key terminus.KeyMsg) bool {
+	yanked := t.yank
+	t.yank = nil
+	if key.Type != terminus.KeyRunes || len(key.Runes) != 1 {
+		return false
+	}
+
+	switch r := key.Runes[0]; {
+	case key.Ctrl && r == 'k':
+		t.killRing.Push(string(t.value[t.cursor:]))
+		t.value = t.value[:t.cursor]
+	case key.Ctrl && r == 'u':
+		t.killRing.Push(string(t.value[:t.cursor]))
+		t.value = t.value[t.cursor:]
+		t.cursor = 0
+	case key.Ctrl && r == 'y':
+		t.paste(0)
+	case key.Alt && r == 'y':
+		if yanked != nil {
+			value, cursor := t.value, t.cursor
+			t.value = append(append([]rune{}, value[:yanked.start]...), value[yanked.end:]...)
+			t.cursor = yanked.start
+			if t.paste(yanked.index + 1); t.yank == nil {
+				// The validator refused the older entry
+				t.value, t.cursor, t.yank = value, cursor, yanked
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// paste inserts entry i of the kill ring at the cursor, as far as the
+// input's length allows, if the validator accepts the result
+func (t *TextInput) paste(i int) {
+	if t.killRing.Len() == 0 {
+		return
+	}
+	var text []rune
+	for _, r := range terminus.Sanitize(t.killRing.At(i)) {
+		if len(t.value)+len(text) >= t.maxLength {
+			break
+		}
+		switch {
+		case r == '\n' || r == '\t':
+			text = append(text, ' ')
+		case unicode.IsPrint(r) || terminus.IsCombining(r):
+			text = append(text, r)
+		}
+	}
+
+	value := make([]rune, 0, len(t.value)+len(text))
+	value = append(value, t.value[:t.cursor]...)
+	value = append(value, text...)
+	value = append(value, t.value[t.cursor:]...)
+	if t.validator != nil && !t.validator(string(value)) {
+		return
+	}
+	t.value = value
+	t.yank = &yankSpan{start: t.cursor, end: t.cursor + len(text), index: i}
+	t.cursor += len(text)
+}
+
+// insert inserts r at the cursor, if the validator accepts the result,
+// and reports whether it did
+func (t *TextInput) insert(r rune) bool {
+	value := make([]rune, 0, len(t.value)+1)
+	value = append(value, t.value[:t.cursor]...)
+	value = append(value, r)
+	value = append(value, t.value[t.cursor:]...)
+	if t.validator != nil && !t.validator(string(value)) {
+		return false
+	}
+	t.value = value
+	t.cursor++
+	return true
+}
+
+// moveCursor moves the cursor by delta characters, stepping over the
+// combining marks drawn with the character before them
+func (t *TextInput) moveCursor(delta int) {
+	t.SetCursor(t.cursor + delta)
+	for t.cursor > 0 && t.cursor < len(t.value) && terminus.IsCombining(t.value[t.cursor]) {
+		if delta < 0 {
+			t.cursor--
+		} else {
+			t.cursor++
+		}
+	}
+}
+
+// View implements the Component interface. Right-to-left input is aligned
+// right, with the cursor at its end drawn left of the text.
 func (t *TextInput) View() string {
 	// Determine what to display
-	displayValue := t.value
+	text := t.value
 	showPlaceholder := len(t.value) == 0
-	
 	if showPlaceholder {
-		displayValue = t.placeholder
+		text = []rune(t.placeholder)
 	}
+	rtl := t.Direction() == DirectionRTL
 	
-	// Calculate display bounds based on width
-	start := 0
-	end := len(displayValue)
-	
-	// If content is longer than width, scroll to show cursor
-	if len(displayValue) > t.width {
-		if t.cursor >= t.width {
-			start = t.cursor - t.width + 1
-		}
-		end = start + t.width
-		if end > len(displayValue) {
-			end = len(displayValue)
-		}
+	// Scroll to keep the cursor in view, leaving it a cell at the end
+	start, cursor := 0, t.cursor
+	if showPlaceholder {
+		cursor = 0
 	}
-	
-	// Extract visible portion
-	visibleValue := ""
-	if end > start {
-		visibleValue = displayValue[start:end]
+	for start < cursor && runesWidth(text[start:cursor])+1 > t.width {
+		start++
 	}
-	
-	// Pad to full width
-	visibleValue = padRight(visibleValue, t.width)
+	end := start
+	for end < len(text) && runesWidth(text[start:end+1]) <= t.width {
+		end++
+	}
+	padding := t.width - runesWidth(text[start:end])
 	
 	// Build the final rendered output
 	if showPlaceholder {
-		return t.placeholderStyle.Render(visibleValue)
+		if rtl {
+			return t.placeholderStyle.Render(spaces(padding) + string(text[start:end]))
+		}
+		return t.placeholderStyle.Render(string(text[start:end]) + spaces(padding))
 	}
 	
 	// Determine base style
@@ -270,54 +410,61 @@ func (t *TextInput) View() string {
 		baseStyle = t.focusStyle
 	}
 	
-	// Handle cursor display
-	if t.Focused() && t.showCursor {
-		cursorPos := t.cursor - start
-		if cursorPos >= 0 && cursorPos <= t.width {
-			// Style the parts separately
-			var result string
-			
-			// Part before cursor
-			if cursorPos > 0 {
-				result += baseStyle.Render(visibleValue[:cursorPos])
-			}
-			
-			// Cursor character
-			if cursorPos < len(visibleValue) {
-				char := []rune(visibleValue)[cursorPos]
-				if char == ' ' {
-					char = t.cursorChar
-				}
-				result += t.cursorStyle.Render(string(char))
-				
-				// Part after cursor
-				if cursorPos+1 < len(visibleValue) {
-					result += baseStyle.Render(visibleValue[cursorPos+1:])
-				}
-			} else {
-				// Cursor at end
-				result += t.cursorStyle.Render(string(t.cursorChar))
-			}
-			
-			return result
+	// No cursor, just apply base style
+	if !t.Focused() || !t.showCursor {
+		if rtl {
+			return baseStyle.Render(spaces(padding) + string(text[start:end]))
 		}
+		return baseStyle.Render(string(text[start:end]) + spaces(padding))
 	}
 	
-	// No cursor, just apply base style
-	return baseStyle.Render(visibleValue)
+	// Style the parts separately: the cursor highlights the character at
+	// it, or takes a cell of the padding at the end of the text
+	var before, at, after string
+	before = string(text[start:cursor])
+	if cursor < end {
+		char := text[cursor]
+		if char == ' ' {
+			char = t.cursorChar
+		}
+		at = t.cursorStyle.Render(string(char))
+		after = string(text[cursor+1 : end])
+	} else {
+		at = t.cursorStyle.Render(string(t.cursorChar))
+		if padding > 0 {
+			padding--
+		}
+	}
+	
+	if rtl {
+		if cursor >= end {
+			return baseStyle.Render(spaces(padding)) + at + baseStyle.Render(before)
+		}
+		return baseStyle.Render(spaces(padding)+before) + at + baseStyle.Render(after)
+	}
+	return baseStyle.Render(before) + at + baseStyle.Render(after+spaces(padding))
+}
+
+// runesWidth returns the number of cells runes take
+func runesWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		width += terminus.RuneWidth(r)
+	}
+	return width
 }
 
-// padRight pads a string to the specified width with spaces
-func padRight(str string, width int) string {
-	if len(str) >= width {
-		return str[:width]
+// spaces returns n spaces
+func spaces(n int) string {
+	if n <= 0 {
+		return ""
 	}
-	return str + strings.Repeat(" ", width-len(str))
+	return strings.Repeat(" ", n)
 }
 
 // Clear clears the input value
 func (t *TextInput) Clear() {
-	t.value = ""
+	t.value = nil
 	t.cursor = 0
 }
 
@@ -445,4 +445,86 @@ func TestTextInputChaining(t *testing.T) {
 	if ti.Value() != "test" {
 		t.Error("Method chaining should work correctly")
 	}
-}
\ No newline at end of file
+}
+
+func TestTextInputRightToLeft(t *testing.T) {
+	// hebrew is "shalom"
+	const hebrew = "שלום"
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Edits by character",
+			test: func(t *testing.T) {
+				ti := NewTextInput()
+				ti.Focus()
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune(hebrew)})
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyBackspace})
+				if ti.Value() != "שלו" || ti.cursor != 3 {
+					t.Errorf("Expected the last letter deleted, got %q at %d", ti.Value(), ti.cursor)
+				}
+			},
+		},
+		{
+			name: "Moves the cursor the way the text is read",
+			test: func(t *testing.T) {
+				ti := NewTextInput().SetValue(hebrew)
+				ti.Focus()
+				ti.SetCursor(2)
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if ti.cursor != 3 {
+					t.Errorf("Expected Left to move toward the end, got %d", ti.cursor)
+				}
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				if ti.cursor != 1 {
+					t.Errorf("Expected Right to move toward the start, got %d", ti.cursor)
+				}
+
+				ti.SetDirection(DirectionLTR)
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if ti.cursor != 0 {
+					t.Errorf("Expected a left-to-right input to move back, got %d", ti.cursor)
+				}
+			},
+		},
+		{
+			name: "Steps over combining marks",
+			test: func(t *testing.T) {
+				// Arabic "bi" with its kasra
+				ti := NewTextInput().SetValue("بِس")
+				ti.Focus()
+				ti.SetCursor(0)
+				ti.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if ti.cursor != 2 {
+					t.Errorf("Expected the cursor past the mark, got %d", ti.cursor)
+				}
+			},
+		},
+		{
+			name: "Aligns right with the cursor left of the text",
+			test: func(t *testing.T) {
+				ti := NewTextInput().SetValue(hebrew).SetCursorChar('_')
+				ti.SetSize(8, 1)
+				ti.SetCursorStyle(terminus.NewStyle())
+				ti.SetFocusStyle(terminus.NewStyle())
+				ti.Focus()
+				if got := ti.View(); got != "   _"+hebrew {
+					t.Errorf("Expected %q, got %q", "   _"+hebrew, got)
+				}
+
+				ti.Blur()
+				ti.SetStyle(terminus.NewStyle())
+				if got := ti.View(); got != "    "+hebrew {
+					t.Errorf("Expected %q, got %q", "    "+hebrew, got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+	"github.com/skaiser/terminusgo/pkg/terminus/layout"
+)
+
+// tourZ draws a tour's surfaces over the surfaces of its content
+const tourZ = 100
+
+// TourStep is a step of a Tour: a callout explaining the part of the screen
+// it highlights
+type TourStep struct {
+	// Target names the part to highlight, found with the content's
+	// ChildRegion, e.g. the name of a child of a Compose
+	Target string
+	// Region is highlighted when there is no Target or the content doesn't
+	// know it. The zero Region highlights nothing and centers the callout.
+	Region terminus.Region
+	Title  string
+	Text   string
+}
+
+// TourTargets is implemented by components that can tell where the parts a
+// Tour highlights are drawn, as a Compose does for its children
+type TourTargets interface {
+	ChildRegion(name string) (terminus.Region, bool)
+}
+
+// Tour walks users through an app, highlighting parts of the screen in turn
+// with a callout explaining each. It wraps the app's root component and
+// draws over it as surfaces. While it runs, Enter or Right goes to the next
+// step, Left or Backspace back to the previous one and Esc skips the rest; other
+// messages still reach the app.
+type Tour struct {
+	content terminus.Component
+	steps   []TourStep
+	step    int
+	active  bool
+	width   int
+	height  int
+
+	highlightStyle terminus.Style
+	titleStyle     terminus.Style
+	hintStyle      terminus.Style
+	calloutWidth   int
+
+	onDone func(completed bool) terminus.Cmd
+}
+
+// NewTour creates a tour of content through steps. Call Start to begin it.
+func NewTour(content terminus.Component, steps ...TourStep) *Tour {
+	return &Tour{
+		content:        content,
+		steps:          steps,
+		highlightStyle: terminus.NewStyle().Bold(true).Foreground(terminus.BrightYellow),
+		titleStyle:     terminus.NewStyle().Bold(true),
+		hintStyle:      terminus.NewStyle().Faint(true),
+		calloutWidth:   40,
+	}
+}
+
+// SetHighlightStyle sets the style of the frame around the highlighted part
+// and of the callout's border
+func (t *Tour) SetHighlightStyle(style terminus.Style) *Tour {
+	t.highlightStyle = style
+	return t
+}
+
+// SetCalloutWidth sets the width of the callout's text, 40 by default. It
+// is narrowed to fit the screen.
+func (t *Tour) SetCalloutWidth(width int) *Tour {
+	t.calloutWidth = width
+	return t
+}
+
+// SetOnDone sets a callback run when the tour ends, completed or skipped
+func (t *Tour) SetOnDone(callback func(completed bool) terminus.Cmd) *Tour {
+	t.onDone = callback
+	return t
+}
+
+// Start begins the tour at its first step
+func (t *Tour) Start() {
+	t.step = 0
+	t.active = len(t.steps) > 0
+}
+
+// Active reports whether the tour is running
+func (t *Tour) Active() bool {
+	return t.active
+}
+
+// Step returns the index of the current step
+func (t *Tour) Step() int {
+	return t.step
+}
+
+// Content returns the component the tour is of
+func (t *Tour) Content() terminus.Component {
+	return t.content
+}
+
+// end stops the tour, running the done callback
+func (t *Tour) end(completed bool) terminus.Cmd {
+	t.active = false
+	if t.onDone != nil {
+		return t.onDone(completed)
+	}
+	return nil
+}
+
+// Init implements the Component interface
+func (t *Tour) Init() terminus.Cmd {
+	return t.content.Init()
+}
+
+// Update implements the Component interface
+func (t *Tour) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	if size, ok := msg.(terminus.WindowSizeMsg); ok {
+		t.width, t.height = size.Width, size.Height
+	}
+	if key, ok := msg.(terminus.KeyMsg); ok && t.active {
+		switch key.Type {
+		case terminus.KeyEnter, terminus.KeyRight:
+			if t.step == len(t.steps)-1 {
+				return t, t.end(true)
+			}
+			t.step++
+		case terminus.KeyLeft, terminus.KeyBackspace:
+			t.step = max(t.step-1, 0)
+		case terminus.KeyEsc:
+			return t, t.end(false)
+		}
+		return t, nil
+	}
+
+	var cmd terminus.Cmd
+	if updated, c := t.content.Update(msg); updated != nil {
+		t.content, cmd = updated, c
+	}
+	return t, cmd
+}
+
+// View implements the Component interface
+func (t *Tour) View() string {
+	return t.content.View()
+}
+
+// Surfaces implements terminus.Layered, drawing the current step over the
+// content's own surfaces
+func (t *Tour) Surfaces() []terminus.Surface {
+	var surfaces []terminus.Surface
+	if l, ok := t.content.(terminus.Layered); ok {
+		surfaces = l.Surfaces()
+	}
+	if !t.active {
+		return surfaces
+	}
+
+	target, ok := t.target()
+	if ok {
+		surfaces = append(surfaces, t.frame(target)...)
+	}
+	callout := t.callout()
+	lines := strings.Split(callout, "\n")
+	region := t.place(target, ok, visibleWidth(lines[0]), len(lines))
+	return append(surfaces, terminus.Surface{Name: "tour-callout", Z: tourZ + 1, Region: region, View: callout})
+}
+
+// target returns the region of the current step's part, if it has one
+func (t *Tour) target() (terminus.Region, bool) {
+	step := t.steps[t.step]
+	if step.Target != "" {
+		if targets, ok := t.content.(TourTargets); ok {
+			if region, ok := targets.ChildRegion(step.Target); ok {
+				return region, true
+			}
+		}
+	}
+	return step.Region, step.Region != (terminus.Region{})
+}
+
+// frame returns the surfaces of a frame around target: its four sides,
+// drawn just outside it so the part itself stays visible
+func (t *Tour) frame(target terminus.Region) []terminus.Surface {
+	box := layout.NewBox(strings.Repeat("\n", max(target.Height-1, 0))).
+		WithStyle(layout.BoxStyleBold).
+		WithBorderStyle(t.highlightStyle).
+		WithWidth(target.Width).
+		WithHeight(target.Height).
+		Render()
+	lines := strings.Split(box, "\n")
+	side := t.highlightStyle.Render(layout.VerticalLine(target.Height, layout.BoxStyleBold))
+
+	left, top := target.X-1, target.Y-1
+	right, bottom := target.X+target.Width, target.Y+target.Height
+	return []terminus.Surface{
+		{Name: "tour-top", Z: tourZ, Region: terminus.Region{X: left, Y: top, Width: target.Width + 2, Height: 1}, View: lines[0]},
+		{Name: "tour-bottom", Z: tourZ, Region: terminus.Region{X: left, Y: bottom, Width: target.Width + 2, Height: 1}, View: lines[len(lines)-1]},
+		{Name: "tour-left", Z: tourZ, Region: terminus.Region{X: left, Y: target.Y, Width: 1, Height: target.Height}, View: side},
+		{Name: "tour-right", Z: tourZ, Region: terminus.Region{X: right, Y: target.Y, Width: 1, Height: target.Height}, View: side},
+	}
+}
+
+// callout renders the current step's title, text and the keys that move
+// through the tour in a box
+func (t *Tour) callout() string {
+	width := t.calloutWidth
+	if t.width > 0 {
+		width = min(width, t.width-4)
+	}
+	width = max(width, 10)
+
+	step := t.steps[t.step]
+	var lines []string
+	if step.Title != "" {
+		lines = append(lines, t.titleStyle.Render(fitWidth(step.Title, width)), "")
+	}
+	lines = append(lines, wrapWords(step.Text, width)...)
+	next := "Enter next"
+	if t.step == len(t.steps)-1 {
+		next = "Enter done"
+	}
+	hint := fmt.Sprintf("%d/%d · %s · ← back · Esc skip", t.step+1, len(t.steps), next)
+	lines = append(lines, "", t.hintStyle.Render(fitWidth(hint, width)))
+
+	return layout.NewBox(strings.Join(lines, "\n")).
+		WithStyle(layout.BoxStyleRounded).
+		WithBorderStyle(t.highlightStyle).
+		WithPadding(0, 1, 0, 1).
+		WithWidth(width).
+		WithHeight(len(lines)).
+		Render()
+}
+
+// place returns where a width x height callout goes: below the target,
+// else above, right or left of it, else over the bottom of the screen. A
+// callout without a target is centered.
+func (t *Tour) place(target terminus.Region, ok bool, width, height int) terminus.Region {
+	region := terminus.Region{Width: width, Height: height}
+	if !ok {
+		region.X, region.Y = max((t.width-width)/2, 0), max((t.height-height)/2, 0)
+		return region
+	}
+
+	// Along the target, kept on screen
+	x := max(min(target.X, t.width-width), 0)
+	y := max(min(target.Y, t.height-height), 0)
+	switch {
+	case target.Y+target.Height+1+height <= t.height:
+		region.X, region.Y = x, target.Y+target.Height+1
+	case target.Y-1-height >= 0:
+		region.X, region.Y = x, target.Y-1-height
+	case target.X+target.Width+1+width <= t.width:
+		region.X, region.Y = target.X+target.Width+1, y
+	case target.X-1-width >= 0:
+		region.X, region.Y = target.X-1-width, y
+	default:
+		region.X, region.Y = x, max(t.height-height, 0)
+	}
+	return region
+}
+
+// wrapWords splits text into lines of at most width cells, breaking between
+// words. Words wider than width are cut.
+func wrapWords(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && visibleWidth(line)+1+visibleWidth(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, fitWidth(line, width))
+	}
+	return lines
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestTour(t *testing.T) {
+	// newTour returns a started tour of a dashboard with a chart above a
+	// list on a 60x20 screen
+	newTour := func() *Tour {
+		content := NewCompose(ComposeVertical).
+			Add("chart", &probe{view: "chart\nchart"}).
+			Add("list", &probe{view: "list"})
+		tour := NewTour(content,
+			TourStep{Target: "chart", Title: "Chart", Text: "Shows the load"},
+			TourStep{Target: "list", Title: "List", Text: "Shows the jobs"},
+			TourStep{Title: "Done", Text: "That's all"},
+		)
+		tour.Update(terminus.WindowSizeMsg{Width: 60, Height: 20})
+		tour.Start()
+		return tour
+	}
+	// surface returns the named surface of the tour
+	surface := func(tour *Tour, name string) (terminus.Surface, bool) {
+		for _, s := range tour.Surfaces() {
+			if s.Name == name {
+				return s, true
+			}
+		}
+		return terminus.Surface{}, false
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Highlights the target of each step",
+			test: func(t *testing.T) {
+				tour := newTour()
+				top, ok := surface(tour, "tour-top")
+				if !ok || top.Region != (terminus.Region{X: -1, Y: -1, Width: 7, Height: 1}) {
+					t.Errorf("Expected a frame around the chart, got %+v", top.Region)
+				}
+				callout, _ := surface(tour, "tour-callout")
+				if callout.Region.Y != 3 || !strings.Contains(plain(callout.View), "Shows the load") ||
+					!strings.Contains(plain(callout.View), "1/3") {
+					t.Errorf("Expected the first callout below the chart, got %+v", callout)
+				}
+
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if left, _ := surface(tour, "tour-left"); left.Region != (terminus.Region{X: -1, Y: 2, Width: 1, Height: 1}) {
+					t.Errorf("Expected a frame around the list, got %+v", left.Region)
+				}
+			},
+		},
+		{
+			name: "Centers the callout of a step without a target",
+			test: func(t *testing.T) {
+				tour := newTour()
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if _, ok := surface(tour, "tour-top"); ok {
+					t.Error("Expected no highlight")
+				}
+				callout, _ := surface(tour, "tour-callout")
+				if r := callout.Region; r.X != (60-r.Width)/2 || r.Y != (20-r.Height)/2 {
+					t.Errorf("Expected a centered callout, got %+v", r)
+				}
+			},
+		},
+		{
+			name: "Moves back, and completes or skips",
+			test: func(t *testing.T) {
+				var done []bool
+				tour := newTour()
+				tour.SetOnDone(func(completed bool) terminus.Cmd {
+					done = append(done, completed)
+					return nil
+				})
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyRight})
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyLeft})
+				if tour.Step() != 0 {
+					t.Errorf("Expected the first step, got %d", tour.Step())
+				}
+				for range 3 {
+					tour.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				}
+				tour.Start()
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyEsc})
+				if tour.Active() || len(done) != 2 || !done[0] || done[1] {
+					t.Errorf("Expected a completed then a skipped tour, got %v", done)
+				}
+				if len(tour.Surfaces()) != 0 {
+					t.Error("Expected no surfaces once done")
+				}
+			},
+		},
+		{
+			name: "Keeps keys from the content while running",
+			test: func(t *testing.T) {
+				p := &probe{}
+				tour := NewTour(p, TourStep{Text: "Welcome"})
+				tour.Start()
+				tour.Update(runeKey('x'))
+				tour.Update(terminus.KeyMsg{Type: terminus.KeyEsc})
+				tour.Update(runeKey('y'))
+				if len(p.msgs) != 1 || string(p.msgs[0].(terminus.KeyMsg).Runes) != "y" {
+					t.Errorf("Expected only the key after the tour, got %v", p.msgs)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// UndoHistory shows the actions of an UndoStack, oldest first below the
+// starting state, with those undone dimmed and a marker at the current
+// state. The arrow keys pick an entry and Enter undoes or redoes actions
+// to return to it.
+type UndoHistory struct {
+	Model
+
+	stack *terminus.UndoStack
+
+	// State
+	selected int // Row of the cursor; row 0 is the starting state
+	offset   int // First row shown
+	seen     int // Done actions when the cursor last followed the stack
+	seenLen  int // Actions then
+
+	// Configuration
+	startLabel string
+	marker     string
+
+	// Styling
+	doneStyle     terminus.Style
+	undoneStyle   terminus.Style
+	selectedStyle terminus.Style
+
+	// Events
+	onChange func() terminus.Cmd
+}
+
+// NewUndoHistory creates a 30 by 10 view of stack
+func NewUndoHistory(stack *terminus.UndoStack) *UndoHistory {
+	m := NewModel()
+	m.width, m.height = 30, 10
+	return &UndoHistory{
+		Model:         m,
+		stack:         stack,
+		seen:          -1,
+		startLabel:    "Start",
+		marker:        "● ",
+		doneStyle:     terminus.NewStyle(),
+		undoneStyle:   terminus.NewStyle().Faint(true),
+		selectedStyle: terminus.NewStyle().Reverse(true),
+	}
+}
+
+// SetStartLabel sets the label of the starting state, before the first
+// action kept (default "Start")
+func (u *UndoHistory) SetStartLabel(label string) *UndoHistory {
+	u.startLabel = label
+	return u
+}
+
+// SetMarker sets the marker of the current state (default "● "). The other
+// entries are indented by its width.
+func (u *UndoHistory) SetMarker(marker string) *UndoHistory {
+	u.marker = marker
+	return u
+}
+
+// SetDoneStyle sets the style of the actions done
+func (u *UndoHistory) SetDoneStyle(style terminus.Style) *UndoHistory {
+	u.doneStyle = style
+	return u
+}
+
+// SetUndoneStyle sets the style of the actions undone, which can be
+// redone (default faint)
+func (u *UndoHistory) SetUndoneStyle(style terminus.Style) *UndoHistory {
+	u.undoneStyle = style
+	return u
+}
+
+// SetSelectedStyle sets the style of the entry under the cursor while
+// focused (default reversed)
+func (u *UndoHistory) SetSelectedStyle(style terminus.Style) *UndoHistory {
+	u.selectedStyle = style
+	return u
+}
+
+// SetOnChange sets the callback run after Enter undoes or redoes actions,
+// e.g. to refresh views of the state they changed. Its command runs after
+// those of the actions.
+func (u *UndoHistory) SetOnChange(fn func() terminus.Cmd) *UndoHistory {
+	u.onChange = fn
+	return u
+}
+
+// Selected returns the entry under the cursor: 0 for the starting state,
+// or n for the state after the first n actions
+func (u *UndoHistory) Selected() int {
+	u.follow()
+	return u.selected
+}
+
+// follow moves the cursor to the current state when the stack has changed
+// since it last did
+func (u *UndoHistory) follow() {
+	done, n := u.stack.Done(), len(u.stack.History())
+	if done != u.seen || n != u.seenLen {
+		u.selected, u.seen, u.seenLen = done, done, n
+	}
+	u.selected = max(0, min(u.selected, n))
+}
+
+// KeyBindings implements terminus.KeyBinder
+func (u *UndoHistory) KeyBindings() []terminus.KeyMsg {
+	return keyBindings("jk", terminus.KeyUp, terminus.KeyDown, terminus.KeyHome, terminus.KeyEnd, terminus.KeyEnter)
+}
+
+// Init implements the Component interface
+func (u *UndoHistory) Init() terminus.Cmd {
+	return nil
+}
+
+// Update implements the Component interface
+func (u *UndoHistory) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd) {
+	keyMsg, ok := msg.(terminus.KeyMsg)
+	if !ok || !u.Focused() {
+		return u, nil
+	}
+	u.follow()
+	n := len(u.stack.History())
+
+	switch keyMsg.Type {
+	case terminus.KeyUp:
+		u.selected = max(0, u.selected-1)
+	case terminus.KeyDown:
+		u.selected = min(n, u.selected+1)
+	case terminus.KeyHome:
+		u.selected = 0
+	case terminus.KeyEnd:
+		u.selected = n
+	case terminus.KeyEnter:
+		cmd := u.stack.Goto(u.selected)
+		u.seen = u.stack.Done()
+		if u.onChange != nil {
+			cmd = terminus.Sequence(cmd, u.onChange())
+		}
+		return u, cmd
+	case terminus.KeyRunes:
+		if len(keyMsg.Runes) == 1 && keyMsg.Runes[0] == 'k' {
+			u.selected = max(0, u.selected-1)
+		} else if len(keyMsg.Runes) == 1 && keyMsg.Runes[0] == 'j' {
+			u.selected = min(n, u.selected+1)
+		}
+	}
+	return u, nil
+}
+
+// View implements the Component interface
+func (u *UndoHistory) View() string {
+	u.follow()
+	history := u.stack.History()
+	done := u.stack.Done()
+	rows := len(history) + 1
+
+	// Scroll to keep the cursor in sight
+	height := max(1, u.height)
+	switch {
+	case u.selected < u.offset:
+		u.offset = u.selected
+	case u.selected >= u.offset+height:
+		u.offset = u.selected - height + 1
+	}
+	u.offset = max(0, min(u.offset, rows-height))
+
+	indent := strings.Repeat(" ", visibleWidth(u.marker))
+	lines := make([]string, 0, height)
+	for row := u.offset; row < rows && len(lines) < height; row++ {
+		label := u.startLabel
+		if row > 0 {
+			label = history[row-1].Name
+		}
+		prefix := indent
+		if row == done {
+			prefix = u.marker
+		}
+		text := fitWidth(prefix+terminus.Sanitize(label), u.width)
+
+		style := u.doneStyle
+		switch {
+		case u.Focused() && row == u.selected:
+			style = u.selectedStyle
+		case row > done:
+			style = u.undoneStyle
+		}
+		lines = append(lines, style.Render(text))
+	}
+	return strings.Join(lines, "\n")
+}
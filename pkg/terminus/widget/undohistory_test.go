@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestUndoHistory(t *testing.T) {
+	// newHistory returns a history of three actions adding to a counter,
+	// the last of them undone
+	newHistory := func() (*UndoHistory, *terminus.UndoStack, *int) {
+		n := 0
+		stack := terminus.NewUndoStack(0)
+		for _, amount := range []int{1, 10, 100} {
+			stack.Do(terminus.Action{
+				Name: fmt.Sprintf("Add %d", amount),
+				Do:   func() terminus.Cmd { n += amount; return nil },
+				Undo: func() terminus.Cmd { n -= amount; return nil },
+			})
+		}
+		stack.Undo()
+		history := NewUndoHistory(stack).
+			SetSelectedStyle(terminus.NewStyle()).
+			SetUndoneStyle(terminus.NewStyle())
+		history.SetSize(12, 10)
+		return history, stack, &n
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Marks the current state",
+			test: func(t *testing.T) {
+				history, _, _ := newHistory()
+				want := "  Start     \n  Add 1     \n● Add 10    \n  Add 100   "
+				if got := history.View(); got != want {
+					t.Errorf("Expected %q, got %q", want, got)
+				}
+				if history.Selected() != 2 {
+					t.Errorf("Expected the cursor on the current state, got %d", history.Selected())
+				}
+			},
+		},
+		{
+			name: "Goes to the selected state",
+			test: func(t *testing.T) {
+				history, stack, n := newHistory()
+				changed := 0
+				history.SetOnChange(func() terminus.Cmd { changed++; return nil })
+				history.Focus()
+				history.Update(terminus.KeyMsg{Type: terminus.KeyHome})
+				_, cmd := history.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if cmd != nil {
+					cmd()
+				}
+				if *n != 0 || stack.Done() != 0 || changed != 1 {
+					t.Errorf("Expected every action undone, got %d with %d done", *n, stack.Done())
+				}
+
+				history.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+				history.Update(terminus.KeyMsg{Type: terminus.KeyEnter})
+				if *n != 111 || history.Selected() != 3 {
+					t.Errorf("Expected every action redone, got %d", *n)
+				}
+			},
+		},
+		{
+			name: "Follows the stack",
+			test: func(t *testing.T) {
+				history, stack, _ := newHistory()
+				history.Focus()
+				history.Update(terminus.KeyMsg{Type: terminus.KeyUp})
+				if history.Selected() != 1 {
+					t.Fatalf("Expected the cursor to move up, got %d", history.Selected())
+				}
+				stack.Redo()
+				if history.Selected() != 3 {
+					t.Errorf("Expected the cursor on the new current state, got %d", history.Selected())
+				}
+			},
+		},
+		{
+			name: "Scrolls to the cursor",
+			test: func(t *testing.T) {
+				history, _, _ := newHistory()
+				history.SetSize(12, 2)
+				history.Focus()
+				history.Update(terminus.KeyMsg{Type: terminus.KeyEnd})
+				want := "● Add 10    \n  Add 100   "
+				if got := history.View(); got != want {
+					t.Errorf("Expected %q, got %q", want, got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
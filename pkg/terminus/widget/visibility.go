@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"strings"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+// Visible returns whether c is shown: false for a hidden widget, true for
+// any other component
+func Visible(c terminus.Component) bool {
+	if v, ok := c.(interface{ Visible() bool }); ok {
+		return v.Visible()
+	}
+	return c != nil
+}
+
+// View returns the view of c, or "" if it is hidden
+func View(c terminus.Component) string {
+	if !Visible(c) {
+		return ""
+	}
+	return c.View()
+}
+
+// Stack returns the views of the visible components top to bottom, with
+// gap blank lines between them. Hidden widgets take no space, so an
+// optional section is a widget that is shown and hidden rather than a flag
+// and a view spliced in:
+//
+//	m.help.Toggle() // On "?"
+//
+//	return widget.Stack(1, m.table, m.help)
+func Stack(gap int, components ...terminus.Component) string {
+	var views []string
+	for _, c := range components {
+		if Visible(c) {
+			views = append(views, c.View())
+		}
+	}
+	return strings.Join(views, strings.Repeat("\n", gap+1))
+}
+
+// Row returns the views of the visible components side by side, each
+// padded to its widest line, with gap columns between them
+func Row(gap int, components ...terminus.Component) string {
+	var views []string
+	for _, c := range components {
+		if Visible(c) {
+			views = append(views, c.View())
+		}
+	}
+	return joinColumns(views, make([]int, len(views)), gap)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widget
+
+import (
+	"testing"
+
+	"github.com/skaiser/terminusgo/pkg/terminus"
+)
+
+func TestVisibility(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "Leaves hidden widgets out of views",
+			test: func(t *testing.T) {
+				header, help, footer := NewLabel("header"), NewLabel("help"), NewLabel("footer")
+				help.Hide()
+
+				if view := Stack(1, header, help, footer); view != "header\n\nfooter" {
+					t.Errorf("Expected the help left out, got %q", view)
+				}
+				if view := Row(1, header, help, footer); view != "header footer" {
+					t.Errorf("Expected the help left out, got %q", view)
+				}
+				if View(help) != "" || View(header) != "header" {
+					t.Errorf("Expected only visible views, got %q and %q", View(help), View(header))
+				}
+
+				help.Show()
+				if view := Stack(0, header, help); view != "header\nhelp" {
+					t.Errorf("Expected the help once shown, got %q", view)
+				}
+			},
+		},
+		{
+			name: "Treats other components as visible",
+			test: func(t *testing.T) {
+				if !Visible(&probe{}) || Visible(nil) {
+					t.Error("Expected components without Visible to be visible, and nil not")
+				}
+			},
+		},
+		{
+			name: "Collapses hidden children of a Compose",
+			test: func(t *testing.T) {
+				name, email := newMockWidget("name"), newMockWidget("email")
+				c := NewCompose(ComposeVertical).Add("name", name).Add("email", email)
+				email.Hide()
+
+				if view := c.View(); view != "name" {
+					t.Errorf("Expected the hidden child left out, got %q", view)
+				}
+				c.Update(terminus.KeyMsg{Type: terminus.KeyTab})
+				if c.FocusedChild() != "name" {
+					t.Errorf("Expected focus to skip the hidden child, got %q", c.FocusedChild())
+				}
+			},
+		},
+		{
+			name: "Skips hidden widgets when moving focus",
+			test: func(t *testing.T) {
+				a, b, c := newMockWidget("a"), newMockWidget("b"), newMockWidget("c")
+				fm := NewFocusManager(a, b, c)
+				b.Hide()
+
+				fm.Next()
+				if fm.Current() != c || !c.Focused() || a.Focused() {
+					t.Error("Expected focus to move from a to c")
+				}
+				fm.Previous()
+				if fm.Current() != a {
+					t.Error("Expected focus to move back to a")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
@@ -44,6 +44,10 @@ type Model struct {
 	x        int
 	y        int
 	disabled bool
+	hidden   bool
+
+	// Constraints for Measure
+	minSize, preferredSize, maxSize Size
 }
 
 // NewModel creates a new base widget model
@@ -75,11 +79,46 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 }
 
-// GetSize returns the widget dimensions
+// GetSize returns the widget dimensions, or 0x0 while it is hidden
 func (m *Model) GetSize() (width, height int) {
+	if m.hidden {
+		return 0, 0
+	}
 	return m.width, m.height
 }
 
+// SetMinSize sets the least size the widget can be laid out at
+func (m *Model) SetMinSize(width, height int) {
+	m.minSize = Size{Width: width, Height: height}
+}
+
+// SetPreferredSize sets the size the widget is laid out at if there is
+// room. A dimension of 0, the default, takes whatever space is left.
+func (m *Model) SetPreferredSize(width, height int) {
+	m.preferredSize = Size{Width: width, Height: height}
+}
+
+// SetMaxSize sets the most size the widget can be laid out at. A dimension
+// of 0, the default, is unbounded.
+func (m *Model) SetMaxSize(width, height int) {
+	m.maxSize = Size{Width: width, Height: height}
+}
+
+// Measure returns the sizes set by SetMinSize, SetPreferredSize and
+// SetMaxSize, or no size while the widget is hidden. Containers call it to
+// size their children with SetSize.
+func (m *Model) Measure() Constraints {
+	if m.hidden {
+		return Constraints{}
+	}
+	return Constraints{Min: m.minSize, Preferred: m.preferredSize, Max: m.maxSize}
+}
+
+// singleLine keeps the widget one line high when laid out
+func (m *Model) singleLine() {
+	m.minSize.Height, m.preferredSize.Height, m.maxSize.Height = 1, 1, 1
+}
+
 // SetPosition sets the widget position
 func (m *Model) SetPosition(x, y int) {
 	m.x = x
@@ -101,6 +140,28 @@ func (m *Model) Disabled() bool {
 	return m.disabled
 }
 
+// Show makes a hidden widget visible again
+func (m *Model) Show() {
+	m.hidden = false
+}
+
+// Hide hides the widget. A hidden widget keeps its state and still
+// receives messages, but takes no space: View, Stack and Row leave it out,
+// as do Compose, Container and FocusManager.
+func (m *Model) Hide() {
+	m.hidden = true
+}
+
+// Toggle hides a visible widget and shows a hidden one
+func (m *Model) Toggle() {
+	m.hidden = !m.hidden
+}
+
+// Visible returns whether the widget is shown
+func (m *Model) Visible() bool {
+	return !m.hidden
+}
+
 // FocusManager manages focus between widgets
 type FocusManager struct {
 	widgets []Widget
@@ -132,32 +193,32 @@ func (fm *FocusManager) AddWidget(w Widget) {
 	}
 }
 
-// Next moves focus to the next widget
+// Next moves focus to the next visible widget
 func (fm *FocusManager) Next() {
-	if len(fm.widgets) == 0 {
-		return
-	}
-	
-	if fm.current >= 0 {
-		fm.widgets[fm.current].Blur()
-	}
-	
-	fm.current = (fm.current + 1) % len(fm.widgets)
-	fm.widgets[fm.current].Focus()
+	fm.step(1)
 }
 
-// Previous moves focus to the previous widget
+// Previous moves focus to the previous visible widget
 func (fm *FocusManager) Previous() {
-	if len(fm.widgets) == 0 {
+	fm.step(-1)
+}
+
+// step moves focus to the next visible widget, or the previous one when
+// delta is negative
+func (fm *FocusManager) step(delta int) {
+	n := len(fm.widgets)
+	for k := 1; k <= n; k++ {
+		i := ((fm.current+delta*k)%n + n) % n
+		if !Visible(fm.widgets[i]) {
+			continue
+		}
+		if fm.current >= 0 {
+			fm.widgets[fm.current].Blur()
+		}
+		fm.current = i
+		fm.widgets[i].Focus()
 		return
 	}
-	
-	if fm.current >= 0 {
-		fm.widgets[fm.current].Blur()
-	}
-	
-	fm.current = (fm.current - 1 + len(fm.widgets)) % len(fm.widgets)
-	fm.widgets[fm.current].Focus()
 }
 
 // Current returns the currently focused widget
@@ -252,15 +313,40 @@ func (c *Container) Update(msg terminus.Msg) (terminus.Component, terminus.Cmd)
 	return c, nil
 }
 
+// SetSize sets the container's size and divides it among its children,
+// top to bottom, by their constraints
+func (c *Container) SetSize(width, height int) {
+	c.Model.SetSize(width, height)
+	children := make([]terminus.Component, len(c.children))
+	for i, child := range c.children {
+		children[i] = child
+	}
+	for i, size := range Arrange(ComposeVertical, Size{Width: width, Height: height}, 0, children...) {
+		if Visible(c.children[i]) {
+			c.children[i].SetSize(size.Width, size.Height)
+		}
+	}
+}
+
 // View implements the Component interface
 func (c *Container) View() string {
 	// Simple vertical layout for now
-	result := ""
+	children := make([]terminus.Component, len(c.children))
 	for i, child := range c.children {
-		if i > 0 {
-			result += "\n"
-		}
-		result += child.View()
+		children[i] = child
 	}
-	return result
-}
\ No newline at end of file
+	return Stack(0, children...)
+}
+
+// keyBindings returns the keys of types, followed by a key for each rune
+// of runes, for KeyBindings
+func keyBindings(runes string, types ...terminus.KeyType) []terminus.KeyMsg {
+	keys := make([]terminus.KeyMsg, 0, len(types)+len(runes))
+	for _, t := range types {
+		keys = append(keys, terminus.KeyMsg{Type: t})
+	}
+	for _, r := range runes {
+		keys = append(keys, terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune{r}})
+	}
+	return keys
+}
@@ -125,6 +125,27 @@ func TestModel(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Visibility",
+			test: func(t *testing.T) {
+				m := NewModel()
+				m.SetSize(20, 5)
+
+				m.Hide()
+				if w, h := m.GetSize(); m.Visible() || w != 0 || h != 0 {
+					t.Errorf("Expected a hidden model to take no space, got %dx%d", w, h)
+				}
+				m.Toggle()
+				if w, h := m.GetSize(); !m.Visible() || w != 20 || h != 5 {
+					t.Errorf("Expected the size back once shown, got %dx%d", w, h)
+				}
+				m.Toggle()
+				m.Show()
+				if !m.Visible() {
+					t.Error("Expected Show to show the model")
+				}
+			},
+		},
 	}
 	
 	for _, tt := range tests {
@@ -349,4 +370,35 @@ func TestContainer(t *testing.T) {
 			tt.test(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestKeyBindings(t *testing.T) {
+	shortcuts := []terminus.Shortcut{
+		{Name: "palette", Key: terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("k"), Ctrl: true}},
+		{Name: "help", Key: terminus.KeyMsg{Type: terminus.KeyF1}},
+		{Name: "search", Key: terminus.KeyMsg{Type: terminus.KeyRunes, Runes: []rune("/")}},
+	}
+
+	tests := []struct {
+		name   string
+		binder terminus.KeyBinder
+		want   []string
+	}{
+		{"TextInput takes every printable key", NewTextInput(), []string{"search"}},
+		{"Pager searches with /", NewPager(), []string{"search"}},
+		{"List leaves them free", NewList(), nil},
+		{"Table leaves them free", NewTable(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range terminus.ShortcutConflicts(shortcuts, tt.binder) {
+				got = append(got, c.Shortcut.Name)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("Expected conflicts with %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+// WrapMode is how the screen breaks a line of a view that is wider than
+// the screen
+type WrapMode int
+
+const (
+	// WrapChar continues the line on the next one at the last column, even
+	// in the middle of a word. It is the default.
+	WrapChar WrapMode = iota
+	// WrapWord breaks the line after the last space that fits, moving the
+	// word at the edge to the next line. Words longer than a line are broken
+	// like WrapChar.
+	WrapWord
+	// WrapNone doesn't break lines; what doesn't fit is cut off as the
+	// overflow policy says
+	WrapNone
+)
+
+// Overflow is what happens to the end of a line that doesn't fit and isn't
+// wrapped
+type Overflow int
+
+const (
+	// OverflowClip cuts the line off at the last column. It is the default.
+	OverflowClip Overflow = iota
+	// OverflowEllipsis cuts the line off and replaces its last visible
+	// character with "…", so the cut shows
+	OverflowEllipsis
+)
+
+// Wrap is how the lines of a view are fitted to the screen
+type Wrap struct {
+	Mode     WrapMode
+	Overflow Overflow
+}
+
+// WrapPolicy is implemented by components that choose how their view is
+// wrapped, overriding the program's policy, e.g. a dashboard laid out to
+// the column that would rather clip a line than wrap it. It is asked on
+// every render, so a component can change it with its state.
+type WrapPolicy interface {
+	Wrap() Wrap
+}
+
+// WithEngineWrap sets how views are wrapped when the component doesn't
+// implement WrapPolicy
+func WithEngineWrap(wrap Wrap) EngineOption {
+	return func(e *Engine) {
+		e.wrap = wrap
+	}
+}
+
+// viewWrap returns how the current view is wrapped: the component's own
+// policy, or the engine's
+func (e *Engine) viewWrap() Wrap {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if p, ok := e.component.(WrapPolicy); ok {
+		return p.Wrap()
+	}
+	return e.wrap
+}
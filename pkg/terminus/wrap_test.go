@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScreenWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		wrap     Wrap
+		width    int
+		height   int
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Char wrap splits words",
+			wrap:     Wrap{Mode: WrapChar},
+			width:    8,
+			height:   3,
+			input:    "hello wonderful world",
+			expected: []string{"hello wo", "nderful", "world"},
+		},
+		{
+			name:     "Word wrap moves the word at the edge",
+			wrap:     Wrap{Mode: WrapWord},
+			width:    8,
+			height:   3,
+			input:    "hello wonderful world",
+			expected: []string{"hello", "wonderfu", "l world"},
+		},
+		{
+			name:     "Word wrap drops the space at the break",
+			wrap:     Wrap{Mode: WrapWord},
+			width:    5,
+			height:   3,
+			input:    "abcde fgh",
+			expected: []string{"abcde", "fgh", ""},
+		},
+		{
+			name:     "Word wrap doesn't add a line after a full one",
+			wrap:     Wrap{Mode: WrapWord},
+			width:    5,
+			height:   3,
+			input:    "abcde\nfgh",
+			expected: []string{"abcde", "fgh", ""},
+		},
+		{
+			name:     "Word wrap keeps moved words when scrolling",
+			wrap:     Wrap{Mode: WrapWord},
+			width:    6,
+			height:   1,
+			input:    "ab cdef",
+			expected: []string{"cdef"},
+		},
+		{
+			name:     "No wrap clips",
+			wrap:     Wrap{Mode: WrapNone},
+			width:    5,
+			height:   2,
+			input:    "abcdefgh\nij",
+			expected: []string{"abcde", "ij"},
+		},
+		{
+			name:     "No wrap with an ellipsis",
+			wrap:     Wrap{Mode: WrapNone, Overflow: OverflowEllipsis},
+			width:    5,
+			height:   2,
+			input:    "abcdefgh\nabcde",
+			expected: []string{"abcd…", "abcde"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen := NewScreen(tt.width, tt.height)
+			screen.SetWrap(tt.wrap)
+			screen.RenderFromString(tt.input)
+
+			lines := strings.Split(screen.ToString(), "\n")
+			for i := range lines {
+				lines[i] = strings.TrimRight(lines[i], " ")
+			}
+			if strings.Join(lines, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestWrapPolicy(t *testing.T) {
+	component := &clippedComponent{wrap: Wrap{Mode: WrapNone}}
+	session := NewSession("wrap", nil, component, WithEngineWrap(Wrap{Mode: WrapWord}))
+	defer session.Close()
+
+	if wrap := session.engine.viewWrap(); wrap != component.wrap {
+		t.Errorf("Expected the component's policy, got %+v", wrap)
+	}
+
+	session.engine.component = &testComponent{}
+	if wrap := session.engine.viewWrap(); wrap.Mode != WrapWord {
+		t.Errorf("Expected the engine's policy, got %+v", wrap)
+	}
+}
+
+// clippedComponent chooses how its view wraps
+type clippedComponent struct {
+	testComponent
+	wrap Wrap
+}
+
+func (c *clippedComponent) Wrap() Wrap {
+	return c.wrap
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrWSNotConnected is reported when sending on a connection that is not open
+var ErrWSNotConnected = errors.New("websocket not connected")
+
+// WSOptions configures an outgoing WebSocket connection
+type WSOptions struct {
+	// ID names the connection for WSSend and WSClose. Defaults to the URL.
+	ID string
+
+	Header           http.Header   // Extra headers for the handshake
+	Subprotocols     []string      // Requested subprotocols
+	HandshakeTimeout time.Duration // Defaults to 10 seconds
+}
+
+// WSConnectedMsg is sent when a connection opened by ConnectWS is ready
+type WSConnectedMsg struct {
+	ID string
+}
+
+// WSMessageMsg is sent for each frame received on a ConnectWS connection
+type WSMessageMsg struct {
+	ID     string
+	Data   []byte
+	Binary bool
+}
+
+// Text returns the frame payload as a string
+func (m WSMessageMsg) Text() string {
+	return string(m.Data)
+}
+
+// JSON decodes the frame payload into v
+func (m WSMessageMsg) JSON(v interface{}) error {
+	return json.Unmarshal(m.Data, v)
+}
+
+// WSClosedMsg is sent when a ConnectWS connection ends. Err is nil if it was
+// closed normally.
+type WSClosedMsg struct {
+	ID  string
+	Err error
+}
+
+// wsKey identifies a connection in the session scope
+type wsKey string
+
+// wsConn serializes writes to a client connection
+type wsConn struct {
+	conn    *websocket.Conn
+	mu      sync.Mutex
+	closing bool
+}
+
+// write sends a single frame
+func (c *wsConn) write(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// close sends a close frame and tears down the connection
+func (c *wsConn) close() {
+	c.mu.Lock()
+	c.closing = true
+	c.mu.Unlock()
+
+	c.write(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.conn.Close()
+}
+
+// closedLocally reports whether close was called on this side
+func (c *wsConn) closedLocally() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closing
+}
+
+// ConnectWS opens a WebSocket connection to an external service. Each frame
+// received is delivered as a WSMessageMsg until the connection closes, the
+// session ends or WSClose is called. The connection belongs to the session
+// that issued the command.
+func ConnectWS(url string, opts WSOptions) Cmd {
+	id := opts.ID
+	if id == "" {
+		id = url
+	}
+	timeout := opts.HandshakeTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		dialer := websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: timeout,
+			Subprotocols:     opts.Subprotocols,
+		}
+		conn, _, err := dialer.DialContext(ctx, url, opts.Header)
+		if err != nil {
+			return WSClosedMsg{ID: id, Err: fmt.Errorf("failed to connect: %w", err)}
+		}
+
+		c := &wsConn{conn: conn}
+		scope := scopeFrom(ctx)
+		if old, ok := scope.swap(wsKey(id), c); ok {
+			old.(*wsConn).close()
+		}
+		defer scope.remove(wsKey(id), c)
+
+		// Close the connection when the session ends
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				c.close()
+			case <-done:
+			}
+		}()
+
+		send(WSConnectedMsg{ID: id})
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				conn.Close()
+				if c.closedLocally() || websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					return WSClosedMsg{ID: id}
+				}
+				return WSClosedMsg{ID: id, Err: err}
+			}
+			send(WSMessageMsg{
+				ID:     id,
+				Data:   data,
+				Binary: messageType == websocket.BinaryMessage,
+			})
+		}
+	})
+}
+
+// WSSend sends a text frame on the connection with the given ID
+func WSSend(id string, data []byte) Cmd {
+	return wsSend(id, websocket.TextMessage, data)
+}
+
+// WSSendBinary sends a binary frame on the connection with the given ID
+func WSSendBinary(id string, data []byte) Cmd {
+	return wsSend(id, websocket.BinaryMessage, data)
+}
+
+// WSSendJSON encodes v as JSON and sends it as a text frame
+func WSSendJSON(id string, v interface{}) Cmd {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return func() Msg {
+			return ErrMsg{Err: err, Source: "WSSendJSON"}
+		}
+	}
+	return WSSend(id, data)
+}
+
+// wsSend looks up the session's connection and writes a frame to it
+func wsSend(id string, messageType int, data []byte) Cmd {
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		c, ok := scopeFrom(ctx).get(wsKey(id))
+		if !ok {
+			return ErrMsg{Err: fmt.Errorf("%w: %s", ErrWSNotConnected, id), Source: "WSSend"}
+		}
+		if err := c.(*wsConn).write(messageType, data); err != nil {
+			return ErrMsg{Err: err, Source: "WSSend"}
+		}
+		return nil
+	})
+}
+
+// WSClose closes the connection with the given ID. A WSClosedMsg follows
+// once the connection has shut down.
+func WSClose(id string) Cmd {
+	return Stream(func(ctx context.Context, send func(Msg)) Msg {
+		scope := scopeFrom(ctx)
+		c, ok := scope.get(wsKey(id))
+		if !ok {
+			return nil
+		}
+		scope.remove(wsKey(id), c)
+		c.(*wsConn).close()
+		return nil
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newEchoServer starts a WebSocket server that echoes every frame back
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// wsComponent sends a greeting once connected and closes after the echo
+type wsComponent struct {
+	url      string
+	received chan Msg
+}
+
+func (c *wsComponent) Init() Cmd {
+	return ConnectWS(c.url, WSOptions{ID: "echo"})
+}
+
+func (c *wsComponent) Update(msg Msg) (Component, Cmd) {
+	switch msg := msg.(type) {
+	case WSConnectedMsg:
+		return c, WSSend(msg.ID, []byte("hello"))
+	case WSMessageMsg:
+		c.received <- msg
+		return c, WSClose(msg.ID)
+	case WSClosedMsg, ErrMsg:
+		c.received <- msg
+	}
+	return c, nil
+}
+
+func (c *wsComponent) View() string { return "" }
+
+func TestConnectWS(t *testing.T) {
+	t.Run("Send, receive and close", func(t *testing.T) {
+		comp := &wsComponent{url: newEchoServer(t), received: make(chan Msg, 4)}
+		engine := NewEngine(comp)
+		engine.Start()
+		defer engine.Stop()
+
+		expect := func() Msg {
+			select {
+			case msg := <-comp.received:
+				return msg
+			case <-time.After(2 * time.Second):
+				t.Fatal("Timed out waiting for WebSocket message")
+				return nil
+			}
+		}
+
+		echo, ok := expect().(WSMessageMsg)
+		if !ok || echo.Text() != "hello" || echo.ID != "echo" {
+			t.Fatalf("Expected echoed frame, got %#v", echo)
+		}
+
+		closed, ok := expect().(WSClosedMsg)
+		if !ok || closed.Err != nil {
+			t.Errorf("Expected clean WSClosedMsg, got %#v", closed)
+		}
+	})
+
+	t.Run("Connection failure", func(t *testing.T) {
+		cmd := ConnectWS("ws://127.0.0.1:1/none", WSOptions{HandshakeTimeout: time.Second})
+		msg, _ := runStream(t, cmd)
+
+		closed, ok := msg.(WSClosedMsg)
+		if !ok || closed.Err == nil {
+			t.Errorf("Expected WSClosedMsg with error, got %#v", msg)
+		}
+	})
+
+	t.Run("Send without connection", func(t *testing.T) {
+		ctx := withSessionScope(context.Background())
+		msg := runCmd(ctx, WSSend("missing", []byte("x")), func(Msg) {})
+
+		errMsg, ok := msg.(ErrMsg)
+		if !ok || !errors.Is(errMsg, ErrWSNotConnected) {
+			t.Errorf("Expected ErrWSNotConnected, got %#v", msg)
+		}
+	})
+
+	t.Run("Closed when session ends", func(t *testing.T) {
+		url := newEchoServer(t)
+		ctx, cancel := context.WithCancel(withSessionScope(context.Background()))
+		connected := make(chan struct{})
+		done := make(chan Msg, 1)
+		go func() {
+			done <- runCmd(ctx, ConnectWS(url, WSOptions{}), func(msg Msg) {
+				if _, ok := msg.(WSConnectedMsg); ok {
+					close(connected)
+				}
+			})
+		}()
+
+		select {
+		case <-connected:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out connecting")
+		}
+		cancel()
+
+		select {
+		case msg := <-done:
+			if closed, ok := msg.(WSClosedMsg); !ok || closed.Err != nil {
+				t.Errorf("Expected clean close, got %#v", msg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Connection was not closed with the session")
+		}
+	})
+}